@@ -4,9 +4,12 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 
 	"github.com/golang-migrate/migrate/v4"
 
+	// Драйвер для выполнения миграций PostgreSQL
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	// Драйвер для выполнения миграций SQLite 3
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	// Драйвер для получения миграций из файлов
@@ -14,24 +17,25 @@ import (
 )
 
 func main() {
-	var storagePath, migrationsPath, migrationsTable string
+	var driver, storagePath, dsn, migrationsPath, migrationsTable string
 
+	flag.StringVar(&driver, "driver", "sqlite", "storage driver: sqlite or postgres")
 	flag.StringVar(&storagePath, "storage-path", "", "path to storage")
+	flag.StringVar(&dsn, "dsn", "", "PostgreSQL connection URL")
 	flag.StringVar(&migrationsPath, "migrations-path", "", "path to migrations")
 	flag.StringVar(&migrationsTable, "migrations-table", "migrations", "name of migrations table")
 	flag.Parse()
 
-	if storagePath == "" {
-		panic("storage-path is required")
-	}
 	if migrationsPath == "" {
 		panic("migrations-path is required")
 	}
 
-	m, err := migrate.New(
-		"file://"+migrationsPath,
-		fmt.Sprintf("sqlite3://%s?x-migrations-table=%s", storagePath, migrationsTable),
-	)
+	databaseURL, err := migrationsDatabaseURL(driver, storagePath, dsn, migrationsTable)
+	if err != nil {
+		panic(err)
+	}
+
+	m, err := migrate.New("file://"+migrationsPath, databaseURL)
 	if err != nil {
 		panic(err)
 	}
@@ -48,3 +52,32 @@ func main() {
 
 	fmt.Println("migrations applied successfully")
 }
+
+// migrationsDatabaseURL returns the URL of the database the migrations are applied to.
+func migrationsDatabaseURL(driver, storagePath, dsn, migrationsTable string) (string, error) {
+	switch driver {
+	case "sqlite":
+		if storagePath == "" {
+			return "", errors.New("storage-path is required")
+		}
+
+		return fmt.Sprintf("sqlite3://%s?x-migrations-table=%s", storagePath, migrationsTable), nil
+	case "postgres":
+		if dsn == "" {
+			return "", errors.New("dsn is required")
+		}
+
+		databaseURL, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid dsn: %w", err)
+		}
+
+		query := databaseURL.Query()
+		query.Set("x-migrations-table", migrationsTable)
+		databaseURL.RawQuery = query.Encode()
+
+		return databaseURL.String(), nil
+	default:
+		return "", fmt.Errorf("unknown driver: %s", driver)
+	}
+}
@@ -1,16 +1,82 @@
 package app
 
 import (
+	"github.com/go-jose/go-jose/v4"
 	grpcapp "github.com/p1xray/pxr-sso/internal/app/grpc"
+	httpapp "github.com/p1xray/pxr-sso/internal/app/http"
 	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/events"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/notifier"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/memory"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/postgres"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/sqlite"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/addaudience"
+	clientcreate "github.com/p1xray/pxr-sso/internal/usecase/admin/client/create"
+	clientlist "github.com/p1xray/pxr-sso/internal/usecase/admin/client/list"
+	clientremove "github.com/p1xray/pxr-sso/internal/usecase/admin/client/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/removeaudience"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/rotatesecret"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/setdefaultroles"
+	clientupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/client/update"
+	permissioncreate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/create"
+	permissionlist "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/list"
+	permissionremove "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/remove"
+	permissionsetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/setactive"
+	permissionupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/attachpermission"
+	rolecreate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/create"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/detachpermission"
+	rolelist "github.com/p1xray/pxr-sso/internal/usecase/admin/role/list"
+	roleremove "github.com/p1xray/pxr-sso/internal/usecase/admin/role/remove"
+	rolesetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/role/setactive"
+	roleupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantrole"
+	userlist "github.com/p1xray/pxr-sso/internal/usecase/admin/user/list"
+	userremove "github.com/p1xray/pxr-sso/internal/usecase/admin/user/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/resetpassword"
+	userrestore "github.com/p1xray/pxr-sso/internal/usecase/admin/user/restore"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokeclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokerole"
+	usersetblocked "github.com/p1xray/pxr-sso/internal/usecase/admin/user/setblocked"
+	userunlock "github.com/p1xray/pxr-sso/internal/usecase/admin/user/unlock"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/authorize"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/changepassword"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/confirmpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/credentials"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/exchange"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/logout"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/refresh"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/register"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/requestpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/verifymfa"
+	"github.com/p1xray/pxr-sso/internal/usecase/keys/jwks"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/confirmtotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/disabletotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/enrolltotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/regeneratebackupcodes"
+	"github.com/p1xray/pxr-sso/internal/usecase/oidc/discovery"
 	"github.com/p1xray/pxr-sso/internal/usecase/profile/card"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/confirmverification"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/requestverification"
+	profileupdate "github.com/p1xray/pxr-sso/internal/usecase/profile/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/list"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revoke"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revokeall"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/introspect"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginregistration"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishregistration"
+	"github.com/p1xray/pxr-sso/pkg/breached"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"github.com/p1xray/pxr-sso/pkg/passhash"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -21,6 +87,7 @@ import (
 type App struct {
 	log     *slog.Logger
 	grpcApp *grpcapp.App
+	httpApp *httpapp.App
 }
 
 // New creates a new application.
@@ -28,34 +95,215 @@ func New(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *App {
-	storage, err := sqlite.New(cfg.StoragePath)
+	storage, err := newStorage(cfg.Storage)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewWithStorage(log, cfg, storage)
+}
+
+// NewWithStorage creates a new application on the given storage, e.g. the seeded in-memory storage
+// of the integration tests. The storage configuration is ignored.
+func NewWithStorage(
+	log *slog.Logger,
+	cfg *config.Config,
+	storage repository.Storage,
+) *App {
+	keyStore, err := loadKeyStore(cfg.Tokens)
 	if err != nil {
 		panic(err)
 	}
 
 	authRepository := repository.NewAuthRepository(log, storage)
 	profileRepository := repository.NewProfileRepository(log, storage)
+	clientRepository := repository.NewClientRepository(log, storage)
+	roleRepository := repository.NewRoleRepository(log, storage)
+	permissionRepository := repository.NewPermissionRepository(log, storage)
+	userRepository := repository.NewUserRepository(log, storage)
+
+	securityEvents := events.NewLogPublisher(log)
+
+	userNotifier, err := newNotifier(cfg.Notifier)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordPolicy, err := newPasswordPolicy(cfg.Password)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordHasher, err := newPasswordHasher(cfg.PasswordHash)
+	if err != nil {
+		panic(err)
+	}
 
-	loginUseCase := login.New(log, cfg.Tokens, authRepository)
-	registerUseCase := register.New(log, cfg.Tokens, authRepository)
-	refreshUseCase := refresh.New(log, cfg.Tokens, authRepository)
+	loginUseCase := login.New(log, cfg.Tokens, cfg.MFA, cfg.LoginThrottle, passwordHasher, keyStore, authRepository)
+	registerUseCase := register.New(log, cfg.Tokens, passwordPolicy, passwordHasher, keyStore, authRepository)
+	refreshUseCase := refresh.New(log, cfg.Tokens, keyStore, authRepository, securityEvents)
 	logoutUseCase := logout.New(log, cfg.Tokens, authRepository)
+	authorizeUseCase := authorize.New(log, cfg.Tokens, cfg.MFA, cfg.LoginThrottle, authRepository)
+	exchangeUseCase := exchange.New(log, cfg.Tokens, keyStore, authRepository)
+	credentialsUseCase := credentials.New(log, cfg.Tokens, keyStore, authRepository)
+	changePasswordUseCase := changepassword.New(
+		log, cfg.Tokens, cfg.LoginThrottle, passwordPolicy, passwordHasher, authRepository)
+	requestPasswordResetUseCase := requestpasswordreset.New(log, cfg.Tokens, cfg.PasswordReset, authRepository, userNotifier)
+	confirmPasswordResetUseCase := confirmpasswordreset.New(log, cfg.Tokens, passwordPolicy, passwordHasher, authRepository)
+	requestVerificationUseCase := requestverification.New(log, cfg.Verification, profileRepository, userNotifier)
+	confirmVerificationUseCase := confirmverification.New(log, cfg.Verification, profileRepository)
+
+	verifyMFAUseCase := verifymfa.New(log, cfg.Tokens, cfg.MFA, keyStore, authRepository)
+	enrollTOTPUseCase := enrolltotp.New(log, cfg.Tokens, cfg.MFA, cfg.LoginThrottle, authRepository)
+	confirmTOTPUseCase := confirmtotp.New(log, cfg.Tokens, cfg.MFA, cfg.LoginThrottle, authRepository)
+	disableTOTPUseCase := disabletotp.New(log, cfg.Tokens, cfg.LoginThrottle, authRepository)
+	regenerateBackupCodesUseCase := regeneratebackupcodes.New(
+		log, cfg.Tokens, cfg.MFA, cfg.LoginThrottle, authRepository)
+
+	beginWebAuthnRegistrationUseCase := beginregistration.New(
+		log, cfg.Tokens, cfg.WebAuthn, cfg.LoginThrottle, authRepository)
+	finishWebAuthnRegistrationUseCase := finishregistration.New(log, cfg.Tokens, cfg.WebAuthn, authRepository)
+	beginWebAuthnLoginUseCase := beginlogin.New(log, cfg.Tokens, cfg.WebAuthn, authRepository)
+	finishWebAuthnLoginUseCase := finishlogin.New(log, cfg.Tokens, cfg.WebAuthn, keyStore, authRepository)
 
 	profileUseCase := card.New(log, profileRepository)
+	updateProfileUseCase := profileupdate.New(log, profileRepository)
+
+	listSessionsUseCase := list.New(log, authRepository)
+	revokeSessionUseCase := revoke.New(log, cfg.Tokens, authRepository)
+	revokeAllSessionsUseCase := revokeall.New(log, cfg.Tokens, authRepository)
+
+	introspectUseCase := introspect.New(log, keyStore, authRepository)
+	verifyAccessTokenUseCase := verify.New(log, keyStore, authRepository)
+
+	createClientUseCase := clientcreate.New(log, clientRepository)
+	updateClientUseCase := clientupdate.New(log, clientRepository)
+	removeClientUseCase := clientremove.New(log, clientRepository)
+	listClientsUseCase := clientlist.New(log, clientRepository)
+	rotateClientSecretUseCase := rotatesecret.New(log, clientRepository)
+	addClientAudienceUseCase := addaudience.New(log, clientRepository)
+	removeClientAudienceUseCase := removeaudience.New(log, clientRepository)
+	setClientDefaultRolesUseCase := setdefaultroles.New(log, clientRepository)
+
+	createRoleUseCase := rolecreate.New(log, roleRepository)
+	updateRoleUseCase := roleupdate.New(log, roleRepository)
+	removeRoleUseCase := roleremove.New(log, roleRepository)
+	setRoleActiveUseCase := rolesetactive.New(log, roleRepository)
+	listRolesUseCase := rolelist.New(log, roleRepository)
+	attachRolePermissionUseCase := attachpermission.New(log, roleRepository)
+	detachRolePermissionUseCase := detachpermission.New(log, roleRepository)
+
+	createPermissionUseCase := permissioncreate.New(log, permissionRepository)
+	updatePermissionUseCase := permissionupdate.New(log, permissionRepository)
+	removePermissionUseCase := permissionremove.New(log, permissionRepository)
+	setPermissionActiveUseCase := permissionsetactive.New(log, permissionRepository)
+	listPermissionsUseCase := permissionlist.New(log, permissionRepository)
+
+	listUsersUseCase := userlist.New(log, userRepository)
+	grantUserRoleUseCase := grantrole.New(log, userRepository)
+	revokeUserRoleUseCase := revokerole.New(log, userRepository)
+	grantUserClientUseCase := grantclient.New(log, userRepository)
+	revokeUserClientUseCase := revokeclient.New(log, userRepository)
+	setUserBlockedUseCase := usersetblocked.New(log, userRepository)
+	removeUserUseCase := userremove.New(log, userRepository)
+	restoreUserUseCase := userrestore.New(log, userRepository)
+	resetUserPasswordUseCase := resetpassword.New(log, passwordPolicy, passwordHasher, userRepository)
+	unlockUserUseCase := userunlock.New(log, userRepository)
+
+	jwksUseCase := jwks.New(log, keyStore)
+	discoveryUseCase := discovery.New(log, cfg.OIDC, keyStore)
+
+	grpcClientIP, err := clientip.NewResolver(cfg.GRPC.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
+	httpClientIP, err := clientip.NewResolver(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
 
 	grpcApp := grpcapp.New(
 		log,
 		cfg.GRPC.Port,
+		cfg.OIDC.Issuer,
 		loginUseCase,
 		registerUseCase,
 		refreshUseCase,
 		logoutUseCase,
 		profileUseCase,
+		updateProfileUseCase,
+		changePasswordUseCase,
+		requestPasswordResetUseCase,
+		confirmPasswordResetUseCase,
+		requestVerificationUseCase,
+		confirmVerificationUseCase,
+		verifyMFAUseCase,
+		enrollTOTPUseCase,
+		confirmTOTPUseCase,
+		disableTOTPUseCase,
+		regenerateBackupCodesUseCase,
+		beginWebAuthnRegistrationUseCase,
+		finishWebAuthnRegistrationUseCase,
+		beginWebAuthnLoginUseCase,
+		finishWebAuthnLoginUseCase,
+		introspectUseCase,
+		listSessionsUseCase,
+		revokeSessionUseCase,
+		revokeAllSessionsUseCase,
+		verifyAccessTokenUseCase,
+		createClientUseCase,
+		updateClientUseCase,
+		removeClientUseCase,
+		listClientsUseCase,
+		rotateClientSecretUseCase,
+		addClientAudienceUseCase,
+		removeClientAudienceUseCase,
+		setClientDefaultRolesUseCase,
+		createRoleUseCase,
+		updateRoleUseCase,
+		removeRoleUseCase,
+		setRoleActiveUseCase,
+		listRolesUseCase,
+		attachRolePermissionUseCase,
+		detachRolePermissionUseCase,
+		createPermissionUseCase,
+		updatePermissionUseCase,
+		removePermissionUseCase,
+		setPermissionActiveUseCase,
+		listPermissionsUseCase,
+		listUsersUseCase,
+		grantUserRoleUseCase,
+		revokeUserRoleUseCase,
+		grantUserClientUseCase,
+		revokeUserClientUseCase,
+		setUserBlockedUseCase,
+		removeUserUseCase,
+		restoreUserUseCase,
+		resetUserPasswordUseCase,
+		unlockUserUseCase,
+		grpcClientIP,
+	)
+
+	httpApp := httpapp.New(
+		log,
+		cfg.HTTP.Port,
+		cfg.HTTP.Timeout,
+		cfg.OIDC.Issuer,
+		cfg.Tokens.AccessTokenTTL,
+		jwksUseCase,
+		discoveryUseCase,
+		authorizeUseCase,
+		exchangeUseCase,
+		credentialsUseCase,
+		introspectUseCase,
+		httpClientIP,
 	)
 
 	return &App{
 		log:     log,
 		grpcApp: grpcApp,
+		httpApp: httpApp,
 	}
 }
 
@@ -67,6 +315,7 @@ func (a *App) Start() {
 	log.Info("starting application")
 
 	a.grpcApp.Start()
+	a.httpApp.Start()
 }
 
 // GracefulStop - gracefully stops the application.
@@ -83,9 +332,119 @@ func (a *App) GracefulStop() {
 		log.Info("signal received from OS", slog.String("signal:", s.String()))
 	case err := <-a.grpcApp.Notify():
 		log.Error("received an error from the gRPC server:", sl.Err(err))
+	case err := <-a.httpApp.Notify():
+		log.Error("received an error from the HTTP server:", sl.Err(err))
 	}
 
 	log.Info("stopping application")
 
+	a.httpApp.Stop()
 	a.grpcApp.Stop()
 }
+
+// loadKeyStore loads the private keys for signing access tokens into the key store.
+// Retired keys stay published for the access token TTL, so the tokens signed by them can be verified until they expire.
+func loadKeyStore(cfg config.TokensConfig) (*jwtkeys.Store, error) {
+	keys := make([]jwtkeys.StoredKey, len(cfg.SigningKeys))
+	for i, keyCfg := range cfg.SigningKeys {
+		signingKey, err := jwtkeys.LoadSigningKey(keyCfg.PrivateKeyPath, jose.SignatureAlgorithm(keyCfg.Algorithm))
+		if err != nil {
+			return nil, err
+		}
+
+		if keyCfg.ID != "" {
+			signingKey.ID = keyCfg.ID
+		}
+
+		keys[i] = jwtkeys.StoredKey{
+			SigningKey:  signingKey,
+			ActivatesAt: keyCfg.ActivatesAt,
+			RetiresAt:   keyCfg.RetiresAt,
+		}
+	}
+
+	return jwtkeys.NewStore(cfg.AccessTokenTTL, keys...)
+}
+
+// newStorage creates the storage of the configured driver.
+func newStorage(cfg config.StorageConfig) (repository.Storage, error) {
+	switch cfg.Driver {
+	case config.StorageDriverPostgres:
+		return postgres.New(cfg.DSN)
+	case config.StorageDriverMemory:
+		return memory.New(), nil
+	default:
+		return sqlite.New(cfg.Path)
+	}
+}
+
+// newNotifier creates the notifier which routes the notifications to the emails to the notifier
+// of the configured driver and the notifications to the phones to the SMS notifier.
+func newNotifier(cfg config.NotifierConfig) (*notifier.ChannelNotifier, error) {
+	var emailNotifier notifier.Notifier
+	switch cfg.Driver {
+	case config.NotifierDriverSMTP:
+		smtpNotifier, err := notifier.NewSMTPNotifier(
+			cfg.SMTP.Host,
+			cfg.SMTP.Port,
+			cfg.SMTP.Username,
+			cfg.SMTP.Password,
+			cfg.SMTP.From,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		emailNotifier = smtpNotifier
+	default:
+		emailNotifier = notifier.NewFileNotifier(cfg.OutboxPath)
+	}
+
+	return notifier.NewChannelNotifier(emailNotifier, notifier.NewFileNotifier(cfg.SMS.OutboxPath)), nil
+}
+
+// newPasswordPolicy creates the policy the new user passwords must meet.
+// The list of the breached passwords is loaded if it is configured.
+func newPasswordPolicy(cfg config.PasswordConfig) (entity.PasswordPolicy, error) {
+	policy := entity.PasswordPolicy{
+		MinLength:        cfg.MinLength,
+		MaxLength:        cfg.MaxLength,
+		RequireUppercase: cfg.RequireUppercase,
+		RequireLowercase: cfg.RequireLowercase,
+		RequireDigit:     cfg.RequireDigit,
+		RequireSymbol:    cfg.RequireSymbol,
+		ForbidUserInfo:   cfg.ForbidUserInfo,
+	}
+
+	if cfg.BreachedListPath != "" {
+		breachedPasswords, err := breached.Load(cfg.BreachedListPath)
+		if err != nil {
+			return entity.PasswordPolicy{}, err
+		}
+
+		policy.Breached = breachedPasswords
+	}
+
+	return policy, nil
+}
+
+// newPasswordHasher creates the hasher of the new user passwords with the configured algorithm.
+// The hashes of the other algorithm or with the other parameters are rehashed on login.
+func newPasswordHasher(cfg config.PasswordHashConfig) (entity.PasswordHasher, error) {
+	switch cfg.Algorithm {
+	case config.PasswordHashBcrypt:
+		hasher := passhash.Bcrypt{Cost: cfg.Bcrypt.Cost}
+
+		return hasher, hasher.Validate()
+	default:
+		hasher := passhash.Argon2id{
+			Memory:      cfg.Argon2id.Memory,
+			Iterations:  cfg.Argon2id.Iterations,
+			Parallelism: cfg.Argon2id.Parallelism,
+			SaltLength:  cfg.Argon2id.SaltLength,
+			KeyLength:   cfg.Argon2id.KeyLength,
+		}
+
+		return hasher, hasher.Validate()
+	}
+}
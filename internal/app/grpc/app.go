@@ -2,6 +2,7 @@ package grpcapp
 
 import (
 	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
 	"github.com/p1xray/pxr-sso/internal/controller/grpc"
 	"github.com/p1xray/pxr-sso/pkg/grpcserver"
 	"log/slog"
@@ -18,21 +19,125 @@ type App struct {
 func New(
 	log *slog.Logger,
 	port string,
+	issuer string,
 	loginUseCase controller.Login,
 	registerUseCase controller.Register,
 	refreshUseCase controller.RefreshTokens,
 	logoutUseCase controller.Logout,
 	profileUseCase controller.UserProfile,
+	updateProfileUseCase controller.UpdateUserProfile,
+	changePasswordUseCase controller.ChangePassword,
+	requestPasswordResetUseCase controller.RequestPasswordReset,
+	confirmPasswordResetUseCase controller.ConfirmPasswordReset,
+	requestVerificationUseCase controller.RequestContactVerification,
+	confirmVerificationUseCase controller.ConfirmContactVerification,
+	verifyMFAUseCase controller.VerifyMFA,
+	enrollTOTPUseCase controller.EnrollTOTP,
+	confirmTOTPUseCase controller.ConfirmTOTP,
+	disableTOTPUseCase controller.DisableTOTP,
+	regenerateBackupCodesUseCase controller.RegenerateBackupCodes,
+	beginWebAuthnRegistrationUseCase controller.BeginWebAuthnRegistration,
+	finishWebAuthnRegistrationUseCase controller.FinishWebAuthnRegistration,
+	beginWebAuthnLoginUseCase controller.BeginWebAuthnLogin,
+	finishWebAuthnLoginUseCase controller.FinishWebAuthnLogin,
+	introspectUseCase controller.Introspect,
+	listSessionsUseCase controller.ListSessions,
+	revokeSessionUseCase controller.RevokeSession,
+	revokeAllSessionsUseCase controller.RevokeAllSessions,
+	verifyAccessTokenUseCase controller.VerifyAccessToken,
+	createClientUseCase controller.CreateClient,
+	updateClientUseCase controller.UpdateClient,
+	removeClientUseCase controller.RemoveClient,
+	listClientsUseCase controller.ListClients,
+	rotateClientSecretUseCase controller.RotateClientSecret,
+	addClientAudienceUseCase controller.AddClientAudience,
+	removeClientAudienceUseCase controller.RemoveClientAudience,
+	setClientDefaultRolesUseCase controller.SetClientDefaultRoles,
+	createRoleUseCase controller.CreateRole,
+	updateRoleUseCase controller.UpdateRole,
+	removeRoleUseCase controller.RemoveRole,
+	setRoleActiveUseCase controller.SetRoleActive,
+	listRolesUseCase controller.ListRoles,
+	attachRolePermissionUseCase controller.AttachRolePermission,
+	detachRolePermissionUseCase controller.DetachRolePermission,
+	createPermissionUseCase controller.CreatePermission,
+	updatePermissionUseCase controller.UpdatePermission,
+	removePermissionUseCase controller.RemovePermission,
+	setPermissionActiveUseCase controller.SetPermissionActive,
+	listPermissionsUseCase controller.ListPermissions,
+	listUsersUseCase controller.ListUsers,
+	grantUserRoleUseCase controller.GrantUserRole,
+	revokeUserRoleUseCase controller.RevokeUserRole,
+	grantUserClientUseCase controller.GrantUserClient,
+	revokeUserClientUseCase controller.RevokeUserClient,
+	setUserBlockedUseCase controller.SetUserBlocked,
+	removeUserUseCase controller.RemoveUser,
+	restoreUserUseCase controller.RestoreUser,
+	resetUserPasswordUseCase controller.ResetUserPassword,
+	unlockUserUseCase controller.UnlockUser,
+	clientIP *clientip.Resolver,
 ) *App {
 	gRPCServer := grpcserver.New(grpcserver.WithPort(port))
 
 	grpc.NewRouter(
 		gRPCServer.App,
+		issuer,
 		loginUseCase,
 		registerUseCase,
 		refreshUseCase,
 		logoutUseCase,
-		profileUseCase)
+		profileUseCase,
+		updateProfileUseCase,
+		changePasswordUseCase,
+		requestPasswordResetUseCase,
+		confirmPasswordResetUseCase,
+		requestVerificationUseCase,
+		confirmVerificationUseCase,
+		verifyMFAUseCase,
+		enrollTOTPUseCase,
+		confirmTOTPUseCase,
+		disableTOTPUseCase,
+		regenerateBackupCodesUseCase,
+		beginWebAuthnRegistrationUseCase,
+		finishWebAuthnRegistrationUseCase,
+		beginWebAuthnLoginUseCase,
+		finishWebAuthnLoginUseCase,
+		introspectUseCase,
+		listSessionsUseCase,
+		revokeSessionUseCase,
+		revokeAllSessionsUseCase,
+		verifyAccessTokenUseCase,
+		createClientUseCase,
+		updateClientUseCase,
+		removeClientUseCase,
+		listClientsUseCase,
+		rotateClientSecretUseCase,
+		addClientAudienceUseCase,
+		removeClientAudienceUseCase,
+		setClientDefaultRolesUseCase,
+		createRoleUseCase,
+		updateRoleUseCase,
+		removeRoleUseCase,
+		setRoleActiveUseCase,
+		listRolesUseCase,
+		attachRolePermissionUseCase,
+		detachRolePermissionUseCase,
+		createPermissionUseCase,
+		updatePermissionUseCase,
+		removePermissionUseCase,
+		setPermissionActiveUseCase,
+		listPermissionsUseCase,
+		listUsersUseCase,
+		grantUserRoleUseCase,
+		revokeUserRoleUseCase,
+		grantUserClientUseCase,
+		revokeUserClientUseCase,
+		setUserBlockedUseCase,
+		removeUserUseCase,
+		restoreUserUseCase,
+		resetUserPasswordUseCase,
+		unlockUserUseCase,
+		clientIP)
 
 	return &App{
 		log:        log,
@@ -0,0 +1,94 @@
+package httpapp
+
+import (
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	httpcontroller "github.com/p1xray/pxr-sso/internal/controller/http"
+	"github.com/p1xray/pxr-sso/pkg/httpserver"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// App is an HTTP controller application.
+type App struct {
+	log        *slog.Logger
+	port       string
+	httpServer *httpserver.Server
+}
+
+// New creates new HTTP controller application.
+func New(
+	log *slog.Logger,
+	port string,
+	timeout time.Duration,
+	issuer string,
+	accessTokenTTL time.Duration,
+	publicKeysUseCase controller.PublicKeys,
+	openIDConfigurationUseCase controller.OpenIDConfiguration,
+	authorizeUseCase controller.Authorize,
+	exchangeAuthCodeUseCase controller.ExchangeAuthorizationCode,
+	clientCredentialsUseCase controller.ClientCredentials,
+	introspectUseCase controller.Introspect,
+	clientIP *clientip.Resolver,
+) *App {
+	mux := http.NewServeMux()
+
+	httpcontroller.NewRouter(
+		mux,
+		issuer,
+		accessTokenTTL,
+		publicKeysUseCase,
+		openIDConfigurationUseCase,
+		authorizeUseCase,
+		exchangeAuthCodeUseCase,
+		clientCredentialsUseCase,
+		introspectUseCase,
+		clientIP,
+	)
+
+	httpServer := httpserver.New(
+		mux,
+		httpserver.WithPort(port),
+		httpserver.WithTimeout(timeout))
+
+	return &App{
+		log:        log,
+		port:       port,
+		httpServer: httpServer,
+	}
+}
+
+// Start - starts the HTTP controller application.
+func (a *App) Start() {
+	const op = "httpapp.Start"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("running HTTP server")
+
+	a.httpServer.Start()
+}
+
+// Stop - stops the HTTP controller application.
+func (a *App) Stop() {
+	const op = "httpapp.Stop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("stopping HTTP server")
+
+	if err := a.httpServer.Stop(); err != nil {
+		log.Error("error stopping HTTP server", sl.Err(err))
+	}
+}
+
+// Notify - notifies about HTTP controller application errors.
+func (a *App) Notify() <-chan error {
+	return a.httpServer.Notify()
+}
@@ -6,26 +6,223 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/p1xray/pxr-sso/internal/enum"
 )
 
 // Config is the project configuration.
 type Config struct {
-	Env         string       `yaml:"env" env-default:"local"`
-	GRPC        GRPCConfig   `yaml:"grpc" env-required:"true"`
-	Tokens      TokensConfig `yaml:"tokens" env-required:"true"`
-	StoragePath string       `yaml:"storage_path" env-required:"true"`
+	Env           string              `yaml:"env" env-default:"local"`
+	GRPC          GRPCConfig          `yaml:"grpc" env-required:"true"`
+	HTTP          HTTPConfig          `yaml:"http" env-required:"true"`
+	Tokens        TokensConfig        `yaml:"tokens" env-required:"true"`
+	Password      PasswordConfig      `yaml:"password"`
+	PasswordHash  PasswordHashConfig  `yaml:"password_hash"`
+	PasswordReset PasswordResetConfig `yaml:"password_reset"`
+	Verification  VerificationConfig  `yaml:"verification"`
+	MFA           MFAConfig           `yaml:"mfa"`
+	WebAuthn      WebAuthnConfig      `yaml:"webauthn"`
+	LoginThrottle LoginThrottleConfig `yaml:"login_throttle"`
+	OIDC          OIDCConfig          `yaml:"oidc" env-required:"true"`
+	Storage       StorageConfig       `yaml:"storage" env-required:"true"`
+	Notifier      NotifierConfig      `yaml:"notifier"`
+}
+
+// Storage drivers.
+const (
+	StorageDriverSQLite   = "sqlite"
+	StorageDriverPostgres = "postgres"
+	StorageDriverMemory   = "memory"
+)
+
+// StorageConfig is the storage configuration.
+// Path is the path to the database file of the SQLite storage, DSN is the connection string of the PostgreSQL storage.
+// The memory storage keeps the data in the process memory, it is intended for tests and ephemeral environments.
+type StorageConfig struct {
+	Driver string `yaml:"driver" env-default:"sqlite"`
+	Path   string `yaml:"path"`
+	DSN    string `yaml:"dsn"`
+}
+
+// Notifier drivers.
+const (
+	NotifierDriverFile = "file"
+	NotifierDriverSMTP = "smtp"
+)
+
+// NotifierConfig is the configuration of the notifier which delivers the messages to the users,
+// e.g. the password reset tokens. The file notifier appends the messages to the outbox file instead of sending them,
+// it is intended for local development and tests. The driver delivers the messages to the emails,
+// the messages to the phones are delivered by the SMS notifier.
+type NotifierConfig struct {
+	Driver     string     `yaml:"driver" env-default:"file"`
+	OutboxPath string     `yaml:"outbox_path" env-default:"./storage/outbox.jsonl"`
+	SMTP       SMTPConfig `yaml:"smtp"`
+	SMS        SMSConfig  `yaml:"sms"`
+}
+
+// SMSConfig is the configuration of the notifier which delivers the messages to the phones of the users.
+// Only the file driver is supported, the messages are appended to the outbox file the SMS gateway reads.
+type SMSConfig struct {
+	Driver     string `yaml:"driver" env-default:"file"`
+	OutboxPath string `yaml:"outbox_path" env-default:"./storage/sms_outbox.jsonl"`
+}
+
+// SMTPConfig is the configuration of the SMTP server the notifier sends the messages through.
+// If the username is empty, the messages are sent without authentication.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from"`
 }
 
 // GRPCConfig is the gRPC controller configuration.
+// TrustedProxies are the IP addresses or the CIDR ranges of the proxies in front of the server,
+// the client IP address is taken from the "x-forwarded-for" metadata only if the request comes from them.
 type GRPCConfig struct {
-	Port    string        `yaml:"port" env-required:"true"`
-	Timeout time.Duration `yaml:"timeout" env-required:"true"`
+	Port           string        `yaml:"port" env-required:"true"`
+	Timeout        time.Duration `yaml:"timeout" env-required:"true"`
+	TrustedProxies []string      `yaml:"trusted_proxies"`
+}
+
+// HTTPConfig is the HTTP controller configuration.
+// TrustedProxies are the IP addresses or the CIDR ranges of the proxies in front of the server,
+// the client IP address is taken from the "X-Forwarded-For" header only if the request comes from them.
+type HTTPConfig struct {
+	Port           string        `yaml:"port" env-required:"true"`
+	Timeout        time.Duration `yaml:"timeout" env-required:"true"`
+	TrustedProxies []string      `yaml:"trusted_proxies"`
 }
 
 // TokensConfig is the auth tokens configuration.
+// MaxSessionsPerClient and SessionEvictionPolicy are the default session limit of the clients,
+// the zero MaxSessionsPerClient means the number of sessions is not limited.
 type TokensConfig struct {
-	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env-required:"true"`
-	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env-required:"true"`
+	AccessTokenTTL        time.Duration                  `yaml:"access_token_ttl" env-required:"true"`
+	RefreshTokenTTL       time.Duration                  `yaml:"refresh_token_ttl" env-required:"true"`
+	AuthorizationCodeTTL  time.Duration                  `yaml:"authorization_code_ttl" env-default:"1m"`
+	MaxSessionsPerClient  int                            `yaml:"max_sessions_per_client" env-default:"5"`
+	SessionEvictionPolicy enum.SessionEvictionPolicyEnum `yaml:"session_eviction_policy" env-default:"oldest"`
+	SigningKeys           []SigningKeyConfig             `yaml:"signing_keys"`
+}
+
+// PasswordConfig is the configuration of the policy the new user passwords must meet.
+// MaxLength is the maximum length of the password in bytes, it can't exceed 72 bytes bcrypt can hash.
+// If ForbidUserInfo is set, the password can't be the username or the full name of the user.
+// BreachedListPath is the file of the SHA-1 hashes of the breached passwords or the directory of the hash ranges
+// named by the hash prefix. If it is empty, the breached passwords are not checked.
+type PasswordConfig struct {
+	MinLength        int    `yaml:"min_length" env-default:"8"`
+	MaxLength        int    `yaml:"max_length" env-default:"72"`
+	RequireUppercase bool   `yaml:"require_uppercase"`
+	RequireLowercase bool   `yaml:"require_lowercase"`
+	RequireDigit     bool   `yaml:"require_digit"`
+	RequireSymbol    bool   `yaml:"require_symbol"`
+	ForbidUserInfo   bool   `yaml:"forbid_user_info" env-default:"true"`
+	BreachedListPath string `yaml:"breached_list_path"`
+}
+
+// Password hashing algorithms.
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
+
+// PasswordHashConfig is the configuration of the password hashing. The new passwords are hashed by the algorithm,
+// the passwords are verified against the hashes of any supported algorithm. The hashes produced by the other algorithm
+// or with the other parameters are rehashed when the users sign in.
+type PasswordHashConfig struct {
+	Algorithm string         `yaml:"algorithm" env-default:"argon2id"`
+	Argon2id  Argon2idConfig `yaml:"argon2id"`
+	Bcrypt    BcryptConfig   `yaml:"bcrypt"`
+}
+
+// Argon2idConfig is the configuration of the argon2id password hashing. Memory is in KiB.
+// The defaults are the second recommended parameters of RFC 9106.
+type Argon2idConfig struct {
+	Memory      uint32 `yaml:"memory" env-default:"65536"`
+	Iterations  uint32 `yaml:"iterations" env-default:"3"`
+	Parallelism uint8  `yaml:"parallelism" env-default:"4"`
+	SaltLength  uint32 `yaml:"salt_length" env-default:"16"`
+	KeyLength   uint32 `yaml:"key_length" env-default:"32"`
+}
+
+// BcryptConfig is the configuration of the bcrypt password hashing.
+type BcryptConfig struct {
+	Cost int `yaml:"cost" env-default:"10"`
+}
+
+// PasswordResetConfig is the self-service password reset configuration.
+// URL is the page of the password reset form, the token is passed in its "token" query parameter.
+// If URL is empty, the token itself is sent to the user.
+type PasswordResetConfig struct {
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"1h"`
+	URL      string        `yaml:"url"`
+}
+
+// VerificationConfig is the configuration of the verification of the user contacts by the one-time codes.
+// MaxAttempts is the number of the mismatched codes after which the code must be requested again.
+type VerificationConfig struct {
+	CodeTTL     time.Duration `yaml:"code_ttl" env-default:"10m"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"5"`
+}
+
+// MFAConfig is the configuration of the second authentication factor.
+// Issuer is the name of the service shown by the authenticator applications. ChallengeTTL is the time the user has
+// to pass the second factor after the password check, MaxAttempts is the number of the mismatched codes after which
+// the user must log in again, the MFA codes of the username are locked for ChallengeTTL after as many mismatched codes
+// across the logins. BackupCodesCount is the number of the backup codes generated for the user.
+type MFAConfig struct {
+	Issuer           string        `yaml:"issuer" env-default:"pxr-sso"`
+	ChallengeTTL     time.Duration `yaml:"challenge_ttl" env-default:"5m"`
+	MaxAttempts      int           `yaml:"max_attempts" env-default:"5"`
+	BackupCodesCount int           `yaml:"backup_codes_count" env-default:"10"`
+}
+
+// WebAuthnConfig is the configuration of the WebAuthn relying party.
+// RPID is the domain the credentials are bound to, Origins are the web origins of the pages
+// which perform the ceremonies, they must be the RPID or its subdomains. ChallengeTTL is the time the user has
+// to complete the ceremony.
+type WebAuthnConfig struct {
+	RPID         string        `yaml:"rp_id" env-default:"localhost"`
+	RPName       string        `yaml:"rp_name" env-default:"pxr-sso"`
+	Origins      []string      `yaml:"origins" env-default:"http://localhost:6005"`
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" env-default:"5m"`
+}
+
+// LoginThrottleConfig is the configuration of the brute-force protection of the login by the password.
+// The failed logins are counted per username and per client IP address, each failure delays the next login
+// by BaseDelay doubled with each failure up to MaxDelay. After MaxFailures failures of the username
+// or IPMaxFailures failures of the address the logins are locked for LockoutDuration, zero disables the lockout.
+// The failures older than FailureWindow are forgotten.
+type LoginThrottleConfig struct {
+	MaxFailures     int           `yaml:"max_failures" env-default:"5"`
+	IPMaxFailures   int           `yaml:"ip_max_failures" env-default:"50"`
+	BaseDelay       time.Duration `yaml:"base_delay" env-default:"1s"`
+	MaxDelay        time.Duration `yaml:"max_delay" env-default:"30s"`
+	LockoutDuration time.Duration `yaml:"lockout_duration" env-default:"15m"`
+	FailureWindow   time.Duration `yaml:"failure_window" env-default:"15m"`
+}
+
+// OIDCConfig is the OpenID Connect provider configuration.
+// Issuer is the public base URL of the HTTP controller, it is the issuer of all tokens.
+// The issuer the clients pass when logging in, if any, must match it.
+type OIDCConfig struct {
+	Issuer string `yaml:"issuer" env-required:"true"`
+}
+
+// SigningKeyConfig is the configuration of the private key for signing access tokens.
+// If no signing keys are configured, access tokens are signed by the client secret key. If they are configured,
+// the protected API rejects the access tokens signed by the client secret keys.
+// If the ID is empty, the thumbprint of the key is used. If the algorithm is empty, it is chosen by the type of the key.
+// The key signs new tokens from ActivatesAt until RetiresAt, the empty RetiresAt means the key is never retired.
+type SigningKeyConfig struct {
+	ID             string    `yaml:"id"`
+	Algorithm      string    `yaml:"algorithm"`
+	PrivateKeyPath string    `yaml:"private_key_path"`
+	ActivatesAt    time.Time `yaml:"activates_at"`
+	RetiresAt      time.Time `yaml:"retires_at"`
 }
 
 // MustLoad loads config and panics if any error occurs.
@@ -49,6 +246,46 @@ func MustLoadByPath(configPath string) *Config {
 		panic("cannot read config: " + err.Error())
 	}
 
+	switch cfg.Storage.Driver {
+	case StorageDriverSQLite:
+		if cfg.Storage.Path == "" {
+			panic("storage path is required for the sqlite storage driver")
+		}
+	case StorageDriverPostgres:
+		if cfg.Storage.DSN == "" {
+			panic("storage DSN is required for the postgres storage driver")
+		}
+	case StorageDriverMemory:
+	default:
+		panic("unknown storage driver: " + cfg.Storage.Driver)
+	}
+
+	switch cfg.Notifier.Driver {
+	case NotifierDriverFile:
+		if cfg.Notifier.OutboxPath == "" {
+			panic("outbox path is required for the file notifier driver")
+		}
+	case NotifierDriverSMTP:
+		if cfg.Notifier.SMTP.Host == "" || cfg.Notifier.SMTP.From == "" {
+			panic("SMTP host and sender address are required for the smtp notifier driver")
+		}
+	default:
+		panic("unknown notifier driver: " + cfg.Notifier.Driver)
+	}
+
+	switch cfg.Notifier.SMS.Driver {
+	case NotifierDriverFile:
+		if cfg.Notifier.SMS.OutboxPath == "" {
+			panic("outbox path is required for the file SMS notifier driver")
+		}
+	default:
+		panic("unknown SMS notifier driver: " + cfg.Notifier.SMS.Driver)
+	}
+
+	if !cfg.Tokens.SessionEvictionPolicy.IsValid() {
+		panic("invalid session eviction policy: " + string(cfg.Tokens.SessionEvictionPolicy))
+	}
+
 	return &cfg
 }
 
@@ -0,0 +1,113 @@
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Resolver resolves the IP address of the client sending the request. The address of the peer is used
+// unless the peer is a trusted proxy, only then the "X-Forwarded-For" addresses set by the proxies are used.
+type Resolver struct {
+	trustedProxies []netip.Prefix
+}
+
+// NewResolver returns new client IP address resolver. The trusted proxies are the IP addresses
+// or the CIDR ranges of the proxies in front of the server, the forwarded addresses are ignored without them.
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	const op = "clientip.NewResolver"
+
+	prefixes := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		prefix, err := parsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid trusted proxy %q: %w", op, proxy, err)
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	return &Resolver{trustedProxies: prefixes}, nil
+}
+
+// ClientIP returns the IP address of the client by the address of the peer, like "127.0.0.1:53124",
+// and the values of the "X-Forwarded-For" header. If the peer is a trusted proxy, the rightmost forwarded
+// address which is not a trusted proxy is returned, the addresses to the left of it may be set by the client.
+// The empty string is returned if the address is unknown.
+func (r *Resolver) ClientIP(peerAddr string, forwardedFor []string) string {
+	peerIP, ok := parseAddr(peerAddr)
+	if !ok {
+		return ""
+	}
+
+	if !r.trusted(peerIP) {
+		return peerIP.String()
+	}
+
+	var forwarded []string
+	for _, value := range forwardedFor {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+
+	clientIP := peerIP
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			// The address set by the client can't be trusted anymore.
+			break
+		}
+
+		clientIP = ip.Unmap()
+		if !r.trusted(clientIP) {
+			break
+		}
+	}
+
+	return clientIP.String()
+}
+
+func (r *Resolver) trusted(ip netip.Addr) bool {
+	for _, prefix := range r.trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAddr parses the IP address with or without the port.
+func parseAddr(addr string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return ip.Unmap(), true
+}
+
+// parsePrefix parses the CIDR range or the single IP address.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	ip = ip.Unmap()
+
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
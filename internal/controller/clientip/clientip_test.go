@@ -0,0 +1,89 @@
+package clientip
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_Resolver_ClientIP(t *testing.T) {
+	resolver, err := NewResolver([]string{"10.0.0.0/8", "192.168.1.10"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		peerAddr     string
+		forwardedFor []string
+		expected     string
+	}{
+		{
+			name:     "peer address without forwarded addresses",
+			peerAddr: "203.0.113.5:53124",
+			expected: "203.0.113.5",
+		},
+		{
+			name:         "forwarded addresses of untrusted peer are ignored",
+			peerAddr:     "203.0.113.5:53124",
+			forwardedFor: []string{"198.51.100.1"},
+			expected:     "203.0.113.5",
+		},
+		{
+			name:         "forwarded address of trusted proxy",
+			peerAddr:     "10.0.0.1:53124",
+			forwardedFor: []string{"198.51.100.1"},
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "spoofed forwarded address is skipped",
+			peerAddr:     "10.0.0.1:53124",
+			forwardedFor: []string{"1.2.3.4, 198.51.100.1"},
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "addresses of trusted proxies chain are skipped",
+			peerAddr:     "10.0.0.1:53124",
+			forwardedFor: []string{"1.2.3.4, 198.51.100.1, 192.168.1.10", "10.0.0.2"},
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "invalid forwarded address stops the chain",
+			peerAddr:     "10.0.0.1:53124",
+			forwardedFor: []string{"198.51.100.1, invalid"},
+			expected:     "10.0.0.1",
+		},
+		{
+			name:         "all forwarded addresses are trusted proxies",
+			peerAddr:     "10.0.0.1:53124",
+			forwardedFor: []string{"10.0.0.3"},
+			expected:     "10.0.0.3",
+		},
+		{
+			name:     "IPv4-mapped IPv6 peer address",
+			peerAddr: "[::ffff:203.0.113.5]:53124",
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "invalid peer address",
+			peerAddr: "invalid",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, resolver.ClientIP(tc.peerAddr, tc.forwardedFor))
+		})
+	}
+}
+
+func Test_NewResolver_InvalidTrustedProxy(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewResolver([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = NewResolver([]string{"proxy"})
+	assert.Error(t, err)
+}
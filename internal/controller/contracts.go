@@ -2,11 +2,64 @@ package controller
 
 import (
 	"context"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/p1xray/pxr-sso/internal/dto"
 	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/addaudience"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/create"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/removeaudience"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/rotatesecret"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/setdefaultroles"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/update"
+	permissioncreate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/create"
+	permissionlist "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/list"
+	permissionremove "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/remove"
+	permissionsetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/setactive"
+	permissionupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/attachpermission"
+	rolecreate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/create"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/detachpermission"
+	rolelist "github.com/p1xray/pxr-sso/internal/usecase/admin/role/list"
+	roleremove "github.com/p1xray/pxr-sso/internal/usecase/admin/role/remove"
+	rolesetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/role/setactive"
+	roleupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantrole"
+	userlist "github.com/p1xray/pxr-sso/internal/usecase/admin/user/list"
+	userremove "github.com/p1xray/pxr-sso/internal/usecase/admin/user/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/resetpassword"
+	userrestore "github.com/p1xray/pxr-sso/internal/usecase/admin/user/restore"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokeclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokerole"
+	usersetblocked "github.com/p1xray/pxr-sso/internal/usecase/admin/user/setblocked"
+	userunlock "github.com/p1xray/pxr-sso/internal/usecase/admin/user/unlock"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/authorize"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/changepassword"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/confirmpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/credentials"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/exchange"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/logout"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/refresh"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/register"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/requestpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/verifymfa"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/confirmtotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/disabletotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/enrolltotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/regeneratebackupcodes"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/confirmverification"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/requestverification"
+	profileupdate "github.com/p1xray/pxr-sso/internal/usecase/profile/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revoke"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revokeall"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/introspect"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginregistration"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishregistration"
 )
 
 type (
@@ -34,9 +87,375 @@ type (
 		Execute(ctx context.Context, data logout.Params) error
 	}
 
+	// Authorize is a use-case for authorizing a client on behalf of a user by the authorization code flow.
+	Authorize interface {
+		// Execute executes the use-case for authorizing a client on behalf of a user.
+		// If successful, a new authorization code is returned.
+		Execute(ctx context.Context, data authorize.Params) (string, error)
+	}
+
+	// ExchangeAuthorizationCode is a use-case for exchanging the authorization code for user tokens.
+	ExchangeAuthorizationCode interface {
+		// Execute executes the use-case for exchanging the authorization code for user tokens.
+		// If successful, new tokens are returned.
+		Execute(ctx context.Context, data exchange.Params) (entity.Tokens, error)
+	}
+
+	// ClientCredentials is a use-case for issuing service-to-service tokens by the client credentials grant.
+	ClientCredentials interface {
+		// Execute executes the use-case for issuing client tokens. If successful, a new access token is returned.
+		Execute(ctx context.Context, data credentials.Params) (entity.Tokens, error)
+	}
+
+	// ListSessions is a use-case for getting the list of user sessions.
+	ListSessions interface {
+		// Execute executes the use-case for getting the list of user sessions.
+		Execute(ctx context.Context, userID int64) ([]entity.Session, error)
+	}
+
+	// RevokeSession is a use-case for revoking a user session.
+	RevokeSession interface {
+		// Execute executes the use-case for revoking a user session.
+		Execute(ctx context.Context, data revoke.Params) error
+	}
+
+	// RevokeAllSessions is a use-case for revoking all user sessions.
+	RevokeAllSessions interface {
+		// Execute executes the use-case for revoking all user sessions. If successful, the number of revoked
+		// sessions is returned.
+		Execute(ctx context.Context, data revokeall.Params) (int, error)
+	}
+
+	// Introspect is a use-case for introspecting access and refresh tokens.
+	Introspect interface {
+		// Execute executes the use-case for introspecting a token. If successful, the state of the token is returned.
+		Execute(ctx context.Context, data introspect.Params) (entity.Introspection, error)
+	}
+
+	// VerifyAccessToken is a use-case for verifying the access token of the caller of the protected API.
+	VerifyAccessToken interface {
+		// Execute executes the use-case for verifying an access token.
+		// If successful, the state of the access token is returned.
+		Execute(ctx context.Context, data verify.Params) (entity.Introspection, error)
+	}
+
+	// CreateClient is a use-case for creating a new client.
+	CreateClient interface {
+		// Execute executes the use-case for creating a new client. If successful, the created client is returned.
+		Execute(ctx context.Context, data create.Params) (entity.Client, error)
+	}
+
+	// UpdateClient is a use-case for updating a client.
+	UpdateClient interface {
+		// Execute executes the use-case for updating a client. If successful, the updated client is returned.
+		Execute(ctx context.Context, data update.Params) (entity.Client, error)
+	}
+
+	// RemoveClient is a use-case for removing a client.
+	RemoveClient interface {
+		// Execute executes the use-case for removing a client.
+		Execute(ctx context.Context, data remove.Params) error
+	}
+
+	// ListClients is a use-case for getting the list of clients.
+	ListClients interface {
+		// Execute executes the use-case for getting the list of clients.
+		Execute(ctx context.Context) ([]entity.Client, error)
+	}
+
+	// RotateClientSecret is a use-case for rotating the secret key of a client.
+	RotateClientSecret interface {
+		// Execute executes the use-case for rotating the secret key of a client.
+		// If successful, the client with the new secret key is returned.
+		Execute(ctx context.Context, data rotatesecret.Params) (entity.Client, error)
+	}
+
+	// AddClientAudience is a use-case for adding an audience to a client.
+	AddClientAudience interface {
+		// Execute executes the use-case for adding an audience to a client.
+		// If successful, the updated client is returned.
+		Execute(ctx context.Context, data addaudience.Params) (entity.Client, error)
+	}
+
+	// RemoveClientAudience is a use-case for removing an audience from a client.
+	RemoveClientAudience interface {
+		// Execute executes the use-case for removing an audience from a client.
+		// If successful, the updated client is returned.
+		Execute(ctx context.Context, data removeaudience.Params) (entity.Client, error)
+	}
+
+	// SetClientDefaultRoles is a use-case for setting the roles granted to the users registered with a client.
+	SetClientDefaultRoles interface {
+		// Execute executes the use-case for setting the default roles of a client.
+		// If successful, the updated client is returned.
+		Execute(ctx context.Context, data setdefaultroles.Params) (entity.Client, error)
+	}
+
+	// CreateRole is a use-case for creating a new role.
+	CreateRole interface {
+		// Execute executes the use-case for creating a new role. If successful, the created role is returned.
+		Execute(ctx context.Context, data rolecreate.Params) (entity.Role, error)
+	}
+
+	// UpdateRole is a use-case for updating a role.
+	UpdateRole interface {
+		// Execute executes the use-case for updating a role. If successful, the updated role is returned.
+		Execute(ctx context.Context, data roleupdate.Params) (entity.Role, error)
+	}
+
+	// RemoveRole is a use-case for removing a role.
+	RemoveRole interface {
+		// Execute executes the use-case for removing a role.
+		Execute(ctx context.Context, data roleremove.Params) error
+	}
+
+	// SetRoleActive is a use-case for activating or deactivating a role.
+	SetRoleActive interface {
+		// Execute executes the use-case for activating or deactivating a role.
+		// If successful, the updated role is returned.
+		Execute(ctx context.Context, data rolesetactive.Params) (entity.Role, error)
+	}
+
+	// ListRoles is a use-case for getting the page of the list of roles.
+	ListRoles interface {
+		// Execute executes the use-case for getting the page of the list of roles.
+		// If successful, the roles of the page and the total number of roles are returned.
+		Execute(ctx context.Context, data rolelist.Params) ([]entity.Role, int64, error)
+	}
+
+	// AttachRolePermission is a use-case for attaching a permission to a role.
+	AttachRolePermission interface {
+		// Execute executes the use-case for attaching a permission to a role.
+		// If successful, the updated role is returned.
+		Execute(ctx context.Context, data attachpermission.Params) (entity.Role, error)
+	}
+
+	// DetachRolePermission is a use-case for detaching a permission from a role.
+	DetachRolePermission interface {
+		// Execute executes the use-case for detaching a permission from a role.
+		// If successful, the updated role is returned.
+		Execute(ctx context.Context, data detachpermission.Params) (entity.Role, error)
+	}
+
+	// CreatePermission is a use-case for creating a new permission.
+	CreatePermission interface {
+		// Execute executes the use-case for creating a new permission.
+		// If successful, the created permission is returned.
+		Execute(ctx context.Context, data permissioncreate.Params) (entity.Permission, error)
+	}
+
+	// UpdatePermission is a use-case for updating a permission.
+	UpdatePermission interface {
+		// Execute executes the use-case for updating a permission.
+		// If successful, the updated permission is returned.
+		Execute(ctx context.Context, data permissionupdate.Params) (entity.Permission, error)
+	}
+
+	// RemovePermission is a use-case for removing a permission.
+	RemovePermission interface {
+		// Execute executes the use-case for removing a permission.
+		Execute(ctx context.Context, data permissionremove.Params) error
+	}
+
+	// SetPermissionActive is a use-case for activating or deactivating a permission.
+	SetPermissionActive interface {
+		// Execute executes the use-case for activating or deactivating a permission.
+		// If successful, the updated permission is returned.
+		Execute(ctx context.Context, data permissionsetactive.Params) (entity.Permission, error)
+	}
+
+	// ListPermissions is a use-case for getting the page of the list of permissions.
+	ListPermissions interface {
+		// Execute executes the use-case for getting the page of the list of permissions.
+		// If successful, the permissions of the page and the total number of permissions are returned.
+		Execute(ctx context.Context, data permissionlist.Params) ([]entity.Permission, int64, error)
+	}
+
+	// ListUsers is a use-case for searching the users.
+	ListUsers interface {
+		// Execute executes the use-case for searching the users by the username or the full name.
+		// If successful, the users of the page and the total number of found users are returned.
+		Execute(ctx context.Context, data userlist.Params) ([]entity.UserAccount, int64, error)
+	}
+
+	// GrantUserRole is a use-case for granting a role to a user.
+	GrantUserRole interface {
+		// Execute executes the use-case for granting a role to a user.
+		// If successful, the updated user account is returned.
+		Execute(ctx context.Context, data grantrole.Params) (entity.UserAccount, error)
+	}
+
+	// RevokeUserRole is a use-case for revoking a role from a user.
+	RevokeUserRole interface {
+		// Execute executes the use-case for revoking a role from a user.
+		// If successful, the updated user account is returned.
+		Execute(ctx context.Context, data revokerole.Params) (entity.UserAccount, error)
+	}
+
+	// GrantUserClient is a use-case for giving a user access to a client.
+	GrantUserClient interface {
+		// Execute executes the use-case for giving a user access to a client.
+		// If successful, the updated user account is returned.
+		Execute(ctx context.Context, data grantclient.Params) (entity.UserAccount, error)
+	}
+
+	// RevokeUserClient is a use-case for taking away a user access to a client.
+	RevokeUserClient interface {
+		// Execute executes the use-case for taking away a user access to a client.
+		// If successful, the updated user account is returned.
+		Execute(ctx context.Context, data revokeclient.Params) (entity.UserAccount, error)
+	}
+
+	// SetUserBlocked is a use-case for blocking or unblocking a user.
+	SetUserBlocked interface {
+		// Execute executes the use-case for blocking or unblocking a user.
+		// If successful, the updated user account is returned.
+		Execute(ctx context.Context, data usersetblocked.Params) (entity.UserAccount, error)
+	}
+
+	// RemoveUser is a use-case for removing a user.
+	RemoveUser interface {
+		// Execute executes the use-case for removing a user.
+		Execute(ctx context.Context, data userremove.Params) error
+	}
+
+	// RestoreUser is a use-case for restoring a deleted user.
+	RestoreUser interface {
+		// Execute executes the use-case for restoring a deleted user.
+		// If successful, the restored user account is returned.
+		Execute(ctx context.Context, data userrestore.Params) (entity.UserAccount, error)
+	}
+
+	// ResetUserPassword is a use-case for resetting the user password by the administrator.
+	ResetUserPassword interface {
+		// Execute executes the use-case for resetting the user password by the administrator.
+		// If successful, the updated user account and the temporary password are returned.
+		Execute(ctx context.Context, data resetpassword.Params) (entity.UserAccount, string, error)
+	}
+
+	// UnlockUser is a use-case for unlocking a user locked after too many failed login attempts.
+	UnlockUser interface {
+		// Execute executes the use-case for unlocking a user locked after too many failed login attempts.
+		// If successful, the user account is returned.
+		Execute(ctx context.Context, data userunlock.Params) (entity.UserAccount, error)
+	}
+
 	// UserProfile is a use-case for getting user profile data.
 	UserProfile interface {
 		// Execute executes the use-case for getting user profile data.
 		Execute(ctx context.Context, id int64) (entity.User, error)
 	}
+
+	// UpdateUserProfile is a use-case for updating user profile data.
+	UpdateUserProfile interface {
+		// Execute executes the use-case for updating user profile data.
+		// If successful, the updated user profile is returned.
+		Execute(ctx context.Context, data profileupdate.Params) (entity.User, error)
+	}
+
+	// ChangePassword is a use-case for changing the user password.
+	ChangePassword interface {
+		// Execute executes the use-case for changing the user password.
+		// If successful, the number of revoked sessions is returned.
+		Execute(ctx context.Context, data changepassword.Params) (int, error)
+	}
+
+	// RequestPasswordReset is a use-case for requesting the password reset.
+	RequestPasswordReset interface {
+		// Execute executes the use-case for requesting the password reset.
+		Execute(ctx context.Context, data requestpasswordreset.Params) error
+	}
+
+	// ConfirmPasswordReset is a use-case for resetting the forgotten password by the password reset token.
+	ConfirmPasswordReset interface {
+		// Execute executes the use-case for resetting the forgotten password by the password reset token.
+		// If successful, the number of revoked sessions is returned.
+		Execute(ctx context.Context, data confirmpasswordreset.Params) (int, error)
+	}
+
+	// RequestContactVerification is a use-case for requesting the verification of the user contact.
+	RequestContactVerification interface {
+		// Execute executes the use-case for requesting the verification of the user contact.
+		Execute(ctx context.Context, data requestverification.Params) error
+	}
+
+	// ConfirmContactVerification is a use-case for confirming the verification of the user contact.
+	ConfirmContactVerification interface {
+		// Execute executes the use-case for confirming the verification of the user contact.
+		Execute(ctx context.Context, data confirmverification.Params) error
+	}
+
+	// VerifyMFA is a use-case for completing the login by the second authentication factor.
+	VerifyMFA interface {
+		// Execute executes the use-case for completing the login by the second authentication factor.
+		// If successful, new tokens are returned.
+		Execute(ctx context.Context, data verifymfa.Params) (entity.Tokens, error)
+	}
+
+	// EnrollTOTP is a use-case for enrolling the TOTP credential of the user.
+	EnrollTOTP interface {
+		// Execute executes the use-case for enrolling the TOTP credential of the user.
+		// If successful, the secret and its otpauth:// URI are returned.
+		Execute(ctx context.Context, data enrolltotp.Params) (entity.TOTPEnrollment, error)
+	}
+
+	// ConfirmTOTP is a use-case for confirming the enrolled TOTP credential of the user.
+	ConfirmTOTP interface {
+		// Execute executes the use-case for confirming the enrolled TOTP credential of the user.
+		// If successful, the backup codes are returned.
+		Execute(ctx context.Context, data confirmtotp.Params) ([]string, error)
+	}
+
+	// DisableTOTP is a use-case for disabling the TOTP credential of the user.
+	DisableTOTP interface {
+		// Execute executes the use-case for disabling the TOTP credential of the user.
+		Execute(ctx context.Context, data disabletotp.Params) error
+	}
+
+	// RegenerateBackupCodes is a use-case for regenerating the backup codes of the user.
+	RegenerateBackupCodes interface {
+		// Execute executes the use-case for regenerating the backup codes of the user.
+		// If successful, the new backup codes are returned.
+		Execute(ctx context.Context, data regeneratebackupcodes.Params) ([]string, error)
+	}
+
+	// BeginWebAuthnRegistration is a use-case for starting the registration of the passkey of the user.
+	BeginWebAuthnRegistration interface {
+		// Execute executes the use-case for starting the registration of the passkey of the user.
+		// If successful, the options of the credential creation are returned.
+		Execute(ctx context.Context, data beginregistration.Params) (entity.WebAuthnCreationOptions, error)
+	}
+
+	// FinishWebAuthnRegistration is a use-case for completing the registration of the passkey of the user.
+	FinishWebAuthnRegistration interface {
+		// Execute executes the use-case for completing the registration of the passkey of the user.
+		// If successful, the registered credential is returned.
+		Execute(ctx context.Context, data finishregistration.Params) (entity.WebAuthnCredential, error)
+	}
+
+	// BeginWebAuthnLogin is a use-case for starting the passwordless login by the passkey.
+	BeginWebAuthnLogin interface {
+		// Execute executes the use-case for starting the passwordless login by the passkey.
+		// If successful, the options of the credential assertion are returned.
+		Execute(ctx context.Context, data beginlogin.Params) (entity.WebAuthnRequestOptions, error)
+	}
+
+	// FinishWebAuthnLogin is a use-case for completing the passwordless login by the passkey.
+	FinishWebAuthnLogin interface {
+		// Execute executes the use-case for completing the passwordless login by the passkey.
+		// If successful, new tokens are returned.
+		Execute(ctx context.Context, data finishlogin.Params) (entity.Tokens, error)
+	}
+
+	// PublicKeys is a use-case for getting the public keys for verifying access tokens.
+	PublicKeys interface {
+		// Execute executes the use-case for getting the public keys for verifying access tokens.
+		Execute(ctx context.Context) (jose.JSONWebKeySet, error)
+	}
+
+	// OpenIDConfiguration is a use-case for getting the OpenID Connect discovery document.
+	OpenIDConfiguration interface {
+		// Execute executes the use-case for getting the OpenID Connect discovery document.
+		Execute(ctx context.Context) (dto.OpenIDConfiguration, error)
+	}
 )
@@ -0,0 +1,53 @@
+package request
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"strings"
+)
+
+const (
+	// authorizationMetadataKey is the request metadata key with the access token of the caller.
+	authorizationMetadataKey = "authorization"
+	// bearerPrefix is the authentication scheme of the access token in the authorization metadata.
+	bearerPrefix = "Bearer "
+	// forwardedForMetadataKey is the request metadata key with the addresses of the client and the proxies
+	// the request has passed through, set by the proxies in front of the server.
+	forwardedForMetadataKey = "x-forwarded-for"
+)
+
+// AccessTokenFromContext returns the access token of the caller from the request metadata.
+// If the metadata has no bearer token, an empty string is returned.
+func AccessTokenFromContext(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, authorizationMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	if len(values[0]) < len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0][len(bearerPrefix):])
+}
+
+// ClientIPFromContext returns the IP address of the client. The address of the peer is used unless the peer
+// is a trusted proxy of the resolver, only then the "x-forwarded-for" request metadata is used.
+// The empty string is returned if the address is unknown.
+func ClientIPFromContext(ctx context.Context, resolver *clientip.Resolver) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return resolver.ClientIP(p.Addr.String(), metadata.ValueFromIncomingContext(ctx, forwardedForMetadataKey))
+}
+
+// IssuerMatches reports whether the issuer passed by the client is the issuer of the server.
+// The tokens are always issued by the issuer of the server, so the client may leave the issuer empty.
+// The trailing slash of the issuers is ignored.
+func IssuerMatches(issuer, requestIssuer string) bool {
+	return requestIssuer == "" || strings.TrimSuffix(requestIssuer, "/") == strings.TrimSuffix(issuer, "/")
+}
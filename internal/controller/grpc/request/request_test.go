@@ -0,0 +1,104 @@
+package request
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"testing"
+)
+
+func Test_ClientIPFromContext(t *testing.T) {
+	resolver, err := clientip.NewResolver([]string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		peerAddr     net.Addr
+		forwardedFor string
+		expected     string
+	}{
+		{
+			name:     "address of the peer",
+			peerAddr: &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 53124},
+			expected: "203.0.113.5",
+		},
+		{
+			name:         "spoofed header of the client is ignored",
+			peerAddr:     &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 53124},
+			forwardedFor: "198.51.100.1",
+			expected:     "203.0.113.5",
+		},
+		{
+			name:         "address forwarded by the trusted proxy",
+			peerAddr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 53124},
+			forwardedFor: "198.51.100.1",
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "spoofed address forwarded by the trusted proxy is ignored",
+			peerAddr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 53124},
+			forwardedFor: "1.2.3.4, 198.51.100.1",
+			expected:     "198.51.100.1",
+		},
+		{
+			name: "unknown peer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tc.peerAddr != nil {
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: tc.peerAddr})
+			}
+			if tc.forwardedFor != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(forwardedForMetadataKey, tc.forwardedFor))
+			}
+
+			assert.Equal(t, tc.expected, ClientIPFromContext(ctx, resolver))
+		})
+	}
+}
+
+func Test_IssuerMatches(t *testing.T) {
+	const issuer = "https://sso.example.com"
+
+	testCases := []struct {
+		name          string
+		requestIssuer string
+		expected      bool
+	}{
+		{
+			name:          "issuer of the server",
+			requestIssuer: issuer,
+			expected:      true,
+		},
+		{
+			name:          "issuer of the server with the trailing slash",
+			requestIssuer: issuer + "/",
+			expected:      true,
+		},
+		{
+			name:     "empty issuer",
+			expected: true,
+		},
+		{
+			name:          "another issuer",
+			requestIssuer: "https://attacker.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, IssuerMatches(issuer, tc.requestIssuer))
+		})
+	}
+}
@@ -1,10 +1,23 @@
 package response
 
 import (
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// errorDomain is the domain of the errdetails.ErrorInfo details of the errors.
+	errorDomain = "pxr-sso"
+	// MFARequiredReason is the reason of the error of the login which must be completed
+	// by the second authentication factor.
+	MFARequiredReason = "MFA_REQUIRED"
+	// MFATokenMetadataKey is the errdetails.ErrorInfo metadata key with the MFA token of the login.
+	MFATokenMetadataKey = "mfa_token"
+)
+
 // InvalidArgumentError returns an error with gRPC code InvalidArgument and message.
 func InvalidArgumentError(msg string) error {
 	return status.Error(codes.InvalidArgument, msg)
@@ -19,3 +32,71 @@ func InternalError(msg string) error {
 func NotFoundError(msg string) error {
 	return status.Error(codes.NotFound, msg)
 }
+
+// ResourceExhaustedError returns an error with gRPC code ResourceExhausted and message.
+func ResourceExhaustedError(msg string) error {
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// UnauthenticatedError returns an error with gRPC code Unauthenticated and message.
+func UnauthenticatedError(msg string) error {
+	return status.Error(codes.Unauthenticated, msg)
+}
+
+// PermissionDeniedError returns an error with gRPC code PermissionDenied and message.
+func PermissionDeniedError(msg string) error {
+	return status.Error(codes.PermissionDenied, msg)
+}
+
+// FailedPreconditionError returns an error with gRPC code FailedPrecondition and message.
+func FailedPreconditionError(msg string) error {
+	return status.Error(codes.FailedPrecondition, msg)
+}
+
+// WeakPasswordError returns an error with gRPC code InvalidArgument and message. The rules of the password policy
+// the password of the request field violates are sent in the errdetails.BadRequest details of the error.
+func WeakPasswordError(msg, field string, err error) error {
+	st := status.New(codes.InvalidArgument, msg)
+
+	var policyErr *entity.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(policyErr.Violations)),
+	}
+	for i, violation := range policyErr.Violations {
+		badRequest.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: violation.Description,
+			Reason:      string(violation.Reason),
+		}
+	}
+
+	detailed, detailsErr := st.WithDetails(badRequest)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// MFARequiredError returns an error with gRPC code FailedPrecondition and message. The MFA token of the login
+// is sent in the errdetails.ErrorInfo details of the error with the MFARequiredReason reason.
+func MFARequiredError(msg, mfaToken string) error {
+	st := status.New(codes.FailedPrecondition, msg)
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason:   MFARequiredReason,
+		Domain:   errorDomain,
+		Metadata: map[string]string{MFATokenMetadataKey: mfaToken},
+	}
+
+	detailed, err := st.WithDetails(errorInfo)
+	if err != nil {
+		return InternalError("failed to send MFA token")
+	}
+
+	return detailed.Err()
+}
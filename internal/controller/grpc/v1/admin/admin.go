@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	ssoadminpb "github.com/p1xray/pxr-sso/api/gen/go/admin"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	"google.golang.org/grpc"
+)
+
+const (
+	emptyID     = 0
+	maxPageSize = 100
+)
+
+type serverAPI struct {
+	ssoadminpb.UnimplementedSsoAdminServer
+	verifyAccessTokenUseCase     controller.VerifyAccessToken
+	createClientUseCase          controller.CreateClient
+	updateClientUseCase          controller.UpdateClient
+	removeClientUseCase          controller.RemoveClient
+	listClientsUseCase           controller.ListClients
+	rotateClientSecretUseCase    controller.RotateClientSecret
+	addClientAudienceUseCase     controller.AddClientAudience
+	removeClientAudienceUseCase  controller.RemoveClientAudience
+	setClientDefaultRolesUseCase controller.SetClientDefaultRoles
+	createRoleUseCase            controller.CreateRole
+	updateRoleUseCase            controller.UpdateRole
+	removeRoleUseCase            controller.RemoveRole
+	setRoleActiveUseCase         controller.SetRoleActive
+	listRolesUseCase             controller.ListRoles
+	attachRolePermissionUseCase  controller.AttachRolePermission
+	detachRolePermissionUseCase  controller.DetachRolePermission
+	createPermissionUseCase      controller.CreatePermission
+	updatePermissionUseCase      controller.UpdatePermission
+	removePermissionUseCase      controller.RemovePermission
+	setPermissionActiveUseCase   controller.SetPermissionActive
+	listPermissionsUseCase       controller.ListPermissions
+	listUsersUseCase             controller.ListUsers
+	grantUserRoleUseCase         controller.GrantUserRole
+	revokeUserRoleUseCase        controller.RevokeUserRole
+	grantUserClientUseCase       controller.GrantUserClient
+	revokeUserClientUseCase      controller.RevokeUserClient
+	setUserBlockedUseCase        controller.SetUserBlocked
+	removeUserUseCase            controller.RemoveUser
+	restoreUserUseCase           controller.RestoreUser
+	resetUserPasswordUseCase     controller.ResetUserPassword
+	unlockUserUseCase            controller.UnlockUser
+}
+
+// RegisterAdminServer registers the implementation of the API service with the gRPC server.
+func RegisterAdminServer(
+	server *grpc.Server,
+	verifyAccessTokenUseCase controller.VerifyAccessToken,
+	createClientUseCase controller.CreateClient,
+	updateClientUseCase controller.UpdateClient,
+	removeClientUseCase controller.RemoveClient,
+	listClientsUseCase controller.ListClients,
+	rotateClientSecretUseCase controller.RotateClientSecret,
+	addClientAudienceUseCase controller.AddClientAudience,
+	removeClientAudienceUseCase controller.RemoveClientAudience,
+	setClientDefaultRolesUseCase controller.SetClientDefaultRoles,
+	createRoleUseCase controller.CreateRole,
+	updateRoleUseCase controller.UpdateRole,
+	removeRoleUseCase controller.RemoveRole,
+	setRoleActiveUseCase controller.SetRoleActive,
+	listRolesUseCase controller.ListRoles,
+	attachRolePermissionUseCase controller.AttachRolePermission,
+	detachRolePermissionUseCase controller.DetachRolePermission,
+	createPermissionUseCase controller.CreatePermission,
+	updatePermissionUseCase controller.UpdatePermission,
+	removePermissionUseCase controller.RemovePermission,
+	setPermissionActiveUseCase controller.SetPermissionActive,
+	listPermissionsUseCase controller.ListPermissions,
+	listUsersUseCase controller.ListUsers,
+	grantUserRoleUseCase controller.GrantUserRole,
+	revokeUserRoleUseCase controller.RevokeUserRole,
+	grantUserClientUseCase controller.GrantUserClient,
+	revokeUserClientUseCase controller.RevokeUserClient,
+	setUserBlockedUseCase controller.SetUserBlocked,
+	removeUserUseCase controller.RemoveUser,
+	restoreUserUseCase controller.RestoreUser,
+	resetUserPasswordUseCase controller.ResetUserPassword,
+	unlockUserUseCase controller.UnlockUser,
+) {
+	ssoadminpb.RegisterSsoAdminServer(server, &serverAPI{
+		verifyAccessTokenUseCase:     verifyAccessTokenUseCase,
+		createClientUseCase:          createClientUseCase,
+		updateClientUseCase:          updateClientUseCase,
+		removeClientUseCase:          removeClientUseCase,
+		listClientsUseCase:           listClientsUseCase,
+		rotateClientSecretUseCase:    rotateClientSecretUseCase,
+		addClientAudienceUseCase:     addClientAudienceUseCase,
+		removeClientAudienceUseCase:  removeClientAudienceUseCase,
+		setClientDefaultRolesUseCase: setClientDefaultRolesUseCase,
+		createRoleUseCase:            createRoleUseCase,
+		updateRoleUseCase:            updateRoleUseCase,
+		removeRoleUseCase:            removeRoleUseCase,
+		setRoleActiveUseCase:         setRoleActiveUseCase,
+		listRolesUseCase:             listRolesUseCase,
+		attachRolePermissionUseCase:  attachRolePermissionUseCase,
+		detachRolePermissionUseCase:  detachRolePermissionUseCase,
+		createPermissionUseCase:      createPermissionUseCase,
+		updatePermissionUseCase:      updatePermissionUseCase,
+		removePermissionUseCase:      removePermissionUseCase,
+		setPermissionActiveUseCase:   setPermissionActiveUseCase,
+		listPermissionsUseCase:       listPermissionsUseCase,
+		listUsersUseCase:             listUsersUseCase,
+		grantUserRoleUseCase:         grantUserRoleUseCase,
+		revokeUserRoleUseCase:        revokeUserRoleUseCase,
+		grantUserClientUseCase:       grantUserClientUseCase,
+		revokeUserClientUseCase:      revokeUserClientUseCase,
+		setUserBlockedUseCase:        setUserBlockedUseCase,
+		removeUserUseCase:            removeUserUseCase,
+		restoreUserUseCase:           restoreUserUseCase,
+		resetUserPasswordUseCase:     resetUserPasswordUseCase,
+		unlockUserUseCase:            unlockUserUseCase,
+	})
+}
+
+// authorize checks that the access token of the caller is valid and grants the admin permission.
+func (s *serverAPI) authorize(ctx context.Context) error {
+	accessToken := request.AccessTokenFromContext(ctx)
+	if accessToken == "" {
+		return response.UnauthenticatedError("access token is empty")
+	}
+
+	verifyData := verify.Params{
+		AccessToken:        accessToken,
+		RequiredPermission: entity.PermissionAdmin,
+	}
+
+	if _, err := s.verifyAccessTokenUseCase.Execute(ctx, verifyData); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken):
+			return response.UnauthenticatedError("invalid access token")
+		case errors.Is(err, usecase.ErrPermissionDenied):
+			return response.PermissionDeniedError("admin permission is not granted")
+		default:
+			return response.InternalError("failed to verify access token")
+		}
+	}
+
+	return nil
+}
+
+// validatePage checks that the page number and the page size of the list request are valid.
+func validatePage(page, pageSize int32) error {
+	if page < 1 {
+		return response.InvalidArgumentError("page must be greater than zero")
+	}
+
+	if pageSize < 1 || pageSize > maxPageSize {
+		return response.InvalidArgumentError("page size must be between 1 and 100")
+	}
+
+	return nil
+}
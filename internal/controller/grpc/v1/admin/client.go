@@ -0,0 +1,359 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	ssoadminpb "github.com/p1xray/pxr-sso/api/gen/go/admin"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/addaudience"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/create"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/removeaudience"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/rotatesecret"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/setdefaultroles"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/client/update"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// CreateClient is a gRPC handler for creating a new client.
+func (s *serverAPI) CreateClient(
+	ctx context.Context,
+	req *ssoadminpb.CreateClientRequest,
+) (*ssoadminpb.CreateClientResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateCreateClientRequest(req); err != nil {
+		return nil, err
+	}
+
+	createClientData := create.Params{
+		Name:                  req.GetName(),
+		Code:                  req.GetCode(),
+		MaxSessions:           int32FromPb(req.GetMaxSessions()),
+		SessionEvictionPolicy: sessionEvictionPolicyFromPb(req.GetSessionEvictionPolicy()),
+		Audiences:             req.GetAudiences(),
+		RequireVerifiedEmail:  req.GetRequireVerifiedEmail(),
+		RequireMFA:            req.GetRequireMfa(),
+	}
+
+	client, err := s.createClientUseCase.Execute(ctx, createClientData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientExists):
+			return nil, response.InvalidArgumentError("client with this code already exists")
+		case errors.Is(err, usecase.ErrInvalidSessionLimit):
+			return nil, response.InvalidArgumentError("invalid session limit")
+		case errors.Is(err, usecase.ErrClientAudienceExists):
+			return nil, response.InvalidArgumentError("duplicate client audience")
+		default:
+			return nil, response.InternalError("failed to create client")
+		}
+	}
+
+	return &ssoadminpb.CreateClientResponse{Client: clientToPb(client), SecretKey: client.SecretKey}, nil
+}
+
+// UpdateClient is a gRPC handler for updating a client.
+func (s *serverAPI) UpdateClient(
+	ctx context.Context,
+	req *ssoadminpb.UpdateClientRequest,
+) (*ssoadminpb.UpdateClientResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUpdateClientRequest(req); err != nil {
+		return nil, err
+	}
+
+	updateClientData := update.Params{
+		ID:                    req.GetId(),
+		Name:                  req.GetName(),
+		MaxSessions:           int32FromPb(req.GetMaxSessions()),
+		SessionEvictionPolicy: sessionEvictionPolicyFromPb(req.GetSessionEvictionPolicy()),
+		RequireVerifiedEmail:  req.GetRequireVerifiedEmail(),
+		RequireMFA:            req.GetRequireMfa(),
+	}
+
+	client, err := s.updateClientUseCase.Execute(ctx, updateClientData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrInvalidSessionLimit):
+			return nil, response.InvalidArgumentError("invalid session limit")
+		default:
+			return nil, response.InternalError("failed to update client")
+		}
+	}
+
+	return &ssoadminpb.UpdateClientResponse{Client: clientToPb(client)}, nil
+}
+
+// DeleteClient is a gRPC handler for removing a client.
+func (s *serverAPI) DeleteClient(
+	ctx context.Context,
+	req *ssoadminpb.DeleteClientRequest,
+) (*ssoadminpb.DeleteClientResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("client id is empty")
+	}
+
+	if err := s.removeClientUseCase.Execute(ctx, remove.Params{ID: req.GetId()}); err != nil {
+		if errors.Is(err, usecase.ErrClientNotFound) {
+			return nil, response.NotFoundError("client not found")
+		}
+
+		return nil, response.InternalError("failed to delete client")
+	}
+
+	return &ssoadminpb.DeleteClientResponse{}, nil
+}
+
+// ListClients is a gRPC handler for getting the list of clients.
+func (s *serverAPI) ListClients(
+	ctx context.Context,
+	_ *ssoadminpb.ListClientsRequest,
+) (*ssoadminpb.ListClientsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	clients, err := s.listClientsUseCase.Execute(ctx)
+	if err != nil {
+		return nil, response.InternalError("failed to list clients")
+	}
+
+	clientsPb := make([]*ssoadminpb.Client, len(clients))
+	for i, client := range clients {
+		clientsPb[i] = clientToPb(client)
+	}
+
+	return &ssoadminpb.ListClientsResponse{Clients: clientsPb}, nil
+}
+
+// RotateClientSecret is a gRPC handler for rotating the secret key of a client.
+func (s *serverAPI) RotateClientSecret(
+	ctx context.Context,
+	req *ssoadminpb.RotateClientSecretRequest,
+) (*ssoadminpb.RotateClientSecretResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("client id is empty")
+	}
+
+	client, err := s.rotateClientSecretUseCase.Execute(ctx, rotatesecret.Params{ID: req.GetId()})
+	if err != nil {
+		if errors.Is(err, usecase.ErrClientNotFound) {
+			return nil, response.NotFoundError("client not found")
+		}
+
+		return nil, response.InternalError("failed to rotate client secret")
+	}
+
+	return &ssoadminpb.RotateClientSecretResponse{SecretKey: client.SecretKey}, nil
+}
+
+// AddClientAudience is a gRPC handler for adding an audience to a client.
+func (s *serverAPI) AddClientAudience(
+	ctx context.Context,
+	req *ssoadminpb.AddClientAudienceRequest,
+) (*ssoadminpb.AddClientAudienceResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateClientAudience(req.GetClientId(), req.GetAudience()); err != nil {
+		return nil, err
+	}
+
+	addAudienceData := addaudience.Params{
+		ClientID: req.GetClientId(),
+		URL:      req.GetAudience(),
+	}
+
+	client, err := s.addClientAudienceUseCase.Execute(ctx, addAudienceData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrClientAudienceExists):
+			return nil, response.InvalidArgumentError("client audience already exists")
+		default:
+			return nil, response.InternalError("failed to add client audience")
+		}
+	}
+
+	return &ssoadminpb.AddClientAudienceResponse{Client: clientToPb(client)}, nil
+}
+
+// RemoveClientAudience is a gRPC handler for removing an audience from a client.
+func (s *serverAPI) RemoveClientAudience(
+	ctx context.Context,
+	req *ssoadminpb.RemoveClientAudienceRequest,
+) (*ssoadminpb.RemoveClientAudienceResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateClientAudience(req.GetClientId(), req.GetAudience()); err != nil {
+		return nil, err
+	}
+
+	removeAudienceData := removeaudience.Params{
+		ClientID: req.GetClientId(),
+		URL:      req.GetAudience(),
+	}
+
+	client, err := s.removeClientAudienceUseCase.Execute(ctx, removeAudienceData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrClientAudienceNotFound):
+			return nil, response.NotFoundError("client audience not found")
+		default:
+			return nil, response.InternalError("failed to remove client audience")
+		}
+	}
+
+	return &ssoadminpb.RemoveClientAudienceResponse{Client: clientToPb(client)}, nil
+}
+
+// SetClientDefaultRoles is a gRPC handler for setting the roles granted to the users registered with a client.
+func (s *serverAPI) SetClientDefaultRoles(
+	ctx context.Context,
+	req *ssoadminpb.SetClientDefaultRolesRequest,
+) (*ssoadminpb.SetClientDefaultRolesResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetClientId() == emptyID {
+		return nil, response.InvalidArgumentError("client id is empty")
+	}
+
+	setDefaultRolesData := setdefaultroles.Params{
+		ClientID:  req.GetClientId(),
+		RoleCodes: req.GetRoleCodes(),
+	}
+
+	client, err := s.setClientDefaultRolesUseCase.Execute(ctx, setDefaultRolesData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrRoleNotFound):
+			return nil, response.NotFoundError("role not found")
+		default:
+			return nil, response.InternalError("failed to set client default roles")
+		}
+	}
+
+	return &ssoadminpb.SetClientDefaultRolesResponse{Client: clientToPb(client)}, nil
+}
+
+func validateCreateClientRequest(req *ssoadminpb.CreateClientRequest) error {
+	if req.GetName() == "" {
+		return response.InvalidArgumentError("client name is empty")
+	}
+
+	if req.GetCode() == "" {
+		return response.InvalidArgumentError("client code is empty")
+	}
+
+	for _, audience := range req.GetAudiences() {
+		if audience == "" {
+			return response.InvalidArgumentError("client audience is empty")
+		}
+	}
+
+	return nil
+}
+
+func validateUpdateClientRequest(req *ssoadminpb.UpdateClientRequest) error {
+	if req.GetId() == emptyID {
+		return response.InvalidArgumentError("client id is empty")
+	}
+
+	if req.GetName() == "" {
+		return response.InvalidArgumentError("client name is empty")
+	}
+
+	return nil
+}
+
+func validateClientAudience(clientID int64, audience string) error {
+	if clientID == emptyID {
+		return response.InvalidArgumentError("client id is empty")
+	}
+
+	if audience == "" {
+		return response.InvalidArgumentError("client audience is empty")
+	}
+
+	return nil
+}
+
+// clientToPb converts the client entity to the API client. The secret key of the client is not included.
+func clientToPb(client entity.Client) *ssoadminpb.Client {
+	defaultRoles := make([]string, len(client.DefaultRoles))
+	for i, role := range client.DefaultRoles {
+		defaultRoles[i] = role.Code
+	}
+
+	clientPb := &ssoadminpb.Client{
+		Id:                   client.ID,
+		Name:                 client.Name,
+		Code:                 client.Code,
+		Audiences:            client.AudienceURLs(),
+		DefaultRoles:         defaultRoles,
+		CreatedAt:            timestamppb.New(client.CreatedAt),
+		UpdatedAt:            timestamppb.New(client.UpdatedAt),
+		RequireVerifiedEmail: client.RequireVerifiedEmail,
+		RequireMfa:           client.RequireMFA,
+	}
+
+	if client.MaxSessions != nil {
+		clientPb.MaxSessions = wrapperspb.Int32(*client.MaxSessions)
+	}
+
+	if client.SessionEvictionPolicy != nil {
+		clientPb.SessionEvictionPolicy = wrapperspb.String(string(*client.SessionEvictionPolicy))
+	}
+
+	return clientPb
+}
+
+func int32FromPb(value *wrapperspb.Int32Value) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	v := value.GetValue()
+
+	return &v
+}
+
+func sessionEvictionPolicyFromPb(value *wrapperspb.StringValue) *enum.SessionEvictionPolicyEnum {
+	if value == nil {
+		return nil
+	}
+
+	policy := enum.SessionEvictionPolicyEnum(value.GetValue())
+
+	return &policy
+}
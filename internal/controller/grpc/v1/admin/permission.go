@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	ssoadminpb "github.com/p1xray/pxr-sso/api/gen/go/admin"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	permissioncreate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/create"
+	permissionlist "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/list"
+	permissionremove "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/remove"
+	permissionsetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/setactive"
+	permissionupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/permission/update"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreatePermission is a gRPC handler for creating a new permission.
+func (s *serverAPI) CreatePermission(
+	ctx context.Context,
+	req *ssoadminpb.CreatePermissionRequest,
+) (*ssoadminpb.CreatePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, response.InvalidArgumentError("permission code is empty")
+	}
+
+	createPermissionData := permissioncreate.Params{
+		Code:        req.GetCode(),
+		Description: req.GetDescription(),
+	}
+
+	permission, err := s.createPermissionUseCase.Execute(ctx, createPermissionData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrPermissionExists) {
+			return nil, response.InvalidArgumentError("permission with this code already exists")
+		}
+
+		return nil, response.InternalError("failed to create permission")
+	}
+
+	return &ssoadminpb.CreatePermissionResponse{Permission: permissionToPb(permission)}, nil
+}
+
+// UpdatePermission is a gRPC handler for updating a permission.
+func (s *serverAPI) UpdatePermission(
+	ctx context.Context,
+	req *ssoadminpb.UpdatePermissionRequest,
+) (*ssoadminpb.UpdatePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("permission id is empty")
+	}
+
+	updatePermissionData := permissionupdate.Params{
+		ID:          req.GetId(),
+		Description: req.GetDescription(),
+	}
+
+	permission, err := s.updatePermissionUseCase.Execute(ctx, updatePermissionData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrPermissionNotFound) {
+			return nil, response.NotFoundError("permission not found")
+		}
+
+		return nil, response.InternalError("failed to update permission")
+	}
+
+	return &ssoadminpb.UpdatePermissionResponse{Permission: permissionToPb(permission)}, nil
+}
+
+// DeletePermission is a gRPC handler for removing a permission.
+func (s *serverAPI) DeletePermission(
+	ctx context.Context,
+	req *ssoadminpb.DeletePermissionRequest,
+) (*ssoadminpb.DeletePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("permission id is empty")
+	}
+
+	if err := s.removePermissionUseCase.Execute(ctx, permissionremove.Params{ID: req.GetId()}); err != nil {
+		if errors.Is(err, usecase.ErrPermissionNotFound) {
+			return nil, response.NotFoundError("permission not found")
+		}
+
+		return nil, response.InternalError("failed to delete permission")
+	}
+
+	return &ssoadminpb.DeletePermissionResponse{}, nil
+}
+
+// ActivatePermission is a gRPC handler for activating a permission.
+func (s *serverAPI) ActivatePermission(
+	ctx context.Context,
+	req *ssoadminpb.ActivatePermissionRequest,
+) (*ssoadminpb.ActivatePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	permission, err := s.setPermissionActive(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.ActivatePermissionResponse{Permission: permissionToPb(permission)}, nil
+}
+
+// DeactivatePermission is a gRPC handler for deactivating a permission.
+func (s *serverAPI) DeactivatePermission(
+	ctx context.Context,
+	req *ssoadminpb.DeactivatePermissionRequest,
+) (*ssoadminpb.DeactivatePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	permission, err := s.setPermissionActive(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.DeactivatePermissionResponse{Permission: permissionToPb(permission)}, nil
+}
+
+// ListPermissions is a gRPC handler for getting the page of the list of permissions.
+func (s *serverAPI) ListPermissions(
+	ctx context.Context,
+	req *ssoadminpb.ListPermissionsRequest,
+) (*ssoadminpb.ListPermissionsResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validatePage(req.GetPage(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+
+	listPermissionsData := permissionlist.Params{
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	permissions, total, err := s.listPermissionsUseCase.Execute(ctx, listPermissionsData)
+	if err != nil {
+		return nil, response.InternalError("failed to list permissions")
+	}
+
+	permissionsPb := make([]*ssoadminpb.Permission, len(permissions))
+	for i, permission := range permissions {
+		permissionsPb[i] = permissionToPb(permission)
+	}
+
+	return &ssoadminpb.ListPermissionsResponse{Permissions: permissionsPb, Total: total}, nil
+}
+
+// setPermissionActive activates or deactivates the permission and maps the errors of the use-case to the gRPC errors.
+func (s *serverAPI) setPermissionActive(ctx context.Context, id int64, active bool) (entity.Permission, error) {
+	if id == emptyID {
+		return entity.Permission{}, response.InvalidArgumentError("permission id is empty")
+	}
+
+	permission, err := s.setPermissionActiveUseCase.Execute(ctx, permissionsetactive.Params{ID: id, Active: active})
+	if err != nil {
+		if errors.Is(err, usecase.ErrPermissionNotFound) {
+			return entity.Permission{}, response.NotFoundError("permission not found")
+		}
+
+		return entity.Permission{}, response.InternalError("failed to set permission active")
+	}
+
+	return permission, nil
+}
+
+func permissionToPb(permission entity.Permission) *ssoadminpb.Permission {
+	return &ssoadminpb.Permission{
+		Id:          permission.ID,
+		Code:        permission.Code,
+		Description: permission.Description,
+		Active:      permission.Active,
+		CreatedAt:   timestamppb.New(permission.CreatedAt),
+		UpdatedAt:   timestamppb.New(permission.UpdatedAt),
+	}
+}
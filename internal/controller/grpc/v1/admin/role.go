@@ -0,0 +1,304 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	ssoadminpb "github.com/p1xray/pxr-sso/api/gen/go/admin"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/attachpermission"
+	rolecreate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/create"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/role/detachpermission"
+	rolelist "github.com/p1xray/pxr-sso/internal/usecase/admin/role/list"
+	roleremove "github.com/p1xray/pxr-sso/internal/usecase/admin/role/remove"
+	rolesetactive "github.com/p1xray/pxr-sso/internal/usecase/admin/role/setactive"
+	roleupdate "github.com/p1xray/pxr-sso/internal/usecase/admin/role/update"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateRole is a gRPC handler for creating a new role.
+func (s *serverAPI) CreateRole(
+	ctx context.Context,
+	req *ssoadminpb.CreateRoleRequest,
+) (*ssoadminpb.CreateRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateCreateRoleRequest(req); err != nil {
+		return nil, err
+	}
+
+	createRoleData := rolecreate.Params{
+		Code:        req.GetCode(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}
+
+	role, err := s.createRoleUseCase.Execute(ctx, createRoleData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrRoleExists) {
+			return nil, response.InvalidArgumentError("role with this code already exists")
+		}
+
+		return nil, response.InternalError("failed to create role")
+	}
+
+	return &ssoadminpb.CreateRoleResponse{Role: roleToPb(role)}, nil
+}
+
+// UpdateRole is a gRPC handler for updating a role.
+func (s *serverAPI) UpdateRole(
+	ctx context.Context,
+	req *ssoadminpb.UpdateRoleRequest,
+) (*ssoadminpb.UpdateRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUpdateRoleRequest(req); err != nil {
+		return nil, err
+	}
+
+	updateRoleData := roleupdate.Params{
+		ID:          req.GetId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}
+
+	role, err := s.updateRoleUseCase.Execute(ctx, updateRoleData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrRoleNotFound) {
+			return nil, response.NotFoundError("role not found")
+		}
+
+		return nil, response.InternalError("failed to update role")
+	}
+
+	return &ssoadminpb.UpdateRoleResponse{Role: roleToPb(role)}, nil
+}
+
+// DeleteRole is a gRPC handler for removing a role.
+func (s *serverAPI) DeleteRole(
+	ctx context.Context,
+	req *ssoadminpb.DeleteRoleRequest,
+) (*ssoadminpb.DeleteRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("role id is empty")
+	}
+
+	if err := s.removeRoleUseCase.Execute(ctx, roleremove.Params{ID: req.GetId()}); err != nil {
+		if errors.Is(err, usecase.ErrRoleNotFound) {
+			return nil, response.NotFoundError("role not found")
+		}
+
+		return nil, response.InternalError("failed to delete role")
+	}
+
+	return &ssoadminpb.DeleteRoleResponse{}, nil
+}
+
+// ActivateRole is a gRPC handler for activating a role.
+func (s *serverAPI) ActivateRole(
+	ctx context.Context,
+	req *ssoadminpb.ActivateRoleRequest,
+) (*ssoadminpb.ActivateRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	role, err := s.setRoleActive(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.ActivateRoleResponse{Role: roleToPb(role)}, nil
+}
+
+// DeactivateRole is a gRPC handler for deactivating a role.
+func (s *serverAPI) DeactivateRole(
+	ctx context.Context,
+	req *ssoadminpb.DeactivateRoleRequest,
+) (*ssoadminpb.DeactivateRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	role, err := s.setRoleActive(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.DeactivateRoleResponse{Role: roleToPb(role)}, nil
+}
+
+// ListRoles is a gRPC handler for getting the page of the list of roles.
+func (s *serverAPI) ListRoles(
+	ctx context.Context,
+	req *ssoadminpb.ListRolesRequest,
+) (*ssoadminpb.ListRolesResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validatePage(req.GetPage(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+
+	listRolesData := rolelist.Params{
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	roles, total, err := s.listRolesUseCase.Execute(ctx, listRolesData)
+	if err != nil {
+		return nil, response.InternalError("failed to list roles")
+	}
+
+	rolesPb := make([]*ssoadminpb.Role, len(roles))
+	for i, role := range roles {
+		rolesPb[i] = roleToPb(role)
+	}
+
+	return &ssoadminpb.ListRolesResponse{Roles: rolesPb, Total: total}, nil
+}
+
+// AttachRolePermission is a gRPC handler for attaching a permission to a role.
+func (s *serverAPI) AttachRolePermission(
+	ctx context.Context,
+	req *ssoadminpb.AttachRolePermissionRequest,
+) (*ssoadminpb.AttachRolePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateRolePermission(req.GetRoleId(), req.GetPermissionId()); err != nil {
+		return nil, err
+	}
+
+	attachPermissionData := attachpermission.Params{
+		RoleID:       req.GetRoleId(),
+		PermissionID: req.GetPermissionId(),
+	}
+
+	role, err := s.attachRolePermissionUseCase.Execute(ctx, attachPermissionData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrRoleNotFound):
+			return nil, response.NotFoundError("role not found")
+		case errors.Is(err, usecase.ErrPermissionNotFound):
+			return nil, response.NotFoundError("permission not found")
+		case errors.Is(err, usecase.ErrRolePermissionExists):
+			return nil, response.InvalidArgumentError("permission is already attached to the role")
+		default:
+			return nil, response.InternalError("failed to attach permission to role")
+		}
+	}
+
+	return &ssoadminpb.AttachRolePermissionResponse{Role: roleToPb(role)}, nil
+}
+
+// DetachRolePermission is a gRPC handler for detaching a permission from a role.
+func (s *serverAPI) DetachRolePermission(
+	ctx context.Context,
+	req *ssoadminpb.DetachRolePermissionRequest,
+) (*ssoadminpb.DetachRolePermissionResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateRolePermission(req.GetRoleId(), req.GetPermissionId()); err != nil {
+		return nil, err
+	}
+
+	detachPermissionData := detachpermission.Params{
+		RoleID:       req.GetRoleId(),
+		PermissionID: req.GetPermissionId(),
+	}
+
+	role, err := s.detachRolePermissionUseCase.Execute(ctx, detachPermissionData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrRoleNotFound):
+			return nil, response.NotFoundError("role not found")
+		case errors.Is(err, usecase.ErrRolePermissionNotFound):
+			return nil, response.NotFoundError("permission is not attached to the role")
+		default:
+			return nil, response.InternalError("failed to detach permission from role")
+		}
+	}
+
+	return &ssoadminpb.DetachRolePermissionResponse{Role: roleToPb(role)}, nil
+}
+
+// setRoleActive activates or deactivates the role and maps the errors of the use-case to the gRPC errors.
+func (s *serverAPI) setRoleActive(ctx context.Context, id int64, active bool) (entity.Role, error) {
+	if id == emptyID {
+		return entity.Role{}, response.InvalidArgumentError("role id is empty")
+	}
+
+	role, err := s.setRoleActiveUseCase.Execute(ctx, rolesetactive.Params{ID: id, Active: active})
+	if err != nil {
+		if errors.Is(err, usecase.ErrRoleNotFound) {
+			return entity.Role{}, response.NotFoundError("role not found")
+		}
+
+		return entity.Role{}, response.InternalError("failed to set role active")
+	}
+
+	return role, nil
+}
+
+func validateCreateRoleRequest(req *ssoadminpb.CreateRoleRequest) error {
+	if req.GetCode() == "" {
+		return response.InvalidArgumentError("role code is empty")
+	}
+
+	if req.GetName() == "" {
+		return response.InvalidArgumentError("role name is empty")
+	}
+
+	return nil
+}
+
+func validateUpdateRoleRequest(req *ssoadminpb.UpdateRoleRequest) error {
+	if req.GetId() == emptyID {
+		return response.InvalidArgumentError("role id is empty")
+	}
+
+	if req.GetName() == "" {
+		return response.InvalidArgumentError("role name is empty")
+	}
+
+	return nil
+}
+
+func validateRolePermission(roleID, permissionID int64) error {
+	if roleID == emptyID {
+		return response.InvalidArgumentError("role id is empty")
+	}
+
+	if permissionID == emptyID {
+		return response.InvalidArgumentError("permission id is empty")
+	}
+
+	return nil
+}
+
+func roleToPb(role entity.Role) *ssoadminpb.Role {
+	return &ssoadminpb.Role{
+		Id:          role.ID,
+		Code:        role.Code,
+		Name:        role.Name,
+		Description: role.Description,
+		Active:      role.Active,
+		Permissions: role.PermissionCodes(),
+		CreatedAt:   timestamppb.New(role.CreatedAt),
+		UpdatedAt:   timestamppb.New(role.UpdatedAt),
+	}
+}
@@ -0,0 +1,401 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	ssoadminpb "github.com/p1xray/pxr-sso/api/gen/go/admin"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/grantrole"
+	userlist "github.com/p1xray/pxr-sso/internal/usecase/admin/user/list"
+	userremove "github.com/p1xray/pxr-sso/internal/usecase/admin/user/remove"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/resetpassword"
+	userrestore "github.com/p1xray/pxr-sso/internal/usecase/admin/user/restore"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokeclient"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/revokerole"
+	usersetblocked "github.com/p1xray/pxr-sso/internal/usecase/admin/user/setblocked"
+	userunlock "github.com/p1xray/pxr-sso/internal/usecase/admin/user/unlock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ListUsers is a gRPC handler for searching the users by the username or the full name.
+func (s *serverAPI) ListUsers(
+	ctx context.Context,
+	req *ssoadminpb.ListUsersRequest,
+) (*ssoadminpb.ListUsersResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validatePage(req.GetPage(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+
+	listUsersData := userlist.Params{
+		Query:    req.GetQuery(),
+		Deleted:  req.GetDeleted(),
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	users, total, err := s.listUsersUseCase.Execute(ctx, listUsersData)
+	if err != nil {
+		return nil, response.InternalError("failed to list users")
+	}
+
+	usersPb := make([]*ssoadminpb.User, len(users))
+	for i, user := range users {
+		usersPb[i] = userToPb(user)
+	}
+
+	return &ssoadminpb.ListUsersResponse{Users: usersPb, Total: total}, nil
+}
+
+// GrantUserRole is a gRPC handler for granting a role to a user.
+func (s *serverAPI) GrantUserRole(
+	ctx context.Context,
+	req *ssoadminpb.GrantUserRoleRequest,
+) (*ssoadminpb.GrantUserRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUserRole(req.GetUserId(), req.GetRoleId()); err != nil {
+		return nil, err
+	}
+
+	grantRoleData := grantrole.Params{
+		UserID: req.GetUserId(),
+		RoleID: req.GetRoleId(),
+	}
+
+	user, err := s.grantUserRoleUseCase.Execute(ctx, grantRoleData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrRoleNotFound):
+			return nil, response.NotFoundError("role not found")
+		case errors.Is(err, usecase.ErrUserRoleExists):
+			return nil, response.InvalidArgumentError("role is already granted to the user")
+		default:
+			return nil, response.InternalError("failed to grant role to user")
+		}
+	}
+
+	return &ssoadminpb.GrantUserRoleResponse{User: userToPb(user)}, nil
+}
+
+// RevokeUserRole is a gRPC handler for revoking a role from a user.
+func (s *serverAPI) RevokeUserRole(
+	ctx context.Context,
+	req *ssoadminpb.RevokeUserRoleRequest,
+) (*ssoadminpb.RevokeUserRoleResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUserRole(req.GetUserId(), req.GetRoleId()); err != nil {
+		return nil, err
+	}
+
+	revokeRoleData := revokerole.Params{
+		UserID: req.GetUserId(),
+		RoleID: req.GetRoleId(),
+	}
+
+	user, err := s.revokeUserRoleUseCase.Execute(ctx, revokeRoleData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrUserRoleNotFound):
+			return nil, response.NotFoundError("role is not granted to the user")
+		default:
+			return nil, response.InternalError("failed to revoke role from user")
+		}
+	}
+
+	return &ssoadminpb.RevokeUserRoleResponse{User: userToPb(user)}, nil
+}
+
+// GrantUserClient is a gRPC handler for giving a user access to a client.
+func (s *serverAPI) GrantUserClient(
+	ctx context.Context,
+	req *ssoadminpb.GrantUserClientRequest,
+) (*ssoadminpb.GrantUserClientResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUserClient(req.GetUserId(), req.GetClientId()); err != nil {
+		return nil, err
+	}
+
+	grantClientData := grantclient.Params{
+		UserID:   req.GetUserId(),
+		ClientID: req.GetClientId(),
+	}
+
+	user, err := s.grantUserClientUseCase.Execute(ctx, grantClientData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrUserClientExists):
+			return nil, response.InvalidArgumentError("user already has access to the client")
+		default:
+			return nil, response.InternalError("failed to grant client access to user")
+		}
+	}
+
+	return &ssoadminpb.GrantUserClientResponse{User: userToPb(user)}, nil
+}
+
+// RevokeUserClient is a gRPC handler for taking away a user access to a client.
+func (s *serverAPI) RevokeUserClient(
+	ctx context.Context,
+	req *ssoadminpb.RevokeUserClientRequest,
+) (*ssoadminpb.RevokeUserClientResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validateUserClient(req.GetUserId(), req.GetClientId()); err != nil {
+		return nil, err
+	}
+
+	revokeClientData := revokeclient.Params{
+		UserID:   req.GetUserId(),
+		ClientID: req.GetClientId(),
+	}
+
+	user, err := s.revokeUserClientUseCase.Execute(ctx, revokeClientData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrUserClientNotFound):
+			return nil, response.NotFoundError("user has no access to the client")
+		default:
+			return nil, response.InternalError("failed to revoke client access from user")
+		}
+	}
+
+	return &ssoadminpb.RevokeUserClientResponse{User: userToPb(user)}, nil
+}
+
+// BlockUser is a gRPC handler for blocking a user.
+func (s *serverAPI) BlockUser(
+	ctx context.Context,
+	req *ssoadminpb.BlockUserRequest,
+) (*ssoadminpb.BlockUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.setUserBlocked(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.BlockUserResponse{User: userToPb(user)}, nil
+}
+
+// UnblockUser is a gRPC handler for unblocking a user.
+func (s *serverAPI) UnblockUser(
+	ctx context.Context,
+	req *ssoadminpb.UnblockUserRequest,
+) (*ssoadminpb.UnblockUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.setUserBlocked(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssoadminpb.UnblockUserResponse{User: userToPb(user)}, nil
+}
+
+// DeleteUser is a gRPC handler for removing a user.
+func (s *serverAPI) DeleteUser(
+	ctx context.Context,
+	req *ssoadminpb.DeleteUserRequest,
+) (*ssoadminpb.DeleteUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	if err := s.removeUserUseCase.Execute(ctx, userremove.Params{ID: req.GetId()}); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("failed to delete user")
+	}
+
+	return &ssoadminpb.DeleteUserResponse{}, nil
+}
+
+// RestoreUser is a gRPC handler for restoring a deleted user.
+func (s *serverAPI) RestoreUser(
+	ctx context.Context,
+	req *ssoadminpb.RestoreUserRequest,
+) (*ssoadminpb.RestoreUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	user, err := s.restoreUserUseCase.Execute(ctx, userrestore.Params{ID: req.GetId()})
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("failed to restore user")
+	}
+
+	return &ssoadminpb.RestoreUserResponse{User: userToPb(user)}, nil
+}
+
+// ResetUserPassword is a gRPC handler for resetting the user password.
+func (s *serverAPI) ResetUserPassword(
+	ctx context.Context,
+	req *ssoadminpb.ResetUserPasswordRequest,
+) (*ssoadminpb.ResetUserPasswordResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	resetPasswordData := resetpassword.Params{
+		ID:                req.GetId(),
+		TemporaryPassword: req.GetTemporaryPassword(),
+	}
+
+	user, temporaryPassword, err := s.resetUserPasswordUseCase.Execute(ctx, resetPasswordData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrWeakPassword):
+			return nil, response.WeakPasswordError("temporary password does not meet the password policy", "temporaryPassword", err)
+		default:
+			return nil, response.InternalError("failed to reset user password")
+		}
+	}
+
+	return &ssoadminpb.ResetUserPasswordResponse{User: userToPb(user), TemporaryPassword: temporaryPassword}, nil
+}
+
+// UnlockUser is a gRPC handler for unlocking a user locked after too many failed login attempts.
+func (s *serverAPI) UnlockUser(
+	ctx context.Context,
+	req *ssoadminpb.UnlockUserRequest,
+) (*ssoadminpb.UnlockUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	user, err := s.unlockUserUseCase.Execute(ctx, userunlock.Params{ID: req.GetId()})
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("failed to unlock user")
+	}
+
+	return &ssoadminpb.UnlockUserResponse{User: userToPb(user)}, nil
+}
+
+// setUserBlocked blocks or unblocks the user and maps the errors of the use-case to the gRPC errors.
+func (s *serverAPI) setUserBlocked(ctx context.Context, id int64, blocked bool) (entity.UserAccount, error) {
+	if id == emptyID {
+		return entity.UserAccount{}, response.InvalidArgumentError("user id is empty")
+	}
+
+	user, err := s.setUserBlockedUseCase.Execute(ctx, usersetblocked.Params{ID: id, Blocked: blocked})
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return entity.UserAccount{}, response.NotFoundError("user not found")
+		}
+
+		return entity.UserAccount{}, response.InternalError("failed to set user blocked")
+	}
+
+	return user, nil
+}
+
+func validateUserRole(userID, roleID int64) error {
+	if userID == emptyID {
+		return response.InvalidArgumentError("user id is empty")
+	}
+
+	if roleID == emptyID {
+		return response.InvalidArgumentError("role id is empty")
+	}
+
+	return nil
+}
+
+func validateUserClient(userID, clientID int64) error {
+	if userID == emptyID {
+		return response.InvalidArgumentError("user id is empty")
+	}
+
+	if clientID == emptyID {
+		return response.InvalidArgumentError("client id is empty")
+	}
+
+	return nil
+}
+
+func userToPb(user entity.UserAccount) *ssoadminpb.User {
+	userPb := &ssoadminpb.User{
+		Id:                 user.ID,
+		Username:           user.Username,
+		FullName:           user.FullName,
+		Blocked:            user.Blocked,
+		Deleted:            user.Deleted,
+		Roles:              user.RoleCodes(),
+		Clients:            user.ClientCodes(),
+		CreatedAt:          timestamppb.New(user.CreatedAt),
+		UpdatedAt:          timestamppb.New(user.UpdatedAt),
+		MustChangePassword: user.MustChangePassword,
+		EmailVerified:      user.EmailVerified,
+		PhoneVerified:      user.PhoneVerified,
+	}
+
+	if user.Email != nil {
+		userPb.Email = wrapperspb.String(*user.Email)
+	}
+
+	if user.Phone != nil {
+		userPb.Phone = wrapperspb.String(*user.Phone)
+	}
+
+	return userPb
+}
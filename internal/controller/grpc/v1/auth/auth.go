@@ -5,6 +5,8 @@ import (
 	"errors"
 	ssopb "github.com/p1xray/pxr-sso-protos/gen/go/sso"
 	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
 	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
 	"github.com/p1xray/pxr-sso/internal/enum"
 	"github.com/p1xray/pxr-sso/internal/usecase"
@@ -13,6 +15,7 @@ import (
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/refresh"
 	"github.com/p1xray/pxr-sso/internal/usecase/auth/register"
 	"google.golang.org/grpc"
+	"strings"
 	"time"
 )
 
@@ -22,46 +25,58 @@ const (
 
 type serverAPI struct {
 	ssopb.UnimplementedSsoServer
+	issuer          string
 	loginUseCase    controller.Login
 	registerUseCase controller.Register
 	refreshUseCase  controller.RefreshTokens
 	logoutUseCase   controller.Logout
+	clientIP        *clientip.Resolver
 }
 
 // RegisterAuthServer registers the implementation of the API service with the gRPC server.
+// The issuer is set to the issued tokens, the client IP address resolver determines the address
+// the failed logins are counted for.
 func RegisterAuthServer(
 	server *grpc.Server,
+	issuer string,
 	loginUseCase controller.Login,
 	registerUseCase controller.Register,
 	refreshUseCase controller.RefreshTokens,
 	logoutUseCase controller.Logout,
+	clientIP *clientip.Resolver,
 ) {
 	api := &serverAPI{
+		issuer:          strings.TrimSuffix(issuer, "/"),
 		loginUseCase:    loginUseCase,
 		registerUseCase: registerUseCase,
 		refreshUseCase:  refreshUseCase,
 		logoutUseCase:   logoutUseCase,
+		clientIP:        clientIP,
 	}
 
 	ssopb.RegisterSsoServer(server, api)
 }
 
 // Login is a gRPC handler for logging in a user.
+// If the user must pass the second authentication factor, the FailedPrecondition error with the MFA token
+// in its details is returned, the login is completed by VerifyMFA of the MFA API.
 func (s *serverAPI) Login(
 	ctx context.Context,
 	req *ssopb.LoginRequest,
 ) (*ssopb.LoginResponse, error) {
-	if err := validateLoginRequest(req); err != nil {
+	if err := validateLoginRequest(req, s.issuer); err != nil {
 		return nil, err
 	}
 
 	loginData := login.Params{
 		Username:    req.GetUsername(),
 		Password:    req.GetPassword(),
+		IPAddress:   request.ClientIPFromContext(ctx, s.clientIP),
 		ClientCode:  req.GetClientCode(),
 		UserAgent:   req.GetUserAgent(),
 		Fingerprint: req.GetFingerprint(),
-		Issuer:      req.GetIssuer(),
+		Issuer:      s.issuer,
+		Nonce:       nonceFromContext(ctx),
 	}
 
 	tokens, err := s.loginUseCase.Execute(ctx, loginData)
@@ -70,13 +85,49 @@ func (s *serverAPI) Login(
 			return nil, response.InvalidArgumentError("invalid username or password")
 		}
 
+		if errors.Is(err, usecase.ErrAccountLocked) {
+			return nil, response.PermissionDeniedError("account is temporarily locked after too many failed login attempts")
+		}
+
+		if errors.Is(err, usecase.ErrLoginThrottled) {
+			return nil, response.ResourceExhaustedError("too many failed login attempts, retry later")
+		}
+
+		if errors.Is(err, usecase.ErrUserBlocked) {
+			return nil, response.PermissionDeniedError("user is blocked")
+		}
+
+		if errors.Is(err, usecase.ErrPasswordChangeRequired) {
+			return nil, response.FailedPreconditionError("password change required")
+		}
+
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			return nil, response.FailedPreconditionError("verified email required")
+		}
+
+		if errors.Is(err, usecase.ErrMFAEnrollmentRequired) {
+			return nil, response.FailedPreconditionError("MFA enrollment required")
+		}
+
+		if errors.Is(err, usecase.ErrSessionLimitExceeded) {
+			return nil, response.ResourceExhaustedError("too many active sessions for the client")
+		}
+
 		return nil, response.InternalError("failed to login")
 	}
 
+	if tokens.MFAToken != "" {
+		return nil, response.MFARequiredError("second authentication factor required", tokens.MFAToken)
+	}
+
+	if err = sendIDToken(ctx, tokens.IDToken); err != nil {
+		return nil, response.InternalError("failed to send ID token")
+	}
+
 	return &ssopb.LoginResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
-func validateLoginRequest(req *ssopb.LoginRequest) error {
+func validateLoginRequest(req *ssopb.LoginRequest, issuer string) error {
 	if req.GetUsername() == "" {
 		return response.InvalidArgumentError("username is empty")
 	}
@@ -97,8 +148,8 @@ func validateLoginRequest(req *ssopb.LoginRequest) error {
 		return response.InvalidArgumentError("fingerprint is empty")
 	}
 
-	if req.GetIssuer() == "" {
-		return response.InvalidArgumentError("issuer is empty")
+	if !request.IssuerMatches(issuer, req.GetIssuer()) {
+		return response.InvalidArgumentError("issuer does not match the issuer of the server")
 	}
 
 	return nil
@@ -109,7 +160,7 @@ func (s *serverAPI) Register(
 	ctx context.Context,
 	req *ssopb.RegisterRequest,
 ) (*ssopb.RegisterResponse, error) {
-	if err := validateRegisterRequest(req); err != nil {
+	if err := validateRegisterRequest(req, s.issuer); err != nil {
 		return nil, err
 	}
 
@@ -141,7 +192,8 @@ func (s *serverAPI) Register(
 		AvatarFileKey: avatarFileKey,
 		UserAgent:     req.GetUserAgent(),
 		Fingerprint:   req.GetFingerprint(),
-		Issuer:        req.GetIssuer(),
+		Issuer:        s.issuer,
+		Nonce:         nonceFromContext(ctx),
 	}
 
 	tokens, err := s.registerUseCase.Execute(ctx, registerData)
@@ -150,13 +202,21 @@ func (s *serverAPI) Register(
 			return nil, response.InvalidArgumentError("user with this username already exists")
 		}
 
+		if errors.Is(err, usecase.ErrWeakPassword) {
+			return nil, response.WeakPasswordError("password does not meet the password policy", "password", err)
+		}
+
 		return nil, response.InternalError("failed to register")
 	}
 
+	if err = sendIDToken(ctx, tokens.IDToken); err != nil {
+		return nil, response.InternalError("failed to send ID token")
+	}
+
 	return &ssopb.RegisterResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
-func validateRegisterRequest(req *ssopb.RegisterRequest) error {
+func validateRegisterRequest(req *ssopb.RegisterRequest, issuer string) error {
 	if req.GetUsername() == "" {
 		return response.InvalidArgumentError("username is empty")
 	}
@@ -181,8 +241,8 @@ func validateRegisterRequest(req *ssopb.RegisterRequest) error {
 		return response.InvalidArgumentError("fingerprint is empty")
 	}
 
-	if req.GetIssuer() == "" {
-		return response.InvalidArgumentError("issuer is empty")
+	if !request.IssuerMatches(issuer, req.GetIssuer()) {
+		return response.InvalidArgumentError("issuer does not match the issuer of the server")
 	}
 
 	return nil
@@ -193,7 +253,7 @@ func (s *serverAPI) RefreshTokens(
 	ctx context.Context,
 	req *ssopb.RefreshTokensRequest,
 ) (*ssopb.RefreshTokensResponse, error) {
-	if err := validateRefreshTokensRequest(req); err != nil {
+	if err := validateRefreshTokensRequest(req, s.issuer); err != nil {
 		return nil, err
 	}
 
@@ -202,18 +262,26 @@ func (s *serverAPI) RefreshTokens(
 		ClientCode:   req.GetClientCode(),
 		UserAgent:    req.GetUserAgent(),
 		Fingerprint:  req.GetFingerprint(),
-		Issuer:       req.GetIssuer(),
+		Issuer:       s.issuer,
 	}
 
 	tokens, err := s.refreshUseCase.Execute(ctx, refreshTokensData)
 	if err != nil {
+		if errors.Is(err, usecase.ErrRefreshTokenReused) || errors.Is(err, usecase.ErrSessionNotFound) {
+			return nil, response.UnauthenticatedError("refresh token is revoked")
+		}
+
 		return nil, response.InternalError("failed to refresh tokens")
 	}
 
+	if err = sendIDToken(ctx, tokens.IDToken); err != nil {
+		return nil, response.InternalError("failed to send ID token")
+	}
+
 	return &ssopb.RefreshTokensResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
-func validateRefreshTokensRequest(req *ssopb.RefreshTokensRequest) error {
+func validateRefreshTokensRequest(req *ssopb.RefreshTokensRequest, issuer string) error {
 	if req.GetRefreshToken() == "" {
 		return response.InvalidArgumentError("refresh token is empty")
 	}
@@ -230,8 +298,8 @@ func validateRefreshTokensRequest(req *ssopb.RefreshTokensRequest) error {
 		return response.InvalidArgumentError("client code is empty")
 	}
 
-	if req.GetIssuer() == "" {
-		return response.InvalidArgumentError("issuer is empty")
+	if !request.IssuerMatches(issuer, req.GetIssuer()) {
+		return response.InvalidArgumentError("issuer does not match the issuer of the server")
 	}
 
 	return nil
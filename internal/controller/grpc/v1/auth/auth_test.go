@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	ssopb "github.com/p1xray/pxr-sso-protos/gen/go/sso"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+const testIssuer = "https://sso.example.com"
+
+type loginStub struct {
+	tokens entity.Tokens
+}
+
+func (s loginStub) Execute(context.Context, login.Params) (entity.Tokens, error) {
+	return s.tokens, nil
+}
+
+func Test_serverAPI_Login(t *testing.T) {
+	clientIP, err := clientip.NewResolver(nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		tokens           entity.Tokens
+		expectedMFAToken string
+	}{
+		{
+			name:   "returns the tokens",
+			tokens: entity.Tokens{AccessToken: "access token", RefreshToken: "refresh token"},
+		},
+		{
+			name:             "returns the MFA token in the error details when MFA is required",
+			tokens:           entity.Tokens{MFAToken: "mfa token"},
+			expectedMFAToken: "mfa token",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &serverAPI{
+				issuer:       testIssuer,
+				loginUseCase: loginStub{tokens: tc.tokens},
+				clientIP:     clientIP,
+			}
+
+			resp, err := api.Login(context.Background(), &ssopb.LoginRequest{
+				Username:    "user",
+				Password:    "password",
+				ClientCode:  "client",
+				UserAgent:   "user agent",
+				Fingerprint: "fingerprint",
+			})
+
+			if tc.expectedMFAToken == "" {
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.tokens.AccessToken, resp.GetAccessToken())
+				assert.Equal(t, tc.tokens.RefreshToken, resp.GetRefreshToken())
+
+				return
+			}
+
+			assert.Nil(t, resp)
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+			require.Len(t, st.Details(), 1)
+			errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, response.MFARequiredReason, errorInfo.GetReason())
+			assert.Equal(t, tc.expectedMFAToken, errorInfo.GetMetadata()[response.MFATokenMetadataKey])
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package mfa
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// idTokenMetadataKey is the response header metadata key with the OpenID Connect ID token.
+	idTokenMetadataKey = "x-id-token"
+)
+
+// sendIDToken sends the ID token to the client in the response header metadata.
+func sendIDToken(ctx context.Context, idToken string) error {
+	if idToken == "" {
+		return nil
+	}
+
+	return grpc.SetHeader(ctx, metadata.Pairs(idTokenMetadataKey, idToken))
+}
@@ -0,0 +1,256 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	ssomfapb "github.com/p1xray/pxr-sso/api/gen/go/mfa"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/verifymfa"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/confirmtotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/disabletotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/enrolltotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/regeneratebackupcodes"
+	"google.golang.org/grpc"
+)
+
+type serverAPI struct {
+	ssomfapb.UnimplementedSsoMFAServer
+	verifyMFAUseCase             controller.VerifyMFA
+	enrollTOTPUseCase            controller.EnrollTOTP
+	confirmTOTPUseCase           controller.ConfirmTOTP
+	disableTOTPUseCase           controller.DisableTOTP
+	regenerateBackupCodesUseCase controller.RegenerateBackupCodes
+	clientIP                     *clientip.Resolver
+}
+
+// RegisterMFAServer registers the implementation of the API service with the gRPC server.
+func RegisterMFAServer(
+	gRPC *grpc.Server,
+	verifyMFAUseCase controller.VerifyMFA,
+	enrollTOTPUseCase controller.EnrollTOTP,
+	confirmTOTPUseCase controller.ConfirmTOTP,
+	disableTOTPUseCase controller.DisableTOTP,
+	regenerateBackupCodesUseCase controller.RegenerateBackupCodes,
+	clientIP *clientip.Resolver,
+) {
+	ssomfapb.RegisterSsoMFAServer(gRPC, &serverAPI{
+		verifyMFAUseCase:             verifyMFAUseCase,
+		enrollTOTPUseCase:            enrollTOTPUseCase,
+		confirmTOTPUseCase:           confirmTOTPUseCase,
+		disableTOTPUseCase:           disableTOTPUseCase,
+		regenerateBackupCodesUseCase: regenerateBackupCodesUseCase,
+		clientIP:                     clientIP,
+	})
+}
+
+// VerifyMFA is a gRPC handler for completing the login by the second authentication factor.
+func (s *serverAPI) VerifyMFA(
+	ctx context.Context,
+	req *ssomfapb.VerifyMFARequest,
+) (*ssomfapb.VerifyMFAResponse, error) {
+	if req.GetMfaToken() == "" {
+		return nil, response.InvalidArgumentError("MFA token is empty")
+	}
+
+	if req.GetCode() == "" {
+		return nil, response.InvalidArgumentError("code is empty")
+	}
+
+	verifyMFAData := verifymfa.Params{
+		MFAToken: req.GetMfaToken(),
+		Code:     req.GetCode(),
+	}
+
+	tokens, err := s.verifyMFAUseCase.Execute(ctx, verifyMFAData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidMFAToken):
+			return nil, response.UnauthenticatedError("invalid MFA token")
+		case errors.Is(err, usecase.ErrInvalidMFACode):
+			return nil, response.InvalidArgumentError("invalid code")
+		case errors.Is(err, usecase.ErrMFAAttemptsExceeded):
+			return nil, response.ResourceExhaustedError("MFA attempts exceeded")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrPasswordChangeRequired):
+			return nil, response.FailedPreconditionError("password change required")
+		case errors.Is(err, usecase.ErrEmailNotVerified):
+			return nil, response.FailedPreconditionError("verified email required")
+		case errors.Is(err, usecase.ErrMFAEnrollmentRequired):
+			return nil, response.FailedPreconditionError("MFA enrollment required")
+		case errors.Is(err, usecase.ErrSessionLimitExceeded):
+			return nil, response.ResourceExhaustedError("too many active sessions for the client")
+		default:
+			return nil, response.InternalError("failed to verify MFA")
+		}
+	}
+
+	if err = sendIDToken(ctx, tokens.IDToken); err != nil {
+		return nil, response.InternalError("failed to send ID token")
+	}
+
+	return &ssomfapb.VerifyMFAResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+}
+
+// EnrollTOTP is a gRPC handler for enrolling the TOTP credential of the user.
+func (s *serverAPI) EnrollTOTP(
+	ctx context.Context,
+	req *ssomfapb.EnrollTOTPRequest,
+) (*ssomfapb.EnrollTOTPResponse, error) {
+	if err := validateCredentials(req.GetUsername(), req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	enrollTOTPData := enrolltotp.Params{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		IPAddress: request.ClientIPFromContext(ctx, s.clientIP),
+	}
+
+	enrollment, err := s.enrollTOTPUseCase.Execute(ctx, enrollTOTPData)
+	if err != nil {
+		if mfaErr := credentialsError(err); mfaErr != nil {
+			return nil, mfaErr
+		}
+
+		return nil, response.InternalError("failed to enroll TOTP")
+	}
+
+	return &ssomfapb.EnrollTOTPResponse{Secret: enrollment.Secret, OtpauthUri: enrollment.URI}, nil
+}
+
+// ConfirmTOTP is a gRPC handler for confirming the enrolled TOTP credential of the user.
+func (s *serverAPI) ConfirmTOTP(
+	ctx context.Context,
+	req *ssomfapb.ConfirmTOTPRequest,
+) (*ssomfapb.ConfirmTOTPResponse, error) {
+	if err := validateCredentials(req.GetUsername(), req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, response.InvalidArgumentError("code is empty")
+	}
+
+	confirmTOTPData := confirmtotp.Params{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		Code:      req.GetCode(),
+		IPAddress: request.ClientIPFromContext(ctx, s.clientIP),
+	}
+
+	backupCodes, err := s.confirmTOTPUseCase.Execute(ctx, confirmTOTPData)
+	if err != nil {
+		if mfaErr := credentialsError(err); mfaErr != nil {
+			return nil, mfaErr
+		}
+
+		return nil, response.InternalError("failed to confirm TOTP")
+	}
+
+	return &ssomfapb.ConfirmTOTPResponse{BackupCodes: backupCodes}, nil
+}
+
+// DisableTOTP is a gRPC handler for disabling the TOTP credential of the user.
+func (s *serverAPI) DisableTOTP(
+	ctx context.Context,
+	req *ssomfapb.DisableTOTPRequest,
+) (*ssomfapb.DisableTOTPResponse, error) {
+	if err := validateCredentials(req.GetUsername(), req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, response.InvalidArgumentError("code is empty")
+	}
+
+	disableTOTPData := disabletotp.Params{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		Code:      req.GetCode(),
+		IPAddress: request.ClientIPFromContext(ctx, s.clientIP),
+	}
+
+	if err := s.disableTOTPUseCase.Execute(ctx, disableTOTPData); err != nil {
+		if mfaErr := credentialsError(err); mfaErr != nil {
+			return nil, mfaErr
+		}
+
+		return nil, response.InternalError("failed to disable TOTP")
+	}
+
+	return &ssomfapb.DisableTOTPResponse{}, nil
+}
+
+// RegenerateBackupCodes is a gRPC handler for regenerating the backup codes of the user.
+func (s *serverAPI) RegenerateBackupCodes(
+	ctx context.Context,
+	req *ssomfapb.RegenerateBackupCodesRequest,
+) (*ssomfapb.RegenerateBackupCodesResponse, error) {
+	if err := validateCredentials(req.GetUsername(), req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	if req.GetCode() == "" {
+		return nil, response.InvalidArgumentError("code is empty")
+	}
+
+	regenerateBackupCodesData := regeneratebackupcodes.Params{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		Code:      req.GetCode(),
+		IPAddress: request.ClientIPFromContext(ctx, s.clientIP),
+	}
+
+	backupCodes, err := s.regenerateBackupCodesUseCase.Execute(ctx, regenerateBackupCodesData)
+	if err != nil {
+		if mfaErr := credentialsError(err); mfaErr != nil {
+			return nil, mfaErr
+		}
+
+		return nil, response.InternalError("failed to regenerate backup codes")
+	}
+
+	return &ssomfapb.RegenerateBackupCodesResponse{BackupCodes: backupCodes}, nil
+}
+
+func validateCredentials(username, password string) error {
+	if username == "" {
+		return response.InvalidArgumentError("username is empty")
+	}
+
+	if password == "" {
+		return response.InvalidArgumentError("password is empty")
+	}
+
+	return nil
+}
+
+// credentialsError returns the gRPC error of the TOTP credential management error.
+// Nil is returned for the unexpected error.
+func credentialsError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCredentials):
+		return response.InvalidArgumentError("invalid username or password")
+	case errors.Is(err, usecase.ErrAccountLocked):
+		return response.PermissionDeniedError("account is temporarily locked after too many failed login attempts")
+	case errors.Is(err, usecase.ErrLoginThrottled):
+		return response.ResourceExhaustedError("too many failed login attempts, retry later")
+	case errors.Is(err, usecase.ErrUserBlocked):
+		return response.PermissionDeniedError("user is blocked")
+	case errors.Is(err, usecase.ErrInvalidMFACode):
+		return response.InvalidArgumentError("invalid code")
+	case errors.Is(err, usecase.ErrTOTPAlreadyEnabled):
+		return response.FailedPreconditionError("TOTP is already enabled")
+	case errors.Is(err, usecase.ErrTOTPNotEnrolled):
+		return response.FailedPreconditionError("TOTP is not enrolled")
+	case errors.Is(err, usecase.ErrTOTPNotEnabled):
+		return response.FailedPreconditionError("TOTP is not enabled")
+	}
+
+	return nil
+}
@@ -4,26 +4,84 @@ import (
 	"context"
 	"errors"
 	"github.com/golang/protobuf/ptypes/wrappers"
-	ssoprofilepb "github.com/p1xray/pxr-sso-protos/gen/go/profile"
+	ssoprofilepb "github.com/p1xray/pxr-sso/api/gen/go/profile"
 	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
 	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
 	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/changepassword"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/confirmpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/requestpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/confirmverification"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/requestverification"
+	profileupdate "github.com/p1xray/pxr-sso/internal/usecase/profile/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"time"
 )
 
 const (
 	emptyID = 0
 )
 
+// profileFields are the update mask paths of the profile fields which can be updated.
+var profileFields = map[string]enum.ProfileFieldEnum{
+	"fio":           enum.ProfileFieldFullName,
+	"dateOfBirth":   enum.ProfileFieldDateOfBirth,
+	"gender":        enum.ProfileFieldGender,
+	"avatarFileKey": enum.ProfileFieldAvatarFileKey,
+	"email":         enum.ProfileFieldEmail,
+	"phone":         enum.ProfileFieldPhone,
+}
+
+// contactChannels are the contact channels of the API.
+var contactChannels = map[ssoprofilepb.ContactChannel]enum.ContactChannelEnum{
+	ssoprofilepb.ContactChannel_CONTACT_CHANNEL_EMAIL: enum.ContactChannelEmail,
+	ssoprofilepb.ContactChannel_CONTACT_CHANNEL_PHONE: enum.ContactChannelPhone,
+}
+
 type serverAPI struct {
 	ssoprofilepb.UnimplementedSsoProfileServer
-	profile controller.UserProfile
+	profile                     controller.UserProfile
+	updateProfileUseCase        controller.UpdateUserProfile
+	changePasswordUseCase       controller.ChangePassword
+	requestPasswordResetUseCase controller.RequestPasswordReset
+	confirmPasswordResetUseCase controller.ConfirmPasswordReset
+	requestVerificationUseCase  controller.RequestContactVerification
+	confirmVerificationUseCase  controller.ConfirmContactVerification
+	verifyAccessTokenUseCase    controller.VerifyAccessToken
+	clientIP                    *clientip.Resolver
 }
 
 // RegisterProfileServer registers the implementation of the API service with the gRPC server.
-func RegisterProfileServer(gRPC *grpc.Server, profile controller.UserProfile) {
-	ssoprofilepb.RegisterSsoProfileServer(gRPC, &serverAPI{profile: profile})
+func RegisterProfileServer(
+	gRPC *grpc.Server,
+	profile controller.UserProfile,
+	updateProfileUseCase controller.UpdateUserProfile,
+	changePasswordUseCase controller.ChangePassword,
+	requestPasswordResetUseCase controller.RequestPasswordReset,
+	confirmPasswordResetUseCase controller.ConfirmPasswordReset,
+	requestVerificationUseCase controller.RequestContactVerification,
+	confirmVerificationUseCase controller.ConfirmContactVerification,
+	verifyAccessTokenUseCase controller.VerifyAccessToken,
+	clientIP *clientip.Resolver,
+) {
+	ssoprofilepb.RegisterSsoProfileServer(gRPC, &serverAPI{
+		profile:                     profile,
+		updateProfileUseCase:        updateProfileUseCase,
+		changePasswordUseCase:       changePasswordUseCase,
+		requestPasswordResetUseCase: requestPasswordResetUseCase,
+		confirmPasswordResetUseCase: confirmPasswordResetUseCase,
+		requestVerificationUseCase:  requestVerificationUseCase,
+		confirmVerificationUseCase:  confirmVerificationUseCase,
+		verifyAccessTokenUseCase:    verifyAccessTokenUseCase,
+		clientIP:                    clientIP,
+	})
 }
 
 // GetProfile is a gRPC handler for getting user profile data.
@@ -35,6 +93,10 @@ func (s *serverAPI) GetProfile(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
 	userProfile, err := s.profile.Execute(ctx, req.GetUserId())
 	if err != nil {
 		if errors.Is(err, usecase.ErrUserNotFound) {
@@ -44,31 +106,283 @@ func (s *serverAPI) GetProfile(
 		return nil, response.InternalError("failed to get user profile")
 	}
 
-	var dateOfBirthPb *timestamppb.Timestamp
-	if userProfile.DateOfBirth != nil {
-		dateOfBirthPb = timestamppb.New(*userProfile.DateOfBirth)
+	dateOfBirthPb, genderPb, avatarFileKeyPb := profileToPb(userProfile)
+
+	return &ssoprofilepb.GetProfileResponse{
+		UserId:        userProfile.ID,
+		Username:      userProfile.Username,
+		Fio:           userProfile.FullName,
+		DateOfBirth:   dateOfBirthPb,
+		Gender:        genderPb,
+		AvatarFileKey: avatarFileKeyPb,
+		Email:         stringValueToPb(userProfile.Email),
+		EmailVerified: userProfile.EmailVerified,
+		Phone:         stringValueToPb(userProfile.Phone),
+		PhoneVerified: userProfile.PhoneVerified,
+	}, nil
+}
+
+// UpdateProfile is a gRPC handler for updating user profile data.
+// Only the fields listed in the update mask are updated.
+func (s *serverAPI) UpdateProfile(
+	ctx context.Context,
+	req *ssoprofilepb.UpdateProfileRequest,
+) (*ssoprofilepb.UpdateProfileResponse, error) {
+	fields, err := validateUpdateProfileRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
-	genderPb := ssoprofilepb.Gender_GENDER_UNSPECIFIED
-	if userProfile.Gender != nil {
-		genderPb = ssoprofilepb.Gender(*userProfile.Gender)
+	if err = s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
 	}
 
-	var avatarFileKeyPb *wrappers.StringValue
-	if userProfile.AvatarFileKey != nil {
-		avatarFileKeyPb = &wrappers.StringValue{Value: *userProfile.AvatarFileKey}
+	updateProfileData := profileupdate.Params{
+		UserID:        req.GetUserId(),
+		Fields:        fields,
+		FullName:      req.GetFio(),
+		DateOfBirth:   dateOfBirthFromPb(req.GetDateOfBirth()),
+		Gender:        genderFromPb(req.GetGender()),
+		AvatarFileKey: stringValueFromPb(req.GetAvatarFileKey()),
+		Email:         stringValueFromPb(req.GetEmail()),
+		Phone:         stringValueFromPb(req.GetPhone()),
 	}
 
-	return &ssoprofilepb.GetProfileResponse{
+	userProfile, err := s.updateProfileUseCase.Execute(ctx, updateProfileData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrInvalidProfile):
+			return nil, response.InvalidArgumentError("invalid profile data")
+		case errors.Is(err, usecase.ErrContactExists):
+			return nil, response.InvalidArgumentError("email or phone is already used by another user")
+		default:
+			return nil, response.InternalError("failed to update user profile")
+		}
+	}
+
+	dateOfBirthPb, genderPb, avatarFileKeyPb := profileToPb(userProfile)
+
+	return &ssoprofilepb.UpdateProfileResponse{
 		UserId:        userProfile.ID,
 		Username:      userProfile.Username,
 		Fio:           userProfile.FullName,
 		DateOfBirth:   dateOfBirthPb,
 		Gender:        genderPb,
 		AvatarFileKey: avatarFileKeyPb,
+		Email:         stringValueToPb(userProfile.Email),
+		EmailVerified: userProfile.EmailVerified,
+		Phone:         stringValueToPb(userProfile.Phone),
+		PhoneVerified: userProfile.PhoneVerified,
 	}, nil
 }
 
+// ChangePassword is a gRPC handler for changing the user password.
+// The user is authenticated by the username and the current password.
+func (s *serverAPI) ChangePassword(
+	ctx context.Context,
+	req *ssoprofilepb.ChangePasswordRequest,
+) (*ssoprofilepb.ChangePasswordResponse, error) {
+	if err := validateChangePasswordRequest(req); err != nil {
+		return nil, err
+	}
+
+	changePasswordData := changepassword.Params{
+		Username:        req.GetUsername(),
+		CurrentPassword: req.GetCurrentPassword(),
+		NewPassword:     req.GetNewPassword(),
+		IPAddress:       request.ClientIPFromContext(ctx, s.clientIP),
+		RefreshToken:    req.GetRefreshToken(),
+		ClientCode:      req.GetClientCode(),
+	}
+
+	revokedCount, err := s.changePasswordUseCase.Execute(ctx, changePasswordData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCredentials):
+			return nil, response.InvalidArgumentError("invalid username or password")
+		case errors.Is(err, usecase.ErrAccountLocked):
+			return nil, response.PermissionDeniedError("account is temporarily locked after too many failed login attempts")
+		case errors.Is(err, usecase.ErrLoginThrottled):
+			return nil, response.ResourceExhaustedError("too many failed login attempts, retry later")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrWeakPassword):
+			return nil, response.WeakPasswordError("new password does not meet the password policy", "newPassword", err)
+		case errors.Is(err, usecase.ErrPasswordNotChanged):
+			return nil, response.InvalidArgumentError("new password must differ from the current password")
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrSessionNotFound):
+			return nil, response.NotFoundError("session not found")
+		default:
+			return nil, response.InternalError("failed to change password")
+		}
+	}
+
+	return &ssoprofilepb.ChangePasswordResponse{RevokedCount: int32(revokedCount)}, nil
+}
+
+// RequestPasswordReset is a gRPC handler for requesting the password reset.
+// The response does not reveal whether the user exists.
+func (s *serverAPI) RequestPasswordReset(
+	ctx context.Context,
+	req *ssoprofilepb.RequestPasswordResetRequest,
+) (*ssoprofilepb.RequestPasswordResetResponse, error) {
+	if req.GetUsername() == "" {
+		return nil, response.InvalidArgumentError("username is empty")
+	}
+
+	requestPasswordResetData := requestpasswordreset.Params{
+		Username: req.GetUsername(),
+	}
+
+	if err := s.requestPasswordResetUseCase.Execute(ctx, requestPasswordResetData); err != nil {
+		return nil, response.InternalError("failed to request password reset")
+	}
+
+	return &ssoprofilepb.RequestPasswordResetResponse{}, nil
+}
+
+// ConfirmPasswordReset is a gRPC handler for resetting the forgotten password by the password reset token.
+func (s *serverAPI) ConfirmPasswordReset(
+	ctx context.Context,
+	req *ssoprofilepb.ConfirmPasswordResetRequest,
+) (*ssoprofilepb.ConfirmPasswordResetResponse, error) {
+	if err := validateConfirmPasswordResetRequest(req); err != nil {
+		return nil, err
+	}
+
+	confirmPasswordResetData := confirmpasswordreset.Params{
+		Token:       req.GetToken(),
+		NewPassword: req.GetNewPassword(),
+	}
+
+	revokedCount, err := s.confirmPasswordResetUseCase.Execute(ctx, confirmPasswordResetData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPasswordResetToken):
+			return nil, response.InvalidArgumentError("invalid or expired password reset token")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrWeakPassword):
+			return nil, response.WeakPasswordError("new password does not meet the password policy", "newPassword", err)
+		default:
+			return nil, response.InternalError("failed to reset password")
+		}
+	}
+
+	return &ssoprofilepb.ConfirmPasswordResetResponse{RevokedCount: int32(revokedCount)}, nil
+}
+
+// RequestContactVerification is a gRPC handler for requesting the verification of the user contact.
+// The verification code is delivered to the contact of the channel.
+func (s *serverAPI) RequestContactVerification(
+	ctx context.Context,
+	req *ssoprofilepb.RequestContactVerificationRequest,
+) (*ssoprofilepb.RequestContactVerificationResponse, error) {
+	channel, err := validateRequestContactVerificationRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	requestVerificationData := requestverification.Params{
+		UserID:  req.GetUserId(),
+		Channel: channel,
+	}
+
+	if err = s.requestVerificationUseCase.Execute(ctx, requestVerificationData); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrContactNotSet):
+			return nil, response.FailedPreconditionError("contact is not set")
+		case errors.Is(err, usecase.ErrContactAlreadyVerified):
+			return nil, response.FailedPreconditionError("contact is already verified")
+		default:
+			return nil, response.InternalError("failed to request contact verification")
+		}
+	}
+
+	return &ssoprofilepb.RequestContactVerificationResponse{}, nil
+}
+
+// ConfirmContactVerification is a gRPC handler for confirming the verification of the user contact.
+func (s *serverAPI) ConfirmContactVerification(
+	ctx context.Context,
+	req *ssoprofilepb.ConfirmContactVerificationRequest,
+) (*ssoprofilepb.ConfirmContactVerificationResponse, error) {
+	channel, err := validateConfirmContactVerificationRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	confirmVerificationData := confirmverification.Params{
+		UserID:  req.GetUserId(),
+		Channel: channel,
+		Code:    req.GetCode(),
+	}
+
+	if err = s.confirmVerificationUseCase.Execute(ctx, confirmVerificationData); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, response.NotFoundError("user not found")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrInvalidVerificationCode):
+			return nil, response.InvalidArgumentError("invalid or expired verification code")
+		case errors.Is(err, usecase.ErrVerificationAttemptsExceeded):
+			return nil, response.ResourceExhaustedError("verification attempts exceeded, request a new code")
+		case errors.Is(err, usecase.ErrContactExists):
+			return nil, response.FailedPreconditionError("contact is already verified by another user")
+		default:
+			return nil, response.InternalError("failed to confirm contact verification")
+		}
+	}
+
+	return &ssoprofilepb.ConfirmContactVerificationResponse{}, nil
+}
+
+// authorize checks the access token of the caller. The users can access their own profile,
+// the admin permission is required to access the profile of another user or by the access token of a client.
+func (s *serverAPI) authorize(ctx context.Context, userID int64) error {
+	accessToken := request.AccessTokenFromContext(ctx)
+	if accessToken == "" {
+		return response.UnauthenticatedError("access token is empty")
+	}
+
+	verifyData := verify.Params{
+		AccessToken: accessToken,
+	}
+
+	introspection, err := s.verifyAccessTokenUseCase.Execute(ctx, verifyData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidToken) {
+			return response.UnauthenticatedError("invalid access token")
+		}
+
+		return response.InternalError("failed to verify access token")
+	}
+
+	isOwner := introspection.IsUserToken() && introspection.Subject == strconv.FormatInt(userID, 10)
+	if isOwner || introspection.HasPermission(entity.PermissionAdmin) {
+		return nil
+	}
+
+	return response.PermissionDeniedError("profile of another user can't be accessed")
+}
+
 func validateGetProfileRequest(req *ssoprofilepb.GetProfileRequest) error {
 	if req.GetUserId() == emptyID {
 		return response.InvalidArgumentError("user id is empty")
@@ -76,3 +390,147 @@ func validateGetProfileRequest(req *ssoprofilepb.GetProfileRequest) error {
 
 	return nil
 }
+
+func validateChangePasswordRequest(req *ssoprofilepb.ChangePasswordRequest) error {
+	if req.GetUsername() == "" {
+		return response.InvalidArgumentError("username is empty")
+	}
+
+	if req.GetCurrentPassword() == "" {
+		return response.InvalidArgumentError("current password is empty")
+	}
+
+	if req.GetNewPassword() == "" {
+		return response.InvalidArgumentError("new password is empty")
+	}
+
+	if req.GetRefreshToken() != "" && req.GetClientCode() == "" {
+		return response.InvalidArgumentError("client code is empty")
+	}
+
+	return nil
+}
+
+func validateConfirmPasswordResetRequest(req *ssoprofilepb.ConfirmPasswordResetRequest) error {
+	if req.GetToken() == "" {
+		return response.InvalidArgumentError("password reset token is empty")
+	}
+
+	if req.GetNewPassword() == "" {
+		return response.InvalidArgumentError("new password is empty")
+	}
+
+	return nil
+}
+
+func validateRequestContactVerificationRequest(
+	req *ssoprofilepb.RequestContactVerificationRequest,
+) (enum.ContactChannelEnum, error) {
+	if req.GetUserId() == emptyID {
+		return "", response.InvalidArgumentError("user id is empty")
+	}
+
+	channel, ok := contactChannels[req.GetChannel()]
+	if !ok {
+		return "", response.InvalidArgumentError("unknown contact channel")
+	}
+
+	return channel, nil
+}
+
+func validateConfirmContactVerificationRequest(
+	req *ssoprofilepb.ConfirmContactVerificationRequest,
+) (enum.ContactChannelEnum, error) {
+	if req.GetUserId() == emptyID {
+		return "", response.InvalidArgumentError("user id is empty")
+	}
+
+	channel, ok := contactChannels[req.GetChannel()]
+	if !ok {
+		return "", response.InvalidArgumentError("unknown contact channel")
+	}
+
+	if req.GetCode() == "" {
+		return "", response.InvalidArgumentError("verification code is empty")
+	}
+
+	return channel, nil
+}
+
+// validateUpdateProfileRequest checks the update profile request and returns the profile fields to update.
+func validateUpdateProfileRequest(req *ssoprofilepb.UpdateProfileRequest) ([]enum.ProfileFieldEnum, error) {
+	if req.GetUserId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, response.InvalidArgumentError("update mask is empty")
+	}
+
+	fields := make([]enum.ProfileFieldEnum, 0, len(paths))
+	for _, path := range paths {
+		field, ok := profileFields[path]
+		if !ok {
+			return nil, response.InvalidArgumentError("unknown update mask path: " + path)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func profileToPb(user entity.User) (*timestamppb.Timestamp, ssoprofilepb.Gender, *wrappers.StringValue) {
+	var dateOfBirthPb *timestamppb.Timestamp
+	if user.DateOfBirth != nil {
+		dateOfBirthPb = timestamppb.New(*user.DateOfBirth)
+	}
+
+	genderPb := ssoprofilepb.Gender_GENDER_UNSPECIFIED
+	if user.Gender != nil {
+		genderPb = ssoprofilepb.Gender(*user.Gender)
+	}
+
+	return dateOfBirthPb, genderPb, stringValueToPb(user.AvatarFileKey)
+}
+
+func stringValueToPb(value *string) *wrappers.StringValue {
+	if value == nil {
+		return nil
+	}
+
+	return &wrappers.StringValue{Value: *value}
+}
+
+func dateOfBirthFromPb(value *timestamppb.Timestamp) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	dateOfBirth := value.AsTime()
+
+	return &dateOfBirth
+}
+
+// genderFromPb returns the gender of the user. The unspecified gender clears it,
+// the unknown gender is passed on to be rejected by the validation of the profile.
+func genderFromPb(value ssoprofilepb.Gender) *enum.GenderEnum {
+	if value == ssoprofilepb.Gender_GENDER_UNSPECIFIED {
+		return nil
+	}
+
+	gender := enum.GenderEnum(value)
+
+	return &gender
+}
+
+func stringValueFromPb(value *wrappers.StringValue) *string {
+	if value == nil {
+		return nil
+	}
+
+	stringValue := value.GetValue()
+
+	return &stringValue
+}
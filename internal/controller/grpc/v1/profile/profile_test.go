@@ -0,0 +1,165 @@
+package profile
+
+import (
+	"context"
+	ssoprofilepb "github.com/p1xray/pxr-sso/api/gen/go/profile"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/confirmverification"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/requestverification"
+	profileupdate "github.com/p1xray/pxr-sso/internal/usecase/profile/update"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"testing"
+)
+
+// authorizationCases are the callers of the profile of the user 1.
+var authorizationCases = []struct {
+	name          string
+	introspection entity.Introspection
+	expectedCode  codes.Code
+}{
+	{
+		name:          "allows the owner",
+		introspection: entity.Introspection{Subject: "1", TokenUse: jwtclaims.TokenUseAccess},
+		expectedCode:  codes.OK,
+	},
+	{
+		name: "allows the admin",
+		introspection: entity.Introspection{
+			Subject:     "2",
+			Scope:       entity.PermissionAdmin,
+			TokenUse:    jwtclaims.TokenUseAccess,
+			Permissions: []string{entity.PermissionAdmin},
+		},
+		expectedCode: codes.OK,
+	},
+	{
+		name:          "denies another user",
+		introspection: entity.Introspection{Subject: "2", TokenUse: jwtclaims.TokenUseAccess},
+		expectedCode:  codes.PermissionDenied,
+	},
+	{
+		name: "denies the admin scope without the admin permission",
+		introspection: entity.Introspection{
+			Subject:  "2",
+			Scope:    entity.PermissionAdmin,
+			TokenUse: jwtclaims.TokenUseAccess,
+		},
+		expectedCode: codes.PermissionDenied,
+	},
+	{
+		name: "denies the client named after the user",
+		introspection: entity.Introspection{
+			Subject:  "1",
+			Scope:    entity.PermissionAdmin,
+			TokenUse: jwtclaims.TokenUseClient,
+		},
+		expectedCode: codes.PermissionDenied,
+	},
+}
+
+type verifyStub struct {
+	introspection entity.Introspection
+}
+
+func (s verifyStub) Execute(context.Context, verify.Params) (entity.Introspection, error) {
+	return s.introspection, nil
+}
+
+type profileStub struct{}
+
+func (profileStub) Execute(_ context.Context, id int64) (entity.User, error) {
+	return entity.User{ID: id}, nil
+}
+
+type updateProfileStub struct{}
+
+func (updateProfileStub) Execute(_ context.Context, data profileupdate.Params) (entity.User, error) {
+	return entity.User{ID: data.UserID}, nil
+}
+
+type requestVerificationStub struct{}
+
+func (requestVerificationStub) Execute(context.Context, requestverification.Params) error {
+	return nil
+}
+
+type confirmVerificationStub struct{}
+
+func (confirmVerificationStub) Execute(context.Context, confirmverification.Params) error {
+	return nil
+}
+
+func authorizedContext() context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+}
+
+func Test_serverAPI_UpdateProfile(t *testing.T) {
+	for _, tc := range authorizationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &serverAPI{
+				updateProfileUseCase:     updateProfileStub{},
+				verifyAccessTokenUseCase: verifyStub{introspection: tc.introspection},
+			}
+
+			_, err := api.UpdateProfile(authorizedContext(), &ssoprofilepb.UpdateProfileRequest{
+				UserId:     1,
+				Fio:        "Full Name",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"fio"}},
+			})
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func Test_serverAPI_GetProfile(t *testing.T) {
+	for _, tc := range authorizationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &serverAPI{
+				profile:                  profileStub{},
+				verifyAccessTokenUseCase: verifyStub{introspection: tc.introspection},
+			}
+
+			_, err := api.GetProfile(authorizedContext(), &ssoprofilepb.GetProfileRequest{UserId: 1})
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func Test_serverAPI_ContactVerification(t *testing.T) {
+	for _, tc := range authorizationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &serverAPI{
+				requestVerificationUseCase: requestVerificationStub{},
+				confirmVerificationUseCase: confirmVerificationStub{},
+				verifyAccessTokenUseCase:   verifyStub{introspection: tc.introspection},
+			}
+
+			_, err := api.RequestContactVerification(authorizedContext(), &ssoprofilepb.RequestContactVerificationRequest{
+				UserId:  1,
+				Channel: ssoprofilepb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+			})
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+
+			_, err = api.ConfirmContactVerification(authorizedContext(), &ssoprofilepb.ConfirmContactVerificationRequest{
+				UserId:  1,
+				Channel: ssoprofilepb.ContactChannel_CONTACT_CHANNEL_EMAIL,
+				Code:    "123456",
+			})
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -2,26 +2,157 @@ package v1
 
 import (
 	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/admin"
 	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/auth"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/mfa"
 	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/profile"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/session"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/token"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/v1/webauthn"
 	"google.golang.org/grpc"
 )
 
 // NewRoutes creates a new routes for the gRPC server controller of version 1.
 func NewRoutes(
 	server *grpc.Server,
+	issuer string,
 	loginUseCase controller.Login,
 	registerUseCase controller.Register,
 	refreshUseCase controller.RefreshTokens,
 	logoutUseCase controller.Logout,
 	profileUseCase controller.UserProfile,
+	updateProfileUseCase controller.UpdateUserProfile,
+	changePasswordUseCase controller.ChangePassword,
+	requestPasswordResetUseCase controller.RequestPasswordReset,
+	confirmPasswordResetUseCase controller.ConfirmPasswordReset,
+	requestVerificationUseCase controller.RequestContactVerification,
+	confirmVerificationUseCase controller.ConfirmContactVerification,
+	verifyMFAUseCase controller.VerifyMFA,
+	enrollTOTPUseCase controller.EnrollTOTP,
+	confirmTOTPUseCase controller.ConfirmTOTP,
+	disableTOTPUseCase controller.DisableTOTP,
+	regenerateBackupCodesUseCase controller.RegenerateBackupCodes,
+	beginWebAuthnRegistrationUseCase controller.BeginWebAuthnRegistration,
+	finishWebAuthnRegistrationUseCase controller.FinishWebAuthnRegistration,
+	beginWebAuthnLoginUseCase controller.BeginWebAuthnLogin,
+	finishWebAuthnLoginUseCase controller.FinishWebAuthnLogin,
+	introspectUseCase controller.Introspect,
+	listSessionsUseCase controller.ListSessions,
+	revokeSessionUseCase controller.RevokeSession,
+	revokeAllSessionsUseCase controller.RevokeAllSessions,
+	verifyAccessTokenUseCase controller.VerifyAccessToken,
+	createClientUseCase controller.CreateClient,
+	updateClientUseCase controller.UpdateClient,
+	removeClientUseCase controller.RemoveClient,
+	listClientsUseCase controller.ListClients,
+	rotateClientSecretUseCase controller.RotateClientSecret,
+	addClientAudienceUseCase controller.AddClientAudience,
+	removeClientAudienceUseCase controller.RemoveClientAudience,
+	setClientDefaultRolesUseCase controller.SetClientDefaultRoles,
+	createRoleUseCase controller.CreateRole,
+	updateRoleUseCase controller.UpdateRole,
+	removeRoleUseCase controller.RemoveRole,
+	setRoleActiveUseCase controller.SetRoleActive,
+	listRolesUseCase controller.ListRoles,
+	attachRolePermissionUseCase controller.AttachRolePermission,
+	detachRolePermissionUseCase controller.DetachRolePermission,
+	createPermissionUseCase controller.CreatePermission,
+	updatePermissionUseCase controller.UpdatePermission,
+	removePermissionUseCase controller.RemovePermission,
+	setPermissionActiveUseCase controller.SetPermissionActive,
+	listPermissionsUseCase controller.ListPermissions,
+	listUsersUseCase controller.ListUsers,
+	grantUserRoleUseCase controller.GrantUserRole,
+	revokeUserRoleUseCase controller.RevokeUserRole,
+	grantUserClientUseCase controller.GrantUserClient,
+	revokeUserClientUseCase controller.RevokeUserClient,
+	setUserBlockedUseCase controller.SetUserBlocked,
+	removeUserUseCase controller.RemoveUser,
+	restoreUserUseCase controller.RestoreUser,
+	resetUserPasswordUseCase controller.ResetUserPassword,
+	unlockUserUseCase controller.UnlockUser,
+	clientIP *clientip.Resolver,
 ) {
 	auth.RegisterAuthServer(
 		server,
+		issuer,
 		loginUseCase,
 		registerUseCase,
 		refreshUseCase,
-		logoutUseCase)
+		logoutUseCase,
+		clientIP)
 
-	profile.RegisterProfileServer(server, profileUseCase)
+	profile.RegisterProfileServer(
+		server,
+		profileUseCase,
+		updateProfileUseCase,
+		changePasswordUseCase,
+		requestPasswordResetUseCase,
+		confirmPasswordResetUseCase,
+		requestVerificationUseCase,
+		confirmVerificationUseCase,
+		verifyAccessTokenUseCase,
+		clientIP)
+
+	mfa.RegisterMFAServer(
+		server,
+		verifyMFAUseCase,
+		enrollTOTPUseCase,
+		confirmTOTPUseCase,
+		disableTOTPUseCase,
+		regenerateBackupCodesUseCase,
+		clientIP)
+
+	webauthn.RegisterWebAuthnServer(
+		server,
+		issuer,
+		beginWebAuthnRegistrationUseCase,
+		finishWebAuthnRegistrationUseCase,
+		beginWebAuthnLoginUseCase,
+		finishWebAuthnLoginUseCase,
+		clientIP)
+
+	token.RegisterTokenServer(server, introspectUseCase)
+
+	session.RegisterSessionServer(
+		server,
+		listSessionsUseCase,
+		revokeSessionUseCase,
+		revokeAllSessionsUseCase,
+		verifyAccessTokenUseCase)
+
+	admin.RegisterAdminServer(
+		server,
+		verifyAccessTokenUseCase,
+		createClientUseCase,
+		updateClientUseCase,
+		removeClientUseCase,
+		listClientsUseCase,
+		rotateClientSecretUseCase,
+		addClientAudienceUseCase,
+		removeClientAudienceUseCase,
+		setClientDefaultRolesUseCase,
+		createRoleUseCase,
+		updateRoleUseCase,
+		removeRoleUseCase,
+		setRoleActiveUseCase,
+		listRolesUseCase,
+		attachRolePermissionUseCase,
+		detachRolePermissionUseCase,
+		createPermissionUseCase,
+		updatePermissionUseCase,
+		removePermissionUseCase,
+		setPermissionActiveUseCase,
+		listPermissionsUseCase,
+		listUsersUseCase,
+		grantUserRoleUseCase,
+		revokeUserRoleUseCase,
+		grantUserClientUseCase,
+		revokeUserClientUseCase,
+		setUserBlockedUseCase,
+		removeUserUseCase,
+		restoreUserUseCase,
+		resetUserPasswordUseCase,
+		unlockUserUseCase)
 }
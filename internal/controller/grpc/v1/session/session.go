@@ -0,0 +1,216 @@
+package session
+
+import (
+	"context"
+	"errors"
+	ssosessionpb "github.com/p1xray/pxr-sso/api/gen/go/session"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revoke"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revokeall"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+)
+
+const (
+	emptyID = 0
+)
+
+type serverAPI struct {
+	ssosessionpb.UnimplementedSsoSessionServer
+	listSessionsUseCase      controller.ListSessions
+	revokeSessionUseCase     controller.RevokeSession
+	revokeAllSessionsUseCase controller.RevokeAllSessions
+	verifyAccessTokenUseCase controller.VerifyAccessToken
+}
+
+// RegisterSessionServer registers the implementation of the API service with the gRPC server.
+func RegisterSessionServer(
+	server *grpc.Server,
+	listSessionsUseCase controller.ListSessions,
+	revokeSessionUseCase controller.RevokeSession,
+	revokeAllSessionsUseCase controller.RevokeAllSessions,
+	verifyAccessTokenUseCase controller.VerifyAccessToken,
+) {
+	ssosessionpb.RegisterSsoSessionServer(server, &serverAPI{
+		listSessionsUseCase:      listSessionsUseCase,
+		revokeSessionUseCase:     revokeSessionUseCase,
+		revokeAllSessionsUseCase: revokeAllSessionsUseCase,
+		verifyAccessTokenUseCase: verifyAccessTokenUseCase,
+	})
+}
+
+// ListSessions is a gRPC handler for getting the list of user sessions.
+func (s *serverAPI) ListSessions(
+	ctx context.Context,
+	req *ssosessionpb.ListSessionsRequest,
+) (*ssosessionpb.ListSessionsResponse, error) {
+	if req.GetUserId() == emptyID {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	if err := s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.listSessionsUseCase.Execute(ctx, req.GetUserId())
+	if err != nil {
+		return nil, response.InternalError("failed to list sessions")
+	}
+
+	sessionsPb := make([]*ssosessionpb.Session, len(sessions))
+	for i, session := range sessions {
+		sessionsPb[i] = &ssosessionpb.Session{
+			Id:          session.ID,
+			UserAgent:   session.UserAgent,
+			Fingerprint: session.Fingerprint,
+			CreatedAt:   timestamppb.New(session.CreatedAt),
+			LastUsedAt:  timestamppb.New(session.LastUsedAt),
+			ExpiresAt:   timestamppb.New(session.ExpiresAt),
+		}
+	}
+
+	return &ssosessionpb.ListSessionsResponse{Sessions: sessionsPb}, nil
+}
+
+// RevokeSession is a gRPC handler for revoking a user session.
+func (s *serverAPI) RevokeSession(
+	ctx context.Context,
+	req *ssosessionpb.RevokeSessionRequest,
+) (*ssosessionpb.RevokeSessionResponse, error) {
+	if req.GetSessionId() == emptyID {
+		return nil, response.InvalidArgumentError("session id is empty")
+	}
+
+	introspection, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revokeSessionData := revoke.Params{
+		SessionID: req.GetSessionId(),
+	}
+
+	// The users can revoke only their own sessions, the admin can revoke the session of any user.
+	if !introspection.HasPermission(entity.PermissionAdmin) {
+		if !introspection.IsUserToken() {
+			return nil, response.PermissionDeniedError("session of another user can't be revoked")
+		}
+
+		userID, err := strconv.ParseInt(introspection.Subject, 10, 64)
+		if err != nil {
+			return nil, response.PermissionDeniedError("session of another user can't be revoked")
+		}
+
+		revokeSessionData.UserID = userID
+	}
+
+	if err = s.revokeSessionUseCase.Execute(ctx, revokeSessionData); err != nil {
+		if errors.Is(err, usecase.ErrSessionNotFound) {
+			return nil, response.NotFoundError("session not found")
+		}
+
+		return nil, response.InternalError("failed to revoke session")
+	}
+
+	return &ssosessionpb.RevokeSessionResponse{}, nil
+}
+
+// RevokeAllSessions is a gRPC handler for revoking all user sessions.
+func (s *serverAPI) RevokeAllSessions(
+	ctx context.Context,
+	req *ssosessionpb.RevokeAllSessionsRequest,
+) (*ssosessionpb.RevokeAllSessionsResponse, error) {
+	if err := validateRevokeAllSessionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	revokeAllSessionsData := revokeall.Params{
+		UserID:        req.GetUserId(),
+		ExceptCurrent: req.GetExceptCurrent(),
+		RefreshToken:  req.GetRefreshToken(),
+		ClientCode:    req.GetClientCode(),
+	}
+
+	revokedCount, err := s.revokeAllSessionsUseCase.Execute(ctx, revokeAllSessionsData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrClientNotFound):
+			return nil, response.NotFoundError("client not found")
+		case errors.Is(err, usecase.ErrSessionNotFound):
+			return nil, response.NotFoundError("current session not found")
+		default:
+			return nil, response.InternalError("failed to revoke sessions")
+		}
+	}
+
+	return &ssosessionpb.RevokeAllSessionsResponse{RevokedCount: int64(revokedCount)}, nil
+}
+
+// authenticate checks the access token of the caller and returns its state.
+func (s *serverAPI) authenticate(ctx context.Context) (entity.Introspection, error) {
+	accessToken := request.AccessTokenFromContext(ctx)
+	if accessToken == "" {
+		return entity.Introspection{}, response.UnauthenticatedError("access token is empty")
+	}
+
+	verifyData := verify.Params{
+		AccessToken: accessToken,
+	}
+
+	introspection, err := s.verifyAccessTokenUseCase.Execute(ctx, verifyData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidToken) {
+			return entity.Introspection{}, response.UnauthenticatedError("invalid access token")
+		}
+
+		return entity.Introspection{}, response.InternalError("failed to verify access token")
+	}
+
+	return introspection, nil
+}
+
+// authorize checks the access token of the caller. The users can manage their own sessions,
+// the admin permission is required to manage the sessions of another user or by the access token of a client.
+func (s *serverAPI) authorize(ctx context.Context, userID int64) error {
+	introspection, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	isOwner := introspection.IsUserToken() && introspection.Subject == strconv.FormatInt(userID, 10)
+	if isOwner || introspection.HasPermission(entity.PermissionAdmin) {
+		return nil
+	}
+
+	return response.PermissionDeniedError("sessions of another user can't be managed")
+}
+
+func validateRevokeAllSessionsRequest(req *ssosessionpb.RevokeAllSessionsRequest) error {
+	if req.GetUserId() == emptyID {
+		return response.InvalidArgumentError("user id is empty")
+	}
+
+	if !req.GetExceptCurrent() {
+		return nil
+	}
+
+	if req.GetRefreshToken() == "" {
+		return response.InvalidArgumentError("refresh token is empty")
+	}
+
+	if req.GetClientCode() == "" {
+		return response.InvalidArgumentError("client code is empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package session
+
+import (
+	"context"
+	ssosessionpb "github.com/p1xray/pxr-sso/api/gen/go/session"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase/session/revoke"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/verify"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+type verifyStub struct {
+	introspection entity.Introspection
+}
+
+func (s verifyStub) Execute(context.Context, verify.Params) (entity.Introspection, error) {
+	return s.introspection, nil
+}
+
+type listSessionsStub struct{}
+
+func (listSessionsStub) Execute(context.Context, int64) ([]entity.Session, error) {
+	return nil, nil
+}
+
+type revokeSessionStub struct {
+	data *revoke.Params
+}
+
+func (s revokeSessionStub) Execute(_ context.Context, data revoke.Params) error {
+	*s.data = data
+
+	return nil
+}
+
+func authorizedContext() context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+}
+
+func Test_serverAPI_ListSessions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		introspection entity.Introspection
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "lists the sessions of the owner",
+			introspection: entity.Introspection{Subject: "1", TokenUse: jwtclaims.TokenUseAccess},
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "lists the sessions of another user by the admin",
+			introspection: entity.Introspection{
+				Subject:     "2",
+				Scope:       entity.PermissionAdmin,
+				TokenUse:    jwtclaims.TokenUseAccess,
+				Permissions: []string{entity.PermissionAdmin},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:          "denies the sessions of another user",
+			introspection: entity.Introspection{Subject: "2", TokenUse: jwtclaims.TokenUseAccess},
+			expectedCode:  codes.PermissionDenied,
+		},
+		{
+			name: "denies the admin scope without the admin permission",
+			introspection: entity.Introspection{
+				Subject:  "2",
+				Scope:    entity.PermissionAdmin,
+				TokenUse: jwtclaims.TokenUseAccess,
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name: "denies the client named after the user",
+			introspection: entity.Introspection{
+				Subject:  "1",
+				Scope:    entity.PermissionAdmin,
+				TokenUse: jwtclaims.TokenUseClient,
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &serverAPI{
+				listSessionsUseCase:      listSessionsStub{},
+				verifyAccessTokenUseCase: verifyStub{introspection: tc.introspection},
+			}
+
+			_, err := api.ListSessions(authorizedContext(), &ssosessionpb.ListSessionsRequest{UserId: 1})
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func Test_serverAPI_RevokeSession(t *testing.T) {
+	testCases := []struct {
+		name           string
+		introspection  entity.Introspection
+		expectedUserID int64
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "revokes the session of the owner only",
+			introspection:  entity.Introspection{Subject: "2", TokenUse: jwtclaims.TokenUseAccess},
+			expectedUserID: 2,
+			expectedCode:   codes.OK,
+		},
+		{
+			name: "revokes the session of any user by the admin",
+			introspection: entity.Introspection{
+				Subject:     "2",
+				Scope:       entity.PermissionAdmin,
+				TokenUse:    jwtclaims.TokenUseAccess,
+				Permissions: []string{entity.PermissionAdmin},
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "revokes the session of the owner only by the admin scope without the admin permission",
+			introspection: entity.Introspection{
+				Subject:  "2",
+				Scope:    entity.PermissionAdmin,
+				TokenUse: jwtclaims.TokenUseAccess,
+			},
+			expectedUserID: 2,
+			expectedCode:   codes.OK,
+		},
+		{
+			name: "denies the client without the admin permission",
+			introspection: entity.Introspection{
+				Subject:  "2",
+				Scope:    entity.PermissionAdmin,
+				TokenUse: jwtclaims.TokenUseClient,
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var revokeData revoke.Params
+			api := &serverAPI{
+				revokeSessionUseCase:     revokeSessionStub{data: &revokeData},
+				verifyAccessTokenUseCase: verifyStub{introspection: tc.introspection},
+			}
+
+			_, err := api.RevokeSession(authorizedContext(), &ssosessionpb.RevokeSessionRequest{SessionId: 1})
+
+			require.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode == codes.OK {
+				assert.Equal(t, int64(1), revokeData.SessionID)
+				assert.Equal(t, tc.expectedUserID, revokeData.UserID)
+			}
+		})
+	}
+}
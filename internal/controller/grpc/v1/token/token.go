@@ -0,0 +1,84 @@
+package token
+
+import (
+	"context"
+	"errors"
+	ssotokenpb "github.com/p1xray/pxr-sso/api/gen/go/token"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/introspect"
+	"google.golang.org/grpc"
+)
+
+type serverAPI struct {
+	ssotokenpb.UnimplementedSsoTokenServer
+	introspectUseCase controller.Introspect
+}
+
+// RegisterTokenServer registers the implementation of the API service with the gRPC server.
+func RegisterTokenServer(server *grpc.Server, introspectUseCase controller.Introspect) {
+	ssotokenpb.RegisterSsoTokenServer(server, &serverAPI{introspectUseCase: introspectUseCase})
+}
+
+// Introspect is a gRPC handler for introspecting access and refresh tokens.
+func (s *serverAPI) Introspect(
+	ctx context.Context,
+	req *ssotokenpb.IntrospectRequest,
+) (*ssotokenpb.IntrospectResponse, error) {
+	if err := validateIntrospectRequest(req); err != nil {
+		return nil, err
+	}
+
+	introspectData := introspect.Params{
+		Token:         req.GetToken(),
+		TokenTypeHint: req.GetTokenTypeHint(),
+		ClientCode:    req.GetClientCode(),
+		ClientSecret:  req.GetClientSecret(),
+	}
+
+	introspection, err := s.introspectUseCase.Execute(ctx, introspectData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidClient) {
+			return nil, response.UnauthenticatedError("invalid client credentials")
+		}
+
+		return nil, response.InternalError("failed to introspect token")
+	}
+
+	if !introspection.Active {
+		return &ssotokenpb.IntrospectResponse{Active: false}, nil
+	}
+
+	var exp int64
+	if !introspection.ExpiresAt.IsZero() {
+		exp = introspection.ExpiresAt.Unix()
+	}
+
+	return &ssotokenpb.IntrospectResponse{
+		Active:    true,
+		Sub:       introspection.Subject,
+		Scope:     introspection.Scope,
+		Aud:       introspection.Audience,
+		Exp:       exp,
+		ClientId:  introspection.ClientID,
+		Jti:       introspection.TokenID,
+		TokenType: introspection.TokenType,
+	}, nil
+}
+
+func validateIntrospectRequest(req *ssotokenpb.IntrospectRequest) error {
+	if req.GetToken() == "" {
+		return response.InvalidArgumentError("token is empty")
+	}
+
+	if req.GetClientCode() == "" {
+		return response.InvalidArgumentError("client code is empty")
+	}
+
+	if req.GetClientSecret() == "" {
+		return response.InvalidArgumentError("client secret is empty")
+	}
+
+	return nil
+}
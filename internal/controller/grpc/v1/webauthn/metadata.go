@@ -0,0 +1,33 @@
+package webauthn
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// nonceMetadataKey is the request metadata key with the OpenID Connect nonce value passed by the client.
+	nonceMetadataKey = "x-nonce"
+	// idTokenMetadataKey is the response header metadata key with the OpenID Connect ID token.
+	idTokenMetadataKey = "x-id-token"
+)
+
+// nonceFromContext returns the nonce value from the request metadata.
+func nonceFromContext(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, nonceMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// sendIDToken sends the ID token to the client in the response header metadata.
+func sendIDToken(ctx context.Context, idToken string) error {
+	if idToken == "" {
+		return nil
+	}
+
+	return grpc.SetHeader(ctx, metadata.Pairs(idTokenMetadataKey, idToken))
+}
@@ -0,0 +1,244 @@
+package webauthn
+
+import (
+	"context"
+	"errors"
+	ssowebauthnpb "github.com/p1xray/pxr-sso/api/gen/go/webauthn"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/request"
+	"github.com/p1xray/pxr-sso/internal/controller/grpc/response"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginregistration"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishlogin"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/finishregistration"
+	"google.golang.org/grpc"
+	"strings"
+)
+
+type serverAPI struct {
+	ssowebauthnpb.UnimplementedSsoWebAuthnServer
+	issuer                    string
+	beginRegistrationUseCase  controller.BeginWebAuthnRegistration
+	finishRegistrationUseCase controller.FinishWebAuthnRegistration
+	beginLoginUseCase         controller.BeginWebAuthnLogin
+	finishLoginUseCase        controller.FinishWebAuthnLogin
+	clientIP                  *clientip.Resolver
+}
+
+// RegisterWebAuthnServer registers the implementation of the API service with the gRPC server.
+// The issuer is set to the tokens issued by the passwordless login.
+func RegisterWebAuthnServer(
+	gRPC *grpc.Server,
+	issuer string,
+	beginRegistrationUseCase controller.BeginWebAuthnRegistration,
+	finishRegistrationUseCase controller.FinishWebAuthnRegistration,
+	beginLoginUseCase controller.BeginWebAuthnLogin,
+	finishLoginUseCase controller.FinishWebAuthnLogin,
+	clientIP *clientip.Resolver,
+) {
+	ssowebauthnpb.RegisterSsoWebAuthnServer(gRPC, &serverAPI{
+		issuer:                    strings.TrimSuffix(issuer, "/"),
+		beginRegistrationUseCase:  beginRegistrationUseCase,
+		finishRegistrationUseCase: finishRegistrationUseCase,
+		beginLoginUseCase:         beginLoginUseCase,
+		finishLoginUseCase:        finishLoginUseCase,
+		clientIP:                  clientIP,
+	})
+}
+
+// BeginRegistration is a gRPC handler for starting the registration of the passkey of the user.
+func (s *serverAPI) BeginRegistration(
+	ctx context.Context,
+	req *ssowebauthnpb.BeginRegistrationRequest,
+) (*ssowebauthnpb.BeginRegistrationResponse, error) {
+	if req.GetUsername() == "" {
+		return nil, response.InvalidArgumentError("username is empty")
+	}
+
+	if req.GetPassword() == "" {
+		return nil, response.InvalidArgumentError("password is empty")
+	}
+
+	beginRegistrationData := beginregistration.Params{
+		Username:  req.GetUsername(),
+		Password:  req.GetPassword(),
+		IPAddress: request.ClientIPFromContext(ctx, s.clientIP),
+	}
+
+	options, err := s.beginRegistrationUseCase.Execute(ctx, beginRegistrationData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCredentials):
+			return nil, response.InvalidArgumentError("invalid username or password")
+		case errors.Is(err, usecase.ErrAccountLocked):
+			return nil, response.PermissionDeniedError("account is temporarily locked after too many failed login attempts")
+		case errors.Is(err, usecase.ErrLoginThrottled):
+			return nil, response.ResourceExhaustedError("too many failed login attempts, retry later")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		default:
+			return nil, response.InternalError("failed to begin passkey registration")
+		}
+	}
+
+	return &ssowebauthnpb.BeginRegistrationResponse{
+		Challenge:            options.Challenge,
+		RpId:                 options.RPID,
+		RpName:               options.RPName,
+		UserHandle:           options.UserHandle,
+		Username:             options.Username,
+		DisplayName:          options.DisplayName,
+		ExcludeCredentialIds: options.ExcludeCredentialIDs,
+		Timeout:              options.Timeout.Milliseconds(),
+	}, nil
+}
+
+// FinishRegistration is a gRPC handler for completing the registration of the passkey of the user.
+func (s *serverAPI) FinishRegistration(
+	ctx context.Context,
+	req *ssowebauthnpb.FinishRegistrationRequest,
+) (*ssowebauthnpb.FinishRegistrationResponse, error) {
+	if len(req.GetClientDataJson()) == 0 {
+		return nil, response.InvalidArgumentError("client data is empty")
+	}
+
+	if len(req.GetAttestationObject()) == 0 {
+		return nil, response.InvalidArgumentError("attestation object is empty")
+	}
+
+	finishRegistrationData := finishregistration.Params{
+		ClientDataJSON:    req.GetClientDataJson(),
+		AttestationObject: req.GetAttestationObject(),
+		Name:              req.GetName(),
+	}
+
+	credential, err := s.finishRegistrationUseCase.Execute(ctx, finishRegistrationData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidWebAuthnChallenge):
+			return nil, response.UnauthenticatedError("invalid or expired challenge")
+		case errors.Is(err, usecase.ErrInvalidWebAuthnResponse):
+			return nil, response.InvalidArgumentError("invalid passkey registration response")
+		case errors.Is(err, usecase.ErrWebAuthnCredentialExists):
+			return nil, response.InvalidArgumentError("passkey is already registered")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		default:
+			return nil, response.InternalError("failed to finish passkey registration")
+		}
+	}
+
+	return &ssowebauthnpb.FinishRegistrationResponse{CredentialId: credential.CredentialID, Name: credential.Name}, nil
+}
+
+// BeginLogin is a gRPC handler for starting the passwordless login by the passkey.
+func (s *serverAPI) BeginLogin(
+	ctx context.Context,
+	req *ssowebauthnpb.BeginLoginRequest,
+) (*ssowebauthnpb.BeginLoginResponse, error) {
+	if req.GetClientCode() == "" {
+		return nil, response.InvalidArgumentError("client code is empty")
+	}
+
+	beginLoginData := beginlogin.Params{
+		Username:   req.GetUsername(),
+		ClientCode: req.GetClientCode(),
+		Nonce:      nonceFromContext(ctx),
+	}
+
+	options, err := s.beginLoginUseCase.Execute(ctx, beginLoginData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrClientNotFound) {
+			return nil, response.NotFoundError("client not found")
+		}
+
+		return nil, response.InternalError("failed to begin passkey login")
+	}
+
+	return &ssowebauthnpb.BeginLoginResponse{
+		Challenge:          options.Challenge,
+		RpId:               options.RPID,
+		AllowCredentialIds: options.AllowCredentialIDs,
+		Timeout:            options.Timeout.Milliseconds(),
+	}, nil
+}
+
+// FinishLogin is a gRPC handler for completing the passwordless login by the passkey.
+func (s *serverAPI) FinishLogin(
+	ctx context.Context,
+	req *ssowebauthnpb.FinishLoginRequest,
+) (*ssowebauthnpb.FinishLoginResponse, error) {
+	if err := validateFinishLoginRequest(req, s.issuer); err != nil {
+		return nil, err
+	}
+
+	finishLoginData := finishlogin.Params{
+		CredentialID:      req.GetCredentialId(),
+		ClientDataJSON:    req.GetClientDataJson(),
+		AuthenticatorData: req.GetAuthenticatorData(),
+		Signature:         req.GetSignature(),
+		UserAgent:         req.GetUserAgent(),
+		Fingerprint:       req.GetFingerprint(),
+		Issuer:            s.issuer,
+	}
+
+	tokens, err := s.finishLoginUseCase.Execute(ctx, finishLoginData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCredentials):
+			return nil, response.UnauthenticatedError("invalid passkey")
+		case errors.Is(err, usecase.ErrInvalidWebAuthnChallenge):
+			return nil, response.UnauthenticatedError("invalid or expired challenge")
+		case errors.Is(err, usecase.ErrUserBlocked):
+			return nil, response.PermissionDeniedError("user is blocked")
+		case errors.Is(err, usecase.ErrPasswordChangeRequired):
+			return nil, response.FailedPreconditionError("password change required")
+		case errors.Is(err, usecase.ErrEmailNotVerified):
+			return nil, response.FailedPreconditionError("verified email required")
+		case errors.Is(err, usecase.ErrSessionLimitExceeded):
+			return nil, response.ResourceExhaustedError("too many active sessions for the client")
+		default:
+			return nil, response.InternalError("failed to finish passkey login")
+		}
+	}
+
+	if err = sendIDToken(ctx, tokens.IDToken); err != nil {
+		return nil, response.InternalError("failed to send ID token")
+	}
+
+	return &ssowebauthnpb.FinishLoginResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+}
+
+func validateFinishLoginRequest(req *ssowebauthnpb.FinishLoginRequest, issuer string) error {
+	if req.GetCredentialId() == "" {
+		return response.InvalidArgumentError("credential ID is empty")
+	}
+
+	if len(req.GetClientDataJson()) == 0 {
+		return response.InvalidArgumentError("client data is empty")
+	}
+
+	if len(req.GetAuthenticatorData()) == 0 {
+		return response.InvalidArgumentError("authenticator data is empty")
+	}
+
+	if len(req.GetSignature()) == 0 {
+		return response.InvalidArgumentError("signature is empty")
+	}
+
+	if req.GetUserAgent() == "" {
+		return response.InvalidArgumentError("user agent is empty")
+	}
+
+	if req.GetFingerprint() == "" {
+		return response.InvalidArgumentError("fingerprint is empty")
+	}
+
+	if !request.IssuerMatches(issuer, req.GetIssuer()) {
+		return response.InvalidArgumentError("issuer does not match the issuer of the server")
+	}
+
+	return nil
+}
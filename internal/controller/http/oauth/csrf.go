@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/p1xray/pxr-sso/internal/usecase/oidc/discovery"
+	"net/http"
+	"net/url"
+)
+
+const (
+	csrfCookieName  = "pxr_sso_csrf"
+	csrfFieldName   = "csrf_token"
+	csrfTokenLength = 32
+)
+
+// newCSRFToken returns new random CSRF token of the login form.
+func newCSRFToken() (string, error) {
+	tokenBytes := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// setCSRFCookie sets the CSRF token of the login form to the cookie of the authorization endpoint.
+// The token of the submitted form must match the cookie, which a cross-site page can neither read nor set.
+func (s *serverAPI) setCSRFCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     discovery.AuthorizationPath,
+		Secure:   s.issuerOrigin.Scheme == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// validCSRF reports whether the submitted login form is sent by the login page of the issuer.
+// The Origin header, or the Referer header if the Origin is not sent, must be the issuer origin,
+// and the CSRF token of the form must match the CSRF cookie.
+func (s *serverAPI) validCSRF(r *http.Request) bool {
+	source := r.Header.Get("Origin")
+	if source == "" {
+		source = r.Header.Get("Referer")
+	}
+
+	if source != "" && !s.sameOrigin(source) {
+		return false
+	}
+
+	cookie, err := r.Cookie(csrfCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	token := r.PostForm.Get(csrfFieldName)
+
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(token)) == 1
+}
+
+// sameOrigin reports whether the URL has the origin of the issuer.
+func (s *serverAPI) sameOrigin(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == s.issuerOrigin.Scheme && u.Host == s.issuerOrigin.Host
+}
@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/authorize"
+	"github.com/p1xray/pxr-sso/internal/usecase/oidc/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testIssuer = "https://sso.example.com"
+
+type authorizeStub struct{}
+
+func (authorizeStub) Execute(context.Context, authorize.Params) (string, error) {
+	return "code", nil
+}
+
+func Test_serverAPI_Authorize_CSRF(t *testing.T) {
+	clientIP, err := clientip.NewResolver(nil)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	RegisterOAuthRoutes(mux, testIssuer, 0, authorizeStub{}, nil, nil, nil, clientIP)
+
+	authorizeQuery := url.Values{
+		"response_type":         {responseTypeCode},
+		"client_id":             {"client"},
+		"redirect_uri":          {"https://client.example.com/callback"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}
+
+	// Show the login form to get the CSRF cookie.
+	formRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(formRecorder, httptest.NewRequest(http.MethodGet,
+		testIssuer+discovery.AuthorizationPath+"?"+authorizeQuery.Encode(), nil))
+	require.Equal(t, http.StatusOK, formRecorder.Code)
+
+	cookies := formRecorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	csrfCookie := cookies[0]
+	assert.Equal(t, csrfCookieName, csrfCookie.Name)
+	assert.True(t, csrfCookie.HttpOnly)
+	assert.True(t, csrfCookie.Secure)
+	assert.Contains(t, formRecorder.Body.String(), csrfCookie.Value)
+
+	testCases := []struct {
+		name         string
+		cookie       bool
+		csrfToken    string
+		origin       string
+		referer      string
+		expectedCode int
+	}{
+		{
+			name:         "form is submitted from the login page",
+			cookie:       true,
+			csrfToken:    csrfCookie.Value,
+			origin:       testIssuer,
+			expectedCode: http.StatusFound,
+		},
+		{
+			name:         "form is submitted with the referer of the login page",
+			cookie:       true,
+			csrfToken:    csrfCookie.Value,
+			referer:      testIssuer + discovery.AuthorizationPath,
+			expectedCode: http.StatusFound,
+		},
+		{
+			name:         "form is submitted from another origin",
+			cookie:       true,
+			csrfToken:    csrfCookie.Value,
+			origin:       "https://attacker.example.com",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "form is submitted with the referer of another origin",
+			cookie:       true,
+			csrfToken:    csrfCookie.Value,
+			referer:      "https://attacker.example.com/login",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "form is submitted without the CSRF token",
+			cookie:       true,
+			origin:       testIssuer,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "form is submitted with another CSRF token",
+			cookie:       true,
+			csrfToken:    "another",
+			origin:       testIssuer,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "form is submitted without the CSRF cookie",
+			csrfToken:    csrfCookie.Value,
+			origin:       testIssuer,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			form := url.Values{
+				"username": {"user"},
+				"password": {"password"},
+			}
+			for key, values := range authorizeQuery {
+				form[key] = values
+			}
+			if tc.csrfToken != "" {
+				form.Set(csrfFieldName, tc.csrfToken)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, testIssuer+discovery.AuthorizationPath,
+				strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.cookie {
+				req.AddCookie(&http.Cookie{Name: csrfCookie.Name, Value: csrfCookie.Value})
+			}
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.referer != "" {
+				req.Header.Set("Referer", tc.referer)
+			}
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, req)
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
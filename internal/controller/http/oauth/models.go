@@ -0,0 +1,47 @@
+package oauth
+
+// tokenResponse is the successful response of the token endpoint (RFC 6749, section 5.1).
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// introspectionResponse is the response of the token introspection endpoint (RFC 7662, section 2.2).
+type introspectionResponse struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	TokenID   string   `json:"jti,omitempty"`
+	TokenType string   `json:"token_type,omitempty"`
+}
+
+// errorResponse is the error response of the token endpoint (RFC 6749, section 5.2).
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// authorizeRequest is the authorization request of the authorization code flow with PKCE.
+type authorizeRequest struct {
+	ClientID            string
+	RedirectURI         string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// loginPage is the data of the login form template.
+type loginPage struct {
+	authorizeRequest
+
+	Username  string
+	CSRFToken string
+	Error     string
+}
@@ -0,0 +1,520 @@
+package oauth
+
+import (
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/http/response"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/authorize"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/credentials"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/exchange"
+	"github.com/p1xray/pxr-sso/internal/usecase/oidc/discovery"
+	"github.com/p1xray/pxr-sso/internal/usecase/token/introspect"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	responseTypeCode           = "code"
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeClientCredentials = "client_credentials"
+	tokenTypeBearer            = "Bearer"
+)
+
+// OAuth 2.0 error codes (RFC 6749, sections 4.1.2.1 and 5.2).
+const (
+	errorInvalidRequest          = "invalid_request"
+	errorInvalidClient           = "invalid_client"
+	errorInvalidGrant            = "invalid_grant"
+	errorInvalidScope            = "invalid_scope"
+	errorUnsupportedGrantType    = "unsupported_grant_type"
+	errorUnsupportedResponseType = "unsupported_response_type"
+	errorServerError             = "server_error"
+)
+
+// loginErrors are the errors of the authorization shown to the user on the login page
+// with the status code of the response.
+var loginErrors = []struct {
+	err        error
+	statusCode int
+	message    string
+}{
+	{
+		usecase.ErrInvalidCredentials,
+		http.StatusUnauthorized,
+		"Invalid username or password.",
+	},
+	{
+		usecase.ErrAccountLocked,
+		http.StatusForbidden,
+		"The account is temporarily locked after too many failed sign-in attempts.",
+	},
+	{
+		usecase.ErrLoginThrottled,
+		http.StatusTooManyRequests,
+		"Too many failed sign-in attempts, retry later.",
+	},
+	{
+		usecase.ErrUserBlocked,
+		http.StatusForbidden,
+		"The account is blocked.",
+	},
+	{
+		usecase.ErrPasswordChangeRequired,
+		http.StatusForbidden,
+		"The password must be changed before signing in.",
+	},
+	{
+		usecase.ErrEmailNotVerified,
+		http.StatusForbidden,
+		"The email must be verified before signing in to this application.",
+	},
+	{
+		usecase.ErrMFAEnrollmentRequired,
+		http.StatusForbidden,
+		"Two-factor authentication must be set up before signing in to this application.",
+	},
+	{
+		usecase.ErrMFARequired,
+		http.StatusUnauthorized,
+		"Enter the code from the authenticator app or a backup code.",
+	},
+	{
+		usecase.ErrInvalidMFACode,
+		http.StatusUnauthorized,
+		"Invalid authentication code.",
+	},
+	{
+		usecase.ErrMFAAttemptsExceeded,
+		http.StatusTooManyRequests,
+		"Too many invalid authentication codes, retry later.",
+	},
+}
+
+type serverAPI struct {
+	issuer                   string
+	issuerOrigin             url.URL
+	accessTokenTTL           time.Duration
+	authorizeUseCase         controller.Authorize
+	exchangeAuthCodeUseCase  controller.ExchangeAuthorizationCode
+	clientCredentialsUseCase controller.ClientCredentials
+	introspectUseCase        controller.Introspect
+	clientIP                 *clientip.Resolver
+}
+
+// RegisterOAuthRoutes registers the handlers of the OAuth 2.0 authorization endpoint, token endpoint
+// and token introspection endpoint with the HTTP server mux.
+// The issuer is set to the tokens issued by the token endpoint.
+func RegisterOAuthRoutes(
+	mux *http.ServeMux,
+	issuer string,
+	accessTokenTTL time.Duration,
+	authorizeUseCase controller.Authorize,
+	exchangeAuthCodeUseCase controller.ExchangeAuthorizationCode,
+	clientCredentialsUseCase controller.ClientCredentials,
+	introspectUseCase controller.Introspect,
+	clientIP *clientip.Resolver,
+) {
+	// The login form is submitted only from the issuer origin.
+	var issuerOrigin url.URL
+	if issuerURL, err := url.Parse(issuer); err == nil {
+		issuerOrigin = url.URL{Scheme: issuerURL.Scheme, Host: issuerURL.Host}
+	}
+
+	api := &serverAPI{
+		issuer:                   strings.TrimSuffix(issuer, "/"),
+		issuerOrigin:             issuerOrigin,
+		accessTokenTTL:           accessTokenTTL,
+		authorizeUseCase:         authorizeUseCase,
+		exchangeAuthCodeUseCase:  exchangeAuthCodeUseCase,
+		clientCredentialsUseCase: clientCredentialsUseCase,
+		introspectUseCase:        introspectUseCase,
+		clientIP:                 clientIP,
+	}
+
+	mux.HandleFunc("GET "+discovery.AuthorizationPath, api.AuthorizeForm)
+	mux.HandleFunc("POST "+discovery.AuthorizationPath, api.Authorize)
+	mux.HandleFunc("POST "+discovery.TokenPath, api.Token)
+	mux.HandleFunc("POST "+discovery.IntrospectionPath, api.Introspect)
+}
+
+// AuthorizeForm is an HTTP handler which validates the authorization request and shows the login form.
+func (s *serverAPI) AuthorizeForm(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req, errMsg := parseAuthorizeRequest(query.Get)
+	if errMsg != "" {
+		http.Error(w, errMsg, http.StatusBadRequest)
+		return
+	}
+
+	csrfToken, err := newCSRFToken()
+	if err != nil {
+		http.Error(w, "failed to show the login form", http.StatusInternalServerError)
+		return
+	}
+	s.setCSRFCookie(w, csrfToken)
+
+	renderLoginPage(w, http.StatusOK, loginPage{authorizeRequest: req, CSRFToken: csrfToken})
+}
+
+// Authorize is an HTTP handler which authenticates the user by the login form,
+// and if successful, redirects the user agent to the client's redirect URI with the authorization code.
+// The form must be submitted from the login page shown by AuthorizeForm.
+func (s *serverAPI) Authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	if !s.validCSRF(r) {
+		http.Error(w, "invalid CSRF token", http.StatusForbidden)
+		return
+	}
+	csrfToken := r.PostForm.Get(csrfFieldName)
+
+	req, errMsg := parseAuthorizeRequest(r.PostForm.Get)
+	if errMsg != "" {
+		http.Error(w, errMsg, http.StatusBadRequest)
+		return
+	}
+
+	username := r.PostForm.Get("username")
+	password := r.PostForm.Get("password")
+	if username == "" || password == "" {
+		renderLoginPage(w, http.StatusBadRequest, loginPage{
+			authorizeRequest: req,
+			Username:         username,
+			CSRFToken:        csrfToken,
+			Error:            "Username and password are required.",
+		})
+		return
+	}
+
+	authorizeData := authorize.Params{
+		Username:            username,
+		Password:            password,
+		IPAddress:           s.clientIP.ClientIP(r.RemoteAddr, r.Header.Values("X-Forwarded-For")),
+		ClientCode:          req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Nonce:               req.Nonce,
+		MFACode:             strings.TrimSpace(r.PostForm.Get("mfa_code")),
+	}
+
+	code, err := s.authorizeUseCase.Execute(r.Context(), authorizeData)
+	if err != nil {
+		if statusCode, message, ok := loginError(err); ok {
+			renderLoginPage(w, statusCode, loginPage{
+				authorizeRequest: req,
+				Username:         username,
+				CSRFToken:        csrfToken,
+				Error:            message,
+			})
+			return
+		}
+
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRedirectURI):
+			// The user agent must not be redirected to the unregistered redirect URI.
+			http.Error(w, "redirect_uri is not registered for the client", http.StatusBadRequest)
+		case errors.Is(err, usecase.ErrInvalidCodeChallenge):
+			redirectWithError(w, r, req, errorInvalidRequest, "invalid code_challenge")
+		default:
+			http.Error(w, "failed to authorize", http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	redirectURI, _ := url.Parse(req.RedirectURI)
+	query := redirectURI.Query()
+	query.Set("code", code)
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	redirectURI.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// Token is an HTTP handler which issues tokens by the authorization code or the client credentials grant.
+func (s *serverAPI) Token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, errorInvalidRequest, "invalid form")
+		return
+	}
+
+	grantType := r.PostForm.Get("grant_type")
+	if grantType == "" {
+		writeError(w, http.StatusBadRequest, errorInvalidRequest, "grant_type is empty")
+		return
+	}
+
+	clientID, clientSecret := clientAuthentication(r)
+
+	switch grantType {
+	case grantTypeAuthorizationCode:
+		s.exchangeAuthorizationCode(w, r, clientID, clientSecret)
+	case grantTypeClientCredentials:
+		s.clientCredentials(w, r, clientID, clientSecret)
+	default:
+		writeError(w, http.StatusBadRequest, errorUnsupportedGrantType, "")
+	}
+}
+
+func (s *serverAPI) exchangeAuthorizationCode(w http.ResponseWriter, r *http.Request, clientID, clientSecret string) {
+	exchangeData := exchange.Params{
+		Code:         r.PostForm.Get("code"),
+		ClientCode:   clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		UserAgent:    r.UserAgent(),
+		Fingerprint:  r.PostForm.Get("fingerprint"),
+		Issuer:       s.issuer,
+	}
+	if errMsg := validateExchangeParams(exchangeData); errMsg != "" {
+		writeError(w, http.StatusBadRequest, errorInvalidRequest, errMsg)
+		return
+	}
+
+	tokens, err := s.exchangeAuthCodeUseCase.Execute(r.Context(), exchangeData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidClient):
+			writeError(w, http.StatusUnauthorized, errorInvalidClient, "")
+		case errors.Is(err, usecase.ErrInvalidGrant):
+			writeError(w, http.StatusBadRequest, errorInvalidGrant, "")
+		case errors.Is(err, usecase.ErrSessionLimitExceeded):
+			writeError(w, http.StatusBadRequest, errorInvalidGrant, "too many active sessions for the client")
+		default:
+			writeError(w, http.StatusInternalServerError, errorServerError, "")
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    int64(s.accessTokenTTL.Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		IDToken:      tokens.IDToken,
+	})
+}
+
+func (s *serverAPI) clientCredentials(w http.ResponseWriter, r *http.Request, clientID, clientSecret string) {
+	// The client credentials grant is used only by confidential clients.
+	if clientID == "" || clientSecret == "" {
+		writeError(w, http.StatusUnauthorized, errorInvalidClient, "client authentication is required")
+		return
+	}
+
+	credentialsData := credentials.Params{
+		ClientCode:   clientID,
+		ClientSecret: clientSecret,
+		Scopes:       strings.Fields(r.PostForm.Get("scope")),
+		Issuer:       s.issuer,
+	}
+
+	tokens, err := s.clientCredentialsUseCase.Execute(r.Context(), credentialsData)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidClient):
+			writeError(w, http.StatusUnauthorized, errorInvalidClient, "")
+		case errors.Is(err, usecase.ErrInvalidScope):
+			writeError(w, http.StatusBadRequest, errorInvalidScope, "")
+		default:
+			writeError(w, http.StatusInternalServerError, errorServerError, "")
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tokenResponse{
+		AccessToken: tokens.AccessToken,
+		TokenType:   tokenTypeBearer,
+		ExpiresIn:   int64(s.accessTokenTTL.Seconds()),
+	})
+}
+
+// Introspect is an HTTP handler which returns the state of the access or refresh token (RFC 7662).
+// Only authenticated clients may introspect tokens.
+func (s *serverAPI) Introspect(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, errorInvalidRequest, "invalid form")
+		return
+	}
+
+	clientID, clientSecret := clientAuthentication(r)
+	if clientID == "" || clientSecret == "" {
+		writeError(w, http.StatusUnauthorized, errorInvalidClient, "client authentication is required")
+		return
+	}
+
+	introspectData := introspect.Params{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+		ClientCode:    clientID,
+		ClientSecret:  clientSecret,
+	}
+	if introspectData.Token == "" {
+		writeError(w, http.StatusBadRequest, errorInvalidRequest, "token is empty")
+		return
+	}
+
+	introspection, err := s.introspectUseCase.Execute(r.Context(), introspectData)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidClient) {
+			writeError(w, http.StatusUnauthorized, errorInvalidClient, "")
+			return
+		}
+
+		writeError(w, http.StatusInternalServerError, errorServerError, "")
+		return
+	}
+
+	if !introspection.Active {
+		response.JSON(w, http.StatusOK, introspectionResponse{Active: false})
+		return
+	}
+
+	var expiresAt int64
+	if !introspection.ExpiresAt.IsZero() {
+		expiresAt = introspection.ExpiresAt.Unix()
+	}
+
+	response.JSON(w, http.StatusOK, introspectionResponse{
+		Active:    true,
+		Scope:     introspection.Scope,
+		ClientID:  introspection.ClientID,
+		Subject:   introspection.Subject,
+		Audience:  introspection.Audience,
+		ExpiresAt: expiresAt,
+		TokenID:   introspection.TokenID,
+		TokenType: introspection.TokenType,
+	})
+}
+
+// clientAuthentication returns the credentials of the client.
+// The client authenticates by HTTP Basic authentication or by the request body (RFC 6749, section 2.3.1).
+func clientAuthentication(r *http.Request) (clientID, clientSecret string) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+
+	return clientID, clientSecret
+}
+
+// parseAuthorizeRequest returns the authorization request by its parameters.
+// If the request is invalid, the error message is returned.
+func parseAuthorizeRequest(get func(key string) string) (authorizeRequest, string) {
+	req := authorizeRequest{
+		ClientID:            get("client_id"),
+		RedirectURI:         get("redirect_uri"),
+		State:               get("state"),
+		Nonce:               get("nonce"),
+		CodeChallenge:       get("code_challenge"),
+		CodeChallengeMethod: get("code_challenge_method"),
+	}
+
+	if req.ClientID == "" {
+		return authorizeRequest{}, "client_id is empty"
+	}
+
+	if req.RedirectURI == "" {
+		return authorizeRequest{}, "redirect_uri is empty"
+	}
+
+	redirectURI, err := url.Parse(req.RedirectURI)
+	if err != nil || !redirectURI.IsAbs() || redirectURI.Fragment != "" {
+		return authorizeRequest{}, "redirect_uri is invalid"
+	}
+
+	if get("response_type") != responseTypeCode {
+		return authorizeRequest{}, errorUnsupportedResponseType
+	}
+
+	if req.CodeChallenge == "" {
+		return authorizeRequest{}, "code_challenge is empty"
+	}
+
+	if req.CodeChallengeMethod != entity.CodeChallengeMethodS256 {
+		return authorizeRequest{}, "code_challenge_method must be S256"
+	}
+
+	return req, ""
+}
+
+func validateExchangeParams(data exchange.Params) string {
+	if data.Code == "" {
+		return "code is empty"
+	}
+
+	if data.ClientCode == "" {
+		return "client_id is empty"
+	}
+
+	if data.RedirectURI == "" {
+		return "redirect_uri is empty"
+	}
+
+	if data.CodeVerifier == "" {
+		return "code_verifier is empty"
+	}
+
+	return ""
+}
+
+// redirectWithError redirects the user agent to the client's redirect URI with the error.
+// It is used only when the redirect URI is registered for the client.
+func redirectWithError(w http.ResponseWriter, r *http.Request, req authorizeRequest, code, description string) {
+	redirectURI, _ := url.Parse(req.RedirectURI)
+	query := redirectURI.Query()
+	query.Set("error", code)
+	if description != "" {
+		query.Set("error_description", description)
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	redirectURI.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// loginError returns the status code and the message of the login page for the authorization error.
+// It reports false if the error is not shown on the login page.
+func loginError(err error) (int, string, bool) {
+	for _, loginErr := range loginErrors {
+		if errors.Is(err, loginErr.err) {
+			return loginErr.statusCode, loginErr.message, true
+		}
+	}
+
+	return 0, "", false
+}
+
+func renderLoginPage(w http.ResponseWriter, statusCode int, page loginPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = loginTemplate.Execute(w, page)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, description string) {
+	response.JSON(w, statusCode, errorResponse{Error: code, ErrorDescription: description})
+}
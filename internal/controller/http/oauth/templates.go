@@ -0,0 +1,33 @@
+package oauth
+
+import "html/template"
+
+// loginTemplate is the login form of the authorization endpoint.
+// The authorization request parameters and the CSRF token are passed through the hidden fields.
+// The authentication code is filled in only by the users who must pass the second authentication factor.
+var loginTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Sign in</title>
+</head>
+<body>
+  <h1>Sign in to {{.ClientID}}</h1>
+  {{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+  <form method="post" action="">
+    <input type="hidden" name="response_type" value="code">
+    <input type="hidden" name="client_id" value="{{.ClientID}}">
+    <input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+    <input type="hidden" name="state" value="{{.State}}">
+    <input type="hidden" name="nonce" value="{{.Nonce}}">
+    <input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
+    <input type="hidden" name="code_challenge_method" value="{{.CodeChallengeMethod}}">
+    <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+    <label>Username <input type="text" name="username" value="{{.Username}}" autocomplete="username" required></label>
+    <label>Password <input type="password" name="password" autocomplete="current-password" required></label>
+    <label>Authentication code <input type="text" name="mfa_code" autocomplete="one-time-code"></label>
+    <button type="submit">Sign in</button>
+  </form>
+</body>
+</html>
+`))
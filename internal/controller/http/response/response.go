@@ -0,0 +1,25 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const contentTypeJSON = "application/json"
+
+// errorResponse is a body of the error response.
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// JSON writes the value as a JSON body with the status code.
+func JSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// InternalError writes an error with HTTP status code 500 and message.
+func InternalError(w http.ResponseWriter, msg string) {
+	JSON(w, http.StatusInternalServerError, errorResponse{Message: msg})
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/clientip"
+	"github.com/p1xray/pxr-sso/internal/controller/http/oauth"
+	"github.com/p1xray/pxr-sso/internal/controller/http/wellknown"
+	"net/http"
+	"time"
+)
+
+// NewRouter creates a new router for the HTTP server controller.
+func NewRouter(
+	mux *http.ServeMux,
+	issuer string,
+	accessTokenTTL time.Duration,
+	publicKeysUseCase controller.PublicKeys,
+	openIDConfigurationUseCase controller.OpenIDConfiguration,
+	authorizeUseCase controller.Authorize,
+	exchangeAuthCodeUseCase controller.ExchangeAuthorizationCode,
+	clientCredentialsUseCase controller.ClientCredentials,
+	introspectUseCase controller.Introspect,
+	clientIP *clientip.Resolver,
+) {
+	wellknown.RegisterWellKnownRoutes(mux, publicKeysUseCase, openIDConfigurationUseCase)
+	oauth.RegisterOAuthRoutes(mux, issuer, accessTokenTTL, authorizeUseCase, exchangeAuthCodeUseCase,
+		clientCredentialsUseCase, introspectUseCase, clientIP)
+}
@@ -0,0 +1,68 @@
+package wellknown
+
+import (
+	"github.com/p1xray/pxr-sso/internal/controller"
+	"github.com/p1xray/pxr-sso/internal/controller/http/response"
+	"github.com/p1xray/pxr-sso/internal/usecase/oidc/discovery"
+	"net/http"
+)
+
+const (
+	openIDConfigurationPath = "/.well-known/openid-configuration"
+)
+
+type serverAPI struct {
+	publicKeysUseCase          controller.PublicKeys
+	openIDConfigurationUseCase controller.OpenIDConfiguration
+}
+
+// RegisterWellKnownRoutes registers the handlers of the well-known documents with the HTTP server mux.
+func RegisterWellKnownRoutes(
+	mux *http.ServeMux,
+	publicKeysUseCase controller.PublicKeys,
+	openIDConfigurationUseCase controller.OpenIDConfiguration,
+) {
+	api := &serverAPI{
+		publicKeysUseCase:          publicKeysUseCase,
+		openIDConfigurationUseCase: openIDConfigurationUseCase,
+	}
+
+	mux.HandleFunc("GET "+discovery.JWKSPath, api.JWKS)
+	mux.HandleFunc("GET "+openIDConfigurationPath, api.OpenIDConfiguration)
+}
+
+// JWKS is an HTTP handler for getting the public keys for verifying access tokens as a JSON Web Key Set.
+func (s *serverAPI) JWKS(w http.ResponseWriter, r *http.Request) {
+	keySet, err := s.publicKeysUseCase.Execute(r.Context())
+	if err != nil {
+		response.InternalError(w, "failed to get public keys")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, keySet)
+}
+
+// OpenIDConfiguration is an HTTP handler for getting the OpenID Connect discovery document.
+func (s *serverAPI) OpenIDConfiguration(w http.ResponseWriter, r *http.Request) {
+	configuration, err := s.openIDConfigurationUseCase.Execute(r.Context())
+	if err != nil {
+		response.InternalError(w, "failed to get OpenID configuration")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, openIDConfigurationResponse{
+		Issuer:                            configuration.Issuer,
+		AuthorizationEndpoint:             configuration.AuthorizationEndpoint,
+		TokenEndpoint:                     configuration.TokenEndpoint,
+		IntrospectionEndpoint:             configuration.IntrospectionEndpoint,
+		JWKSURI:                           configuration.JWKSURI,
+		ResponseTypesSupported:            configuration.ResponseTypesSupported,
+		GrantTypesSupported:               configuration.GrantTypesSupported,
+		CodeChallengeMethodsSupported:     configuration.CodeChallengeMethodsSupported,
+		TokenEndpointAuthMethodsSupported: configuration.TokenEndpointAuthMethodsSupported,
+		SubjectTypesSupported:             configuration.SubjectTypesSupported,
+		IDTokenSigningAlgValuesSupported:  configuration.IDTokenSigningAlgValuesSupported,
+		ScopesSupported:                   configuration.ScopesSupported,
+		ClaimsSupported:                   configuration.ClaimsSupported,
+	})
+}
@@ -1,10 +1,13 @@
 package dto
 
 // DataForLogin is a DTO with data for logging in a user.
+// LoginAttempts are the saved failed login attempts of the username and the client IP address.
 type DataForLogin struct {
-	User     User
-	Client   Client
-	Sessions []Session
+	User          User
+	MFA           UserMFA
+	Client        Client
+	Sessions      []Session
+	LoginAttempts []LoginAttempt
 }
 
 // DataForRegister is a DTO with data for registering a new user.
@@ -16,12 +19,78 @@ type DataForRegister struct {
 }
 
 // DataForRefreshTokens is a DTO with data for refreshing user tokens.
+// If the refresh token has already been rotated, ConsumedRefreshToken is set instead of Session,
+// and FamilySessions contains the sessions of the token family.
 type DataForRefreshTokens struct {
-	Session Session
-	User    User
+	Session              Session
+	User                 User
+	ConsumedRefreshToken ConsumedRefreshToken
+	FamilySessions       []Session
 }
 
 // DataForLogout is a DTO with data for logging out a user.
 type DataForLogout struct {
 	Session Session
 }
+
+// DataForAuthorize is a DTO with data for authorizing a client on behalf of a user.
+type DataForAuthorize struct {
+	User          User
+	MFA           UserMFA
+	Client        Client
+	LoginAttempts []LoginAttempt
+}
+
+// DataForExchangeAuthorizationCode is a DTO with data for exchanging the authorization code for user tokens.
+type DataForExchangeAuthorizationCode struct {
+	AuthorizationCode AuthorizationCode
+	User              User
+	Client            Client
+	Sessions          []Session
+}
+
+// DataForClientCredentials is a DTO with data for issuing client tokens by the client credentials grant.
+type DataForClientCredentials struct {
+	Client          Client
+	PermissionCodes []string
+}
+
+// DataForRevokeSession is a DTO with data for revoking a user session.
+type DataForRevokeSession struct {
+	Session Session
+}
+
+// DataForRevokeAllSessions is a DTO with data for revoking all user sessions.
+type DataForRevokeAllSessions struct {
+	Sessions []Session
+}
+
+// DataForChangePassword is a DTO with data for changing the user password.
+type DataForChangePassword struct {
+	User          User
+	Sessions      []Session
+	LoginAttempts []LoginAttempt
+}
+
+// DataForRequestPasswordReset is a DTO with data for requesting the password reset.
+// If the user is not found, User is empty.
+type DataForRequestPasswordReset struct {
+	User User
+}
+
+// DataForConfirmPasswordReset is a DTO with data for resetting the password by the password reset token.
+type DataForConfirmPasswordReset struct {
+	PasswordResetToken PasswordResetToken
+	User               User
+	Sessions           []Session
+}
+
+// DataForVerifyMFA is a DTO with data for completing the login by the second authentication factor.
+type DataForVerifyMFA struct {
+	MFAChallenge  MFAChallenge
+	User          User
+	MFA           UserMFA
+	Client        Client
+	Sessions      []Session
+	LoginAttempts []LoginAttempt
+}
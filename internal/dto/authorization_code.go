@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// AuthorizationCode is a DTO with authorization code data.
+type AuthorizationCode struct {
+	ID                  int64
+	CodeHash            string
+	UserID              int64
+	ClientID            int64
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+	Used                bool
+}
@@ -1,9 +1,44 @@
 package dto
 
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
 // Client is a DTO with client data.
+// MaxSessions and SessionEvictionPolicy are empty if the client uses the default session limit.
+// If RequireVerifiedEmail is set, only the users with the verified email can sign in to the client.
+// If RequireMFA is set, the users must pass the second authentication factor to sign in to the client.
 type Client struct {
-	ID        int64
-	Code      string
-	SecretKey string
-	Audiences []string
+	ID                    int64
+	Code                  string
+	SecretKey             string
+	Audiences             []string
+	RedirectURIs          []string
+	MaxSessions           *int32
+	SessionEvictionPolicy *enum.SessionEvictionPolicyEnum
+	RequireVerifiedEmail  bool
+	RequireMFA            bool
+}
+
+// ClientDetails is a DTO with client data managed by the administration API.
+type ClientDetails struct {
+	ID                    int64
+	Name                  string
+	Code                  string
+	SecretKey             string
+	MaxSessions           *int32
+	SessionEvictionPolicy *enum.SessionEvictionPolicyEnum
+	RequireVerifiedEmail  bool
+	RequireMFA            bool
+	Audiences             []Audience
+	DefaultRoles          []Role
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// Audience is a DTO with data of the client audience.
+type Audience struct {
+	ID  int64
+	URL string
 }
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// LoginAttempt is a DTO with data of the failed login attempts of the username or the client IP address.
+// LockedUntil is zero if the logins are not locked.
+type LoginAttempt struct {
+	ID            int64
+	Kind          enum.LoginAttemptKindEnum
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// TOTPCredential is a DTO with data of the user TOTP credential.
+// The credential is used as the second authentication factor only once it is confirmed.
+type TOTPCredential struct {
+	ID           int64
+	UserID       int64
+	Secret       string
+	Confirmed    bool
+	LastUsedStep int64
+}
+
+// BackupCode is a DTO with data of the single-use backup code of the user.
+type BackupCode struct {
+	ID       int64
+	UserID   int64
+	CodeHash string
+	Used     bool
+}
+
+// UserMFA is a DTO with the second authentication factor data of the user.
+// TOTPCredential is empty if the user has not enrolled TOTP.
+type UserMFA struct {
+	TOTPCredential TOTPCredential
+	BackupCodes    []BackupCode
+}
+
+// MFAChallenge is a DTO with data of the MFA challenge issued when the user has passed the password check.
+type MFAChallenge struct {
+	ID          int64
+	TokenHash   string
+	UserID      int64
+	ClientID    int64
+	UserAgent   string
+	Fingerprint string
+	Issuer      string
+	Nonce       string
+	ExpiresAt   time.Time
+	Attempts    int
+	Used        bool
+}
+
+// DataForMFA is a DTO with data for managing the second authentication factor of the user.
+// If the user is not found, User is empty. LoginAttempts are the failed password checks of the username
+// and the client IP address.
+type DataForMFA struct {
+	User          User
+	MFA           UserMFA
+	LoginAttempts []LoginAttempt
+}
@@ -0,0 +1,18 @@
+package dto
+
+// OpenIDConfiguration is a DTO with OpenID Connect provider metadata.
+type OpenIDConfiguration struct {
+	Issuer                            string
+	AuthorizationEndpoint             string
+	TokenEndpoint                     string
+	IntrospectionEndpoint             string
+	JWKSURI                           string
+	ResponseTypesSupported            []string
+	GrantTypesSupported               []string
+	CodeChallengeMethodsSupported     []string
+	TokenEndpointAuthMethodsSupported []string
+	SubjectTypesSupported             []string
+	IDTokenSigningAlgValuesSupported  []string
+	ScopesSupported                   []string
+	ClaimsSupported                   []string
+}
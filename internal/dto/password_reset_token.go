@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// PasswordResetToken is a DTO with password reset token data.
+type PasswordResetToken struct {
+	ID        int64
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+	Used      bool
+}
@@ -1,8 +1,20 @@
 package dto
 
+import "time"
+
 // Permission is a DTO with permission data.
 type Permission struct {
 	ID          int64
 	Code        string
 	Description string
 }
+
+// PermissionDetails is a DTO with permission data managed by the administration API.
+type PermissionDetails struct {
+	ID          int64
+	Code        string
+	Description string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
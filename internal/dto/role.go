@@ -1,7 +1,21 @@
 package dto
 
+import "time"
+
 // Role is a DTO with role data.
 type Role struct {
 	ID   int64
 	Code string
 }
+
+// RoleDetails is a DTO with role data managed by the administration API.
+type RoleDetails struct {
+	ID          int64
+	Code        string
+	Name        string
+	Description string
+	Active      bool
+	Permissions []PermissionDetails
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -6,8 +6,21 @@ import "time"
 type Session struct {
 	ID             int64
 	UserID         int64
+	ClientID       *int64
 	RefreshTokenID string
+	FamilyID       string
 	UserAgent      string
 	Fingerprint    string
 	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	LastUsedAt     time.Time
+}
+
+// ConsumedRefreshToken is a DTO with data of the refresh token which has been rotated.
+type ConsumedRefreshToken struct {
+	ID             int64
+	RefreshTokenID string
+	FamilyID       string
+	UserID         int64
+	ExpiresAt      time.Time
 }
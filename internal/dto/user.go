@@ -7,15 +7,22 @@ import (
 
 // User is a DTO with user data.
 type User struct {
-	ID            int64
-	Username      string
-	PasswordHash  string
-	FullName      string
-	DateOfBirth   *time.Time
-	Gender        *enum.GenderEnum
-	AvatarFileKey *string
-	Roles         []Role
-	Permissions   []string
+	ID                 int64
+	Username           string
+	PasswordHash       string
+	FullName           string
+	DateOfBirth        *time.Time
+	Gender             *enum.GenderEnum
+	AvatarFileKey      *string
+	Email              *string
+	EmailVerified      bool
+	Phone              *string
+	PhoneVerified      bool
+	Blocked            bool
+	MustChangePassword bool
+	Deleted            bool
+	Roles              []Role
+	Permissions        []string
 }
 
 // UserProfile is a DTO with user profile data.
@@ -26,4 +33,32 @@ type UserProfile struct {
 	DateOfBirth   *time.Time
 	Gender        *enum.GenderEnum
 	AvatarFileKey *string
+	Email         *string
+	EmailVerified bool
+	Phone         *string
+	PhoneVerified bool
+	Blocked       bool
+	Deleted       bool
+}
+
+// UserDetails is a DTO with user data managed by the administration API.
+// Roles include the inactive roles granted to the user, clients are the clients the user has access to.
+type UserDetails struct {
+	ID                 int64
+	Username           string
+	FullName           string
+	DateOfBirth        *time.Time
+	Gender             *enum.GenderEnum
+	AvatarFileKey      *string
+	Email              *string
+	EmailVerified      bool
+	Phone              *string
+	PhoneVerified      bool
+	Blocked            bool
+	MustChangePassword bool
+	Deleted            bool
+	Roles              []Role
+	Clients            []Client
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// VerificationCode is a DTO with data of the contact verification code.
+// Target is the contact the code is sent to.
+type VerificationCode struct {
+	ID        int64
+	UserID    int64
+	Channel   enum.ContactChannelEnum
+	Target    string
+	CodeHash  string
+	ExpiresAt time.Time
+	Attempts  int
+	Used      bool
+}
+
+// DataForContactVerification is a DTO with data for verifying the user contact.
+// VerificationCode is empty if no code has been requested for the contact yet.
+type DataForContactVerification struct {
+	User             UserProfile
+	VerificationCode VerificationCode
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// WebAuthnCredential is a DTO with data of the WebAuthn credential (passkey) of the user.
+// CredentialID is the base64url encoding of the credential ID, PublicKey is the COSE key of the credential.
+type WebAuthnCredential struct {
+	ID           int64
+	UserID       int64
+	CredentialID string
+	PublicKey    []byte
+	SignCount    uint32
+	Name         string
+}
+
+// WebAuthnChallenge is a DTO with data of the challenge of the WebAuthn ceremony.
+// UserID is 0 if the login challenge is issued without the username, ClientID is 0 for the registration challenge.
+type WebAuthnChallenge struct {
+	ID        int64
+	Challenge string
+	Ceremony  enum.WebAuthnCeremonyEnum
+	UserID    int64
+	ClientID  int64
+	Nonce     string
+	ExpiresAt time.Time
+	Used      bool
+}
+
+// DataForWebAuthnRegistration is a DTO with data for registering the WebAuthn credential of the user.
+// WebAuthnChallenge is empty when the registration is started, LoginAttempts are set only then.
+type DataForWebAuthnRegistration struct {
+	WebAuthnChallenge   WebAuthnChallenge
+	User                User
+	WebAuthnCredentials []WebAuthnCredential
+	LoginAttempts       []LoginAttempt
+}
+
+// DataForBeginWebAuthnLogin is a DTO with data for starting the login by the WebAuthn credential.
+// If the username is not given or the user is not found, User is empty.
+type DataForBeginWebAuthnLogin struct {
+	User                User
+	WebAuthnCredentials []WebAuthnCredential
+	Client              Client
+}
+
+// DataForFinishWebAuthnLogin is a DTO with data for completing the login by the WebAuthn credential.
+type DataForFinishWebAuthnLogin struct {
+	WebAuthnChallenge  WebAuthnChallenge
+	WebAuthnCredential WebAuthnCredential
+	User               User
+	Client             Client
+	Sessions           []Session
+}
@@ -1,24 +1,41 @@
 package entity
 
 import (
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"github.com/p1xray/pxr-sso/internal/dto"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/totp"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"slices"
 	"time"
 )
 
-// maxUserSessionsCount specifies how many sessions a user can have at the same time.
-// If the number of sessions exceeds this value, all previous user sessions are removed from the storage.
-const maxUserSessionsCount int = 5
-
 // Auth is the user authentication entity.
 type Auth struct {
-	Sessions []Session
-	User     User
+	Sessions              []Session
+	User                  User
+	AuthorizationCodes    []AuthorizationCode
+	ConsumedRefreshTokens []ConsumedRefreshToken
+	PasswordResetTokens   []PasswordResetToken
+	TOTP                  TOTPCredential
+	BackupCodes           []BackupCode
+	MFAChallenges         []MFAChallenge
+	WebAuthnCredentials   []WebAuthnCredential
+	WebAuthnChallenges    []WebAuthnChallenge
+	LoginAttempts         []LoginAttempt
 
 	client                 dto.Client
 	defaultRoles           []dto.Role
 	defaultPermissionCodes []string
+	signingKey             *jwtkeys.SigningKey
+	sessionLimit           SessionLimit
+	loginThrottle          LoginThrottle
+	passwordHasher         PasswordHasher
+	nonce                  string
+	authTime               time.Time
 	accessTokenTTL         time.Duration
 	refreshTokenTTL        time.Duration
 }
@@ -28,6 +45,7 @@ func NewAuth(accessTokenTTL, refreshTokenTTL time.Duration, setters ...AuthOptio
 	auth := Auth{
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
+		passwordHasher:  defaultPasswordHasher,
 	}
 
 	for _, setter := range setters {
@@ -40,18 +58,201 @@ func NewAuth(accessTokenTTL, refreshTokenTTL time.Duration, setters ...AuthOptio
 }
 
 // Login verifies the user's login data, and if successful, creates a new user session.
+// The password hash produced by the outdated algorithm or parameters is rehashed by the password hasher.
+// If the user must pass the second authentication factor, the MFA challenge is created instead of the session,
+// and only the MFA token is returned. The login is completed by VerifyMFA.
 func (a *Auth) Login(data LoginParams) (Tokens, error) {
 	// Check password hash.
-	if err := bcrypt.CompareHashAndPassword([]byte(a.User.PasswordHash), []byte(data.Password)); err != nil {
-		return Tokens{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	if err := a.verifyPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return Tokens{}, err
 	}
 
+	// Check user can sign in.
+	if err := a.checkUserCanSignIn(); err != nil {
+		return Tokens{}, err
+	}
+
+	// Rehash the password if its hash is outdated.
+	a.rehashPassword(data.Password)
+
+	// Check second authentication factor is required.
+	if err := a.checkMFAEnrolled(); err != nil {
+		return Tokens{}, err
+	}
+
+	if a.mfaRequired() {
+		return a.createMFAChallenge(data)
+	}
+
+	a.authTime = time.Now()
+
 	// Check user sessions count.
-	if len(a.Sessions) >= maxUserSessionsCount {
-		// Set all sessions to remove.
-		for i := range a.Sessions {
-			a.Sessions[i].SetToRemove()
+	if err := a.enforceSessionLimit(); err != nil {
+		return Tokens{}, err
+	}
+
+	// Create new session.
+	tokens, err := a.CreateNewSession(data.Issuer, data.UserAgent, data.Fingerprint)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return tokens, nil
+}
+
+// Authorize verifies the user's password and the client's redirect URI, and if successful,
+// creates a new single-use authorization code bound to the client, the redirect URI and the PKCE code challenge.
+// The failed password checks are throttled as the failed logins, the failed MFA codes are counted per username,
+// so the auth entity must be saved even if the authorization fails with ErrInvalidMFACode.
+func (a *Auth) Authorize(data AuthorizeParams) (AuthorizationCode, error) {
+	// Check password hash.
+	if err := a.verifyPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	// Check user can sign in.
+	if err := a.checkUserCanSignIn(); err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	// Check redirect URI is registered for the client.
+	if !slices.Contains(a.client.RedirectURIs, data.RedirectURI) {
+		return AuthorizationCode{}, ErrInvalidRedirectURI
+	}
+
+	// Check second authentication factor.
+	if err := a.checkMFAEnrolled(); err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	if a.mfaRequired() {
+		if data.MFACode == "" {
+			return AuthorizationCode{}, ErrMFARequired
 		}
+
+		if err := a.verifyLoginFormSecondFactor(data); err != nil {
+			return AuthorizationCode{}, err
+		}
+	}
+
+	// Create new authorization code.
+	createAuthorizationCodeParams := CreateAuthorizationCodeParams{
+		UserID:              a.User.ID,
+		ClientID:            a.client.ID,
+		RedirectURI:         data.RedirectURI,
+		CodeChallenge:       data.CodeChallenge,
+		CodeChallengeMethod: data.CodeChallengeMethod,
+		Nonce:               a.nonce,
+		AuthTime:            time.Now(),
+		TTL:                 data.CodeTTL,
+	}
+	authorizationCode, err := NewAuthorizationCode(createAuthorizationCodeParams)
+	if err != nil {
+		return AuthorizationCode{}, err
+	}
+
+	authorizationCode.SetToCreate()
+	a.AuthorizationCodes = append(a.AuthorizationCodes, authorizationCode)
+
+	return authorizationCode, nil
+}
+
+// VerifyMFA verifies the second authentication factor of the user for the MFA challenge issued by Login,
+// and if successful, creates a new user session with the session data of the login.
+// The mismatched code counts as an attempt of the challenge and of the username, so the challenge
+// and the login attempts must be saved even if the verification fails.
+func (a *Auth) VerifyMFA(data VerifyMFAParams) (Tokens, error) {
+	const op = "entity.Auth.VerifyMFA"
+
+	if len(a.MFAChallenges) == 0 {
+		return Tokens{}, ErrMFAChallengeNotFound
+	}
+
+	// Check MFA challenge.
+	challenge := &a.MFAChallenges[0]
+	if err := challenge.Validate(data.MaxAttempts); err != nil {
+		return Tokens{}, err
+	}
+
+	// Check user can still sign in, the user may be blocked after the challenge is issued.
+	if err := a.checkUserCanSignIn(); err != nil {
+		return Tokens{}, err
+	}
+
+	if !a.TOTP.Enabled() {
+		return Tokens{}, ErrMFAEnrollmentRequired
+	}
+
+	// The mismatched codes are counted per username as well, so a new login does not give new attempts.
+	now := time.Now()
+	attempt := a.loginAttempt(enum.LoginAttemptKindMFA, a.User.Username)
+	if attempt.Locked(now) {
+		return Tokens{}, fmt.Errorf("%s: %w", op, ErrMFAAttemptsExceeded)
+	}
+
+	// Verify second authentication factor. Every verification counts as an attempt, the valid code as well,
+	// so the valid code is rejected once the concurrent verifications have used up the attempts.
+	challenge.countAttempt(data.MaxAttempts)
+	if err := a.verifySecondFactor(data.Code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			throttle := LoginThrottle{
+				MaxFailures:     data.MaxAttempts,
+				LockoutDuration: data.LockoutDuration,
+				FailureWindow:   data.LockoutDuration,
+			}
+			attempt.fail(throttle, now)
+		}
+
+		return Tokens{}, err
+	}
+
+	challenge.Used = true
+
+	if attempt.ID != emptyID {
+		attempt.SetToRemove()
+	}
+
+	a.authTime = time.Now()
+	a.nonce = challenge.Nonce
+
+	// Check user sessions count.
+	if err := a.enforceSessionLimit(); err != nil {
+		return Tokens{}, err
+	}
+
+	// Create new session.
+	tokens, err := a.CreateNewSession(challenge.Issuer, challenge.UserAgent, challenge.Fingerprint)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return tokens, nil
+}
+
+// ExchangeAuthorizationCode redeems the authorization code, and if successful, creates a new user session.
+func (a *Auth) ExchangeAuthorizationCode(data ExchangeAuthorizationCodeParams) (Tokens, error) {
+	if len(a.AuthorizationCodes) == 0 {
+		return Tokens{}, ErrAuthorizationCodeNotFound
+	}
+
+	// Check user can still sign in, the user may be blocked after the code is issued.
+	if err := a.checkUserCanSignIn(); err != nil {
+		return Tokens{}, err
+	}
+
+	// Redeem authorization code.
+	authorizationCode := &a.AuthorizationCodes[0]
+	if err := authorizationCode.Redeem(a.client.ID, data.RedirectURI, data.CodeVerifier); err != nil {
+		return Tokens{}, err
+	}
+	authorizationCode.SetToUpdate()
+
+	a.authTime = authorizationCode.AuthTime
+	a.nonce = authorizationCode.Nonce
+
+	// Check user sessions count.
+	if err := a.enforceSessionLimit(); err != nil {
+		return Tokens{}, err
 	}
 
 	// Create new session.
@@ -63,19 +264,22 @@ func (a *Auth) Login(data LoginParams) (Tokens, error) {
 	return tokens, nil
 }
 
-// Register creates a new user in the system.
+// Register creates a new user in the system. The password must meet the password policy.
 func (a *Auth) Register(data RegisterParams) error {
 	// Check if user with given username already exists.
 	if a.User.ID > emptyID {
 		return ErrUserExists
 	}
 
+	// Check password.
+	if err := data.PasswordPolicy.Validate(data.Password, data.Username, data.FullName); err != nil {
+		return err
+	}
+
 	// Generate hash from password.
-	passwordHash, err := bcrypt.GenerateFromPassword(
-		[]byte(data.Password),
-		bcrypt.DefaultCost)
+	passwordHash, err := hashPassword(a.passwordHasher, data.Password)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrGeneratePasswordHash, err)
+		return err
 	}
 
 	// Create new user.
@@ -85,18 +289,405 @@ func (a *Auth) Register(data RegisterParams) error {
 		data.DateOfBirth,
 		data.Gender,
 		data.AvatarFileKey,
-		WithUserPasswordHash(string(passwordHash)),
+		WithUserPasswordHash(passwordHash),
 		WithUserRoles(a.defaultRoles),
 		WithUserPermissions(a.defaultPermissionCodes),
 	)
 	user.SetToCreate()
 	a.setUser(user)
 
+	a.authTime = time.Now()
+
+	return nil
+}
+
+// ChangePassword verifies the current password of the user and sets the new one.
+// The new password must meet the password policy and differ from the current one.
+// All user sessions except the session with the refresh token ID are revoked, the number of revoked sessions
+// is returned. If the refresh token ID is empty, all sessions are revoked.
+// The failed password checks are throttled as the failed logins.
+func (a *Auth) ChangePassword(data ChangePasswordParams) (int, error) {
+	// Check current password hash.
+	if err := a.verifyPassword(data.Username, data.CurrentPassword, data.IPAddress); err != nil {
+		return 0, err
+	}
+
+	// Check user is not blocked or deleted. The user who must change the password is allowed to do it.
+	if err := a.checkUserStatus(); err != nil {
+		return 0, err
+	}
+
+	// Check new password.
+	if data.NewPassword == data.CurrentPassword {
+		return 0, ErrPasswordNotChanged
+	}
+
+	if err := data.PasswordPolicy.Validate(data.NewPassword, a.User.Username, a.User.FullName); err != nil {
+		return 0, err
+	}
+
+	// Check the kept session belongs to the user.
+	if data.ExceptRefreshTokenID != "" && !a.HasSession(data.ExceptRefreshTokenID) {
+		return 0, ErrSessionNotFound
+	}
+
+	// Set new password.
+	passwordHash, err := hashPassword(a.passwordHasher, data.NewPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	a.User.PasswordHash = passwordHash
+	a.User.MustChangePassword = false
+	a.User.SetToUpdate()
+
+	// Revoke other sessions.
+	return a.RevokeSessions(data.ExceptRefreshTokenID), nil
+}
+
+// RequestPasswordReset creates a new single-use password reset token of the user.
+// ErrInvalidCredentials is returned for the unknown or deleted user, ErrUserBlocked is returned for the blocked user.
+// The token is delivered to the verified contact of the user, so ErrNoVerifiedContact is returned
+// if the user has none.
+func (a *Auth) RequestPasswordReset(tokenTTL time.Duration) (PasswordResetToken, error) {
+	if a.User.ID == emptyID {
+		return PasswordResetToken{}, ErrInvalidCredentials
+	}
+
+	if err := a.checkUserStatus(); err != nil {
+		return PasswordResetToken{}, err
+	}
+
+	if _, _, err := a.User.VerifiedContact(); err != nil {
+		return PasswordResetToken{}, err
+	}
+
+	token, err := NewPasswordResetToken(a.User.ID, tokenTTL)
+	if err != nil {
+		return PasswordResetToken{}, err
+	}
+	token.SetToCreate()
+
+	a.PasswordResetTokens = append(a.PasswordResetTokens, token)
+
+	return token, nil
+}
+
+// ConfirmPasswordReset redeems the password reset token and sets the new password of the user.
+// All the user sessions are revoked, the number of revoked sessions is returned.
+func (a *Auth) ConfirmPasswordReset(data ConfirmPasswordResetParams) (int, error) {
+	if len(a.PasswordResetTokens) == 0 {
+		return 0, ErrPasswordResetTokenNotFound
+	}
+
+	// Check user is not blocked or deleted.
+	if err := a.checkUserStatus(); err != nil {
+		return 0, err
+	}
+
+	// Check new password before the token is redeemed, so the user can try another password.
+	if err := data.PasswordPolicy.Validate(data.NewPassword, a.User.Username, a.User.FullName); err != nil {
+		return 0, err
+	}
+
+	// Redeem password reset token.
+	token := &a.PasswordResetTokens[0]
+	if err := token.Redeem(); err != nil {
+		return 0, err
+	}
+	token.SetToUpdate()
+
+	// Set new password.
+	passwordHash, err := hashPassword(a.passwordHasher, data.NewPassword)
+	if err != nil {
+		return 0, err
+	}
+
+	a.User.PasswordHash = passwordHash
+	a.User.MustChangePassword = false
+	a.User.SetToUpdate()
+
+	// Revoke all sessions.
+	return a.RevokeSessions(""), nil
+}
+
+// EnrollTOTP verifies the password of the user and generates a new TOTP secret. The credential is unconfirmed
+// until the user confirms it by ConfirmTOTP, the previous unconfirmed credential is replaced.
+// ErrTOTPAlreadyEnabled is returned if the user has the confirmed credential.
+func (a *Auth) EnrollTOTP(data EnrollTOTPParams) (TOTPEnrollment, error) {
+	if err := a.checkPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	if a.TOTP.Enabled() {
+		return TOTPEnrollment{}, ErrTOTPAlreadyEnabled
+	}
+
+	if err := a.TOTP.generateSecret(); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	if a.TOTP.ID == emptyID {
+		a.TOTP.UserID = a.User.ID
+		a.TOTP.SetToCreate()
+	} else {
+		a.TOTP.SetToUpdate()
+	}
+
+	return TOTPEnrollment{
+		Secret: a.TOTP.Secret,
+		URI:    totp.URI(a.TOTP.Secret, data.Issuer, a.User.Username),
+	}, nil
+}
+
+// ConfirmTOTP verifies the password of the user and the first code of the enrolled TOTP credential,
+// and if successful, enables the credential as the second authentication factor.
+// The new backup codes are generated, the previous ones are removed.
+func (a *Auth) ConfirmTOTP(data ConfirmTOTPParams) ([]BackupCode, error) {
+	if err := a.checkPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if a.TOTP.Secret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+
+	if a.TOTP.Confirmed {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	if err := a.TOTP.Verify(data.Code); err != nil {
+		return nil, err
+	}
+
+	a.TOTP.Confirmed = true
+	a.TOTP.SetToUpdate()
+
+	return a.replaceBackupCodes(data.BackupCodesCount)
+}
+
+// DisableTOTP verifies the password and the second authentication factor of the user,
+// and if successful, removes the TOTP credential and the backup codes of the user.
+func (a *Auth) DisableTOTP(data DisableTOTPParams) error {
+	if err := a.checkPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return err
+	}
+
+	if !a.TOTP.Enabled() {
+		return ErrTOTPNotEnabled
+	}
+
+	if err := a.verifySecondFactor(data.Code); err != nil {
+		return err
+	}
+
+	a.TOTP.SetToRemove()
+	for i := range a.BackupCodes {
+		a.BackupCodes[i].SetToRemove()
+	}
+
 	return nil
 }
 
-// RefreshTokens refreshes the user's tokens, and if successful creates a new user session.
+// RegenerateBackupCodes verifies the password and the second authentication factor of the user,
+// and if successful, replaces the backup codes of the user with the new generated ones.
+func (a *Auth) RegenerateBackupCodes(data RegenerateBackupCodesParams) ([]BackupCode, error) {
+	if err := a.checkPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if !a.TOTP.Enabled() {
+		return nil, ErrTOTPNotEnabled
+	}
+
+	if err := a.verifySecondFactor(data.Code); err != nil {
+		return nil, err
+	}
+
+	return a.replaceBackupCodes(data.Count)
+}
+
+// BeginWebAuthnRegistration verifies the password of the user and creates a new challenge for registering
+// the WebAuthn credential of the user. The registration is completed by FinishWebAuthnRegistration.
+func (a *Auth) BeginWebAuthnRegistration(data BeginWebAuthnRegistrationParams) (WebAuthnCreationOptions, error) {
+	if err := a.checkPassword(data.Username, data.Password, data.IPAddress); err != nil {
+		return WebAuthnCreationOptions{}, err
+	}
+
+	challenge, err := a.createWebAuthnChallenge(enum.WebAuthnCeremonyRegistration, data.ChallengeTTL)
+	if err != nil {
+		return WebAuthnCreationOptions{}, err
+	}
+
+	return WebAuthnCreationOptions{
+		Challenge:            challenge.Challenge,
+		RPID:                 data.RelyingParty.ID,
+		RPName:               data.RelyingPartyName,
+		UserHandle:           webAuthnUserHandle(a.User.ID),
+		Username:             a.User.Username,
+		DisplayName:          a.User.FullName,
+		ExcludeCredentialIDs: webAuthnCredentialIDs(a.WebAuthnCredentials),
+		Timeout:              data.ChallengeTTL,
+	}, nil
+}
+
+// FinishWebAuthnRegistration verifies the response of the authenticator to the registration challenge,
+// and if successful, adds the created WebAuthn credential to the user.
+// The challenge is used whether the response is valid or not, so it must be saved even if the verification fails.
+func (a *Auth) FinishWebAuthnRegistration(data FinishWebAuthnRegistrationParams) (WebAuthnCredential, error) {
+	if len(a.WebAuthnChallenges) == 0 {
+		return WebAuthnCredential{}, ErrWebAuthnChallengeNotFound
+	}
+
+	// Check WebAuthn challenge.
+	challenge := &a.WebAuthnChallenges[0]
+	if err := challenge.Validate(enum.WebAuthnCeremonyRegistration); err != nil {
+		return WebAuthnCredential{}, err
+	}
+
+	challenge.Used = true
+	challenge.SetToUpdate()
+
+	// Check user status, the user may be blocked after the challenge is issued.
+	if err := a.checkUserStatus(); err != nil {
+		return WebAuthnCredential{}, err
+	}
+
+	// Verify the response of the authenticator.
+	credential, err := data.RelyingParty.VerifyRegistration(data.ClientDataJSON, data.AttestationObject, challenge.Challenge)
+	if err != nil {
+		return WebAuthnCredential{}, fmt.Errorf("%w: %w", ErrInvalidWebAuthnResponse, err)
+	}
+
+	credentialID := webauthn.EncodeID(credential.ID)
+	if slices.ContainsFunc(a.WebAuthnCredentials, func(c WebAuthnCredential) bool {
+		return c.CredentialID == credentialID
+	}) {
+		return WebAuthnCredential{}, ErrWebAuthnCredentialExists
+	}
+
+	name := data.Name
+	if name == "" {
+		name = defaultWebAuthnCredentialName
+	}
+
+	// Add new WebAuthn credential.
+	webAuthnCredential := WebAuthnCredential{
+		UserID:       a.User.ID,
+		CredentialID: credentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		Name:         name,
+	}
+	webAuthnCredential.SetToCreate()
+	a.WebAuthnCredentials = append(a.WebAuthnCredentials, webAuthnCredential)
+
+	return webAuthnCredential, nil
+}
+
+// BeginWebAuthnLogin creates a new challenge for logging in to the client by the WebAuthn credential.
+// If the user is set up, the challenge is bound to the user and only the credentials of the user are accepted,
+// otherwise any discoverable credential is accepted. The login is completed by FinishWebAuthnLogin.
+func (a *Auth) BeginWebAuthnLogin(data BeginWebAuthnLoginParams) (WebAuthnRequestOptions, error) {
+	challenge, err := a.createWebAuthnChallenge(enum.WebAuthnCeremonyLogin, data.ChallengeTTL)
+	if err != nil {
+		return WebAuthnRequestOptions{}, err
+	}
+
+	return WebAuthnRequestOptions{
+		Challenge:          challenge.Challenge,
+		RPID:               data.RelyingParty.ID,
+		AllowCredentialIDs: webAuthnCredentialIDs(a.WebAuthnCredentials),
+		Timeout:            data.ChallengeTTL,
+	}, nil
+}
+
+// FinishWebAuthnLogin verifies the assertion of the WebAuthn credential to the login challenge,
+// and if successful, creates a new user session with the nonce of the challenge.
+// The user verification of the authenticator is the second authentication factor itself,
+// so the MFA challenge is not created. The challenge is used whether the assertion is valid or not,
+// so it must be saved even if the verification fails.
+func (a *Auth) FinishWebAuthnLogin(data FinishWebAuthnLoginParams) (Tokens, error) {
+	if len(a.WebAuthnChallenges) == 0 {
+		return Tokens{}, ErrWebAuthnChallengeNotFound
+	}
+
+	// Check WebAuthn challenge.
+	challenge := &a.WebAuthnChallenges[0]
+	if err := challenge.Validate(enum.WebAuthnCeremonyLogin); err != nil {
+		return Tokens{}, err
+	}
+
+	challenge.Used = true
+	challenge.SetToUpdate()
+
+	// Check the credential belongs to the user the challenge is issued for.
+	if len(a.WebAuthnCredentials) == 0 {
+		return Tokens{}, ErrInvalidCredentials
+	}
+
+	credential := &a.WebAuthnCredentials[0]
+	if credential.UserID != a.User.ID || (challenge.UserID != emptyID && challenge.UserID != credential.UserID) {
+		return Tokens{}, ErrInvalidCredentials
+	}
+
+	// Verify the assertion of the credential.
+	signCount, err := data.RelyingParty.VerifyAssertion(
+		credential.PublicKey,
+		data.ClientDataJSON,
+		data.AuthenticatorData,
+		data.Signature,
+		challenge.Challenge,
+	)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	if err = credential.updateSignCount(signCount); err != nil {
+		return Tokens{}, err
+	}
+	credential.SetToUpdate()
+
+	// Check user can sign in.
+	if err = a.checkUserCanSignIn(); err != nil {
+		return Tokens{}, err
+	}
+
+	a.authTime = time.Now()
+	a.nonce = challenge.Nonce
+
+	// Check user sessions count.
+	if err = a.enforceSessionLimit(); err != nil {
+		return Tokens{}, err
+	}
+
+	// Create new session.
+	tokens, err := a.CreateNewSession(data.Issuer, data.UserAgent, data.Fingerprint)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return tokens, nil
+}
+
+// RefreshTokens refreshes the user's tokens, and if successful creates a new user session
+// in the token family of the current session. The refresh token of the current session is consumed.
+// If the refresh token has already been consumed, all sessions of the token family are revoked
+// and ErrRefreshTokenReused is returned.
 func (a *Auth) RefreshTokens(data RefreshTokensParams) (Tokens, error) {
+	// Check refresh token is not reused.
+	if len(a.ConsumedRefreshTokens) > 0 {
+		for i := range a.Sessions {
+			a.Sessions[i].SetToRemove()
+		}
+
+		return Tokens{}, ErrRefreshTokenReused
+	}
+
+	if len(a.Sessions) == 0 {
+		return Tokens{}, ErrSessionNotFound
+	}
+
 	// Check session data.
 	for _, session := range a.Sessions {
 		if err := session.Validate(data.UserAgent, data.Fingerprint); err != nil {
@@ -104,13 +695,24 @@ func (a *Auth) RefreshTokens(data RefreshTokensParams) (Tokens, error) {
 		}
 	}
 
-	// Set current session to remove.
+	// Set current session to remove and consume its refresh token.
+	familyID, familyCreatedAt := a.Sessions[0].FamilyID, a.Sessions[0].CreatedAt
 	for i := range a.Sessions {
 		a.Sessions[i].SetToRemove()
+
+		consumedRefreshToken := NewConsumedRefreshToken(a.Sessions[i])
+		consumedRefreshToken.SetToCreate()
+		a.ConsumedRefreshTokens = append(a.ConsumedRefreshTokens, consumedRefreshToken)
 	}
 
 	// Create new session.
-	tokens, err := a.CreateNewSession(data.Issuer, data.UserAgent, data.Fingerprint)
+	tokens, err := a.createSession(
+		data.Issuer,
+		data.UserAgent,
+		data.Fingerprint,
+		WithSessionFamilyID(familyID),
+		WithSessionCreatedAt(familyCreatedAt),
+	)
 	if err != nil {
 		return Tokens{}, err
 	}
@@ -133,23 +735,68 @@ func (a *Auth) Logout() error {
 	return nil
 }
 
-// CreateNewSession creates a new user session.
+// CreateNewSession creates a new user session which starts a new token family.
+// Along with the session tokens, the ID token is created for the client the user is authenticated for.
 func (a *Auth) CreateNewSession(issuer, userAgent, fingerprint string) (Tokens, error) {
+	return a.createSession(issuer, userAgent, fingerprint)
+}
+
+// RevokeSessions deletes the user sessions except the session with the refresh token ID.
+// If the refresh token ID is empty, all sessions are deleted. The number of revoked sessions is returned.
+func (a *Auth) RevokeSessions(exceptRefreshTokenID string) int {
+	revokedCount := 0
+	for i := range a.Sessions {
+		if exceptRefreshTokenID != "" && a.Sessions[i].RefreshTokenID == exceptRefreshTokenID {
+			continue
+		}
+
+		a.Sessions[i].SetToRemove()
+		revokedCount++
+	}
+
+	return revokedCount
+}
+
+// HasSession reports whether the user has the session with the refresh token ID.
+func (a *Auth) HasSession(refreshTokenID string) bool {
+	return slices.ContainsFunc(a.Sessions, func(session Session) bool {
+		return session.RefreshTokenID == refreshTokenID
+	})
+}
+
+// createSession creates a new user session. The session is used at the moment of creation.
+// If the token family is not set up by the options, the session starts a new token family.
+func (a *Auth) createSession(issuer, userAgent, fingerprint string, setters ...SessionOption) (Tokens, error) {
 	generateTokensParams := SessionWithGeneratedTokensParams{
 		UserPermissions: a.User.Permissions,
 		Audiences:       a.client.Audiences,
+		ClientCode:      a.client.Code,
+		IDToken: IDTokenParams{
+			Username:    a.User.Username,
+			FullName:    a.User.FullName,
+			DateOfBirth: a.User.DateOfBirth,
+			Gender:      a.User.Gender,
+			Email:       a.verifiedEmail(),
+			AuthTime:    a.authTime,
+			Nonce:       a.nonce,
+		},
 		ClientSecretKey: a.client.SecretKey,
+		SigningKey:      a.signingKey,
 		Issuer:          issuer,
 		AccessTokenTTL:  a.accessTokenTTL,
 		RefreshTokenTTL: a.refreshTokenTTL,
 	}
 
-	session, err := NewSession(
-		a.User.ID,
-		userAgent,
-		fingerprint,
-		WithGeneratedTokens(generateTokensParams),
-	)
+	now := time.Now()
+	sessionSetters := []SessionOption{
+		WithSessionClientID(&a.client.ID),
+		WithSessionCreatedAt(now),
+		WithSessionLastUsedAt(now),
+	}
+	sessionSetters = append(sessionSetters, setters...)
+	sessionSetters = append(sessionSetters, WithGeneratedTokens(generateTokensParams))
+
+	session, err := NewSession(a.User.ID, userAgent, fingerprint, sessionSetters...)
 	if err != nil {
 		return Tokens{}, fmt.Errorf("%w: %w", ErrCreateSession, err)
 	}
@@ -161,6 +808,302 @@ func (a *Auth) CreateNewSession(issuer, userAgent, fingerprint string) (Tokens,
 	return session.Tokens, nil
 }
 
+// checkUserStatus checks the user can sign in. The deleted user is treated as unknown,
+// so ErrInvalidCredentials is returned for it. ErrUserBlocked is returned for the blocked user.
+func (a *Auth) checkUserStatus() error {
+	if a.User.Deleted {
+		return ErrInvalidCredentials
+	}
+
+	if a.User.Blocked {
+		return ErrUserBlocked
+	}
+
+	return nil
+}
+
+// checkUserCanSignIn checks the user can sign in. In addition to the user status,
+// ErrPasswordChangeRequired is returned for the user who must change the password before signing in,
+// ErrEmailNotVerified is returned if the client requires the verified email and the user has none.
+func (a *Auth) checkUserCanSignIn() error {
+	if err := a.checkUserStatus(); err != nil {
+		return err
+	}
+
+	if a.User.MustChangePassword {
+		return ErrPasswordChangeRequired
+	}
+
+	if a.client.RequireVerifiedEmail && a.verifiedEmail() == "" {
+		return ErrEmailNotVerified
+	}
+
+	return nil
+}
+
+// checkPassword checks the password of the user and the user is not blocked or deleted.
+// The failed checks are throttled as the failed logins.
+func (a *Auth) checkPassword(username, password, ipAddress string) error {
+	if err := a.verifyPassword(username, password, ipAddress); err != nil {
+		return err
+	}
+
+	return a.checkUserStatus()
+}
+
+// verifyPassword checks the password matches the password hash of the user. The failed checks are counted
+// per username and per client IP address, ErrAccountLocked or ErrLoginThrottled is returned without checking
+// the password after too many failures. The counted failures must be saved even if the check fails.
+func (a *Auth) verifyPassword(username, password, ipAddress string) error {
+	now := time.Now()
+
+	// Check the password check is not throttled.
+	attempts := a.loginAttempts(username, ipAddress)
+	for _, attempt := range attempts {
+		if err := attempt.check(a.loginThrottle, now); err != nil {
+			return err
+		}
+	}
+
+	if err := a.passwordHasher.Verify(a.User.PasswordHash, password); err != nil {
+		for _, attempt := range attempts {
+			attempt.fail(a.loginThrottle, now)
+		}
+
+		return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	// The failures of the username are forgotten once the password matches. The failures of the address are kept,
+	// so the valid password of one user does not let to guess the passwords of the others.
+	if attempts[0].ID != emptyID {
+		attempts[0].SetToRemove()
+	}
+
+	return nil
+}
+
+// verifyLoginFormSecondFactor verifies the MFA code sent along with the password. Unlike the MFA challenge,
+// the code may be sent with every login form, so the failed codes are counted per username, and once they reach
+// the maximum attempts, the codes are rejected with ErrMFAAttemptsExceeded for the lockout duration.
+func (a *Auth) verifyLoginFormSecondFactor(data AuthorizeParams) error {
+	const op = "entity.Auth.verifyLoginFormSecondFactor"
+
+	now := time.Now()
+	attempt := a.loginAttempt(enum.LoginAttemptKindMFA, data.Username)
+	if attempt.Locked(now) {
+		return fmt.Errorf("%s: %w", op, ErrMFAAttemptsExceeded)
+	}
+
+	if err := a.verifySecondFactor(data.MFACode); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			throttle := LoginThrottle{
+				MaxFailures:     data.MFAMaxAttempts,
+				LockoutDuration: data.MFALockoutDuration,
+				FailureWindow:   data.MFALockoutDuration,
+			}
+			attempt.fail(throttle, now)
+		}
+
+		return err
+	}
+
+	if attempt.ID != emptyID {
+		attempt.SetToRemove()
+	}
+
+	return nil
+}
+
+// rehashPassword rehashes the password of the user if its hash is produced by the outdated algorithm
+// or parameters, so the users are migrated to the current password hasher as they sign in.
+// The login does not fail if the password can't be rehashed, it is rehashed on the next login.
+func (a *Auth) rehashPassword(password string) {
+	if !a.passwordHasher.NeedsRehash(a.User.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := hashPassword(a.passwordHasher, password)
+	if err != nil {
+		return
+	}
+
+	a.User.PasswordHash = passwordHash
+	a.User.SetToUpdate()
+}
+
+// mfaRequired reports whether the user must pass the second authentication factor to sign in to the client.
+// The second factor is required if the user has enabled it or the client requires it.
+func (a *Auth) mfaRequired() bool {
+	return a.TOTP.Enabled() || a.client.RequireMFA
+}
+
+// checkMFAEnrolled checks the user has enabled the second authentication factor if the client requires it.
+func (a *Auth) checkMFAEnrolled() error {
+	if a.client.RequireMFA && !a.TOTP.Enabled() {
+		return ErrMFAEnrollmentRequired
+	}
+
+	return nil
+}
+
+// createMFAChallenge creates a new MFA challenge which keeps the session data of the login.
+func (a *Auth) createMFAChallenge(data LoginParams) (Tokens, error) {
+	createMFAChallengeParams := CreateMFAChallengeParams{
+		UserID:      a.User.ID,
+		ClientID:    a.client.ID,
+		UserAgent:   data.UserAgent,
+		Fingerprint: data.Fingerprint,
+		Issuer:      data.Issuer,
+		Nonce:       a.nonce,
+		TTL:         data.MFAChallengeTTL,
+	}
+	challenge, err := NewMFAChallenge(createMFAChallengeParams)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	challenge.SetToCreate()
+	a.MFAChallenges = append(a.MFAChallenges, challenge)
+
+	return Tokens{MFAToken: challenge.Token}, nil
+}
+
+// verifySecondFactor checks the code is the TOTP code of the enabled credential or the unused backup code
+// of the user. The accepted TOTP code and backup code can't be used again.
+func (a *Auth) verifySecondFactor(code string) error {
+	err := a.TOTP.Verify(code)
+	if err == nil {
+		a.TOTP.SetToUpdate()
+
+		return nil
+	}
+
+	if !errors.Is(err, ErrInvalidMFACode) {
+		return err
+	}
+
+	codeHash := HashBackupCode(code)
+	for i := range a.BackupCodes {
+		backupCode := &a.BackupCodes[i]
+		if backupCode.Used || backupCode.IsToRemove() {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(backupCode.CodeHash), []byte(codeHash)) == 1 {
+			backupCode.Used = true
+			backupCode.SetToUpdate()
+
+			return nil
+		}
+	}
+
+	return ErrInvalidMFACode
+}
+
+// replaceBackupCodes removes the backup codes of the user and generates the new ones.
+func (a *Auth) replaceBackupCodes(count int) ([]BackupCode, error) {
+	for i := range a.BackupCodes {
+		a.BackupCodes[i].SetToRemove()
+	}
+
+	backupCodes := make([]BackupCode, count)
+	for i := range backupCodes {
+		backupCode, err := NewBackupCode(a.User.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		backupCode.SetToCreate()
+		backupCodes[i] = backupCode
+	}
+
+	a.BackupCodes = append(a.BackupCodes, backupCodes...)
+
+	return backupCodes, nil
+}
+
+// createWebAuthnChallenge creates a new WebAuthn challenge of the ceremony for the user and the client.
+func (a *Auth) createWebAuthnChallenge(ceremony enum.WebAuthnCeremonyEnum, ttl time.Duration) (WebAuthnChallenge, error) {
+	createWebAuthnChallengeParams := CreateWebAuthnChallengeParams{
+		Ceremony: ceremony,
+		UserID:   a.User.ID,
+		ClientID: a.client.ID,
+		Nonce:    a.nonce,
+		TTL:      ttl,
+	}
+	challenge, err := NewWebAuthnChallenge(createWebAuthnChallengeParams)
+	if err != nil {
+		return WebAuthnChallenge{}, err
+	}
+
+	challenge.SetToCreate()
+	a.WebAuthnChallenges = append(a.WebAuthnChallenges, challenge)
+
+	return challenge, nil
+}
+
+// verifiedEmail returns the email of the user if it is verified, otherwise the empty string is returned.
+func (a *Auth) verifiedEmail() string {
+	email, verified := a.User.Contact(enum.ContactChannelEmail)
+	if !verified {
+		return ""
+	}
+
+	return email
+}
+
+// enforceSessionLimit makes room for a new session of the client the user is authenticated for.
+// Only the sessions of this client are counted, expired sessions are set to remove and are not counted.
+// If the limit is reached, the sessions are evicted according to the eviction policy of the client,
+// or ErrSessionLimitExceeded is returned if the policy rejects the new session.
+func (a *Auth) enforceSessionLimit() error {
+	limit := a.sessionLimit.ForClient(a.client)
+	if limit.IsUnlimited() {
+		return nil
+	}
+
+	now := time.Now()
+	clientSessions := make([]*Session, 0, len(a.Sessions))
+	for i := range a.Sessions {
+		session := &a.Sessions[i]
+		if session.IsToRemove() || !session.IsIssuedTo(a.client.ID) {
+			continue
+		}
+
+		if session.ExpiresAt.Before(now) {
+			session.SetToRemove()
+			continue
+		}
+
+		clientSessions = append(clientSessions, session)
+	}
+
+	// The new session takes one place of the limit.
+	evictCount := len(clientSessions) - limit.MaxSessions + 1
+	if evictCount <= 0 {
+		return nil
+	}
+
+	switch limit.EvictionPolicy {
+	case enum.RejectNewSession:
+		return ErrSessionLimitExceeded
+	case enum.EvictLeastRecentlyUsed:
+		slices.SortFunc(clientSessions, func(a, b *Session) int {
+			return a.LastUsedAt.Compare(b.LastUsedAt)
+		})
+	default:
+		slices.SortFunc(clientSessions, func(a, b *Session) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+	}
+
+	for _, session := range clientSessions[:evictCount] {
+		session.SetToRemove()
+	}
+
+	return nil
+}
+
 func (a *Auth) addSession(session Session) {
 	a.Sessions = append(a.Sessions, session)
 }
@@ -172,3 +1115,45 @@ func (a *Auth) setUser(user User) {
 func (a *Auth) ClientID() int64 {
 	return a.client.ID
 }
+
+// loginAttempts returns the failed login attempts of the username and the client IP address,
+// the new attempts are added if there are no saved ones. The attempt of the username goes first,
+// the attempt of the address is omitted if the address is unknown.
+func (a *Auth) loginAttempts(username, ipAddress string) []*LoginAttempt {
+	keys := []LoginAttempt{NewLoginAttempt(enum.LoginAttemptKindUsername, username)}
+	if ipAddress != "" {
+		keys = append(keys, NewLoginAttempt(enum.LoginAttemptKindIP, ipAddress))
+	}
+
+	// The missing attempts are added before the pointers are taken, so the pointers stay valid.
+	for _, key := range keys {
+		if a.loginAttemptIndex(key.Kind, key.Key) < 0 {
+			a.LoginAttempts = append(a.LoginAttempts, key)
+		}
+	}
+
+	attempts := make([]*LoginAttempt, len(keys))
+	for i, key := range keys {
+		attempts[i] = &a.LoginAttempts[a.loginAttemptIndex(key.Kind, key.Key)]
+	}
+
+	return attempts
+}
+
+// loginAttempt returns the failed attempts of the kind and the key, the new attempt is added
+// if there is no saved one.
+func (a *Auth) loginAttempt(kind enum.LoginAttemptKindEnum, key string) *LoginAttempt {
+	i := a.loginAttemptIndex(kind, key)
+	if i < 0 {
+		a.LoginAttempts = append(a.LoginAttempts, NewLoginAttempt(kind, key))
+		i = len(a.LoginAttempts) - 1
+	}
+
+	return &a.LoginAttempts[i]
+}
+
+func (a *Auth) loginAttemptIndex(kind enum.LoginAttemptKindEnum, key string) int {
+	return slices.IndexFunc(a.LoginAttempts, func(attempt LoginAttempt) bool {
+		return attempt.Kind == kind && attempt.Key == key
+	})
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"fmt"
 	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 )
 
 // AuthOption is how options for the Auth are set up.
@@ -23,6 +24,10 @@ func WithAuthUser(user dto.User) AuthOption {
 			user.AvatarFileKey,
 			WithUserID(user.ID),
 			WithUserPasswordHash(user.PasswordHash),
+			WithUserStatus(user.Blocked, user.Deleted),
+			WithUserMustChangePassword(user.MustChangePassword),
+			WithUserEmail(user.Email, user.EmailVerified),
+			WithUserPhone(user.Phone, user.PhoneVerified),
 			WithUserRoles(user.Roles),
 			WithUserPermissions(user.Permissions),
 		)
@@ -58,8 +63,12 @@ func WithAuthSession(sessions ...dto.Session) AuthOption {
 				session.UserAgent,
 				session.Fingerprint,
 				WithSessionID(session.ID),
+				WithSessionClientID(session.ClientID),
 				WithSessionRefreshTokenID(session.RefreshTokenID),
+				WithSessionFamilyID(session.FamilyID),
 				WithSessionExpiresAt(session.ExpiresAt),
+				WithSessionCreatedAt(session.CreatedAt),
+				WithSessionLastUsedAt(session.LastUsedAt),
 			)
 			if err != nil {
 				return fmt.Errorf("%w: %w", ErrCreateSession, err)
@@ -74,6 +83,37 @@ func WithAuthSession(sessions ...dto.Session) AuthOption {
 	}
 }
 
+// WithAuthSessionLimit is an option which sets up the default session limit for the user authentication entity.
+// The session limit of the client the user is authenticated for takes precedence over the default one.
+func WithAuthSessionLimit(limit SessionLimit) AuthOption {
+	return func(a *Auth) error {
+		a.sessionLimit = limit
+
+		return nil
+	}
+}
+
+// WithAuthConsumedRefreshToken is an option which sets up the consumed refresh token
+// for the user authentication entity. The consumed refresh token is set when the presented refresh token
+// has already been rotated.
+func WithAuthConsumedRefreshToken(token dto.ConsumedRefreshToken) AuthOption {
+	return func(a *Auth) error {
+		if token.ID == emptyID {
+			return nil
+		}
+
+		a.ConsumedRefreshTokens = append(a.ConsumedRefreshTokens, ConsumedRefreshToken{
+			ID:             token.ID,
+			RefreshTokenID: token.RefreshTokenID,
+			FamilyID:       token.FamilyID,
+			UserID:         token.UserID,
+			ExpiresAt:      token.ExpiresAt,
+		})
+
+		return nil
+	}
+}
+
 // WithAuthDefaultRoles is an option which sets up the default roles for the user authentication entity.
 func WithAuthDefaultRoles(roles ...dto.Role) AuthOption {
 	return func(a *Auth) error {
@@ -92,3 +132,203 @@ func WithAuthDefaultPermissionCodes(permissions ...string) AuthOption {
 		return nil
 	}
 }
+
+// WithAuthSigningKey is an option which sets up the key for signing access tokens
+// for the user authentication entity. If the key is nil, access tokens are signed by the client secret key.
+func WithAuthSigningKey(key *jwtkeys.SigningKey) AuthOption {
+	return func(a *Auth) error {
+		a.signingKey = key
+
+		return nil
+	}
+}
+
+// WithAuthNonce is an option which sets up the nonce value passed by the client
+// for the user authentication entity. The nonce is put into the ID token.
+func WithAuthNonce(nonce string) AuthOption {
+	return func(a *Auth) error {
+		a.nonce = nonce
+
+		return nil
+	}
+}
+
+// WithAuthAuthorizationCode is an option which sets up the authorization code for the user authentication entity.
+func WithAuthAuthorizationCode(code dto.AuthorizationCode) AuthOption {
+	return func(a *Auth) error {
+		if code.ID == emptyID {
+			return nil
+		}
+
+		a.AuthorizationCodes = append(a.AuthorizationCodes, AuthorizationCode{
+			ID:                  code.ID,
+			CodeHash:            code.CodeHash,
+			UserID:              code.UserID,
+			ClientID:            code.ClientID,
+			RedirectURI:         code.RedirectURI,
+			CodeChallenge:       code.CodeChallenge,
+			CodeChallengeMethod: code.CodeChallengeMethod,
+			Nonce:               code.Nonce,
+			AuthTime:            code.AuthTime,
+			ExpiresAt:           code.ExpiresAt,
+			Used:                code.Used,
+		})
+
+		return nil
+	}
+}
+
+// WithAuthPasswordResetToken is an option which sets up the password reset token for the user authentication entity.
+func WithAuthPasswordResetToken(token dto.PasswordResetToken) AuthOption {
+	return func(a *Auth) error {
+		if token.ID == emptyID {
+			return nil
+		}
+
+		a.PasswordResetTokens = append(a.PasswordResetTokens, PasswordResetToken{
+			ID:        token.ID,
+			TokenHash: token.TokenHash,
+			UserID:    token.UserID,
+			ExpiresAt: token.ExpiresAt,
+			Used:      token.Used,
+		})
+
+		return nil
+	}
+}
+
+// WithAuthMFA is an option which sets up the second authentication factor data of the user
+// for the user authentication entity.
+func WithAuthMFA(mfa dto.UserMFA) AuthOption {
+	return func(a *Auth) error {
+		if mfa.TOTPCredential.ID != emptyID {
+			a.TOTP = TOTPCredential{
+				ID:           mfa.TOTPCredential.ID,
+				UserID:       mfa.TOTPCredential.UserID,
+				Secret:       mfa.TOTPCredential.Secret,
+				Confirmed:    mfa.TOTPCredential.Confirmed,
+				LastUsedStep: mfa.TOTPCredential.LastUsedStep,
+			}
+		}
+
+		a.BackupCodes = make([]BackupCode, len(mfa.BackupCodes))
+		for i, code := range mfa.BackupCodes {
+			a.BackupCodes[i] = BackupCode{
+				ID:       code.ID,
+				UserID:   code.UserID,
+				CodeHash: code.CodeHash,
+				Used:     code.Used,
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithAuthMFAChallenge is an option which sets up the MFA challenge for the user authentication entity.
+func WithAuthMFAChallenge(challenge dto.MFAChallenge) AuthOption {
+	return func(a *Auth) error {
+		if challenge.ID == emptyID {
+			return nil
+		}
+
+		a.MFAChallenges = append(a.MFAChallenges, MFAChallenge{
+			ID:          challenge.ID,
+			TokenHash:   challenge.TokenHash,
+			UserID:      challenge.UserID,
+			ClientID:    challenge.ClientID,
+			UserAgent:   challenge.UserAgent,
+			Fingerprint: challenge.Fingerprint,
+			Issuer:      challenge.Issuer,
+			Nonce:       challenge.Nonce,
+			ExpiresAt:   challenge.ExpiresAt,
+			Attempts:    challenge.Attempts,
+			Used:        challenge.Used,
+		})
+
+		return nil
+	}
+}
+
+// WithAuthWebAuthnCredentials is an option which sets up the WebAuthn credentials of the user
+// for the user authentication entity.
+func WithAuthWebAuthnCredentials(credentials ...dto.WebAuthnCredential) AuthOption {
+	return func(a *Auth) error {
+		for _, credential := range credentials {
+			if credential.ID == emptyID {
+				continue
+			}
+
+			a.WebAuthnCredentials = append(a.WebAuthnCredentials, WebAuthnCredential{
+				ID:           credential.ID,
+				UserID:       credential.UserID,
+				CredentialID: credential.CredentialID,
+				PublicKey:    credential.PublicKey,
+				SignCount:    credential.SignCount,
+				Name:         credential.Name,
+			})
+		}
+
+		return nil
+	}
+}
+
+// WithAuthWebAuthnChallenge is an option which sets up the WebAuthn challenge for the user authentication entity.
+func WithAuthWebAuthnChallenge(challenge dto.WebAuthnChallenge) AuthOption {
+	return func(a *Auth) error {
+		if challenge.ID == emptyID {
+			return nil
+		}
+
+		a.WebAuthnChallenges = append(a.WebAuthnChallenges, WebAuthnChallenge{
+			ID:        challenge.ID,
+			Challenge: challenge.Challenge,
+			Ceremony:  challenge.Ceremony,
+			UserID:    challenge.UserID,
+			ClientID:  challenge.ClientID,
+			Nonce:     challenge.Nonce,
+			ExpiresAt: challenge.ExpiresAt,
+			Used:      challenge.Used,
+		})
+
+		return nil
+	}
+}
+
+// WithAuthLoginThrottle is an option which sets up the brute-force protection of the login
+// for the user authentication entity.
+func WithAuthLoginThrottle(throttle LoginThrottle) AuthOption {
+	return func(a *Auth) error {
+		a.loginThrottle = throttle
+
+		return nil
+	}
+}
+
+// WithAuthPasswordHasher is an option which sets up the password hasher for the user authentication entity.
+func WithAuthPasswordHasher(hasher PasswordHasher) AuthOption {
+	return func(a *Auth) error {
+		a.passwordHasher = hasher
+
+		return nil
+	}
+}
+
+// WithAuthLoginAttempts is an option which sets up the saved failed login attempts of the username
+// and the client IP address for the user authentication entity.
+func WithAuthLoginAttempts(attempts ...dto.LoginAttempt) AuthOption {
+	return func(a *Auth) error {
+		for _, attempt := range attempts {
+			a.LoginAttempts = append(a.LoginAttempts, LoginAttempt{
+				ID:            attempt.ID,
+				Kind:          attempt.Kind,
+				Key:           attempt.Key,
+				Failures:      attempt.Failures,
+				LastFailureAt: attempt.LastFailureAt,
+				LockedUntil:   attempt.LockedUntil,
+			})
+		}
+
+		return nil
+	}
+}
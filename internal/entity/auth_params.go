@@ -2,28 +2,45 @@ package entity
 
 import (
 	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
 	"time"
 )
 
 // LoginParams is a data for logging in a user.
+// Username and IPAddress are the keys the failed logins are counted by, IPAddress is optional.
+// MFAChallengeTTL is the lifetime of the MFA challenge issued if the user must pass the second authentication factor.
 type LoginParams struct {
-	Password    string
-	UserAgent   string
-	Fingerprint string
-	Issuer      string
+	Username        string
+	Password        string
+	IPAddress       string
+	UserAgent       string
+	Fingerprint     string
+	Issuer          string
+	MFAChallengeTTL time.Duration
+}
+
+// VerifyMFAParams is a data for completing the login by the second authentication factor.
+// Code is the TOTP code or the backup code of the user. MaxAttempts is the number of the mismatched codes
+// of the MFA challenge, it also limits the mismatched codes of the username across the challenges,
+// the MFA codes of the username are locked for LockoutDuration then.
+type VerifyMFAParams struct {
+	Code            string
+	MaxAttempts     int
+	LockoutDuration time.Duration
 }
 
 // RegisterParams is a data for registering a user.
 type RegisterParams struct {
-	Username      string
-	Password      string
-	FullName      string
-	DateOfBirth   *time.Time
-	Gender        *enum.GenderEnum
-	AvatarFileKey *string
-	UserAgent     string
-	Fingerprint   string
-	Issuer        string
+	Username       string
+	Password       string
+	PasswordPolicy PasswordPolicy
+	FullName       string
+	DateOfBirth    *time.Time
+	Gender         *enum.GenderEnum
+	AvatarFileKey  *string
+	UserAgent      string
+	Fingerprint    string
+	Issuer         string
 }
 
 // RefreshTokensParams is a data for refreshing user tokens.
@@ -32,3 +49,129 @@ type RefreshTokensParams struct {
 	Fingerprint string
 	Issuer      string
 }
+
+// AuthorizeParams is a data for authorizing a client on behalf of a user by the authorization code flow.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// MFACode is the TOTP code or the backup code of the user, it is required if the user must pass
+// the second authentication factor. The MFA codes are locked for MFALockoutDuration
+// after MFAMaxAttempts failed codes.
+type AuthorizeParams struct {
+	Username            string
+	Password            string
+	IPAddress           string
+	MFACode             string
+	MFAMaxAttempts      int
+	MFALockoutDuration  time.Duration
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	CodeTTL             time.Duration
+}
+
+// ExchangeAuthorizationCodeParams is a data for exchanging the authorization code for user tokens.
+type ExchangeAuthorizationCodeParams struct {
+	RedirectURI  string
+	CodeVerifier string
+	UserAgent    string
+	Fingerprint  string
+	Issuer       string
+}
+
+// ChangePasswordParams is a data for changing the user password.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// The session with ExceptRefreshTokenID is kept, the other user sessions are revoked.
+type ChangePasswordParams struct {
+	Username             string
+	IPAddress            string
+	CurrentPassword      string
+	NewPassword          string
+	PasswordPolicy       PasswordPolicy
+	ExceptRefreshTokenID string
+}
+
+// ConfirmPasswordResetParams is a data for resetting the forgotten password by the password reset token.
+type ConfirmPasswordResetParams struct {
+	NewPassword    string
+	PasswordPolicy PasswordPolicy
+}
+
+// EnrollTOTPParams is a data for enrolling the TOTP credential of the user.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// Issuer is the name of the service shown by the authenticator application.
+type EnrollTOTPParams struct {
+	Username  string
+	Password  string
+	IPAddress string
+	Issuer    string
+}
+
+// ConfirmTOTPParams is a data for confirming the enrolled TOTP credential of the user by the first code.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+type ConfirmTOTPParams struct {
+	Username         string
+	Password         string
+	IPAddress        string
+	Code             string
+	BackupCodesCount int
+}
+
+// DisableTOTPParams is a data for disabling the TOTP credential of the user.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// Code is the TOTP code or the backup code of the user.
+type DisableTOTPParams struct {
+	Username  string
+	Password  string
+	IPAddress string
+	Code      string
+}
+
+// RegenerateBackupCodesParams is a data for replacing the backup codes of the user with new ones.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// Code is the TOTP code or the backup code of the user.
+type RegenerateBackupCodesParams struct {
+	Username  string
+	Password  string
+	IPAddress string
+	Code      string
+	Count     int
+}
+
+// BeginWebAuthnRegistrationParams is a data for starting the registration of the WebAuthn credential of the user.
+// Username and IPAddress are the keys the failed password checks are counted by, IPAddress is optional.
+// RelyingPartyName is the name of the service shown by the authenticator.
+type BeginWebAuthnRegistrationParams struct {
+	Username         string
+	Password         string
+	IPAddress        string
+	RelyingParty     webauthn.RelyingParty
+	RelyingPartyName string
+	ChallengeTTL     time.Duration
+}
+
+// FinishWebAuthnRegistrationParams is a data for completing the registration of the WebAuthn credential
+// of the user. ClientDataJSON and AttestationObject are the response of navigator.credentials.create(),
+// Name is the name of the credential shown to the user.
+type FinishWebAuthnRegistrationParams struct {
+	RelyingParty      webauthn.RelyingParty
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Name              string
+}
+
+// BeginWebAuthnLoginParams is a data for starting the login by the WebAuthn credential.
+type BeginWebAuthnLoginParams struct {
+	RelyingParty webauthn.RelyingParty
+	ChallengeTTL time.Duration
+}
+
+// FinishWebAuthnLoginParams is a data for completing the login by the WebAuthn credential.
+// ClientDataJSON, AuthenticatorData and Signature are the response of navigator.credentials.get().
+type FinishWebAuthnLoginParams struct {
+	RelyingParty      webauthn.RelyingParty
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserAgent         string
+	Fingerprint       string
+	Issuer            string
+}
@@ -1,10 +1,15 @@
 package entity
 
 import (
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/p1xray/pxr-sso/internal/dto"
 	"github.com/p1xray/pxr-sso/internal/enum"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"github.com/p1xray/pxr-sso/pkg/passhash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"slices"
 	"testing"
 	"time"
 )
@@ -23,6 +28,7 @@ const (
 	refreshTokenID = "6424f67d-61c3-4251-b193-f2da172f9e01"
 
 	clientID         = 1
+	clientCode       = "test client"
 	userAgent        = "test user agent"
 	fingerprint      = "test fingerprint"
 	issuer           = "test issuer"
@@ -128,6 +134,338 @@ func Test_Auth_Login(t *testing.T) {
 	}
 }
 
+func Test_Auth_Login_IDToken(t *testing.T) {
+	const nonce = "test nonce"
+
+	dateOfBirth := time.Date(2000, 5, 16, 0, 0, 0, 0, time.UTC)
+	gender := enum.FEMALE
+
+	user := dto.User{
+		ID:           userID,
+		Username:     "test@mail.com",
+		FullName:     "test user",
+		DateOfBirth:  &dateOfBirth,
+		Gender:       &gender,
+		PasswordHash: passwordHash,
+	}
+	client := dto.Client{
+		ID:        clientID,
+		Code:      clientCode,
+		SecretKey: secretKey,
+	}
+
+	auth, err := NewAuth(
+		accessTokenTTL,
+		refreshTokenTTL,
+		WithAuthUser(user),
+		WithAuthClient(client),
+		WithAuthNonce(nonce),
+	)
+	require.NoError(t, err)
+
+	loginParams := LoginParams{
+		Password:    validPassword,
+		UserAgent:   userAgent,
+		Fingerprint: fingerprint,
+		Issuer:      issuer,
+	}
+	tokens, err := auth.Login(loginParams)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.IDToken)
+
+	token, err := jwt.ParseSigned(tokens.IDToken, []jose.SignatureAlgorithm{jose.HS256})
+	require.NoError(t, err)
+
+	var claims jwtclaims.IDTokenClaims
+	err = token.Claims([]byte(secretKey), &claims)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", claims.Subject)
+	assert.Equal(t, issuer, claims.Issuer)
+	assert.Equal(t, jwt.Audience{clientCode}, claims.Audience)
+	assert.Equal(t, nonce, claims.Nonce)
+	assert.Equal(t, user.FullName, claims.Name)
+	assert.Equal(t, user.Username, claims.PreferredUsername)
+	assert.Equal(t, "2000-05-16", claims.Birthdate)
+	assert.Equal(t, "female", claims.Gender)
+	assert.NotNil(t, claims.AuthTime)
+}
+
+func Test_Auth_Login_Rehash(t *testing.T) {
+	argon2idHasher := passhash.Argon2id{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+
+	testCases := []struct {
+		name             string
+		hasher           PasswordHasher
+		password         string
+		expectedRehashed bool
+	}{
+		{
+			name:             "rehashes the password with the new algorithm",
+			hasher:           argon2idHasher,
+			password:         validPassword,
+			expectedRehashed: true,
+		},
+		{
+			name:             "rehashes the password with the new parameters",
+			hasher:           passhash.Bcrypt{Cost: 4},
+			password:         validPassword,
+			expectedRehashed: true,
+		},
+		{
+			name:     "keeps the up-to-date hash",
+			hasher:   passhash.Bcrypt{Cost: 10},
+			password: validPassword,
+		},
+		{
+			name:     "keeps the hash when the password is invalid",
+			hasher:   argon2idHasher,
+			password: invalidPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(dto.User{ID: userID, PasswordHash: passwordHash}),
+				WithAuthClient(dto.Client{ID: clientID, SecretKey: secretKey}),
+				WithAuthPasswordHasher(tc.hasher),
+			)
+			require.NoError(t, err)
+
+			_, _ = auth.Login(LoginParams{Password: tc.password, Issuer: issuer})
+
+			if !tc.expectedRehashed {
+				assert.Equal(t, passwordHash, auth.User.PasswordHash)
+				assert.False(t, auth.User.IsToUpdate())
+
+				return
+			}
+
+			assert.NotEqual(t, passwordHash, auth.User.PasswordHash)
+			assert.True(t, auth.User.IsToUpdate())
+			assert.False(t, tc.hasher.NeedsRehash(auth.User.PasswordHash))
+			assert.NoError(t, tc.hasher.Verify(auth.User.PasswordHash, validPassword))
+		})
+	}
+}
+
+func Test_Auth_Authorize(t *testing.T) {
+	client := dto.Client{
+		ID:           clientID,
+		Code:         clientCode,
+		SecretKey:    secretKey,
+		RedirectURIs: []string{redirectURI},
+	}
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+
+	testCases := []struct {
+		name          string
+		data          AuthorizeParams
+		expectedError error
+	}{
+		{
+			name: "successfully authorizes the client",
+			data: AuthorizeParams{
+				Password:            validPassword,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				CodeTTL:             time.Minute,
+			},
+		},
+		{
+			name: "throws an error when given password is invalid",
+			data: AuthorizeParams{
+				Password:            invalidPassword,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				CodeTTL:             time.Minute,
+			},
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name: "throws an error when the redirect URI is not registered",
+			data: AuthorizeParams{
+				Password:            validPassword,
+				RedirectURI:         "https://evil.example.com/callback",
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				CodeTTL:             time.Minute,
+			},
+			expectedError: ErrInvalidRedirectURI,
+		},
+		{
+			name: "throws an error when the code challenge method is not supported",
+			data: AuthorizeParams{
+				Password:            validPassword,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: "plain",
+				CodeTTL:             time.Minute,
+			},
+			expectedError: ErrUnsupportedCodeChallengeMethod,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(accessTokenTTL, refreshTokenTTL, WithAuthUser(user), WithAuthClient(client))
+			require.NoError(t, err)
+
+			code, err := auth.Authorize(tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, auth.AuthorizationCodes)
+			} else {
+				require.NoError(t, err)
+
+				assert.NotEmpty(t, code.Code)
+				require.Len(t, auth.AuthorizationCodes, 1)
+				assert.True(t, auth.AuthorizationCodes[0].IsToCreate())
+				assert.Equal(t, int64(userID), auth.AuthorizationCodes[0].UserID)
+				assert.Equal(t, int64(clientID), auth.AuthorizationCodes[0].ClientID)
+				assert.Empty(t, auth.Sessions)
+			}
+		})
+	}
+}
+
+func Test_Auth_ExchangeAuthorizationCode(t *testing.T) {
+	const nonce = "test nonce"
+
+	client := dto.Client{
+		ID:           clientID,
+		Code:         clientCode,
+		SecretKey:    secretKey,
+		RedirectURIs: []string{redirectURI},
+	}
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+
+	testCases := []struct {
+		name          string
+		code          dto.AuthorizationCode
+		data          ExchangeAuthorizationCodeParams
+		expectedError error
+	}{
+		{
+			name: "successfully exchanges the code for tokens",
+			code: dto.AuthorizationCode{
+				ID:                  1,
+				UserID:              userID,
+				ClientID:            clientID,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				Nonce:               nonce,
+				AuthTime:            time.Now(),
+				ExpiresAt:           time.Now().Add(time.Minute),
+			},
+			data: ExchangeAuthorizationCodeParams{
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+				UserAgent:    userAgent,
+				Fingerprint:  fingerprint,
+				Issuer:       issuer,
+			},
+		},
+		{
+			name: "throws an error when the code is not found",
+			data: ExchangeAuthorizationCodeParams{
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+				UserAgent:    userAgent,
+				Fingerprint:  fingerprint,
+				Issuer:       issuer,
+			},
+			expectedError: ErrAuthorizationCodeNotFound,
+		},
+		{
+			name: "throws an error when the code is already used",
+			code: dto.AuthorizationCode{
+				ID:                  1,
+				UserID:              userID,
+				ClientID:            clientID,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				ExpiresAt:           time.Now().Add(time.Minute),
+				Used:                true,
+			},
+			data: ExchangeAuthorizationCodeParams{
+				RedirectURI:  redirectURI,
+				CodeVerifier: codeVerifier,
+				UserAgent:    userAgent,
+				Fingerprint:  fingerprint,
+				Issuer:       issuer,
+			},
+			expectedError: ErrAuthorizationCodeUsed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(user),
+				WithAuthClient(client),
+				WithAuthAuthorizationCode(tc.code),
+			)
+			require.NoError(t, err)
+
+			tokens, err := auth.ExchangeAuthorizationCode(tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, auth.Sessions)
+			} else {
+				require.NoError(t, err)
+
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.NotEmpty(t, tokens.IDToken)
+
+				require.Len(t, auth.AuthorizationCodes, 1)
+				assert.True(t, auth.AuthorizationCodes[0].Used)
+				assert.True(t, auth.AuthorizationCodes[0].IsToUpdate())
+				assert.True(t, auth.Sessions[0].IsToCreate())
+
+				token, err := jwt.ParseSigned(tokens.IDToken, []jose.SignatureAlgorithm{jose.HS256})
+				require.NoError(t, err)
+
+				var claims jwtclaims.IDTokenClaims
+				err = token.Claims([]byte(secretKey), &claims)
+				require.NoError(t, err)
+
+				assert.Equal(t, nonce, claims.Nonce)
+			}
+		})
+	}
+}
+
 func Test_Auth_Register(t *testing.T) {
 	var (
 		username      = "test@mail.com"
@@ -201,7 +539,7 @@ func Test_Auth_Register(t *testing.T) {
 				Fingerprint:   fingerprint,
 				Issuer:        issuer,
 			},
-			expectedError: ErrGeneratePasswordHash,
+			expectedError: ErrWeakPassword,
 		},
 	}
 
@@ -344,11 +682,306 @@ func Test_Auth_RefreshTokens(t *testing.T) {
 
 				assert.True(t, previousSession.IsToRemove())
 				assert.True(t, newSession.IsToCreate())
+				assert.Equal(t, previousSession.FamilyID, newSession.FamilyID)
+				assert.Equal(t, previousSession.CreatedAt, newSession.CreatedAt)
+				assert.True(t, newSession.LastUsedAt.After(previousSession.LastUsedAt))
+
+				require.Len(t, auth.ConsumedRefreshTokens, 1)
+				assert.True(t, auth.ConsumedRefreshTokens[0].IsToCreate())
+				assert.Equal(t, refreshTokenID, auth.ConsumedRefreshTokens[0].RefreshTokenID)
+				assert.Equal(t, previousSession.FamilyID, auth.ConsumedRefreshTokens[0].FamilyID)
 			}
 		})
 	}
 }
 
+func Test_Auth_RefreshTokens_Reuse(t *testing.T) {
+	const familyID = "3c1d2b7e-0f5a-4d8e-9b6a-2f4e8c7d1a90"
+
+	sessionExpires := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name                 string
+		consumedRefreshToken dto.ConsumedRefreshToken
+		familySessions       []dto.Session
+		expectedError        error
+	}{
+		{
+			name: "revokes the token family when the refresh token is reused",
+			consumedRefreshToken: dto.ConsumedRefreshToken{
+				ID:             1,
+				RefreshTokenID: refreshTokenID,
+				FamilyID:       familyID,
+				UserID:         userID,
+				ExpiresAt:      sessionExpires,
+			},
+			familySessions: []dto.Session{
+				{
+					ID:             sessionID,
+					UserID:         userID,
+					RefreshTokenID: "c9a4b3d1-7e2f-4a6b-8c5d-1e0f9a8b7c6d",
+					FamilyID:       familyID,
+					UserAgent:      userAgent,
+					Fingerprint:    fingerprint,
+					ExpiresAt:      sessionExpires,
+				},
+			},
+			expectedError: ErrRefreshTokenReused,
+		},
+		{
+			name: "throws an error when the token family is already revoked",
+			consumedRefreshToken: dto.ConsumedRefreshToken{
+				ID:             1,
+				RefreshTokenID: refreshTokenID,
+				FamilyID:       familyID,
+				UserID:         userID,
+				ExpiresAt:      sessionExpires,
+			},
+			expectedError: ErrRefreshTokenReused,
+		},
+		{
+			name:          "throws an error when the session is not found",
+			expectedError: ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(dto.User{ID: userID}),
+				WithAuthClient(dto.Client{ID: clientID, SecretKey: secretKey}),
+				WithAuthSession(tc.familySessions...),
+				WithAuthConsumedRefreshToken(tc.consumedRefreshToken),
+			)
+			require.NoError(t, err)
+
+			_, err = auth.RefreshTokens(RefreshTokensParams{
+				UserAgent:   userAgent,
+				Fingerprint: fingerprint,
+				Issuer:      issuer,
+			})
+
+			assert.ErrorIs(t, err, tc.expectedError)
+
+			require.Len(t, auth.Sessions, len(tc.familySessions))
+			for _, session := range auth.Sessions {
+				assert.True(t, session.IsToRemove())
+			}
+
+			for _, consumedRefreshToken := range auth.ConsumedRefreshTokens {
+				assert.False(t, consumedRefreshToken.IsToCreate())
+			}
+		})
+	}
+}
+
+func Test_Auth_RevokeSessions(t *testing.T) {
+	const currentRefreshTokenID = "0b8e4f2a-5c6d-4e7f-8a9b-1c2d3e4f5a6b"
+
+	sessionExpires := time.Now().Add(time.Hour)
+	sessions := []dto.Session{
+		{
+			ID:             1,
+			UserID:         userID,
+			RefreshTokenID: refreshTokenID,
+			ExpiresAt:      sessionExpires,
+		},
+		{
+			ID:             2,
+			UserID:         userID,
+			RefreshTokenID: currentRefreshTokenID,
+			ExpiresAt:      sessionExpires,
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		exceptRefreshTokenID string
+		expectedRevoked      []int64
+	}{
+		{
+			name:            "revokes all sessions",
+			expectedRevoked: []int64{1, 2},
+		},
+		{
+			name:                 "revokes all sessions except the current one",
+			exceptRefreshTokenID: currentRefreshTokenID,
+			expectedRevoked:      []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(accessTokenTTL, refreshTokenTTL, WithAuthSession(sessions...))
+			require.NoError(t, err)
+
+			revokedCount := auth.RevokeSessions(tc.exceptRefreshTokenID)
+
+			assert.Equal(t, len(tc.expectedRevoked), revokedCount)
+			for _, session := range auth.Sessions {
+				assert.Equal(t, slices.Contains(tc.expectedRevoked, session.ID), session.IsToRemove())
+			}
+		})
+	}
+}
+
+func Test_Auth_Login_SessionLimit(t *testing.T) {
+	const otherClientID = 2
+
+	now := time.Now()
+	sessionExpires := now.Add(time.Hour)
+	sessions := []dto.Session{
+		{
+			ID:             1,
+			UserID:         userID,
+			ClientID:       ptr(int64(clientID)),
+			RefreshTokenID: "1",
+			ExpiresAt:      sessionExpires,
+			CreatedAt:      now.Add(-3 * time.Hour),
+			LastUsedAt:     now.Add(-time.Minute),
+		},
+		{
+			ID:             2,
+			UserID:         userID,
+			ClientID:       ptr(int64(clientID)),
+			RefreshTokenID: "2",
+			ExpiresAt:      sessionExpires,
+			CreatedAt:      now.Add(-2 * time.Hour),
+			LastUsedAt:     now.Add(-time.Hour),
+		},
+		{
+			ID:             3,
+			UserID:         userID,
+			ClientID:       ptr(int64(clientID)),
+			RefreshTokenID: "3",
+			ExpiresAt:      now.Add(-time.Minute),
+			CreatedAt:      now.Add(-4 * time.Hour),
+			LastUsedAt:     now.Add(-4 * time.Hour),
+		},
+		{
+			ID:             4,
+			UserID:         userID,
+			ClientID:       ptr(int64(otherClientID)),
+			RefreshTokenID: "4",
+			ExpiresAt:      sessionExpires,
+			CreatedAt:      now.Add(-5 * time.Hour),
+			LastUsedAt:     now.Add(-5 * time.Hour),
+		},
+		{
+			ID:             5,
+			UserID:         userID,
+			RefreshTokenID: "5",
+			ExpiresAt:      sessionExpires,
+			CreatedAt:      now.Add(-6 * time.Hour),
+			LastUsedAt:     now.Add(-6 * time.Hour),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		limit           SessionLimit
+		client          dto.Client
+		expectedRemoved []int64
+		expectedError   error
+	}{
+		{
+			name:            "evicts the oldest session of the client",
+			limit:           SessionLimit{MaxSessions: 2, EvictionPolicy: enum.EvictOldest},
+			client:          dto.Client{ID: clientID, SecretKey: secretKey},
+			expectedRemoved: []int64{1, 3},
+		},
+		{
+			name:            "evicts the least recently used session of the client",
+			limit:           SessionLimit{MaxSessions: 2, EvictionPolicy: enum.EvictLeastRecentlyUsed},
+			client:          dto.Client{ID: clientID, SecretKey: secretKey},
+			expectedRemoved: []int64{2, 3},
+		},
+		{
+			name:          "rejects the new session",
+			limit:         SessionLimit{MaxSessions: 2, EvictionPolicy: enum.RejectNewSession},
+			client:        dto.Client{ID: clientID, SecretKey: secretKey},
+			expectedError: ErrSessionLimitExceeded,
+		},
+		{
+			name:  "uses the session limit of the client",
+			limit: SessionLimit{MaxSessions: 2, EvictionPolicy: enum.EvictOldest},
+			client: dto.Client{
+				ID:          clientID,
+				SecretKey:   secretKey,
+				MaxSessions: ptr(int32(3)),
+			},
+			expectedRemoved: []int64{3},
+		},
+		{
+			name:  "uses the eviction policy of the client",
+			limit: SessionLimit{MaxSessions: 2, EvictionPolicy: enum.EvictOldest},
+			client: dto.Client{
+				ID:                    clientID,
+				SecretKey:             secretKey,
+				SessionEvictionPolicy: ptr(enum.RejectNewSession),
+			},
+			expectedError: ErrSessionLimitExceeded,
+		},
+		{
+			name:            "does not limit sessions when the limit is zero",
+			limit:           SessionLimit{EvictionPolicy: enum.RejectNewSession},
+			client:          dto.Client{ID: clientID, SecretKey: secretKey},
+			expectedRemoved: []int64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthSessionLimit(tc.limit),
+				WithAuthUser(dto.User{ID: userID, PasswordHash: passwordHash}),
+				WithAuthClient(tc.client),
+				WithAuthSession(sessions...),
+			)
+			require.NoError(t, err)
+
+			_, err = auth.Login(LoginParams{
+				Password:    validPassword,
+				UserAgent:   userAgent,
+				Fingerprint: fingerprint,
+				Issuer:      issuer,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, auth.Sessions, len(sessions)+1)
+
+			for _, session := range auth.Sessions[:len(sessions)] {
+				assert.Equal(t, slices.Contains(tc.expectedRemoved, session.ID), session.IsToRemove())
+			}
+
+			newSession := auth.Sessions[len(sessions)]
+			assert.True(t, newSession.IsToCreate())
+			require.NotNil(t, newSession.ClientID)
+			assert.Equal(t, int64(clientID), *newSession.ClientID)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func Test_Auth_Logout(t *testing.T) {
 	sessionExpires := time.Now().Add(time.Hour)
 
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"regexp"
+	"time"
+)
+
+// CodeChallengeMethodS256 is the only supported PKCE code challenge method.
+const CodeChallengeMethodS256 = "S256"
+
+// authorizationCodeLength is the number of random bytes of the authorization code.
+const authorizationCodeLength = 32
+
+// codeChallengeRegexp matches the value of the PKCE code challenge and code verifier (RFC 7636, section 4.1).
+var codeChallengeRegexp = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// AuthorizationCode is the OAuth 2.0 authorization code entity.
+// The code itself is known only when it is created, the storage keeps the hash of the code.
+type AuthorizationCode struct {
+	ID                  int64
+	Code                string
+	CodeHash            string
+	UserID              int64
+	ClientID            int64
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+	Used                bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewAuthorizationCode returns a new authorization code entity with the generated code.
+func NewAuthorizationCode(data CreateAuthorizationCodeParams) (AuthorizationCode, error) {
+	if data.CodeChallengeMethod != CodeChallengeMethodS256 {
+		return AuthorizationCode{}, ErrUnsupportedCodeChallengeMethod
+	}
+
+	if !codeChallengeRegexp.MatchString(data.CodeChallenge) {
+		return AuthorizationCode{}, ErrInvalidCodeChallenge
+	}
+
+	codeBytes := make([]byte, authorizationCodeLength)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return AuthorizationCode{}, fmt.Errorf("%w: %w", ErrCreateAuthorizationCode, err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	now := time.Now()
+
+	return AuthorizationCode{
+		Code:                code,
+		CodeHash:            HashAuthorizationCode(code),
+		UserID:              data.UserID,
+		ClientID:            data.ClientID,
+		RedirectURI:         data.RedirectURI,
+		CodeChallenge:       data.CodeChallenge,
+		CodeChallengeMethod: data.CodeChallengeMethod,
+		Nonce:               data.Nonce,
+		AuthTime:            data.AuthTime,
+		ExpiresAt:           now.Add(data.TTL),
+	}, nil
+}
+
+// HashAuthorizationCode returns the hash of the authorization code which is kept in the storage.
+func HashAuthorizationCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Redeem checks that the authorization code is issued to the client for the redirect URI
+// and matches the PKCE code verifier, and marks the code as used.
+func (c *AuthorizationCode) Redeem(clientID int64, redirectURI, codeVerifier string) error {
+	const op = "entity.AuthorizationCode.Redeem"
+
+	if c.Used {
+		return fmt.Errorf("%s: %w", op, ErrAuthorizationCodeUsed)
+	}
+
+	if c.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("%s: %w", op, ErrAuthorizationCodeExpired)
+	}
+
+	if c.ClientID != clientID || c.RedirectURI != redirectURI {
+		return fmt.Errorf("%s: %w", op, ErrAuthorizationCodeMismatch)
+	}
+
+	if !codeChallengeRegexp.MatchString(codeVerifier) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCodeVerifier)
+	}
+
+	verifierHash := sha256.Sum256([]byte(codeVerifier))
+	expectedChallenge := base64.RawURLEncoding.EncodeToString(verifierHash[:])
+	if subtle.ConstantTimeCompare([]byte(expectedChallenge), []byte(c.CodeChallenge)) != 1 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidCodeVerifier)
+	}
+
+	c.Used = true
+
+	return nil
+}
+
+func (c *AuthorizationCode) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *AuthorizationCode) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *AuthorizationCode) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *AuthorizationCode) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *AuthorizationCode) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *AuthorizationCode) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *AuthorizationCode) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
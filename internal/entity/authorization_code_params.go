@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// CreateAuthorizationCodeParams is a data for creating new authorization code.
+type CreateAuthorizationCodeParams struct {
+	UserID              int64
+	ClientID            int64
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	AuthTime            time.Time
+	TTL                 time.Duration
+}
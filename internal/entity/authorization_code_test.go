@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	redirectURI   = "https://client.example.com/callback"
+	codeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	codeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func Test_NewAuthorizationCode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          CreateAuthorizationCodeParams
+		expectedError error
+	}{
+		{
+			name: "successfully creates an authorization code",
+			data: CreateAuthorizationCodeParams{
+				UserID:              userID,
+				ClientID:            clientID,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				TTL:                 time.Minute,
+			},
+		},
+		{
+			name: "throws an error when the code challenge method is plain",
+			data: CreateAuthorizationCodeParams{
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: "plain",
+				TTL:                 time.Minute,
+			},
+			expectedError: ErrUnsupportedCodeChallengeMethod,
+		},
+		{
+			name: "throws an error when the code challenge is too short",
+			data: CreateAuthorizationCodeParams{
+				RedirectURI:         redirectURI,
+				CodeChallenge:       "short",
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				TTL:                 time.Minute,
+			},
+			expectedError: ErrInvalidCodeChallenge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := NewAuthorizationCode(tc.data)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.NotEmpty(t, code.Code)
+				assert.Equal(t, HashAuthorizationCode(code.Code), code.CodeHash)
+				assert.NotEqual(t, code.Code, code.CodeHash)
+				assert.False(t, code.Used)
+				assert.True(t, code.ExpiresAt.After(time.Now()))
+			}
+		})
+	}
+}
+
+func Test_AuthorizationCode_Redeem(t *testing.T) {
+	testCases := []struct {
+		name          string
+		code          AuthorizationCode
+		clientID      int64
+		redirectURI   string
+		codeVerifier  string
+		expectedError error
+	}{
+		{
+			name:         "successfully redeems the code",
+			code:         newTestAuthorizationCode(time.Minute, false),
+			clientID:     clientID,
+			redirectURI:  redirectURI,
+			codeVerifier: codeVerifier,
+		},
+		{
+			name:          "throws an error when the code is already used",
+			code:          newTestAuthorizationCode(time.Minute, true),
+			clientID:      clientID,
+			redirectURI:   redirectURI,
+			codeVerifier:  codeVerifier,
+			expectedError: ErrAuthorizationCodeUsed,
+		},
+		{
+			name:          "throws an error when the code is expired",
+			code:          newTestAuthorizationCode(-time.Minute, false),
+			clientID:      clientID,
+			redirectURI:   redirectURI,
+			codeVerifier:  codeVerifier,
+			expectedError: ErrAuthorizationCodeExpired,
+		},
+		{
+			name:          "throws an error when the code is issued to another client",
+			code:          newTestAuthorizationCode(time.Minute, false),
+			clientID:      clientID + 1,
+			redirectURI:   redirectURI,
+			codeVerifier:  codeVerifier,
+			expectedError: ErrAuthorizationCodeMismatch,
+		},
+		{
+			name:          "throws an error when the code is issued for another redirect URI",
+			code:          newTestAuthorizationCode(time.Minute, false),
+			clientID:      clientID,
+			redirectURI:   "https://evil.example.com/callback",
+			codeVerifier:  codeVerifier,
+			expectedError: ErrAuthorizationCodeMismatch,
+		},
+		{
+			name:          "throws an error when the code verifier does not match the challenge",
+			code:          newTestAuthorizationCode(time.Minute, false),
+			clientID:      clientID,
+			redirectURI:   redirectURI,
+			codeVerifier:  "aBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+			expectedError: ErrInvalidCodeVerifier,
+		},
+		{
+			name:          "throws an error when the code verifier has an invalid format",
+			code:          newTestAuthorizationCode(time.Minute, false),
+			clientID:      clientID,
+			redirectURI:   redirectURI,
+			codeVerifier:  "short",
+			expectedError: ErrInvalidCodeVerifier,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.code.Redeem(tc.clientID, tc.redirectURI, tc.codeVerifier)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.True(t, tc.code.Used)
+			}
+		})
+	}
+}
+
+func newTestAuthorizationCode(ttl time.Duration, used bool) AuthorizationCode {
+	return AuthorizationCode{
+		ID:                  1,
+		UserID:              userID,
+		ClientID:            clientID,
+		RedirectURI:         redirectURI,
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: CodeChallengeMethodS256,
+		ExpiresAt:           time.Now().Add(ttl),
+		Used:                used,
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"math/big"
+	"strings"
+)
+
+const (
+	// backupCodeLength is the number of characters of the backup code.
+	backupCodeLength = 10
+
+	// backupCodeAlphabet is the characters of the backup code, the look-alike characters are left out.
+	backupCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// BackupCode is the single-use recovery code the user passes the second authentication factor with
+// when the TOTP device is not available. The code itself is known only when it is generated,
+// the storage keeps the hash of the code.
+type BackupCode struct {
+	ID       int64
+	UserID   int64
+	Code     string
+	CodeHash string
+	Used     bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewBackupCode returns a new backup code entity with the generated code.
+// The code is formatted as two groups of characters separated by a hyphen.
+func NewBackupCode(userID int64) (BackupCode, error) {
+	alphabetLength := big.NewInt(int64(len(backupCodeAlphabet)))
+
+	var code strings.Builder
+	for i := range backupCodeLength {
+		if i == backupCodeLength/2 {
+			code.WriteByte('-')
+		}
+
+		index, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return BackupCode{}, fmt.Errorf("%w: %w", ErrCreateBackupCode, err)
+		}
+		code.WriteByte(backupCodeAlphabet[index.Int64()])
+	}
+
+	return BackupCode{
+		UserID:   userID,
+		Code:     code.String(),
+		CodeHash: HashBackupCode(code.String()),
+	}, nil
+}
+
+// HashBackupCode returns the hash of the backup code which is kept in the storage.
+// The case, the hyphens and the spaces of the code are ignored.
+func HashBackupCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *BackupCode) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *BackupCode) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *BackupCode) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *BackupCode) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *BackupCode) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *BackupCode) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *BackupCode) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
@@ -0,0 +1,242 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"slices"
+	"time"
+)
+
+// clientSecretKeyLength is the number of random bytes of the generated client secret key.
+const clientSecretKeyLength = 32
+
+// Client is the client entity managed by the administration API.
+// The audiences and the default roles of the client are saved together with the client.
+// MaxSessions and SessionEvictionPolicy are empty if the client uses the default session limit.
+// If RequireVerifiedEmail is set, only the users with the verified email can sign in to the client.
+// If RequireMFA is set, the users must pass the second authentication factor to sign in to the client.
+type Client struct {
+	ID                    int64
+	Name                  string
+	Code                  string
+	SecretKey             string
+	MaxSessions           *int32
+	SessionEvictionPolicy *enum.SessionEvictionPolicyEnum
+	RequireVerifiedEmail  bool
+	RequireMFA            bool
+	Audiences             []ClientAudience
+	DefaultRoles          []dto.Role
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+
+	defaultRolesChanged bool
+	dataStatus          enum.DataStatusEnum
+}
+
+// ClientAudience is the audience of the access tokens issued to the client.
+type ClientAudience struct {
+	ID  int64
+	URL string
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewClient returns a new client entity. If the secret key is not set up, a new secret key is generated.
+func NewClient(name, code string, setters ...ClientOption) (Client, error) {
+	client := Client{
+		Name: name,
+		Code: code,
+	}
+
+	for _, setter := range setters {
+		if err := setter(&client); err != nil {
+			return Client{}, err
+		}
+	}
+
+	if client.SecretKey == "" {
+		secretKey, err := generateClientSecretKey()
+		if err != nil {
+			return Client{}, err
+		}
+
+		client.SecretKey = secretKey
+	}
+
+	return client, nil
+}
+
+// Update updates the name, the session limit and the sign-in requirements of the client.
+func (c *Client) Update(
+	name string,
+	maxSessions *int32,
+	evictionPolicy *enum.SessionEvictionPolicyEnum,
+	requireVerifiedEmail bool,
+	requireMFA bool,
+) error {
+	if err := validateClientSessionLimit(maxSessions, evictionPolicy); err != nil {
+		return err
+	}
+
+	c.Name = name
+	c.MaxSessions = maxSessions
+	c.SessionEvictionPolicy = evictionPolicy
+	c.RequireVerifiedEmail = requireVerifiedEmail
+	c.RequireMFA = requireMFA
+
+	c.SetToUpdate()
+
+	return nil
+}
+
+// RotateSecretKey replaces the secret key of the client with a new generated one.
+// The tokens signed by the previous secret key can no longer be verified.
+func (c *Client) RotateSecretKey() error {
+	secretKey, err := generateClientSecretKey()
+	if err != nil {
+		return err
+	}
+
+	c.SecretKey = secretKey
+
+	c.SetToUpdate()
+
+	return nil
+}
+
+// AddAudience adds the audience to the access tokens issued to the client.
+func (c *Client) AddAudience(url string) error {
+	if slices.Contains(c.AudienceURLs(), url) {
+		return ErrClientAudienceExists
+	}
+
+	audience := ClientAudience{URL: url}
+	audience.SetToCreate()
+
+	c.Audiences = append(c.Audiences, audience)
+
+	return nil
+}
+
+// RemoveAudience removes the audience from the access tokens issued to the client.
+func (c *Client) RemoveAudience(url string) error {
+	for i, audience := range c.Audiences {
+		if audience.URL != url || audience.IsToRemove() {
+			continue
+		}
+
+		// The audience which is not saved yet is just forgotten.
+		if audience.IsToCreate() {
+			c.Audiences = slices.Delete(c.Audiences, i, i+1)
+
+			return nil
+		}
+
+		c.Audiences[i].SetToRemove()
+
+		return nil
+	}
+
+	return ErrClientAudienceNotFound
+}
+
+// AudienceURLs returns the audiences of the access tokens issued to the client.
+func (c *Client) AudienceURLs() []string {
+	urls := make([]string, 0, len(c.Audiences))
+	for _, audience := range c.Audiences {
+		if !audience.IsToRemove() {
+			urls = append(urls, audience.URL)
+		}
+	}
+
+	return urls
+}
+
+// SetDefaultRoles replaces the roles granted to the users registered with the client.
+func (c *Client) SetDefaultRoles(roles []dto.Role) {
+	c.DefaultRoles = roles
+	c.defaultRolesChanged = true
+}
+
+// DefaultRolesChanged reports whether the default roles of the client are replaced and must be saved.
+func (c *Client) DefaultRolesChanged() bool {
+	return c.defaultRolesChanged
+}
+
+// ResetDefaultRolesChanged marks the default roles of the client as saved.
+func (c *Client) ResetDefaultRolesChanged() {
+	c.defaultRolesChanged = false
+}
+
+func (c *Client) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *Client) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *Client) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *Client) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *Client) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *Client) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *Client) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
+
+func (a *ClientAudience) SetToCreate() {
+	a.dataStatus = enum.ToCreate
+}
+
+func (a *ClientAudience) SetToRemove() {
+	a.dataStatus = enum.ToRemove
+}
+
+func (a *ClientAudience) IsToCreate() bool {
+	return a.dataStatus == enum.ToCreate
+}
+
+func (a *ClientAudience) IsToRemove() bool {
+	return a.dataStatus == enum.ToRemove
+}
+
+func (a *ClientAudience) ResetDataStatus() {
+	a.dataStatus = enum.None
+}
+
+// validateClientSessionLimit checks that the session limit of the client is either empty or valid.
+func validateClientSessionLimit(maxSessions *int32, evictionPolicy *enum.SessionEvictionPolicyEnum) error {
+	if maxSessions != nil && *maxSessions < 0 {
+		return ErrInvalidSessionLimit
+	}
+
+	if evictionPolicy != nil && !evictionPolicy.IsValid() {
+		return ErrInvalidSessionLimit
+	}
+
+	return nil
+}
+
+func generateClientSecretKey() (string, error) {
+	secretKeyBytes := make([]byte, clientSecretKeyLength)
+	if _, err := rand.Read(secretKeyBytes); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGenerateSecretKey, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secretKeyBytes), nil
+}
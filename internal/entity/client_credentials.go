@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"crypto/subtle"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"slices"
+	"time"
+)
+
+// ClientCredentials is the client authentication entity for the client credentials grant.
+// Tokens issued by the client credentials are not tied to a user and have no session.
+type ClientCredentials struct {
+	client          dto.Client
+	permissionCodes []string
+	signingKey      *jwtkeys.SigningKey
+	accessTokenTTL  time.Duration
+}
+
+// NewClientCredentials returns a new client credentials entity.
+func NewClientCredentials(accessTokenTTL time.Duration, setters ...ClientCredentialsOption) (ClientCredentials, error) {
+	clientCredentials := ClientCredentials{
+		accessTokenTTL: accessTokenTTL,
+	}
+
+	for _, setter := range setters {
+		if err := setter(&clientCredentials); err != nil {
+			return ClientCredentials{}, err
+		}
+	}
+
+	return clientCredentials, nil
+}
+
+// IssueTokens verifies the client secret, and if successful, creates a new access token for the client.
+// The requested scopes must be granted to the client.
+func (c *ClientCredentials) IssueTokens(data IssueClientTokensParams) (Tokens, error) {
+	// Check client secret.
+	if c.client.ID == emptyID ||
+		subtle.ConstantTimeCompare([]byte(c.client.SecretKey), []byte(data.ClientSecret)) != 1 {
+		return Tokens{}, ErrInvalidClientCredentials
+	}
+
+	// Check requested scopes.
+	scopes := c.permissionCodes
+	if len(data.Scopes) > 0 {
+		for _, scope := range data.Scopes {
+			if !slices.Contains(c.permissionCodes, scope) {
+				return Tokens{}, ErrInvalidScope
+			}
+		}
+
+		scopes = data.Scopes
+	}
+
+	// Create tokens.
+	createClientTokensParams := CreateClientTokensParams{
+		ClientCode:     c.client.Code,
+		Permissions:    scopes,
+		Audiences:      c.client.Audiences,
+		SecretKey:      c.client.SecretKey,
+		SigningKey:     c.signingKey,
+		Issuer:         data.Issuer,
+		AccessTokenTTL: c.accessTokenTTL,
+	}
+
+	return NewClientTokens(createClientTokensParams)
+}
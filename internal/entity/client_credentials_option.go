@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+)
+
+// ClientCredentialsOption is how options for the ClientCredentials are set up.
+type ClientCredentialsOption func(*ClientCredentials) error
+
+// WithClientCredentialsClient is an option which sets up the client data for the client credentials entity.
+func WithClientCredentialsClient(client dto.Client) ClientCredentialsOption {
+	return func(c *ClientCredentials) error {
+		if client.ID == emptyID {
+			return nil
+		}
+
+		c.client = client
+
+		return nil
+	}
+}
+
+// WithClientCredentialsPermissionCodes is an option which sets up the codes of the permissions
+// granted to the client for the client credentials entity.
+func WithClientCredentialsPermissionCodes(permissions ...string) ClientCredentialsOption {
+	return func(c *ClientCredentials) error {
+		c.permissionCodes = permissions
+
+		return nil
+	}
+}
+
+// WithClientCredentialsSigningKey is an option which sets up the key for signing access tokens
+// for the client credentials entity. If the key is nil, access tokens are signed by the client secret key.
+func WithClientCredentialsSigningKey(key *jwtkeys.SigningKey) ClientCredentialsOption {
+	return func(c *ClientCredentials) error {
+		c.signingKey = key
+
+		return nil
+	}
+}
@@ -0,0 +1,9 @@
+package entity
+
+// IssueClientTokensParams is a data for issuing client tokens by the client credentials grant.
+// If Scopes is empty, all permissions of the client are granted.
+type IssueClientTokensParams struct {
+	ClientSecret string
+	Scopes       []string
+	Issuer       string
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_ClientCredentials_IssueTokens(t *testing.T) {
+	client := dto.Client{
+		ID:        clientID,
+		Code:      clientCode,
+		SecretKey: secretKey,
+		Audiences: []string{"test audience"},
+	}
+	permissionCodes := []string{"orders.read", "orders.write"}
+
+	testCases := []struct {
+		name          string
+		client        dto.Client
+		data          IssueClientTokensParams
+		expectedScope string
+		expectedError error
+	}{
+		{
+			name:   "successfully issues tokens with all client permissions",
+			client: client,
+			data: IssueClientTokensParams{
+				ClientSecret: secretKey,
+				Issuer:       issuer,
+			},
+			expectedScope: "orders.read orders.write",
+		},
+		{
+			name:   "successfully issues tokens with the requested scopes",
+			client: client,
+			data: IssueClientTokensParams{
+				ClientSecret: secretKey,
+				Scopes:       []string{"orders.read"},
+				Issuer:       issuer,
+			},
+			expectedScope: "orders.read",
+		},
+		{
+			name:   "throws an error when the client secret is invalid",
+			client: client,
+			data: IssueClientTokensParams{
+				ClientSecret: invalidSecretKey,
+				Issuer:       issuer,
+			},
+			expectedError: ErrInvalidClientCredentials,
+		},
+		{
+			name: "throws an error when the client is empty",
+			data: IssueClientTokensParams{
+				Issuer: issuer,
+			},
+			expectedError: ErrInvalidClientCredentials,
+		},
+		{
+			name:   "throws an error when the requested scope is not granted to the client",
+			client: client,
+			data: IssueClientTokensParams{
+				ClientSecret: secretKey,
+				Scopes:       []string{"orders.read", "users.write"},
+				Issuer:       issuer,
+			},
+			expectedError: ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientCredentials, err := NewClientCredentials(
+				accessTokenTTL,
+				WithClientCredentialsClient(tc.client),
+				WithClientCredentialsPermissionCodes(permissionCodes...),
+			)
+			require.NoError(t, err)
+
+			tokens, err := clientCredentials.IssueTokens(tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.Empty(t, tokens.RefreshToken)
+				assert.Empty(t, tokens.RefreshTokenID)
+				assert.Empty(t, tokens.IDToken)
+
+				token, err := jwt.ParseSigned(tokens.AccessToken, []jose.SignatureAlgorithm{jose.HS256})
+				require.NoError(t, err)
+
+				var claims jwtclaims.AccessTokenClaims
+				err = token.Claims([]byte(secretKey), &claims)
+				require.NoError(t, err)
+
+				assert.Equal(t, clientCode, claims.Subject)
+				assert.Equal(t, clientCode, claims.ClientID)
+				assert.Equal(t, jwt.Audience{"test audience"}, claims.Audience)
+				assert.Equal(t, tc.expectedScope, claims.Scope)
+				assert.Equal(t, jwtclaims.TokenUseClient, claims.TokenUse)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+)
+
+// ClientOption is how options for the Client are set up.
+type ClientOption func(*Client) error
+
+// WithClientID is an option which sets up the client ID for the client entity.
+func WithClientID(id int64) ClientOption {
+	return func(c *Client) error {
+		c.ID = id
+
+		return nil
+	}
+}
+
+// WithClientSecretKey is an option which sets up the secret key for the client entity.
+func WithClientSecretKey(secretKey string) ClientOption {
+	return func(c *Client) error {
+		c.SecretKey = secretKey
+
+		return nil
+	}
+}
+
+// WithClientSessionLimit is an option which sets up the session limit for the client entity.
+// If the values are nil, the client uses the default session limit.
+func WithClientSessionLimit(maxSessions *int32, evictionPolicy *enum.SessionEvictionPolicyEnum) ClientOption {
+	return func(c *Client) error {
+		if err := validateClientSessionLimit(maxSessions, evictionPolicy); err != nil {
+			return err
+		}
+
+		c.MaxSessions = maxSessions
+		c.SessionEvictionPolicy = evictionPolicy
+
+		return nil
+	}
+}
+
+// WithClientRequireVerifiedEmail is an option which sets up the flag only the users with the verified email
+// can sign in to the client for the client entity.
+func WithClientRequireVerifiedEmail(requireVerifiedEmail bool) ClientOption {
+	return func(c *Client) error {
+		c.RequireVerifiedEmail = requireVerifiedEmail
+
+		return nil
+	}
+}
+
+// WithClientRequireMFA is an option which sets up the flag the users must pass the second authentication factor
+// to sign in to the client for the client entity.
+func WithClientRequireMFA(requireMFA bool) ClientOption {
+	return func(c *Client) error {
+		c.RequireMFA = requireMFA
+
+		return nil
+	}
+}
+
+// WithClientAudiences is an option which sets up the saved audiences for the client entity.
+func WithClientAudiences(audiences []dto.Audience) ClientOption {
+	return func(c *Client) error {
+		c.Audiences = make([]ClientAudience, len(audiences))
+		for i, audience := range audiences {
+			c.Audiences[i] = ClientAudience{
+				ID:  audience.ID,
+				URL: audience.URL,
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithClientDetails is an option which sets up the saved client data for the client entity.
+func WithClientDetails(client dto.ClientDetails) ClientOption {
+	return func(c *Client) error {
+		c.ID = client.ID
+		c.SecretKey = client.SecretKey
+		c.MaxSessions = client.MaxSessions
+		c.SessionEvictionPolicy = client.SessionEvictionPolicy
+		c.RequireVerifiedEmail = client.RequireVerifiedEmail
+		c.RequireMFA = client.RequireMFA
+		c.DefaultRoles = client.DefaultRoles
+		c.CreatedAt = client.CreatedAt
+		c.UpdatedAt = client.UpdatedAt
+
+		return WithClientAudiences(client.Audiences)(c)
+	}
+}
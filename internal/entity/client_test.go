@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_NewClient(t *testing.T) {
+	maxSessions := int32(3)
+	negativeMaxSessions := int32(-1)
+	policy := enum.EvictLeastRecentlyUsed
+	invalidPolicy := enum.SessionEvictionPolicyEnum("invalid")
+
+	testCases := []struct {
+		name              string
+		setters           []ClientOption
+		expectedSecretKey string
+		expectedError     error
+	}{
+		{
+			name: "generates the secret key",
+		},
+		{
+			name:              "keeps the secret key",
+			setters:           []ClientOption{WithClientSecretKey(secretKey)},
+			expectedSecretKey: secretKey,
+		},
+		{
+			name:    "sets up the session limit",
+			setters: []ClientOption{WithClientSessionLimit(&maxSessions, &policy)},
+		},
+		{
+			name:          "throws an error when the max sessions is negative",
+			setters:       []ClientOption{WithClientSessionLimit(&negativeMaxSessions, nil)},
+			expectedError: ErrInvalidSessionLimit,
+		},
+		{
+			name:          "throws an error when the eviction policy is unknown",
+			setters:       []ClientOption{WithClientSessionLimit(nil, &invalidPolicy)},
+			expectedError: ErrInvalidSessionLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient("Client", clientCode, tc.setters...)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, client.SecretKey)
+
+			if tc.expectedSecretKey != "" {
+				assert.Equal(t, tc.expectedSecretKey, client.SecretKey)
+			}
+		})
+	}
+}
+
+func Test_Client_RotateSecretKey(t *testing.T) {
+	client, err := NewClient("Client", clientCode, WithClientID(1), WithClientSecretKey(secretKey))
+	require.NoError(t, err)
+
+	require.NoError(t, client.RotateSecretKey())
+
+	assert.NotEmpty(t, client.SecretKey)
+	assert.NotEqual(t, secretKey, client.SecretKey)
+	assert.True(t, client.IsToUpdate())
+}
+
+func Test_Client_Audiences(t *testing.T) {
+	const (
+		savedURL = "https://saved.example.com"
+		newURL   = "https://new.example.com"
+	)
+
+	testCases := []struct {
+		name          string
+		add           []string
+		remove        []string
+		expectedURLs  []string
+		expectedError error
+	}{
+		{
+			name:         "adds the audience",
+			add:          []string{newURL},
+			expectedURLs: []string{savedURL, newURL},
+		},
+		{
+			name:          "throws an error when the audience already exists",
+			add:           []string{savedURL},
+			expectedError: ErrClientAudienceExists,
+		},
+		{
+			name:         "removes the saved audience",
+			remove:       []string{savedURL},
+			expectedURLs: []string{},
+		},
+		{
+			name:         "removes the added audience",
+			add:          []string{newURL},
+			remove:       []string{newURL},
+			expectedURLs: []string{savedURL},
+		},
+		{
+			name:          "throws an error when the audience is not found",
+			remove:        []string{newURL},
+			expectedError: ErrClientAudienceNotFound,
+		},
+		{
+			name:          "throws an error when the audience is already removed",
+			remove:        []string{savedURL, savedURL},
+			expectedError: ErrClientAudienceNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(
+				"Client",
+				clientCode,
+				WithClientID(1),
+				WithClientAudiences([]dto.Audience{{ID: 1, URL: savedURL}}),
+			)
+			require.NoError(t, err)
+
+			for _, url := range tc.add {
+				if err = client.AddAudience(url); err != nil {
+					break
+				}
+			}
+
+			if err == nil {
+				for _, url := range tc.remove {
+					if err = client.RemoveAudience(url); err != nil {
+						break
+					}
+				}
+			}
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedURLs, client.AudienceURLs())
+			}
+		})
+	}
+}
+
+func Test_Client_SetDefaultRoles(t *testing.T) {
+	client, err := NewClient("Client", clientCode, WithClientID(1))
+	require.NoError(t, err)
+	assert.False(t, client.DefaultRolesChanged())
+
+	roles := []dto.Role{{ID: 1, Code: "user"}}
+	client.SetDefaultRoles(roles)
+
+	assert.Equal(t, roles, client.DefaultRoles)
+	assert.True(t, client.DefaultRolesChanged())
+}
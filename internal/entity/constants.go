@@ -3,3 +3,6 @@ package entity
 const (
 	emptyID = 0
 )
+
+// PermissionAdmin is the permission which grants access to the administration API.
+const PermissionAdmin = "sso:admin"
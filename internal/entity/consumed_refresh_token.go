@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// ConsumedRefreshToken is the refresh token which has been rotated.
+// Presenting the consumed refresh token again means that the token is reused, and the token family is compromised.
+type ConsumedRefreshToken struct {
+	ID             int64
+	RefreshTokenID string
+	FamilyID       string
+	UserID         int64
+	ExpiresAt      time.Time
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewConsumedRefreshToken returns a new consumed refresh token entity for the refresh token of the session.
+func NewConsumedRefreshToken(session Session) ConsumedRefreshToken {
+	return ConsumedRefreshToken{
+		RefreshTokenID: session.RefreshTokenID,
+		FamilyID:       session.FamilyID,
+		UserID:         session.UserID,
+		ExpiresAt:      session.ExpiresAt,
+	}
+}
+
+func (t *ConsumedRefreshToken) SetToCreate() {
+	t.dataStatus = enum.ToCreate
+}
+
+func (t *ConsumedRefreshToken) IsToCreate() bool {
+	return t.dataStatus == enum.ToCreate
+}
+
+func (t *ConsumedRefreshToken) ResetDataStatus() {
+	t.dataStatus = enum.None
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// phonePattern is the phone number in the E.164 format, e.g. +15550100.
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// normalizeEmail returns the email in the form it is stored in, so the same address is never saved twice.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// isValidEmail reports whether the value is a bare email address without the display name.
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+
+	return err == nil && address.Address == email
+}
+
+// isValidPhone reports whether the value is a phone number in the E.164 format.
+func isValidPhone(phone string) bool {
+	return phonePattern.MatchString(phone)
+}
+
+// equalContacts reports whether the optional contacts are the same.
+func equalContacts(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
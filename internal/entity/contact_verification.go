@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// ContactVerification is the verification of the user contact by the one-time code.
+// The user has one verification code per channel, requesting a new code replaces the previous one.
+type ContactVerification struct {
+	User User
+	Code VerificationCode
+
+	channel enum.ContactChannelEnum
+}
+
+// NewContactVerification returns a new contact verification entity for the channel of the user contact.
+func NewContactVerification(
+	user User,
+	channel enum.ContactChannelEnum,
+	setters ...ContactVerificationOption,
+) (ContactVerification, error) {
+	const op = "entity.NewContactVerification"
+
+	if !channel.IsValid() {
+		return ContactVerification{}, fmt.Errorf("%s: unknown contact channel %q", op, channel)
+	}
+
+	verification := ContactVerification{
+		User:    user,
+		channel: channel,
+	}
+
+	for _, setter := range setters {
+		setter(&verification)
+	}
+
+	return verification, nil
+}
+
+// Request issues a new verification code sent to the contact of the user.
+// ErrContactNotSet is returned if the user has no contact of the channel,
+// ErrContactAlreadyVerified is returned if the contact is already verified.
+func (v *ContactVerification) Request(codeTTL time.Duration) (VerificationCode, error) {
+	if err := v.checkUserStatus(); err != nil {
+		return VerificationCode{}, err
+	}
+
+	target, verified := v.User.Contact(v.channel)
+	if target == "" {
+		return VerificationCode{}, ErrContactNotSet
+	}
+
+	if verified {
+		return VerificationCode{}, ErrContactAlreadyVerified
+	}
+
+	if err := v.Code.issue(target, codeTTL); err != nil {
+		return VerificationCode{}, err
+	}
+
+	if v.Code.ID == emptyID {
+		v.Code.UserID = v.User.ID
+		v.Code.Channel = v.channel
+		v.Code.SetToCreate()
+	} else {
+		v.Code.SetToUpdate()
+	}
+
+	return v.Code, nil
+}
+
+// Confirm verifies the code sent to the contact of the user, and if it matches, marks the contact as verified.
+// The mismatched code counts as an attempt, so the code must be saved even if the verification fails.
+func (v *ContactVerification) Confirm(data ConfirmContactVerificationParams) error {
+	if err := v.checkUserStatus(); err != nil {
+		return err
+	}
+
+	if v.Code.ID == emptyID {
+		return ErrVerificationCodeNotFound
+	}
+
+	target, _ := v.User.Contact(v.channel)
+
+	err := v.Code.Verify(target, data.Code, data.MaxAttempts)
+	if err != nil {
+		if errors.Is(err, ErrVerificationCodeMismatch) {
+			v.Code.SetToUpdate()
+		}
+
+		return err
+	}
+	v.Code.SetToUpdate()
+
+	v.User.markContactVerified(v.channel)
+	v.User.SetToUpdate()
+
+	return nil
+}
+
+// Channel returns the channel of the contact being verified.
+func (v *ContactVerification) Channel() enum.ContactChannelEnum {
+	return v.channel
+}
+
+// checkUserStatus checks the user can verify the contacts.
+func (v *ContactVerification) checkUserStatus() error {
+	if v.User.Deleted {
+		return ErrUserDeleted
+	}
+
+	if v.User.Blocked {
+		return ErrUserBlocked
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/p1xray/pxr-sso/internal/dto"
+
+// ContactVerificationOption is how options for the ContactVerification are set up.
+type ContactVerificationOption func(*ContactVerification)
+
+// WithContactVerificationCode is an option which sets up the saved verification code
+// for the contact verification entity.
+func WithContactVerificationCode(code dto.VerificationCode) ContactVerificationOption {
+	return func(v *ContactVerification) {
+		if code.ID == emptyID {
+			return
+		}
+
+		v.Code = VerificationCode{
+			ID:        code.ID,
+			UserID:    code.UserID,
+			Channel:   code.Channel,
+			Target:    code.Target,
+			CodeHash:  code.CodeHash,
+			ExpiresAt: code.ExpiresAt,
+			Attempts:  code.Attempts,
+			Used:      code.Used,
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package entity
+
+// ConfirmContactVerificationParams is a data for confirming the user contact by the verification code.
+// MaxAttempts is the number of the mismatched codes after which the code can't be verified.
+type ConfirmContactVerificationParams struct {
+	Code        string
+	MaxAttempts int
+}
@@ -0,0 +1,274 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testEmail = "user@example.com"
+	testPhone = "+15550000000"
+)
+
+func Test_ContactVerification_Request(t *testing.T) {
+	testCases := []struct {
+		name          string
+		user          User
+		channel       enum.ContactChannelEnum
+		code          VerificationCode
+		expectedError error
+	}{
+		{
+			name:    "issues a new code to the email",
+			user:    newTestContactUser(false, false),
+			channel: enum.ContactChannelEmail,
+		},
+		{
+			name:    "replaces the previous code of the phone",
+			user:    newTestContactUser(false, false),
+			channel: enum.ContactChannelPhone,
+			code:    VerificationCode{ID: 1, UserID: userID, Channel: enum.ContactChannelPhone, Attempts: 3, Used: true},
+		},
+		{
+			name:          "throws an error when the contact is not set",
+			user:          NewUser("user", "user", nil, nil, nil, WithUserID(userID)),
+			channel:       enum.ContactChannelEmail,
+			expectedError: ErrContactNotSet,
+		},
+		{
+			name:          "throws an error when the contact is already verified",
+			user:          newTestContactUser(true, false),
+			channel:       enum.ContactChannelEmail,
+			expectedError: ErrContactAlreadyVerified,
+		},
+		{
+			name:          "throws an error when the user is blocked",
+			user:          newTestContactUser(false, false, WithUserStatus(true, false)),
+			channel:       enum.ContactChannelEmail,
+			expectedError: ErrUserBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			verification, err := NewContactVerification(tc.user, tc.channel)
+			require.NoError(t, err)
+			verification.Code = tc.code
+
+			code, err := verification.Request(time.Minute)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+			require.NoError(t, err)
+
+			target, _ := tc.user.Contact(tc.channel)
+			assert.Equal(t, target, code.Target)
+			assert.Len(t, code.Code, verificationCodeDigits)
+			assert.Equal(t, HashVerificationCode(code.Code), code.CodeHash)
+			assert.Zero(t, code.Attempts)
+			assert.False(t, code.Used)
+			assert.True(t, code.ExpiresAt.After(time.Now()))
+			assert.Equal(t, tc.code.ID == emptyID, verification.Code.IsToCreate())
+			assert.Equal(t, tc.code.ID != emptyID, verification.Code.IsToUpdate())
+		})
+	}
+}
+
+func Test_ContactVerification_Confirm(t *testing.T) {
+	testCases := []struct {
+		name             string
+		code             func(VerificationCode) VerificationCode
+		enteredCode      func(VerificationCode) string
+		expectedError    error
+		expectedAttempts int
+		expectedToUpdate bool
+	}{
+		{
+			name:             "verifies the contact",
+			expectedToUpdate: true,
+		},
+		{
+			name:             "counts the mismatched code as an attempt",
+			enteredCode:      func(VerificationCode) string { return "wrong" },
+			expectedError:    ErrVerificationCodeMismatch,
+			expectedAttempts: 1,
+			expectedToUpdate: true,
+		},
+		{
+			name: "throws an error when the code is expired",
+			code: func(code VerificationCode) VerificationCode {
+				code.ExpiresAt = time.Now().Add(-time.Minute)
+
+				return code
+			},
+			expectedError: ErrVerificationCodeExpired,
+		},
+		{
+			name: "throws an error when the attempts are exceeded",
+			code: func(code VerificationCode) VerificationCode {
+				code.Attempts = 5
+
+				return code
+			},
+			expectedError:    ErrVerificationAttemptsExceeded,
+			expectedAttempts: 5,
+		},
+		{
+			name: "throws an error when the code is sent to another email",
+			code: func(code VerificationCode) VerificationCode {
+				code.Target = "old@example.com"
+
+				return code
+			},
+			expectedError: ErrVerificationCodeNotFound,
+		},
+		{
+			name: "throws an error when the code is used",
+			code: func(code VerificationCode) VerificationCode {
+				code.Used = true
+
+				return code
+			},
+			expectedError: ErrVerificationCodeNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code := VerificationCode{ID: 1, UserID: userID, Channel: enum.ContactChannelEmail}
+			require.NoError(t, code.issue(testEmail, time.Minute))
+			if tc.code != nil {
+				code = tc.code(code)
+			}
+
+			enteredCode := code.Code
+			if tc.enteredCode != nil {
+				enteredCode = tc.enteredCode(code)
+			}
+
+			verification, err := NewContactVerification(newTestContactUser(false, false), enum.ContactChannelEmail)
+			require.NoError(t, err)
+			verification.Code = code
+
+			err = verification.Confirm(ConfirmContactVerificationParams{Code: enteredCode, MaxAttempts: 5})
+
+			assert.Equal(t, tc.expectedAttempts, verification.Code.Attempts)
+			assert.Equal(t, tc.expectedToUpdate, verification.Code.IsToUpdate())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.False(t, verification.User.EmailVerified)
+				assert.False(t, verification.User.IsToUpdate())
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.True(t, verification.Code.Used)
+			assert.True(t, verification.User.EmailVerified)
+			assert.True(t, verification.User.IsToUpdate())
+		})
+	}
+}
+
+func Test_User_UpdateProfile_Contacts(t *testing.T) {
+	newEmail := " New@Example.com "
+	invalidEmail := "New User <new@example.com>"
+	invalidPhone := "5550000000"
+
+	testCases := []struct {
+		name                  string
+		params                UpdateProfileParams
+		expectedEmail         *string
+		expectedEmailVerified bool
+		expectedPhone         *string
+		expectedPhoneVerified bool
+		expectedError         error
+	}{
+		{
+			name: "changed email is no longer verified",
+			params: UpdateProfileParams{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldEmail},
+				Email:  &newEmail,
+			},
+			expectedEmail:         ptr("new@example.com"),
+			expectedPhone:         ptr(testPhone),
+			expectedPhoneVerified: true,
+		},
+		{
+			name: "same email stays verified",
+			params: UpdateProfileParams{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldEmail},
+				Email:  ptr("USER@example.com"),
+			},
+			expectedEmail:         ptr(testEmail),
+			expectedEmailVerified: true,
+			expectedPhone:         ptr(testPhone),
+			expectedPhoneVerified: true,
+		},
+		{
+			name: "clears the phone",
+			params: UpdateProfileParams{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldPhone},
+			},
+			expectedEmail:         ptr(testEmail),
+			expectedEmailVerified: true,
+		},
+		{
+			name: "throws an error when the email is invalid",
+			params: UpdateProfileParams{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldEmail},
+				Email:  &invalidEmail,
+			},
+			expectedError: ErrInvalidProfile,
+		},
+		{
+			name: "throws an error when the phone is not in the E.164 format",
+			params: UpdateProfileParams{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldPhone},
+				Phone:  &invalidPhone,
+			},
+			expectedError: ErrInvalidProfile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			user := newTestContactUser(true, true)
+
+			err := user.UpdateProfile(tc.params)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedEmail, user.Email)
+			assert.Equal(t, tc.expectedEmailVerified, user.EmailVerified)
+			assert.Equal(t, tc.expectedPhone, user.Phone)
+			assert.Equal(t, tc.expectedPhoneVerified, user.PhoneVerified)
+		})
+	}
+}
+
+func newTestContactUser(emailVerified, phoneVerified bool, setters ...UserOption) User {
+	setters = append([]UserOption{
+		WithUserID(userID),
+		WithUserEmail(ptr(testEmail), emailVerified),
+		WithUserPhone(ptr(testPhone), phoneVerified),
+	}, setters...)
+
+	return NewUser("user", "user", nil, nil, nil, setters...)
+}
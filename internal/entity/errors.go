@@ -6,6 +6,7 @@ var (
 	ErrInvalidCredentials   = errors.New("invalid credentials")
 	ErrUserExists           = errors.New("user already exists")
 	ErrGeneratePasswordHash = errors.New("error generating password hash")
+	ErrGeneratePassword     = errors.New("error generating password")
 	ErrRefreshTokenExpired  = errors.New("refresh token expired")
 	ErrValidateSession      = errors.New("error validating session")
 	ErrInvalidSession       = errors.New("invalid session")
@@ -14,4 +15,83 @@ var (
 	ErrCreateTokens         = errors.New("error creating tokens")
 	ErrCreateAccessToken    = errors.New("error creating access token")
 	ErrCreateRefreshToken   = errors.New("error creating refresh token")
+	ErrCreateIDToken        = errors.New("error creating ID token")
+	ErrRefreshTokenReused   = errors.New("refresh token is reused")
+	ErrSessionLimitExceeded = errors.New("session limit exceeded for the client")
+
+	ErrInvalidRedirectURI             = errors.New("redirect URI is not registered for the client")
+	ErrUnsupportedCodeChallengeMethod = errors.New("unsupported code challenge method")
+	ErrInvalidCodeChallenge           = errors.New("invalid code challenge")
+	ErrInvalidCodeVerifier            = errors.New("invalid code verifier")
+	ErrCreateAuthorizationCode        = errors.New("error creating authorization code")
+	ErrAuthorizationCodeNotFound      = errors.New("authorization code not found")
+	ErrAuthorizationCodeUsed          = errors.New("authorization code is already used")
+	ErrAuthorizationCodeExpired       = errors.New("authorization code expired")
+	ErrAuthorizationCodeMismatch      = errors.New("authorization code is issued to another client or redirect URI")
+
+	ErrInvalidClientCredentials = errors.New("invalid client credentials")
+	ErrInvalidScope             = errors.New("requested scope is not granted to the client")
+
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrPermissionDenied = errors.New("permission is not granted")
+
+	ErrInvalidSessionLimit    = errors.New("invalid session limit")
+	ErrClientAudienceExists   = errors.New("client audience already exists")
+	ErrClientAudienceNotFound = errors.New("client audience not found")
+	ErrGenerateSecretKey      = errors.New("error generating secret key")
+
+	ErrRolePermissionExists   = errors.New("permission is already attached to the role")
+	ErrRolePermissionNotFound = errors.New("permission is not attached to the role")
+
+	ErrUserBlocked        = errors.New("user is blocked")
+	ErrUserDeleted        = errors.New("user is deleted")
+	ErrUserRoleExists     = errors.New("role is already granted to the user")
+	ErrUserRoleNotFound   = errors.New("role is not granted to the user")
+	ErrUserClientExists   = errors.New("user already has access to the client")
+	ErrUserClientNotFound = errors.New("user has no access to the client")
+
+	ErrInvalidProfile = errors.New("invalid profile")
+
+	ErrWeakPassword           = errors.New("password does not meet the password policy")
+	ErrPasswordNotChanged     = errors.New("new password matches the current password")
+	ErrPasswordChangeRequired = errors.New("password must be changed")
+
+	ErrCreatePasswordResetToken   = errors.New("error creating password reset token")
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenUsed     = errors.New("password reset token is already used")
+	ErrPasswordResetTokenExpired  = errors.New("password reset token expired")
+	ErrCreateNotification         = errors.New("error creating notification")
+
+	ErrEmailNotVerified             = errors.New("email is not verified")
+	ErrContactNotSet                = errors.New("contact is not set")
+	ErrContactAlreadyVerified       = errors.New("contact is already verified")
+	ErrNoVerifiedContact            = errors.New("user has no verified contact")
+	ErrCreateVerificationCode       = errors.New("error creating verification code")
+	ErrVerificationCodeNotFound     = errors.New("verification code not found")
+	ErrVerificationCodeExpired      = errors.New("verification code expired")
+	ErrVerificationCodeMismatch     = errors.New("verification code does not match")
+	ErrVerificationAttemptsExceeded = errors.New("verification attempts exceeded")
+
+	ErrMFARequired           = errors.New("second authentication factor is required")
+	ErrMFAEnrollmentRequired = errors.New("second authentication factor must be enrolled")
+	ErrInvalidMFACode        = errors.New("invalid second authentication factor code")
+	ErrCreateMFAChallenge    = errors.New("error creating MFA challenge")
+	ErrMFAChallengeNotFound  = errors.New("MFA challenge not found")
+	ErrMFAChallengeExpired   = errors.New("MFA challenge expired")
+	ErrMFAAttemptsExceeded   = errors.New("MFA attempts exceeded")
+	ErrCreateTOTPCredential  = errors.New("error creating TOTP credential")
+	ErrTOTPAlreadyEnabled    = errors.New("TOTP is already enabled")
+	ErrTOTPNotEnrolled       = errors.New("TOTP is not enrolled")
+	ErrTOTPNotEnabled        = errors.New("TOTP is not enabled")
+	ErrCreateBackupCode      = errors.New("error creating backup code")
+
+	ErrCreateWebAuthnChallenge   = errors.New("error creating WebAuthn challenge")
+	ErrWebAuthnChallengeNotFound = errors.New("WebAuthn challenge not found")
+	ErrWebAuthnChallengeExpired  = errors.New("WebAuthn challenge expired")
+	ErrInvalidWebAuthnResponse   = errors.New("invalid WebAuthn response")
+	ErrWebAuthnCredentialExists  = errors.New("WebAuthn credential is already registered")
+	ErrWebAuthnSignCountMismatch = errors.New("WebAuthn signature counter did not increase, the authenticator may be cloned")
+
+	ErrAccountLocked  = errors.New("account is temporarily locked after too many failed login attempts")
+	ErrLoginThrottled = errors.New("too many failed login attempts, retry later")
 )
@@ -0,0 +1,236 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	jwtparser "github.com/p1xray/pxr-sso/pkg/jwt/parser"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token types of the token introspection (RFC 7662, section 2.1).
+const (
+	TokenTypeAccessToken  = "access_token"
+	TokenTypeRefreshToken = "refresh_token"
+)
+
+// introspectionSignatureAlgorithms are the signature algorithms of the tokens which can be introspected.
+var introspectionSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.HS256,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// Introspection is the state of the token returned by the token introspection (RFC 7662).
+// SessionID identifies the user session the token belongs to: for the access tokens it is the token family ID
+// of the session, which survives the refresh token rotation, for the refresh tokens it is the refresh token ID.
+// It is empty for the tokens which are not tied to a session.
+// TokenUse tells the access tokens of the users from the access tokens of the clients, whose subject is the client.
+// Permissions are the permissions granted to the owner of the access token in the storage.
+type Introspection struct {
+	Active      bool
+	TokenType   string
+	Subject     string
+	Scope       string
+	Audience    []string
+	ExpiresAt   time.Time
+	ClientID    string
+	TokenID     string
+	SessionID   string
+	TokenUse    string
+	Permissions []string
+}
+
+// IntrospectedToken is the token which state is requested by the token introspection.
+// The claims of the token are not verified until the token is introspected.
+type IntrospectedToken struct {
+	raw    string
+	token  *jwt.JSONWebToken
+	claims jwtclaims.AccessTokenClaims
+}
+
+// ParseIntrospectedToken parses the access or refresh token without verifying its signature.
+func ParseIntrospectedToken(tokenStr string) (IntrospectedToken, error) {
+	token, err := jwt.ParseSigned(tokenStr, introspectionSignatureAlgorithms)
+	if err != nil {
+		return IntrospectedToken{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	var claims jwtclaims.AccessTokenClaims
+	if err = token.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return IntrospectedToken{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return IntrospectedToken{
+		raw:    tokenStr,
+		token:  token,
+		claims: claims,
+	}, nil
+}
+
+// Type returns the type of the token. Access tokens are marked by the token use, refresh tokens have
+// neither the subject nor the token use. Other tokens, e.g. the ID tokens, have no type and are never active.
+func (t IntrospectedToken) Type() string {
+	switch {
+	case t.claims.TokenUse == jwtclaims.TokenUseAccess || t.claims.TokenUse == jwtclaims.TokenUseClient:
+		return TokenTypeAccessToken
+	case t.claims.Subject == "" && t.claims.TokenUse == "":
+		return TokenTypeRefreshToken
+	default:
+		return ""
+	}
+}
+
+// ClientID returns the unverified client the token is issued to.
+func (t IntrospectedToken) ClientID() string {
+	return t.claims.ClientID
+}
+
+// IsSignedBySecretKey reports whether the token is signed by the client secret key.
+func (t IntrospectedToken) IsSignedBySecretKey() bool {
+	algorithm, err := jwtparser.ParseSignatureAlgorithm(t.token)
+
+	return err == nil && algorithm == jose.HS256
+}
+
+// Introspect verifies the signature and the expiration time of the token and returns its state.
+// Access tokens are verified by the key set or by the secret key of the client they are issued to,
+// refresh tokens are verified by the secret key of the client requesting the introspection.
+func (t IntrospectedToken) Introspect(data IntrospectTokenParams) (Introspection, error) {
+	switch t.Type() {
+	case TokenTypeAccessToken:
+		return t.introspectAccessToken(data)
+	case TokenTypeRefreshToken:
+		return t.introspectRefreshToken(data)
+	default:
+		return Introspection{}, fmt.Errorf("%w: unknown token type", ErrInvalidToken)
+	}
+}
+
+func (t IntrospectedToken) introspectAccessToken(data IntrospectTokenParams) (Introspection, error) {
+	var (
+		claims jwtclaims.AccessTokenClaims
+		err    error
+	)
+	if t.IsSignedBySecretKey() {
+		claims, _, err = jwtparser.ParseAccessToken(t.token, []byte(data.SecretKey), nil)
+	} else {
+		claims, _, err = jwtparser.ParseAccessTokenWithKeySet(t.token, data.KeySet, nil)
+	}
+	if err != nil {
+		return Introspection{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if err = claims.Claims.ValidateWithLeeway(jwt.Expected{Time: time.Now()}, 0); err != nil {
+		return Introspection{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	introspection := Introspection{
+		Active:    true,
+		TokenType: TokenTypeAccessToken,
+		Subject:   claims.Subject,
+		Scope:     claims.Scope,
+		Audience:  claims.Audience,
+		ClientID:  claims.ClientID,
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		TokenUse:  claims.TokenUse,
+	}
+	if claims.Expiry != nil {
+		introspection.ExpiresAt = claims.Expiry.Time()
+	}
+
+	return introspection, nil
+}
+
+func (t IntrospectedToken) introspectRefreshToken(data IntrospectTokenParams) (Introspection, error) {
+	claims, err := jwtparser.ParseRefreshToken(t.raw, []byte(data.SecretKey))
+	if err != nil {
+		return Introspection{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if claims.Expiry == nil || claims.Expiry.Time().Before(time.Now()) {
+		return Introspection{}, ErrRefreshTokenExpired
+	}
+
+	return Introspection{
+		Active:    true,
+		TokenType: TokenTypeRefreshToken,
+		ExpiresAt: claims.Expiry.Time(),
+		ClientID:  data.ClientCode,
+		TokenID:   claims.ID,
+		SessionID: claims.ID,
+	}, nil
+}
+
+// HasScope reports whether the scope is granted to the token.
+func (i *Introspection) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(i.Scope), scope)
+}
+
+// HasPermission reports whether the permission is both granted to the token by its scope
+// and still granted to the owner of the token in the storage.
+func (i *Introspection) HasPermission(code string) bool {
+	return i.HasScope(code) && slices.Contains(i.Permissions, code)
+}
+
+// ValidateClient checks that the access token is issued to the client: the token names the client,
+// the subject of the access token of the client is the client itself
+// and every audience of the token is the audience of the client.
+func (i *Introspection) ValidateClient(client dto.Client) error {
+	if i.ClientID != client.Code {
+		return fmt.Errorf("%w: token is issued to another client", ErrInvalidToken)
+	}
+
+	if !i.IsUserToken() && i.Subject != client.Code {
+		return fmt.Errorf("%w: subject of the client token is another client", ErrInvalidToken)
+	}
+
+	for _, audience := range i.Audience {
+		if !slices.Contains(client.Audiences, audience) {
+			return fmt.Errorf("%w: %q is not an audience of the client", ErrInvalidToken, audience)
+		}
+	}
+
+	return nil
+}
+
+// IsUserToken reports whether the access token is issued to the user, so its subject is the user ID.
+func (i *Introspection) IsUserToken() bool {
+	return i.TokenUse == jwtclaims.TokenUseAccess
+}
+
+// ValidateSessionOwner checks that the session the access token belongs to is the session
+// of the user of the token logged in to the client of the token.
+func (i *Introspection) ValidateSessionOwner(session dto.Session, client dto.Client) error {
+	if i.Subject != strconv.FormatInt(session.UserID, 10) {
+		return fmt.Errorf("%w: session belongs to another user", ErrInvalidToken)
+	}
+
+	if session.ClientID != nil && *session.ClientID != client.ID {
+		return fmt.Errorf("%w: session belongs to another client", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// ValidateSession checks that the session the token belongs to is not expired.
+// The subject of the refresh token is taken from the session.
+func (i *Introspection) ValidateSession(session dto.Session) error {
+	if session.ExpiresAt.Before(time.Now()) {
+		return ErrRefreshTokenExpired
+	}
+
+	if i.Subject == "" {
+		i.Subject = strconv.FormatInt(session.UserID, 10)
+	}
+
+	return nil
+}
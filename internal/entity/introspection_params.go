@@ -0,0 +1,12 @@
+package entity
+
+import "github.com/go-jose/go-jose/v4"
+
+// IntrospectTokenParams is a data for introspecting a token.
+// KeySet verifies the tokens signed by the signing keys, SecretKey verifies the tokens signed by the client.
+// ClientCode is the client requesting the introspection.
+type IntrospectTokenParams struct {
+	KeySet     jose.JSONWebKeySet
+	SecretKey  string
+	ClientCode string
+}
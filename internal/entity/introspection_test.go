@@ -0,0 +1,394 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_IntrospectedToken_Introspect(t *testing.T) {
+	tokens, err := NewTokens(CreateTokensParams{
+		UserID:          userID,
+		ClientCode:      clientCode,
+		SecretKey:       secretKey,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	})
+	require.NoError(t, err)
+
+	expiredTokens, err := NewTokens(CreateTokensParams{
+		UserID:          userID,
+		ClientCode:      clientCode,
+		SecretKey:       secretKey,
+		AccessTokenTTL:  -time.Minute,
+		RefreshTokenTTL: -time.Minute,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		token             string
+		data              IntrospectTokenParams
+		expectedTokenType string
+		expectedSubject   string
+		expectedError     error
+	}{
+		{
+			name:              "successfully introspects an access token",
+			token:             tokens.AccessToken,
+			data:              IntrospectTokenParams{SecretKey: secretKey, ClientCode: clientCode},
+			expectedTokenType: TokenTypeAccessToken,
+			expectedSubject:   strconv.Itoa(userID),
+		},
+		{
+			name:              "successfully introspects a refresh token",
+			token:             tokens.RefreshToken,
+			data:              IntrospectTokenParams{SecretKey: secretKey, ClientCode: clientCode},
+			expectedTokenType: TokenTypeRefreshToken,
+		},
+		{
+			name:          "throws an error when the access token is signed by another key",
+			token:         tokens.AccessToken,
+			data:          IntrospectTokenParams{SecretKey: invalidSecretKey, ClientCode: clientCode},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "throws an error when the refresh token is signed by another key",
+			token:         tokens.RefreshToken,
+			data:          IntrospectTokenParams{SecretKey: invalidSecretKey, ClientCode: clientCode},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "throws an error when the token is an ID token",
+			token:         tokens.IDToken,
+			data:          IntrospectTokenParams{SecretKey: secretKey, ClientCode: clientCode},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "throws an error when the access token is expired",
+			token:         expiredTokens.AccessToken,
+			data:          IntrospectTokenParams{SecretKey: secretKey, ClientCode: clientCode},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "throws an error when the refresh token is expired",
+			token:         expiredTokens.RefreshToken,
+			data:          IntrospectTokenParams{SecretKey: secretKey, ClientCode: clientCode},
+			expectedError: ErrRefreshTokenExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := ParseIntrospectedToken(tc.token)
+			require.NoError(t, err)
+
+			introspection, err := token.Introspect(tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.True(t, introspection.Active)
+				assert.Equal(t, tc.expectedTokenType, introspection.TokenType)
+				assert.Equal(t, tc.expectedSubject, introspection.Subject)
+				assert.Equal(t, clientCode, introspection.ClientID)
+				assert.Equal(t, tokens.RefreshTokenID, introspection.SessionID)
+				assert.False(t, introspection.ExpiresAt.IsZero())
+			}
+		})
+	}
+}
+
+func Test_Introspection_IsUserToken(t *testing.T) {
+	tokens, err := NewTokens(CreateTokensParams{
+		UserID:          userID,
+		ClientCode:      clientCode,
+		SecretKey:       secretKey,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	})
+	require.NoError(t, err)
+
+	// The code of the client is the same as the ID of the user.
+	clientTokens, err := NewClientTokens(CreateClientTokensParams{
+		ClientCode:     strconv.Itoa(userID),
+		SecretKey:      secretKey,
+		AccessTokenTTL: accessTokenTTL,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		token    string
+		expected bool
+	}{
+		{
+			name:     "access token of the user",
+			token:    tokens.AccessToken,
+			expected: true,
+		},
+		{
+			name:  "access token of the client",
+			token: clientTokens.AccessToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := ParseIntrospectedToken(tc.token)
+			require.NoError(t, err)
+
+			introspection, err := token.Introspect(IntrospectTokenParams{SecretKey: secretKey})
+			require.NoError(t, err)
+
+			assert.Equal(t, strconv.Itoa(userID), introspection.Subject)
+			assert.Equal(t, tc.expected, introspection.IsUserToken())
+		})
+	}
+}
+
+func Test_ParseIntrospectedToken(t *testing.T) {
+	_, err := ParseIntrospectedToken("invalid token")
+
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func Test_Introspection_ValidateSession(t *testing.T) {
+	testCases := []struct {
+		name            string
+		introspection   Introspection
+		session         dto.Session
+		expectedSubject string
+		expectedError   error
+	}{
+		{
+			name:            "successfully validates the session of the access token",
+			introspection:   Introspection{Active: true, Subject: "2"},
+			session:         dto.Session{UserID: userID, ExpiresAt: time.Now().Add(time.Minute)},
+			expectedSubject: "2",
+		},
+		{
+			name:            "sets up the subject of the refresh token from the session",
+			introspection:   Introspection{Active: true},
+			session:         dto.Session{UserID: userID, ExpiresAt: time.Now().Add(time.Minute)},
+			expectedSubject: strconv.Itoa(userID),
+		},
+		{
+			name:          "throws an error when the session is expired",
+			introspection: Introspection{Active: true},
+			session:       dto.Session{UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)},
+			expectedError: ErrRefreshTokenExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.introspection.ValidateSession(tc.session)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedSubject, tc.introspection.Subject)
+			}
+		})
+	}
+}
+
+func Test_Introspection_HasScope(t *testing.T) {
+	testCases := []struct {
+		name          string
+		introspection Introspection
+		scope         string
+		expected      bool
+	}{
+		{
+			name:          "scope is granted",
+			introspection: Introspection{Scope: "profile:read sso:admin"},
+			scope:         "sso:admin",
+			expected:      true,
+		},
+		{
+			name:          "scope is not granted",
+			introspection: Introspection{Scope: "profile:read"},
+			scope:         "sso:admin",
+		},
+		{
+			name:          "scope is a part of the granted scope",
+			introspection: Introspection{Scope: "sso:admin:read"},
+			scope:         "sso:admin",
+		},
+		{
+			name:          "token has no scope",
+			introspection: Introspection{},
+			scope:         "sso:admin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.introspection.HasScope(tc.scope))
+		})
+	}
+}
+
+func Test_Introspection_HasPermission(t *testing.T) {
+	testCases := []struct {
+		name          string
+		introspection Introspection
+		expected      bool
+	}{
+		{
+			name:          "permission is granted to the token and its owner",
+			introspection: Introspection{Scope: "sso:admin", Permissions: []string{"sso:admin"}},
+			expected:      true,
+		},
+		{
+			name:          "permission is granted to the token only",
+			introspection: Introspection{Scope: "sso:admin", Permissions: []string{"profile:read"}},
+		},
+		{
+			name:          "permission is granted to the owner only",
+			introspection: Introspection{Scope: "profile:read", Permissions: []string{"sso:admin"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.introspection.HasPermission("sso:admin"))
+		})
+	}
+}
+
+func Test_Introspection_ValidateClient(t *testing.T) {
+	client := dto.Client{ID: clientID, Code: clientCode, Audiences: []string{"test audience"}}
+
+	testCases := []struct {
+		name          string
+		introspection Introspection
+		expectedError error
+	}{
+		{
+			name: "successfully validates the access token of the user",
+			introspection: Introspection{
+				Subject:  strconv.Itoa(userID),
+				ClientID: clientCode,
+				Audience: []string{"test audience"},
+				TokenUse: jwtclaims.TokenUseAccess,
+			},
+		},
+		{
+			name: "successfully validates the access token of the client",
+			introspection: Introspection{
+				Subject:  clientCode,
+				ClientID: clientCode,
+				TokenUse: jwtclaims.TokenUseClient,
+			},
+		},
+		{
+			name: "throws an error when the token is issued to another client",
+			introspection: Introspection{
+				Subject:  strconv.Itoa(userID),
+				ClientID: "another client",
+				TokenUse: jwtclaims.TokenUseAccess,
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "throws an error when the subject of the client token is another client",
+			introspection: Introspection{
+				Subject:  "another client",
+				ClientID: clientCode,
+				TokenUse: jwtclaims.TokenUseClient,
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "throws an error when the audience is not an audience of the client",
+			introspection: Introspection{
+				Subject:  strconv.Itoa(userID),
+				ClientID: clientCode,
+				Audience: []string{"another audience"},
+				TokenUse: jwtclaims.TokenUseAccess,
+			},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.introspection.ValidateClient(client)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Introspection_ValidateSessionOwner(t *testing.T) {
+	client := dto.Client{ID: clientID, Code: clientCode}
+	sessionClientID := int64(clientID)
+	anotherClientID := int64(clientID + 1)
+
+	testCases := []struct {
+		name          string
+		subject       string
+		session       dto.Session
+		expectedError error
+	}{
+		{
+			name:    "successfully validates the session of the user and the client",
+			subject: strconv.Itoa(userID),
+			session: dto.Session{UserID: userID, ClientID: &sessionClientID},
+		},
+		{
+			name:          "throws an error when the session belongs to another user",
+			subject:       strconv.Itoa(userID + 1),
+			session:       dto.Session{UserID: userID, ClientID: &sessionClientID},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "throws an error when the session belongs to another client",
+			subject:       strconv.Itoa(userID),
+			session:       dto.Session{UserID: userID, ClientID: &anotherClientID},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			introspection := Introspection{Subject: tc.subject, TokenUse: jwtclaims.TokenUseAccess}
+			err := introspection.ValidateSessionOwner(tc.session, client)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// LoginAttempt is the record of the failed logins of the username or the client IP address.
+// The record of the username is kept for the unknown usernames as well, so the lockout does not reveal
+// whether the user exists.
+type LoginAttempt struct {
+	ID            int64
+	Kind          enum.LoginAttemptKindEnum
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+
+	throttle   LoginThrottle
+	dataStatus enum.DataStatusEnum
+}
+
+// NewLoginAttempt returns a new login attempt entity without failures.
+func NewLoginAttempt(kind enum.LoginAttemptKindEnum, key string) LoginAttempt {
+	return LoginAttempt{
+		Kind: kind,
+		Key:  key,
+	}
+}
+
+// Locked reports whether the logins are locked at the given time.
+func (a *LoginAttempt) Locked(now time.Time) bool {
+	return a.LockedUntil.After(now)
+}
+
+// check checks the login is allowed at the given time. The locked username returns ErrAccountLocked,
+// the locked address and the login before the delay after the last failure return ErrLoginThrottled.
+func (a *LoginAttempt) check(throttle LoginThrottle, now time.Time) error {
+	const op = "entity.LoginAttempt.check"
+
+	if a.Locked(now) {
+		if a.Kind == enum.LoginAttemptKindUsername {
+			return fmt.Errorf("%s: %w", op, ErrAccountLocked)
+		}
+
+		return fmt.Errorf("%s: %w", op, ErrLoginThrottled)
+	}
+
+	if a.expired(throttle, now) {
+		return nil
+	}
+
+	if now.Before(a.LastFailureAt.Add(throttle.delay(a.Failures))) {
+		return fmt.Errorf("%s: %w", op, ErrLoginThrottled)
+	}
+
+	return nil
+}
+
+// WindowStart returns the time before which the failures are forgotten at the last failure.
+// The zero time is returned if the failures are never forgotten.
+func (a *LoginAttempt) WindowStart() time.Time {
+	if a.throttle.FailureWindow <= 0 {
+		return time.Time{}
+	}
+
+	return a.LastFailureAt.Add(-a.throttle.FailureWindow)
+}
+
+// CountSavedFailures sets up the failures counted by the storage, which include the failures of the concurrent
+// logins the attempt was read before, and locks the logins once they reach the maximum.
+// It reports whether the lock is changed and must be saved.
+func (a *LoginAttempt) CountSavedFailures(id int64, failures int, lockedUntil time.Time) bool {
+	a.ID = id
+	a.Failures = failures
+	a.LockedUntil = lockedUntil
+
+	return a.lock()
+}
+
+// fail counts the failed login at the given time and locks the logins once the failures reach the maximum.
+// The storage counts the failure atomically and reports the saved failures back by CountSavedFailures,
+// so the failures of the concurrent logins are not lost.
+func (a *LoginAttempt) fail(throttle LoginThrottle, now time.Time) {
+	if a.expired(throttle, now) {
+		a.Failures = 0
+	}
+
+	a.Failures++
+	a.LastFailureAt = now
+	a.throttle = throttle
+	a.lock()
+
+	if a.ID == emptyID {
+		a.SetToCreate()
+	} else {
+		a.SetToUpdate()
+	}
+}
+
+// lock locks the logins for the lockout duration after the last failure once the failures reach the maximum.
+// It reports whether the lock is changed.
+func (a *LoginAttempt) lock() bool {
+	maxFailures := a.throttle.maxFailures(a.Kind)
+	if maxFailures <= 0 || a.Failures < maxFailures || a.throttle.LockoutDuration <= 0 {
+		return false
+	}
+
+	lockedUntil := a.LastFailureAt.Add(a.throttle.LockoutDuration)
+	if !lockedUntil.After(a.LockedUntil) {
+		return false
+	}
+
+	a.LockedUntil = lockedUntil
+
+	return true
+}
+
+// expired reports whether the failures are older than the failure window, so they are forgotten.
+func (a *LoginAttempt) expired(throttle LoginThrottle, now time.Time) bool {
+	return throttle.FailureWindow > 0 && now.Sub(a.LastFailureAt) > throttle.FailureWindow
+}
+
+func (a *LoginAttempt) SetToCreate() {
+	a.dataStatus = enum.ToCreate
+}
+
+func (a *LoginAttempt) SetToUpdate() {
+	a.dataStatus = enum.ToUpdate
+}
+
+func (a *LoginAttempt) SetToRemove() {
+	a.dataStatus = enum.ToRemove
+}
+
+func (a *LoginAttempt) IsToCreate() bool {
+	return a.dataStatus == enum.ToCreate
+}
+
+func (a *LoginAttempt) IsToUpdate() bool {
+	return a.dataStatus == enum.ToUpdate
+}
+
+func (a *LoginAttempt) IsToRemove() bool {
+	return a.dataStatus == enum.ToRemove
+}
+
+func (a *LoginAttempt) ResetDataStatus() {
+	a.dataStatus = enum.None
+}
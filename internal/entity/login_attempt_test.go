@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_LoginThrottle_delay(t *testing.T) {
+	t.Parallel()
+
+	throttle := LoginThrottle{
+		BaseDelay: time.Second,
+		MaxDelay:  10 * time.Second,
+	}
+
+	testCases := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 0, expected: 0},
+		{failures: 1, expected: time.Second},
+		{failures: 2, expected: 2 * time.Second},
+		{failures: 4, expected: 8 * time.Second},
+		{failures: 5, expected: 10 * time.Second},
+		{failures: 1000, expected: 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, throttle.delay(tc.failures), "failures: %d", tc.failures)
+	}
+}
+
+func Test_LoginAttempt_check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	throttle := LoginThrottle{
+		MaxFailures:     3,
+		IPMaxFailures:   5,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutDuration: time.Hour,
+		FailureWindow:   time.Hour,
+	}
+
+	testCases := []struct {
+		name          string
+		kind          enum.LoginAttemptKindEnum
+		failures      int
+		sinceFailure  time.Duration
+		expectedError error
+	}{
+		{
+			name: "allows first login",
+			kind: enum.LoginAttemptKindUsername,
+		},
+		{
+			name:          "delays login after failure",
+			kind:          enum.LoginAttemptKindUsername,
+			failures:      2,
+			sinceFailure:  time.Second,
+			expectedError: ErrLoginThrottled,
+		},
+		{
+			name:         "allows login after delay",
+			kind:         enum.LoginAttemptKindUsername,
+			failures:     2,
+			sinceFailure: 3 * time.Second,
+		},
+		{
+			name:          "locks username",
+			kind:          enum.LoginAttemptKindUsername,
+			failures:      3,
+			sinceFailure:  time.Minute,
+			expectedError: ErrAccountLocked,
+		},
+		{
+			name:         "allows address below its own limit",
+			kind:         enum.LoginAttemptKindIP,
+			failures:     3,
+			sinceFailure: time.Minute,
+		},
+		{
+			name:          "locks address",
+			kind:          enum.LoginAttemptKindIP,
+			failures:      5,
+			sinceFailure:  time.Minute,
+			expectedError: ErrLoginThrottled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attempt := NewLoginAttempt(tc.kind, "key")
+			for i := range tc.failures {
+				attempt.fail(throttle, now.Add(-tc.sinceFailure-time.Duration(tc.failures-1-i)*time.Millisecond))
+			}
+
+			err := attempt.check(throttle, now)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_LoginAttempt_fail(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	throttle := LoginThrottle{
+		MaxFailures:     3,
+		LockoutDuration: time.Hour,
+		FailureWindow:   time.Hour,
+	}
+
+	attempt := NewLoginAttempt(enum.LoginAttemptKindUsername, "user")
+	attempt.fail(throttle, now.Add(-2*time.Hour))
+	attempt.fail(throttle, now.Add(-2*time.Hour))
+	assert.True(t, attempt.IsToCreate())
+
+	// The failures older than the window are forgotten.
+	attempt.fail(throttle, now)
+	assert.Equal(t, 1, attempt.Failures)
+	assert.False(t, attempt.Locked(now))
+
+	attempt.fail(throttle, now)
+	attempt.fail(throttle, now)
+	assert.True(t, attempt.Locked(now))
+	assert.False(t, attempt.Locked(now.Add(throttle.LockoutDuration)))
+}
+
+func Test_LoginAttempt_CountSavedFailures(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	throttle := LoginThrottle{
+		MaxFailures:     3,
+		LockoutDuration: time.Hour,
+		FailureWindow:   time.Hour,
+	}
+
+	attempt := NewLoginAttempt(enum.LoginAttemptKindUsername, "user")
+	attempt.fail(throttle, now)
+	assert.Equal(t, now.Add(-throttle.FailureWindow), attempt.WindowStart())
+
+	// The attempt was read before the failures of the concurrent logins were counted.
+	assert.False(t, attempt.CountSavedFailures(1, 2, time.Time{}))
+	assert.Equal(t, int64(1), attempt.ID)
+	assert.False(t, attempt.Locked(now))
+
+	// The lock is changed once the saved failures reach the maximum.
+	assert.True(t, attempt.CountSavedFailures(1, 3, time.Time{}))
+	assert.True(t, attempt.Locked(now))
+
+	// The lock saved by the concurrent login is kept.
+	assert.False(t, attempt.CountSavedFailures(1, 4, now.Add(throttle.LockoutDuration)))
+	assert.True(t, attempt.Locked(now))
+
+	// The failures are never forgotten without the window.
+	attempt.fail(LoginThrottle{}, now)
+	assert.True(t, attempt.WindowStart().IsZero())
+}
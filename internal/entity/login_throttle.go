@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// LoginThrottle is the brute-force protection of the login by the password.
+// The failed logins are counted per username and per client IP address. After each failure the next login
+// with the same username or from the same address is delayed, the delay starts with BaseDelay and doubles
+// with each failure up to MaxDelay. After MaxFailures failures of the username or IPMaxFailures failures
+// of the address, the logins are locked for LockoutDuration. Zero MaxFailures or IPMaxFailures disables the lockout,
+// zero BaseDelay disables the delay. The failures older than FailureWindow are forgotten.
+type LoginThrottle struct {
+	MaxFailures     int
+	IPMaxFailures   int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+	FailureWindow   time.Duration
+}
+
+// delay returns the time the next login must wait after the given number of the failures.
+// If MaxDelay is less than BaseDelay, the delay does not grow.
+func (t LoginThrottle) delay(failures int) time.Duration {
+	if failures <= 0 || t.BaseDelay <= 0 {
+		return 0
+	}
+
+	maxDelay := max(t.MaxDelay, t.BaseDelay)
+
+	delay := t.BaseDelay
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+// maxFailures returns the number of the failures after which the logins of the kind are locked.
+func (t LoginThrottle) maxFailures(kind enum.LoginAttemptKindEnum) int {
+	if kind == enum.LoginAttemptKindIP {
+		return t.IPMaxFailures
+	}
+
+	return t.MaxFailures
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// mfaChallengeTokenLength is the number of random bytes of the MFA challenge token.
+const mfaChallengeTokenLength = 32
+
+// MFAChallenge is the pending login of the user who has passed the password check and must pass
+// the second authentication factor. The challenge keeps the session data of the login, the session is created
+// once the second factor is verified. The token itself is known only when it is created,
+// the storage keeps the hash of the token.
+type MFAChallenge struct {
+	ID          int64
+	Token       string
+	TokenHash   string
+	UserID      int64
+	ClientID    int64
+	UserAgent   string
+	Fingerprint string
+	Issuer      string
+	Nonce       string
+	ExpiresAt   time.Time
+	Attempts    int
+	Used        bool
+
+	maxAttempts int
+	dataStatus  enum.DataStatusEnum
+}
+
+// NewMFAChallenge returns a new MFA challenge entity with the generated token.
+func NewMFAChallenge(data CreateMFAChallengeParams) (MFAChallenge, error) {
+	tokenBytes := make([]byte, mfaChallengeTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return MFAChallenge{}, fmt.Errorf("%w: %w", ErrCreateMFAChallenge, err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	return MFAChallenge{
+		Token:       token,
+		TokenHash:   HashMFAChallengeToken(token),
+		UserID:      data.UserID,
+		ClientID:    data.ClientID,
+		UserAgent:   data.UserAgent,
+		Fingerprint: data.Fingerprint,
+		Issuer:      data.Issuer,
+		Nonce:       data.Nonce,
+		ExpiresAt:   time.Now().Add(data.TTL),
+	}, nil
+}
+
+// HashMFAChallengeToken returns the hash of the MFA challenge token which is kept in the storage.
+func HashMFAChallengeToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Validate checks that the MFA challenge is neither used nor expired, and the attempts are not exceeded.
+func (c *MFAChallenge) Validate(maxAttempts int) error {
+	const op = "entity.MFAChallenge.Validate"
+
+	if c.Used {
+		return fmt.Errorf("%s: %w", op, ErrMFAChallengeNotFound)
+	}
+
+	if c.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("%s: %w", op, ErrMFAChallengeExpired)
+	}
+
+	if c.Attempts >= maxAttempts {
+		return fmt.Errorf("%s: %w", op, ErrMFAAttemptsExceeded)
+	}
+
+	return nil
+}
+
+// MaxAttempts returns the maximum attempts of the challenge the attempt is counted against.
+func (c *MFAChallenge) MaxAttempts() int {
+	return c.maxAttempts
+}
+
+// countAttempt counts the verification of the challenge as an attempt. The storage counts the attempt
+// atomically against the maximum attempts, so the concurrent verifications do not exceed them.
+func (c *MFAChallenge) countAttempt(maxAttempts int) {
+	c.Attempts++
+	c.maxAttempts = maxAttempts
+	c.SetToUpdate()
+}
+
+func (c *MFAChallenge) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *MFAChallenge) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *MFAChallenge) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *MFAChallenge) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *MFAChallenge) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *MFAChallenge) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *MFAChallenge) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
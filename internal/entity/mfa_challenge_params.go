@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// CreateMFAChallengeParams is a data for creating new MFA challenge.
+type CreateMFAChallengeParams struct {
+	UserID      int64
+	ClientID    int64
+	UserAgent   string
+	Fingerprint string
+	Issuer      string
+	Nonce       string
+	TTL         time.Duration
+}
@@ -0,0 +1,506 @@
+package entity
+
+import (
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	totpSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	backupCode = "abcde-fghjk"
+)
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	return code
+}
+
+func enabledMFA() dto.UserMFA {
+	return dto.UserMFA{
+		TOTPCredential: dto.TOTPCredential{
+			ID:        1,
+			UserID:    userID,
+			Secret:    totpSecret,
+			Confirmed: true,
+		},
+		BackupCodes: []dto.BackupCode{
+			{ID: 1, UserID: userID, CodeHash: HashBackupCode(backupCode)},
+		},
+	}
+}
+
+func Test_Auth_Login_MFA(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+	data := LoginParams{
+		Password:        validPassword,
+		UserAgent:       userAgent,
+		Fingerprint:     fingerprint,
+		Issuer:          issuer,
+		MFAChallengeTTL: time.Minute,
+	}
+
+	testCases := []struct {
+		name              string
+		mfa               dto.UserMFA
+		requireMFA        bool
+		expectedChallenge bool
+		expectedError     error
+	}{
+		{
+			name: "creates the session when MFA is not enabled",
+		},
+		{
+			name:              "creates the MFA challenge when the user has enabled MFA",
+			mfa:               enabledMFA(),
+			expectedChallenge: true,
+		},
+		{
+			name:              "creates the MFA challenge when the client requires MFA",
+			mfa:               enabledMFA(),
+			requireMFA:        true,
+			expectedChallenge: true,
+		},
+		{
+			name:          "throws an error when the client requires MFA and the user has not enabled it",
+			requireMFA:    true,
+			expectedError: ErrMFAEnrollmentRequired,
+		},
+		{
+			name: "throws an error when the client requires MFA and the TOTP is not confirmed",
+			mfa: dto.UserMFA{
+				TOTPCredential: dto.TOTPCredential{ID: 1, UserID: userID, Secret: totpSecret},
+			},
+			requireMFA:    true,
+			expectedError: ErrMFAEnrollmentRequired,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := dto.Client{ID: clientID, SecretKey: secretKey, RequireMFA: tt.requireMFA}
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(user),
+				WithAuthClient(client),
+				WithAuthMFA(tt.mfa),
+				WithAuthNonce("nonce"),
+			)
+			require.NoError(t, err)
+
+			tokens, err := auth.Login(data)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+
+				return
+			}
+			require.NoError(t, err)
+
+			if !tt.expectedChallenge {
+				assert.Empty(t, tokens.MFAToken)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.Empty(t, auth.MFAChallenges)
+
+				return
+			}
+
+			assert.NotEmpty(t, tokens.MFAToken)
+			assert.Empty(t, tokens.AccessToken)
+			assert.Empty(t, tokens.RefreshToken)
+			assert.Empty(t, auth.Sessions)
+
+			require.Len(t, auth.MFAChallenges, 1)
+			challenge := auth.MFAChallenges[0]
+			assert.True(t, challenge.IsToCreate())
+			assert.Equal(t, HashMFAChallengeToken(tokens.MFAToken), challenge.TokenHash)
+			assert.Equal(t, int64(userID), challenge.UserID)
+			assert.Equal(t, int64(clientID), challenge.ClientID)
+			assert.Equal(t, userAgent, challenge.UserAgent)
+			assert.Equal(t, fingerprint, challenge.Fingerprint)
+			assert.Equal(t, issuer, challenge.Issuer)
+			assert.Equal(t, "nonce", challenge.Nonce)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), challenge.ExpiresAt, time.Second)
+		})
+	}
+}
+
+func Test_Auth_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+	client := dto.Client{ID: clientID, Code: clientCode, SecretKey: secretKey}
+	validChallenge := dto.MFAChallenge{
+		ID:          1,
+		TokenHash:   HashMFAChallengeToken("token"),
+		UserID:      userID,
+		ClientID:    clientID,
+		UserAgent:   userAgent,
+		Fingerprint: fingerprint,
+		Issuer:      issuer,
+		ExpiresAt:   time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		name             string
+		challenge        dto.MFAChallenge
+		mfa              dto.UserMFA
+		loginAttempts    []dto.LoginAttempt
+		code             func(t *testing.T) string
+		expectedError    error
+		expectedAttempts int
+	}{
+		{
+			name:      "successfully verifies the TOTP code",
+			challenge: validChallenge,
+			mfa:       enabledMFA(),
+			code:      func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+		},
+		{
+			name:      "successfully verifies the backup code",
+			challenge: validChallenge,
+			mfa:       enabledMFA(),
+			code:      func(*testing.T) string { return "ABCDE FGHJK" },
+		},
+		{
+			name:             "throws an error when the code is invalid",
+			challenge:        validChallenge,
+			mfa:              enabledMFA(),
+			code:             func(*testing.T) string { return "000000" },
+			expectedError:    ErrInvalidMFACode,
+			expectedAttempts: 1,
+		},
+		{
+			name:      "throws an error when the TOTP code is reused",
+			challenge: validChallenge,
+			mfa: func() dto.UserMFA {
+				mfa := enabledMFA()
+				mfa.TOTPCredential.LastUsedStep = totp.Step(time.Now()) + totpSkew
+
+				return mfa
+			}(),
+			code:             func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError:    ErrInvalidMFACode,
+			expectedAttempts: 1,
+		},
+		{
+			name:      "throws an error when the backup code is used",
+			challenge: validChallenge,
+			mfa: func() dto.UserMFA {
+				mfa := enabledMFA()
+				mfa.BackupCodes[0].Used = true
+
+				return mfa
+			}(),
+			code:             func(*testing.T) string { return backupCode },
+			expectedError:    ErrInvalidMFACode,
+			expectedAttempts: 1,
+		},
+		{
+			name:          "throws an error when the challenge is not found",
+			mfa:           enabledMFA(),
+			code:          func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError: ErrMFAChallengeNotFound,
+		},
+		{
+			name: "throws an error when the challenge is used",
+			challenge: func() dto.MFAChallenge {
+				challenge := validChallenge
+				challenge.Used = true
+
+				return challenge
+			}(),
+			mfa:           enabledMFA(),
+			code:          func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError: ErrMFAChallengeNotFound,
+		},
+		{
+			name: "throws an error when the challenge is expired",
+			challenge: func() dto.MFAChallenge {
+				challenge := validChallenge
+				challenge.ExpiresAt = time.Now().Add(-time.Minute)
+
+				return challenge
+			}(),
+			mfa:           enabledMFA(),
+			code:          func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError: ErrMFAChallengeExpired,
+		},
+		{
+			name: "throws an error when the attempts are exceeded",
+			challenge: func() dto.MFAChallenge {
+				challenge := validChallenge
+				challenge.Attempts = 3
+
+				return challenge
+			}(),
+			mfa:              enabledMFA(),
+			code:             func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError:    ErrMFAAttemptsExceeded,
+			expectedAttempts: 3,
+		},
+		{
+			name:      "throws an error when the MFA codes of the username are locked",
+			challenge: validChallenge,
+			mfa:       enabledMFA(),
+			loginAttempts: []dto.LoginAttempt{
+				{
+					ID:            1,
+					Kind:          enum.LoginAttemptKindMFA,
+					Failures:      3,
+					LastFailureAt: time.Now(),
+					LockedUntil:   time.Now().Add(time.Minute),
+				},
+			},
+			code:          func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError: ErrMFAAttemptsExceeded,
+		},
+		{
+			name:          "throws an error when the user has disabled MFA",
+			challenge:     validChallenge,
+			code:          func(t *testing.T) string { return currentTOTPCode(t, totpSecret) },
+			expectedError: ErrMFAEnrollmentRequired,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(user),
+				WithAuthClient(client),
+				WithAuthMFA(tt.mfa),
+				WithAuthMFAChallenge(tt.challenge),
+				WithAuthLoginAttempts(tt.loginAttempts...),
+			)
+			require.NoError(t, err)
+
+			tokens, err := auth.VerifyMFA(VerifyMFAParams{Code: tt.code(t), MaxAttempts: 3, LockoutDuration: time.Minute})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, auth.Sessions)
+
+				if len(auth.MFAChallenges) > 0 {
+					assert.Equal(t, tt.expectedAttempts, auth.MFAChallenges[0].Attempts)
+					assert.Equal(t, tt.challenge.Used, auth.MFAChallenges[0].Used)
+				}
+
+				// The mismatched code counts as an attempt of the username as well.
+				if errors.Is(tt.expectedError, ErrInvalidMFACode) {
+					require.Len(t, auth.LoginAttempts, 1)
+					assert.Equal(t, 1, auth.LoginAttempts[0].Failures)
+					assert.True(t, auth.LoginAttempts[0].IsToCreate())
+				}
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEmpty(t, tokens.RefreshToken)
+			assert.NotEmpty(t, tokens.IDToken)
+			assert.Empty(t, tokens.MFAToken)
+
+			require.Len(t, auth.Sessions, 1)
+			assert.Equal(t, userAgent, auth.Sessions[0].UserAgent)
+			assert.Equal(t, fingerprint, auth.Sessions[0].Fingerprint)
+
+			assert.True(t, auth.MFAChallenges[0].Used)
+			assert.True(t, auth.MFAChallenges[0].IsToUpdate())
+			assert.True(t, auth.TOTP.IsToUpdate() || auth.BackupCodes[0].IsToUpdate())
+		})
+	}
+}
+
+func Test_Auth_Authorize_MFA(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+	client := dto.Client{
+		ID:           clientID,
+		Code:         clientCode,
+		SecretKey:    secretKey,
+		RedirectURIs: []string{redirectURI},
+	}
+
+	testCases := []struct {
+		name          string
+		mfaCode       string
+		expectedError error
+	}{
+		{
+			name:    "successfully authorizes the client with the backup code",
+			mfaCode: backupCode,
+		},
+		{
+			name:          "throws an error when the code is missing",
+			expectedError: ErrMFARequired,
+		},
+		{
+			name:          "throws an error when the code is invalid",
+			mfaCode:       "000000",
+			expectedError: ErrInvalidMFACode,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(user),
+				WithAuthClient(client),
+				WithAuthMFA(enabledMFA()),
+			)
+			require.NoError(t, err)
+
+			_, err = auth.Authorize(AuthorizeParams{
+				Password:            validPassword,
+				MFACode:             tt.mfaCode,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: CodeChallengeMethodS256,
+				CodeTTL:             time.Minute,
+			})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, auth.AuthorizationCodes)
+
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, auth.AuthorizationCodes, 1)
+			assert.True(t, auth.BackupCodes[0].Used)
+			assert.True(t, auth.BackupCodes[0].IsToUpdate())
+		})
+	}
+}
+
+func Test_Auth_TOTPLifecycle(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		Username:     "john",
+		PasswordHash: passwordHash,
+	}
+
+	auth, err := NewAuth(accessTokenTTL, refreshTokenTTL, WithAuthUser(user), WithAuthMFA(dto.UserMFA{}))
+	require.NoError(t, err)
+
+	// Enrollment.
+	_, err = auth.EnrollTOTP(EnrollTOTPParams{Password: invalidPassword, Issuer: "pxr-sso"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	enrollment, err := auth.EnrollTOTP(EnrollTOTPParams{Password: validPassword, Issuer: "pxr-sso"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/pxr-sso:john?")
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+	assert.True(t, auth.TOTP.IsToCreate())
+	assert.False(t, auth.TOTP.Confirmed)
+
+	// Confirmation.
+	_, err = auth.ConfirmTOTP(ConfirmTOTPParams{Password: validPassword, Code: "000000", BackupCodesCount: 3})
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+	assert.False(t, auth.TOTP.Confirmed)
+
+	auth.TOTP.ID = 1
+	auth.TOTP.ResetDataStatus()
+
+	backupCodes, err := auth.ConfirmTOTP(ConfirmTOTPParams{
+		Password:         validPassword,
+		Code:             currentTOTPCode(t, enrollment.Secret),
+		BackupCodesCount: 3,
+	})
+	require.NoError(t, err)
+	assert.True(t, auth.TOTP.Confirmed)
+	assert.True(t, auth.TOTP.IsToUpdate())
+	require.Len(t, backupCodes, 3)
+	for _, code := range backupCodes {
+		assert.Len(t, code.Code, backupCodeLength+1)
+		assert.Equal(t, HashBackupCode(code.Code), code.CodeHash)
+		assert.True(t, code.IsToCreate())
+	}
+
+	_, err = auth.EnrollTOTP(EnrollTOTPParams{Password: validPassword, Issuer: "pxr-sso"})
+	assert.ErrorIs(t, err, ErrTOTPAlreadyEnabled)
+
+	// Backup codes regeneration.
+	for i := range auth.BackupCodes {
+		auth.BackupCodes[i].ID = int64(i + 1)
+		auth.BackupCodes[i].ResetDataStatus()
+	}
+
+	regenerated, err := auth.RegenerateBackupCodes(RegenerateBackupCodesParams{
+		Password: validPassword,
+		Code:     backupCodes[0].Code,
+		Count:    2,
+	})
+	require.NoError(t, err)
+	assert.Len(t, regenerated, 2)
+	require.Len(t, auth.BackupCodes, 5)
+	for _, code := range auth.BackupCodes[:3] {
+		assert.True(t, code.IsToRemove())
+	}
+
+	// Disabling.
+	err = auth.DisableTOTP(DisableTOTPParams{Password: validPassword, Code: "000000"})
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	err = auth.DisableTOTP(DisableTOTPParams{Password: validPassword, Code: regenerated[0].Code})
+	require.NoError(t, err)
+	assert.True(t, auth.TOTP.IsToRemove())
+	for _, code := range auth.BackupCodes {
+		assert.True(t, code.IsToRemove())
+	}
+}
+
+func Test_Auth_TOTPNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+
+	auth, err := NewAuth(accessTokenTTL, refreshTokenTTL, WithAuthUser(user))
+	require.NoError(t, err)
+
+	_, err = auth.ConfirmTOTP(ConfirmTOTPParams{Password: validPassword, Code: "000000"})
+	assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+
+	err = auth.DisableTOTP(DisableTOTPParams{Password: validPassword, Code: "000000"})
+	assert.ErrorIs(t, err, ErrTOTPNotEnabled)
+
+	_, err = auth.RegenerateBackupCodes(RegenerateBackupCodesParams{Password: validPassword, Code: "000000"})
+	assert.ErrorIs(t, err, ErrTOTPNotEnabled)
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"net/url"
+	"time"
+)
+
+// Notification is the message delivered to the user by the notifier.
+// Channel is the kind of the recipient contact, the notifier delivers the message through it.
+type Notification struct {
+	Channel   enum.ContactChannelEnum
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// NewPasswordResetNotification returns the notification which delivers the password reset token
+// to the contact of the user. If the reset URL is set, the token is passed in its "token" query parameter,
+// otherwise the token itself is sent.
+func NewPasswordResetNotification(
+	channel enum.ContactChannelEnum,
+	recipient string,
+	token PasswordResetToken,
+	resetURL string,
+) (Notification, error) {
+	instruction := "Use the following token to reset your password: " + token.Token
+	if resetURL != "" {
+		link, err := url.Parse(resetURL)
+		if err != nil {
+			return Notification{}, fmt.Errorf("%w: %w", ErrCreateNotification, err)
+		}
+
+		query := link.Query()
+		query.Set("token", token.Token)
+		link.RawQuery = query.Encode()
+
+		instruction = "Follow the link to reset your password: " + link.String()
+	}
+
+	body := fmt.Sprintf(
+		"A password reset was requested for your account.\n\n%s\n\n"+
+			"The token expires at %s and can be used only once.\n"+
+			"If you did not request the password reset, ignore this message.\n",
+		instruction,
+		token.ExpiresAt.UTC().Format(time.RFC1123),
+	)
+
+	return Notification{
+		Channel:   channel,
+		Recipient: recipient,
+		Subject:   "Password reset",
+		Body:      body,
+	}, nil
+}
+
+// NewVerificationCodeNotification returns the notification which delivers the verification code
+// to the contact being verified.
+func NewVerificationCodeNotification(code VerificationCode) Notification {
+	body := fmt.Sprintf(
+		"Your verification code is %s.\n\n"+
+			"The code expires at %s.\n"+
+			"If you did not request the verification, ignore this message.\n",
+		code.Code,
+		code.ExpiresAt.UTC().Format(time.RFC1123),
+	)
+
+	return Notification{
+		Channel:   code.Channel,
+		Recipient: code.Target,
+		Subject:   "Verification code",
+		Body:      body,
+	}
+}
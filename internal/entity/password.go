@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/pkg/passhash"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxPasswordBytes is the maximum length of the password bcrypt can hash.
+	maxPasswordBytes = 72
+
+	// temporaryPasswordLength is the number of random bytes of the generated temporary password.
+	temporaryPasswordLength = 16
+)
+
+// PasswordPolicy is the policy the new passwords of the users must meet.
+// MaxLength is the maximum length of the password in bytes, it is capped by the 72 bytes bcrypt can hash.
+// If ForbidUserInfo is set, the password can't be the username or the full name of the user.
+// If Breached is set, the passwords known from the data breaches are rejected.
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	ForbidUserInfo   bool
+	Breached         BreachedPasswords
+}
+
+// BreachedPasswords is the list of the passwords known from the data breaches.
+type BreachedPasswords interface {
+	// Contains reports whether the password is in the list.
+	Contains(password string) bool
+}
+
+// PasswordViolation is the rule of the password policy the password violates.
+type PasswordViolation struct {
+	Reason      enum.PasswordViolationEnum
+	Description string
+}
+
+// PasswordPolicyError is the error with all the rules of the password policy the password violates,
+// it wraps ErrWeakPassword.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		descriptions[i] = violation.Description
+	}
+
+	return fmt.Sprintf("%s: %s", ErrWeakPassword, strings.Join(descriptions, "; "))
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// Validate checks that the password meets the policy. The user info is the username and the full name
+// of the user the password is set for. If the password violates the policy, *PasswordPolicyError is returned.
+func (p PasswordPolicy) Validate(password string, userInfo ...string) error {
+	var violations []PasswordViolation
+	violate := func(reason enum.PasswordViolationEnum, format string, args ...any) {
+		violations = append(violations, PasswordViolation{
+			Reason:      reason,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violate(enum.PasswordViolationTooShort, "password must be at least %d characters long", p.MinLength)
+	}
+
+	if maxLength := p.maxLength(); len(password) > maxLength {
+		violate(enum.PasswordViolationTooLong, "password must be at most %d bytes long", maxLength)
+	}
+
+	var hasUppercase, hasLowercase, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUppercase = true
+		case unicode.IsLower(r):
+			hasLowercase = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUppercase {
+		violate(enum.PasswordViolationMissingUppercase, "password must contain an uppercase letter")
+	}
+
+	if p.RequireLowercase && !hasLowercase {
+		violate(enum.PasswordViolationMissingLowercase, "password must contain a lowercase letter")
+	}
+
+	if p.RequireDigit && !hasDigit {
+		violate(enum.PasswordViolationMissingDigit, "password must contain a digit")
+	}
+
+	if p.RequireSymbol && !hasSymbol {
+		violate(enum.PasswordViolationMissingSymbol, "password must contain a symbol")
+	}
+
+	if p.ForbidUserInfo && matchesUserInfo(password, userInfo) {
+		violate(enum.PasswordViolationUserInfo, "password must not be the username or the full name")
+	}
+
+	if p.Breached != nil && p.Breached.Contains(password) {
+		violate(enum.PasswordViolationBreached, "password is known from a data breach")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+// maxLength returns the maximum length of the password in bytes.
+func (p PasswordPolicy) maxLength() int {
+	if p.MaxLength <= 0 || p.MaxLength > maxPasswordBytes {
+		return maxPasswordBytes
+	}
+
+	return p.MaxLength
+}
+
+// matchesUserInfo reports whether the password is one of the user info values, the case is ignored.
+func matchesUserInfo(password string, userInfo []string) bool {
+	password = strings.TrimSpace(password)
+	for _, info := range userInfo {
+		info = strings.TrimSpace(info)
+		if info != "" && strings.EqualFold(password, info) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PasswordHasher hashes the passwords of the users and verifies them. The hashes are self-describing,
+// so the password is verified against the hash of any supported algorithm, and the hashes produced
+// by the outdated algorithm or parameters are rehashed.
+type PasswordHasher interface {
+	// Hash returns the encoded hash of the password.
+	Hash(password string) (string, error)
+	// Verify checks the password matches the encoded hash.
+	Verify(encodedHash, password string) error
+	// NeedsRehash reports whether the encoded hash is produced by another algorithm or with other parameters.
+	NeedsRehash(encodedHash string) bool
+}
+
+// defaultPasswordHasher is the password hasher of the entities the hasher is not set up for.
+var defaultPasswordHasher PasswordHasher = passhash.Bcrypt{Cost: bcrypt.DefaultCost}
+
+// hashPassword returns the hash of the password to keep in the storage.
+func hashPassword(hasher PasswordHasher, password string) (string, error) {
+	passwordHash, err := hasher.Hash(password)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGeneratePasswordHash, err)
+	}
+
+	return passwordHash, nil
+}
+
+// generateTemporaryPassword returns a random password to reset the password of the user.
+func generateTemporaryPassword() (string, error) {
+	passwordBytes := make([]byte, temporaryPasswordLength)
+	if _, err := rand.Read(passwordBytes); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGeneratePassword, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(passwordBytes), nil
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// passwordResetTokenLength is the number of random bytes of the password reset token.
+const passwordResetTokenLength = 32
+
+// PasswordResetToken is the single-use token the user resets the forgotten password with.
+// The token itself is known only when it is created, the storage keeps the hash of the token.
+type PasswordResetToken struct {
+	ID        int64
+	Token     string
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+	Used      bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewPasswordResetToken returns a new password reset token entity with the generated token.
+func NewPasswordResetToken(userID int64, ttl time.Duration) (PasswordResetToken, error) {
+	tokenBytes := make([]byte, passwordResetTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return PasswordResetToken{}, fmt.Errorf("%w: %w", ErrCreatePasswordResetToken, err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	return PasswordResetToken{
+		Token:     token,
+		TokenHash: HashPasswordResetToken(token),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// HashPasswordResetToken returns the hash of the password reset token which is kept in the storage.
+func HashPasswordResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Redeem checks that the password reset token is neither used nor expired, and marks the token as used.
+func (t *PasswordResetToken) Redeem() error {
+	const op = "entity.PasswordResetToken.Redeem"
+
+	if t.Used {
+		return fmt.Errorf("%s: %w", op, ErrPasswordResetTokenUsed)
+	}
+
+	if t.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("%s: %w", op, ErrPasswordResetTokenExpired)
+	}
+
+	t.Used = true
+
+	return nil
+}
+
+func (t *PasswordResetToken) SetToCreate() {
+	t.dataStatus = enum.ToCreate
+}
+
+func (t *PasswordResetToken) SetToUpdate() {
+	t.dataStatus = enum.ToUpdate
+}
+
+func (t *PasswordResetToken) SetToRemove() {
+	t.dataStatus = enum.ToRemove
+}
+
+func (t *PasswordResetToken) IsToCreate() bool {
+	return t.dataStatus == enum.ToCreate
+}
+
+func (t *PasswordResetToken) IsToUpdate() bool {
+	return t.dataStatus == enum.ToUpdate
+}
+
+func (t *PasswordResetToken) IsToRemove() bool {
+	return t.dataStatus == enum.ToRemove
+}
+
+func (t *PasswordResetToken) ResetDataStatus() {
+	t.dataStatus = enum.None
+}
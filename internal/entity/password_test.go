@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_PasswordPolicy_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		password      string
+		expectedError error
+	}{
+		{
+			name:     "valid password",
+			password: "password",
+		},
+		{
+			name:     "length is counted in characters",
+			password: "пароль12",
+		},
+		{
+			name:          "too short password",
+			password:      "passwor",
+			expectedError: ErrWeakPassword,
+		},
+		{
+			name:          "too long password",
+			password:      strings.Repeat("a", maxPasswordBytes+1),
+			expectedError: ErrWeakPassword,
+		},
+	}
+
+	policy := PasswordPolicy{MinLength: 8}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := policy.Validate(tc.password)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_PasswordPolicy_Validate_Violations(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:        8,
+		MaxLength:        100,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		ForbidUserInfo:   true,
+		Breached:         breachedPasswords{"Qwerty-123"},
+	}
+
+	testCases := []struct {
+		name               string
+		password           string
+		expectedViolations []enum.PasswordViolationEnum
+	}{
+		{
+			name:     "valid password",
+			password: "Pa55-word",
+		},
+		{
+			name:     "all the violations are reported",
+			password: "abc",
+			expectedViolations: []enum.PasswordViolationEnum{
+				enum.PasswordViolationTooShort,
+				enum.PasswordViolationMissingUppercase,
+				enum.PasswordViolationMissingDigit,
+				enum.PasswordViolationMissingSymbol,
+			},
+		},
+		{
+			name:               "maximum length is capped by bcrypt",
+			password:           "Aa1-" + strings.Repeat("a", maxPasswordBytes),
+			expectedViolations: []enum.PasswordViolationEnum{enum.PasswordViolationTooLong},
+		},
+		{
+			name:               "password is the username",
+			password:           "JOHN.doe-1",
+			expectedViolations: []enum.PasswordViolationEnum{enum.PasswordViolationUserInfo},
+		},
+		{
+			name:               "password is the full name",
+			password:           "John Doe-1",
+			expectedViolations: []enum.PasswordViolationEnum{enum.PasswordViolationUserInfo},
+		},
+		{
+			name:               "password is breached",
+			password:           "Qwerty-123",
+			expectedViolations: []enum.PasswordViolationEnum{enum.PasswordViolationBreached},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := policy.Validate(tc.password, "john.doe-1", "John Doe-1")
+
+			if len(tc.expectedViolations) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrWeakPassword)
+
+			var policyErr *PasswordPolicyError
+			require.ErrorAs(t, err, &policyErr)
+
+			reasons := make([]enum.PasswordViolationEnum, len(policyErr.Violations))
+			for i, violation := range policyErr.Violations {
+				reasons[i] = violation.Reason
+			}
+			assert.Equal(t, tc.expectedViolations, reasons)
+		})
+	}
+}
+
+// breachedPasswords is the list of the breached passwords for tests.
+type breachedPasswords []string
+
+func (b breachedPasswords) Contains(password string) bool {
+	return slices.Contains(b, password)
+}
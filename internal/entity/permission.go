@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// Permission is the permission entity managed by the administration API.
+// The inactive permission is not granted to the users even if it is attached to their roles.
+type Permission struct {
+	ID          int64
+	Code        string
+	Description string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewPermission returns a new active permission entity.
+func NewPermission(code, description string, setters ...PermissionOption) Permission {
+	permission := Permission{
+		Code:        code,
+		Description: description,
+		Active:      true,
+	}
+
+	for _, setter := range setters {
+		setter(&permission)
+	}
+
+	return permission
+}
+
+// Update updates the description of the permission.
+func (p *Permission) Update(description string) {
+	p.Description = description
+
+	p.SetToUpdate()
+}
+
+// Activate grants the permission to the users of the roles it is attached to.
+func (p *Permission) Activate() {
+	p.Active = true
+
+	p.SetToUpdate()
+}
+
+// Deactivate stops granting the permission to the users of the roles it is attached to.
+func (p *Permission) Deactivate() {
+	p.Active = false
+
+	p.SetToUpdate()
+}
+
+func (p *Permission) SetToCreate() {
+	p.dataStatus = enum.ToCreate
+}
+
+func (p *Permission) SetToUpdate() {
+	p.dataStatus = enum.ToUpdate
+}
+
+func (p *Permission) SetToRemove() {
+	p.dataStatus = enum.ToRemove
+}
+
+func (p *Permission) IsToCreate() bool {
+	return p.dataStatus == enum.ToCreate
+}
+
+func (p *Permission) IsToUpdate() bool {
+	return p.dataStatus == enum.ToUpdate
+}
+
+func (p *Permission) IsToRemove() bool {
+	return p.dataStatus == enum.ToRemove
+}
+
+func (p *Permission) ResetDataStatus() {
+	p.dataStatus = enum.None
+}
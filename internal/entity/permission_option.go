@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/p1xray/pxr-sso/internal/dto"
+
+// PermissionOption is how options for the Permission are set up.
+type PermissionOption func(*Permission)
+
+// WithPermissionDetails is an option which sets up the saved permission data for the permission entity.
+func WithPermissionDetails(permission dto.PermissionDetails) PermissionOption {
+	return func(p *Permission) {
+		p.ID = permission.ID
+		p.Active = permission.Active
+		p.CreatedAt = permission.CreatedAt
+		p.UpdatedAt = permission.UpdatedAt
+	}
+}
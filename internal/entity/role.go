@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"slices"
+	"time"
+)
+
+// Role is the role entity managed by the administration API.
+// The permissions attached to the role are saved together with the role.
+// The inactive role grants neither itself nor its permissions to the users.
+type Role struct {
+	ID          int64
+	Code        string
+	Name        string
+	Description string
+	Active      bool
+	Permissions []RolePermission
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	dataStatus enum.DataStatusEnum
+}
+
+// RolePermission is the permission attached to the role.
+type RolePermission struct {
+	ID     int64
+	Code   string
+	Active bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewRole returns a new active role entity.
+func NewRole(code, name, description string, setters ...RoleOption) Role {
+	role := Role{
+		Code:        code,
+		Name:        name,
+		Description: description,
+		Active:      true,
+	}
+
+	for _, setter := range setters {
+		setter(&role)
+	}
+
+	return role
+}
+
+// Update updates the name and the description of the role.
+func (r *Role) Update(name, description string) {
+	r.Name = name
+	r.Description = description
+
+	r.SetToUpdate()
+}
+
+// Activate grants the role and its permissions to the users of the role.
+func (r *Role) Activate() {
+	r.Active = true
+
+	r.SetToUpdate()
+}
+
+// Deactivate stops granting the role and its permissions to the users of the role.
+func (r *Role) Deactivate() {
+	r.Active = false
+
+	r.SetToUpdate()
+}
+
+// AttachPermission attaches the permission to the role.
+func (r *Role) AttachPermission(permission dto.PermissionDetails) error {
+	for i, rolePermission := range r.Permissions {
+		if rolePermission.ID != permission.ID {
+			continue
+		}
+
+		// The permission which is detached but not saved yet is just kept.
+		if rolePermission.IsToRemove() {
+			r.Permissions[i].ResetDataStatus()
+
+			return nil
+		}
+
+		return ErrRolePermissionExists
+	}
+
+	rolePermission := RolePermission{
+		ID:     permission.ID,
+		Code:   permission.Code,
+		Active: permission.Active,
+	}
+	rolePermission.SetToCreate()
+
+	r.Permissions = append(r.Permissions, rolePermission)
+
+	return nil
+}
+
+// DetachPermission detaches the permission from the role.
+func (r *Role) DetachPermission(permissionID int64) error {
+	for i, rolePermission := range r.Permissions {
+		if rolePermission.ID != permissionID || rolePermission.IsToRemove() {
+			continue
+		}
+
+		// The permission which is not saved yet is just forgotten.
+		if rolePermission.IsToCreate() {
+			r.Permissions = slices.Delete(r.Permissions, i, i+1)
+
+			return nil
+		}
+
+		r.Permissions[i].SetToRemove()
+
+		return nil
+	}
+
+	return ErrRolePermissionNotFound
+}
+
+// PermissionCodes returns the codes of the permissions attached to the role.
+func (r *Role) PermissionCodes() []string {
+	codes := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		if !permission.IsToRemove() {
+			codes = append(codes, permission.Code)
+		}
+	}
+
+	return codes
+}
+
+func (r *Role) SetToCreate() {
+	r.dataStatus = enum.ToCreate
+}
+
+func (r *Role) SetToUpdate() {
+	r.dataStatus = enum.ToUpdate
+}
+
+func (r *Role) SetToRemove() {
+	r.dataStatus = enum.ToRemove
+}
+
+func (r *Role) IsToCreate() bool {
+	return r.dataStatus == enum.ToCreate
+}
+
+func (r *Role) IsToUpdate() bool {
+	return r.dataStatus == enum.ToUpdate
+}
+
+func (r *Role) IsToRemove() bool {
+	return r.dataStatus == enum.ToRemove
+}
+
+func (r *Role) ResetDataStatus() {
+	r.dataStatus = enum.None
+}
+
+func (p *RolePermission) SetToCreate() {
+	p.dataStatus = enum.ToCreate
+}
+
+func (p *RolePermission) SetToRemove() {
+	p.dataStatus = enum.ToRemove
+}
+
+func (p *RolePermission) IsToCreate() bool {
+	return p.dataStatus == enum.ToCreate
+}
+
+func (p *RolePermission) IsToRemove() bool {
+	return p.dataStatus == enum.ToRemove
+}
+
+func (p *RolePermission) ResetDataStatus() {
+	p.dataStatus = enum.None
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "github.com/p1xray/pxr-sso/internal/dto"
+
+// RoleOption is how options for the Role are set up.
+type RoleOption func(*Role)
+
+// WithRolePermissions is an option which sets up the saved permissions attached to the role entity.
+func WithRolePermissions(permissions []dto.PermissionDetails) RoleOption {
+	return func(r *Role) {
+		r.Permissions = make([]RolePermission, len(permissions))
+		for i, permission := range permissions {
+			r.Permissions[i] = RolePermission{
+				ID:     permission.ID,
+				Code:   permission.Code,
+				Active: permission.Active,
+			}
+		}
+	}
+}
+
+// WithRoleDetails is an option which sets up the saved role data for the role entity.
+func WithRoleDetails(role dto.RoleDetails) RoleOption {
+	return func(r *Role) {
+		r.ID = role.ID
+		r.Active = role.Active
+		r.CreatedAt = role.CreatedAt
+		r.UpdatedAt = role.UpdatedAt
+
+		WithRolePermissions(role.Permissions)(r)
+	}
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Role_AttachPermission(t *testing.T) {
+	savedPermission := dto.PermissionDetails{ID: 1, Code: "users:read", Active: true}
+	newPermission := dto.PermissionDetails{ID: 2, Code: "users:write", Active: true}
+
+	testCases := []struct {
+		name                string
+		detachFirst         bool
+		permission          dto.PermissionDetails
+		expectedPermissions []string
+		expectedError       error
+	}{
+		{
+			name:                "attaches the permission",
+			permission:          newPermission,
+			expectedPermissions: []string{"users:read", "users:write"},
+		},
+		{
+			name:                "throws an error when the permission is already attached",
+			permission:          savedPermission,
+			expectedPermissions: []string{"users:read"},
+			expectedError:       ErrRolePermissionExists,
+		},
+		{
+			name:                "keeps the detached permission which is not saved yet",
+			detachFirst:         true,
+			permission:          savedPermission,
+			expectedPermissions: []string{"users:read"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			role := NewRole("admin", "Administrator", "", WithRolePermissions([]dto.PermissionDetails{savedPermission}))
+
+			if tc.detachFirst {
+				assert.NoError(t, role.DetachPermission(savedPermission.ID))
+			}
+
+			err := role.AttachPermission(tc.permission)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedPermissions, role.PermissionCodes())
+			for _, permission := range role.Permissions {
+				assert.False(t, permission.IsToRemove())
+			}
+		})
+	}
+}
+
+func Test_Role_DetachPermission(t *testing.T) {
+	savedPermission := dto.PermissionDetails{ID: 1, Code: "users:read", Active: true}
+	newPermission := dto.PermissionDetails{ID: 2, Code: "users:write", Active: true}
+
+	testCases := []struct {
+		name                string
+		permissionID        int64
+		expectedPermissions []string
+		expectedLinks       int
+		expectedError       error
+	}{
+		{
+			name:                "marks the saved permission to remove",
+			permissionID:        savedPermission.ID,
+			expectedPermissions: []string{"users:write"},
+			expectedLinks:       2,
+		},
+		{
+			name:                "forgets the permission which is not saved yet",
+			permissionID:        newPermission.ID,
+			expectedPermissions: []string{"users:read"},
+			expectedLinks:       1,
+		},
+		{
+			name:                "throws an error when the permission is not attached",
+			permissionID:        100,
+			expectedPermissions: []string{"users:read", "users:write"},
+			expectedLinks:       2,
+			expectedError:       ErrRolePermissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			role := NewRole("admin", "Administrator", "", WithRolePermissions([]dto.PermissionDetails{savedPermission}))
+			assert.NoError(t, role.AttachPermission(newPermission))
+
+			err := role.DetachPermission(tc.permissionID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedPermissions, role.PermissionCodes())
+			assert.Len(t, role.Permissions, tc.expectedLinks)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Types of the security events.
+const (
+	// SecurityEventRefreshTokenReused is the event of presenting the refresh token which has already been rotated.
+	// The token family of the refresh token is revoked.
+	SecurityEventRefreshTokenReused = "refresh_token_reused"
+)
+
+// SecurityEvent is the event which is important for the security of the users, such as a possible token theft.
+type SecurityEvent struct {
+	Type       string
+	UserID     int64
+	ClientCode string
+	Details    map[string]string
+	OccurredAt time.Time
+}
+
+// NewSecurityEvent returns a new security event of the type for the user.
+func NewSecurityEvent(eventType string, userID int64, clientCode string, details map[string]string) SecurityEvent {
+	return SecurityEvent{
+		Type:       eventType,
+		UserID:     userID,
+		ClientCode: clientCode,
+		Details:    details,
+		OccurredAt: time.Now(),
+	}
+}
@@ -7,13 +7,19 @@ import (
 )
 
 // Session is the user session entity.
+// Sessions created from each other by refreshing tokens belong to the same token family.
+// The family is identified by the refresh token ID of the first session of the family.
 type Session struct {
 	ID             int64
 	UserID         int64
+	ClientID       *int64
 	RefreshTokenID string
+	FamilyID       string
 	UserAgent      string
 	Fingerprint    string
 	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	LastUsedAt     time.Time
 
 	Tokens Tokens
 
@@ -34,6 +40,19 @@ func NewSession(userID int64, userAgent, fingerprint string, setters ...SessionO
 		}
 	}
 
+	// The session which is not created from another session starts a new token family.
+	if session.FamilyID == "" {
+		session.FamilyID = session.RefreshTokenID
+	}
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+
+	if session.LastUsedAt.IsZero() {
+		session.LastUsedAt = session.CreatedAt
+	}
+
 	return session, nil
 }
 
@@ -55,6 +74,12 @@ func (s *Session) Validate(userAgent, fingerprint string) error {
 	return nil
 }
 
+// IsIssuedTo reports whether the session is issued to the client.
+// Sessions created before the client was recorded are not issued to any client.
+func (s *Session) IsIssuedTo(clientID int64) bool {
+	return s.ClientID != nil && *s.ClientID == clientID
+}
+
 func (s *Session) SetToCreate() {
 	s.dataStatus = enum.ToCreate
 }
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+)
+
+// SessionLimit is the limit of the user sessions for one client.
+// If MaxSessions is zero or negative, the number of sessions is not limited.
+// EvictionPolicy determines what happens when the user logs in to the client which already has
+// the maximum number of sessions.
+type SessionLimit struct {
+	MaxSessions    int
+	EvictionPolicy enum.SessionEvictionPolicyEnum
+}
+
+// ForClient returns the session limit of the client. The client's own values take precedence over the limit.
+func (l SessionLimit) ForClient(client dto.Client) SessionLimit {
+	limit := l
+	if client.MaxSessions != nil {
+		limit.MaxSessions = int(*client.MaxSessions)
+	}
+
+	if client.SessionEvictionPolicy != nil && client.SessionEvictionPolicy.IsValid() {
+		limit.EvictionPolicy = *client.SessionEvictionPolicy
+	}
+
+	return limit
+}
+
+// IsUnlimited reports whether the number of sessions is not limited.
+func (l SessionLimit) IsUnlimited() bool {
+	return l.MaxSessions <= 0
+}
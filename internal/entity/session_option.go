@@ -17,6 +17,23 @@ func WithSessionID(id int64) SessionOption {
 	}
 }
 
+// WithSessionClientID is an option which sets up the ID of the client the session is issued to
+// for the user session entity. If the client ID is nil or empty, the session is not issued to any client.
+func WithSessionClientID(clientID *int64) SessionOption {
+	return func(s *Session) error {
+		if clientID == nil || *clientID == emptyID {
+			s.ClientID = nil
+
+			return nil
+		}
+
+		id := *clientID
+		s.ClientID = &id
+
+		return nil
+	}
+}
+
 // WithSessionRefreshTokenID is an option which sets up the refresh token ID for the user session entity.
 func WithSessionRefreshTokenID(refreshTokenID string) SessionOption {
 	return func(s *Session) error {
@@ -26,6 +43,15 @@ func WithSessionRefreshTokenID(refreshTokenID string) SessionOption {
 	}
 }
 
+// WithSessionFamilyID is an option which sets up the token family ID for the user session entity.
+func WithSessionFamilyID(familyID string) SessionOption {
+	return func(s *Session) error {
+		s.FamilyID = familyID
+
+		return nil
+	}
+}
+
 // WithSessionExpiresAt is an option which sets up the time of expires session for the user session entity.
 func WithSessionExpiresAt(expiresAt time.Time) SessionOption {
 	return func(s *Session) error {
@@ -35,14 +61,39 @@ func WithSessionExpiresAt(expiresAt time.Time) SessionOption {
 	}
 }
 
+// WithSessionCreatedAt is an option which sets up the time of creating the token family
+// for the user session entity.
+func WithSessionCreatedAt(createdAt time.Time) SessionOption {
+	return func(s *Session) error {
+		s.CreatedAt = createdAt
+
+		return nil
+	}
+}
+
+// WithSessionLastUsedAt is an option which sets up the time of the last use of the session
+// for the user session entity.
+func WithSessionLastUsedAt(lastUsedAt time.Time) SessionOption {
+	return func(s *Session) error {
+		s.LastUsedAt = lastUsedAt
+
+		return nil
+	}
+}
+
 // WithGeneratedTokens is an option which sets up the generated tokens for the user session entity.
+// The access token refers to the token family of the session, so the option must follow WithSessionFamilyID.
 func WithGeneratedTokens(data SessionWithGeneratedTokensParams) SessionOption {
 	return func(s *Session) error {
 		createTokensParams := CreateTokensParams{
 			UserID:          s.UserID,
+			SessionID:       s.FamilyID,
 			Permissions:     data.UserPermissions,
 			Audiences:       data.Audiences,
+			ClientCode:      data.ClientCode,
+			IDToken:         data.IDToken,
 			SecretKey:       data.ClientSecretKey,
+			SigningKey:      data.SigningKey,
 			Issuer:          data.Issuer,
 			AccessTokenTTL:  data.AccessTokenTTL,
 			RefreshTokenTTL: data.RefreshTokenTTL,
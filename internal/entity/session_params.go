@@ -1,12 +1,18 @@
 package entity
 
-import "time"
+import (
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"time"
+)
 
 // SessionWithGeneratedTokensParams is a data for option which sets up the generated tokens for the user session entity.
 type SessionWithGeneratedTokensParams struct {
 	UserPermissions []string
 	Audiences       []string
+	ClientCode      string
+	IDToken         IDTokenParams
 	ClientSecretKey string
+	SigningKey      *jwtkeys.SigningKey
 	Issuer          string
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
@@ -92,3 +92,52 @@ func Test_Session_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewSession(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	lastUsedAt := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name               string
+		setters            []SessionOption
+		expectedFamilyID   string
+		expectedCreatedAt  time.Time
+		expectedLastUsedAt time.Time
+	}{
+		{
+			name: "the session starts a new token family",
+			setters: []SessionOption{
+				WithSessionRefreshTokenID(refreshTokenID),
+				WithSessionCreatedAt(createdAt),
+			},
+			expectedFamilyID:   refreshTokenID,
+			expectedCreatedAt:  createdAt,
+			expectedLastUsedAt: createdAt,
+		},
+		{
+			name: "the session belongs to the token family",
+			setters: []SessionOption{
+				WithSessionRefreshTokenID(refreshTokenID),
+				WithSessionFamilyID("test family"),
+				WithSessionCreatedAt(createdAt),
+				WithSessionLastUsedAt(lastUsedAt),
+			},
+			expectedFamilyID:   "test family",
+			expectedCreatedAt:  createdAt,
+			expectedLastUsedAt: lastUsedAt,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			session, err := NewSession(userID, userAgent, fingerprint, tc.setters...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedFamilyID, session.FamilyID)
+			assert.Equal(t, tc.expectedCreatedAt, session.CreatedAt)
+			assert.Equal(t, tc.expectedLastUsedAt, session.LastUsedAt)
+		})
+	}
+}
@@ -2,27 +2,46 @@ package entity
 
 import (
 	"fmt"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
 	jwtcreator "github.com/p1xray/pxr-sso/pkg/jwt/creator"
 	"strconv"
 )
 
 // Tokens is the user session tokens entity.
+// IDToken is the OpenID Connect ID token, it is empty if the tokens are not issued for a client.
+// MFAToken is set instead of the session tokens if the user must pass the second authentication factor
+// to complete the login.
 type Tokens struct {
 	AccessToken    string
 	RefreshToken   string
 	RefreshTokenID string
+	IDToken        string
+	MFAToken       string
 }
 
 // NewTokens returns new user session tokens entity.
 func NewTokens(data CreateTokensParams) (Tokens, error) {
+	refreshTokenID := jwtcreator.NewRefreshTokenID()
+
+	// The access token refers to the session by its token family ID, which does not change when the refresh
+	// token is rotated. The session which starts a new token family is identified by its refresh token ID.
+	sessionID := data.SessionID
+	if sessionID == "" {
+		sessionID = refreshTokenID
+	}
+
 	// Create access token.
 	createAccessTokenData := jwtcreator.AccessTokenCreateData{
-		Subject:   strconv.FormatInt(data.UserID, 10),
-		Audiences: data.Audiences,
-		Scopes:    data.Permissions,
-		Issuer:    data.Issuer,
-		TTL:       data.AccessTokenTTL,
-		Key:       []byte(data.SecretKey),
+		Subject:    strconv.FormatInt(data.UserID, 10),
+		ClientID:   data.ClientCode,
+		SessionID:  sessionID,
+		TokenUse:   jwtclaims.TokenUseAccess,
+		Audiences:  data.Audiences,
+		Scopes:     data.Permissions,
+		Issuer:     data.Issuer,
+		TTL:        data.AccessTokenTTL,
+		Key:        []byte(data.SecretKey),
+		SigningKey: data.SigningKey,
 	}
 	accessToken, err := jwtcreator.NewAccessToken(createAccessTokenData)
 	if err != nil {
@@ -30,15 +49,66 @@ func NewTokens(data CreateTokensParams) (Tokens, error) {
 	}
 
 	// Create refresh token.
-	refreshToken, refreshTokenID, err := jwtcreator.NewRefreshToken([]byte(data.SecretKey), data.RefreshTokenTTL)
+	refreshToken, err := jwtcreator.NewRefreshTokenWithID([]byte(data.SecretKey), refreshTokenID, data.RefreshTokenTTL)
 	if err != nil {
 
 		return Tokens{}, fmt.Errorf("%w: %w", ErrCreateRefreshToken, err)
 	}
 
+	// Create ID token.
+	var idToken string
+	if data.ClientCode != "" {
+		createIDTokenData := jwtcreator.IDTokenCreateData{
+			Subject:           strconv.FormatInt(data.UserID, 10),
+			Audience:          data.ClientCode,
+			Issuer:            data.Issuer,
+			Nonce:             data.IDToken.Nonce,
+			Name:              data.IDToken.FullName,
+			PreferredUsername: data.IDToken.Username,
+			Birthdate:         data.IDToken.DateOfBirth,
+			Gender:            data.IDToken.Gender.OIDC(),
+			Email:             data.IDToken.Email,
+			EmailVerified:     data.IDToken.Email != "",
+			AuthTime:          data.IDToken.AuthTime,
+			TTL:               data.AccessTokenTTL,
+			Key:               []byte(data.SecretKey),
+			SigningKey:        data.SigningKey,
+		}
+		idToken, err = jwtcreator.NewIDToken(createIDTokenData)
+		if err != nil {
+			return Tokens{}, fmt.Errorf("%w: %w", ErrCreateIDToken, err)
+		}
+	}
+
 	return Tokens{
 		AccessToken:    accessToken,
 		RefreshToken:   refreshToken,
 		RefreshTokenID: refreshTokenID,
+		IDToken:        idToken,
+	}, nil
+}
+
+// NewClientTokens returns new client tokens entity. The subject of the access token is the client itself,
+// the token use of the access token keeps it apart from the access tokens of the users.
+// The refresh token and the ID token are not created.
+func NewClientTokens(data CreateClientTokensParams) (Tokens, error) {
+	createAccessTokenData := jwtcreator.AccessTokenCreateData{
+		Subject:    data.ClientCode,
+		ClientID:   data.ClientCode,
+		TokenUse:   jwtclaims.TokenUseClient,
+		Audiences:  data.Audiences,
+		Scopes:     data.Permissions,
+		Issuer:     data.Issuer,
+		TTL:        data.AccessTokenTTL,
+		Key:        []byte(data.SecretKey),
+		SigningKey: data.SigningKey,
+	}
+	accessToken, err := jwtcreator.NewAccessToken(createAccessTokenData)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("%w: %w", ErrCreateAccessToken, err)
+	}
+
+	return Tokens{
+		AccessToken: accessToken,
 	}, nil
 }
@@ -1,14 +1,48 @@
 package entity
 
-import "time"
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"time"
+)
 
 // CreateTokensParams is a data for creating new user session tokens.
+// If SigningKey is set, the access token is signed with it, otherwise the client secret key is used.
+// If ClientCode is set, the ID token for the client is created.
 type CreateTokensParams struct {
 	UserID          int64
+	SessionID       string
 	Permissions     []string
 	Audiences       []string
+	ClientCode      string
+	IDToken         IDTokenParams
 	SecretKey       string
+	SigningKey      *jwtkeys.SigningKey
 	Issuer          string
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
 }
+
+// IDTokenParams is a data of the authenticated user for creating OpenID Connect ID token.
+// Email is the verified email of the user, it is empty if the user has no verified email.
+type IDTokenParams struct {
+	Username    string
+	FullName    string
+	DateOfBirth *time.Time
+	Gender      *enum.GenderEnum
+	Email       string
+	AuthTime    time.Time
+	Nonce       string
+}
+
+// CreateClientTokensParams is a data for creating new client tokens by the client credentials grant.
+// If SigningKey is set, the access token is signed with it, otherwise the client secret key is used.
+type CreateClientTokensParams struct {
+	ClientCode     string
+	Permissions    []string
+	Audiences      []string
+	SecretKey      string
+	SigningKey     *jwtkeys.SigningKey
+	Issuer         string
+	AccessTokenTTL time.Duration
+}
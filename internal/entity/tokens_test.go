@@ -4,13 +4,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func Test_NewTokens(t *testing.T) {
 	testCases := []struct {
-		name          string
-		data          CreateTokensParams
-		expectedError error
+		name            string
+		data            CreateTokensParams
+		expectedIDToken bool
+		expectedError   error
 	}{
 		{
 			name: "successfully create tokens",
@@ -22,6 +24,24 @@ func Test_NewTokens(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "successfully create tokens with ID token",
+			data: CreateTokensParams{
+				UserID:     userID,
+				ClientCode: clientCode,
+				IDToken: IDTokenParams{
+					Username: "test",
+					FullName: "test user",
+					AuthTime: time.Now(),
+					Nonce:    "test nonce",
+				},
+				SecretKey:       secretKey,
+				AccessTokenTTL:  accessTokenTTL,
+				RefreshTokenTTL: refreshTokenTTL,
+			},
+			expectedIDToken: true,
+			expectedError:   nil,
+		},
 		{
 			name: "throws an error when secret key is empty",
 			data: CreateTokensParams{
@@ -58,6 +78,7 @@ func Test_NewTokens(t *testing.T) {
 				assert.NotEmpty(t, tokens.AccessToken)
 				assert.NotEmpty(t, tokens.RefreshToken)
 				assert.NotEmpty(t, tokens.RefreshTokenID)
+				assert.Equal(t, tc.expectedIDToken, tokens.IDToken != "")
 			}
 		})
 	}
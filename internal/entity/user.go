@@ -1,22 +1,31 @@
 package entity
 
 import (
+	"fmt"
 	"github.com/p1xray/pxr-sso/internal/dto"
 	"github.com/p1xray/pxr-sso/internal/enum"
+	"strings"
 	"time"
 )
 
 // User is the user entity.
 type User struct {
-	ID            int64
-	Username      string
-	PasswordHash  string
-	FullName      string
-	DateOfBirth   *time.Time
-	Gender        *enum.GenderEnum
-	AvatarFileKey *string
-	Roles         []dto.Role
-	Permissions   []string
+	ID                 int64
+	Username           string
+	PasswordHash       string
+	FullName           string
+	DateOfBirth        *time.Time
+	Gender             *enum.GenderEnum
+	AvatarFileKey      *string
+	Email              *string
+	EmailVerified      bool
+	Phone              *string
+	PhoneVerified      bool
+	Blocked            bool
+	MustChangePassword bool
+	Deleted            bool
+	Roles              []dto.Role
+	Permissions        []string
 
 	dataStatus enum.DataStatusEnum
 }
@@ -45,6 +54,133 @@ func NewUser(
 	return user
 }
 
+// UpdateProfile updates the profile fields of the user listed in the params, the other fields are left alone.
+// The full name can't be cleared, the date of birth can't be in the future.
+// The changed email or phone must be verified again.
+func (u *User) UpdateProfile(params UpdateProfileParams) error {
+	const op = "entity.User.UpdateProfile"
+
+	if u.Deleted {
+		return ErrUserDeleted
+	}
+
+	// Validate all the listed fields before any of them is changed.
+	for _, field := range params.Fields {
+		switch field {
+		case enum.ProfileFieldFullName:
+			if strings.TrimSpace(params.FullName) == "" {
+				return fmt.Errorf("%s: %w: full name is empty", op, ErrInvalidProfile)
+			}
+		case enum.ProfileFieldDateOfBirth:
+			if params.DateOfBirth != nil && params.DateOfBirth.After(time.Now()) {
+				return fmt.Errorf("%s: %w: date of birth is in the future", op, ErrInvalidProfile)
+			}
+		case enum.ProfileFieldGender:
+			if params.Gender != nil && *params.Gender != enum.MALE && *params.Gender != enum.FEMALE {
+				return fmt.Errorf("%s: %w: unknown gender", op, ErrInvalidProfile)
+			}
+		case enum.ProfileFieldAvatarFileKey:
+		case enum.ProfileFieldEmail:
+			if params.Email != nil && !isValidEmail(normalizeEmail(*params.Email)) {
+				return fmt.Errorf("%s: %w: invalid email", op, ErrInvalidProfile)
+			}
+		case enum.ProfileFieldPhone:
+			if params.Phone != nil && !isValidPhone(*params.Phone) {
+				return fmt.Errorf("%s: %w: invalid phone", op, ErrInvalidProfile)
+			}
+		default:
+			return fmt.Errorf("%s: %w: unknown field %q", op, ErrInvalidProfile, field)
+		}
+	}
+
+	for _, field := range params.Fields {
+		switch field {
+		case enum.ProfileFieldFullName:
+			u.FullName = strings.TrimSpace(params.FullName)
+		case enum.ProfileFieldDateOfBirth:
+			u.DateOfBirth = params.DateOfBirth
+		case enum.ProfileFieldGender:
+			u.Gender = params.Gender
+		case enum.ProfileFieldAvatarFileKey:
+			u.AvatarFileKey = params.AvatarFileKey
+		case enum.ProfileFieldEmail:
+			u.setEmail(params.Email)
+		case enum.ProfileFieldPhone:
+			u.setPhone(params.Phone)
+		}
+	}
+
+	u.SetToUpdate()
+
+	return nil
+}
+
+// VerifiedContact returns the verified contact the messages to the user are delivered to.
+// The verified email is preferred to the verified phone. ErrNoVerifiedContact is returned if the user
+// has no verified contacts.
+func (u *User) VerifiedContact() (enum.ContactChannelEnum, string, error) {
+	switch {
+	case u.Email != nil && u.EmailVerified:
+		return enum.ContactChannelEmail, *u.Email, nil
+	case u.Phone != nil && u.PhoneVerified:
+		return enum.ContactChannelPhone, *u.Phone, nil
+	default:
+		return "", "", ErrNoVerifiedContact
+	}
+}
+
+// Contact returns the contact of the user for the channel and whether the contact is verified.
+// The empty string is returned if the contact is not set.
+func (u *User) Contact(channel enum.ContactChannelEnum) (string, bool) {
+	var contact *string
+	var verified bool
+	switch channel {
+	case enum.ContactChannelEmail:
+		contact, verified = u.Email, u.EmailVerified
+	case enum.ContactChannelPhone:
+		contact, verified = u.Phone, u.PhoneVerified
+	}
+
+	if contact == nil {
+		return "", false
+	}
+
+	return *contact, verified
+}
+
+// setEmail sets the email of the user. The changed email is no longer verified.
+func (u *User) setEmail(email *string) {
+	if email != nil {
+		normalized := normalizeEmail(*email)
+		email = &normalized
+	}
+
+	if !equalContacts(u.Email, email) {
+		u.EmailVerified = false
+	}
+
+	u.Email = email
+}
+
+// setPhone sets the phone of the user. The changed phone is no longer verified.
+func (u *User) setPhone(phone *string) {
+	if !equalContacts(u.Phone, phone) {
+		u.PhoneVerified = false
+	}
+
+	u.Phone = phone
+}
+
+// markContactVerified marks the contact of the channel as verified.
+func (u *User) markContactVerified(channel enum.ContactChannelEnum) {
+	switch channel {
+	case enum.ContactChannelEmail:
+		u.EmailVerified = true
+	case enum.ContactChannelPhone:
+		u.PhoneVerified = true
+	}
+}
+
 func (u *User) SetToCreate() {
 	u.dataStatus = enum.ToCreate
 }
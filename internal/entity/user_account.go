@@ -0,0 +1,383 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"slices"
+	"time"
+)
+
+// UserAccount is the user account entity managed by the administration API.
+// The roles granted to the user, the clients the user has access to and the user sessions
+// are saved together with the account.
+// The blocked or deleted user can't sign in, blocking or deleting the user revokes all the user sessions.
+// The password hash is set only when the password is reset.
+// The login attempts are the failed logins of the username, which lock the account temporarily.
+type UserAccount struct {
+	ID                 int64
+	Username           string
+	FullName           string
+	PasswordHash       string
+	Email              *string
+	EmailVerified      bool
+	Phone              *string
+	PhoneVerified      bool
+	Blocked            bool
+	MustChangePassword bool
+	Deleted            bool
+	Roles              []UserRole
+	Clients            []UserClient
+	Sessions           []Session
+	LoginAttempts      []LoginAttempt
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	passwordChanged bool
+	passwordHasher  PasswordHasher
+	dataStatus      enum.DataStatusEnum
+}
+
+// UserRole is the role granted to the user.
+type UserRole struct {
+	ID   int64
+	Code string
+
+	dataStatus enum.DataStatusEnum
+}
+
+// UserClient is the client the user has access to.
+type UserClient struct {
+	ID   int64
+	Code string
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewUserAccount returns a new user account entity.
+func NewUserAccount(username, fullName string, setters ...UserAccountOption) UserAccount {
+	account := UserAccount{
+		Username:       username,
+		FullName:       fullName,
+		passwordHasher: defaultPasswordHasher,
+	}
+
+	for _, setter := range setters {
+		setter(&account)
+	}
+
+	return account
+}
+
+// Block blocks the user and revokes all the user sessions.
+func (a *UserAccount) Block() error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	a.Blocked = true
+	a.revokeSessions(func(Session) bool { return true })
+
+	a.SetToUpdate()
+
+	return nil
+}
+
+// Unblock allows the blocked user to sign in again.
+func (a *UserAccount) Unblock() error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	a.Blocked = false
+
+	a.SetToUpdate()
+
+	return nil
+}
+
+// Unlock unlocks the account locked after too many failed login attempts, the failures of the username
+// are forgotten. The lockout of the client IP addresses is kept.
+func (a *UserAccount) Unlock() error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	for i := range a.LoginAttempts {
+		a.LoginAttempts[i].SetToRemove()
+	}
+
+	return nil
+}
+
+// Remove soft-deletes the user and revokes all the user sessions.
+func (a *UserAccount) Remove() error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	a.Deleted = true
+	a.revokeSessions(func(Session) bool { return true })
+
+	a.SetToRemove()
+
+	return nil
+}
+
+// Restore restores the deleted user. The roles and the clients of the user are kept when the user is deleted,
+// so the restored user gets them back.
+func (a *UserAccount) Restore() {
+	a.Deleted = false
+
+	a.SetToUpdate()
+}
+
+// ResetPassword sets the temporary password of the user and revokes all the user sessions.
+// If the temporary password is empty, a random one is generated. The temporary password is returned,
+// the user must change it on the next login.
+func (a *UserAccount) ResetPassword(temporaryPassword string, policy PasswordPolicy) (string, error) {
+	if a.Deleted {
+		return "", ErrUserDeleted
+	}
+
+	if temporaryPassword == "" {
+		generatedPassword, err := generateTemporaryPassword()
+		if err != nil {
+			return "", err
+		}
+
+		temporaryPassword = generatedPassword
+	} else if err := policy.Validate(temporaryPassword, a.Username, a.FullName); err != nil {
+		return "", err
+	}
+
+	passwordHash, err := hashPassword(a.passwordHasher, temporaryPassword)
+	if err != nil {
+		return "", err
+	}
+
+	a.PasswordHash = passwordHash
+	a.MustChangePassword = true
+	a.passwordChanged = true
+	a.revokeSessions(func(Session) bool { return true })
+
+	a.SetToUpdate()
+
+	return temporaryPassword, nil
+}
+
+// PasswordChanged reports whether the password of the user is reset and must be saved.
+func (a *UserAccount) PasswordChanged() bool {
+	return a.passwordChanged
+}
+
+// GrantRole grants the role to the user.
+func (a *UserAccount) GrantRole(role dto.Role) error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	for i, userRole := range a.Roles {
+		if userRole.ID != role.ID {
+			continue
+		}
+
+		// The role which is revoked but not saved yet is just kept.
+		if userRole.IsToRemove() {
+			a.Roles[i].ResetDataStatus()
+
+			return nil
+		}
+
+		return ErrUserRoleExists
+	}
+
+	userRole := UserRole{
+		ID:   role.ID,
+		Code: role.Code,
+	}
+	userRole.SetToCreate()
+
+	a.Roles = append(a.Roles, userRole)
+
+	return nil
+}
+
+// RevokeRole revokes the role from the user.
+func (a *UserAccount) RevokeRole(roleID int64) error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	for i, userRole := range a.Roles {
+		if userRole.ID != roleID || userRole.IsToRemove() {
+			continue
+		}
+
+		// The role which is not saved yet is just forgotten.
+		if userRole.IsToCreate() {
+			a.Roles = slices.Delete(a.Roles, i, i+1)
+
+			return nil
+		}
+
+		a.Roles[i].SetToRemove()
+
+		return nil
+	}
+
+	return ErrUserRoleNotFound
+}
+
+// GrantClient gives the user access to the client.
+func (a *UserAccount) GrantClient(client dto.Client) error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	for i, userClient := range a.Clients {
+		if userClient.ID != client.ID {
+			continue
+		}
+
+		// The client which is revoked but not saved yet is just kept.
+		if userClient.IsToRemove() {
+			a.Clients[i].ResetDataStatus()
+
+			return nil
+		}
+
+		return ErrUserClientExists
+	}
+
+	userClient := UserClient{
+		ID:   client.ID,
+		Code: client.Code,
+	}
+	userClient.SetToCreate()
+
+	a.Clients = append(a.Clients, userClient)
+
+	return nil
+}
+
+// RevokeClient takes away the user access to the client and revokes the user sessions issued to the client.
+func (a *UserAccount) RevokeClient(clientID int64) error {
+	if a.Deleted {
+		return ErrUserDeleted
+	}
+
+	for i, userClient := range a.Clients {
+		if userClient.ID != clientID || userClient.IsToRemove() {
+			continue
+		}
+
+		a.revokeSessions(func(session Session) bool { return session.IsIssuedTo(clientID) })
+
+		// The client which is not saved yet is just forgotten.
+		if userClient.IsToCreate() {
+			a.Clients = slices.Delete(a.Clients, i, i+1)
+
+			return nil
+		}
+
+		a.Clients[i].SetToRemove()
+
+		return nil
+	}
+
+	return ErrUserClientNotFound
+}
+
+// RoleCodes returns the codes of the roles granted to the user.
+func (a *UserAccount) RoleCodes() []string {
+	codes := make([]string, 0, len(a.Roles))
+	for _, role := range a.Roles {
+		if !role.IsToRemove() {
+			codes = append(codes, role.Code)
+		}
+	}
+
+	return codes
+}
+
+// ClientCodes returns the codes of the clients the user has access to.
+func (a *UserAccount) ClientCodes() []string {
+	codes := make([]string, 0, len(a.Clients))
+	for _, client := range a.Clients {
+		if !client.IsToRemove() {
+			codes = append(codes, client.Code)
+		}
+	}
+
+	return codes
+}
+
+// revokeSessions sets the user sessions which match the condition to remove.
+func (a *UserAccount) revokeSessions(match func(session Session) bool) {
+	for i := range a.Sessions {
+		if match(a.Sessions[i]) {
+			a.Sessions[i].SetToRemove()
+		}
+	}
+}
+
+func (a *UserAccount) SetToUpdate() {
+	a.dataStatus = enum.ToUpdate
+}
+
+func (a *UserAccount) SetToRemove() {
+	a.dataStatus = enum.ToRemove
+}
+
+func (a *UserAccount) IsToUpdate() bool {
+	return a.dataStatus == enum.ToUpdate
+}
+
+func (a *UserAccount) IsToRemove() bool {
+	return a.dataStatus == enum.ToRemove
+}
+
+func (a *UserAccount) ResetDataStatus() {
+	a.dataStatus = enum.None
+	a.passwordChanged = false
+}
+
+func (r *UserRole) SetToCreate() {
+	r.dataStatus = enum.ToCreate
+}
+
+func (r *UserRole) SetToRemove() {
+	r.dataStatus = enum.ToRemove
+}
+
+func (r *UserRole) IsToCreate() bool {
+	return r.dataStatus == enum.ToCreate
+}
+
+func (r *UserRole) IsToRemove() bool {
+	return r.dataStatus == enum.ToRemove
+}
+
+func (r *UserRole) ResetDataStatus() {
+	r.dataStatus = enum.None
+}
+
+func (c *UserClient) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *UserClient) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *UserClient) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *UserClient) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *UserClient) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
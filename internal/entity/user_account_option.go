@@ -0,0 +1,85 @@
+package entity
+
+import "github.com/p1xray/pxr-sso/internal/dto"
+
+// UserAccountOption is how options for the UserAccount are set up.
+type UserAccountOption func(*UserAccount)
+
+// WithUserAccountDetails is an option which sets up the saved user data, the user roles and clients
+// for the user account entity.
+func WithUserAccountDetails(user dto.UserDetails) UserAccountOption {
+	return func(a *UserAccount) {
+		a.ID = user.ID
+		a.Email = user.Email
+		a.EmailVerified = user.EmailVerified
+		a.Phone = user.Phone
+		a.PhoneVerified = user.PhoneVerified
+		a.Blocked = user.Blocked
+		a.MustChangePassword = user.MustChangePassword
+		a.Deleted = user.Deleted
+		a.CreatedAt = user.CreatedAt
+		a.UpdatedAt = user.UpdatedAt
+
+		a.Roles = make([]UserRole, len(user.Roles))
+		for i, role := range user.Roles {
+			a.Roles[i] = UserRole{
+				ID:   role.ID,
+				Code: role.Code,
+			}
+		}
+
+		a.Clients = make([]UserClient, len(user.Clients))
+		for i, client := range user.Clients {
+			a.Clients[i] = UserClient{
+				ID:   client.ID,
+				Code: client.Code,
+			}
+		}
+	}
+}
+
+// WithUserAccountSessions is an option which sets up the saved user sessions for the user account entity.
+func WithUserAccountSessions(sessions []dto.Session) UserAccountOption {
+	return func(a *UserAccount) {
+		a.Sessions = make([]Session, len(sessions))
+		for i, session := range sessions {
+			a.Sessions[i] = Session{
+				ID:             session.ID,
+				UserID:         session.UserID,
+				ClientID:       session.ClientID,
+				RefreshTokenID: session.RefreshTokenID,
+				FamilyID:       session.FamilyID,
+				UserAgent:      session.UserAgent,
+				Fingerprint:    session.Fingerprint,
+				ExpiresAt:      session.ExpiresAt,
+				CreatedAt:      session.CreatedAt,
+				LastUsedAt:     session.LastUsedAt,
+			}
+		}
+	}
+}
+
+// WithUserAccountLoginAttempts is an option which sets up the saved failed login attempts of the username
+// for the user account entity.
+func WithUserAccountLoginAttempts(attempts []dto.LoginAttempt) UserAccountOption {
+	return func(a *UserAccount) {
+		a.LoginAttempts = make([]LoginAttempt, len(attempts))
+		for i, attempt := range attempts {
+			a.LoginAttempts[i] = LoginAttempt{
+				ID:            attempt.ID,
+				Kind:          attempt.Kind,
+				Key:           attempt.Key,
+				Failures:      attempt.Failures,
+				LastFailureAt: attempt.LastFailureAt,
+				LockedUntil:   attempt.LockedUntil,
+			}
+		}
+	}
+}
+
+// WithUserAccountPasswordHasher is an option which sets up the password hasher for the user account entity.
+func WithUserAccountPasswordHasher(hasher PasswordHasher) UserAccountOption {
+	return func(a *UserAccount) {
+		a.passwordHasher = hasher
+	}
+}
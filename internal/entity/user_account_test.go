@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func Test_UserAccount_Block(t *testing.T) {
+	testCases := []struct {
+		name          string
+		deleted       bool
+		expectedError error
+	}{
+		{
+			name: "blocks the user and revokes all the sessions",
+		},
+		{
+			name:          "throws an error when the user is deleted",
+			deleted:       true,
+			expectedError: ErrUserDeleted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			account := newTestUserAccount(tc.deleted)
+
+			err := account.Block()
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.False(t, account.Blocked)
+				assert.False(t, account.IsToUpdate())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, account.Blocked)
+			assert.True(t, account.IsToUpdate())
+			for _, session := range account.Sessions {
+				assert.True(t, session.IsToRemove())
+			}
+		})
+	}
+}
+
+func Test_UserAccount_Remove_Restore(t *testing.T) {
+	account := newTestUserAccount(false)
+
+	assert.NoError(t, account.Remove())
+	assert.True(t, account.Deleted)
+	assert.True(t, account.IsToRemove())
+	for _, session := range account.Sessions {
+		assert.True(t, session.IsToRemove())
+	}
+
+	assert.ErrorIs(t, account.Remove(), ErrUserDeleted)
+	assert.ErrorIs(t, account.GrantRole(dto.Role{ID: 2, Code: "admin"}), ErrUserDeleted)
+
+	account.Restore()
+	assert.False(t, account.Deleted)
+	assert.True(t, account.IsToUpdate())
+	assert.Equal(t, []string{"user"}, account.RoleCodes())
+}
+
+func Test_UserAccount_GrantRole_RevokeRole(t *testing.T) {
+	account := newTestUserAccount(false)
+
+	assert.ErrorIs(t, account.GrantRole(dto.Role{ID: 1, Code: "user"}), ErrUserRoleExists)
+
+	assert.NoError(t, account.GrantRole(dto.Role{ID: 2, Code: "admin"}))
+	assert.Equal(t, []string{"user", "admin"}, account.RoleCodes())
+
+	// The granted role which is not saved yet is just forgotten.
+	assert.NoError(t, account.RevokeRole(2))
+	assert.Len(t, account.Roles, 1)
+
+	assert.NoError(t, account.RevokeRole(1))
+	assert.Empty(t, account.RoleCodes())
+	assert.True(t, account.Roles[0].IsToRemove())
+
+	assert.ErrorIs(t, account.RevokeRole(1), ErrUserRoleNotFound)
+
+	// The revoked role which is not saved yet is just kept.
+	assert.NoError(t, account.GrantRole(dto.Role{ID: 1, Code: "user"}))
+	assert.Equal(t, []string{"user"}, account.RoleCodes())
+	assert.False(t, account.Roles[0].IsToRemove())
+}
+
+func Test_UserAccount_RevokeClient(t *testing.T) {
+	testCases := []struct {
+		name            string
+		clientID        int64
+		expectedClients []string
+		expectedRevoked int
+		expectedError   error
+	}{
+		{
+			name:            "revokes the client and the sessions issued to it",
+			clientID:        1,
+			expectedClients: []string{"other-client"},
+			expectedRevoked: 1,
+		},
+		{
+			name:            "throws an error when the user has no access to the client",
+			clientID:        100,
+			expectedClients: []string{"test-client", "other-client"},
+			expectedError:   ErrUserClientNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			account := newTestUserAccount(false)
+
+			err := account.RevokeClient(tc.clientID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedClients, account.ClientCodes())
+
+			revoked := 0
+			for _, session := range account.Sessions {
+				if session.IsToRemove() {
+					revoked++
+				}
+			}
+			assert.Equal(t, tc.expectedRevoked, revoked)
+		})
+	}
+}
+
+func Test_UserAccount_ResetPassword(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8}
+
+	account := newTestUserAccount(false)
+
+	_, err := account.ResetPassword("short", policy)
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.False(t, account.PasswordChanged())
+
+	temporaryPassword, err := account.ResetPassword("", policy)
+	assert.NoError(t, err)
+	assert.NoError(t, policy.Validate(temporaryPassword))
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(temporaryPassword)))
+	assert.True(t, account.MustChangePassword)
+	assert.True(t, account.PasswordChanged())
+	assert.True(t, account.IsToUpdate())
+	for _, session := range account.Sessions {
+		assert.True(t, session.IsToRemove())
+	}
+
+	deleted := newTestUserAccount(true)
+	_, err = deleted.ResetPassword("temporary-password", policy)
+	assert.ErrorIs(t, err, ErrUserDeleted)
+}
+
+func newTestUserAccount(deleted bool) UserAccount {
+	clientID := int64(1)
+	otherClientID := int64(2)
+
+	return NewUserAccount(
+		"user",
+		"Test User",
+		WithUserAccountDetails(dto.UserDetails{
+			ID:      1,
+			Deleted: deleted,
+			Roles:   []dto.Role{{ID: 1, Code: "user"}},
+			Clients: []dto.Client{{ID: 1, Code: "test-client"}, {ID: 2, Code: "other-client"}},
+		}),
+		WithUserAccountSessions([]dto.Session{
+			{ID: 1, UserID: 1, ClientID: &clientID},
+			{ID: 2, UserID: 1, ClientID: &otherClientID},
+		}),
+	)
+}
@@ -19,6 +19,38 @@ func WithUserPasswordHash(passwordHash string) UserOption {
 	}
 }
 
+// WithUserStatus is an option which sets up the blocked and deleted flags for the user entity.
+func WithUserStatus(blocked, deleted bool) UserOption {
+	return func(u *User) {
+		u.Blocked = blocked
+		u.Deleted = deleted
+	}
+}
+
+// WithUserMustChangePassword is an option which sets up the flag the user must change the password
+// before signing in for the user entity.
+func WithUserMustChangePassword(mustChangePassword bool) UserOption {
+	return func(u *User) {
+		u.MustChangePassword = mustChangePassword
+	}
+}
+
+// WithUserEmail is an option which sets up the email and the flag the email is verified for the user entity.
+func WithUserEmail(email *string, verified bool) UserOption {
+	return func(u *User) {
+		u.Email = email
+		u.EmailVerified = verified
+	}
+}
+
+// WithUserPhone is an option which sets up the phone and the flag the phone is verified for the user entity.
+func WithUserPhone(phone *string, verified bool) UserOption {
+	return func(u *User) {
+		u.Phone = phone
+		u.PhoneVerified = verified
+	}
+}
+
 // WithUserRoles is an option which sets up the user roles for the user entity.
 func WithUserRoles(roles []dto.Role) UserOption {
 	return func(u *User) {
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// UpdateProfileParams is a data for updating the user profile.
+// Only the fields listed in Fields are updated, the nil value of the listed field clears it.
+type UpdateProfileParams struct {
+	Fields        []enum.ProfileFieldEnum
+	FullName      string
+	DateOfBirth   *time.Time
+	Gender        *enum.GenderEnum
+	AvatarFileKey *string
+	Email         *string
+	Phone         *string
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"math/big"
+	"time"
+)
+
+// verificationCodeDigits is the number of digits of the verification code.
+const verificationCodeDigits = 6
+
+// VerificationCode is the one-time code which confirms the user owns the contact.
+// Target is the contact the code is sent to, the code is no longer valid once the contact is changed.
+// The code itself is known only when it is issued, the storage keeps the hash of the code.
+type VerificationCode struct {
+	ID        int64
+	UserID    int64
+	Channel   enum.ContactChannelEnum
+	Target    string
+	Code      string
+	CodeHash  string
+	ExpiresAt time.Time
+	Attempts  int
+	Used      bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// HashVerificationCode returns the hash of the verification code which is kept in the storage.
+func HashVerificationCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// issue generates a new code sent to the target. The attempts of the previous code are forgotten.
+func (c *VerificationCode) issue(target string, ttl time.Duration) error {
+	limit := big.NewInt(1)
+	for range verificationCodeDigits {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	number, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateVerificationCode, err)
+	}
+
+	c.Target = target
+	c.Code = fmt.Sprintf("%0*d", verificationCodeDigits, number)
+	c.CodeHash = HashVerificationCode(c.Code)
+	c.ExpiresAt = time.Now().Add(ttl)
+	c.Attempts = 0
+	c.Used = false
+
+	return nil
+}
+
+// Verify checks the code sent to the target, and if it matches, marks the code as used.
+// Every mismatch counts as an attempt, the code can't be verified once the attempts are exceeded.
+func (c *VerificationCode) Verify(target, code string, maxAttempts int) error {
+	const op = "entity.VerificationCode.Verify"
+
+	if c.Used || c.Target != target {
+		return fmt.Errorf("%s: %w", op, ErrVerificationCodeNotFound)
+	}
+
+	if c.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("%s: %w", op, ErrVerificationCodeExpired)
+	}
+
+	if c.Attempts >= maxAttempts {
+		return fmt.Errorf("%s: %w", op, ErrVerificationAttemptsExceeded)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(HashVerificationCode(code))) != 1 {
+		c.Attempts++
+
+		return fmt.Errorf("%s: %w", op, ErrVerificationCodeMismatch)
+	}
+
+	c.Used = true
+
+	return nil
+}
+
+func (c *VerificationCode) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *VerificationCode) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *VerificationCode) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *VerificationCode) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *VerificationCode) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *VerificationCode) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *VerificationCode) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
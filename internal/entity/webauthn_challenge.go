@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"time"
+)
+
+// WebAuthnChallenge is the challenge of the WebAuthn ceremony, the response of the authenticator must be signed
+// over it. The challenge is single-use and bound to the ceremony it is issued for.
+// The registration challenge is bound to the user, the login challenge is bound to the client and keeps the nonce
+// of the login. UserID of the login challenge is 0 if the login is started without the username.
+type WebAuthnChallenge struct {
+	ID        int64
+	Challenge string
+	Ceremony  enum.WebAuthnCeremonyEnum
+	UserID    int64
+	ClientID  int64
+	Nonce     string
+	ExpiresAt time.Time
+	Used      bool
+
+	dataStatus enum.DataStatusEnum
+}
+
+// NewWebAuthnChallenge returns a new WebAuthn challenge entity with the generated challenge.
+func NewWebAuthnChallenge(data CreateWebAuthnChallengeParams) (WebAuthnChallenge, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return WebAuthnChallenge{}, fmt.Errorf("%w: %w", ErrCreateWebAuthnChallenge, err)
+	}
+
+	return WebAuthnChallenge{
+		Challenge: challenge,
+		Ceremony:  data.Ceremony,
+		UserID:    data.UserID,
+		ClientID:  data.ClientID,
+		Nonce:     data.Nonce,
+		ExpiresAt: time.Now().Add(data.TTL),
+	}, nil
+}
+
+// Validate checks that the WebAuthn challenge is issued for the ceremony and is neither used nor expired.
+func (c *WebAuthnChallenge) Validate(ceremony enum.WebAuthnCeremonyEnum) error {
+	const op = "entity.WebAuthnChallenge.Validate"
+
+	if c.Used || c.Ceremony != ceremony {
+		return fmt.Errorf("%s: %w", op, ErrWebAuthnChallengeNotFound)
+	}
+
+	if c.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("%s: %w", op, ErrWebAuthnChallengeExpired)
+	}
+
+	return nil
+}
+
+func (c *WebAuthnChallenge) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *WebAuthnChallenge) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *WebAuthnChallenge) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *WebAuthnChallenge) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *WebAuthnChallenge) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *WebAuthnChallenge) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *WebAuthnChallenge) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// CreateWebAuthnChallengeParams is a data for creating new WebAuthn challenge.
+type CreateWebAuthnChallengeParams struct {
+	Ceremony enum.WebAuthnCeremonyEnum
+	UserID   int64
+	ClientID int64
+	Nonce    string
+	TTL      time.Duration
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/enum"
+)
+
+// defaultWebAuthnCredentialName is the name of the WebAuthn credential if the user has not named it.
+const defaultWebAuthnCredentialName = "Passkey"
+
+// WebAuthnCredential is the WebAuthn credential (passkey) of the user used for the passwordless login.
+// CredentialID is the base64url encoding of the credential ID, PublicKey is the COSE key of the credential.
+// SignCount is the last signature counter reported by the authenticator, it is used to detect the cloned
+// authenticators.
+type WebAuthnCredential struct {
+	ID           int64
+	UserID       int64
+	CredentialID string
+	PublicKey    []byte
+	SignCount    uint32
+	Name         string
+
+	dataStatus enum.DataStatusEnum
+}
+
+// updateSignCount checks the signature counter of the assertion is greater than the stored one and remembers it.
+// The authenticators which do not support the counter always report zero, the counter is not checked for them.
+func (c *WebAuthnCredential) updateSignCount(signCount uint32) error {
+	const op = "entity.WebAuthnCredential.updateSignCount"
+
+	if (signCount != 0 || c.SignCount != 0) && signCount <= c.SignCount {
+		return fmt.Errorf("%s: %w", op, ErrWebAuthnSignCountMismatch)
+	}
+
+	c.SignCount = signCount
+
+	return nil
+}
+
+func (c *WebAuthnCredential) SetToCreate() {
+	c.dataStatus = enum.ToCreate
+}
+
+func (c *WebAuthnCredential) SetToUpdate() {
+	c.dataStatus = enum.ToUpdate
+}
+
+func (c *WebAuthnCredential) SetToRemove() {
+	c.dataStatus = enum.ToRemove
+}
+
+func (c *WebAuthnCredential) IsToCreate() bool {
+	return c.dataStatus == enum.ToCreate
+}
+
+func (c *WebAuthnCredential) IsToUpdate() bool {
+	return c.dataStatus == enum.ToUpdate
+}
+
+func (c *WebAuthnCredential) IsToRemove() bool {
+	return c.dataStatus == enum.ToRemove
+}
+
+func (c *WebAuthnCredential) ResetDataStatus() {
+	c.dataStatus = enum.None
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"encoding/binary"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"time"
+)
+
+// WebAuthnCreationOptions is the data the browser creates the WebAuthn credential with
+// by navigator.credentials.create(). UserHandle is the base64url encoding of the user handle the credential
+// is created for, ExcludeCredentialIDs are the credentials of the user which must not be registered again.
+type WebAuthnCreationOptions struct {
+	Challenge            string
+	RPID                 string
+	RPName               string
+	UserHandle           string
+	Username             string
+	DisplayName          string
+	ExcludeCredentialIDs []string
+	Timeout              time.Duration
+}
+
+// WebAuthnRequestOptions is the data the browser gets the assertion of the WebAuthn credential with
+// by navigator.credentials.get(). AllowCredentialIDs is empty if the login is started without the username,
+// then the user chooses any discoverable credential of the relying party.
+type WebAuthnRequestOptions struct {
+	Challenge          string
+	RPID               string
+	AllowCredentialIDs []string
+	Timeout            time.Duration
+}
+
+// webAuthnUserHandle returns the user handle of the user, the user ID is used so the handle has no personal data.
+func webAuthnUserHandle(userID int64) string {
+	return webauthn.EncodeID(binary.BigEndian.AppendUint64(nil, uint64(userID)))
+}
+
+// webAuthnCredentialIDs returns the IDs of the WebAuthn credentials.
+func webAuthnCredentialIDs(credentials []WebAuthnCredential) []string {
+	ids := make([]string, len(credentials))
+	for i, credential := range credentials {
+		ids[i] = credential.CredentialID
+	}
+
+	return ids
+}
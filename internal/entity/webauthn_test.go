@@ -0,0 +1,452 @@
+package entity
+
+import (
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"github.com/p1xray/pxr-sso/pkg/webauthn/webauthntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	rpID     = "example.com"
+	rpOrigin = "https://login.example.com"
+)
+
+var relyingParty = webauthn.RelyingParty{
+	ID:               rpID,
+	Origins:          []string{rpOrigin},
+	UserVerification: true,
+}
+
+// registeredCredential registers the credential of the authenticator and returns its data for the storage.
+func registeredCredential(t *testing.T, authenticator *webauthntest.Authenticator) dto.WebAuthnCredential {
+	t.Helper()
+
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+
+	clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+	require.NoError(t, err)
+
+	credential, err := relyingParty.VerifyRegistration(clientDataJSON, attestationObject, challenge)
+	require.NoError(t, err)
+
+	return dto.WebAuthnCredential{
+		ID:           1,
+		UserID:       userID,
+		CredentialID: webauthn.EncodeID(credential.ID),
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		Name:         "passkey",
+	}
+}
+
+func Test_Auth_BeginWebAuthnRegistration(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+
+	testCases := []struct {
+		name          string
+		user          dto.User
+		password      string
+		expectedError error
+	}{
+		{
+			name:     "successfully creates the registration challenge",
+			user:     user,
+			password: validPassword,
+		},
+		{
+			name:          "throws an error when the password is invalid",
+			user:          user,
+			password:      invalidPassword,
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name: "throws an error when the user is blocked",
+			user: dto.User{
+				ID:           userID,
+				PasswordHash: passwordHash,
+				Blocked:      true,
+			},
+			password:      validPassword,
+			expectedError: ErrUserBlocked,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(tt.user),
+				WithAuthWebAuthnCredentials(dto.WebAuthnCredential{ID: 1, UserID: userID, CredentialID: "credential-id"}),
+			)
+			require.NoError(t, err)
+
+			options, err := auth.BeginWebAuthnRegistration(BeginWebAuthnRegistrationParams{
+				Password:         tt.password,
+				RelyingParty:     relyingParty,
+				RelyingPartyName: "test",
+				ChallengeTTL:     time.Minute,
+			})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, auth.WebAuthnChallenges)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.NotEmpty(t, options.Challenge)
+			assert.Equal(t, rpID, options.RPID)
+			assert.Equal(t, "test", options.RPName)
+			assert.NotEmpty(t, options.UserHandle)
+			assert.Equal(t, []string{"credential-id"}, options.ExcludeCredentialIDs)
+
+			require.Len(t, auth.WebAuthnChallenges, 1)
+			challenge := auth.WebAuthnChallenges[0]
+			assert.Equal(t, options.Challenge, challenge.Challenge)
+			assert.Equal(t, enum.WebAuthnCeremonyRegistration, challenge.Ceremony)
+			assert.Equal(t, int64(userID), challenge.UserID)
+			assert.True(t, challenge.IsToCreate())
+		})
+	}
+}
+
+func Test_Auth_FinishWebAuthnRegistration(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	}
+
+	testCases := []struct {
+		name          string
+		ceremony      enum.WebAuthnCeremonyEnum
+		expiresAt     time.Time
+		used          bool
+		registered    bool
+		configure     func(a *webauthntest.Authenticator)
+		expectedError error
+	}{
+		{
+			name: "successfully registers the credential",
+		},
+		{
+			name:          "throws an error when the credential is already registered",
+			registered:    true,
+			expectedError: ErrWebAuthnCredentialExists,
+		},
+		{
+			name:          "throws an error when the challenge is used",
+			used:          true,
+			expectedError: ErrWebAuthnChallengeNotFound,
+		},
+		{
+			name:          "throws an error when the challenge is issued for the login",
+			ceremony:      enum.WebAuthnCeremonyLogin,
+			expectedError: ErrWebAuthnChallengeNotFound,
+		},
+		{
+			name:          "throws an error when the challenge is expired",
+			expiresAt:     time.Now().Add(-time.Minute),
+			expectedError: ErrWebAuthnChallengeExpired,
+		},
+		{
+			name: "throws an error when the user is not verified",
+			configure: func(a *webauthntest.Authenticator) {
+				a.UserVerified = false
+			},
+			expectedError: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name: "throws an error when the origin is not allowed",
+			configure: func(a *webauthntest.Authenticator) {
+				a.Origin = "https://evil.example.com"
+			},
+			expectedError: ErrInvalidWebAuthnResponse,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authenticator, err := webauthntest.New(rpID, rpOrigin)
+			require.NoError(t, err)
+
+			var credentials []dto.WebAuthnCredential
+			if tt.registered {
+				credentials = append(credentials, registeredCredential(t, authenticator))
+			}
+
+			if tt.configure != nil {
+				tt.configure(authenticator)
+			}
+
+			challenge, err := webauthn.NewChallenge()
+			require.NoError(t, err)
+
+			ceremony := enum.WebAuthnCeremonyRegistration
+			if tt.ceremony != "" {
+				ceremony = tt.ceremony
+			}
+
+			expiresAt := time.Now().Add(time.Minute)
+			if !tt.expiresAt.IsZero() {
+				expiresAt = tt.expiresAt
+			}
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(user),
+				WithAuthWebAuthnCredentials(credentials...),
+				WithAuthWebAuthnChallenge(dto.WebAuthnChallenge{
+					ID:        1,
+					Challenge: challenge,
+					Ceremony:  ceremony,
+					UserID:    userID,
+					ExpiresAt: expiresAt,
+					Used:      tt.used,
+				}),
+			)
+			require.NoError(t, err)
+
+			clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+			require.NoError(t, err)
+
+			credential, err := auth.FinishWebAuthnRegistration(FinishWebAuthnRegistrationParams{
+				RelyingParty:      relyingParty,
+				ClientDataJSON:    clientDataJSON,
+				AttestationObject: attestationObject,
+			})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Len(t, auth.WebAuthnCredentials, len(credentials))
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, authenticator.CredentialIDString(), credential.CredentialID)
+			assert.Equal(t, int64(userID), credential.UserID)
+			assert.Equal(t, defaultWebAuthnCredentialName, credential.Name)
+			assert.NotEmpty(t, credential.PublicKey)
+
+			require.Len(t, auth.WebAuthnCredentials, 1)
+			assert.True(t, auth.WebAuthnCredentials[0].IsToCreate())
+			assert.True(t, auth.WebAuthnChallenges[0].Used)
+			assert.True(t, auth.WebAuthnChallenges[0].IsToUpdate())
+		})
+	}
+}
+
+func Test_Auth_BeginWebAuthnLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		user               dto.User
+		credentials        []dto.WebAuthnCredential
+		expectedUserID     int64
+		expectedCredential []string
+	}{
+		{
+			name:               "creates the challenge for the credentials of the user",
+			user:               dto.User{ID: userID, PasswordHash: passwordHash},
+			credentials:        []dto.WebAuthnCredential{{ID: 1, UserID: userID, CredentialID: "credential-id"}},
+			expectedUserID:     userID,
+			expectedCredential: []string{"credential-id"},
+		},
+		{
+			name:               "creates the challenge for any discoverable credential without the user",
+			expectedCredential: []string{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(tt.user),
+				WithAuthWebAuthnCredentials(tt.credentials...),
+				WithAuthClient(dto.Client{ID: clientID, Code: clientCode, SecretKey: secretKey}),
+				WithAuthNonce("nonce"),
+			)
+			require.NoError(t, err)
+
+			options, err := auth.BeginWebAuthnLogin(BeginWebAuthnLoginParams{
+				RelyingParty: relyingParty,
+				ChallengeTTL: time.Minute,
+			})
+			require.NoError(t, err)
+
+			assert.NotEmpty(t, options.Challenge)
+			assert.Equal(t, rpID, options.RPID)
+			assert.Equal(t, tt.expectedCredential, options.AllowCredentialIDs)
+
+			require.Len(t, auth.WebAuthnChallenges, 1)
+			challenge := auth.WebAuthnChallenges[0]
+			assert.Equal(t, enum.WebAuthnCeremonyLogin, challenge.Ceremony)
+			assert.Equal(t, tt.expectedUserID, challenge.UserID)
+			assert.Equal(t, int64(clientID), challenge.ClientID)
+			assert.Equal(t, "nonce", challenge.Nonce)
+			assert.True(t, challenge.IsToCreate())
+		})
+	}
+}
+
+func Test_Auth_FinishWebAuthnLogin(t *testing.T) {
+	t.Parallel()
+
+	user := dto.User{
+		ID:           userID,
+		Username:     "user",
+		PasswordHash: passwordHash,
+	}
+	client := dto.Client{ID: clientID, Code: clientCode, SecretKey: secretKey}
+
+	testCases := []struct {
+		name              string
+		user              dto.User
+		challengeUserID   int64
+		storedSignCount   uint32
+		otherCredential   bool
+		used              bool
+		expectedError     error
+		expectedSignCount uint32
+	}{
+		{
+			name:              "successfully logs in by the discoverable credential",
+			user:              user,
+			expectedSignCount: 1,
+		},
+		{
+			name:              "successfully logs in by the credential of the user the challenge is issued for",
+			user:              user,
+			challengeUserID:   userID,
+			expectedSignCount: 1,
+		},
+		{
+			name:            "throws an error when the challenge is issued for another user",
+			user:            user,
+			challengeUserID: userID + 1,
+			expectedError:   ErrInvalidCredentials,
+		},
+		{
+			name:            "throws an error when the assertion is signed by another credential",
+			user:            user,
+			otherCredential: true,
+			expectedError:   ErrInvalidCredentials,
+		},
+		{
+			name:            "throws an error when the signature counter did not increase",
+			user:            user,
+			storedSignCount: 5,
+			expectedError:   ErrWebAuthnSignCountMismatch,
+		},
+		{
+			name:          "throws an error when the challenge is used",
+			user:          user,
+			used:          true,
+			expectedError: ErrWebAuthnChallengeNotFound,
+		},
+		{
+			name: "throws an error when the user is blocked",
+			user: dto.User{
+				ID:           userID,
+				PasswordHash: passwordHash,
+				Blocked:      true,
+			},
+			expectedError: ErrUserBlocked,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authenticator, err := webauthntest.New(rpID, rpOrigin)
+			require.NoError(t, err)
+
+			credential := registeredCredential(t, authenticator)
+			credential.SignCount = tt.storedSignCount
+
+			signer := authenticator
+			if tt.otherCredential {
+				signer, err = webauthntest.New(rpID, rpOrigin)
+				require.NoError(t, err)
+			}
+
+			challenge, err := webauthn.NewChallenge()
+			require.NoError(t, err)
+
+			auth, err := NewAuth(
+				accessTokenTTL,
+				refreshTokenTTL,
+				WithAuthUser(tt.user),
+				WithAuthClient(client),
+				WithAuthWebAuthnCredentials(credential),
+				WithAuthWebAuthnChallenge(dto.WebAuthnChallenge{
+					ID:        1,
+					Challenge: challenge,
+					Ceremony:  enum.WebAuthnCeremonyLogin,
+					UserID:    tt.challengeUserID,
+					ClientID:  clientID,
+					Nonce:     "nonce",
+					ExpiresAt: time.Now().Add(time.Minute),
+					Used:      tt.used,
+				}),
+			)
+			require.NoError(t, err)
+
+			clientDataJSON, authenticatorData, signature, err := signer.Get(challenge)
+			require.NoError(t, err)
+
+			tokens, err := auth.FinishWebAuthnLogin(FinishWebAuthnLoginParams{
+				RelyingParty:      relyingParty,
+				ClientDataJSON:    clientDataJSON,
+				AuthenticatorData: authenticatorData,
+				Signature:         signature,
+				UserAgent:         userAgent,
+				Fingerprint:       fingerprint,
+				Issuer:            issuer,
+			})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, auth.Sessions)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEmpty(t, tokens.RefreshToken)
+			assert.Empty(t, tokens.MFAToken)
+			require.Len(t, auth.Sessions, 1)
+			assert.True(t, auth.Sessions[0].IsToCreate())
+			assert.Equal(t, "nonce", auth.nonce)
+
+			assert.Equal(t, tt.expectedSignCount, auth.WebAuthnCredentials[0].SignCount)
+			assert.True(t, auth.WebAuthnCredentials[0].IsToUpdate())
+			assert.True(t, auth.WebAuthnChallenges[0].Used)
+			assert.True(t, auth.WebAuthnChallenges[0].IsToUpdate())
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package enum
+
+// ContactChannelEnum is type for user contact channel enum.
+// Used to determine the contact of the user the message is delivered to and the contact being verified.
+type ContactChannelEnum string
+
+// ContactChannelEnum enum.
+const (
+	ContactChannelEmail ContactChannelEnum = "email"
+	ContactChannelPhone ContactChannelEnum = "phone"
+)
+
+// IsValid reports whether the value is one of the contact channels.
+func (c ContactChannelEnum) IsValid() bool {
+	switch c {
+	case ContactChannelEmail, ContactChannelPhone:
+		return true
+	default:
+		return false
+	}
+}
@@ -11,6 +11,23 @@ const (
 	FEMALE GenderEnum = 2
 )
 
+// OIDC returns the value of the OpenID Connect "gender" claim.
+// If the gender is not set, an empty string is returned.
+func (ge *GenderEnum) OIDC() string {
+	if ge == nil {
+		return ""
+	}
+
+	switch *ge {
+	case MALE:
+		return "male"
+	case FEMALE:
+		return "female"
+	default:
+		return ""
+	}
+}
+
 // ToNullInt16 converts GenderEnum to nullable int16 type.
 func (ge *GenderEnum) ToNullInt16() null.Int16 {
 	if ge == nil {
@@ -0,0 +1,13 @@
+package enum
+
+// LoginAttemptKindEnum is type for login attempt kind enum.
+// Used to tell the failed login attempts of the username from the failed login attempts of the client IP address,
+// and from the failed MFA codes of the username sent along with the password.
+type LoginAttemptKindEnum string
+
+// LoginAttemptKindEnum enum.
+const (
+	LoginAttemptKindUsername LoginAttemptKindEnum = "username"
+	LoginAttemptKindIP       LoginAttemptKindEnum = "ip"
+	LoginAttemptKindMFA      LoginAttemptKindEnum = "mfa"
+)
@@ -0,0 +1,17 @@
+package enum
+
+// PasswordViolationEnum is type for password violation enum.
+// Used to tell the client which rule of the password policy the password violates.
+type PasswordViolationEnum string
+
+// PasswordViolationEnum enum.
+const (
+	PasswordViolationTooShort         PasswordViolationEnum = "PASSWORD_TOO_SHORT"
+	PasswordViolationTooLong          PasswordViolationEnum = "PASSWORD_TOO_LONG"
+	PasswordViolationMissingUppercase PasswordViolationEnum = "PASSWORD_MISSING_UPPERCASE"
+	PasswordViolationMissingLowercase PasswordViolationEnum = "PASSWORD_MISSING_LOWERCASE"
+	PasswordViolationMissingDigit     PasswordViolationEnum = "PASSWORD_MISSING_DIGIT"
+	PasswordViolationMissingSymbol    PasswordViolationEnum = "PASSWORD_MISSING_SYMBOL"
+	PasswordViolationUserInfo         PasswordViolationEnum = "PASSWORD_MATCHES_USER_INFO"
+	PasswordViolationBreached         PasswordViolationEnum = "PASSWORD_BREACHED"
+)
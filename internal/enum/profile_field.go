@@ -0,0 +1,15 @@
+package enum
+
+// ProfileFieldEnum is type for user profile field enum.
+// Used to list the fields of the user profile which are updated.
+type ProfileFieldEnum string
+
+// ProfileFieldEnum enum.
+const (
+	ProfileFieldFullName      ProfileFieldEnum = "fio"
+	ProfileFieldDateOfBirth   ProfileFieldEnum = "date_of_birth"
+	ProfileFieldGender        ProfileFieldEnum = "gender"
+	ProfileFieldAvatarFileKey ProfileFieldEnum = "avatar_file_key"
+	ProfileFieldEmail         ProfileFieldEnum = "email"
+	ProfileFieldPhone         ProfileFieldEnum = "phone"
+)
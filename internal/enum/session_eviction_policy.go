@@ -0,0 +1,48 @@
+package enum
+
+import "github.com/guregu/null/v6"
+
+// SessionEvictionPolicyEnum is type for session eviction policy enum.
+// Used to determine what happens when a user logs in to a client which already has the maximum number of sessions.
+type SessionEvictionPolicyEnum string
+
+// SessionEvictionPolicyEnum enum.
+const (
+	// EvictOldest removes the sessions which were created first.
+	EvictOldest SessionEvictionPolicyEnum = "oldest"
+	// EvictLeastRecentlyUsed removes the sessions which were used last the longest time ago.
+	EvictLeastRecentlyUsed SessionEvictionPolicyEnum = "lru"
+	// RejectNewSession keeps the existing sessions and rejects the new login.
+	RejectNewSession SessionEvictionPolicyEnum = "reject"
+)
+
+// IsValid reports whether the value is one of the session eviction policies.
+func (p SessionEvictionPolicyEnum) IsValid() bool {
+	switch p {
+	case EvictOldest, EvictLeastRecentlyUsed, RejectNewSession:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToNullString converts SessionEvictionPolicyEnum to nullable string type.
+func (p *SessionEvictionPolicyEnum) ToNullString() null.String {
+	if p == nil {
+		return null.NewString("", false)
+	}
+	return null.StringFrom(string(*p))
+}
+
+// SessionEvictionPolicyEnumFromNullString returns the session eviction policy from nullable string.
+// If the value is null, nil is returned.
+func SessionEvictionPolicyEnumFromNullString(value null.String) *SessionEvictionPolicyEnum {
+	policyStr := value.Ptr()
+	var policy *SessionEvictionPolicyEnum
+	if policyStr != nil {
+		policyValue := SessionEvictionPolicyEnum(*policyStr)
+		policy = &policyValue
+	}
+
+	return policy
+}
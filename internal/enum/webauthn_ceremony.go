@@ -0,0 +1,11 @@
+package enum
+
+// WebAuthnCeremonyEnum is type for WebAuthn ceremony enum.
+// Used to bind the WebAuthn challenge to the ceremony it is issued for.
+type WebAuthnCeremonyEnum string
+
+// WebAuthnCeremonyEnum enum.
+const (
+	WebAuthnCeremonyRegistration WebAuthnCeremonyEnum = "registration"
+	WebAuthnCeremonyLogin        WebAuthnCeremonyEnum = "login"
+)
@@ -17,15 +17,22 @@ func ToUserDTO(user models.User, roles []models.Role, permissions []models.Permi
 	permissionCodes := ToPermissionCodes(permissions)
 
 	return dto.User{
-		ID:            user.ID,
-		Username:      user.Username,
-		PasswordHash:  user.PasswordHash,
-		FullName:      user.FullName,
-		DateOfBirth:   user.DateOfBirth.Ptr(),
-		Gender:        enum.GenderEnumFromNullInt16(user.Gender),
-		AvatarFileKey: user.AvatarFileKey.Ptr(),
-		Roles:         rolesDTO,
-		Permissions:   permissionCodes,
+		ID:                 user.ID,
+		Username:           user.Username,
+		PasswordHash:       user.PasswordHash,
+		FullName:           user.FullName,
+		DateOfBirth:        user.DateOfBirth.Ptr(),
+		Gender:             enum.GenderEnumFromNullInt16(user.Gender),
+		AvatarFileKey:      user.AvatarFileKey.Ptr(),
+		Email:              user.Email.Ptr(),
+		EmailVerified:      user.EmailVerified,
+		Phone:              user.Phone.Ptr(),
+		PhoneVerified:      user.PhoneVerified,
+		Blocked:            user.Blocked,
+		MustChangePassword: user.MustChangePassword,
+		Deleted:            user.Deleted,
+		Roles:              rolesDTO,
+		Permissions:        permissionCodes,
 	}
 }
 
@@ -37,6 +44,12 @@ func ToUserProfileDTO(user models.User) dto.UserProfile {
 		DateOfBirth:   user.DateOfBirth.Ptr(),
 		Gender:        enum.GenderEnumFromNullInt16(user.Gender),
 		AvatarFileKey: user.AvatarFileKey.Ptr(),
+		Email:         user.Email.Ptr(),
+		EmailVerified: user.EmailVerified,
+		Phone:         user.Phone.Ptr(),
+		PhoneVerified: user.PhoneVerified,
+		Blocked:       user.Blocked,
+		Deleted:       user.Deleted,
 	}
 }
 
@@ -47,10 +60,14 @@ func ToClientDTO(client models.Client, audiences []models.Audience) dto.Client {
 	}
 
 	return dto.Client{
-		ID:        client.ID,
-		Code:      client.Code,
-		SecretKey: client.SecretKey,
-		Audiences: audienceURLs,
+		ID:                    client.ID,
+		Code:                  client.Code,
+		SecretKey:             client.SecretKey,
+		Audiences:             audienceURLs,
+		MaxSessions:           client.MaxSessions.Ptr(),
+		SessionEvictionPolicy: enum.SessionEvictionPolicyEnumFromNullString(client.SessionEvictionPolicy),
+		RequireVerifiedEmail:  client.RequireVerifiedEmail,
+		RequireMFA:            client.RequireMFA,
 	}
 }
 
@@ -58,22 +75,33 @@ func ToSessionDTO(session models.Session) dto.Session {
 	return dto.Session{
 		ID:             session.ID,
 		UserID:         session.UserID,
+		ClientID:       session.ClientID.Ptr(),
 		RefreshTokenID: session.RefreshToken,
+		FamilyID:       session.FamilyID,
 		UserAgent:      session.UserAgent,
 		Fingerprint:    session.Fingerprint,
 		ExpiresAt:      session.ExpiresAt,
+		CreatedAt:      session.CreatedAt,
+		LastUsedAt:     session.LastUsedAt,
 	}
 }
 
 func ToUserStorage(user *entity.User, setters ...models.UserOption) models.User {
 	userStorageModel := models.User{
-		ID:            user.ID,
-		Username:      user.Username,
-		PasswordHash:  user.PasswordHash,
-		FullName:      user.FullName,
-		DateOfBirth:   null.TimeFromPtr(user.DateOfBirth),
-		Gender:        user.Gender.ToNullInt16(),
-		AvatarFileKey: null.StringFromPtr(user.AvatarFileKey),
+		ID:                 user.ID,
+		Username:           user.Username,
+		PasswordHash:       user.PasswordHash,
+		FullName:           user.FullName,
+		DateOfBirth:        null.TimeFromPtr(user.DateOfBirth),
+		Gender:             user.Gender.ToNullInt16(),
+		AvatarFileKey:      null.StringFromPtr(user.AvatarFileKey),
+		Email:              null.StringFromPtr(user.Email),
+		EmailVerified:      user.EmailVerified,
+		Phone:              null.StringFromPtr(user.Phone),
+		PhoneVerified:      user.PhoneVerified,
+		Blocked:            user.Blocked,
+		MustChangePassword: user.MustChangePassword,
+		Deleted:            user.Deleted,
 	}
 
 	for _, setter := range setters {
@@ -87,10 +115,14 @@ func ToSessionStorage(session *entity.Session, setters ...models.SessionOption)
 	sessionStorageModel := models.Session{
 		ID:           session.ID,
 		UserID:       session.UserID,
+		ClientID:     null.IntFromPtr(session.ClientID),
 		RefreshToken: session.RefreshTokenID,
+		FamilyID:     session.FamilyID,
 		UserAgent:    session.UserAgent,
 		Fingerprint:  session.Fingerprint,
 		ExpiresAt:    session.ExpiresAt,
+		LastUsedAt:   session.LastUsedAt,
+		CreatedAt:    session.CreatedAt,
 	}
 
 	for _, setter := range setters {
@@ -100,6 +132,35 @@ func ToSessionStorage(session *entity.Session, setters ...models.SessionOption)
 	return sessionStorageModel
 }
 
+func ToConsumedRefreshTokenDTO(token models.ConsumedRefreshToken) dto.ConsumedRefreshToken {
+	return dto.ConsumedRefreshToken{
+		ID:             token.ID,
+		RefreshTokenID: token.RefreshToken,
+		FamilyID:       token.FamilyID,
+		UserID:         token.UserID,
+		ExpiresAt:      token.ExpiresAt,
+	}
+}
+
+func ToConsumedRefreshTokenStorage(
+	token *entity.ConsumedRefreshToken,
+	setters ...models.ConsumedRefreshTokenOption,
+) models.ConsumedRefreshToken {
+	tokenStorageModel := models.ConsumedRefreshToken{
+		ID:           token.ID,
+		RefreshToken: token.RefreshTokenID,
+		FamilyID:     token.FamilyID,
+		UserID:       token.UserID,
+		ExpiresAt:    token.ExpiresAt,
+	}
+
+	for _, setter := range setters {
+		setter(&tokenStorageModel)
+	}
+
+	return tokenStorageModel
+}
+
 func ToRoleDTO(role models.Role) dto.Role {
 	return dto.Role{
 		ID:   role.ID,
@@ -150,3 +211,526 @@ func ToUserRoleLinkStorage(userID, roleID int64, setters ...models.UserRoleLinkO
 
 	return userRoleLinkModel
 }
+
+func ToRedirectURIs(redirectURIs []models.RedirectURI) []string {
+	uris := make([]string, len(redirectURIs))
+	for i, redirectURI := range redirectURIs {
+		uris[i] = redirectURI.URI
+	}
+
+	return uris
+}
+
+func ToAuthorizationCodeDTO(code models.AuthorizationCode) dto.AuthorizationCode {
+	return dto.AuthorizationCode{
+		ID:                  code.ID,
+		CodeHash:            code.CodeHash,
+		UserID:              code.UserID,
+		ClientID:            code.ClientID,
+		RedirectURI:         code.RedirectURI,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		Nonce:               code.Nonce,
+		AuthTime:            code.AuthTime,
+		ExpiresAt:           code.ExpiresAt,
+		Used:                code.Used,
+	}
+}
+
+func ToAuthorizationCodeStorage(
+	code *entity.AuthorizationCode,
+	setters ...models.AuthorizationCodeOption,
+) models.AuthorizationCode {
+	codeStorageModel := models.AuthorizationCode{
+		ID:                  code.ID,
+		CodeHash:            code.CodeHash,
+		UserID:              code.UserID,
+		ClientID:            code.ClientID,
+		RedirectURI:         code.RedirectURI,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		Nonce:               code.Nonce,
+		AuthTime:            code.AuthTime,
+		ExpiresAt:           code.ExpiresAt,
+		Used:                code.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&codeStorageModel)
+	}
+
+	return codeStorageModel
+}
+
+func ToPasswordResetTokenDTO(token models.PasswordResetToken) dto.PasswordResetToken {
+	return dto.PasswordResetToken{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		Used:      token.Used,
+	}
+}
+
+func ToPasswordResetTokenStorage(
+	token *entity.PasswordResetToken,
+	setters ...models.PasswordResetTokenOption,
+) models.PasswordResetToken {
+	tokenStorageModel := models.PasswordResetToken{
+		ID:        token.ID,
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		Used:      token.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&tokenStorageModel)
+	}
+
+	return tokenStorageModel
+}
+
+func ToVerificationCodeDTO(code models.VerificationCode) dto.VerificationCode {
+	return dto.VerificationCode{
+		ID:        code.ID,
+		UserID:    code.UserID,
+		Channel:   enum.ContactChannelEnum(code.Channel),
+		Target:    code.Target,
+		CodeHash:  code.CodeHash,
+		ExpiresAt: code.ExpiresAt,
+		Attempts:  code.Attempts,
+		Used:      code.Used,
+	}
+}
+
+func ToVerificationCodeStorage(
+	code *entity.VerificationCode,
+	setters ...models.VerificationCodeOption,
+) models.VerificationCode {
+	codeStorageModel := models.VerificationCode{
+		ID:        code.ID,
+		UserID:    code.UserID,
+		Channel:   string(code.Channel),
+		Target:    code.Target,
+		CodeHash:  code.CodeHash,
+		ExpiresAt: code.ExpiresAt,
+		Attempts:  code.Attempts,
+		Used:      code.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&codeStorageModel)
+	}
+
+	return codeStorageModel
+}
+
+func ToUserMFADTO(credential models.TOTPCredential, backupCodes []models.BackupCode) dto.UserMFA {
+	backupCodesDTO := make([]dto.BackupCode, len(backupCodes))
+	for i, code := range backupCodes {
+		backupCodesDTO[i] = dto.BackupCode{
+			ID:       code.ID,
+			UserID:   code.UserID,
+			CodeHash: code.CodeHash,
+			Used:     code.Used,
+		}
+	}
+
+	return dto.UserMFA{
+		TOTPCredential: dto.TOTPCredential{
+			ID:           credential.ID,
+			UserID:       credential.UserID,
+			Secret:       credential.Secret,
+			Confirmed:    credential.Confirmed,
+			LastUsedStep: credential.LastUsedStep,
+		},
+		BackupCodes: backupCodesDTO,
+	}
+}
+
+func ToTOTPCredentialStorage(
+	credential *entity.TOTPCredential,
+	setters ...models.TOTPCredentialOption,
+) models.TOTPCredential {
+	credentialStorageModel := models.TOTPCredential{
+		ID:           credential.ID,
+		UserID:       credential.UserID,
+		Secret:       credential.Secret,
+		Confirmed:    credential.Confirmed,
+		LastUsedStep: credential.LastUsedStep,
+	}
+
+	for _, setter := range setters {
+		setter(&credentialStorageModel)
+	}
+
+	return credentialStorageModel
+}
+
+func ToBackupCodeStorage(code *entity.BackupCode, setters ...models.BackupCodeOption) models.BackupCode {
+	codeStorageModel := models.BackupCode{
+		ID:       code.ID,
+		UserID:   code.UserID,
+		CodeHash: code.CodeHash,
+		Used:     code.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&codeStorageModel)
+	}
+
+	return codeStorageModel
+}
+
+func ToMFAChallengeDTO(challenge models.MFAChallenge) dto.MFAChallenge {
+	return dto.MFAChallenge{
+		ID:          challenge.ID,
+		TokenHash:   challenge.TokenHash,
+		UserID:      challenge.UserID,
+		ClientID:    challenge.ClientID,
+		UserAgent:   challenge.UserAgent,
+		Fingerprint: challenge.Fingerprint,
+		Issuer:      challenge.Issuer,
+		Nonce:       challenge.Nonce,
+		ExpiresAt:   challenge.ExpiresAt,
+		Attempts:    challenge.Attempts,
+		Used:        challenge.Used,
+	}
+}
+
+func ToMFAChallengeStorage(
+	challenge *entity.MFAChallenge,
+	setters ...models.MFAChallengeOption,
+) models.MFAChallenge {
+	challengeStorageModel := models.MFAChallenge{
+		ID:          challenge.ID,
+		TokenHash:   challenge.TokenHash,
+		UserID:      challenge.UserID,
+		ClientID:    challenge.ClientID,
+		UserAgent:   challenge.UserAgent,
+		Fingerprint: challenge.Fingerprint,
+		Issuer:      challenge.Issuer,
+		Nonce:       challenge.Nonce,
+		ExpiresAt:   challenge.ExpiresAt,
+		Attempts:    challenge.Attempts,
+		Used:        challenge.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&challengeStorageModel)
+	}
+
+	return challengeStorageModel
+}
+
+func ToWebAuthnCredentialDTO(credential models.WebAuthnCredential) dto.WebAuthnCredential {
+	return dto.WebAuthnCredential{
+		ID:           credential.ID,
+		UserID:       credential.UserID,
+		CredentialID: credential.CredentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    uint32(credential.SignCount),
+		Name:         credential.Name,
+	}
+}
+
+func ToWebAuthnCredentialStorage(
+	credential *entity.WebAuthnCredential,
+	setters ...models.WebAuthnCredentialOption,
+) models.WebAuthnCredential {
+	credentialStorageModel := models.WebAuthnCredential{
+		ID:           credential.ID,
+		UserID:       credential.UserID,
+		CredentialID: credential.CredentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Name:         credential.Name,
+	}
+
+	for _, setter := range setters {
+		setter(&credentialStorageModel)
+	}
+
+	return credentialStorageModel
+}
+
+func ToWebAuthnChallengeDTO(challenge models.WebAuthnChallenge) dto.WebAuthnChallenge {
+	return dto.WebAuthnChallenge{
+		ID:        challenge.ID,
+		Challenge: challenge.Challenge,
+		Ceremony:  enum.WebAuthnCeremonyEnum(challenge.Ceremony),
+		UserID:    challenge.UserID.ValueOrZero(),
+		ClientID:  challenge.ClientID.ValueOrZero(),
+		Nonce:     challenge.Nonce,
+		ExpiresAt: challenge.ExpiresAt,
+		Used:      challenge.Used,
+	}
+}
+
+func ToWebAuthnChallengeStorage(
+	challenge *entity.WebAuthnChallenge,
+	setters ...models.WebAuthnChallengeOption,
+) models.WebAuthnChallenge {
+	challengeStorageModel := models.WebAuthnChallenge{
+		ID:        challenge.ID,
+		Challenge: challenge.Challenge,
+		Ceremony:  string(challenge.Ceremony),
+		UserID:    null.NewInt(challenge.UserID, challenge.UserID != 0),
+		ClientID:  null.NewInt(challenge.ClientID, challenge.ClientID != 0),
+		Nonce:     challenge.Nonce,
+		ExpiresAt: challenge.ExpiresAt,
+		Used:      challenge.Used,
+	}
+
+	for _, setter := range setters {
+		setter(&challengeStorageModel)
+	}
+
+	return challengeStorageModel
+}
+
+func ToLoginAttemptDTO(attempt models.LoginAttempt) dto.LoginAttempt {
+	return dto.LoginAttempt{
+		ID:            attempt.ID,
+		Kind:          enum.LoginAttemptKindEnum(attempt.Kind),
+		Key:           attempt.Key,
+		Failures:      attempt.Failures,
+		LastFailureAt: attempt.LastFailureAt,
+		LockedUntil:   attempt.LockedUntil.ValueOrZero(),
+	}
+}
+
+func ToLoginAttemptStorage(attempt *entity.LoginAttempt, setters ...models.LoginAttemptOption) models.LoginAttempt {
+	attemptStorageModel := models.LoginAttempt{
+		ID:            attempt.ID,
+		Kind:          string(attempt.Kind),
+		Key:           attempt.Key,
+		Failures:      attempt.Failures,
+		LastFailureAt: attempt.LastFailureAt,
+		LockedUntil:   null.NewTime(attempt.LockedUntil, !attempt.LockedUntil.IsZero()),
+	}
+
+	for _, setter := range setters {
+		setter(&attemptStorageModel)
+	}
+
+	return attemptStorageModel
+}
+
+func ToClientDetailsDTO(client models.Client, audiences []models.Audience, roles []models.Role) dto.ClientDetails {
+	audiencesDTO := make([]dto.Audience, len(audiences))
+	for i, audience := range audiences {
+		audiencesDTO[i] = dto.Audience{
+			ID:  audience.ID,
+			URL: audience.URL,
+		}
+	}
+
+	rolesDTO := make([]dto.Role, len(roles))
+	for i, role := range roles {
+		rolesDTO[i] = ToRoleDTO(role)
+	}
+
+	return dto.ClientDetails{
+		ID:                    client.ID,
+		Name:                  client.Name,
+		Code:                  client.Code,
+		SecretKey:             client.SecretKey,
+		MaxSessions:           client.MaxSessions.Ptr(),
+		SessionEvictionPolicy: enum.SessionEvictionPolicyEnumFromNullString(client.SessionEvictionPolicy),
+		RequireVerifiedEmail:  client.RequireVerifiedEmail,
+		RequireMFA:            client.RequireMFA,
+		Audiences:             audiencesDTO,
+		DefaultRoles:          rolesDTO,
+		CreatedAt:             client.CreatedAt,
+		UpdatedAt:             client.UpdatedAt,
+	}
+}
+
+func ToClientStorage(client *entity.Client, setters ...models.ClientOption) models.Client {
+	clientStorageModel := models.Client{
+		ID:                    client.ID,
+		Name:                  client.Name,
+		Code:                  client.Code,
+		SecretKey:             client.SecretKey,
+		MaxSessions:           null.Int32FromPtr(client.MaxSessions),
+		SessionEvictionPolicy: client.SessionEvictionPolicy.ToNullString(),
+		RequireVerifiedEmail:  client.RequireVerifiedEmail,
+		RequireMFA:            client.RequireMFA,
+		CreatedAt:             client.CreatedAt,
+		UpdatedAt:             client.UpdatedAt,
+	}
+
+	for _, setter := range setters {
+		setter(&clientStorageModel)
+	}
+
+	return clientStorageModel
+}
+
+func ToAudienceStorage(clientID int64, url string, setters ...models.AudienceOption) models.Audience {
+	audienceModel := models.Audience{
+		ClientID: clientID,
+		URL:      url,
+	}
+
+	for _, setter := range setters {
+		setter(&audienceModel)
+	}
+
+	return audienceModel
+}
+
+func ToClientDefaultRoleLinkStorage(
+	clientID,
+	roleID int64,
+	setters ...models.ClientDefaultRoleLinkOption,
+) models.ClientDefaultRoleLink {
+	clientDefaultRoleLinkModel := models.ClientDefaultRoleLink{
+		ClientID: clientID,
+		RoleID:   roleID,
+	}
+
+	for _, setter := range setters {
+		setter(&clientDefaultRoleLinkModel)
+	}
+
+	return clientDefaultRoleLinkModel
+}
+
+func ToPermissionDetailsDTO(permission models.Permission) dto.PermissionDetails {
+	return dto.PermissionDetails{
+		ID:          permission.ID,
+		Code:        permission.Code,
+		Description: permission.Description.ValueOrZero(),
+		Active:      permission.Active,
+		CreatedAt:   permission.CreatedAt,
+		UpdatedAt:   permission.UpdatedAt,
+	}
+}
+
+func ToRoleDetailsDTO(role models.Role, permissions []models.Permission) dto.RoleDetails {
+	permissionsDTO := make([]dto.PermissionDetails, len(permissions))
+	for i, permission := range permissions {
+		permissionsDTO[i] = ToPermissionDetailsDTO(permission)
+	}
+
+	return dto.RoleDetails{
+		ID:          role.ID,
+		Code:        role.Code,
+		Name:        role.Name,
+		Description: role.Description.ValueOrZero(),
+		Active:      role.Active,
+		Permissions: permissionsDTO,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func ToRoleStorage(role *entity.Role, setters ...models.RoleOption) models.Role {
+	roleStorageModel := models.Role{
+		ID:          role.ID,
+		Code:        role.Code,
+		Name:        role.Name,
+		Description: null.NewString(role.Description, role.Description != ""),
+		Active:      role.Active,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+
+	for _, setter := range setters {
+		setter(&roleStorageModel)
+	}
+
+	return roleStorageModel
+}
+
+func ToPermissionStorage(permission *entity.Permission, setters ...models.PermissionOption) models.Permission {
+	permissionStorageModel := models.Permission{
+		ID:          permission.ID,
+		Code:        permission.Code,
+		Description: null.NewString(permission.Description, permission.Description != ""),
+		Active:      permission.Active,
+		CreatedAt:   permission.CreatedAt,
+		UpdatedAt:   permission.UpdatedAt,
+	}
+
+	for _, setter := range setters {
+		setter(&permissionStorageModel)
+	}
+
+	return permissionStorageModel
+}
+
+func ToRolePermissionLinkStorage(
+	roleID,
+	permissionID int64,
+	setters ...models.RolePermissionLinkOption,
+) models.RolePermissionLink {
+	rolePermissionLinkModel := models.RolePermissionLink{
+		RoleID:       roleID,
+		PermissionID: permissionID,
+	}
+
+	for _, setter := range setters {
+		setter(&rolePermissionLinkModel)
+	}
+
+	return rolePermissionLinkModel
+}
+
+func ToUserDetailsDTO(user models.User, roles []models.Role, clients []models.Client) dto.UserDetails {
+	rolesDTO := make([]dto.Role, len(roles))
+	for i, role := range roles {
+		rolesDTO[i] = ToRoleDTO(role)
+	}
+
+	clientsDTO := make([]dto.Client, len(clients))
+	for i, client := range clients {
+		clientsDTO[i] = dto.Client{
+			ID:   client.ID,
+			Code: client.Code,
+		}
+	}
+
+	return dto.UserDetails{
+		ID:                 user.ID,
+		Username:           user.Username,
+		FullName:           user.FullName,
+		DateOfBirth:        user.DateOfBirth.Ptr(),
+		Gender:             enum.GenderEnumFromNullInt16(user.Gender),
+		AvatarFileKey:      user.AvatarFileKey.Ptr(),
+		Email:              user.Email.Ptr(),
+		EmailVerified:      user.EmailVerified,
+		Phone:              user.Phone.Ptr(),
+		PhoneVerified:      user.PhoneVerified,
+		Blocked:            user.Blocked,
+		MustChangePassword: user.MustChangePassword,
+		Deleted:            user.Deleted,
+		Roles:              rolesDTO,
+		Clients:            clientsDTO,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+	}
+}
+
+func ToUserAccountStorage(account *entity.UserAccount, setters ...models.UserOption) models.User {
+	userStorageModel := models.User{
+		ID:                 account.ID,
+		Username:           account.Username,
+		FullName:           account.FullName,
+		PasswordHash:       account.PasswordHash,
+		Blocked:            account.Blocked,
+		MustChangePassword: account.MustChangePassword,
+		Deleted:            account.Deleted,
+		CreatedAt:          account.CreatedAt,
+		UpdatedAt:          account.UpdatedAt,
+	}
+
+	for _, setter := range setters {
+		setter(&userStorageModel)
+	}
+
+	return userStorageModel
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"log/slog"
+)
+
+// LogPublisher is a publisher which writes the security events to the log.
+type LogPublisher struct {
+	log *slog.Logger
+}
+
+// NewLogPublisher returns new publisher which writes the security events to the log.
+func NewLogPublisher(log *slog.Logger) *LogPublisher {
+	return &LogPublisher{
+		log: log,
+	}
+}
+
+// PublishSecurityEvent writes the security event to the log.
+func (p *LogPublisher) PublishSecurityEvent(ctx context.Context, event entity.SecurityEvent) error {
+	attrs := []slog.Attr{
+		slog.String("type", event.Type),
+		slog.Int64("user id", event.UserID),
+		slog.String("client code", event.ClientCode),
+		slog.Time("occurred at", event.OccurredAt),
+	}
+	for key, value := range event.Details {
+		attrs = append(attrs, slog.String(key, value))
+	}
+
+	p.log.LogAttrs(ctx, slog.LevelWarn, "security event", attrs...)
+
+	return nil
+}
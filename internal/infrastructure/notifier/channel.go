@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+)
+
+// ErrUnknownChannel is returned when the notification is addressed to the channel no notifier is set up for.
+var ErrUnknownChannel = errors.New("unknown notification channel")
+
+// Notifier is a notifier which delivers the notifications of a single channel.
+type Notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+// ChannelNotifier is a notifier which routes the notifications to the notifier of their channel:
+// the notifications to the emails and the notifications to the phones are delivered by different notifiers.
+type ChannelNotifier struct {
+	notifiers map[enum.ContactChannelEnum]Notifier
+}
+
+// NewChannelNotifier returns new notifier which routes the notifications to the email and the phone notifiers.
+func NewChannelNotifier(email, phone Notifier) *ChannelNotifier {
+	return &ChannelNotifier{
+		notifiers: map[enum.ContactChannelEnum]Notifier{
+			enum.ContactChannelEmail: email,
+			enum.ContactChannelPhone: phone,
+		},
+	}
+}
+
+// Notify delivers the notification by the notifier of its channel.
+func (n *ChannelNotifier) Notify(ctx context.Context, notification entity.Notification) error {
+	const op = "notifier.channel.Notify"
+
+	notifier, ok := n.notifiers[notification.Channel]
+	if !ok || notifier == nil {
+		return fmt.Errorf("%s: %w: %q", op, ErrUnknownChannel, notification.Channel)
+	}
+
+	if err := notifier.Notify(ctx, notification); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func Test_ChannelNotifier(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	email := NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	phone := NewFileNotifier(filepath.Join(t.TempDir(), "sms_outbox.jsonl"))
+	notifier := NewChannelNotifier(email, phone)
+
+	emailNotification := entity.Notification{Channel: enum.ContactChannelEmail, Recipient: "user@example.com", Body: "email"}
+	phoneNotification := entity.Notification{Channel: enum.ContactChannelPhone, Recipient: "+15550000000", Body: "sms"}
+
+	require.NoError(t, notifier.Notify(ctx, emailNotification))
+	require.NoError(t, notifier.Notify(ctx, phoneNotification))
+
+	err := notifier.Notify(ctx, entity.Notification{Channel: "fax", Recipient: "1"})
+	assert.ErrorIs(t, err, ErrUnknownChannel)
+
+	emailNotifications, err := email.Notifications()
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Notification{emailNotification}, emailNotifications)
+
+	phoneNotifications, err := phone.Notifications()
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Notification{phoneNotification}, phoneNotifications)
+}
@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"os"
+	"sync"
+	"time"
+)
+
+// outboxRecord is the notification written to the outbox file.
+type outboxRecord struct {
+	Channel   string    `json:"channel"`
+	Recipient string    `json:"recipient"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FileNotifier is a notifier which appends the notifications to the outbox file, one JSON object per line,
+// instead of sending them. It is intended for local development and tests.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier returns new notifier which appends the notifications to the outbox file.
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{
+		path: path,
+	}
+}
+
+// Notify appends the notification to the outbox file.
+func (n *FileNotifier) Notify(_ context.Context, notification entity.Notification) error {
+	const op = "notifier.file.Notify"
+
+	record, err := json.Marshal(outboxRecord{
+		Channel:   string(notification.Channel),
+		Recipient: notification.Recipient,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = file.Write(append(record, '\n')); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Notifications returns the notifications of the outbox file in the order they are written.
+// If the outbox file does not exist, no notifications are returned.
+func (n *FileNotifier) Notifications() ([]entity.Notification, error) {
+	const op = "notifier.file.Notifications"
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.Open(n.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer file.Close()
+
+	var notifications []entity.Notification
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		var record outboxRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		notifications = append(notifications, entity.Notification{
+			Channel:   enum.ContactChannelEnum(record.Channel),
+			Recipient: record.Recipient,
+			Subject:   record.Subject,
+			Body:      record.Body,
+		})
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
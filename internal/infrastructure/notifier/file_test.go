@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func Test_FileNotifier(t *testing.T) {
+	ctx := context.Background()
+	notifier := NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+
+	notifications, err := notifier.Notifications()
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	first := entity.Notification{Channel: enum.ContactChannelEmail, Recipient: "first@example.com", Subject: "First", Body: "line 1\nline 2\n"}
+	second := entity.Notification{Channel: enum.ContactChannelPhone, Recipient: "+15550000000", Subject: "Second", Body: "body"}
+
+	require.NoError(t, notifier.Notify(ctx, first))
+	require.NoError(t, notifier.Notify(ctx, second))
+
+	notifications, err = notifier.Notifications()
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Notification{first, second}, notifications)
+}
@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sendMailFunc is the signature of smtp.SendMail, it is replaced in tests.
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// SMTPNotifier is a notifier which sends the notifications by email through the SMTP server.
+// The connection is upgraded by STARTTLS if the server supports it.
+type SMTPNotifier struct {
+	addr     string
+	auth     smtp.Auth
+	from     mail.Address
+	sendMail sendMailFunc
+}
+
+// NewSMTPNotifier returns new notifier which sends the notifications through the SMTP server.
+// If the username is empty, the notifications are sent without authentication.
+func NewSMTPNotifier(host string, port int, username, password, from string) (*SMTPNotifier, error) {
+	const op = "notifier.smtp.New"
+
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid sender address: %w", op, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPNotifier{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		auth:     auth,
+		from:     *fromAddress,
+		sendMail: smtp.SendMail,
+	}, nil
+}
+
+// Notify sends the notification to the recipient by email.
+func (n *SMTPNotifier) Notify(_ context.Context, notification entity.Notification) error {
+	const op = "notifier.smtp.Notify"
+
+	to, err := mail.ParseAddress(notification.Recipient)
+	if err != nil {
+		return fmt.Errorf("%s: invalid recipient address: %w", op, err)
+	}
+
+	if err = n.sendMail(n.addr, n.auth, n.from.Address, []string{to.Address}, n.message(*to, notification)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// message returns the plain text email message of the notification.
+func (n *SMTPNotifier) message(to mail.Address, notification entity.Notification) []byte {
+	var msg strings.Builder
+
+	msg.WriteString("From: " + n.from.String() + "\r\n")
+	msg.WriteString("To: " + to.String() + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+
+	return []byte(msg.String())
+}
@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+func Test_SMTPNotifier_Notify(t *testing.T) {
+	errSend := errors.New("connection refused")
+
+	testCases := []struct {
+		name          string
+		recipient     string
+		sendErr       error
+		expectedSent  bool
+		expectedError error
+	}{
+		{
+			name:         "sends the notification",
+			recipient:    "User <user@example.com>",
+			expectedSent: true,
+		},
+		{
+			name:      "invalid recipient address",
+			recipient: "user",
+		},
+		{
+			name:          "SMTP server fails",
+			recipient:     "user@example.com",
+			sendErr:       errSend,
+			expectedSent:  true,
+			expectedError: errSend,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			notifier, err := NewSMTPNotifier("smtp.example.com", 587, "sso", "secret", "SSO <sso@example.com>")
+			require.NoError(t, err)
+
+			var (
+				sent    bool
+				addr    string
+				from    string
+				to      []string
+				message string
+			)
+			notifier.sendMail = func(a string, _ smtp.Auth, f string, recipients []string, msg []byte) error {
+				sent, addr, from, to, message = true, a, f, recipients, string(msg)
+
+				return tc.sendErr
+			}
+
+			err = notifier.Notify(context.Background(), entity.Notification{
+				Recipient: tc.recipient,
+				Subject:   "Password reset",
+				Body:      "line 1\nline 2\n",
+			})
+
+			assert.Equal(t, tc.expectedSent, sent)
+			switch {
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+			case !tc.expectedSent:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "smtp.example.com:587", addr)
+				assert.Equal(t, "sso@example.com", from)
+				assert.Equal(t, []string{"user@example.com"}, to)
+				assert.Contains(t, message, "To: \"User\" <user@example.com>\r\n")
+				assert.Contains(t, message, "Subject: Password reset\r\n")
+				assert.True(t, strings.HasSuffix(message, "\r\n\r\nline 1\r\nline 2\r\n"))
+			}
+		})
+	}
+}
+
+func Test_NewSMTPNotifier_InvalidSender(t *testing.T) {
+	_, err := NewSMTPNotifier("smtp.example.com", 587, "", "", "sso")
+	assert.Error(t, err)
+}
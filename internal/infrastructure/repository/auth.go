@@ -6,40 +6,128 @@ import (
 	"fmt"
 	"github.com/p1xray/pxr-sso/internal/dto"
 	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
 	"github.com/p1xray/pxr-sso/internal/infrastructure"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
 	"github.com/p1xray/pxr-sso/pkg/logger/sl"
 	"log/slog"
+	"time"
 )
 
 const emptyID = 0
 
+// Transactor executes functions in a storage transaction.
+// The storage methods called with the context passed to the function are executed in the transaction.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type Storage interface {
+	Transactor
+
 	User(ctx context.Context, id int64) (models.User, error)
 	UserByUsername(ctx context.Context, username string) (models.User, error)
 	CreateUser(ctx context.Context, user models.User) (int64, error)
 	UpdateUser(ctx context.Context, user models.User) error
+	UpdateUserProfile(ctx context.Context, user models.User) error
+	UpdateUserPassword(ctx context.Context, user models.User) error
 	RemoveUser(ctx context.Context, user models.User) error
 
 	RolesByUserID(ctx context.Context, userID int64) ([]models.Role, error)
 	RolesByClientID(ctx context.Context, clientID int64) ([]models.Role, error)
 
+	PermissionsByClientID(ctx context.Context, clientID int64) ([]models.Permission, error)
 	PermissionsByUserID(ctx context.Context, userID int64) ([]models.Permission, error)
 	PermissionsByRoleCodes(ctx context.Context, roleCodes []string) ([]models.Permission, error)
 
+	Session(ctx context.Context, id int64) (models.Session, error)
 	SessionsByUserID(ctx context.Context, userID int64) ([]models.Session, error)
 	SessionByRefreshTokenID(ctx context.Context, refreshTokenID string) (models.Session, error)
+	SessionsByFamilyID(ctx context.Context, familyID string) ([]models.Session, error)
 	CreateSession(ctx context.Context, session models.Session) (int64, error)
 	UpdateSession(ctx context.Context, session models.Session) error
 	RemoveSession(ctx context.Context, id int64) error
 
+	ConsumedRefreshTokenByID(ctx context.Context, refreshTokenID string) (models.ConsumedRefreshToken, error)
+	CreateConsumedRefreshToken(ctx context.Context, token models.ConsumedRefreshToken) (int64, error)
+
 	ClientByCodeAndUserID(ctx context.Context, code string, userID int64) (models.Client, error)
 	ClientByCode(ctx context.Context, code string) (models.Client, error)
 	ClientAudiences(ctx context.Context, clientID int64) ([]models.Audience, error)
+	ClientRedirectURIs(ctx context.Context, clientID int64) ([]models.RedirectURI, error)
+
+	AuthorizationCodeByHash(ctx context.Context, codeHash string) (models.AuthorizationCode, error)
+	CreateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) (int64, error)
+	UpdateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) error
+
+	PasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error)
+	CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) (int64, error)
+	UpdatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error
+
+	VerificationCode(ctx context.Context, userID int64, channel string) (models.VerificationCode, error)
+	CreateVerificationCode(ctx context.Context, code models.VerificationCode) (int64, error)
+	UpdateVerificationCode(ctx context.Context, code models.VerificationCode) error
+
+	TOTPCredential(ctx context.Context, userID int64) (models.TOTPCredential, error)
+	CreateTOTPCredential(ctx context.Context, credential models.TOTPCredential) (int64, error)
+	UpdateTOTPCredential(ctx context.Context, credential models.TOTPCredential) error
+	RemoveTOTPCredential(ctx context.Context, id int64) error
+	BackupCodes(ctx context.Context, userID int64) ([]models.BackupCode, error)
+	CreateBackupCode(ctx context.Context, code models.BackupCode) (int64, error)
+	UpdateBackupCode(ctx context.Context, code models.BackupCode) error
+	RemoveBackupCode(ctx context.Context, id int64) error
+	MFAChallengeByHash(ctx context.Context, tokenHash string) (models.MFAChallenge, error)
+	CreateMFAChallenge(ctx context.Context, challenge models.MFAChallenge) (int64, error)
+	UpdateMFAChallenge(ctx context.Context, challenge models.MFAChallenge, maxAttempts int) error
+	WebAuthnCredentials(ctx context.Context, userID int64) ([]models.WebAuthnCredential, error)
+	WebAuthnCredential(ctx context.Context, credentialID string) (models.WebAuthnCredential, error)
+	CreateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) (int64, error)
+	UpdateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) error
+	WebAuthnChallenge(ctx context.Context, challenge string) (models.WebAuthnChallenge, error)
+	CreateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) (int64, error)
+	UpdateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) error
+
+	LoginAttempt(ctx context.Context, kind, key string) (models.LoginAttempt, error)
+	FailLoginAttempt(ctx context.Context, attempt models.LoginAttempt, windowStart time.Time) (models.LoginAttempt, error)
+	UpdateLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error
+	RemoveLoginAttempt(ctx context.Context, id int64) error
+	RemoveExpiredLoginAttempts(ctx context.Context, kind string, before, now time.Time) error
 
 	CreateUserClientLink(ctx context.Context, userClientLink models.UserClientLink) (int64, error)
 	CreateUserRoleLink(ctx context.Context, userRoleLink models.UserRoleLink) (int64, error)
+
+	Client(ctx context.Context, id int64) (models.Client, error)
+	Clients(ctx context.Context) ([]models.Client, error)
+	CreateClient(ctx context.Context, client models.Client) (int64, error)
+	UpdateClient(ctx context.Context, client models.Client) error
+	CreateAudience(ctx context.Context, audience models.Audience) (int64, error)
+	RemoveAudience(ctx context.Context, id int64) error
+	RolesByCodes(ctx context.Context, codes []string) ([]models.Role, error)
+	CreateClientDefaultRoleLink(ctx context.Context, clientDefaultRoleLink models.ClientDefaultRoleLink) (int64, error)
+	RemoveClientDefaultRoleLinks(ctx context.Context, clientID int64) error
+
+	Role(ctx context.Context, id int64) (models.Role, error)
+	Roles(ctx context.Context, limit, offset int) ([]models.Role, error)
+	RolesCount(ctx context.Context) (int64, error)
+	CreateRole(ctx context.Context, role models.Role) (int64, error)
+	UpdateRole(ctx context.Context, role models.Role) error
+	Permission(ctx context.Context, id int64) (models.Permission, error)
+	Permissions(ctx context.Context, limit, offset int) ([]models.Permission, error)
+	PermissionsCount(ctx context.Context) (int64, error)
+	CreatePermission(ctx context.Context, permission models.Permission) (int64, error)
+	UpdatePermission(ctx context.Context, permission models.Permission) error
+	PermissionsByRoleID(ctx context.Context, roleID int64) ([]models.Permission, error)
+	CreateRolePermissionLink(ctx context.Context, rolePermissionLink models.RolePermissionLink) (int64, error)
+	RemoveRolePermissionLink(ctx context.Context, roleID, permissionID int64) error
+
+	Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]models.User, error)
+	UsersCount(ctx context.Context, query string, deleted bool) (int64, error)
+	UpdateUserStatus(ctx context.Context, user models.User) error
+	UserRoles(ctx context.Context, userID int64) ([]models.Role, error)
+	RemoveUserRoleLink(ctx context.Context, userID, roleID int64) error
+	UserClients(ctx context.Context, userID int64) ([]models.Client, error)
+	RemoveUserClientLink(ctx context.Context, userID, clientID int64) error
 }
 
 type Auth struct {
@@ -73,6 +161,12 @@ func (a *Auth) ClientByCode(ctx context.Context, code string) (dto.Client, error
 		return dto.Client{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.Client{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
 	clientAudiences, err := a.storage.ClientAudiences(ctx, client.ID)
 	if err != nil {
 		log.Error("error getting client audiences", sl.Err(err))
@@ -85,13 +179,101 @@ func (a *Auth) ClientByCode(ctx context.Context, code string) (dto.Client, error
 	return clientDTO, nil
 }
 
-func (a *Auth) DataForLogin(ctx context.Context, username, clientCode string) (dto.DataForLogin, error) {
+func (a *Auth) SessionByRefreshTokenID(ctx context.Context, refreshTokenID string) (dto.Session, error) {
+	const op = "repository.auth.SessionByRefreshTokenID"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("refresh token ID", refreshTokenID),
+	)
+
+	sessionDTO, err := a.sessionByRefreshTokenID(ctx, log, refreshTokenID)
+	if err != nil {
+		return dto.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessionDTO, nil
+}
+
+// SessionByFamilyID returns the current session of the token family. The session of the family is replaced
+// on every refresh token rotation, so infrastructure.ErrEntityNotFound is returned only if the family is revoked.
+func (a *Auth) SessionByFamilyID(ctx context.Context, familyID string) (dto.Session, error) {
+	const op = "repository.auth.SessionByFamilyID"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("family ID", familyID),
+	)
+
+	familySessions, err := a.storage.SessionsByFamilyID(ctx, familyID)
+	if err != nil {
+		log.Error("error getting token family sessions", sl.Err(err))
+
+		return dto.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(familySessions) == 0 {
+		log.Warn("session not found")
+
+		return dto.Session{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	current := familySessions[0]
+	for _, familySession := range familySessions[1:] {
+		if familySession.ExpiresAt.After(current.ExpiresAt) {
+			current = familySession
+		}
+	}
+
+	return converter.ToSessionDTO(current), nil
+}
+
+// UserPermissionCodes returns the codes of the active permissions granted to the user by the active roles.
+func (a *Auth) UserPermissionCodes(ctx context.Context, userID int64) ([]string, error) {
+	const op = "repository.auth.UserPermissionCodes"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	permissions, err := a.storage.PermissionsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error getting user permissions", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToPermissionCodes(permissions), nil
+}
+
+// ClientPermissionCodes returns the codes of the active permissions granted to the client.
+func (a *Auth) ClientPermissionCodes(ctx context.Context, clientID int64) ([]string, error) {
+	const op = "repository.auth.ClientPermissionCodes"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", clientID),
+	)
+
+	permissions, err := a.storage.PermissionsByClientID(ctx, clientID)
+	if err != nil {
+		log.Error("error getting client permissions", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToPermissionCodes(permissions), nil
+}
+
+func (a *Auth) DataForLogin(ctx context.Context, username, clientCode, ipAddress string) (dto.DataForLogin, error) {
 	const op = "repository.auth.DataForLogin"
 
 	log := a.log.With(
 		slog.String("op", op),
 		slog.String("username", username),
 		slog.String("client code", clientCode),
+		slog.String("IP address", ipAddress),
 	)
 
 	userDTO, err := a.userByUsername(ctx, log, username)
@@ -110,6 +292,12 @@ func (a *Auth) DataForLogin(ctx context.Context, username, clientCode string) (d
 		}
 	}
 
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		client = models.Client{}
+	}
+
 	clientAudiences, err := a.storage.ClientAudiences(ctx, client.ID)
 	if err != nil {
 		log.Error("error getting client audiences", sl.Err(err))
@@ -131,10 +319,270 @@ func (a *Auth) DataForLogin(ctx context.Context, username, clientCode string) (d
 		sessionsDTO[i] = converter.ToSessionDTO(userSession)
 	}
 
+	mfaDTO, err := a.userMFA(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loginAttemptsDTO, err := a.loginAttempts(ctx, log, username, ipAddress)
+	if err != nil {
+		return dto.DataForLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return dto.DataForLogin{
-		User:     userDTO,
-		Client:   clientDTO,
-		Sessions: sessionsDTO,
+		User:          userDTO,
+		MFA:           mfaDTO,
+		Client:        clientDTO,
+		Sessions:      sessionsDTO,
+		LoginAttempts: loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForAuthorize(
+	ctx context.Context,
+	username, clientCode, ipAddress string,
+) (dto.DataForAuthorize, error) {
+	const op = "repository.auth.DataForAuthorize"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+		slog.String("client code", clientCode),
+		slog.String("IP address", ipAddress),
+	)
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	client, err := a.storage.ClientByCodeAndUserID(ctx, clientCode, userDTO.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting user client", sl.Err(err))
+		}
+
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	clientRedirectURIs, err := a.storage.ClientRedirectURIs(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client redirect URIs", sl.Err(err))
+
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clientDTO := converter.ToClientDTO(client, nil)
+	clientDTO.RedirectURIs = converter.ToRedirectURIs(clientRedirectURIs)
+
+	mfaDTO, err := a.userMFA(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loginAttemptsDTO, err := a.loginAttempts(ctx, log, username, ipAddress)
+	if err != nil {
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The failed MFA codes sent with the login form are counted per username.
+	mfaAttemptDTO, found, err := a.loginAttempt(ctx, log, enum.LoginAttemptKindMFA, username)
+	if err != nil {
+		return dto.DataForAuthorize{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if found {
+		loginAttemptsDTO = append(loginAttemptsDTO, mfaAttemptDTO)
+	}
+
+	return dto.DataForAuthorize{
+		User:          userDTO,
+		MFA:           mfaDTO,
+		Client:        clientDTO,
+		LoginAttempts: loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForVerifyMFA(ctx context.Context, tokenHash string) (dto.DataForVerifyMFA, error) {
+	const op = "repository.auth.DataForVerifyMFA"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	challenge, err := a.storage.MFAChallengeByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("MFA challenge not found", sl.Err(err))
+		} else {
+			log.Error("error getting MFA challenge", sl.Err(err))
+		}
+
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	client, err := a.storage.Client(ctx, challenge.ClientID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting client", sl.Err(err))
+		}
+
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	clientAudiences, err := a.storage.ClientAudiences(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client audiences", sl.Err(err))
+
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, challenge.UserID)
+	if err != nil {
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mfaDTO, err := a.userMFA(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The failed MFA codes are counted per username across the challenges.
+	var loginAttemptsDTO []dto.LoginAttempt
+	mfaAttemptDTO, found, err := a.loginAttempt(ctx, log, enum.LoginAttemptKindMFA, userDTO.Username)
+	if err != nil {
+		return dto.DataForVerifyMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if found {
+		loginAttemptsDTO = append(loginAttemptsDTO, mfaAttemptDTO)
+	}
+
+	return dto.DataForVerifyMFA{
+		MFAChallenge:  converter.ToMFAChallengeDTO(challenge),
+		User:          userDTO,
+		MFA:           mfaDTO,
+		Client:        converter.ToClientDTO(client, clientAudiences),
+		Sessions:      sessionsDTO,
+		LoginAttempts: loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForExchangeAuthorizationCode(
+	ctx context.Context,
+	codeHash, clientCode string,
+) (dto.DataForExchangeAuthorizationCode, error) {
+	const op = "repository.auth.DataForExchangeAuthorizationCode"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client code", clientCode),
+	)
+
+	code, err := a.storage.AuthorizationCodeByHash(ctx, codeHash)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("authorization code not found", sl.Err(err))
+		} else {
+			log.Error("error getting authorization code", sl.Err(err))
+		}
+
+		return dto.DataForExchangeAuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clientDTO, err := a.ClientByCode(ctx, clientCode)
+	if err != nil {
+		return dto.DataForExchangeAuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, code.UserID)
+	if err != nil {
+		return dto.DataForExchangeAuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userSessions, err := a.storage.SessionsByUserID(ctx, userDTO.ID)
+	if err != nil {
+		log.Error("error getting user sessions", sl.Err(err))
+
+		return dto.DataForExchangeAuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO := make([]dto.Session, len(userSessions))
+	for i, userSession := range userSessions {
+		sessionsDTO[i] = converter.ToSessionDTO(userSession)
+	}
+
+	return dto.DataForExchangeAuthorizationCode{
+		AuthorizationCode: converter.ToAuthorizationCodeDTO(code),
+		User:              userDTO,
+		Client:            clientDTO,
+		Sessions:          sessionsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForClientCredentials(ctx context.Context, clientCode string) (dto.DataForClientCredentials, error) {
+	const op = "repository.auth.DataForClientCredentials"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("client code", clientCode),
+	)
+
+	client, err := a.storage.ClientByCode(ctx, clientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting client", sl.Err(err))
+		}
+
+		return dto.DataForClientCredentials{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.DataForClientCredentials{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	clientAudiences, err := a.storage.ClientAudiences(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client audiences", sl.Err(err))
+
+		return dto.DataForClientCredentials{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clientPermissions, err := a.storage.PermissionsByClientID(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client permissions", sl.Err(err))
+
+		return dto.DataForClientCredentials{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForClientCredentials{
+		Client:          converter.ToClientDTO(client, clientAudiences),
+		PermissionCodes: converter.ToPermissionCodes(clientPermissions),
 	}, nil
 }
 
@@ -157,88 +605,1141 @@ func (a *Auth) DataForRegister(ctx context.Context, username, clientCode string)
 		return dto.DataForRegister{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	roles, err := a.storage.RolesByClientID(ctx, clientDTO.ID)
-	if err != nil {
-		return dto.DataForRegister{}, fmt.Errorf("%s: %w", op, err)
+	roles, err := a.storage.RolesByClientID(ctx, clientDTO.ID)
+	if err != nil {
+		return dto.DataForRegister{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rolesDTO := make([]dto.Role, len(roles))
+	for i, role := range roles {
+		rolesDTO[i] = converter.ToRoleDTO(role)
+	}
+
+	roleCodes := converter.ToRoleCodes(roles)
+
+	permissions, err := a.storage.PermissionsByRoleCodes(ctx, roleCodes)
+	if err != nil {
+		return dto.DataForRegister{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	permissionCodes := converter.ToPermissionCodes(permissions)
+
+	return dto.DataForRegister{
+		User:                         userDTO,
+		Client:                       clientDTO,
+		ClientDefaultRoles:           rolesDTO,
+		ClientDefaultPermissionCodes: permissionCodes,
+	}, nil
+}
+
+func (a *Auth) DataForRefreshTokens(ctx context.Context, refreshTokenID string) (dto.DataForRefreshTokens, error) {
+	const op = "repository.auth.DataForRefreshTokens"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("refresh token ID", refreshTokenID),
+	)
+
+	sessionDTO, err := a.sessionByRefreshTokenID(ctx, log, refreshTokenID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return a.dataForReusedRefreshToken(ctx, log, refreshTokenID)
+		}
+
+		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, sessionDTO.UserID)
+	if err != nil {
+		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForRefreshTokens{
+		Session: sessionDTO,
+		User:    userDTO,
+	}, nil
+}
+
+// dataForReusedRefreshToken returns the data for refreshing user tokens by the refresh token
+// which has already been rotated. If the refresh token has never been consumed, ErrEntityNotFound is returned.
+func (a *Auth) dataForReusedRefreshToken(
+	ctx context.Context,
+	log *slog.Logger,
+	refreshTokenID string,
+) (dto.DataForRefreshTokens, error) {
+	const op = "repository.auth.dataForReusedRefreshToken"
+
+	consumedRefreshToken, err := a.storage.ConsumedRefreshTokenByID(ctx, refreshTokenID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("consumed refresh token not found", sl.Err(err))
+		} else {
+			log.Error("error getting consumed refresh token", sl.Err(err))
+		}
+
+		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	familySessions, err := a.storage.SessionsByFamilyID(ctx, consumedRefreshToken.FamilyID)
+	if err != nil {
+		log.Error("error getting token family sessions", sl.Err(err))
+
+		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO := make([]dto.Session, len(familySessions))
+	for i, familySession := range familySessions {
+		sessionsDTO[i] = converter.ToSessionDTO(familySession)
+	}
+
+	userDTO, err := a.user(ctx, log, consumedRefreshToken.UserID)
+	if err != nil {
+		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForRefreshTokens{
+		User:                 userDTO,
+		ConsumedRefreshToken: converter.ToConsumedRefreshTokenDTO(consumedRefreshToken),
+		FamilySessions:       sessionsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForLogout(ctx context.Context, refreshTokenID string) (dto.DataForLogout, error) {
+	const op = "repository.auth.DataForLogout"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("refresh token ID", refreshTokenID),
+	)
+
+	sessionDTO, err := a.sessionByRefreshTokenID(ctx, log, refreshTokenID)
+	if err != nil {
+		return dto.DataForLogout{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForLogout{
+		Session: sessionDTO,
+	}, nil
+}
+
+func (a *Auth) UserSessions(ctx context.Context, userID int64) ([]dto.Session, error) {
+	const op = "repository.auth.UserSessions"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessionsDTO, nil
+}
+
+func (a *Auth) DataForRevokeSession(ctx context.Context, sessionID int64) (dto.DataForRevokeSession, error) {
+	const op = "repository.auth.DataForRevokeSession"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("session ID", sessionID),
+	)
+
+	session, err := a.storage.Session(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("session not found", sl.Err(err))
+		} else {
+			log.Error("error getting session", sl.Err(err))
+		}
+
+		return dto.DataForRevokeSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForRevokeSession{
+		Session: converter.ToSessionDTO(session),
+	}, nil
+}
+
+func (a *Auth) DataForRevokeAllSessions(ctx context.Context, userID int64) (dto.DataForRevokeAllSessions, error) {
+	const op = "repository.auth.DataForRevokeAllSessions"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userID)
+	if err != nil {
+		return dto.DataForRevokeAllSessions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForRevokeAllSessions{
+		Sessions: sessionsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForChangePassword(
+	ctx context.Context,
+	username, ipAddress string,
+) (dto.DataForChangePassword, error) {
+	const op = "repository.auth.DataForChangePassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+		slog.String("IP address", ipAddress),
+	)
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForChangePassword{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForChangePassword{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loginAttemptsDTO, err := a.loginAttempts(ctx, log, username, ipAddress)
+	if err != nil {
+		return dto.DataForChangePassword{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForChangePassword{
+		User:          userDTO,
+		Sessions:      sessionsDTO,
+		LoginAttempts: loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForRequestPasswordReset(
+	ctx context.Context,
+	username string,
+) (dto.DataForRequestPasswordReset, error) {
+	const op = "repository.auth.DataForRequestPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+	)
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForRequestPasswordReset{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForRequestPasswordReset{
+		User: userDTO,
+	}, nil
+}
+
+func (a *Auth) DataForConfirmPasswordReset(
+	ctx context.Context,
+	tokenHash string,
+) (dto.DataForConfirmPasswordReset, error) {
+	const op = "repository.auth.DataForConfirmPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	token, err := a.storage.PasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("password reset token not found", sl.Err(err))
+		} else {
+			log.Error("error getting password reset token", sl.Err(err))
+		}
+
+		return dto.DataForConfirmPasswordReset{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, token.UserID)
+	if err != nil {
+		return dto.DataForConfirmPasswordReset{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForConfirmPasswordReset{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForConfirmPasswordReset{
+		PasswordResetToken: converter.ToPasswordResetTokenDTO(token),
+		User:               userDTO,
+		Sessions:           sessionsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForMFA(ctx context.Context, username, ipAddress string) (dto.DataForMFA, error) {
+	const op = "repository.auth.DataForMFA"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+		slog.String("IP address", ipAddress),
+	)
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mfaDTO, err := a.userMFA(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loginAttemptsDTO, err := a.loginAttempts(ctx, log, username, ipAddress)
+	if err != nil {
+		return dto.DataForMFA{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForMFA{
+		User:          userDTO,
+		MFA:           mfaDTO,
+		LoginAttempts: loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForBeginWebAuthnRegistration(
+	ctx context.Context,
+	username, ipAddress string,
+) (dto.DataForWebAuthnRegistration, error) {
+	const op = "repository.auth.DataForBeginWebAuthnRegistration"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+		slog.String("IP address", ipAddress),
+	)
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	credentialsDTO, err := a.webAuthnCredentials(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	loginAttemptsDTO, err := a.loginAttempts(ctx, log, username, ipAddress)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForWebAuthnRegistration{
+		User:                userDTO,
+		WebAuthnCredentials: credentialsDTO,
+		LoginAttempts:       loginAttemptsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForFinishWebAuthnRegistration(
+	ctx context.Context,
+	challenge string,
+) (dto.DataForWebAuthnRegistration, error) {
+	const op = "repository.auth.DataForFinishWebAuthnRegistration"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	challengeDTO, err := a.webAuthnChallenge(ctx, log, challenge)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, challengeDTO.UserID)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	credentialsDTO, err := a.webAuthnCredentials(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForWebAuthnRegistration{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForWebAuthnRegistration{
+		WebAuthnChallenge:   challengeDTO,
+		User:                userDTO,
+		WebAuthnCredentials: credentialsDTO,
+	}, nil
+}
+
+func (a *Auth) DataForBeginWebAuthnLogin(
+	ctx context.Context,
+	username, clientCode string,
+) (dto.DataForBeginWebAuthnLogin, error) {
+	const op = "repository.auth.DataForBeginWebAuthnLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+		slog.String("client code", clientCode),
+	)
+
+	clientDTO, err := a.ClientByCode(ctx, clientCode)
+	if err != nil {
+		return dto.DataForBeginWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The login may be started without the username, then any discoverable credential is accepted.
+	if username == "" {
+		return dto.DataForBeginWebAuthnLogin{
+			Client: clientDTO,
+		}, nil
+	}
+
+	userDTO, err := a.userByUsername(ctx, log, username)
+	if err != nil {
+		return dto.DataForBeginWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	credentialsDTO, err := a.webAuthnCredentials(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForBeginWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForBeginWebAuthnLogin{
+		User:                userDTO,
+		WebAuthnCredentials: credentialsDTO,
+		Client:              clientDTO,
+	}, nil
+}
+
+func (a *Auth) DataForFinishWebAuthnLogin(
+	ctx context.Context,
+	challenge, credentialID string,
+) (dto.DataForFinishWebAuthnLogin, error) {
+	const op = "repository.auth.DataForFinishWebAuthnLogin"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("credential ID", credentialID),
+	)
+
+	challengeDTO, err := a.webAuthnChallenge(ctx, log, challenge)
+	if err != nil {
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	credential, err := a.storage.WebAuthnCredential(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("WebAuthn credential not found", sl.Err(err))
+		} else {
+			log.Error("error getting WebAuthn credential", sl.Err(err))
+		}
+
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := a.user(ctx, log, credential.UserID)
+	if err != nil {
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	client, err := a.storage.Client(ctx, challengeDTO.ClientID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting client", sl.Err(err))
+		}
+
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The user must have access to the client.
+	client, err = a.storage.ClientByCodeAndUserID(ctx, client.Code, userDTO.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user client not found", sl.Err(err))
+		} else {
+			log.Error("error getting user client", sl.Err(err))
+		}
+
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	clientAudiences, err := a.storage.ClientAudiences(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client audiences", sl.Err(err))
+
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO, err := a.sessionsByUserID(ctx, log, userDTO.ID)
+	if err != nil {
+		return dto.DataForFinishWebAuthnLogin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForFinishWebAuthnLogin{
+		WebAuthnChallenge:  challengeDTO,
+		WebAuthnCredential: converter.ToWebAuthnCredentialDTO(credential),
+		User:               userDTO,
+		Client:             converter.ToClientDTO(client, clientAudiences),
+		Sessions:           sessionsDTO,
+	}, nil
+}
+
+// InTransaction executes the function as a unit of work. All data saved by the function
+// is committed if the function succeeds, or rolled back if it returns an error.
+func (a *Auth) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "repository.auth.InTransaction"
+
+	if err := a.storage.Transaction(ctx, fn); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Save saves all changes of the auth entity in one transaction.
+// If saving of any change fails, none of the changes are saved.
+// If the context carries the transaction of the unit of work, the changes are saved in that transaction.
+func (a *Auth) Save(ctx context.Context, auth *entity.Auth) error {
+	const op = "repository.auth.Save"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	err := a.storage.Transaction(ctx, func(ctx context.Context) error {
+		if err := a.SaveUser(ctx, &auth.User, auth.ClientID()); err != nil {
+			log.Error("error saving user", sl.Err(err))
+
+			return err
+		}
+
+		// Authorization codes are saved before the sessions, so a session is not created for the code
+		// which has been redeemed concurrently.
+		for i := range auth.AuthorizationCodes {
+			if err := a.SaveAuthorizationCode(ctx, &auth.AuthorizationCodes[i]); err != nil {
+				log.Error("error saving authorization code", sl.Err(err))
+
+				return err
+			}
+		}
+
+		// Password reset tokens are saved before the sessions, so the sessions are not revoked
+		// by the token which has been redeemed concurrently.
+		for i := range auth.PasswordResetTokens {
+			if err := a.SavePasswordResetToken(ctx, &auth.PasswordResetTokens[i]); err != nil {
+				log.Error("error saving password reset token", sl.Err(err))
+
+				return err
+			}
+		}
+
+		if err := a.SaveTOTPCredential(ctx, &auth.TOTP); err != nil {
+			log.Error("error saving TOTP credential", sl.Err(err))
+
+			return err
+		}
+
+		// Backup codes and MFA challenges are saved before the sessions, so a session is not created
+		// for the backup code or the challenge which has been used concurrently.
+		for i := range auth.BackupCodes {
+			if err := a.SaveBackupCode(ctx, &auth.BackupCodes[i]); err != nil {
+				log.Error("error saving backup code", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range auth.MFAChallenges {
+			if err := a.SaveMFAChallenge(ctx, &auth.MFAChallenges[i]); err != nil {
+				log.Error("error saving MFA challenge", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range auth.WebAuthnCredentials {
+			if err := a.SaveWebAuthnCredential(ctx, &auth.WebAuthnCredentials[i]); err != nil {
+				log.Error("error saving WebAuthn credential", sl.Err(err))
+
+				return err
+			}
+		}
+
+		// WebAuthn challenges are saved before the sessions, so a session is not created for the challenge
+		// which has been used concurrently.
+		for i := range auth.WebAuthnChallenges {
+			if err := a.SaveWebAuthnChallenge(ctx, &auth.WebAuthnChallenges[i]); err != nil {
+				log.Error("error saving WebAuthn challenge", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range auth.LoginAttempts {
+			if err := a.SaveLoginAttempt(ctx, &auth.LoginAttempts[i]); err != nil {
+				log.Error("error saving login attempt", sl.Err(err))
+
+				return err
+			}
+		}
+
+		// Consumed refresh tokens are saved before the sessions, so a session is not created for the refresh token
+		// which has been rotated concurrently.
+		for i := range auth.ConsumedRefreshTokens {
+			if err := a.SaveConsumedRefreshToken(ctx, &auth.ConsumedRefreshTokens[i]); err != nil {
+				log.Error("error saving consumed refresh token", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range auth.Sessions {
+			if err := a.SaveSession(ctx, &auth.Sessions[i]); err != nil {
+				log.Error("error saving session", sl.Err(err))
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *Auth) SaveUser(ctx context.Context, user *entity.User, clientID int64) error {
+	const op = "repository.auth.SaveUser"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if user.IsToCreate() {
+		if err := a.createUser(ctx, user); err != nil {
+			log.Error("error creating user", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := a.createUserClientLink(ctx, user.ID, clientID); err != nil {
+			log.Error("error creating user client link", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, role := range user.Roles {
+			if err := a.createUserRoleLink(ctx, user.ID, role.ID); err != nil {
+				log.Error("error creating user role link", sl.Err(err))
+
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if user.IsToUpdate() {
+		if err := a.updateUser(ctx, user); err != nil {
+			log.Error("error updating user", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if user.IsToRemove() {
+		if err := a.removeUser(ctx, user); err != nil {
+			log.Error("error removing user", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createUser(ctx context.Context, user *entity.User) error {
+	userStorageModel := converter.ToUserStorage(user, models.UserCreated())
+
+	id, err := a.storage.CreateUser(ctx, userStorageModel)
+	if err != nil {
+		return err
+	}
+
+	user.ID = id
+	user.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) updateUser(ctx context.Context, user *entity.User) error {
+	if user.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
+
+	userStorageModel := converter.ToUserStorage(user, models.UserUpdated())
+
+	// The auth entity changes only the credentials of the existing user.
+	err := a.storage.UpdateUserPassword(ctx, userStorageModel)
+	if err != nil {
+		return err
+	}
+
+	user.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) removeUser(ctx context.Context, user *entity.User) error {
+	if user.ID == emptyID {
+		return infrastructure.ErrRequireIDToRemove
+	}
+
+	userStorageModel := converter.ToUserStorage(user, models.UserRemoved())
+
+	err := a.storage.RemoveUser(ctx, userStorageModel)
+	if err != nil {
+		return err
+	}
+
+	user.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) createUserClientLink(ctx context.Context, userID, clientID int64) error {
+	if userID == emptyID || clientID == emptyID {
+		return infrastructure.ErrRequireIDToCreateLink
+	}
+
+	userClientLinkStorageModel := converter.ToUserClientLinkStorage(userID, clientID, models.UserClientLinkCreated())
+
+	_, err := a.storage.CreateUserClientLink(ctx, userClientLinkStorageModel)
+
+	return err
+}
+
+func (a *Auth) createUserRoleLink(ctx context.Context, userID, roleID int64) error {
+	if userID == emptyID || roleID == emptyID {
+		return infrastructure.ErrRequireIDToCreateLink
+	}
+
+	userRoleLinkStorageModel := converter.ToUserRoleLinkStorage(userID, roleID, models.UserRoleLinkCreated())
+
+	_, err := a.storage.CreateUserRoleLink(ctx, userRoleLinkStorageModel)
+
+	return err
+}
+
+func (a *Auth) SaveSession(ctx context.Context, session *entity.Session) error {
+	const op = "repository.auth.SaveSession"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if session.IsToCreate() {
+		if err := a.createSession(ctx, session); err != nil {
+			log.Error("error creating session", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if session.IsToUpdate() {
+		if err := a.updateSession(ctx, session); err != nil {
+			log.Error("error updating session", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if session.IsToRemove() {
+		if err := a.removeSession(ctx, session); err != nil {
+			log.Error("error removing session", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createSession(ctx context.Context, session *entity.Session) error {
+	sessionStorageModel := converter.ToSessionStorage(session, models.SessionCreated())
+
+	id, err := a.storage.CreateSession(ctx, sessionStorageModel)
+	if err != nil {
+		return err
+	}
+
+	session.ID = id
+	session.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) updateSession(ctx context.Context, session *entity.Session) error {
+	if session.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
+
+	sessionStorageModel := converter.ToSessionStorage(session, models.SessionUpdated())
+
+	err := a.storage.UpdateSession(ctx, sessionStorageModel)
+	if err != nil {
+		return err
+	}
+
+	session.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) removeSession(ctx context.Context, session *entity.Session) error {
+	if session.ID == emptyID {
+		return infrastructure.ErrRequireIDToRemove
+	}
+
+	err := a.storage.RemoveSession(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+
+	session.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SaveConsumedRefreshToken(ctx context.Context, token *entity.ConsumedRefreshToken) error {
+	const op = "repository.auth.SaveConsumedRefreshToken"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if token.IsToCreate() {
+		if err := a.createConsumedRefreshToken(ctx, token); err != nil {
+			log.Error("error creating consumed refresh token", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createConsumedRefreshToken(ctx context.Context, token *entity.ConsumedRefreshToken) error {
+	tokenStorageModel := converter.ToConsumedRefreshTokenStorage(token, models.ConsumedRefreshTokenCreated())
+
+	id, err := a.storage.CreateConsumedRefreshToken(ctx, tokenStorageModel)
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	token.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SaveAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) error {
+	const op = "repository.auth.SaveAuthorizationCode"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if code.IsToCreate() {
+		if err := a.createAuthorizationCode(ctx, code); err != nil {
+			log.Error("error creating authorization code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if code.IsToUpdate() {
+		if err := a.updateAuthorizationCode(ctx, code); err != nil {
+			log.Error("error updating authorization code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) error {
+	codeStorageModel := converter.ToAuthorizationCodeStorage(code, models.AuthorizationCodeCreated())
+
+	id, err := a.storage.CreateAuthorizationCode(ctx, codeStorageModel)
+	if err != nil {
+		return err
+	}
+
+	code.ID = id
+	code.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) updateAuthorizationCode(ctx context.Context, code *entity.AuthorizationCode) error {
+	if code.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
+
+	codeStorageModel := converter.ToAuthorizationCodeStorage(code, models.AuthorizationCodeUpdated())
+
+	err := a.storage.UpdateAuthorizationCode(ctx, codeStorageModel)
+	if err != nil {
+		return err
+	}
+
+	code.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SavePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	const op = "repository.auth.SavePasswordResetToken"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if token.IsToCreate() {
+		if err := a.createPasswordResetToken(ctx, token); err != nil {
+			log.Error("error creating password reset token", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if token.IsToUpdate() {
+		if err := a.updatePasswordResetToken(ctx, token); err != nil {
+			log.Error("error updating password reset token", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createPasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	tokenStorageModel := converter.ToPasswordResetTokenStorage(token, models.PasswordResetTokenCreated())
+
+	id, err := a.storage.CreatePasswordResetToken(ctx, tokenStorageModel)
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	token.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) updatePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	if token.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
+
+	tokenStorageModel := converter.ToPasswordResetTokenStorage(token, models.PasswordResetTokenUpdated())
+
+	err := a.storage.UpdatePasswordResetToken(ctx, tokenStorageModel)
+	if err != nil {
+		return err
+	}
+
+	token.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SaveTOTPCredential(ctx context.Context, credential *entity.TOTPCredential) error {
+	const op = "repository.auth.SaveTOTPCredential"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if credential.IsToCreate() {
+		if err := a.createTOTPCredential(ctx, credential); err != nil {
+			log.Error("error creating TOTP credential", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if credential.IsToUpdate() {
+		if err := a.updateTOTPCredential(ctx, credential); err != nil {
+			log.Error("error updating TOTP credential", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if credential.IsToRemove() {
+		if err := a.removeTOTPCredential(ctx, credential); err != nil {
+			log.Error("error removing TOTP credential", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Auth) createTOTPCredential(ctx context.Context, credential *entity.TOTPCredential) error {
+	credentialStorageModel := converter.ToTOTPCredentialStorage(credential, models.TOTPCredentialCreated())
+
+	id, err := a.storage.CreateTOTPCredential(ctx, credentialStorageModel)
+	if err != nil {
+		return err
+	}
+
+	credential.ID = id
+	credential.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) updateTOTPCredential(ctx context.Context, credential *entity.TOTPCredential) error {
+	if credential.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
+
+	credentialStorageModel := converter.ToTOTPCredentialStorage(credential, models.TOTPCredentialUpdated())
+
+	if err := a.storage.UpdateTOTPCredential(ctx, credentialStorageModel); err != nil {
+		return err
+	}
+
+	credential.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) removeTOTPCredential(ctx context.Context, credential *entity.TOTPCredential) error {
+	if credential.ID == emptyID {
+		return infrastructure.ErrRequireIDToRemove
+	}
+
+	if err := a.storage.RemoveTOTPCredential(ctx, credential.ID); err != nil {
+		return err
+	}
+
+	credential.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SaveBackupCode(ctx context.Context, code *entity.BackupCode) error {
+	const op = "repository.auth.SaveBackupCode"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if code.IsToCreate() {
+		if err := a.createBackupCode(ctx, code); err != nil {
+			log.Error("error creating backup code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if code.IsToUpdate() {
+		if err := a.updateBackupCode(ctx, code); err != nil {
+			log.Error("error updating backup code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	rolesDTO := make([]dto.Role, len(roles))
-	for i, role := range roles {
-		rolesDTO[i] = converter.ToRoleDTO(role)
+	if code.IsToRemove() {
+		if err := a.removeBackupCode(ctx, code); err != nil {
+			log.Error("error removing backup code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	roleCodes := converter.ToRoleCodes(roles)
+	return nil
+}
 
-	permissions, err := a.storage.PermissionsByRoleCodes(ctx, roleCodes)
+func (a *Auth) createBackupCode(ctx context.Context, code *entity.BackupCode) error {
+	codeStorageModel := converter.ToBackupCodeStorage(code, models.BackupCodeCreated())
+
+	id, err := a.storage.CreateBackupCode(ctx, codeStorageModel)
 	if err != nil {
-		return dto.DataForRegister{}, fmt.Errorf("%s: %w", op, err)
+		return err
 	}
 
-	permissionCodes := converter.ToPermissionCodes(permissions)
+	code.ID = id
+	code.ResetDataStatus()
 
-	return dto.DataForRegister{
-		User:                         userDTO,
-		Client:                       clientDTO,
-		ClientDefaultRoles:           rolesDTO,
-		ClientDefaultPermissionCodes: permissionCodes,
-	}, nil
+	return nil
 }
 
-func (a *Auth) DataForRefreshTokens(ctx context.Context, refreshTokenID string) (dto.DataForRefreshTokens, error) {
-	const op = "repository.auth.DataForRefreshTokens"
+func (a *Auth) updateBackupCode(ctx context.Context, code *entity.BackupCode) error {
+	if code.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
 
-	log := a.log.With(
-		slog.String("op", op),
-		slog.String("refresh token ID", refreshTokenID),
-	)
+	codeStorageModel := converter.ToBackupCodeStorage(code, models.BackupCodeUpdated())
 
-	sessionDTO, err := a.sessionByRefreshTokenID(ctx, log, refreshTokenID)
-	if err != nil {
-		return dto.DataForRefreshTokens{}, fmt.Errorf("%s: %w", op, err)
+	if err := a.storage.UpdateBackupCode(ctx, codeStorageModel); err != nil {
+		return err
 	}
 
-	userDTO, err := a.user(ctx, log, sessionDTO.UserID)
+	code.ResetDataStatus()
 
-	return dto.DataForRefreshTokens{
-		Session: sessionDTO,
-		User:    userDTO,
-	}, nil
+	return nil
 }
 
-func (a *Auth) DataForLogout(ctx context.Context, refreshTokenID string) (dto.DataForLogout, error) {
-	const op = "repository.auth.DataForLogout"
-
-	log := a.log.With(
-		slog.String("op", op),
-		slog.String("refresh token ID", refreshTokenID),
-	)
+func (a *Auth) removeBackupCode(ctx context.Context, code *entity.BackupCode) error {
+	if code.ID == emptyID {
+		return infrastructure.ErrRequireIDToRemove
+	}
 
-	sessionDTO, err := a.sessionByRefreshTokenID(ctx, log, refreshTokenID)
-	if err != nil {
-		return dto.DataForLogout{}, fmt.Errorf("%s: %w", op, err)
+	if err := a.storage.RemoveBackupCode(ctx, code.ID); err != nil {
+		return err
 	}
 
-	return dto.DataForLogout{
-		Session: sessionDTO,
-	}, nil
+	code.ResetDataStatus()
+
+	return nil
 }
 
-func (a *Auth) Save(ctx context.Context, auth *entity.Auth) error {
-	const op = "repository.auth.Save"
+func (a *Auth) SaveMFAChallenge(ctx context.Context, challenge *entity.MFAChallenge) error {
+	const op = "repository.auth.SaveMFAChallenge"
 
 	log := a.log.With(
 		slog.String("op", op),
 	)
 
-	if err := a.SaveUser(ctx, &auth.User, auth.ClientID()); err != nil {
-		log.Error("error saving user", sl.Err(err))
+	if challenge.IsToCreate() {
+		if err := a.createMFAChallenge(ctx, challenge); err != nil {
+			log.Error("error creating MFA challenge", sl.Err(err))
 
-		return fmt.Errorf("%s: %w", op, err)
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	for i := range auth.Sessions {
-		if err := a.SaveSession(ctx, &auth.Sessions[i]); err != nil {
-			log.Error("error saving session", sl.Err(err))
+	if challenge.IsToUpdate() {
+		if err := a.updateMFAChallenge(ctx, challenge); err != nil {
+			log.Error("error updating MFA challenge", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
@@ -247,46 +1748,54 @@ func (a *Auth) Save(ctx context.Context, auth *entity.Auth) error {
 	return nil
 }
 
-func (a *Auth) SaveUser(ctx context.Context, user *entity.User, clientID int64) error {
-	const op = "repository.auth.SaveUser"
-
-	log := a.log.With(
-		slog.String("op", op),
-	)
+func (a *Auth) createMFAChallenge(ctx context.Context, challenge *entity.MFAChallenge) error {
+	challengeStorageModel := converter.ToMFAChallengeStorage(challenge, models.MFAChallengeCreated())
 
-	if user.IsToCreate() {
-		if err := a.createUser(ctx, user); err != nil {
-			log.Error("error creating user", sl.Err(err))
+	id, err := a.storage.CreateMFAChallenge(ctx, challengeStorageModel)
+	if err != nil {
+		return err
+	}
 
-			return fmt.Errorf("%s: %w", op, err)
-		}
+	challenge.ID = id
+	challenge.ResetDataStatus()
 
-		if err := a.createUserClientLink(ctx, user.ID, clientID); err != nil {
-			log.Error("error creating user client link", sl.Err(err))
+	return nil
+}
 
-			return fmt.Errorf("%s: %w", op, err)
-		}
+func (a *Auth) updateMFAChallenge(ctx context.Context, challenge *entity.MFAChallenge) error {
+	if challenge.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
+	}
 
-		for _, role := range user.Roles {
-			if err := a.createUserRoleLink(ctx, user.ID, role.ID); err != nil {
-				log.Error("error creating user role link", sl.Err(err))
+	challengeStorageModel := converter.ToMFAChallengeStorage(challenge, models.MFAChallengeUpdated())
 
-				return fmt.Errorf("%s: %w", op, err)
-			}
-		}
+	if err := a.storage.UpdateMFAChallenge(ctx, challengeStorageModel, challenge.MaxAttempts()); err != nil {
+		return err
 	}
 
-	if user.IsToUpdate() {
-		if err := a.updateUser(ctx, user); err != nil {
-			log.Error("error updating user", sl.Err(err))
+	challenge.ResetDataStatus()
+
+	return nil
+}
+
+func (a *Auth) SaveWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	const op = "repository.auth.SaveWebAuthnCredential"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if credential.IsToCreate() {
+		if err := a.createWebAuthnCredential(ctx, credential); err != nil {
+			log.Error("error creating WebAuthn credential", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	if user.IsToRemove() {
-		if err := a.removeUser(ctx, user); err != nil {
-			log.Error("error removing user", sl.Err(err))
+	if credential.IsToUpdate() {
+		if err := a.updateWebAuthnCredential(ctx, credential); err != nil {
+			log.Error("error updating WebAuthn credential", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
@@ -295,104 +1804,123 @@ func (a *Auth) SaveUser(ctx context.Context, user *entity.User, clientID int64)
 	return nil
 }
 
-func (a *Auth) createUser(ctx context.Context, user *entity.User) error {
-	userStorageModel := converter.ToUserStorage(user, models.UserCreated())
+func (a *Auth) createWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	credentialStorageModel := converter.ToWebAuthnCredentialStorage(credential, models.WebAuthnCredentialCreated())
 
-	id, err := a.storage.CreateUser(ctx, userStorageModel)
+	id, err := a.storage.CreateWebAuthnCredential(ctx, credentialStorageModel)
 	if err != nil {
 		return err
 	}
 
-	user.ID = id
-	user.ResetDataStatus()
+	credential.ID = id
+	credential.ResetDataStatus()
 
 	return nil
 }
 
-func (a *Auth) updateUser(ctx context.Context, user *entity.User) error {
-	if user.ID == emptyID {
+func (a *Auth) updateWebAuthnCredential(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	if credential.ID == emptyID {
 		return infrastructure.ErrRequireIDToUpdate
 	}
 
-	userStorageModel := converter.ToUserStorage(user, models.UserUpdated())
+	credentialStorageModel := converter.ToWebAuthnCredentialStorage(credential, models.WebAuthnCredentialUpdated())
 
-	err := a.storage.UpdateUser(ctx, userStorageModel)
-	if err != nil {
+	if err := a.storage.UpdateWebAuthnCredential(ctx, credentialStorageModel); err != nil {
 		return err
 	}
 
-	user.ResetDataStatus()
+	credential.ResetDataStatus()
 
 	return nil
 }
 
-func (a *Auth) removeUser(ctx context.Context, user *entity.User) error {
-	if user.ID == emptyID {
-		return infrastructure.ErrRequireIDToRemove
-	}
+func (a *Auth) SaveWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	const op = "repository.auth.SaveWebAuthnChallenge"
 
-	userStorageModel := converter.ToUserStorage(user, models.UserRemoved())
+	log := a.log.With(
+		slog.String("op", op),
+	)
 
-	err := a.storage.RemoveUser(ctx, userStorageModel)
-	if err != nil {
-		return err
+	if challenge.IsToCreate() {
+		if err := a.createWebAuthnChallenge(ctx, challenge); err != nil {
+			log.Error("error creating WebAuthn challenge", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	user.ResetDataStatus()
+	if challenge.IsToUpdate() {
+		if err := a.updateWebAuthnChallenge(ctx, challenge); err != nil {
+			log.Error("error updating WebAuthn challenge", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
 
 	return nil
 }
 
-func (a *Auth) createUserClientLink(ctx context.Context, userID, clientID int64) error {
-	if userID == emptyID || clientID == emptyID {
-		return infrastructure.ErrRequireIDToCreateLink
-	}
+func (a *Auth) createWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	challengeStorageModel := converter.ToWebAuthnChallengeStorage(challenge, models.WebAuthnChallengeCreated())
 
-	userClientLinkStorageModel := converter.ToUserClientLinkStorage(userID, clientID, models.UserClientLinkCreated())
+	id, err := a.storage.CreateWebAuthnChallenge(ctx, challengeStorageModel)
+	if err != nil {
+		return err
+	}
 
-	_, err := a.storage.CreateUserClientLink(ctx, userClientLinkStorageModel)
+	challenge.ID = id
+	challenge.ResetDataStatus()
 
-	return err
+	return nil
 }
 
-func (a *Auth) createUserRoleLink(ctx context.Context, userID, roleID int64) error {
-	if userID == emptyID || roleID == emptyID {
-		return infrastructure.ErrRequireIDToCreateLink
+func (a *Auth) updateWebAuthnChallenge(ctx context.Context, challenge *entity.WebAuthnChallenge) error {
+	if challenge.ID == emptyID {
+		return infrastructure.ErrRequireIDToUpdate
 	}
 
-	userRoleLinkStorageModel := converter.ToUserRoleLinkStorage(userID, roleID, models.UserRoleLinkCreated())
+	challengeStorageModel := converter.ToWebAuthnChallengeStorage(challenge, models.WebAuthnChallengeUpdated())
 
-	_, err := a.storage.CreateUserRoleLink(ctx, userRoleLinkStorageModel)
+	if err := a.storage.UpdateWebAuthnChallenge(ctx, challengeStorageModel); err != nil {
+		return err
+	}
 
-	return err
+	challenge.ResetDataStatus()
+
+	return nil
 }
 
-func (a *Auth) SaveSession(ctx context.Context, session *entity.Session) error {
-	const op = "repository.auth.SaveSession"
+func (a *Auth) SaveLoginAttempt(ctx context.Context, attempt *entity.LoginAttempt) error {
+	const op = "repository.auth.SaveLoginAttempt"
 
 	log := a.log.With(
 		slog.String("op", op),
+		slog.String("kind", string(attempt.Kind)),
 	)
 
-	if session.IsToCreate() {
-		if err := a.createSession(ctx, session); err != nil {
-			log.Error("error creating session", sl.Err(err))
+	// The attempt is created or updated only by the failed login.
+	if attempt.IsToCreate() || attempt.IsToUpdate() {
+		if err := a.failLoginAttempt(ctx, attempt); err != nil {
+			log.Error("error counting failed login attempt", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	if session.IsToUpdate() {
-		if err := a.updateSession(ctx, session); err != nil {
-			log.Error("error updating session", sl.Err(err))
+	// The failed login removes the expired attempts of its kind, the attempts are kept if the failures
+	// are never forgotten.
+	if windowStart := attempt.WindowStart(); !windowStart.IsZero() {
+		err := a.storage.RemoveExpiredLoginAttempts(ctx, string(attempt.Kind), windowStart, attempt.LastFailureAt)
+		if err != nil {
+			log.Error("error removing expired login attempts", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	if session.IsToRemove() {
-		if err := a.removeSession(ctx, session); err != nil {
-			log.Error("error removing session", sl.Err(err))
+	if attempt.IsToRemove() {
+		if err := a.removeLoginAttempt(ctx, attempt); err != nil {
+			log.Error("error removing login attempt", sl.Err(err))
 
 			return fmt.Errorf("%s: %w", op, err)
 		}
@@ -401,48 +1929,40 @@ func (a *Auth) SaveSession(ctx context.Context, session *entity.Session) error {
 	return nil
 }
 
-func (a *Auth) createSession(ctx context.Context, session *entity.Session) error {
-	sessionStorageModel := converter.ToSessionStorage(session, models.SessionCreated())
+// failLoginAttempt counts the failed login in the storage atomically, so the failures of the concurrent logins
+// are not lost. The counted failure keeps the attempt locked in the transaction, so the lock is saved
+// by the failures the storage has counted.
+func (a *Auth) failLoginAttempt(ctx context.Context, attempt *entity.LoginAttempt) error {
+	attemptStorageModel := converter.ToLoginAttemptStorage(attempt, models.LoginAttemptCreated())
 
-	id, err := a.storage.CreateSession(ctx, sessionStorageModel)
+	savedAttempt, err := a.storage.FailLoginAttempt(ctx, attemptStorageModel, attempt.WindowStart())
 	if err != nil {
 		return err
 	}
 
-	session.ID = id
-	session.ResetDataStatus()
-
-	return nil
-}
-
-func (a *Auth) updateSession(ctx context.Context, session *entity.Session) error {
-	if session.ID == emptyID {
-		return infrastructure.ErrRequireIDToUpdate
-	}
-
-	sessionStorageModel := converter.ToSessionStorage(session, models.SessionUpdated())
+	if attempt.CountSavedFailures(savedAttempt.ID, savedAttempt.Failures, savedAttempt.LockedUntil.ValueOrZero()) {
+		attemptStorageModel = converter.ToLoginAttemptStorage(attempt, models.LoginAttemptUpdated())
 
-	err := a.storage.UpdateSession(ctx, sessionStorageModel)
-	if err != nil {
-		return err
+		if err = a.storage.UpdateLoginAttempt(ctx, attemptStorageModel); err != nil {
+			return err
+		}
 	}
 
-	session.ResetDataStatus()
+	attempt.ResetDataStatus()
 
 	return nil
 }
 
-func (a *Auth) removeSession(ctx context.Context, session *entity.Session) error {
-	if session.ID == emptyID {
+func (a *Auth) removeLoginAttempt(ctx context.Context, attempt *entity.LoginAttempt) error {
+	if attempt.ID == emptyID {
 		return infrastructure.ErrRequireIDToRemove
 	}
 
-	err := a.storage.RemoveSession(ctx, session.ID)
-	if err != nil {
+	if err := a.storage.RemoveLoginAttempt(ctx, attempt.ID); err != nil {
 		return err
 	}
 
-	session.ResetDataStatus()
+	attempt.ResetDataStatus()
 
 	return nil
 }
@@ -523,3 +2043,115 @@ func (a *Auth) sessionByRefreshTokenID(ctx context.Context, log *slog.Logger, re
 
 	return sessionDTO, nil
 }
+
+func (a *Auth) sessionsByUserID(ctx context.Context, log *slog.Logger, userID int64) ([]dto.Session, error) {
+	userSessions, err := a.storage.SessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error getting user sessions", sl.Err(err))
+
+		return nil, err
+	}
+
+	sessionsDTO := make([]dto.Session, len(userSessions))
+	for i, userSession := range userSessions {
+		sessionsDTO[i] = converter.ToSessionDTO(userSession)
+	}
+
+	return sessionsDTO, nil
+}
+
+func (a *Auth) userMFA(ctx context.Context, log *slog.Logger, userID int64) (dto.UserMFA, error) {
+	credential, err := a.storage.TOTPCredential(ctx, userID)
+	if err != nil && !errors.Is(err, infrastructure.ErrEntityNotFound) {
+		log.Error("error getting user TOTP credential", sl.Err(err))
+
+		return dto.UserMFA{}, err
+	}
+
+	backupCodes, err := a.storage.BackupCodes(ctx, userID)
+	if err != nil {
+		log.Error("error getting user backup codes", sl.Err(err))
+
+		return dto.UserMFA{}, err
+	}
+
+	return converter.ToUserMFADTO(credential, backupCodes), nil
+}
+
+func (a *Auth) webAuthnCredentials(ctx context.Context, log *slog.Logger, userID int64) ([]dto.WebAuthnCredential, error) {
+	credentials, err := a.storage.WebAuthnCredentials(ctx, userID)
+	if err != nil {
+		log.Error("error getting user WebAuthn credentials", sl.Err(err))
+
+		return nil, err
+	}
+
+	credentialsDTO := make([]dto.WebAuthnCredential, len(credentials))
+	for i, credential := range credentials {
+		credentialsDTO[i] = converter.ToWebAuthnCredentialDTO(credential)
+	}
+
+	return credentialsDTO, nil
+}
+
+func (a *Auth) webAuthnChallenge(ctx context.Context, log *slog.Logger, challenge string) (dto.WebAuthnChallenge, error) {
+	webAuthnChallenge, err := a.storage.WebAuthnChallenge(ctx, challenge)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("WebAuthn challenge not found", sl.Err(err))
+		} else {
+			log.Error("error getting WebAuthn challenge", sl.Err(err))
+		}
+
+		return dto.WebAuthnChallenge{}, err
+	}
+
+	return converter.ToWebAuthnChallengeDTO(webAuthnChallenge), nil
+}
+
+// loginAttempts returns the saved failed login attempts of the username and the client IP address.
+// The address is optional.
+func (a *Auth) loginAttempts(ctx context.Context, log *slog.Logger, username, ipAddress string) ([]dto.LoginAttempt, error) {
+	kinds := []enum.LoginAttemptKindEnum{enum.LoginAttemptKindUsername, enum.LoginAttemptKindIP}
+	keys := []string{username, ipAddress}
+
+	var attempts []dto.LoginAttempt
+	for i, kind := range kinds {
+		key := keys[i]
+		if key == "" {
+			continue
+		}
+
+		attempt, found, err := a.loginAttempt(ctx, log, kind, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			attempts = append(attempts, attempt)
+		}
+	}
+
+	return attempts, nil
+}
+
+// loginAttempt returns the saved failed attempts of the kind and the key, if any.
+func (a *Auth) loginAttempt(
+	ctx context.Context,
+	log *slog.Logger,
+	kind enum.LoginAttemptKindEnum,
+	key string,
+) (dto.LoginAttempt, bool, error) {
+	attempt, err := a.storage.LoginAttempt(ctx, string(kind), key)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return dto.LoginAttempt{}, false, nil
+		}
+
+		log.Error("error getting login attempt", sl.Err(err))
+
+		return dto.LoginAttempt{}, false, err
+	}
+
+	return converter.ToLoginAttemptDTO(attempt), true, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+const (
+	stepCreateUser                 = "CreateUser"
+	stepCreateUserClientLink       = "CreateUserClientLink"
+	stepCreateUserRoleLink         = "CreateUserRoleLink"
+	stepUpdateAuthorizationCode    = "UpdateAuthorizationCode"
+	stepCreateConsumedRefreshToken = "CreateConsumedRefreshToken"
+	stepRemoveSession              = "RemoveSession"
+	stepCreateSession              = "CreateSession"
+)
+
+var errInjected = errors.New("injected failure")
+
+// transactionalStorage is the storage which keeps the changes made in a transaction until it is committed.
+// The storage fails at the step set up by failOn.
+type transactionalStorage struct {
+	Storage
+
+	failOn    string
+	inTx      bool
+	pending   []string
+	committed []string
+}
+
+func (s *transactionalStorage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.inTx {
+		return fn(ctx)
+	}
+
+	s.inTx = true
+	defer func() {
+		s.inTx = false
+		s.pending = nil
+	}()
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	s.committed = append(s.committed, s.pending...)
+
+	return nil
+}
+
+func (s *transactionalStorage) exec(step string) error {
+	if step == s.failOn {
+		return errInjected
+	}
+
+	if s.inTx {
+		s.pending = append(s.pending, step)
+	} else {
+		s.committed = append(s.committed, step)
+	}
+
+	return nil
+}
+
+func (s *transactionalStorage) CreateUser(_ context.Context, _ models.User) (int64, error) {
+	return 1, s.exec(stepCreateUser)
+}
+
+func (s *transactionalStorage) CreateUserClientLink(_ context.Context, _ models.UserClientLink) (int64, error) {
+	return 1, s.exec(stepCreateUserClientLink)
+}
+
+func (s *transactionalStorage) CreateUserRoleLink(_ context.Context, _ models.UserRoleLink) (int64, error) {
+	return 1, s.exec(stepCreateUserRoleLink)
+}
+
+func (s *transactionalStorage) UpdateAuthorizationCode(_ context.Context, _ models.AuthorizationCode) error {
+	return s.exec(stepUpdateAuthorizationCode)
+}
+
+func (s *transactionalStorage) CreateConsumedRefreshToken(
+	_ context.Context,
+	_ models.ConsumedRefreshToken,
+) (int64, error) {
+	return 1, s.exec(stepCreateConsumedRefreshToken)
+}
+
+func (s *transactionalStorage) RemoveSession(_ context.Context, _ int64) error {
+	return s.exec(stepRemoveSession)
+}
+
+func (s *transactionalStorage) CreateSession(_ context.Context, _ models.Session) (int64, error) {
+	return 2, s.exec(stepCreateSession)
+}
+
+func newTestAuth(t *testing.T) *entity.Auth {
+	t.Helper()
+
+	auth, err := entity.NewAuth(0, 0, entity.WithAuthClient(dto.Client{ID: 1}))
+	require.NoError(t, err)
+
+	auth.User = entity.NewUser("test", "test", nil, nil, nil, entity.WithUserRoles([]dto.Role{{ID: 1}}))
+	auth.User.SetToCreate()
+
+	authorizationCode := entity.AuthorizationCode{ID: 1}
+	authorizationCode.SetToUpdate()
+	auth.AuthorizationCodes = append(auth.AuthorizationCodes, authorizationCode)
+
+	oldSession, err := entity.NewSession(1, "test", "test", entity.WithSessionID(1))
+	require.NoError(t, err)
+	oldSession.SetToRemove()
+
+	consumedRefreshToken := entity.NewConsumedRefreshToken(oldSession)
+	consumedRefreshToken.SetToCreate()
+	auth.ConsumedRefreshTokens = append(auth.ConsumedRefreshTokens, consumedRefreshToken)
+
+	newSession, err := entity.NewSession(1, "test", "test")
+	require.NoError(t, err)
+	newSession.SetToCreate()
+
+	auth.Sessions = append(auth.Sessions, oldSession, newSession)
+
+	return &auth
+}
+
+func Test_Auth_Save(t *testing.T) {
+	allSteps := []string{
+		stepCreateUser,
+		stepCreateUserClientLink,
+		stepCreateUserRoleLink,
+		stepUpdateAuthorizationCode,
+		stepCreateConsumedRefreshToken,
+		stepRemoveSession,
+		stepCreateSession,
+	}
+
+	type testCase struct {
+		name              string
+		failOn            string
+		expectedError     error
+		expectedCommitted []string
+	}
+
+	testCases := []testCase{
+		{
+			name:              "commits all changes",
+			expectedCommitted: allSteps,
+		},
+	}
+	for _, step := range allSteps {
+		testCases = append(testCases, testCase{
+			name:          "rolls back all changes when " + step + " fails",
+			failOn:        step,
+			expectedError: errInjected,
+		})
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := &transactionalStorage{failOn: tc.failOn}
+			repo := NewAuthRepository(log, storage)
+
+			err := repo.Save(context.Background(), newTestAuth(t))
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedCommitted, storage.committed)
+		})
+	}
+}
+
+func Test_Auth_InTransaction(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	storage := &transactionalStorage{failOn: stepCreateSession}
+	repo := NewAuthRepository(log, storage)
+
+	auth := newTestAuth(t)
+	sessions := auth.Sessions
+	auth.Sessions = nil
+
+	err := repo.InTransaction(context.Background(), func(ctx context.Context) error {
+		if err := repo.Save(ctx, auth); err != nil {
+			return err
+		}
+
+		auth.Sessions = sessions
+
+		return repo.Save(ctx, auth)
+	})
+
+	assert.ErrorIs(t, err, errInjected)
+	assert.Empty(t, storage.committed)
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+type ClientStorage interface {
+	Transactor
+
+	Client(ctx context.Context, id int64) (models.Client, error)
+	Clients(ctx context.Context) ([]models.Client, error)
+	CreateClient(ctx context.Context, client models.Client) (int64, error)
+	UpdateClient(ctx context.Context, client models.Client) error
+
+	ClientAudiences(ctx context.Context, clientID int64) ([]models.Audience, error)
+	CreateAudience(ctx context.Context, audience models.Audience) (int64, error)
+	RemoveAudience(ctx context.Context, id int64) error
+
+	RolesByClientID(ctx context.Context, clientID int64) ([]models.Role, error)
+	RolesByCodes(ctx context.Context, codes []string) ([]models.Role, error)
+	CreateClientDefaultRoleLink(ctx context.Context, clientDefaultRoleLink models.ClientDefaultRoleLink) (int64, error)
+	RemoveClientDefaultRoleLinks(ctx context.Context, clientID int64) error
+}
+
+type Client struct {
+	log     *slog.Logger
+	storage ClientStorage
+}
+
+func NewClientRepository(log *slog.Logger, storage ClientStorage) *Client {
+	return &Client{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// ClientDetails returns the client with its audiences and default roles. Deleted clients are not found.
+func (c *Client) ClientDetails(ctx context.Context, id int64) (dto.ClientDetails, error) {
+	const op = "repository.client.ClientDetails"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", id),
+	)
+
+	client, err := c.storage.Client(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting client", sl.Err(err))
+		}
+
+		return dto.ClientDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.ClientDetails{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	clientDTO, err := c.clientDetails(ctx, log, client)
+	if err != nil {
+		return dto.ClientDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return clientDTO, nil
+}
+
+// Clients returns all clients which are not deleted.
+func (c *Client) Clients(ctx context.Context) ([]dto.ClientDetails, error) {
+	const op = "repository.client.Clients"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+
+	clients, err := c.storage.Clients(ctx)
+	if err != nil {
+		log.Error("error getting clients", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clientsDTO := make([]dto.ClientDetails, len(clients))
+	for i, client := range clients {
+		clientsDTO[i], err = c.clientDetails(ctx, log, client)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return clientsDTO, nil
+}
+
+// RolesByCodes returns the roles with the codes. Deleted roles are not returned.
+func (c *Client) RolesByCodes(ctx context.Context, codes []string) ([]dto.Role, error) {
+	const op = "repository.client.RolesByCodes"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+
+	roles, err := c.storage.RolesByCodes(ctx, codes)
+	if err != nil {
+		log.Error("error getting roles", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rolesDTO := make([]dto.Role, len(roles))
+	for i, role := range roles {
+		rolesDTO[i] = converter.ToRoleDTO(role)
+	}
+
+	return rolesDTO, nil
+}
+
+// Save saves all changes of the client entity, its audiences and default roles in one transaction.
+func (c *Client) Save(ctx context.Context, client *entity.Client) error {
+	const op = "repository.client.Save"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+
+	err := c.storage.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.saveClient(ctx, client); err != nil {
+			log.Error("error saving client", sl.Err(err))
+
+			return err
+		}
+
+		for i := range client.Audiences {
+			if err := c.saveAudience(ctx, client.ID, &client.Audiences[i]); err != nil {
+				log.Error("error saving client audience", sl.Err(err))
+
+				return err
+			}
+		}
+
+		if client.DefaultRolesChanged() {
+			if err := c.saveDefaultRoles(ctx, client); err != nil {
+				log.Error("error saving client default roles", sl.Err(err))
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) saveClient(ctx context.Context, client *entity.Client) error {
+	if client.IsToCreate() {
+		clientStorageModel := converter.ToClientStorage(client, models.ClientCreated())
+
+		id, err := c.storage.CreateClient(ctx, clientStorageModel)
+		if err != nil {
+			return err
+		}
+
+		client.ID = id
+		client.CreatedAt = clientStorageModel.CreatedAt
+		client.UpdatedAt = clientStorageModel.UpdatedAt
+		client.ResetDataStatus()
+	}
+
+	if client.IsToUpdate() || client.IsToRemove() {
+		if client.ID == emptyID {
+			return infrastructure.ErrRequireIDToUpdate
+		}
+
+		setter := models.ClientUpdated()
+		if client.IsToRemove() {
+			setter = models.ClientRemoved()
+		}
+
+		clientStorageModel := converter.ToClientStorage(client, setter)
+
+		if err := c.storage.UpdateClient(ctx, clientStorageModel); err != nil {
+			return err
+		}
+
+		client.UpdatedAt = clientStorageModel.UpdatedAt
+		client.ResetDataStatus()
+	}
+
+	return nil
+}
+
+func (c *Client) saveAudience(ctx context.Context, clientID int64, audience *entity.ClientAudience) error {
+	if audience.IsToCreate() {
+		if clientID == emptyID {
+			return infrastructure.ErrRequireIDToCreateLink
+		}
+
+		audienceStorageModel := converter.ToAudienceStorage(clientID, audience.URL, models.AudienceCreated())
+
+		id, err := c.storage.CreateAudience(ctx, audienceStorageModel)
+		if err != nil {
+			return err
+		}
+
+		audience.ID = id
+		audience.ResetDataStatus()
+	}
+
+	if audience.IsToRemove() {
+		if audience.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := c.storage.RemoveAudience(ctx, audience.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveDefaultRoles replaces the default role links of the client with the links to its current default roles.
+func (c *Client) saveDefaultRoles(ctx context.Context, client *entity.Client) error {
+	if client.ID == emptyID {
+		return infrastructure.ErrRequireIDToCreateLink
+	}
+
+	if err := c.storage.RemoveClientDefaultRoleLinks(ctx, client.ID); err != nil {
+		return err
+	}
+
+	for _, role := range client.DefaultRoles {
+		if role.ID == emptyID {
+			return infrastructure.ErrRequireIDToCreateLink
+		}
+
+		linkStorageModel := converter.ToClientDefaultRoleLinkStorage(
+			client.ID,
+			role.ID,
+			models.ClientDefaultRoleLinkCreated(),
+		)
+
+		if _, err := c.storage.CreateClientDefaultRoleLink(ctx, linkStorageModel); err != nil {
+			return err
+		}
+	}
+
+	client.ResetDefaultRolesChanged()
+
+	return nil
+}
+
+func (c *Client) clientDetails(ctx context.Context, log *slog.Logger, client models.Client) (dto.ClientDetails, error) {
+	clientAudiences, err := c.storage.ClientAudiences(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client audiences", sl.Err(err))
+
+		return dto.ClientDetails{}, err
+	}
+
+	clientRoles, err := c.storage.RolesByClientID(ctx, client.ID)
+	if err != nil {
+		log.Error("error getting client default roles", sl.Err(err))
+
+		return dto.ClientDetails{}, err
+	}
+
+	return converter.ToClientDetailsDTO(client, clientAudiences, clientRoles), nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+type PermissionStorage interface {
+	Permission(ctx context.Context, id int64) (models.Permission, error)
+	Permissions(ctx context.Context, limit, offset int) ([]models.Permission, error)
+	PermissionsCount(ctx context.Context) (int64, error)
+	CreatePermission(ctx context.Context, permission models.Permission) (int64, error)
+	UpdatePermission(ctx context.Context, permission models.Permission) error
+}
+
+type Permission struct {
+	log     *slog.Logger
+	storage PermissionStorage
+}
+
+func NewPermissionRepository(log *slog.Logger, storage PermissionStorage) *Permission {
+	return &Permission{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// PermissionDetails returns the permission. Deleted permissions are not found.
+func (p *Permission) PermissionDetails(ctx context.Context, id int64) (dto.PermissionDetails, error) {
+	const op = "repository.permission.PermissionDetails"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("permission ID", id),
+	)
+
+	permission, err := p.storage.Permission(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("permission not found", sl.Err(err))
+		} else {
+			log.Error("error getting permission", sl.Err(err))
+		}
+
+		return dto.PermissionDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if permission.Deleted {
+		log.Warn("permission is deleted")
+
+		return dto.PermissionDetails{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return converter.ToPermissionDetailsDTO(permission), nil
+}
+
+// Permissions returns the page of the permissions which are not deleted and the total number of such permissions.
+func (p *Permission) Permissions(ctx context.Context, limit, offset int) ([]dto.PermissionDetails, int64, error) {
+	const op = "repository.permission.Permissions"
+
+	log := p.log.With(
+		slog.String("op", op),
+	)
+
+	permissions, err := p.storage.Permissions(ctx, limit, offset)
+	if err != nil {
+		log.Error("error getting permissions", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := p.storage.PermissionsCount(ctx)
+	if err != nil {
+		log.Error("error getting permissions count", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	permissionsDTO := make([]dto.PermissionDetails, len(permissions))
+	for i, permission := range permissions {
+		permissionsDTO[i] = converter.ToPermissionDetailsDTO(permission)
+	}
+
+	return permissionsDTO, total, nil
+}
+
+// Save saves all changes of the permission entity.
+func (p *Permission) Save(ctx context.Context, permission *entity.Permission) error {
+	const op = "repository.permission.Save"
+
+	log := p.log.With(
+		slog.String("op", op),
+	)
+
+	if permission.IsToCreate() {
+		permissionStorageModel := converter.ToPermissionStorage(permission, models.PermissionCreated())
+
+		id, err := p.storage.CreatePermission(ctx, permissionStorageModel)
+		if err != nil {
+			log.Error("error creating permission", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		permission.ID = id
+		permission.CreatedAt = permissionStorageModel.CreatedAt
+		permission.UpdatedAt = permissionStorageModel.UpdatedAt
+		permission.ResetDataStatus()
+	}
+
+	if permission.IsToUpdate() || permission.IsToRemove() {
+		if permission.ID == emptyID {
+			return fmt.Errorf("%s: %w", op, infrastructure.ErrRequireIDToUpdate)
+		}
+
+		setter := models.PermissionUpdated()
+		if permission.IsToRemove() {
+			setter = models.PermissionRemoved()
+		}
+
+		permissionStorageModel := converter.ToPermissionStorage(permission, setter)
+
+		if err := p.storage.UpdatePermission(ctx, permissionStorageModel); err != nil {
+			log.Error("error updating permission", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		permission.UpdatedAt = permissionStorageModel.UpdatedAt
+		permission.ResetDataStatus()
+	}
+
+	return nil
+}
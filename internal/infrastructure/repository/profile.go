@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
 	"github.com/p1xray/pxr-sso/internal/infrastructure"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
 	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
@@ -18,7 +20,14 @@ type Profile struct {
 }
 
 type ProfileStorage interface {
+	Transactor
+
 	User(ctx context.Context, id int64) (models.User, error)
+	UpdateUserProfile(ctx context.Context, user models.User) error
+
+	VerificationCode(ctx context.Context, userID int64, channel string) (models.VerificationCode, error)
+	CreateVerificationCode(ctx context.Context, code models.VerificationCode) (int64, error)
+	UpdateVerificationCode(ctx context.Context, code models.VerificationCode) error
 }
 
 func NewProfileRepository(log *slog.Logger, storage ProfileStorage) *Profile {
@@ -51,3 +60,121 @@ func (p *Profile) UserProfile(ctx context.Context, id int64) (dto.UserProfile, e
 
 	return userDTO, nil
 }
+
+// Save saves the changed profile of the user to the storage.
+func (p *Profile) Save(ctx context.Context, user *entity.User) error {
+	const op = "repository.profile.Save"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", user.ID),
+	)
+
+	if !user.IsToUpdate() {
+		return nil
+	}
+
+	if user.ID == emptyID {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrRequireIDToUpdate)
+	}
+
+	userStorageModel := converter.ToUserStorage(user, models.UserUpdated())
+
+	if err := p.storage.UpdateUserProfile(ctx, userStorageModel); err != nil {
+		log.Error("error updating user profile", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.ResetDataStatus()
+
+	return nil
+}
+
+// DataForContactVerification returns the user profile and the verification code of the user contact of the channel.
+// The verification code is empty if no code has been requested for the contact yet.
+func (p *Profile) DataForContactVerification(
+	ctx context.Context,
+	userID int64,
+	channel enum.ContactChannelEnum,
+) (dto.DataForContactVerification, error) {
+	const op = "repository.profile.DataForContactVerification"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+		slog.String("channel", string(channel)),
+	)
+
+	userDTO, err := p.UserProfile(ctx, userID)
+	if err != nil {
+		return dto.DataForContactVerification{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	code, err := p.storage.VerificationCode(ctx, userID, string(channel))
+	if err != nil && !errors.Is(err, infrastructure.ErrEntityNotFound) {
+		log.Error("error getting verification code from storage", sl.Err(err))
+
+		return dto.DataForContactVerification{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.DataForContactVerification{
+		User:             userDTO,
+		VerificationCode: converter.ToVerificationCodeDTO(code),
+	}, nil
+}
+
+// SaveContactVerification saves the verification code and the verified contact of the user in one transaction.
+func (p *Profile) SaveContactVerification(ctx context.Context, verification *entity.ContactVerification) error {
+	const op = "repository.profile.SaveContactVerification"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", verification.User.ID),
+	)
+
+	err := p.storage.Transaction(ctx, func(ctx context.Context) error {
+		if err := p.saveVerificationCode(ctx, &verification.Code); err != nil {
+			log.Error("error saving verification code", sl.Err(err))
+
+			return err
+		}
+
+		return p.Save(ctx, &verification.User)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Profile) saveVerificationCode(ctx context.Context, code *entity.VerificationCode) error {
+	if code.IsToCreate() {
+		codeStorageModel := converter.ToVerificationCodeStorage(code, models.VerificationCodeCreated())
+
+		id, err := p.storage.CreateVerificationCode(ctx, codeStorageModel)
+		if err != nil {
+			return err
+		}
+
+		code.ID = id
+		code.ResetDataStatus()
+	}
+
+	if code.IsToUpdate() {
+		if code.ID == emptyID {
+			return infrastructure.ErrRequireIDToUpdate
+		}
+
+		codeStorageModel := converter.ToVerificationCodeStorage(code, models.VerificationCodeUpdated())
+
+		if err := p.storage.UpdateVerificationCode(ctx, codeStorageModel); err != nil {
+			return err
+		}
+
+		code.ResetDataStatus()
+	}
+
+	return nil
+}
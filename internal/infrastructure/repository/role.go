@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+type RoleStorage interface {
+	Transactor
+
+	Role(ctx context.Context, id int64) (models.Role, error)
+	Roles(ctx context.Context, limit, offset int) ([]models.Role, error)
+	RolesCount(ctx context.Context) (int64, error)
+	CreateRole(ctx context.Context, role models.Role) (int64, error)
+	UpdateRole(ctx context.Context, role models.Role) error
+
+	Permission(ctx context.Context, id int64) (models.Permission, error)
+	PermissionsByRoleID(ctx context.Context, roleID int64) ([]models.Permission, error)
+	CreateRolePermissionLink(ctx context.Context, rolePermissionLink models.RolePermissionLink) (int64, error)
+	RemoveRolePermissionLink(ctx context.Context, roleID, permissionID int64) error
+}
+
+type Role struct {
+	log     *slog.Logger
+	storage RoleStorage
+}
+
+func NewRoleRepository(log *slog.Logger, storage RoleStorage) *Role {
+	return &Role{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// RoleDetails returns the role with its permissions. Deleted roles are not found.
+func (r *Role) RoleDetails(ctx context.Context, id int64) (dto.RoleDetails, error) {
+	const op = "repository.role.RoleDetails"
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("role ID", id),
+	)
+
+	role, err := r.storage.Role(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+		} else {
+			log.Error("error getting role", sl.Err(err))
+		}
+
+		return dto.RoleDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if role.Deleted {
+		log.Warn("role is deleted")
+
+		return dto.RoleDetails{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	roleDTO, err := r.roleDetails(ctx, log, role)
+	if err != nil {
+		return dto.RoleDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roleDTO, nil
+}
+
+// Roles returns the page of the roles which are not deleted and the total number of such roles.
+func (r *Role) Roles(ctx context.Context, limit, offset int) ([]dto.RoleDetails, int64, error) {
+	const op = "repository.role.Roles"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	roles, err := r.storage.Roles(ctx, limit, offset)
+	if err != nil {
+		log.Error("error getting roles", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := r.storage.RolesCount(ctx)
+	if err != nil {
+		log.Error("error getting roles count", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rolesDTO := make([]dto.RoleDetails, len(roles))
+	for i, role := range roles {
+		rolesDTO[i], err = r.roleDetails(ctx, log, role)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return rolesDTO, total, nil
+}
+
+// PermissionDetails returns the permission. Deleted permissions are not found.
+func (r *Role) PermissionDetails(ctx context.Context, id int64) (dto.PermissionDetails, error) {
+	const op = "repository.role.PermissionDetails"
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("permission ID", id),
+	)
+
+	permission, err := r.storage.Permission(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("permission not found", sl.Err(err))
+		} else {
+			log.Error("error getting permission", sl.Err(err))
+		}
+
+		return dto.PermissionDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if permission.Deleted {
+		log.Warn("permission is deleted")
+
+		return dto.PermissionDetails{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return converter.ToPermissionDetailsDTO(permission), nil
+}
+
+// Save saves all changes of the role entity and its permissions in one transaction.
+func (r *Role) Save(ctx context.Context, role *entity.Role) error {
+	const op = "repository.role.Save"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	err := r.storage.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.saveRole(ctx, role); err != nil {
+			log.Error("error saving role", sl.Err(err))
+
+			return err
+		}
+
+		for i := range role.Permissions {
+			if err := r.saveRolePermission(ctx, role.ID, &role.Permissions[i]); err != nil {
+				log.Error("error saving role permission", sl.Err(err))
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Role) saveRole(ctx context.Context, role *entity.Role) error {
+	if role.IsToCreate() {
+		roleStorageModel := converter.ToRoleStorage(role, models.RoleCreated())
+
+		id, err := r.storage.CreateRole(ctx, roleStorageModel)
+		if err != nil {
+			return err
+		}
+
+		role.ID = id
+		role.CreatedAt = roleStorageModel.CreatedAt
+		role.UpdatedAt = roleStorageModel.UpdatedAt
+		role.ResetDataStatus()
+	}
+
+	if role.IsToUpdate() || role.IsToRemove() {
+		if role.ID == emptyID {
+			return infrastructure.ErrRequireIDToUpdate
+		}
+
+		setter := models.RoleUpdated()
+		if role.IsToRemove() {
+			setter = models.RoleRemoved()
+		}
+
+		roleStorageModel := converter.ToRoleStorage(role, setter)
+
+		if err := r.storage.UpdateRole(ctx, roleStorageModel); err != nil {
+			return err
+		}
+
+		role.UpdatedAt = roleStorageModel.UpdatedAt
+		role.ResetDataStatus()
+	}
+
+	return nil
+}
+
+func (r *Role) saveRolePermission(ctx context.Context, roleID int64, permission *entity.RolePermission) error {
+	if permission.IsToCreate() {
+		if roleID == emptyID || permission.ID == emptyID {
+			return infrastructure.ErrRequireIDToCreateLink
+		}
+
+		linkStorageModel := converter.ToRolePermissionLinkStorage(
+			roleID,
+			permission.ID,
+			models.RolePermissionLinkCreated(),
+		)
+
+		if _, err := r.storage.CreateRolePermissionLink(ctx, linkStorageModel); err != nil {
+			return err
+		}
+
+		permission.ResetDataStatus()
+	}
+
+	if permission.IsToRemove() {
+		if roleID == emptyID || permission.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := r.storage.RemoveRolePermissionLink(ctx, roleID, permission.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Role) roleDetails(ctx context.Context, log *slog.Logger, role models.Role) (dto.RoleDetails, error) {
+	permissions, err := r.storage.PermissionsByRoleID(ctx, role.ID)
+	if err != nil {
+		log.Error("error getting role permissions", sl.Err(err))
+
+		return dto.RoleDetails{}, err
+	}
+
+	return converter.ToRoleDetailsDTO(role, permissions), nil
+}
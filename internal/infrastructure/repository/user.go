@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+type UserStorage interface {
+	Transactor
+
+	User(ctx context.Context, id int64) (models.User, error)
+	Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]models.User, error)
+	UsersCount(ctx context.Context, query string, deleted bool) (int64, error)
+	UpdateUserStatus(ctx context.Context, user models.User) error
+	UpdateUserPassword(ctx context.Context, user models.User) error
+	RemoveUser(ctx context.Context, user models.User) error
+
+	Role(ctx context.Context, id int64) (models.Role, error)
+	UserRoles(ctx context.Context, userID int64) ([]models.Role, error)
+	CreateUserRoleLink(ctx context.Context, userRoleLink models.UserRoleLink) (int64, error)
+	RemoveUserRoleLink(ctx context.Context, userID, roleID int64) error
+
+	Client(ctx context.Context, id int64) (models.Client, error)
+	UserClients(ctx context.Context, userID int64) ([]models.Client, error)
+	CreateUserClientLink(ctx context.Context, userClientLink models.UserClientLink) (int64, error)
+	RemoveUserClientLink(ctx context.Context, userID, clientID int64) error
+
+	SessionsByUserID(ctx context.Context, userID int64) ([]models.Session, error)
+	RemoveSession(ctx context.Context, id int64) error
+
+	LoginAttempt(ctx context.Context, kind, key string) (models.LoginAttempt, error)
+	RemoveLoginAttempt(ctx context.Context, id int64) error
+}
+
+type User struct {
+	log     *slog.Logger
+	storage UserStorage
+}
+
+func NewUserRepository(log *slog.Logger, storage UserStorage) *User {
+	return &User{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// UserDetails returns the user with the roles and the clients of the user. Deleted users are found too,
+// so they can be restored.
+func (u *User) UserDetails(ctx context.Context, id int64) (dto.UserDetails, error) {
+	const op = "repository.user.UserDetails"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", id),
+	)
+
+	user, err := u.storage.User(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+		} else {
+			log.Error("error getting user", sl.Err(err))
+		}
+
+		return dto.UserDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userDTO, err := u.userDetails(ctx, log, user)
+	if err != nil {
+		return dto.UserDetails{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userDTO, nil
+}
+
+// Users returns the page of the users whose username or full name contains the query
+// and the total number of such users. If deleted is set, only deleted users are returned,
+// otherwise only users which are not deleted.
+func (u *User) Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]dto.UserDetails, int64, error) {
+	const op = "repository.user.Users"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("query", query),
+	)
+
+	users, err := u.storage.Users(ctx, query, deleted, limit, offset)
+	if err != nil {
+		log.Error("error getting users", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total, err := u.storage.UsersCount(ctx, query, deleted)
+	if err != nil {
+		log.Error("error getting users count", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	usersDTO := make([]dto.UserDetails, len(users))
+	for i, user := range users {
+		usersDTO[i], err = u.userDetails(ctx, log, user)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return usersDTO, total, nil
+}
+
+// UserSessions returns the sessions of the user.
+func (u *User) UserSessions(ctx context.Context, userID int64) ([]dto.Session, error) {
+	const op = "repository.user.UserSessions"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	sessions, err := u.storage.SessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error getting user sessions", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionsDTO := make([]dto.Session, len(sessions))
+	for i, session := range sessions {
+		sessionsDTO[i] = converter.ToSessionDTO(session)
+	}
+
+	return sessionsDTO, nil
+}
+
+// UserLoginAttempts returns the failed login attempts of the username.
+func (u *User) UserLoginAttempts(ctx context.Context, username string) ([]dto.LoginAttempt, error) {
+	const op = "repository.user.UserLoginAttempts"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.String("username", username),
+	)
+
+	attempt, err := u.storage.LoginAttempt(ctx, string(enum.LoginAttemptKindUsername), username)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return []dto.LoginAttempt{}, nil
+		}
+
+		log.Error("error getting login attempts", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []dto.LoginAttempt{converter.ToLoginAttemptDTO(attempt)}, nil
+}
+
+// Role returns the role. Deleted roles are not found.
+func (u *User) Role(ctx context.Context, id int64) (dto.Role, error) {
+	const op = "repository.user.Role"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.Int64("role ID", id),
+	)
+
+	role, err := u.storage.Role(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+		} else {
+			log.Error("error getting role", sl.Err(err))
+		}
+
+		return dto.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if role.Deleted {
+		log.Warn("role is deleted")
+
+		return dto.Role{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return converter.ToRoleDTO(role), nil
+}
+
+// Client returns the client. Deleted clients are not found.
+func (u *User) Client(ctx context.Context, id int64) (dto.Client, error) {
+	const op = "repository.user.Client"
+
+	log := u.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", id),
+	)
+
+	client, err := u.storage.Client(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+		} else {
+			log.Error("error getting client", sl.Err(err))
+		}
+
+		return dto.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if client.Deleted {
+		log.Warn("client is deleted")
+
+		return dto.Client{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return converter.ToClientDTO(client, nil), nil
+}
+
+// Save saves all changes of the user account entity, its roles, clients, sessions and login attempts
+// in one transaction.
+func (u *User) Save(ctx context.Context, account *entity.UserAccount) error {
+	const op = "repository.user.Save"
+
+	log := u.log.With(
+		slog.String("op", op),
+	)
+
+	err := u.storage.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.saveUserAccount(ctx, account); err != nil {
+			log.Error("error saving user", sl.Err(err))
+
+			return err
+		}
+
+		for i := range account.Roles {
+			if err := u.saveUserRole(ctx, account.ID, &account.Roles[i]); err != nil {
+				log.Error("error saving user role", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range account.Clients {
+			if err := u.saveUserClient(ctx, account.ID, &account.Clients[i]); err != nil {
+				log.Error("error saving user client", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range account.Sessions {
+			if err := u.saveUserSession(ctx, &account.Sessions[i]); err != nil {
+				log.Error("error saving user session", sl.Err(err))
+
+				return err
+			}
+		}
+
+		for i := range account.LoginAttempts {
+			if err := u.saveUserLoginAttempt(ctx, &account.LoginAttempts[i]); err != nil {
+				log.Error("error saving user login attempt", sl.Err(err))
+
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *User) saveUserAccount(ctx context.Context, account *entity.UserAccount) error {
+	if account.IsToUpdate() {
+		if account.ID == emptyID {
+			return infrastructure.ErrRequireIDToUpdate
+		}
+
+		userStorageModel := converter.ToUserAccountStorage(account, models.UserUpdated())
+
+		if err := u.storage.UpdateUserStatus(ctx, userStorageModel); err != nil {
+			return err
+		}
+
+		if account.PasswordChanged() {
+			if err := u.storage.UpdateUserPassword(ctx, userStorageModel); err != nil {
+				return err
+			}
+		}
+
+		account.UpdatedAt = userStorageModel.UpdatedAt
+		account.ResetDataStatus()
+	}
+
+	if account.IsToRemove() {
+		if account.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		userStorageModel := converter.ToUserAccountStorage(account, models.UserRemoved())
+
+		if err := u.storage.RemoveUser(ctx, userStorageModel); err != nil {
+			return err
+		}
+
+		account.UpdatedAt = userStorageModel.UpdatedAt
+		account.ResetDataStatus()
+	}
+
+	return nil
+}
+
+func (u *User) saveUserRole(ctx context.Context, userID int64, role *entity.UserRole) error {
+	if role.IsToCreate() {
+		if userID == emptyID || role.ID == emptyID {
+			return infrastructure.ErrRequireIDToCreateLink
+		}
+
+		linkStorageModel := converter.ToUserRoleLinkStorage(userID, role.ID, models.UserRoleLinkCreated())
+
+		if _, err := u.storage.CreateUserRoleLink(ctx, linkStorageModel); err != nil {
+			return err
+		}
+
+		role.ResetDataStatus()
+	}
+
+	if role.IsToRemove() {
+		if userID == emptyID || role.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := u.storage.RemoveUserRoleLink(ctx, userID, role.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *User) saveUserClient(ctx context.Context, userID int64, client *entity.UserClient) error {
+	if client.IsToCreate() {
+		if userID == emptyID || client.ID == emptyID {
+			return infrastructure.ErrRequireIDToCreateLink
+		}
+
+		linkStorageModel := converter.ToUserClientLinkStorage(userID, client.ID, models.UserClientLinkCreated())
+
+		if _, err := u.storage.CreateUserClientLink(ctx, linkStorageModel); err != nil {
+			return err
+		}
+
+		client.ResetDataStatus()
+	}
+
+	if client.IsToRemove() {
+		if userID == emptyID || client.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := u.storage.RemoveUserClientLink(ctx, userID, client.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *User) saveUserSession(ctx context.Context, session *entity.Session) error {
+	if session.IsToRemove() {
+		if session.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := u.storage.RemoveSession(ctx, session.ID); err != nil {
+			return err
+		}
+
+		session.ResetDataStatus()
+	}
+
+	return nil
+}
+
+func (u *User) saveUserLoginAttempt(ctx context.Context, attempt *entity.LoginAttempt) error {
+	if attempt.IsToRemove() {
+		if attempt.ID == emptyID {
+			return infrastructure.ErrRequireIDToRemove
+		}
+
+		if err := u.storage.RemoveLoginAttempt(ctx, attempt.ID); err != nil {
+			return err
+		}
+
+		attempt.ResetDataStatus()
+	}
+
+	return nil
+}
+
+func (u *User) userDetails(ctx context.Context, log *slog.Logger, user models.User) (dto.UserDetails, error) {
+	roles, err := u.storage.UserRoles(ctx, user.ID)
+	if err != nil {
+		log.Error("error getting user roles", sl.Err(err))
+
+		return dto.UserDetails{}, err
+	}
+
+	clients, err := u.storage.UserClients(ctx, user.ID)
+	if err != nil {
+		log.Error("error getting user clients", sl.Err(err))
+
+		return dto.UserDetails{}, err
+	}
+
+	return converter.ToUserDetailsDTO(user, roles, clients), nil
+}
@@ -0,0 +1,1706 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Storage provides access to in-memory storage.
+// The storage is safe for concurrent use, its data is lost when the process exits.
+type Storage struct {
+	mu   sync.RWMutex
+	data *data
+}
+
+// data is the tables of the in-memory storage.
+type data struct {
+	users                 table[models.User]
+	clients               table[models.Client]
+	userClients           table[models.UserClientLink]
+	roles                 table[models.Role]
+	permissions           table[models.Permission]
+	userRoles             table[models.UserRoleLink]
+	rolePermissions       table[models.RolePermissionLink]
+	clientDefaultRoles    table[models.ClientDefaultRoleLink]
+	clientPermissions     table[clientPermission]
+	audiences             table[models.Audience]
+	redirectURIs          table[models.RedirectURI]
+	sessions              table[models.Session]
+	consumedRefreshTokens table[models.ConsumedRefreshToken]
+	authorizationCodes    table[models.AuthorizationCode]
+	passwordResetTokens   table[models.PasswordResetToken]
+	verificationCodes     table[models.VerificationCode]
+	totpCredentials       table[models.TOTPCredential]
+	backupCodes           table[models.BackupCode]
+	mfaChallenges         table[models.MFAChallenge]
+	webAuthnCredentials   table[models.WebAuthnCredential]
+	webAuthnChallenges    table[models.WebAuthnChallenge]
+	loginAttempts         table[models.LoginAttempt]
+}
+
+type clientPermission struct {
+	ID           int64
+	ClientID     int64
+	PermissionID int64
+}
+
+// New creates a new instance of the in-memory store.
+func New() *Storage {
+	return &Storage{
+		data: &data{
+			users:                 newTable[models.User](),
+			clients:               newTable[models.Client](),
+			userClients:           newTable[models.UserClientLink](),
+			roles:                 newTable[models.Role](),
+			permissions:           newTable[models.Permission](),
+			userRoles:             newTable[models.UserRoleLink](),
+			rolePermissions:       newTable[models.RolePermissionLink](),
+			clientDefaultRoles:    newTable[models.ClientDefaultRoleLink](),
+			clientPermissions:     newTable[clientPermission](),
+			audiences:             newTable[models.Audience](),
+			redirectURIs:          newTable[models.RedirectURI](),
+			sessions:              newTable[models.Session](),
+			consumedRefreshTokens: newTable[models.ConsumedRefreshToken](),
+			authorizationCodes:    newTable[models.AuthorizationCode](),
+			passwordResetTokens:   newTable[models.PasswordResetToken](),
+			verificationCodes:     newTable[models.VerificationCode](),
+			totpCredentials:       newTable[models.TOTPCredential](),
+			backupCodes:           newTable[models.BackupCode](),
+			mfaChallenges:         newTable[models.MFAChallenge](),
+			webAuthnCredentials:   newTable[models.WebAuthnCredential](),
+			webAuthnChallenges:    newTable[models.WebAuthnChallenge](),
+			loginAttempts:         newTable[models.LoginAttempt](),
+		},
+	}
+}
+
+func (d *data) clone() *data {
+	return &data{
+		users:                 d.users.clone(),
+		clients:               d.clients.clone(),
+		userClients:           d.userClients.clone(),
+		roles:                 d.roles.clone(),
+		permissions:           d.permissions.clone(),
+		userRoles:             d.userRoles.clone(),
+		rolePermissions:       d.rolePermissions.clone(),
+		clientDefaultRoles:    d.clientDefaultRoles.clone(),
+		clientPermissions:     d.clientPermissions.clone(),
+		audiences:             d.audiences.clone(),
+		redirectURIs:          d.redirectURIs.clone(),
+		sessions:              d.sessions.clone(),
+		consumedRefreshTokens: d.consumedRefreshTokens.clone(),
+		authorizationCodes:    d.authorizationCodes.clone(),
+		passwordResetTokens:   d.passwordResetTokens.clone(),
+		verificationCodes:     d.verificationCodes.clone(),
+		totpCredentials:       d.totpCredentials.clone(),
+		backupCodes:           d.backupCodes.clone(),
+		mfaChallenges:         d.mfaChallenges.clone(),
+		webAuthnCredentials:   d.webAuthnCredentials.clone(),
+		webAuthnChallenges:    d.webAuthnChallenges.clone(),
+		loginAttempts:         d.loginAttempts.clone(),
+	}
+}
+
+func (s *Storage) User(ctx context.Context, id int64) (models.User, error) {
+	const op = "memory.User"
+
+	var user models.User
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		if user, ok = d.users.rows[id]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) UserByUsername(ctx context.Context, username string) (models.User, error) {
+	const op = "memory.UserByUsername"
+
+	var user models.User
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		user, ok = d.users.find(func(u models.User) bool {
+			return u.Username == username
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, user models.User) (int64, error) {
+	const op = "memory.CreateUser"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.users.exists(func(u models.User) bool { return u.Username == user.Username || sameContacts(u, user) }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		user.ID = d.users.nextID()
+		d.users.rows[user.ID] = user
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user.ID, nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, user models.User) error {
+	const op = "memory.UpdateUser"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.users.rows[user.ID]; !ok {
+			return nil
+		}
+
+		if d.users.exists(func(u models.User) bool {
+			return u.ID != user.ID && (u.Username == user.Username || sameContacts(u, user))
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		d.users.rows[user.ID] = user
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateUserProfile(ctx context.Context, user models.User) error {
+	const op = "memory.UpdateUserProfile"
+
+	err := s.write(ctx, func(d *data) error {
+		saved, ok := d.users.rows[user.ID]
+		if !ok {
+			return nil
+		}
+
+		if d.users.exists(func(u models.User) bool { return u.ID != user.ID && sameContacts(u, user) }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		saved.FullName = user.FullName
+		saved.DateOfBirth = user.DateOfBirth
+		saved.Gender = user.Gender
+		saved.AvatarFileKey = user.AvatarFileKey
+		saved.Email = user.Email
+		saved.EmailVerified = user.EmailVerified
+		saved.Phone = user.Phone
+		saved.PhoneVerified = user.PhoneVerified
+		saved.UpdatedAt = user.UpdatedAt
+		d.users.rows[user.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// sameContacts reports whether the users have the same verified email or phone. The unverified contacts
+// are never the same, so the contact can't be taken from its owner without the verification.
+func sameContacts(a, b models.User) bool {
+	return (a.EmailVerified && b.EmailVerified && a.Email.Valid && b.Email.Valid && a.Email.String == b.Email.String) ||
+		(a.PhoneVerified && b.PhoneVerified && a.Phone.Valid && b.Phone.Valid && a.Phone.String == b.Phone.String)
+}
+
+func (s *Storage) UpdateUserPassword(ctx context.Context, user models.User) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.users.rows[user.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.PasswordHash = user.PasswordHash
+		saved.MustChangePassword = user.MustChangePassword
+		saved.UpdatedAt = user.UpdatedAt
+		d.users.rows[user.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) RemoveUser(ctx context.Context, user models.User) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.users.rows[user.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.Deleted = user.Deleted
+		saved.UpdatedAt = user.UpdatedAt
+		d.users.rows[user.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) RolesByUserID(ctx context.Context, userID int64) ([]models.Role, error) {
+	roles := make([]models.Role, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.userRoles.filter(func(l models.UserRoleLink) bool { return l.UserID == userID }) {
+			if role, ok := d.roles.rows[link.RoleID]; ok && role.Active && !role.Deleted {
+				roles = append(roles, role)
+			}
+		}
+
+		return nil
+	})
+
+	return roles, err
+}
+
+func (s *Storage) RolesByClientID(ctx context.Context, clientID int64) ([]models.Role, error) {
+	roles := make([]models.Role, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.clientDefaultRoles.filter(func(l models.ClientDefaultRoleLink) bool { return l.ClientID == clientID }) {
+			if role, ok := d.roles.rows[link.RoleID]; ok && role.Active && !role.Deleted {
+				roles = append(roles, role)
+			}
+		}
+
+		return nil
+	})
+
+	return roles, err
+}
+
+func (s *Storage) PermissionsByUserID(ctx context.Context, userID int64) ([]models.Permission, error) {
+	permissions := make([]models.Permission, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, userRole := range d.userRoles.filter(func(l models.UserRoleLink) bool { return l.UserID == userID }) {
+			if role, ok := d.roles.rows[userRole.RoleID]; ok && role.Active && !role.Deleted {
+				permissions = append(permissions, d.activeRolePermissions(role.ID)...)
+			}
+		}
+
+		return nil
+	})
+
+	return permissions, err
+}
+
+func (s *Storage) PermissionsByClientID(ctx context.Context, clientID int64) ([]models.Permission, error) {
+	permissions := make([]models.Permission, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.clientPermissions.filter(func(l clientPermission) bool { return l.ClientID == clientID }) {
+			if permission, ok := d.permissions.rows[link.PermissionID]; ok && permission.Active && !permission.Deleted {
+				permissions = append(permissions, permission)
+			}
+		}
+
+		return nil
+	})
+
+	return permissions, err
+}
+
+func (s *Storage) PermissionsByRoleCodes(ctx context.Context, roleCodes []string) ([]models.Permission, error) {
+	permissions := make([]models.Permission, 0)
+	err := s.read(ctx, func(d *data) error {
+		roles := d.roles.filter(func(r models.Role) bool {
+			return r.Active && !r.Deleted && slices.Contains(roleCodes, r.Code)
+		})
+		for _, role := range roles {
+			permissions = append(permissions, d.activeRolePermissions(role.ID)...)
+		}
+
+		return nil
+	})
+
+	return permissions, err
+}
+
+// activeRolePermissions returns the active permissions of the role which are not deleted.
+func (d *data) activeRolePermissions(roleID int64) []models.Permission {
+	permissions := make([]models.Permission, 0)
+	for _, link := range d.rolePermissions.filter(func(l models.RolePermissionLink) bool { return l.RoleID == roleID }) {
+		if permission, ok := d.permissions.rows[link.PermissionID]; ok && permission.Active && !permission.Deleted {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+func (s *Storage) SessionsByUserID(ctx context.Context, userID int64) ([]models.Session, error) {
+	var sessions []models.Session
+	err := s.read(ctx, func(d *data) error {
+		sessions = d.sessions.filter(func(session models.Session) bool { return session.UserID == userID })
+
+		return nil
+	})
+
+	return sessions, err
+}
+
+func (s *Storage) Session(ctx context.Context, id int64) (models.Session, error) {
+	const op = "memory.Session"
+
+	var session models.Session
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		if session, ok = d.sessions.rows[id]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) SessionByRefreshTokenID(ctx context.Context, refreshTokenID string) (models.Session, error) {
+	const op = "memory.SessionByRefreshTokenID"
+
+	var session models.Session
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		session, ok = d.sessions.find(func(session models.Session) bool {
+			return session.RefreshToken == refreshTokenID
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) CreateSession(ctx context.Context, session models.Session) (int64, error) {
+	const op = "memory.CreateSession"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.sessions.exists(func(ss models.Session) bool { return ss.RefreshToken == session.RefreshToken }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		session.ID = d.sessions.nextID()
+		d.sessions.rows[session.ID] = session
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session.ID, nil
+}
+
+func (s *Storage) UpdateSession(ctx context.Context, session models.Session) error {
+	const op = "memory.UpdateSession"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.sessions.rows[session.ID]; !ok {
+			return nil
+		}
+
+		if d.sessions.exists(func(ss models.Session) bool {
+			return ss.ID != session.ID && ss.RefreshToken == session.RefreshToken
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		d.sessions.rows[session.ID] = session
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveSession(ctx context.Context, id int64) error {
+	return s.write(ctx, func(d *data) error {
+		delete(d.sessions.rows, id)
+
+		return nil
+	})
+}
+
+func (s *Storage) SessionsByFamilyID(ctx context.Context, familyID string) ([]models.Session, error) {
+	var sessions []models.Session
+	err := s.read(ctx, func(d *data) error {
+		sessions = d.sessions.filter(func(session models.Session) bool { return session.FamilyID == familyID })
+
+		return nil
+	})
+
+	return sessions, err
+}
+
+func (s *Storage) ConsumedRefreshTokenByID(
+	ctx context.Context,
+	refreshTokenID string,
+) (models.ConsumedRefreshToken, error) {
+	const op = "memory.ConsumedRefreshTokenByID"
+
+	var token models.ConsumedRefreshToken
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		token, ok = d.consumedRefreshTokens.find(func(t models.ConsumedRefreshToken) bool {
+			return t.RefreshToken == refreshTokenID
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.ConsumedRefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (s *Storage) CreateConsumedRefreshToken(ctx context.Context, token models.ConsumedRefreshToken) (int64, error) {
+	const op = "memory.CreateConsumedRefreshToken"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.consumedRefreshTokens.exists(func(t models.ConsumedRefreshToken) bool {
+			return t.RefreshToken == token.RefreshToken
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		token.ID = d.consumedRefreshTokens.nextID()
+		d.consumedRefreshTokens.rows[token.ID] = token
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token.ID, nil
+}
+
+func (s *Storage) ClientByCodeAndUserID(ctx context.Context, code string, userID int64) (models.Client, error) {
+	const op = "memory.ClientByCodeAndUserID"
+
+	var client models.Client
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		client, ok = d.clients.find(func(c models.Client) bool {
+			return c.Code == code && d.userClients.exists(func(l models.UserClientLink) bool {
+				return l.ClientID == c.ID && l.UserID == userID
+			})
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) ClientByCode(ctx context.Context, code string) (models.Client, error) {
+	const op = "memory.ClientByCode"
+
+	var client models.Client
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		client, ok = d.clients.find(func(c models.Client) bool { return c.Code == code })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) ClientAudiences(ctx context.Context, clientID int64) ([]models.Audience, error) {
+	var audiences []models.Audience
+	err := s.read(ctx, func(d *data) error {
+		audiences = d.audiences.filter(func(a models.Audience) bool { return a.ClientID == clientID })
+
+		return nil
+	})
+
+	return audiences, err
+}
+
+func (s *Storage) CreateUserClientLink(ctx context.Context, userClientLink models.UserClientLink) (int64, error) {
+	const op = "memory.CreateUserClientLink"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.userClients.exists(func(l models.UserClientLink) bool {
+			return l.UserID == userClientLink.UserID && l.ClientID == userClientLink.ClientID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		userClientLink.ID = d.userClients.nextID()
+		d.userClients.rows[userClientLink.ID] = userClientLink
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userClientLink.ID, nil
+}
+
+func (s *Storage) CreateUserRoleLink(ctx context.Context, userRoleLink models.UserRoleLink) (int64, error) {
+	const op = "memory.CreateUserRoleLink"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.userRoles.exists(func(l models.UserRoleLink) bool {
+			return l.UserID == userRoleLink.UserID && l.RoleID == userRoleLink.RoleID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		userRoleLink.ID = d.userRoles.nextID()
+		d.userRoles.rows[userRoleLink.ID] = userRoleLink
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userRoleLink.ID, nil
+}
+
+func (s *Storage) ClientRedirectURIs(ctx context.Context, clientID int64) ([]models.RedirectURI, error) {
+	var redirectURIs []models.RedirectURI
+	err := s.read(ctx, func(d *data) error {
+		redirectURIs = d.redirectURIs.filter(func(u models.RedirectURI) bool { return u.ClientID == clientID })
+
+		return nil
+	})
+
+	return redirectURIs, err
+}
+
+func (s *Storage) AuthorizationCodeByHash(ctx context.Context, codeHash string) (models.AuthorizationCode, error) {
+	const op = "memory.AuthorizationCodeByHash"
+
+	var code models.AuthorizationCode
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		code, ok = d.authorizationCodes.find(func(c models.AuthorizationCode) bool { return c.CodeHash == codeHash })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.AuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, nil
+}
+
+func (s *Storage) CreateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) (int64, error) {
+	const op = "memory.CreateAuthorizationCode"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.authorizationCodes.exists(func(c models.AuthorizationCode) bool { return c.CodeHash == code.CodeHash }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		code.ID = d.authorizationCodes.nextID()
+		d.authorizationCodes.rows[code.ID] = code
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code.ID, nil
+}
+
+func (s *Storage) UpdateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) error {
+	const op = "memory.UpdateAuthorizationCode"
+
+	err := s.write(ctx, func(d *data) error {
+		// The code can be redeemed only once, so the code which is already used is not found.
+		saved, ok := d.authorizationCodes.rows[code.ID]
+		if !ok || saved.Used {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Used = code.Used
+		saved.UpdatedAt = code.UpdatedAt
+		d.authorizationCodes.rows[code.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) PasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	const op = "memory.PasswordResetTokenByHash"
+
+	var token models.PasswordResetToken
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		token, ok = d.passwordResetTokens.find(func(t models.PasswordResetToken) bool { return t.TokenHash == tokenHash })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.PasswordResetToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (s *Storage) CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) (int64, error) {
+	const op = "memory.CreatePasswordResetToken"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.passwordResetTokens.exists(func(t models.PasswordResetToken) bool { return t.TokenHash == token.TokenHash }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		token.ID = d.passwordResetTokens.nextID()
+		d.passwordResetTokens.rows[token.ID] = token
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token.ID, nil
+}
+
+func (s *Storage) UpdatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error {
+	const op = "memory.UpdatePasswordResetToken"
+
+	err := s.write(ctx, func(d *data) error {
+		// The token can be redeemed only once, so the token which is already used is not found.
+		saved, ok := d.passwordResetTokens.rows[token.ID]
+		if !ok || saved.Used {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Used = token.Used
+		saved.UpdatedAt = token.UpdatedAt
+		d.passwordResetTokens.rows[token.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) VerificationCode(ctx context.Context, userID int64, channel string) (models.VerificationCode, error) {
+	const op = "memory.VerificationCode"
+
+	var code models.VerificationCode
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		code, ok = d.verificationCodes.find(func(c models.VerificationCode) bool {
+			return c.UserID == userID && c.Channel == channel
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.VerificationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, nil
+}
+
+func (s *Storage) CreateVerificationCode(ctx context.Context, code models.VerificationCode) (int64, error) {
+	const op = "memory.CreateVerificationCode"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.verificationCodes.exists(func(c models.VerificationCode) bool {
+			return c.UserID == code.UserID && c.Channel == code.Channel
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		code.ID = d.verificationCodes.nextID()
+		d.verificationCodes.rows[code.ID] = code
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code.ID, nil
+}
+
+func (s *Storage) UpdateVerificationCode(ctx context.Context, code models.VerificationCode) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.verificationCodes.rows[code.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.Target = code.Target
+		saved.CodeHash = code.CodeHash
+		saved.ExpiresAt = code.ExpiresAt
+		saved.Attempts = code.Attempts
+		saved.Used = code.Used
+		saved.UpdatedAt = code.UpdatedAt
+		d.verificationCodes.rows[code.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) TOTPCredential(ctx context.Context, userID int64) (models.TOTPCredential, error) {
+	const op = "memory.TOTPCredential"
+
+	var credential models.TOTPCredential
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		credential, ok = d.totpCredentials.find(func(c models.TOTPCredential) bool { return c.UserID == userID })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.TOTPCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential, nil
+}
+
+func (s *Storage) CreateTOTPCredential(ctx context.Context, credential models.TOTPCredential) (int64, error) {
+	const op = "memory.CreateTOTPCredential"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.totpCredentials.exists(func(c models.TOTPCredential) bool { return c.UserID == credential.UserID }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		credential.ID = d.totpCredentials.nextID()
+		d.totpCredentials.rows[credential.ID] = credential
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential.ID, nil
+}
+
+func (s *Storage) UpdateTOTPCredential(ctx context.Context, credential models.TOTPCredential) error {
+	const op = "memory.UpdateTOTPCredential"
+
+	err := s.write(ctx, func(d *data) error {
+		// The code of the secret can be used only once, so the code which is already used is not found.
+		saved, ok := d.totpCredentials.rows[credential.ID]
+		if !ok || (saved.LastUsedStep >= credential.LastUsedStep && saved.Secret == credential.Secret) {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Secret = credential.Secret
+		saved.Confirmed = credential.Confirmed
+		saved.LastUsedStep = credential.LastUsedStep
+		saved.UpdatedAt = credential.UpdatedAt
+		d.totpCredentials.rows[credential.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveTOTPCredential(ctx context.Context, id int64) error {
+	return s.write(ctx, func(d *data) error {
+		delete(d.totpCredentials.rows, id)
+
+		return nil
+	})
+}
+
+func (s *Storage) BackupCodes(ctx context.Context, userID int64) ([]models.BackupCode, error) {
+	var codes []models.BackupCode
+	err := s.read(ctx, func(d *data) error {
+		codes = d.backupCodes.filter(func(c models.BackupCode) bool { return c.UserID == userID })
+
+		return nil
+	})
+
+	return codes, err
+}
+
+func (s *Storage) CreateBackupCode(ctx context.Context, code models.BackupCode) (int64, error) {
+	err := s.write(ctx, func(d *data) error {
+		code.ID = d.backupCodes.nextID()
+		d.backupCodes.rows[code.ID] = code
+
+		return nil
+	})
+
+	return code.ID, err
+}
+
+func (s *Storage) UpdateBackupCode(ctx context.Context, code models.BackupCode) error {
+	const op = "memory.UpdateBackupCode"
+
+	err := s.write(ctx, func(d *data) error {
+		// The code can be used only once, so the code which is already used is not found.
+		saved, ok := d.backupCodes.rows[code.ID]
+		if !ok || saved.Used {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Used = code.Used
+		saved.UpdatedAt = code.UpdatedAt
+		d.backupCodes.rows[code.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveBackupCode(ctx context.Context, id int64) error {
+	return s.write(ctx, func(d *data) error {
+		delete(d.backupCodes.rows, id)
+
+		return nil
+	})
+}
+
+func (s *Storage) MFAChallengeByHash(ctx context.Context, tokenHash string) (models.MFAChallenge, error) {
+	const op = "memory.MFAChallengeByHash"
+
+	var challenge models.MFAChallenge
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		challenge, ok = d.mfaChallenges.find(func(c models.MFAChallenge) bool { return c.TokenHash == tokenHash })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.MFAChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return challenge, nil
+}
+
+func (s *Storage) CreateMFAChallenge(ctx context.Context, challenge models.MFAChallenge) (int64, error) {
+	const op = "memory.CreateMFAChallenge"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.mfaChallenges.exists(func(c models.MFAChallenge) bool { return c.TokenHash == challenge.TokenHash }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		challenge.ID = d.mfaChallenges.nextID()
+		d.mfaChallenges.rows[challenge.ID] = challenge
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return challenge.ID, nil
+}
+
+func (s *Storage) UpdateMFAChallenge(ctx context.Context, challenge models.MFAChallenge, maxAttempts int) error {
+	const op = "memory.UpdateMFAChallenge"
+
+	err := s.write(ctx, func(d *data) error {
+		// The challenge which is already used or has no attempts left is not found.
+		saved, ok := d.mfaChallenges.rows[challenge.ID]
+		if !ok || saved.Used || saved.Attempts >= maxAttempts {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Attempts++
+		saved.Used = challenge.Used
+		saved.UpdatedAt = challenge.UpdatedAt
+		d.mfaChallenges.rows[challenge.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) WebAuthnCredentials(ctx context.Context, userID int64) ([]models.WebAuthnCredential, error) {
+	var credentials []models.WebAuthnCredential
+	err := s.read(ctx, func(d *data) error {
+		credentials = d.webAuthnCredentials.filter(func(c models.WebAuthnCredential) bool { return c.UserID == userID })
+
+		return nil
+	})
+
+	return credentials, err
+}
+
+func (s *Storage) WebAuthnCredential(ctx context.Context, credentialID string) (models.WebAuthnCredential, error) {
+	const op = "memory.WebAuthnCredential"
+
+	var credential models.WebAuthnCredential
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		credential, ok = d.webAuthnCredentials.find(func(c models.WebAuthnCredential) bool {
+			return c.CredentialID == credentialID
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential, nil
+}
+
+func (s *Storage) CreateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) (int64, error) {
+	const op = "memory.CreateWebAuthnCredential"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.webAuthnCredentials.exists(func(c models.WebAuthnCredential) bool {
+			return c.CredentialID == credential.CredentialID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		credential.ID = d.webAuthnCredentials.nextID()
+		d.webAuthnCredentials.rows[credential.ID] = credential
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential.ID, nil
+}
+
+func (s *Storage) UpdateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.webAuthnCredentials.rows[credential.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.SignCount = credential.SignCount
+		saved.Name = credential.Name
+		saved.UpdatedAt = credential.UpdatedAt
+		d.webAuthnCredentials.rows[credential.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) WebAuthnChallenge(ctx context.Context, challenge string) (models.WebAuthnChallenge, error) {
+	const op = "memory.WebAuthnChallenge"
+
+	var webAuthnChallenge models.WebAuthnChallenge
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		webAuthnChallenge, ok = d.webAuthnChallenges.find(func(c models.WebAuthnChallenge) bool {
+			return c.Challenge == challenge
+		})
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.WebAuthnChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webAuthnChallenge, nil
+}
+
+func (s *Storage) CreateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) (int64, error) {
+	const op = "memory.CreateWebAuthnChallenge"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.webAuthnChallenges.exists(func(c models.WebAuthnChallenge) bool { return c.Challenge == challenge.Challenge }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		challenge.ID = d.webAuthnChallenges.nextID()
+		d.webAuthnChallenges.rows[challenge.ID] = challenge
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return challenge.ID, nil
+}
+
+func (s *Storage) UpdateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) error {
+	const op = "memory.UpdateWebAuthnChallenge"
+
+	err := s.write(ctx, func(d *data) error {
+		// The challenge can be used only once, so the challenge which is already used is not found.
+		saved, ok := d.webAuthnChallenges.rows[challenge.ID]
+		if !ok || saved.Used {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		saved.Used = challenge.Used
+		saved.UpdatedAt = challenge.UpdatedAt
+		d.webAuthnChallenges.rows[challenge.ID] = saved
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) LoginAttempt(ctx context.Context, kind, key string) (models.LoginAttempt, error) {
+	const op = "memory.LoginAttempt"
+
+	var attempt models.LoginAttempt
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		attempt, ok = d.loginAttempts.find(func(a models.LoginAttempt) bool { return a.Kind == kind && a.Key == key })
+		if !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attempt, nil
+}
+
+// FailLoginAttempt counts the failed login of the attempt atomically. The attempt is created with one failure
+// or the failures of the saved attempt are incremented, they start over if the last failure is before
+// the window start. The last failure time is set from the attempt, the lock is kept. The saved attempt is returned.
+func (s *Storage) FailLoginAttempt(
+	ctx context.Context,
+	attempt models.LoginAttempt,
+	windowStart time.Time,
+) (models.LoginAttempt, error) {
+	const op = "memory.FailLoginAttempt"
+
+	var savedAttempt models.LoginAttempt
+	err := s.write(ctx, func(d *data) error {
+		saved, ok := d.loginAttempts.find(func(a models.LoginAttempt) bool {
+			return a.Kind == attempt.Kind && a.Key == attempt.Key
+		})
+		if !ok {
+			saved = models.LoginAttempt{
+				ID:        d.loginAttempts.nextID(),
+				Kind:      attempt.Kind,
+				Key:       attempt.Key,
+				CreatedAt: attempt.CreatedAt,
+			}
+		}
+
+		if saved.LastFailureAt.Before(windowStart) {
+			saved.Failures = 0
+		}
+
+		saved.Failures++
+		saved.LastFailureAt = attempt.LastFailureAt
+		saved.UpdatedAt = attempt.UpdatedAt
+		d.loginAttempts.rows[saved.ID] = saved
+
+		savedAttempt = saved
+
+		return nil
+	})
+	if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return savedAttempt, nil
+}
+
+func (s *Storage) UpdateLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.loginAttempts.rows[attempt.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.Failures = attempt.Failures
+		saved.LastFailureAt = attempt.LastFailureAt
+		saved.LockedUntil = attempt.LockedUntil
+		saved.UpdatedAt = attempt.UpdatedAt
+		d.loginAttempts.rows[attempt.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) RemoveLoginAttempt(ctx context.Context, id int64) error {
+	return s.write(ctx, func(d *data) error {
+		delete(d.loginAttempts.rows, id)
+
+		return nil
+	})
+}
+
+func (s *Storage) RemoveExpiredLoginAttempts(ctx context.Context, kind string, before, now time.Time) error {
+	return s.write(ctx, func(d *data) error {
+		for id, attempt := range d.loginAttempts.rows {
+			if attempt.Kind != kind || !attempt.LastFailureAt.Before(before) {
+				continue
+			}
+
+			if attempt.LockedUntil.Valid && !attempt.LockedUntil.Time.Before(now) {
+				continue
+			}
+
+			delete(d.loginAttempts.rows, id)
+		}
+
+		return nil
+	})
+}
+
+func (s *Storage) Client(ctx context.Context, id int64) (models.Client, error) {
+	const op = "memory.Client"
+
+	var client models.Client
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		if client, ok = d.clients.rows[id]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) Clients(ctx context.Context) ([]models.Client, error) {
+	var clients []models.Client
+	err := s.read(ctx, func(d *data) error {
+		clients = d.clients.filter(func(c models.Client) bool { return !c.Deleted })
+
+		return nil
+	})
+
+	return clients, err
+}
+
+func (s *Storage) CreateClient(ctx context.Context, client models.Client) (int64, error) {
+	const op = "memory.CreateClient"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.clients.exists(func(c models.Client) bool { return c.Code == client.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		client.ID = d.clients.nextID()
+		d.clients.rows[client.ID] = client
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client.ID, nil
+}
+
+func (s *Storage) UpdateClient(ctx context.Context, client models.Client) error {
+	const op = "memory.UpdateClient"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.clients.rows[client.ID]; !ok {
+			return nil
+		}
+
+		if d.clients.exists(func(c models.Client) bool { return c.ID != client.ID && c.Code == client.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		d.clients.rows[client.ID] = client
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateAudience(ctx context.Context, audience models.Audience) (int64, error) {
+	err := s.write(ctx, func(d *data) error {
+		audience.ID = d.audiences.nextID()
+		d.audiences.rows[audience.ID] = audience
+
+		return nil
+	})
+
+	return audience.ID, err
+}
+
+func (s *Storage) RemoveAudience(ctx context.Context, id int64) error {
+	return s.write(ctx, func(d *data) error {
+		delete(d.audiences.rows, id)
+
+		return nil
+	})
+}
+
+func (s *Storage) RolesByCodes(ctx context.Context, codes []string) ([]models.Role, error) {
+	var roles []models.Role
+	err := s.read(ctx, func(d *data) error {
+		roles = d.roles.filter(func(r models.Role) bool { return !r.Deleted && slices.Contains(codes, r.Code) })
+
+		return nil
+	})
+
+	return roles, err
+}
+
+func (s *Storage) CreateClientDefaultRoleLink(
+	ctx context.Context,
+	clientDefaultRoleLink models.ClientDefaultRoleLink,
+) (int64, error) {
+	err := s.write(ctx, func(d *data) error {
+		clientDefaultRoleLink.ID = d.clientDefaultRoles.nextID()
+		d.clientDefaultRoles.rows[clientDefaultRoleLink.ID] = clientDefaultRoleLink
+
+		return nil
+	})
+
+	return clientDefaultRoleLink.ID, err
+}
+
+func (s *Storage) RemoveClientDefaultRoleLinks(ctx context.Context, clientID int64) error {
+	return s.write(ctx, func(d *data) error {
+		links := d.clientDefaultRoles.filter(func(l models.ClientDefaultRoleLink) bool { return l.ClientID == clientID })
+		for _, link := range links {
+			delete(d.clientDefaultRoles.rows, link.ID)
+		}
+
+		return nil
+	})
+}
+
+func (s *Storage) Role(ctx context.Context, id int64) (models.Role, error) {
+	const op = "memory.Role"
+
+	var role models.Role
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		if role, ok = d.roles.rows[id]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role, nil
+}
+
+func (s *Storage) Roles(ctx context.Context, limit, offset int) ([]models.Role, error) {
+	var roles []models.Role
+	err := s.read(ctx, func(d *data) error {
+		roles = page(d.roles.filter(func(r models.Role) bool { return !r.Deleted }), limit, offset)
+
+		return nil
+	})
+
+	return roles, err
+}
+
+func (s *Storage) RolesCount(ctx context.Context) (int64, error) {
+	var count int64
+	err := s.read(ctx, func(d *data) error {
+		count = int64(len(d.roles.filter(func(r models.Role) bool { return !r.Deleted })))
+
+		return nil
+	})
+
+	return count, err
+}
+
+func (s *Storage) CreateRole(ctx context.Context, role models.Role) (int64, error) {
+	const op = "memory.CreateRole"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.roles.exists(func(r models.Role) bool { return r.Code == role.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		role.ID = d.roles.nextID()
+		d.roles.rows[role.ID] = role
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role.ID, nil
+}
+
+func (s *Storage) UpdateRole(ctx context.Context, role models.Role) error {
+	const op = "memory.UpdateRole"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.roles.rows[role.ID]; !ok {
+			return nil
+		}
+
+		if d.roles.exists(func(r models.Role) bool { return r.ID != role.ID && r.Code == role.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		d.roles.rows[role.ID] = role
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Permission(ctx context.Context, id int64) (models.Permission, error) {
+	const op = "memory.Permission"
+
+	var permission models.Permission
+	err := s.read(ctx, func(d *data) error {
+		var ok bool
+		if permission, ok = d.permissions.rows[id]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permission, nil
+}
+
+func (s *Storage) Permissions(ctx context.Context, limit, offset int) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := s.read(ctx, func(d *data) error {
+		permissions = page(d.permissions.filter(func(p models.Permission) bool { return !p.Deleted }), limit, offset)
+
+		return nil
+	})
+
+	return permissions, err
+}
+
+func (s *Storage) PermissionsCount(ctx context.Context) (int64, error) {
+	var count int64
+	err := s.read(ctx, func(d *data) error {
+		count = int64(len(d.permissions.filter(func(p models.Permission) bool { return !p.Deleted })))
+
+		return nil
+	})
+
+	return count, err
+}
+
+func (s *Storage) CreatePermission(ctx context.Context, permission models.Permission) (int64, error) {
+	const op = "memory.CreatePermission"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.permissions.exists(func(p models.Permission) bool { return p.Code == permission.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		permission.ID = d.permissions.nextID()
+		d.permissions.rows[permission.ID] = permission
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permission.ID, nil
+}
+
+func (s *Storage) UpdatePermission(ctx context.Context, permission models.Permission) error {
+	const op = "memory.UpdatePermission"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.permissions.rows[permission.ID]; !ok {
+			return nil
+		}
+
+		if d.permissions.exists(func(p models.Permission) bool { return p.ID != permission.ID && p.Code == permission.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		d.permissions.rows[permission.ID] = permission
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) PermissionsByRoleID(ctx context.Context, roleID int64) ([]models.Permission, error) {
+	permissions := make([]models.Permission, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.rolePermissions.filter(func(l models.RolePermissionLink) bool { return l.RoleID == roleID }) {
+			if permission, ok := d.permissions.rows[link.PermissionID]; ok && !permission.Deleted {
+				permissions = append(permissions, permission)
+			}
+		}
+
+		slices.SortFunc(permissions, func(a, b models.Permission) int { return cmp.Compare(a.ID, b.ID) })
+
+		return nil
+	})
+
+	return permissions, err
+}
+
+func (s *Storage) CreateRolePermissionLink(ctx context.Context, rolePermissionLink models.RolePermissionLink) (int64, error) {
+	const op = "memory.CreateRolePermissionLink"
+
+	err := s.write(ctx, func(d *data) error {
+		if d.rolePermissions.exists(func(l models.RolePermissionLink) bool {
+			return l.RoleID == rolePermissionLink.RoleID && l.PermissionID == rolePermissionLink.PermissionID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		rolePermissionLink.ID = d.rolePermissions.nextID()
+		d.rolePermissions.rows[rolePermissionLink.ID] = rolePermissionLink
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rolePermissionLink.ID, nil
+}
+
+func (s *Storage) RemoveRolePermissionLink(ctx context.Context, roleID, permissionID int64) error {
+	return s.write(ctx, func(d *data) error {
+		links := d.rolePermissions.filter(func(l models.RolePermissionLink) bool {
+			return l.RoleID == roleID && l.PermissionID == permissionID
+		})
+		for _, link := range links {
+			delete(d.rolePermissions.rows, link.ID)
+		}
+
+		return nil
+	})
+}
+
+func (s *Storage) Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]models.User, error) {
+	var users []models.User
+	err := s.read(ctx, func(d *data) error {
+		users = page(d.users.filter(userMatches(query, deleted)), limit, offset)
+
+		return nil
+	})
+
+	return users, err
+}
+
+func (s *Storage) UsersCount(ctx context.Context, query string, deleted bool) (int64, error) {
+	var count int64
+	err := s.read(ctx, func(d *data) error {
+		count = int64(len(d.users.filter(userMatches(query, deleted))))
+
+		return nil
+	})
+
+	return count, err
+}
+
+// userMatches returns the condition which matches the users with the deleted flag
+// whose username or full name contains the query regardless of case.
+func userMatches(query string, deleted bool) func(u models.User) bool {
+	query = strings.ToLower(query)
+
+	return func(u models.User) bool {
+		return u.Deleted == deleted &&
+			(strings.Contains(strings.ToLower(u.Username), query) || strings.Contains(strings.ToLower(u.FullName), query))
+	}
+}
+
+func (s *Storage) UpdateUserStatus(ctx context.Context, user models.User) error {
+	return s.write(ctx, func(d *data) error {
+		saved, ok := d.users.rows[user.ID]
+		if !ok {
+			return nil
+		}
+
+		saved.Blocked = user.Blocked
+		saved.Deleted = user.Deleted
+		saved.UpdatedAt = user.UpdatedAt
+		d.users.rows[user.ID] = saved
+
+		return nil
+	})
+}
+
+func (s *Storage) UserRoles(ctx context.Context, userID int64) ([]models.Role, error) {
+	roles := make([]models.Role, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.userRoles.filter(func(l models.UserRoleLink) bool { return l.UserID == userID }) {
+			if role, ok := d.roles.rows[link.RoleID]; ok && !role.Deleted {
+				roles = append(roles, role)
+			}
+		}
+
+		slices.SortFunc(roles, func(a, b models.Role) int { return cmp.Compare(a.ID, b.ID) })
+
+		return nil
+	})
+
+	return roles, err
+}
+
+func (s *Storage) RemoveUserRoleLink(ctx context.Context, userID, roleID int64) error {
+	return s.write(ctx, func(d *data) error {
+		links := d.userRoles.filter(func(l models.UserRoleLink) bool {
+			return l.UserID == userID && l.RoleID == roleID
+		})
+		for _, link := range links {
+			delete(d.userRoles.rows, link.ID)
+		}
+
+		return nil
+	})
+}
+
+func (s *Storage) UserClients(ctx context.Context, userID int64) ([]models.Client, error) {
+	clients := make([]models.Client, 0)
+	err := s.read(ctx, func(d *data) error {
+		for _, link := range d.userClients.filter(func(l models.UserClientLink) bool { return l.UserID == userID }) {
+			if client, ok := d.clients.rows[link.ClientID]; ok && !client.Deleted {
+				clients = append(clients, client)
+			}
+		}
+
+		slices.SortFunc(clients, func(a, b models.Client) int { return cmp.Compare(a.ID, b.ID) })
+
+		return nil
+	})
+
+	return clients, err
+}
+
+func (s *Storage) RemoveUserClientLink(ctx context.Context, userID, clientID int64) error {
+	return s.write(ctx, func(d *data) error {
+		links := d.userClients.filter(func(l models.UserClientLink) bool {
+			return l.UserID == userID && l.ClientID == clientID
+		})
+		for _, link := range links {
+			delete(d.userClients.rows, link.ID)
+		}
+
+		return nil
+	})
+}
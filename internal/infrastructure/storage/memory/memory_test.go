@@ -0,0 +1,96 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/memory"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Storage_Conformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Backend {
+		storage := memory.New()
+
+		return storagetest.Backend{
+			Storage: storage,
+			Seed:    storagetest.MemorySeed(storage),
+		}
+	})
+}
+
+func Test_Storage_Concurrency(t *testing.T) {
+	const goroutines = 50
+
+	ctx := context.Background()
+	storage := memory.New()
+	errRollback := errors.New("rollback")
+
+	userID, err := storage.CreateUser(ctx, models.User{Username: "test", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_ = storage.Transaction(ctx, func(ctx context.Context) error {
+				_, err := storage.CreateSession(ctx, models.Session{
+					UserID:       userID,
+					RefreshToken: fmt.Sprintf("refresh-token-%d", i),
+				})
+				if err != nil {
+					return err
+				}
+
+				// Every second transaction is rolled back.
+				if i%2 == 1 {
+					return errRollback
+				}
+
+				return nil
+			})
+
+			_, _ = storage.SessionsByUserID(ctx, userID)
+		}()
+	}
+	wg.Wait()
+
+	sessions, err := storage.SessionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, goroutines/2)
+}
+
+func Test_Storage_Seed(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+
+	clientID, err := storage.SeedClient(ctx, models.Client{Code: "client"})
+	require.NoError(t, err)
+
+	_, err = storage.SeedClient(ctx, models.Client{Code: "client"})
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	roleID, err := storage.SeedRole(ctx, models.Role{Code: "role", Active: true})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, storage.SeedClientDefaultRole(ctx, clientID+1, roleID), infrastructure.ErrEntityNotFound)
+	require.NoError(t, storage.SeedClientDefaultRole(ctx, clientID, roleID))
+
+	client, err := storage.ClientByCode(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, client.CreatedAt.IsZero())
+
+	roles, err := storage.RolesByClientID(ctx, clientID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, roleID, roles[0].ID)
+}
@@ -0,0 +1,246 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"time"
+)
+
+// The seed methods fill the in-memory storage with the clients, roles, permissions and their links
+// without going through the use-cases, the zero creation and update times are set to the current time.
+
+// SeedClient creates the client. The client code must be unique.
+func (s *Storage) SeedClient(ctx context.Context, client models.Client) (int64, error) {
+	const op = "memory.SeedClient"
+
+	setTimestamps(&client.CreatedAt, &client.UpdatedAt)
+
+	err := s.write(ctx, func(d *data) error {
+		if d.clients.exists(func(c models.Client) bool { return c.Code == client.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		client.ID = d.clients.nextID()
+		d.clients.rows[client.ID] = client
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client.ID, nil
+}
+
+// SeedClientAudience creates the audience of the client.
+func (s *Storage) SeedClientAudience(ctx context.Context, audience models.Audience) (int64, error) {
+	const op = "memory.SeedClientAudience"
+
+	setTimestamps(&audience.CreatedAt, &audience.UpdatedAt)
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.clients.rows[audience.ClientID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		audience.ID = d.audiences.nextID()
+		d.audiences.rows[audience.ID] = audience
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return audience.ID, nil
+}
+
+// SeedClientRedirectURI creates the redirect URI of the client. The URI must be unique for the client.
+func (s *Storage) SeedClientRedirectURI(ctx context.Context, redirectURI models.RedirectURI) (int64, error) {
+	const op = "memory.SeedClientRedirectURI"
+
+	setTimestamps(&redirectURI.CreatedAt, &redirectURI.UpdatedAt)
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.clients.rows[redirectURI.ClientID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if d.redirectURIs.exists(func(u models.RedirectURI) bool {
+			return u.ClientID == redirectURI.ClientID && u.URI == redirectURI.URI
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		redirectURI.ID = d.redirectURIs.nextID()
+		d.redirectURIs.rows[redirectURI.ID] = redirectURI
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return redirectURI.ID, nil
+}
+
+// SeedRole creates the role. The role code must be unique.
+func (s *Storage) SeedRole(ctx context.Context, role models.Role) (int64, error) {
+	const op = "memory.SeedRole"
+
+	setTimestamps(&role.CreatedAt, &role.UpdatedAt)
+
+	err := s.write(ctx, func(d *data) error {
+		if d.roles.exists(func(r models.Role) bool { return r.Code == role.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		role.ID = d.roles.nextID()
+		d.roles.rows[role.ID] = role
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role.ID, nil
+}
+
+// SeedPermission creates the permission. The permission code must be unique.
+func (s *Storage) SeedPermission(ctx context.Context, permission models.Permission) (int64, error) {
+	const op = "memory.SeedPermission"
+
+	setTimestamps(&permission.CreatedAt, &permission.UpdatedAt)
+
+	err := s.write(ctx, func(d *data) error {
+		if d.permissions.exists(func(p models.Permission) bool { return p.Code == permission.Code }) {
+			return infrastructure.ErrEntityExists
+		}
+
+		permission.ID = d.permissions.nextID()
+		d.permissions.rows[permission.ID] = permission
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permission.ID, nil
+}
+
+// SeedRolePermission grants the permission to the role. The permission must be granted to the role once.
+func (s *Storage) SeedRolePermission(ctx context.Context, roleID, permissionID int64) error {
+	const op = "memory.SeedRolePermission"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.roles.rows[roleID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if _, ok := d.permissions.rows[permissionID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if d.rolePermissions.exists(func(l models.RolePermissionLink) bool {
+			return l.RoleID == roleID && l.PermissionID == permissionID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		now := time.Now()
+		id := d.rolePermissions.nextID()
+		d.rolePermissions.rows[id] = models.RolePermissionLink{
+			ID:           id,
+			RoleID:       roleID,
+			PermissionID: permissionID,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SeedClientDefaultRole adds the role to the roles granted to the users registered with the client.
+func (s *Storage) SeedClientDefaultRole(ctx context.Context, clientID, roleID int64) error {
+	const op = "memory.SeedClientDefaultRole"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.clients.rows[clientID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if _, ok := d.roles.rows[roleID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		now := time.Now()
+		id := d.clientDefaultRoles.nextID()
+		d.clientDefaultRoles.rows[id] = models.ClientDefaultRoleLink{
+			ID:        id,
+			ClientID:  clientID,
+			RoleID:    roleID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SeedClientPermission grants the permission to the client itself. The permission must be granted to the client once.
+func (s *Storage) SeedClientPermission(ctx context.Context, clientID, permissionID int64) error {
+	const op = "memory.SeedClientPermission"
+
+	err := s.write(ctx, func(d *data) error {
+		if _, ok := d.clients.rows[clientID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if _, ok := d.permissions.rows[permissionID]; !ok {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if d.clientPermissions.exists(func(l clientPermission) bool {
+			return l.ClientID == clientID && l.PermissionID == permissionID
+		}) {
+			return infrastructure.ErrEntityExists
+		}
+
+		id := d.clientPermissions.nextID()
+		d.clientPermissions.rows[id] = clientPermission{ID: id, ClientID: clientID, PermissionID: permissionID}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func setTimestamps(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+
+	if updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
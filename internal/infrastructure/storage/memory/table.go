@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+)
+
+// table is the in-memory table of the rows identified by the auto-incremented ID.
+type table[T any] struct {
+	rows   map[int64]T
+	lastID int64
+}
+
+func newTable[T any]() table[T] {
+	return table[T]{rows: make(map[int64]T)}
+}
+
+// nextID returns the ID of the next row inserted into the table.
+func (t *table[T]) nextID() int64 {
+	t.lastID++
+
+	return t.lastID
+}
+
+// find returns the first row in order of IDs which matches the condition.
+func (t *table[T]) find(match func(row T) bool) (T, bool) {
+	for _, id := range slices.Sorted(maps.Keys(t.rows)) {
+		if row := t.rows[id]; match(row) {
+			return row, true
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// filter returns the rows in order of IDs which match the condition.
+func (t *table[T]) filter(match func(row T) bool) []T {
+	rows := make([]T, 0)
+	for _, id := range slices.Sorted(maps.Keys(t.rows)) {
+		if row := t.rows[id]; match(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// exists reports whether any row matches the condition.
+func (t *table[T]) exists(match func(row T) bool) bool {
+	for _, row := range t.rows {
+		if match(row) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *table[T]) clone() table[T] {
+	return table[T]{
+		rows:   maps.Clone(t.rows),
+		lastID: t.lastID,
+	}
+}
+
+// page returns the rows of the page which starts at the offset and contains at most limit rows.
+func page[T any](rows []T, limit, offset int) []T {
+	if offset >= len(rows) {
+		return make([]T, 0)
+	}
+
+	return rows[offset:min(offset+limit, len(rows))]
+}
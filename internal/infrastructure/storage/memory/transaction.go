@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+)
+
+// txKey is the context key of the storage the transaction is opened in.
+type txKey struct{}
+
+// Transaction executes the function in a transaction. The storage methods called with the context
+// passed to the function are executed in the transaction. If the function returns an error,
+// the changes are rolled back and the error is returned, otherwise the changes are kept.
+// If the context already carries a transaction, the function is executed in that transaction.
+//
+// The transaction locks the storage exclusively until it is finished, so the function must call
+// the storage methods with the context passed to it and must not call them concurrently.
+func (s *Storage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.data.clone()
+
+	defer func() {
+		if p := recover(); p != nil {
+			s.data = snapshot
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, s)); err != nil {
+		s.data = snapshot
+
+		return err
+	}
+
+	return nil
+}
+
+// inTransaction reports whether the context carries the transaction opened in the storage.
+func (s *Storage) inTransaction(ctx context.Context) bool {
+	tx, ok := ctx.Value(txKey{}).(*Storage)
+
+	return ok && tx == s
+}
+
+// read executes the function with the storage data locked for reading.
+func (s *Storage) read(ctx context.Context, fn func(d *data) error) error {
+	if !s.inTransaction(ctx) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+
+	return fn(s.data)
+}
+
+// write executes the function with the storage data locked for writing.
+func (s *Storage) write(ctx context.Context, fn func(d *data) error) error {
+	if !s.inTransaction(ctx) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	return fn(s.data)
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type AudienceOption func(*Audience)
+
+func AudienceCreated() AudienceOption {
+	now := time.Now()
+	return func(a *Audience) {
+		a.CreatedAt = now
+		a.UpdatedAt = now
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AuthorizationCode is data for authorization code in storage.
+type AuthorizationCode struct {
+	ID                  int64
+	CodeHash            string
+	UserID              int64
+	ClientID            int64
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	AuthTime            time.Time
+	ExpiresAt           time.Time
+	Used                bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
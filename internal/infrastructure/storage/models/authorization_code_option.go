@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type AuthorizationCodeOption func(*AuthorizationCode)
+
+func AuthorizationCodeCreated() AuthorizationCodeOption {
+	now := time.Now()
+	return func(c *AuthorizationCode) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func AuthorizationCodeUpdated() AuthorizationCodeOption {
+	return func(c *AuthorizationCode) {
+		c.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// BackupCode is data for user MFA backup code in storage.
+type BackupCode struct {
+	ID        int64
+	UserID    int64
+	CodeHash  string
+	Used      bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
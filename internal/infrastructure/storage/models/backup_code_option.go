@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type BackupCodeOption func(*BackupCode)
+
+func BackupCodeCreated() BackupCodeOption {
+	now := time.Now()
+	return func(c *BackupCode) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func BackupCodeUpdated() BackupCodeOption {
+	return func(c *BackupCode) {
+		c.UpdatedAt = time.Now()
+	}
+}
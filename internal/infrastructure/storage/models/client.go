@@ -1,14 +1,21 @@
 package models
 
-import "time"
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
 
 // Client is data for client in storage.
 type Client struct {
-	ID        int64
-	Name      string
-	Code      string
-	SecretKey string
-	Deleted   bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                    int64
+	Name                  string
+	Code                  string
+	SecretKey             string
+	MaxSessions           null.Int32
+	SessionEvictionPolicy null.String
+	RequireVerifiedEmail  bool
+	RequireMFA            bool
+	Deleted               bool
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
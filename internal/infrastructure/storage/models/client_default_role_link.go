@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type ClientDefaultRoleLink struct {
+	ID        int64
+	ClientID  int64
+	RoleID    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type ClientDefaultRoleLinkOption func(*ClientDefaultRoleLink)
+
+func ClientDefaultRoleLinkCreated() ClientDefaultRoleLinkOption {
+	now := time.Now()
+	return func(l *ClientDefaultRoleLink) {
+		l.CreatedAt = now
+		l.UpdatedAt = now
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type ClientOption func(*Client)
+
+func ClientCreated() ClientOption {
+	now := time.Now()
+	return func(c *Client) {
+		c.Deleted = false
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func ClientUpdated() ClientOption {
+	return func(c *Client) {
+		c.Deleted = false
+		c.UpdatedAt = time.Now()
+	}
+}
+
+func ClientRemoved() ClientOption {
+	return func(c *Client) {
+		c.Deleted = true
+		c.UpdatedAt = time.Now()
+	}
+}
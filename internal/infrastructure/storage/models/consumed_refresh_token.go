@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type ConsumedRefreshToken struct {
+	ID           int64
+	RefreshToken string
+	FamilyID     string
+	UserID       int64
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
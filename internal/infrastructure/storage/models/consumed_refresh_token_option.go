@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type ConsumedRefreshTokenOption func(*ConsumedRefreshToken)
+
+func ConsumedRefreshTokenCreated() ConsumedRefreshTokenOption {
+	now := time.Now()
+	return func(t *ConsumedRefreshToken) {
+		t.CreatedAt = now
+		t.UpdatedAt = now
+	}
+}
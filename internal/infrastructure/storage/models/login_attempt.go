@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
+
+// LoginAttempt is data for failed login attempts of the username or the client IP address in storage.
+type LoginAttempt struct {
+	ID            int64
+	Kind          string
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   null.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
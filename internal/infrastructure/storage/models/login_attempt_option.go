@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type LoginAttemptOption func(*LoginAttempt)
+
+func LoginAttemptCreated() LoginAttemptOption {
+	now := time.Now()
+	return func(a *LoginAttempt) {
+		a.CreatedAt = now
+		a.UpdatedAt = now
+	}
+}
+
+func LoginAttemptUpdated() LoginAttemptOption {
+	return func(a *LoginAttempt) {
+		a.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// MFAChallenge is data for MFA challenge in storage.
+type MFAChallenge struct {
+	ID          int64
+	TokenHash   string
+	UserID      int64
+	ClientID    int64
+	UserAgent   string
+	Fingerprint string
+	Issuer      string
+	Nonce       string
+	ExpiresAt   time.Time
+	Attempts    int
+	Used        bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type MFAChallengeOption func(*MFAChallenge)
+
+func MFAChallengeCreated() MFAChallengeOption {
+	now := time.Now()
+	return func(c *MFAChallenge) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func MFAChallengeUpdated() MFAChallengeOption {
+	return func(c *MFAChallenge) {
+		c.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PasswordResetToken is data for password reset token in storage.
+type PasswordResetToken struct {
+	ID        int64
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+	Used      bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type PasswordResetTokenOption func(*PasswordResetToken)
+
+func PasswordResetTokenCreated() PasswordResetTokenOption {
+	now := time.Now()
+	return func(t *PasswordResetToken) {
+		t.CreatedAt = now
+		t.UpdatedAt = now
+	}
+}
+
+func PasswordResetTokenUpdated() PasswordResetTokenOption {
+	return func(t *PasswordResetToken) {
+		t.UpdatedAt = time.Now()
+	}
+}
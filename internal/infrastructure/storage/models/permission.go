@@ -1,12 +1,15 @@
 package models
 
-import "time"
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
 
 // Permission is data for permission in storage.
 type Permission struct {
 	ID          int64
 	Code        string
-	Description string
+	Description null.String
 	Active      bool
 	Deleted     bool
 	CreatedAt   time.Time
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type PermissionOption func(*Permission)
+
+func PermissionCreated() PermissionOption {
+	now := time.Now()
+	return func(p *Permission) {
+		p.Deleted = false
+		p.CreatedAt = now
+		p.UpdatedAt = now
+	}
+}
+
+func PermissionUpdated() PermissionOption {
+	return func(p *Permission) {
+		p.Deleted = false
+		p.UpdatedAt = time.Now()
+	}
+}
+
+func PermissionRemoved() PermissionOption {
+	return func(p *Permission) {
+		p.Deleted = true
+		p.UpdatedAt = time.Now()
+	}
+}
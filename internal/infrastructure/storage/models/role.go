@@ -1,12 +1,15 @@
 package models
 
-import "time"
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
 
 type Role struct {
 	ID          int64
 	Code        string
 	Name        string
-	Description string
+	Description null.String
 	Active      bool
 	Deleted     bool
 	CreatedAt   time.Time
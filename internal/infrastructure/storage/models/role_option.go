@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type RoleOption func(*Role)
+
+func RoleCreated() RoleOption {
+	now := time.Now()
+	return func(r *Role) {
+		r.Deleted = false
+		r.CreatedAt = now
+		r.UpdatedAt = now
+	}
+}
+
+func RoleUpdated() RoleOption {
+	return func(r *Role) {
+		r.Deleted = false
+		r.UpdatedAt = time.Now()
+	}
+}
+
+func RoleRemoved() RoleOption {
+	return func(r *Role) {
+		r.Deleted = true
+		r.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type RolePermissionLink struct {
+	ID           int64
+	RoleID       int64
+	PermissionID int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type RolePermissionLinkOption func(*RolePermissionLink)
+
+func RolePermissionLinkCreated() RolePermissionLinkOption {
+	now := time.Now()
+	return func(l *RolePermissionLink) {
+		l.CreatedAt = now
+		l.UpdatedAt = now
+	}
+}
@@ -1,14 +1,20 @@
 package models
 
-import "time"
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
 
 type Session struct {
 	ID           int64
 	UserID       int64
+	ClientID     null.Int
 	RefreshToken string
+	FamilyID     string
 	UserAgent    string
 	Fingerprint  string
 	ExpiresAt    time.Time
+	LastUsedAt   time.Time
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -7,7 +7,10 @@ type SessionOption func(*Session)
 func SessionCreated() SessionOption {
 	now := time.Now()
 	return func(s *Session) {
-		s.CreatedAt = now
+		// The session created by refreshing tokens keeps the creation time of the first session of its family.
+		if s.CreatedAt.IsZero() {
+			s.CreatedAt = now
+		}
 		s.UpdatedAt = now
 	}
 }
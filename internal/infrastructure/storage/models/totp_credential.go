@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// TOTPCredential is data for user TOTP credential in storage.
+type TOTPCredential struct {
+	ID           int64
+	UserID       int64
+	Secret       string
+	Confirmed    bool
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
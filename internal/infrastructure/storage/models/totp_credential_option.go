@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type TOTPCredentialOption func(*TOTPCredential)
+
+func TOTPCredentialCreated() TOTPCredentialOption {
+	now := time.Now()
+	return func(c *TOTPCredential) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func TOTPCredentialUpdated() TOTPCredentialOption {
+	return func(c *TOTPCredential) {
+		c.UpdatedAt = time.Now()
+	}
+}
@@ -7,14 +7,20 @@ import (
 
 // User is data for user in storage.
 type User struct {
-	ID            int64
-	Username      string
-	PasswordHash  string
-	FullName      string
-	DateOfBirth   null.Time
-	Gender        null.Int16
-	AvatarFileKey null.String
-	Deleted       bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID                 int64
+	Username           string
+	PasswordHash       string
+	FullName           string
+	DateOfBirth        null.Time
+	Gender             null.Int16
+	AvatarFileKey      null.String
+	Email              null.String
+	EmailVerified      bool
+	Phone              null.String
+	PhoneVerified      bool
+	Blocked            bool
+	MustChangePassword bool
+	Deleted            bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// VerificationCode is data for contact verification code in storage.
+type VerificationCode struct {
+	ID        int64
+	UserID    int64
+	Channel   string
+	Target    string
+	CodeHash  string
+	ExpiresAt time.Time
+	Attempts  int
+	Used      bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type VerificationCodeOption func(*VerificationCode)
+
+func VerificationCodeCreated() VerificationCodeOption {
+	now := time.Now()
+	return func(c *VerificationCode) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func VerificationCodeUpdated() VerificationCodeOption {
+	return func(c *VerificationCode) {
+		c.UpdatedAt = time.Now()
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
+
+// WebAuthnChallenge is data for WebAuthn challenge in storage.
+type WebAuthnChallenge struct {
+	ID        int64
+	Challenge string
+	Ceremony  string
+	UserID    null.Int
+	ClientID  null.Int
+	Nonce     string
+	ExpiresAt time.Time
+	Used      bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type WebAuthnChallengeOption func(*WebAuthnChallenge)
+
+func WebAuthnChallengeCreated() WebAuthnChallengeOption {
+	now := time.Now()
+	return func(c *WebAuthnChallenge) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func WebAuthnChallengeUpdated() WebAuthnChallengeOption {
+	return func(c *WebAuthnChallenge) {
+		c.UpdatedAt = time.Now()
+	}
+}
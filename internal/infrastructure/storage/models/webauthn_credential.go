@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// WebAuthnCredential is data for WebAuthn credential in storage.
+type WebAuthnCredential struct {
+	ID           int64
+	UserID       int64
+	CredentialID string
+	PublicKey    []byte
+	SignCount    int64
+	Name         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
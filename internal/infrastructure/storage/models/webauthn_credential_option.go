@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type WebAuthnCredentialOption func(*WebAuthnCredential)
+
+func WebAuthnCredentialCreated() WebAuthnCredentialOption {
+	now := time.Now()
+	return func(c *WebAuthnCredential) {
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	}
+}
+
+func WebAuthnCredentialUpdated() WebAuthnCredentialOption {
+	return func(c *WebAuthnCredential) {
+		c.UpdatedAt = time.Now()
+	}
+}
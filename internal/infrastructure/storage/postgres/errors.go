@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/lib/pq"
+)
+
+// uniqueViolationCode is the PostgreSQL error code of the unique constraint violation.
+const uniqueViolationCode pq.ErrorCode = "23505"
+
+// isUniqueViolation reports whether the error is caused by the unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
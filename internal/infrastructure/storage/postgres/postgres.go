@@ -0,0 +1,3587 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"strings"
+	"time"
+)
+
+// Storage provides access to PostgreSQL storage.
+type Storage struct {
+	db *sql.DB
+}
+
+// New creates a new instance of the PostgreSQL store.
+func New(dsn string) (*Storage, error) {
+	const op = "postgres.New"
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{db: db}, nil
+}
+
+func (s *Storage) User(ctx context.Context, id int64) (models.User, error) {
+	const op = "postgres.User"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+    		u.id,
+    		u.username,
+    		u.password_hash,
+    		u.fio,
+    		u.date_of_birth,
+    		u.gender,
+    		u.avatar_file_key,
+    		u.email,
+    		u.email_verified,
+    		u.phone,
+    		u.phone_verified,
+    		u.blocked,
+    		u.must_change_password,
+    		u.deleted,
+    		u.created_at,
+    		u.updated_at
+		from users u
+		where u.id = $1;`)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, id)
+
+	var user models.User
+	err = row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.FullName,
+		&user.DateOfBirth,
+		&user.Gender,
+		&user.AvatarFileKey,
+		&user.Email,
+		&user.EmailVerified,
+		&user.Phone,
+		&user.PhoneVerified,
+		&user.Blocked,
+		&user.MustChangePassword,
+		&user.Deleted,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) UserByUsername(ctx context.Context, username string) (models.User, error) {
+	const op = "postgres.UserByUsername"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+    		u.id,
+    		u.username,
+    		u.password_hash,
+    		u.fio,
+    		u.date_of_birth,
+    		u.gender,
+    		u.avatar_file_key,
+    		u.email,
+    		u.email_verified,
+    		u.phone,
+    		u.phone_verified,
+    		u.blocked,
+    		u.must_change_password,
+    		u.deleted,
+    		u.created_at,
+    		u.updated_at
+		from users u
+		where u.username = $1;`)
+	if err != nil {
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, username)
+
+	var user models.User
+	err = row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.FullName,
+		&user.DateOfBirth,
+		&user.Gender,
+		&user.AvatarFileKey,
+		&user.Email,
+		&user.EmailVerified,
+		&user.Phone,
+		&user.PhoneVerified,
+		&user.Blocked,
+		&user.MustChangePassword,
+		&user.Deleted,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, user models.User) (int64, error) {
+	const op = "postgres.CreateUser"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into users (
+		   username,
+		   password_hash,
+		   fio,
+		   date_of_birth,
+		   gender,
+		   avatar_file_key,
+		   email,
+		   email_verified,
+		   phone,
+		   phone_verified,
+		   blocked,
+		   must_change_password,
+		   deleted,
+		   created_at,
+		   updated_at)
+		values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		user.Username,
+		user.PasswordHash,
+		user.FullName,
+		user.DateOfBirth,
+		user.Gender,
+		user.AvatarFileKey,
+		user.Email,
+		user.EmailVerified,
+		user.Phone,
+		user.PhoneVerified,
+		user.Blocked,
+		user.MustChangePassword,
+		user.Deleted,
+		user.CreatedAt,
+		user.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, user models.User) error {
+	const op = "postgres.UpdateUser"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update users
+		 set username = $1,
+			 password_hash = $2,
+			 fio = $3,
+			 date_of_birth = $4,
+			 gender = $5,
+			 avatar_file_key = $6,
+			 email = $7,
+			 email_verified = $8,
+			 phone = $9,
+			 phone_verified = $10,
+			 blocked = $11,
+			 must_change_password = $12,
+			 deleted = $13,
+			 created_at = $14,
+			 updated_at = $15
+		 where id = $16;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		user.Username,
+		user.PasswordHash,
+		user.FullName,
+		user.DateOfBirth,
+		user.Gender,
+		user.AvatarFileKey,
+		user.Email,
+		user.EmailVerified,
+		user.Phone,
+		user.PhoneVerified,
+		user.Blocked,
+		user.MustChangePassword,
+		user.Deleted,
+		user.CreatedAt,
+		user.UpdatedAt,
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateUserProfile(ctx context.Context, user models.User) error {
+	const op = "postgres.UpdateUserProfile"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update users
+		 set fio = $1,
+			 date_of_birth = $2,
+			 gender = $3,
+			 avatar_file_key = $4,
+			 email = $5,
+			 email_verified = $6,
+			 phone = $7,
+			 phone_verified = $8,
+			 updated_at = $9
+		 where id = $10;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		user.FullName,
+		user.DateOfBirth,
+		user.Gender,
+		user.AvatarFileKey,
+		user.Email,
+		user.EmailVerified,
+		user.Phone,
+		user.PhoneVerified,
+		user.UpdatedAt,
+		user.ID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateUserPassword(ctx context.Context, user models.User) error {
+	const op = "postgres.UpdateUserPassword"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update users
+		 set password_hash = $1,
+			 must_change_password = $2,
+			 updated_at = $3
+		 where id = $4;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		user.PasswordHash,
+		user.MustChangePassword,
+		user.UpdatedAt,
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveUser(ctx context.Context, user models.User) error {
+	const op = "postgres.RemoveUser"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update users
+		 set deleted = $1,
+			 updated_at = $2
+		 where id = $3;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		user.Deleted,
+		user.UpdatedAt,
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RolesByUserID(ctx context.Context, userID int64) ([]models.Role, error) {
+	const op = "postgres.RolesByUserID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 r.id,
+			 r.code,
+			 r.name,
+			 r.description,
+			 r.active,
+			 r.deleted,
+			 r.created_at,
+			 r.updated_at
+		 from roles r
+			 join user_roles ur on ur.role_id = r.id
+		 where r.active is true and r.deleted is false and ur.user_id = $1;`)
+	if err != nil {
+		return []models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role := models.Role{}
+		err = rows.Scan(
+			&role.ID,
+			&role.Code,
+			&role.Name,
+			&role.Description,
+			&role.Active,
+			&role.Deleted,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) RolesByClientID(ctx context.Context, clientID int64) ([]models.Role, error) {
+	const op = "postgres.RolesByClientID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			  r.id,
+			  r.code,
+			  r.name,
+			  r.description,
+			  r.active,
+			  r.deleted,
+			  r.created_at,
+			  r.updated_at
+		  from roles r
+			  join client_default_roles cdr on cdr.role_id = r.id
+		  where r.active is true and r.deleted is false and cdr.client_id = $1;`)
+	if err != nil {
+		return []models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role := models.Role{}
+		err = rows.Scan(
+			&role.ID,
+			&role.Code,
+			&role.Name,
+			&role.Description,
+			&role.Active,
+			&role.Deleted,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) PermissionsByUserID(ctx context.Context, userID int64) ([]models.Permission, error) {
+	const op = "postgres.PermissionsByUserID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+			 join role_permissions rp on rp.permission_id = p.id
+			 join user_roles ur on ur.role_id = rp.role_id
+			 join roles r on r.id = ur.role_id
+		 where p.active is true and p.deleted is false and r.active is true and r.deleted is false
+			 and ur.user_id = $1;`)
+	if err != nil {
+		return []models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	permissions := make([]models.Permission, 0)
+	for rows.Next() {
+		permission := models.Permission{}
+		err = rows.Scan(
+			&permission.ID,
+			&permission.Code,
+			&permission.Description,
+			&permission.Active,
+			&permission.Deleted,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (s *Storage) PermissionsByClientID(ctx context.Context, clientID int64) ([]models.Permission, error) {
+	const op = "postgres.PermissionsByClientID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+			 join client_permissions cp on cp.permission_id = p.id
+		 where p.active is true and p.deleted is false and cp.client_id = $1;`)
+	if err != nil {
+		return []models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	permissions := make([]models.Permission, 0)
+	for rows.Next() {
+		permission := models.Permission{}
+		err = rows.Scan(
+			&permission.ID,
+			&permission.Code,
+			&permission.Description,
+			&permission.Active,
+			&permission.Deleted,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (s *Storage) PermissionsByRoleCodes(ctx context.Context, roleCodes []string) ([]models.Permission, error) {
+	const op = "postgres.PermissionsByRoleCodes"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+			 join role_permissions rp on rp.permission_id = p.id
+			 join roles r on rp.role_id = r.id
+		 where p.active is true and p.deleted is false and r.active is true and r.deleted is false
+			 and r.code = any($1);`)
+	if err != nil {
+		return []models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(roleCodes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	permissions := make([]models.Permission, 0)
+	for rows.Next() {
+		permission := models.Permission{}
+		err = rows.Scan(
+			&permission.ID,
+			&permission.Code,
+			&permission.Description,
+			&permission.Active,
+			&permission.Deleted,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (s *Storage) SessionsByUserID(ctx context.Context, userID int64) ([]models.Session, error) {
+	const op = "postgres.SessionsByUserID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 s.id,
+			 s.user_id,
+			 s.client_id,
+			 s.refresh_token,
+			 s.family_id,
+			 s.user_agent,
+			 s.fingerprint,
+			 s.expires_at,
+			 s.last_used_at,
+			 s.created_at,
+			 s.updated_at
+		 from sessions s
+		 where s.user_id = $1;`)
+	if err != nil {
+		return []models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	sessions := make([]models.Session, 0)
+	for rows.Next() {
+		session := models.Session{}
+		err = rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.ClientID,
+			&session.RefreshToken,
+			&session.FamilyID,
+			&session.UserAgent,
+			&session.Fingerprint,
+			&session.ExpiresAt,
+			&session.LastUsedAt,
+			&session.CreatedAt,
+			&session.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *Storage) Session(ctx context.Context, id int64) (models.Session, error) {
+	const op = "postgres.Session"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 s.id,
+			 s.user_id,
+			 s.client_id,
+			 s.refresh_token,
+			 s.family_id,
+			 s.user_agent,
+			 s.fingerprint,
+			 s.expires_at,
+			 s.last_used_at,
+			 s.created_at,
+			 s.updated_at
+		 from sessions s
+		 where s.id = $1;`)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, id)
+
+	var session models.Session
+	err = row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.ClientID,
+		&session.RefreshToken,
+		&session.FamilyID,
+		&session.UserAgent,
+		&session.Fingerprint,
+		&session.ExpiresAt,
+		&session.LastUsedAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Session{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) SessionByRefreshTokenID(ctx context.Context, refreshTokenID string) (models.Session, error) {
+	const op = "postgres.SessionByRefreshTokenID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 s.id,
+			 s.user_id,
+			 s.client_id,
+			 s.refresh_token,
+			 s.family_id,
+			 s.user_agent,
+			 s.fingerprint,
+			 s.expires_at,
+			 s.last_used_at,
+			 s.created_at,
+			 s.updated_at
+		 from sessions s
+		 where s.refresh_token = $1;`)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, refreshTokenID)
+
+	var session models.Session
+	err = row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.ClientID,
+		&session.RefreshToken,
+		&session.FamilyID,
+		&session.UserAgent,
+		&session.Fingerprint,
+		&session.ExpiresAt,
+		&session.LastUsedAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Session{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) CreateSession(ctx context.Context, session models.Session) (int64, error) {
+	const op = "postgres.CreateSession"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into sessions (
+			 user_id,
+			 client_id,
+			 refresh_token,
+			 family_id,
+			 user_agent,
+			 fingerprint,
+			 expires_at,
+			 last_used_at,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		session.UserID,
+		session.ClientID,
+		session.RefreshToken,
+		session.FamilyID,
+		session.UserAgent,
+		session.Fingerprint,
+		session.ExpiresAt,
+		session.LastUsedAt,
+		session.CreatedAt,
+		session.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateSession(ctx context.Context, session models.Session) error {
+	const op = "postgres.UpdateSession"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update sessions
+		 set user_id = $1,
+			 client_id = $2,
+			 refresh_token = $3,
+			 family_id = $4,
+			 user_agent = $5,
+			 fingerprint = $6,
+			 expires_at = $7,
+			 last_used_at = $8,
+			 created_at = $9,
+			 updated_at = $10
+		 where id = $11;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		session.UserID,
+		session.ClientID,
+		session.RefreshToken,
+		session.FamilyID,
+		session.UserAgent,
+		session.Fingerprint,
+		session.ExpiresAt,
+		session.LastUsedAt,
+		session.CreatedAt,
+		session.UpdatedAt,
+		session.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveSession(ctx context.Context, id int64) error {
+	const op = "postgres.RemoveSession"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from sessions where id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SessionsByFamilyID(ctx context.Context, familyID string) ([]models.Session, error) {
+	const op = "postgres.SessionsByFamilyID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 s.id,
+			 s.user_id,
+			 s.client_id,
+			 s.refresh_token,
+			 s.family_id,
+			 s.user_agent,
+			 s.fingerprint,
+			 s.expires_at,
+			 s.last_used_at,
+			 s.created_at,
+			 s.updated_at
+		 from sessions s
+		 where s.family_id = $1;`)
+	if err != nil {
+		return []models.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	sessions := make([]models.Session, 0)
+	for rows.Next() {
+		session := models.Session{}
+		err = rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.ClientID,
+			&session.RefreshToken,
+			&session.FamilyID,
+			&session.UserAgent,
+			&session.Fingerprint,
+			&session.ExpiresAt,
+			&session.LastUsedAt,
+			&session.CreatedAt,
+			&session.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *Storage) ConsumedRefreshTokenByID(
+	ctx context.Context,
+	refreshTokenID string,
+) (models.ConsumedRefreshToken, error) {
+	const op = "postgres.ConsumedRefreshTokenByID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 t.id,
+			 t.refresh_token,
+			 t.family_id,
+			 t.user_id,
+			 t.expires_at,
+			 t.created_at,
+			 t.updated_at
+		 from consumed_refresh_tokens t
+		 where t.refresh_token = $1;`)
+	if err != nil {
+		return models.ConsumedRefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, refreshTokenID)
+
+	var token models.ConsumedRefreshToken
+	err = row.Scan(
+		&token.ID,
+		&token.RefreshToken,
+		&token.FamilyID,
+		&token.UserID,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ConsumedRefreshToken{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.ConsumedRefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (s *Storage) CreateConsumedRefreshToken(ctx context.Context, token models.ConsumedRefreshToken) (int64, error) {
+	const op = "postgres.CreateConsumedRefreshToken"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into consumed_refresh_tokens (
+			 refresh_token,
+			 family_id,
+			 user_id,
+			 expires_at,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		token.RefreshToken,
+		token.FamilyID,
+		token.UserID,
+		token.ExpiresAt,
+		token.CreatedAt,
+		token.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) ClientByCodeAndUserID(ctx context.Context, code string, userID int64) (models.Client, error) {
+	const op = "postgres.ClientByCodeAndUserID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 c.id,
+			 c.name,
+			 c.code,
+			 c.secret_key,
+			 c.max_sessions,
+			 c.session_eviction_policy,
+			 c.require_verified_email,
+			 c.require_mfa,
+			 c.deleted,
+			 c.created_at,
+			 c.updated_at
+		 from clients c
+			 join user_clients uc on uc.client_id = c.id
+		 where c.code = $1 and uc.user_id = $2;`)
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, code, userID)
+
+	var client models.Client
+	err = row.Scan(
+		&client.ID,
+		&client.Name,
+		&client.Code,
+		&client.SecretKey,
+		&client.MaxSessions,
+		&client.SessionEvictionPolicy,
+		&client.RequireVerifiedEmail,
+		&client.RequireMFA,
+		&client.Deleted,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Client{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) ClientByCode(ctx context.Context, code string) (models.Client, error) {
+	const op = "postgres.ClientByCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 c.id,
+			 c.name,
+			 c.code,
+			 c.secret_key,
+			 c.max_sessions,
+			 c.session_eviction_policy,
+			 c.require_verified_email,
+			 c.require_mfa,
+			 c.deleted,
+			 c.created_at,
+			 c.updated_at
+		 from clients c
+		 where c.code = $1;`)
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, code)
+
+	var client models.Client
+	err = row.Scan(
+		&client.ID,
+		&client.Name,
+		&client.Code,
+		&client.SecretKey,
+		&client.MaxSessions,
+		&client.SessionEvictionPolicy,
+		&client.RequireVerifiedEmail,
+		&client.RequireMFA,
+		&client.Deleted,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Client{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) ClientAudiences(ctx context.Context, clientID int64) ([]models.Audience, error) {
+	const op = "postgres.ClientAudiences"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 ca.id,
+			 ca.client_id,
+			 ca.url,
+			 ca.created_at,
+			 ca.updated_at
+		 from client_audiences ca
+		 where ca.client_id = $1;`)
+	if err != nil {
+		return []models.Audience{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	audiences := make([]models.Audience, 0)
+	for rows.Next() {
+		audience := models.Audience{}
+		err = rows.Scan(
+			&audience.ID,
+			&audience.ClientID,
+			&audience.URL,
+			&audience.CreatedAt,
+			&audience.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		audiences = append(audiences, audience)
+	}
+
+	return audiences, nil
+}
+
+func (s *Storage) CreateUserClientLink(ctx context.Context, userClientLink models.UserClientLink) (int64, error) {
+	const op = "postgres.CreateUserClientLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into user_clients (user_id, client_id, created_at, updated_at)
+		 values ($1, $2, $3, $4)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		userClientLink.UserID,
+		userClientLink.ClientID,
+		userClientLink.CreatedAt,
+		userClientLink.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) CreateUserRoleLink(ctx context.Context, userRoleLink models.UserRoleLink) (int64, error) {
+	const op = "postgres.CreateUserRoleLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into user_roles (user_id, role_id, created_at, updated_at)
+		 values ($1, $2, $3, $4)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		userRoleLink.UserID,
+		userRoleLink.RoleID,
+		userRoleLink.CreatedAt,
+		userRoleLink.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) ClientRedirectURIs(ctx context.Context, clientID int64) ([]models.RedirectURI, error) {
+	const op = "postgres.ClientRedirectURIs"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 cru.id,
+			 cru.client_id,
+			 cru.uri,
+			 cru.created_at,
+			 cru.updated_at
+		 from client_redirect_uris cru
+		 where cru.client_id = $1;`)
+	if err != nil {
+		return []models.RedirectURI{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	redirectURIs := make([]models.RedirectURI, 0)
+	for rows.Next() {
+		redirectURI := models.RedirectURI{}
+		err = rows.Scan(
+			&redirectURI.ID,
+			&redirectURI.ClientID,
+			&redirectURI.URI,
+			&redirectURI.CreatedAt,
+			&redirectURI.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		redirectURIs = append(redirectURIs, redirectURI)
+	}
+
+	return redirectURIs, nil
+}
+
+func (s *Storage) AuthorizationCodeByHash(ctx context.Context, codeHash string) (models.AuthorizationCode, error) {
+	const op = "postgres.AuthorizationCodeByHash"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 ac.id,
+			 ac.code_hash,
+			 ac.user_id,
+			 ac.client_id,
+			 ac.redirect_uri,
+			 ac.code_challenge,
+			 ac.code_challenge_method,
+			 ac.nonce,
+			 ac.auth_time,
+			 ac.expires_at,
+			 ac.used,
+			 ac.created_at,
+			 ac.updated_at
+		 from authorization_codes ac
+		 where ac.code_hash = $1;`)
+	if err != nil {
+		return models.AuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, codeHash)
+
+	var code models.AuthorizationCode
+	err = row.Scan(
+		&code.ID,
+		&code.CodeHash,
+		&code.UserID,
+		&code.ClientID,
+		&code.RedirectURI,
+		&code.CodeChallenge,
+		&code.CodeChallengeMethod,
+		&code.Nonce,
+		&code.AuthTime,
+		&code.ExpiresAt,
+		&code.Used,
+		&code.CreatedAt,
+		&code.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AuthorizationCode{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.AuthorizationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, nil
+}
+
+func (s *Storage) CreateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) (int64, error) {
+	const op = "postgres.CreateAuthorizationCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into authorization_codes (
+			 code_hash,
+			 user_id,
+			 client_id,
+			 redirect_uri,
+			 code_challenge,
+			 code_challenge_method,
+			 nonce,
+			 auth_time,
+			 expires_at,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		code.CodeHash,
+		code.UserID,
+		code.ClientID,
+		code.RedirectURI,
+		code.CodeChallenge,
+		code.CodeChallengeMethod,
+		code.Nonce,
+		code.AuthTime,
+		code.ExpiresAt,
+		code.Used,
+		code.CreatedAt,
+		code.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateAuthorizationCode(ctx context.Context, code models.AuthorizationCode) error {
+	const op = "postgres.UpdateAuthorizationCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update authorization_codes
+		 set used = $1,
+			 updated_at = $2
+		 where id = $3 and used = false;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		code.Used,
+		code.UpdatedAt,
+		code.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The code can be redeemed only once, so the code which is already used is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) PasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	const op = "postgres.PasswordResetTokenByHash"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 prt.id,
+			 prt.token_hash,
+			 prt.user_id,
+			 prt.expires_at,
+			 prt.used,
+			 prt.created_at,
+			 prt.updated_at
+		 from password_reset_tokens prt
+		 where prt.token_hash = $1;`)
+	if err != nil {
+		return models.PasswordResetToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, tokenHash)
+
+	var token models.PasswordResetToken
+	err = row.Scan(
+		&token.ID,
+		&token.TokenHash,
+		&token.UserID,
+		&token.ExpiresAt,
+		&token.Used,
+		&token.CreatedAt,
+		&token.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PasswordResetToken{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.PasswordResetToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (s *Storage) CreatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) (int64, error) {
+	const op = "postgres.CreatePasswordResetToken"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into password_reset_tokens (
+			 token_hash,
+			 user_id,
+			 expires_at,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		token.TokenHash,
+		token.UserID,
+		token.ExpiresAt,
+		token.Used,
+		token.CreatedAt,
+		token.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdatePasswordResetToken(ctx context.Context, token models.PasswordResetToken) error {
+	const op = "postgres.UpdatePasswordResetToken"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update password_reset_tokens
+		 set used = $1,
+			 updated_at = $2
+		 where id = $3 and used = false;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		token.Used,
+		token.UpdatedAt,
+		token.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The token can be redeemed only once, so the token which is already used is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) VerificationCode(ctx context.Context, userID int64, channel string) (models.VerificationCode, error) {
+	const op = "postgres.VerificationCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 vc.id,
+			 vc.user_id,
+			 vc.channel,
+			 vc.target,
+			 vc.code_hash,
+			 vc.expires_at,
+			 vc.attempts,
+			 vc.used,
+			 vc.created_at,
+			 vc.updated_at
+		 from verification_codes vc
+		 where vc.user_id = $1 and vc.channel = $2;`)
+	if err != nil {
+		return models.VerificationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, userID, channel)
+
+	var code models.VerificationCode
+	err = row.Scan(
+		&code.ID,
+		&code.UserID,
+		&code.Channel,
+		&code.Target,
+		&code.CodeHash,
+		&code.ExpiresAt,
+		&code.Attempts,
+		&code.Used,
+		&code.CreatedAt,
+		&code.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.VerificationCode{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.VerificationCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, nil
+}
+
+func (s *Storage) CreateVerificationCode(ctx context.Context, code models.VerificationCode) (int64, error) {
+	const op = "postgres.CreateVerificationCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into verification_codes (
+			 user_id,
+			 channel,
+			 target,
+			 code_hash,
+			 expires_at,
+			 attempts,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		code.UserID,
+		code.Channel,
+		code.Target,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.Attempts,
+		code.Used,
+		code.CreatedAt,
+		code.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateVerificationCode(ctx context.Context, code models.VerificationCode) error {
+	const op = "postgres.UpdateVerificationCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update verification_codes
+		 set target = $1,
+			 code_hash = $2,
+			 expires_at = $3,
+			 attempts = $4,
+			 used = $5,
+			 updated_at = $6
+		 where id = $7;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		code.Target,
+		code.CodeHash,
+		code.ExpiresAt,
+		code.Attempts,
+		code.Used,
+		code.UpdatedAt,
+		code.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) TOTPCredential(ctx context.Context, userID int64) (models.TOTPCredential, error) {
+	const op = "postgres.TOTPCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 tc.id,
+			 tc.user_id,
+			 tc.secret,
+			 tc.confirmed,
+			 tc.last_used_step,
+			 tc.created_at,
+			 tc.updated_at
+		 from totp_credentials tc
+		 where tc.user_id = $1;`)
+	if err != nil {
+		return models.TOTPCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, userID)
+
+	var credential models.TOTPCredential
+	err = row.Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.Secret,
+		&credential.Confirmed,
+		&credential.LastUsedStep,
+		&credential.CreatedAt,
+		&credential.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.TOTPCredential{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.TOTPCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential, nil
+}
+
+func (s *Storage) CreateTOTPCredential(ctx context.Context, credential models.TOTPCredential) (int64, error) {
+	const op = "postgres.CreateTOTPCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into totp_credentials (
+			 user_id,
+			 secret,
+			 confirmed,
+			 last_used_step,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		credential.UserID,
+		credential.Secret,
+		credential.Confirmed,
+		credential.LastUsedStep,
+		credential.CreatedAt,
+		credential.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// UpdateTOTPCredential updates the TOTP credential. The code of the secret can be used only once,
+// so the credential whose last used time step of the same secret is not before the given one is not found.
+func (s *Storage) UpdateTOTPCredential(ctx context.Context, credential models.TOTPCredential) error {
+	const op = "postgres.UpdateTOTPCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update totp_credentials
+		 set secret = $1,
+			 confirmed = $2,
+			 last_used_step = $3,
+			 updated_at = $4
+		 where id = $5 and (last_used_step < $3 or secret <> $1);`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		credential.Secret,
+		credential.Confirmed,
+		credential.LastUsedStep,
+		credential.UpdatedAt,
+		credential.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The code which is already used is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveTOTPCredential(ctx context.Context, id int64) error {
+	const op = "postgres.RemoveTOTPCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from totp_credentials where id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) BackupCodes(ctx context.Context, userID int64) ([]models.BackupCode, error) {
+	const op = "postgres.BackupCodes"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 bc.id,
+			 bc.user_id,
+			 bc.code_hash,
+			 bc.used,
+			 bc.created_at,
+			 bc.updated_at
+		 from backup_codes bc
+		 where bc.user_id = $1
+		 order by bc.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	codes := make([]models.BackupCode, 0)
+	for rows.Next() {
+		code := models.BackupCode{}
+		err = rows.Scan(
+			&code.ID,
+			&code.UserID,
+			&code.CodeHash,
+			&code.Used,
+			&code.CreatedAt,
+			&code.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+func (s *Storage) CreateBackupCode(ctx context.Context, code models.BackupCode) (int64, error) {
+	const op = "postgres.CreateBackupCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into backup_codes (
+			 user_id,
+			 code_hash,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		code.UserID,
+		code.CodeHash,
+		code.Used,
+		code.CreatedAt,
+		code.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateBackupCode(ctx context.Context, code models.BackupCode) error {
+	const op = "postgres.UpdateBackupCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update backup_codes
+		 set used = $1,
+			 updated_at = $2
+		 where id = $3 and used = false;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		code.Used,
+		code.UpdatedAt,
+		code.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The code can be used only once, so the code which is already used is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveBackupCode(ctx context.Context, id int64) error {
+	const op = "postgres.RemoveBackupCode"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from backup_codes where id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) MFAChallengeByHash(ctx context.Context, tokenHash string) (models.MFAChallenge, error) {
+	const op = "postgres.MFAChallengeByHash"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 mc.id,
+			 mc.token_hash,
+			 mc.user_id,
+			 mc.client_id,
+			 mc.user_agent,
+			 mc.fingerprint,
+			 mc.issuer,
+			 mc.nonce,
+			 mc.expires_at,
+			 mc.attempts,
+			 mc.used,
+			 mc.created_at,
+			 mc.updated_at
+		 from mfa_challenges mc
+		 where mc.token_hash = $1;`)
+	if err != nil {
+		return models.MFAChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, tokenHash)
+
+	var challenge models.MFAChallenge
+	err = row.Scan(
+		&challenge.ID,
+		&challenge.TokenHash,
+		&challenge.UserID,
+		&challenge.ClientID,
+		&challenge.UserAgent,
+		&challenge.Fingerprint,
+		&challenge.Issuer,
+		&challenge.Nonce,
+		&challenge.ExpiresAt,
+		&challenge.Attempts,
+		&challenge.Used,
+		&challenge.CreatedAt,
+		&challenge.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.MFAChallenge{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.MFAChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return challenge, nil
+}
+
+func (s *Storage) CreateMFAChallenge(ctx context.Context, challenge models.MFAChallenge) (int64, error) {
+	const op = "postgres.CreateMFAChallenge"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into mfa_challenges (
+			 token_hash,
+			 user_id,
+			 client_id,
+			 user_agent,
+			 fingerprint,
+			 issuer,
+			 nonce,
+			 expires_at,
+			 attempts,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		challenge.TokenHash,
+		challenge.UserID,
+		challenge.ClientID,
+		challenge.UserAgent,
+		challenge.Fingerprint,
+		challenge.Issuer,
+		challenge.Nonce,
+		challenge.ExpiresAt,
+		challenge.Attempts,
+		challenge.Used,
+		challenge.CreatedAt,
+		challenge.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// UpdateMFAChallenge counts the attempt of the MFA challenge atomically and marks the challenge as used if it is.
+// The challenge which is already used or has no attempts left is not found, so the concurrent verifications
+// never exceed the maximum attempts.
+func (s *Storage) UpdateMFAChallenge(ctx context.Context, challenge models.MFAChallenge, maxAttempts int) error {
+	const op = "postgres.UpdateMFAChallenge"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update mfa_challenges
+		 set attempts = attempts + 1,
+			 used = $1,
+			 updated_at = $2
+		 where id = $3 and used = false and attempts < $4;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		challenge.Used,
+		challenge.UpdatedAt,
+		challenge.ID,
+		maxAttempts,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The challenge which is already used or has no attempts left is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) WebAuthnCredentials(ctx context.Context, userID int64) ([]models.WebAuthnCredential, error) {
+	const op = "postgres.WebAuthnCredentials"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 wc.id,
+			 wc.user_id,
+			 wc.credential_id,
+			 wc.public_key,
+			 wc.sign_count,
+			 wc.name,
+			 wc.created_at,
+			 wc.updated_at
+		 from webauthn_credentials wc
+		 where wc.user_id = $1
+		 order by wc.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	credentials := make([]models.WebAuthnCredential, 0)
+	for rows.Next() {
+		credential := models.WebAuthnCredential{}
+		err = rows.Scan(
+			&credential.ID,
+			&credential.UserID,
+			&credential.CredentialID,
+			&credential.PublicKey,
+			&credential.SignCount,
+			&credential.Name,
+			&credential.CreatedAt,
+			&credential.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, nil
+}
+
+func (s *Storage) WebAuthnCredential(ctx context.Context, credentialID string) (models.WebAuthnCredential, error) {
+	const op = "postgres.WebAuthnCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 wc.id,
+			 wc.user_id,
+			 wc.credential_id,
+			 wc.public_key,
+			 wc.sign_count,
+			 wc.name,
+			 wc.created_at,
+			 wc.updated_at
+		 from webauthn_credentials wc
+		 where wc.credential_id = $1;`)
+	if err != nil {
+		return models.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, credentialID)
+
+	var credential models.WebAuthnCredential
+	err = row.Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.CredentialID,
+		&credential.PublicKey,
+		&credential.SignCount,
+		&credential.Name,
+		&credential.CreatedAt,
+		&credential.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return credential, nil
+}
+
+func (s *Storage) CreateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) (int64, error) {
+	const op = "postgres.CreateWebAuthnCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into webauthn_credentials (
+			 user_id,
+			 credential_id,
+			 public_key,
+			 sign_count,
+			 name,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		credential.UserID,
+		credential.CredentialID,
+		credential.PublicKey,
+		credential.SignCount,
+		credential.Name,
+		credential.CreatedAt,
+		credential.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateWebAuthnCredential(ctx context.Context, credential models.WebAuthnCredential) error {
+	const op = "postgres.UpdateWebAuthnCredential"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update webauthn_credentials
+		 set sign_count = $1,
+			 name = $2,
+			 updated_at = $3
+		 where id = $4;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		credential.SignCount,
+		credential.Name,
+		credential.UpdatedAt,
+		credential.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) WebAuthnChallenge(ctx context.Context, challenge string) (models.WebAuthnChallenge, error) {
+	const op = "postgres.WebAuthnChallenge"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 wc.id,
+			 wc.challenge,
+			 wc.ceremony,
+			 wc.user_id,
+			 wc.client_id,
+			 wc.nonce,
+			 wc.expires_at,
+			 wc.used,
+			 wc.created_at,
+			 wc.updated_at
+		 from webauthn_challenges wc
+		 where wc.challenge = $1;`)
+	if err != nil {
+		return models.WebAuthnChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, challenge)
+
+	var webAuthnChallenge models.WebAuthnChallenge
+	err = row.Scan(
+		&webAuthnChallenge.ID,
+		&webAuthnChallenge.Challenge,
+		&webAuthnChallenge.Ceremony,
+		&webAuthnChallenge.UserID,
+		&webAuthnChallenge.ClientID,
+		&webAuthnChallenge.Nonce,
+		&webAuthnChallenge.ExpiresAt,
+		&webAuthnChallenge.Used,
+		&webAuthnChallenge.CreatedAt,
+		&webAuthnChallenge.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WebAuthnChallenge{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.WebAuthnChallenge{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webAuthnChallenge, nil
+}
+
+func (s *Storage) CreateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) (int64, error) {
+	const op = "postgres.CreateWebAuthnChallenge"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into webauthn_challenges (
+			 challenge,
+			 ceremony,
+			 user_id,
+			 client_id,
+			 nonce,
+			 expires_at,
+			 used,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		challenge.Challenge,
+		challenge.Ceremony,
+		challenge.UserID,
+		challenge.ClientID,
+		challenge.Nonce,
+		challenge.ExpiresAt,
+		challenge.Used,
+		challenge.CreatedAt,
+		challenge.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateWebAuthnChallenge(ctx context.Context, challenge models.WebAuthnChallenge) error {
+	const op = "postgres.UpdateWebAuthnChallenge"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update webauthn_challenges
+		 set used = $1,
+			 updated_at = $2
+		 where id = $3 and used = false;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		challenge.Used,
+		challenge.UpdatedAt,
+		challenge.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The challenge can be used only once, so the challenge which is already used is not found.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) LoginAttempt(ctx context.Context, kind, key string) (models.LoginAttempt, error) {
+	const op = "postgres.LoginAttempt"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 la.id,
+			 la.kind,
+			 la.key,
+			 la.failures,
+			 la.last_failure_at,
+			 la.locked_until,
+			 la.created_at,
+			 la.updated_at
+		 from login_attempts la
+		 where la.kind = $1 and la.key = $2;`)
+	if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, kind, key)
+
+	var attempt models.LoginAttempt
+	err = row.Scan(
+		&attempt.ID,
+		&attempt.Kind,
+		&attempt.Key,
+		&attempt.Failures,
+		&attempt.LastFailureAt,
+		&attempt.LockedUntil,
+		&attempt.CreatedAt,
+		&attempt.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attempt, nil
+}
+
+// FailLoginAttempt counts the failed login of the attempt atomically. The attempt is created with one failure
+// or the failures of the saved attempt are incremented, they start over if the last failure is before
+// the window start. The last failure time is set from the attempt, the lock is kept. The saved attempt is returned.
+func (s *Storage) FailLoginAttempt(
+	ctx context.Context,
+	attempt models.LoginAttempt,
+	windowStart time.Time,
+) (models.LoginAttempt, error) {
+	const op = "postgres.FailLoginAttempt"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into login_attempts (
+			 kind,
+			 key,
+			 failures,
+			 last_failure_at,
+			 locked_until,
+			 created_at,
+			 updated_at)
+		 values($1, $2, 1, $3, null, $4, $5)
+		 on conflict (kind, key) do update
+		 set failures = case
+				 when login_attempts.last_failure_at < $6 then 1
+				 else login_attempts.failures + 1
+			 end,
+			 last_failure_at = excluded.last_failure_at,
+			 updated_at = excluded.updated_at
+		 returning
+			 id,
+			 kind,
+			 key,
+			 failures,
+			 last_failure_at,
+			 locked_until,
+			 created_at,
+			 updated_at;`)
+	if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(
+		ctx,
+		attempt.Kind,
+		attempt.Key,
+		attempt.LastFailureAt,
+		attempt.CreatedAt,
+		attempt.UpdatedAt,
+		windowStart,
+	)
+
+	var savedAttempt models.LoginAttempt
+	err = row.Scan(
+		&savedAttempt.ID,
+		&savedAttempt.Kind,
+		&savedAttempt.Key,
+		&savedAttempt.Failures,
+		&savedAttempt.LastFailureAt,
+		&savedAttempt.LockedUntil,
+		&savedAttempt.CreatedAt,
+		&savedAttempt.UpdatedAt,
+	)
+	if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return savedAttempt, nil
+}
+
+func (s *Storage) UpdateLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	const op = "postgres.UpdateLoginAttempt"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update login_attempts
+		 set failures = $1,
+			 last_failure_at = $2,
+			 locked_until = $3,
+			 updated_at = $4
+		 where id = $5;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		attempt.Failures,
+		attempt.LastFailureAt,
+		attempt.LockedUntil,
+		attempt.UpdatedAt,
+		attempt.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveLoginAttempt(ctx context.Context, id int64) error {
+	const op = "postgres.RemoveLoginAttempt"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from login_attempts where id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveExpiredLoginAttempts removes the attempts of the kind with the last failure before the given time,
+// unless they are still locked at now, so the attempts of the sprayed usernames do not pile up.
+func (s *Storage) RemoveExpiredLoginAttempts(ctx context.Context, kind string, before, now time.Time) error {
+	const op = "postgres.RemoveExpiredLoginAttempts"
+
+	// The attempts locked by the concurrent failed logins are skipped, they are removed by the later logins.
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`delete from login_attempts
+		 where id in (
+			 select id
+			 from login_attempts
+			 where kind = $1
+			   and last_failure_at < $2
+			   and (locked_until is null or locked_until < $3)
+			 for update skip locked);`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, kind, before, now)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Client(ctx context.Context, id int64) (models.Client, error) {
+	const op = "postgres.Client"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 c.id,
+			 c.name,
+			 c.code,
+			 c.secret_key,
+			 c.max_sessions,
+			 c.session_eviction_policy,
+			 c.require_verified_email,
+			 c.require_mfa,
+			 c.deleted,
+			 c.created_at,
+			 c.updated_at
+		 from clients c
+		 where c.id = $1;`)
+	if err != nil {
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, id)
+
+	var client models.Client
+	err = row.Scan(
+		&client.ID,
+		&client.Name,
+		&client.Code,
+		&client.SecretKey,
+		&client.MaxSessions,
+		&client.SessionEvictionPolicy,
+		&client.RequireVerifiedEmail,
+		&client.RequireMFA,
+		&client.Deleted,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Client{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return client, nil
+}
+
+func (s *Storage) Clients(ctx context.Context) ([]models.Client, error) {
+	const op = "postgres.Clients"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 c.id,
+			 c.name,
+			 c.code,
+			 c.secret_key,
+			 c.max_sessions,
+			 c.session_eviction_policy,
+			 c.require_verified_email,
+			 c.require_mfa,
+			 c.deleted,
+			 c.created_at,
+			 c.updated_at
+		 from clients c
+		 where c.deleted is false
+		 order by c.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	clients := make([]models.Client, 0)
+	for rows.Next() {
+		client := models.Client{}
+		err = rows.Scan(
+			&client.ID,
+			&client.Name,
+			&client.Code,
+			&client.SecretKey,
+			&client.MaxSessions,
+			&client.SessionEvictionPolicy,
+			&client.RequireVerifiedEmail,
+			&client.RequireMFA,
+			&client.Deleted,
+			&client.CreatedAt,
+			&client.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+func (s *Storage) CreateClient(ctx context.Context, client models.Client) (int64, error) {
+	const op = "postgres.CreateClient"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into clients (
+			 name,
+			 code,
+			 secret_key,
+			 max_sessions,
+			 session_eviction_policy,
+			 require_verified_email,
+			 require_mfa,
+			 deleted,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		client.Name,
+		client.Code,
+		client.SecretKey,
+		client.MaxSessions,
+		client.SessionEvictionPolicy,
+		client.RequireVerifiedEmail,
+		client.RequireMFA,
+		client.Deleted,
+		client.CreatedAt,
+		client.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateClient(ctx context.Context, client models.Client) error {
+	const op = "postgres.UpdateClient"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update clients
+		 set name = $1,
+			 code = $2,
+			 secret_key = $3,
+			 max_sessions = $4,
+			 session_eviction_policy = $5,
+			 require_verified_email = $6,
+			 require_mfa = $7,
+			 deleted = $8,
+			 created_at = $9,
+			 updated_at = $10
+		 where id = $11;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		client.Name,
+		client.Code,
+		client.SecretKey,
+		client.MaxSessions,
+		client.SessionEvictionPolicy,
+		client.RequireVerifiedEmail,
+		client.RequireMFA,
+		client.Deleted,
+		client.CreatedAt,
+		client.UpdatedAt,
+		client.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) CreateAudience(ctx context.Context, audience models.Audience) (int64, error) {
+	const op = "postgres.CreateAudience"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into client_audiences (client_id, url, created_at, updated_at)
+		 values ($1, $2, $3, $4)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		audience.ClientID,
+		audience.URL,
+		audience.CreatedAt,
+		audience.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RemoveAudience(ctx context.Context, id int64) error {
+	const op = "postgres.RemoveAudience"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from client_audiences where id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RolesByCodes(ctx context.Context, codes []string) ([]models.Role, error) {
+	const op = "postgres.RolesByCodes"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 r.id,
+			 r.code,
+			 r.name,
+			 r.description,
+			 r.active,
+			 r.deleted,
+			 r.created_at,
+			 r.updated_at
+		 from roles r
+		 where r.deleted is false and r.code = any($1);`)
+	if err != nil {
+		return []models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(codes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role := models.Role{}
+		err = rows.Scan(
+			&role.ID,
+			&role.Code,
+			&role.Name,
+			&role.Description,
+			&role.Active,
+			&role.Deleted,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) CreateClientDefaultRoleLink(
+	ctx context.Context,
+	clientDefaultRoleLink models.ClientDefaultRoleLink,
+) (int64, error) {
+	const op = "postgres.CreateClientDefaultRoleLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into client_default_roles (client_id, role_id, created_at, updated_at)
+		 values ($1, $2, $3, $4)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		clientDefaultRoleLink.ClientID,
+		clientDefaultRoleLink.RoleID,
+		clientDefaultRoleLink.CreatedAt,
+		clientDefaultRoleLink.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RemoveClientDefaultRoleLinks(ctx context.Context, clientID int64) error {
+	const op = "postgres.RemoveClientDefaultRoleLinks"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `delete from client_default_roles where client_id = $1;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Role(ctx context.Context, id int64) (models.Role, error) {
+	const op = "postgres.Role"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 r.id,
+			 r.code,
+			 r.name,
+			 r.description,
+			 r.active,
+			 r.deleted,
+			 r.created_at,
+			 r.updated_at
+		 from roles r
+		 where r.id = $1;`)
+	if err != nil {
+		return models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, id)
+
+	var role models.Role
+	err = row.Scan(
+		&role.ID,
+		&role.Code,
+		&role.Name,
+		&role.Description,
+		&role.Active,
+		&role.Deleted,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Role{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role, nil
+}
+
+func (s *Storage) Roles(ctx context.Context, limit, offset int) ([]models.Role, error) {
+	const op = "postgres.Roles"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 r.id,
+			 r.code,
+			 r.name,
+			 r.description,
+			 r.active,
+			 r.deleted,
+			 r.created_at,
+			 r.updated_at
+		 from roles r
+		 where r.deleted is false
+		 order by r.id
+		 limit $1 offset $2;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role := models.Role{}
+		err = rows.Scan(
+			&role.ID,
+			&role.Code,
+			&role.Name,
+			&role.Description,
+			&role.Active,
+			&role.Deleted,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) RolesCount(ctx context.Context) (int64, error) {
+	const op = "postgres.RolesCount"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `select count(*) from roles where deleted is false;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var count int64
+	if err = stmt.QueryRowContext(ctx).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) CreateRole(ctx context.Context, role models.Role) (int64, error) {
+	const op = "postgres.CreateRole"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into roles (
+			 code,
+			 name,
+			 description,
+			 active,
+			 deleted,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6, $7)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		role.Code,
+		role.Name,
+		role.Description,
+		role.Active,
+		role.Deleted,
+		role.CreatedAt,
+		role.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateRole(ctx context.Context, role models.Role) error {
+	const op = "postgres.UpdateRole"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update roles
+		 set code = $1,
+			 name = $2,
+			 description = $3,
+			 active = $4,
+			 deleted = $5,
+			 created_at = $6,
+			 updated_at = $7
+		 where id = $8;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		role.Code,
+		role.Name,
+		role.Description,
+		role.Active,
+		role.Deleted,
+		role.CreatedAt,
+		role.UpdatedAt,
+		role.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Permission(ctx context.Context, id int64) (models.Permission, error) {
+	const op = "postgres.Permission"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+		 where p.id = $1;`)
+	if err != nil {
+		return models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, id)
+
+	var permission models.Permission
+	err = row.Scan(
+		&permission.ID,
+		&permission.Code,
+		&permission.Description,
+		&permission.Active,
+		&permission.Deleted,
+		&permission.CreatedAt,
+		&permission.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Permission{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permission, nil
+}
+
+func (s *Storage) Permissions(ctx context.Context, limit, offset int) ([]models.Permission, error) {
+	const op = "postgres.Permissions"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+		 where p.deleted is false
+		 order by p.id
+		 limit $1 offset $2;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	permissions := make([]models.Permission, 0)
+	for rows.Next() {
+		permission := models.Permission{}
+		err = rows.Scan(
+			&permission.ID,
+			&permission.Code,
+			&permission.Description,
+			&permission.Active,
+			&permission.Deleted,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (s *Storage) PermissionsCount(ctx context.Context) (int64, error) {
+	const op = "postgres.PermissionsCount"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx, `select count(*) from permissions where deleted is false;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var count int64
+	if err = stmt.QueryRowContext(ctx).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) CreatePermission(ctx context.Context, permission models.Permission) (int64, error) {
+	const op = "postgres.CreatePermission"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into permissions (
+			 code,
+			 description,
+			 active,
+			 deleted,
+			 created_at,
+			 updated_at)
+		 values($1, $2, $3, $4, $5, $6)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		permission.Code,
+		permission.Description,
+		permission.Active,
+		permission.Deleted,
+		permission.CreatedAt,
+		permission.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdatePermission(ctx context.Context, permission models.Permission) error {
+	const op = "postgres.UpdatePermission"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update permissions
+		 set code = $1,
+			 description = $2,
+			 active = $3,
+			 deleted = $4,
+			 created_at = $5,
+			 updated_at = $6
+		 where id = $7;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		permission.Code,
+		permission.Description,
+		permission.Active,
+		permission.Deleted,
+		permission.CreatedAt,
+		permission.UpdatedAt,
+		permission.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) PermissionsByRoleID(ctx context.Context, roleID int64) ([]models.Permission, error) {
+	const op = "postgres.PermissionsByRoleID"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 p.id,
+			 p.code,
+			 p.description,
+			 p.active,
+			 p.deleted,
+			 p.created_at,
+			 p.updated_at
+		 from permissions p
+			 join role_permissions rp on rp.permission_id = p.id
+		 where p.deleted is false and rp.role_id = $1
+		 order by p.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	permissions := make([]models.Permission, 0)
+	for rows.Next() {
+		permission := models.Permission{}
+		err = rows.Scan(
+			&permission.ID,
+			&permission.Code,
+			&permission.Description,
+			&permission.Active,
+			&permission.Deleted,
+			&permission.CreatedAt,
+			&permission.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (s *Storage) CreateRolePermissionLink(ctx context.Context, rolePermissionLink models.RolePermissionLink) (int64, error) {
+	const op = "postgres.CreateRolePermissionLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`insert into role_permissions (role_id, permission_id, created_at, updated_at)
+		 values ($1, $2, $3, $4)
+		 returning id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err = stmt.QueryRowContext(
+		ctx,
+		rolePermissionLink.RoleID,
+		rolePermissionLink.PermissionID,
+		rolePermissionLink.CreatedAt,
+		rolePermissionLink.UpdatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RemoveRolePermissionLink(ctx context.Context, roleID, permissionID int64) error {
+	const op = "postgres.RemoveRolePermissionLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`delete from role_permissions where role_id = $1 and permission_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, roleID, permissionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]models.User, error) {
+	const op = "postgres.Users"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 u.id,
+			 u.username,
+			 u.password_hash,
+			 u.fio,
+			 u.date_of_birth,
+			 u.gender,
+			 u.avatar_file_key,
+			 u.email,
+			 u.email_verified,
+			 u.phone,
+			 u.phone_verified,
+			 u.blocked,
+			 u.must_change_password,
+			 u.deleted,
+			 u.created_at,
+			 u.updated_at
+		 from users u
+		 where u.deleted = $1 and (u.username ilike $2 escape '\' or u.fio ilike $3 escape '\')
+		 order by u.id
+		 limit $4 offset $5;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pattern := likePattern(query)
+
+	rows, err := stmt.QueryContext(ctx, deleted, pattern, pattern, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		user := models.User{}
+		err = rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.PasswordHash,
+			&user.FullName,
+			&user.DateOfBirth,
+			&user.Gender,
+			&user.AvatarFileKey,
+			&user.Email,
+			&user.EmailVerified,
+			&user.Phone,
+			&user.PhoneVerified,
+			&user.Blocked,
+			&user.MustChangePassword,
+			&user.Deleted,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (s *Storage) UsersCount(ctx context.Context, query string, deleted bool) (int64, error) {
+	const op = "postgres.UsersCount"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select count(*)
+		 from users u
+		 where u.deleted = $1 and (u.username ilike $2 escape '\' or u.fio ilike $3 escape '\');`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pattern := likePattern(query)
+
+	var count int64
+	if err = stmt.QueryRowContext(ctx, deleted, pattern, pattern).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) UpdateUserStatus(ctx context.Context, user models.User) error {
+	const op = "postgres.UpdateUserStatus"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`update users
+		 set blocked = $1,
+			 deleted = $2,
+			 updated_at = $3
+		 where id = $4;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		user.Blocked,
+		user.Deleted,
+		user.UpdatedAt,
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UserRoles(ctx context.Context, userID int64) ([]models.Role, error) {
+	const op = "postgres.UserRoles"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 r.id,
+			 r.code,
+			 r.name,
+			 r.description,
+			 r.active,
+			 r.deleted,
+			 r.created_at,
+			 r.updated_at
+		 from roles r
+			 join user_roles ur on ur.role_id = r.id
+		 where r.deleted is false and ur.user_id = $1
+		 order by r.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role := models.Role{}
+		err = rows.Scan(
+			&role.ID,
+			&role.Code,
+			&role.Name,
+			&role.Description,
+			&role.Active,
+			&role.Deleted,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) RemoveUserRoleLink(ctx context.Context, userID, roleID int64) error {
+	const op = "postgres.RemoveUserRoleLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`delete from user_roles where user_id = $1 and role_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UserClients(ctx context.Context, userID int64) ([]models.Client, error) {
+	const op = "postgres.UserClients"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`select
+			 c.id,
+			 c.name,
+			 c.code,
+			 c.secret_key,
+			 c.max_sessions,
+			 c.session_eviction_policy,
+			 c.require_verified_email,
+			 c.require_mfa,
+			 c.deleted,
+			 c.created_at,
+			 c.updated_at
+		 from clients c
+			 join user_clients uc on uc.client_id = c.id
+		 where c.deleted is false and uc.user_id = $1
+		 order by c.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	clients := make([]models.Client, 0)
+	for rows.Next() {
+		client := models.Client{}
+		err = rows.Scan(
+			&client.ID,
+			&client.Name,
+			&client.Code,
+			&client.SecretKey,
+			&client.MaxSessions,
+			&client.SessionEvictionPolicy,
+			&client.RequireVerifiedEmail,
+			&client.RequireMFA,
+			&client.Deleted,
+			&client.CreatedAt,
+			&client.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+func (s *Storage) RemoveUserClientLink(ctx context.Context, userID, clientID int64) error {
+	const op = "postgres.RemoveUserClientLink"
+
+	stmt, err := s.conn(ctx).PrepareContext(ctx,
+		`delete from user_clients where user_id = $1 and client_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = stmt.ExecContext(ctx, userID, clientID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// likePattern returns the pattern of the like operator which matches the strings containing the query.
+func likePattern(query string) string {
+	return "%" + likeEscaper.Replace(query) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// txKey is the context key of the transaction the storage methods are executed in.
+type txKey struct{}
+
+// querier is the database handle the storage methods are executed on.
+// It is either the database or the transaction.
+type querier interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// Transaction executes the function in a transaction. The storage methods called with the context
+// passed to the function are executed in the transaction. If the function returns an error,
+// the transaction is rolled back and the error is returned, otherwise the transaction is committed.
+// If the context already carries a transaction, the function is executed in that transaction.
+func (s *Storage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "postgres.Transaction"
+
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("%s: %w", op, rollbackErr))
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// conn returns the transaction carried by the context or the database if there is no transaction.
+func (s *Storage) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return s.db
+}
@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+const migrationsPath = "../../../../migrations/sqlite"
+
+func Test_Storage_Conformance(t *testing.T) {
+	storagetest.Run(t, newTestBackend)
+}
+
+func newTestBackend(t *testing.T) storagetest.Backend {
+	t.Helper()
+
+	storagePath := filepath.Join(t.TempDir(), "sso.db")
+
+	m, err := migrate.New("file://"+migrationsPath, fmt.Sprintf("sqlite3://%s", storagePath))
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+
+	sourceErr, dbErr := m.Close()
+	require.NoError(t, sourceErr)
+	require.NoError(t, dbErr)
+
+	storage, err := New(storagePath)
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", storagePath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return storagetest.Backend{
+		Storage: storage,
+		Seed: storagetest.SQLSeed(func(ctx context.Context, query string) error {
+			_, err := db.ExecContext(ctx, query)
+
+			return err
+		}),
+	}
+}
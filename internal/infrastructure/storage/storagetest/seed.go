@@ -0,0 +1,108 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/guregu/null/v6"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/memory"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+)
+
+const (
+	testClientCode        = "test-client"
+	testRoleCode          = "test-role"
+	testPermissionCode    = "test-permission"
+	testAudience          = "https://api.example.com"
+	testRedirectURI       = "https://app.example.com/callback"
+	testClientMaxSessions = 3
+	testClientPolicy      = "lru"
+)
+
+// seedQueries are the queries which seed the test client with its default role, permission,
+// audience and redirect URI. The queries are written in the SQL dialect common to all SQL backends.
+var seedQueries = []string{
+	`insert into clients (name, code, secret_key, max_sessions, session_eviction_policy, deleted, created_at, updated_at)
+	 values ('Test client', '` + testClientCode + `', 'secret', 3, '` + testClientPolicy + `', false,
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into roles (code, name, active, deleted, created_at, updated_at)
+	 values ('` + testRoleCode + `', 'Test role', true, false, '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into permissions (code, active, deleted, created_at, updated_at)
+	 values ('` + testPermissionCode + `', true, false, '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into role_permissions (role_id, permission_id, created_at, updated_at)
+	 values ((select id from roles where code = '` + testRoleCode + `'),
+	         (select id from permissions where code = '` + testPermissionCode + `'),
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into client_default_roles (client_id, role_id, created_at, updated_at)
+	 values ((select id from clients where code = '` + testClientCode + `'),
+	         (select id from roles where code = '` + testRoleCode + `'),
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into client_permissions (client_id, permission_id, created_at, updated_at)
+	 values ((select id from clients where code = '` + testClientCode + `'),
+	         (select id from permissions where code = '` + testPermissionCode + `'),
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into client_audiences (client_id, url, created_at, updated_at)
+	 values ((select id from clients where code = '` + testClientCode + `'), '` + testAudience + `',
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+	`insert into client_redirect_uris (client_id, uri, created_at, updated_at)
+	 values ((select id from clients where code = '` + testClientCode + `'), '` + testRedirectURI + `',
+	         '2024-01-01 00:00:00', '2024-01-01 00:00:00');`,
+}
+
+// SQLSeed returns the seed function of the SQL backend. The exec function executes
+// the SQL statement on the storage database.
+func SQLSeed(exec func(ctx context.Context, query string) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, query := range seedQueries {
+			if err := exec(ctx, query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// MemorySeed returns the seed function of the in-memory backend.
+func MemorySeed(storage *memory.Storage) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		clientID, err := storage.SeedClient(ctx, models.Client{
+			Name:                  "Test client",
+			Code:                  testClientCode,
+			SecretKey:             "secret",
+			MaxSessions:           null.Int32From(testClientMaxSessions),
+			SessionEvictionPolicy: null.StringFrom(testClientPolicy),
+		})
+		if err != nil {
+			return err
+		}
+
+		roleID, err := storage.SeedRole(ctx, models.Role{Code: testRoleCode, Name: "Test role", Active: true})
+		if err != nil {
+			return err
+		}
+
+		permissionID, err := storage.SeedPermission(ctx, models.Permission{Code: testPermissionCode, Active: true})
+		if err != nil {
+			return err
+		}
+
+		if err = storage.SeedRolePermission(ctx, roleID, permissionID); err != nil {
+			return err
+		}
+
+		if err = storage.SeedClientDefaultRole(ctx, clientID, roleID); err != nil {
+			return err
+		}
+
+		if err = storage.SeedClientPermission(ctx, clientID, permissionID); err != nil {
+			return err
+		}
+
+		if _, err = storage.SeedClientAudience(ctx, models.Audience{ClientID: clientID, URL: testAudience}); err != nil {
+			return err
+		}
+
+		_, err = storage.SeedClientRedirectURI(ctx, models.RedirectURI{ClientID: clientID, URI: testRedirectURI})
+
+		return err
+	}
+}
@@ -0,0 +1,1449 @@
+// Package storagetest provides the conformance test suite every storage backend must pass.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"github.com/guregu/null/v6"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Backend is the storage backend under test.
+// Seed seeds the test client with its default role, permission, audience and redirect URI,
+// the data which can not be created through the storage interface.
+type Backend struct {
+	Storage repository.Storage
+	Seed    func(ctx context.Context) error
+}
+
+// Factory creates a new backend with the empty migrated database for a test.
+type Factory func(t *testing.T) Backend
+
+// Run runs the conformance test suite. Every test gets a new backend from the factory.
+func Run(t *testing.T, newBackend Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, backend Backend)
+	}{
+		{name: "Users", test: testUsers},
+		{name: "Clients", test: testClients},
+		{name: "ClientAdministration", test: testClientAdministration},
+		{name: "RolesAndPermissions", test: testRolesAndPermissions},
+		{name: "RoleAdministration", test: testRoleAdministration},
+		{name: "UserAdministration", test: testUserAdministration},
+		{name: "Sessions", test: testSessions},
+		{name: "ConsumedRefreshTokens", test: testConsumedRefreshTokens},
+		{name: "AuthorizationCodes", test: testAuthorizationCodes},
+		{name: "PasswordResetTokens", test: testPasswordResetTokens},
+		{name: "UserContacts", test: testUserContacts},
+		{name: "VerificationCodes", test: testVerificationCodes},
+		{name: "MFA", test: testMFA},
+		{name: "WebAuthn", test: testWebAuthn},
+		{name: "LoginAttempts", test: testLoginAttempts},
+		{name: "Transactions", test: testTransactions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.test(t, newBackend(t))
+		})
+	}
+}
+
+func testUsers(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	dateOfBirth := time.Date(2000, 5, 16, 0, 0, 0, 0, time.UTC)
+	user := models.User{
+		Username:      "test",
+		PasswordHash:  "hash",
+		FullName:      "Test User",
+		DateOfBirth:   null.TimeFrom(dateOfBirth),
+		Gender:        null.Int16From(1),
+		AvatarFileKey: null.StringFrom("avatar"),
+		CreatedAt:     now(),
+		UpdatedAt:     now(),
+	}
+
+	id, err := storage.CreateUser(ctx, user)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreateUser(ctx, user)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.User(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, user.Username, saved.Username)
+	assert.Equal(t, user.PasswordHash, saved.PasswordHash)
+	assert.Equal(t, user.FullName, saved.FullName)
+	assertTimeEqual(t, dateOfBirth, saved.DateOfBirth.Time)
+	assert.Equal(t, user.Gender, saved.Gender)
+	assert.Equal(t, user.AvatarFileKey, saved.AvatarFileKey)
+	assert.False(t, saved.Deleted)
+	assertTimeEqual(t, user.CreatedAt, saved.CreatedAt)
+
+	byUsername, err := storage.UserByUsername(ctx, user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, id, byUsername.ID)
+
+	saved.FullName = "Updated User"
+	saved.DateOfBirth = null.Time{}
+	saved.Gender = null.Int16{}
+	saved.AvatarFileKey = null.String{}
+	require.NoError(t, storage.UpdateUser(ctx, saved))
+
+	updated, err := storage.User(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated User", updated.FullName)
+	assert.False(t, updated.DateOfBirth.Valid)
+	assert.False(t, updated.Gender.Valid)
+	assert.False(t, updated.AvatarFileKey.Valid)
+
+	// Updating the profile keeps the credentials and the creation time.
+	profile := models.User{
+		ID:            id,
+		Username:      "ignored",
+		FullName:      "Profile User",
+		DateOfBirth:   null.TimeFrom(dateOfBirth),
+		Gender:        null.Int16From(2),
+		AvatarFileKey: null.StringFrom("new-avatar"),
+		UpdatedAt:     now(),
+	}
+	require.NoError(t, storage.UpdateUserProfile(ctx, profile))
+
+	updated, err = storage.User(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, user.Username, updated.Username)
+	assert.Equal(t, user.PasswordHash, updated.PasswordHash)
+	assert.Equal(t, "Profile User", updated.FullName)
+	assertTimeEqual(t, dateOfBirth, updated.DateOfBirth.Time)
+	assert.Equal(t, null.Int16From(2), updated.Gender)
+	assert.Equal(t, null.StringFrom("new-avatar"), updated.AvatarFileKey)
+	assertTimeEqual(t, user.CreatedAt, updated.CreatedAt)
+	assert.False(t, updated.MustChangePassword)
+
+	password := models.User{
+		ID:                 id,
+		Username:           "ignored",
+		PasswordHash:       "new-hash",
+		FullName:           "Ignored",
+		MustChangePassword: true,
+		UpdatedAt:          now(),
+	}
+	require.NoError(t, storage.UpdateUserPassword(ctx, password))
+
+	updated, err = storage.UserByUsername(ctx, user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", updated.PasswordHash)
+	assert.True(t, updated.MustChangePassword)
+	assert.Equal(t, "Profile User", updated.FullName)
+
+	updated.Deleted = true
+	require.NoError(t, storage.RemoveUser(ctx, updated))
+
+	removed, err := storage.User(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, removed.Deleted)
+
+	_, err = storage.User(ctx, id+1000)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	_, err = storage.UserByUsername(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testClients(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+	assert.Equal(t, testClientCode, client.Code)
+	assert.Equal(t, "secret", client.SecretKey)
+	assert.Equal(t, null.Int32From(testClientMaxSessions), client.MaxSessions)
+	assert.Equal(t, null.StringFrom(testClientPolicy), client.SessionEvictionPolicy)
+
+	_, err = storage.ClientByCode(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	userID := createUser(t, storage, "test")
+
+	_, err = storage.ClientByCodeAndUserID(ctx, testClientCode, userID)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	link := models.UserClientLink{UserID: userID, ClientID: client.ID, CreatedAt: now(), UpdatedAt: now()}
+	_, err = storage.CreateUserClientLink(ctx, link)
+	require.NoError(t, err)
+
+	_, err = storage.CreateUserClientLink(ctx, link)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	userClient, err := storage.ClientByCodeAndUserID(ctx, testClientCode, userID)
+	require.NoError(t, err)
+	assert.Equal(t, client.ID, userClient.ID)
+
+	audiences, err := storage.ClientAudiences(ctx, client.ID)
+	require.NoError(t, err)
+	require.Len(t, audiences, 1)
+	assert.Equal(t, testAudience, audiences[0].URL)
+
+	redirectURIs, err := storage.ClientRedirectURIs(ctx, client.ID)
+	require.NoError(t, err)
+	require.Len(t, redirectURIs, 1)
+	assert.Equal(t, testRedirectURI, redirectURIs[0].URI)
+}
+
+func testClientAdministration(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client := models.Client{
+		Name:                  "Admin client",
+		Code:                  "admin-client",
+		SecretKey:             "admin-secret",
+		MaxSessions:           null.Int32From(2),
+		SessionEvictionPolicy: null.StringFrom("reject"),
+		RequireVerifiedEmail:  true,
+		RequireMFA:            true,
+		CreatedAt:             now(),
+		UpdatedAt:             now(),
+	}
+
+	id, err := storage.CreateClient(ctx, client)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreateClient(ctx, client)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.Client(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, client.Name, saved.Name)
+	assert.Equal(t, client.Code, saved.Code)
+	assert.Equal(t, client.SecretKey, saved.SecretKey)
+	assert.Equal(t, client.MaxSessions, saved.MaxSessions)
+	assert.Equal(t, client.SessionEvictionPolicy, saved.SessionEvictionPolicy)
+	assert.True(t, saved.RequireVerifiedEmail)
+	assert.True(t, saved.RequireMFA)
+	assertTimeEqual(t, client.CreatedAt, saved.CreatedAt)
+
+	_, err = storage.Client(ctx, id+100)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// Audiences.
+	audienceID, err := storage.CreateAudience(ctx, models.Audience{
+		ClientID:  id,
+		URL:       "https://admin.example.com",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	audiences, err := storage.ClientAudiences(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, audiences, 1)
+	assert.Equal(t, "https://admin.example.com", audiences[0].URL)
+
+	require.NoError(t, storage.RemoveAudience(ctx, audienceID))
+
+	audiences, err = storage.ClientAudiences(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, audiences)
+
+	// Default roles.
+	roles, err := storage.RolesByCodes(ctx, []string{testRoleCode, "unknown"})
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, testRoleCode, roles[0].Code)
+
+	_, err = storage.CreateClientDefaultRoleLink(ctx, models.ClientDefaultRoleLink{
+		ClientID:  id,
+		RoleID:    roles[0].ID,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	clientRoles, err := storage.RolesByClientID(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, clientRoles, 1)
+
+	require.NoError(t, storage.RemoveClientDefaultRoleLinks(ctx, id))
+
+	clientRoles, err = storage.RolesByClientID(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, clientRoles)
+
+	// Updating and soft deletion.
+	saved.Name = "Renamed client"
+	saved.SecretKey = "rotated-secret"
+	saved.MaxSessions = null.Int32{}
+	saved.RequireVerifiedEmail = false
+	saved.RequireMFA = false
+	require.NoError(t, storage.UpdateClient(ctx, saved))
+
+	updated, err := storage.Client(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed client", updated.Name)
+	assert.Equal(t, "rotated-secret", updated.SecretKey)
+	assert.False(t, updated.MaxSessions.Valid)
+	assert.False(t, updated.RequireVerifiedEmail)
+	assert.False(t, updated.RequireMFA)
+
+	clients, err := storage.Clients(ctx)
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+
+	updated.Deleted = true
+	require.NoError(t, storage.UpdateClient(ctx, updated))
+
+	clients, err = storage.Clients(ctx)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.Equal(t, testClientCode, clients[0].Code)
+}
+
+func testRolesAndPermissions(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	clientRoles, err := storage.RolesByClientID(ctx, client.ID)
+	require.NoError(t, err)
+	require.Len(t, clientRoles, 1)
+	assert.Equal(t, testRoleCode, clientRoles[0].Code)
+
+	clientPermissions, err := storage.PermissionsByClientID(ctx, client.ID)
+	require.NoError(t, err)
+	require.Len(t, clientPermissions, 1)
+	assert.Equal(t, testPermissionCode, clientPermissions[0].Code)
+
+	rolePermissions, err := storage.PermissionsByRoleCodes(ctx, []string{testRoleCode, "unknown"})
+	require.NoError(t, err)
+	require.Len(t, rolePermissions, 1)
+	assert.Equal(t, testPermissionCode, rolePermissions[0].Code)
+
+	userID := createUser(t, storage, "test")
+
+	userRoles, err := storage.RolesByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, userRoles)
+
+	_, err = storage.CreateUserRoleLink(ctx, models.UserRoleLink{
+		UserID:    userID,
+		RoleID:    clientRoles[0].ID,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	userRoles, err = storage.RolesByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, userRoles, 1)
+	assert.Equal(t, testRoleCode, userRoles[0].Code)
+
+	userPermissions, err := storage.PermissionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, userPermissions, 1)
+	assert.Equal(t, testPermissionCode, userPermissions[0].Code)
+}
+
+func testRoleAdministration(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	role := models.Role{
+		Code:        "admin",
+		Name:        "Administrator",
+		Description: null.StringFrom("description"),
+		Active:      true,
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+
+	roleID, err := storage.CreateRole(ctx, role)
+	require.NoError(t, err)
+	assert.NotZero(t, roleID)
+
+	_, err = storage.CreateRole(ctx, role)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	// The migrations may create the permissions of the SSO itself.
+	migratedPermissions, err := storage.PermissionsCount(ctx)
+	require.NoError(t, err)
+
+	permissionIDs := make([]int64, 0)
+	for _, code := range []string{"users:read", "users:write"} {
+		permissionID, err := storage.CreatePermission(ctx, models.Permission{
+			Code:      code,
+			Active:    true,
+			CreatedAt: now(),
+			UpdatedAt: now(),
+		})
+		require.NoError(t, err)
+
+		permissionIDs = append(permissionIDs, permissionID)
+	}
+
+	_, err = storage.CreatePermission(ctx, models.Permission{Code: "users:read", CreatedAt: now(), UpdatedAt: now()})
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	for _, permissionID := range permissionIDs {
+		_, err = storage.CreateRolePermissionLink(ctx, models.RolePermissionLink{
+			RoleID:       roleID,
+			PermissionID: permissionID,
+			CreatedAt:    now(),
+			UpdatedAt:    now(),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = storage.CreateRolePermissionLink(ctx, models.RolePermissionLink{
+		RoleID:       roleID,
+		PermissionID: permissionIDs[0],
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	})
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	userID := createUser(t, storage, "test")
+	_, err = storage.CreateUserRoleLink(ctx, models.UserRoleLink{
+		UserID:    userID,
+		RoleID:    roleID,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	userPermissions, err := storage.PermissionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, userPermissions, 2)
+
+	// The inactive permission is listed for the role, but is not granted to the user.
+	permission, err := storage.Permission(ctx, permissionIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, "users:write", permission.Code)
+
+	permission.Active = false
+	permission.UpdatedAt = now()
+	require.NoError(t, storage.UpdatePermission(ctx, permission))
+
+	rolePermissions, err := storage.PermissionsByRoleID(ctx, roleID)
+	require.NoError(t, err)
+	require.Len(t, rolePermissions, 2)
+	assert.False(t, rolePermissions[1].Active)
+
+	userPermissions, err = storage.PermissionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, userPermissions, 1)
+	assert.Equal(t, "users:read", userPermissions[0].Code)
+
+	// The detached permission is not granted to the user.
+	require.NoError(t, storage.RemoveRolePermissionLink(ctx, roleID, permissionIDs[0]))
+
+	rolePermissions, err = storage.PermissionsByRoleID(ctx, roleID)
+	require.NoError(t, err)
+	require.Len(t, rolePermissions, 1)
+	assert.Equal(t, permissionIDs[1], rolePermissions[0].ID)
+
+	userPermissions, err = storage.PermissionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, userPermissions)
+
+	// The permissions of the inactive role are not granted to the user.
+	permission.Active = true
+	require.NoError(t, storage.UpdatePermission(ctx, permission))
+
+	saved, err := storage.Role(ctx, roleID)
+	require.NoError(t, err)
+	assert.Equal(t, role.Code, saved.Code)
+	assert.Equal(t, role.Name, saved.Name)
+	assert.Equal(t, role.Description, saved.Description)
+	assert.True(t, saved.Active)
+	assertTimeEqual(t, role.CreatedAt, saved.CreatedAt)
+
+	saved.Active = false
+	require.NoError(t, storage.UpdateRole(ctx, saved))
+
+	userRoles, err := storage.RolesByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, userRoles)
+
+	userPermissions, err = storage.PermissionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, userPermissions)
+
+	// The deleted roles and permissions are not listed.
+	roles, err := storage.Roles(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+
+	count, err := storage.RolesCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	saved.Deleted = true
+	require.NoError(t, storage.UpdateRole(ctx, saved))
+
+	roles, err = storage.Roles(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	count, err = storage.RolesCount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	permissions, err := storage.Permissions(ctx, 1, int(migratedPermissions)+1)
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, permissionIDs[1], permissions[0].ID)
+
+	permission.Deleted = true
+	require.NoError(t, storage.UpdatePermission(ctx, permission))
+
+	permissions, err = storage.Permissions(ctx, 10, int(migratedPermissions))
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, permissionIDs[0], permissions[0].ID)
+
+	count, err = storage.PermissionsCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migratedPermissions+1, count)
+
+	_, err = storage.Role(ctx, roleID+100)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	_, err = storage.Permission(ctx, permissionIDs[1]+100)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testUserAdministration(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	aliceID := createUser(t, storage, "alice")
+	createUser(t, storage, "bob")
+	createUser(t, storage, "100%_user")
+
+	// Searching.
+	users, err := storage.Users(ctx, "", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 3)
+	assert.Equal(t, "alice", users[0].Username)
+
+	users, err = storage.Users(ctx, "", false, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "bob", users[0].Username)
+
+	users, err = storage.Users(ctx, "ALI", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, aliceID, users[0].ID)
+
+	// The wildcards of the query are matched literally.
+	users, err = storage.Users(ctx, "%_", false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "100%_user", users[0].Username)
+
+	count, err := storage.UsersCount(ctx, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Blocking and soft deletion.
+	alice, err := storage.User(ctx, aliceID)
+	require.NoError(t, err)
+	assert.False(t, alice.Blocked)
+
+	alice.Blocked = true
+	alice.UpdatedAt = now()
+	require.NoError(t, storage.UpdateUserStatus(ctx, alice))
+
+	blocked, err := storage.UserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.True(t, blocked.Blocked)
+
+	blocked.Deleted = true
+	require.NoError(t, storage.UpdateUserStatus(ctx, blocked))
+
+	count, err = storage.UsersCount(ctx, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	users, err = storage.Users(ctx, "", true, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, aliceID, users[0].ID)
+
+	// Roles, the inactive ones included.
+	seededRoles, err := storage.RolesByCodes(ctx, []string{testRoleCode})
+	require.NoError(t, err)
+	require.Len(t, seededRoles, 1)
+	role := seededRoles[0]
+
+	inactiveRoleID, err := storage.CreateRole(ctx, models.Role{
+		Code:      "inactive",
+		Name:      "Inactive",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	for _, roleID := range []int64{role.ID, inactiveRoleID} {
+		_, err = storage.CreateUserRoleLink(ctx, models.UserRoleLink{
+			UserID:    aliceID,
+			RoleID:    roleID,
+			CreatedAt: now(),
+			UpdatedAt: now(),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = storage.CreateUserRoleLink(ctx, models.UserRoleLink{
+		UserID:    aliceID,
+		RoleID:    role.ID,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	roles, err := storage.UserRoles(ctx, aliceID)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, testRoleCode, roles[0].Code)
+	assert.Equal(t, "inactive", roles[1].Code)
+
+	require.NoError(t, storage.RemoveUserRoleLink(ctx, aliceID, inactiveRoleID))
+
+	roles, err = storage.UserRoles(ctx, aliceID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+
+	// Clients.
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	_, err = storage.CreateUserClientLink(ctx, models.UserClientLink{
+		UserID:    aliceID,
+		ClientID:  client.ID,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	clients, err := storage.UserClients(ctx, aliceID)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.Equal(t, testClientCode, clients[0].Code)
+
+	require.NoError(t, storage.RemoveUserClientLink(ctx, aliceID, client.ID))
+
+	clients, err = storage.UserClients(ctx, aliceID)
+	require.NoError(t, err)
+	assert.Empty(t, clients)
+}
+
+func testSessions(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	userID := createUser(t, storage, "test")
+
+	session := models.Session{
+		UserID:       userID,
+		ClientID:     null.IntFrom(client.ID),
+		RefreshToken: "refresh-token-1",
+		FamilyID:     "family-1",
+		UserAgent:    "user agent",
+		Fingerprint:  "fingerprint",
+		ExpiresAt:    now().Add(time.Hour),
+		LastUsedAt:   now(),
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	}
+
+	id, err := storage.CreateSession(ctx, session)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreateSession(ctx, session)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	legacySession := session
+	legacySession.ClientID = null.Int{}
+	legacySession.RefreshToken = "refresh-token-2"
+	legacySession.FamilyID = "family-2"
+	legacyID, err := storage.CreateSession(ctx, legacySession)
+	require.NoError(t, err)
+
+	saved, err := storage.Session(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, userID, saved.UserID)
+	assert.Equal(t, null.IntFrom(client.ID), saved.ClientID)
+	assert.Equal(t, session.RefreshToken, saved.RefreshToken)
+	assert.Equal(t, session.FamilyID, saved.FamilyID)
+	assert.Equal(t, session.UserAgent, saved.UserAgent)
+	assert.Equal(t, session.Fingerprint, saved.Fingerprint)
+	assertTimeEqual(t, session.ExpiresAt, saved.ExpiresAt)
+	assertTimeEqual(t, session.LastUsedAt, saved.LastUsedAt)
+
+	legacy, err := storage.Session(ctx, legacyID)
+	require.NoError(t, err)
+	assert.False(t, legacy.ClientID.Valid)
+
+	byRefreshToken, err := storage.SessionByRefreshTokenID(ctx, session.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, id, byRefreshToken.ID)
+
+	byFamily, err := storage.SessionsByFamilyID(ctx, session.FamilyID)
+	require.NoError(t, err)
+	require.Len(t, byFamily, 1)
+	assert.Equal(t, id, byFamily[0].ID)
+
+	byUser, err := storage.SessionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, byUser, 2)
+
+	lastUsedAt := now().Add(time.Minute)
+	saved.LastUsedAt = lastUsedAt
+	require.NoError(t, storage.UpdateSession(ctx, saved))
+
+	updated, err := storage.Session(ctx, id)
+	require.NoError(t, err)
+	assertTimeEqual(t, lastUsedAt, updated.LastUsedAt)
+
+	require.NoError(t, storage.RemoveSession(ctx, id))
+
+	_, err = storage.Session(ctx, id)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	_, err = storage.SessionByRefreshTokenID(ctx, session.RefreshToken)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testConsumedRefreshTokens(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	userID := createUser(t, storage, "test")
+
+	token := models.ConsumedRefreshToken{
+		RefreshToken: "refresh-token",
+		FamilyID:     "family",
+		UserID:       userID,
+		ExpiresAt:    now().Add(time.Hour),
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	}
+
+	id, err := storage.CreateConsumedRefreshToken(ctx, token)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreateConsumedRefreshToken(ctx, token)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.ConsumedRefreshTokenByID(ctx, token.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, token.FamilyID, saved.FamilyID)
+	assert.Equal(t, userID, saved.UserID)
+	assertTimeEqual(t, token.ExpiresAt, saved.ExpiresAt)
+
+	_, err = storage.ConsumedRefreshTokenByID(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testAuthorizationCodes(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	userID := createUser(t, storage, "test")
+
+	code := models.AuthorizationCode{
+		CodeHash:            "code-hash",
+		UserID:              userID,
+		ClientID:            client.ID,
+		RedirectURI:         testRedirectURI,
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: "S256",
+		Nonce:               "nonce",
+		AuthTime:            now(),
+		ExpiresAt:           now().Add(time.Minute),
+		CreatedAt:           now(),
+		UpdatedAt:           now(),
+	}
+
+	id, err := storage.CreateAuthorizationCode(ctx, code)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreateAuthorizationCode(ctx, code)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.AuthorizationCodeByHash(ctx, code.CodeHash)
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, client.ID, saved.ClientID)
+	assert.Equal(t, code.CodeChallenge, saved.CodeChallenge)
+	assert.Equal(t, code.Nonce, saved.Nonce)
+	assertTimeEqual(t, code.AuthTime, saved.AuthTime)
+	assert.False(t, saved.Used)
+
+	// The code can be redeemed only once.
+	saved.Used = true
+	saved.UpdatedAt = now()
+	require.NoError(t, storage.UpdateAuthorizationCode(ctx, saved))
+	assert.ErrorIs(t, storage.UpdateAuthorizationCode(ctx, saved), infrastructure.ErrEntityNotFound)
+
+	redeemed, err := storage.AuthorizationCodeByHash(ctx, code.CodeHash)
+	require.NoError(t, err)
+	assert.True(t, redeemed.Used)
+
+	_, err = storage.AuthorizationCodeByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testPasswordResetTokens(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	userID := createUser(t, storage, "test")
+
+	token := models.PasswordResetToken{
+		TokenHash: "token-hash",
+		UserID:    userID,
+		ExpiresAt: now().Add(time.Hour),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+
+	id, err := storage.CreatePasswordResetToken(ctx, token)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	_, err = storage.CreatePasswordResetToken(ctx, token)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.PasswordResetTokenByHash(ctx, token.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, userID, saved.UserID)
+	assertTimeEqual(t, token.ExpiresAt, saved.ExpiresAt)
+	assert.False(t, saved.Used)
+
+	// The token can be redeemed only once.
+	saved.Used = true
+	saved.UpdatedAt = now()
+	require.NoError(t, storage.UpdatePasswordResetToken(ctx, saved))
+	assert.ErrorIs(t, storage.UpdatePasswordResetToken(ctx, saved), infrastructure.ErrEntityNotFound)
+
+	redeemed, err := storage.PasswordResetTokenByHash(ctx, token.TokenHash)
+	require.NoError(t, err)
+	assert.True(t, redeemed.Used)
+
+	_, err = storage.PasswordResetTokenByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testUserContacts(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	userID := createUser(t, storage, "test")
+	otherUserID := createUser(t, storage, "other")
+
+	user, err := storage.User(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, user.Email.Valid)
+	assert.False(t, user.Phone.Valid)
+
+	user.Email = null.StringFrom("test@example.com")
+	user.EmailVerified = true
+	user.Phone = null.StringFrom("+15550100")
+	user.UpdatedAt = now()
+	require.NoError(t, storage.UpdateUserProfile(ctx, user))
+
+	saved, err := storage.UserByUsername(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", saved.Email.String)
+	assert.True(t, saved.EmailVerified)
+	assert.Equal(t, "+15550100", saved.Phone.String)
+	assert.False(t, saved.PhoneVerified)
+
+	// The verified contacts are unique, the unverified contacts and the users without contacts don't conflict.
+	otherUser, err := storage.User(ctx, otherUserID)
+	require.NoError(t, err)
+
+	otherUser.Email = null.StringFrom("test@example.com")
+	otherUser.Phone = null.StringFrom("+15550100")
+	otherUser.UpdatedAt = now()
+	require.NoError(t, storage.UpdateUserProfile(ctx, otherUser))
+
+	otherUser.EmailVerified = true
+	assert.ErrorIs(t, storage.UpdateUserProfile(ctx, otherUser), infrastructure.ErrEntityExists)
+
+	// The phone is verified by the other user first.
+	otherUser.EmailVerified = false
+	otherUser.PhoneVerified = true
+	require.NoError(t, storage.UpdateUserProfile(ctx, otherUser))
+
+	user.PhoneVerified = true
+	assert.ErrorIs(t, storage.UpdateUserProfile(ctx, user), infrastructure.ErrEntityExists)
+}
+
+func testVerificationCodes(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	userID := createUser(t, storage, "test")
+
+	code := models.VerificationCode{
+		UserID:    userID,
+		Channel:   "email",
+		Target:    "test@example.com",
+		CodeHash:  "code-hash",
+		ExpiresAt: now().Add(time.Hour),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+
+	id, err := storage.CreateVerificationCode(ctx, code)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	// The user has one verification code per channel.
+	_, err = storage.CreateVerificationCode(ctx, code)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	saved, err := storage.VerificationCode(ctx, userID, "email")
+	require.NoError(t, err)
+	assert.Equal(t, id, saved.ID)
+	assert.Equal(t, "test@example.com", saved.Target)
+	assert.Equal(t, "code-hash", saved.CodeHash)
+	assertTimeEqual(t, code.ExpiresAt, saved.ExpiresAt)
+	assert.Zero(t, saved.Attempts)
+	assert.False(t, saved.Used)
+
+	saved.CodeHash = "new-code-hash"
+	saved.Attempts = 2
+	saved.Used = true
+	saved.UpdatedAt = now()
+	require.NoError(t, storage.UpdateVerificationCode(ctx, saved))
+
+	updated, err := storage.VerificationCode(ctx, userID, "email")
+	require.NoError(t, err)
+	assert.Equal(t, "new-code-hash", updated.CodeHash)
+	assert.Equal(t, 2, updated.Attempts)
+	assert.True(t, updated.Used)
+
+	_, err = storage.VerificationCode(ctx, userID, "phone")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testMFA(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	userID := createUser(t, storage, "test")
+
+	// TOTP credentials.
+	credential := models.TOTPCredential{
+		UserID:    userID,
+		Secret:    "secret",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+
+	credentialID, err := storage.CreateTOTPCredential(ctx, credential)
+	require.NoError(t, err)
+	assert.NotZero(t, credentialID)
+
+	// The user has one TOTP credential.
+	_, err = storage.CreateTOTPCredential(ctx, credential)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	savedCredential, err := storage.TOTPCredential(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, credentialID, savedCredential.ID)
+	assert.Equal(t, "secret", savedCredential.Secret)
+	assert.False(t, savedCredential.Confirmed)
+	assert.Zero(t, savedCredential.LastUsedStep)
+
+	savedCredential.Confirmed = true
+	savedCredential.LastUsedStep = 42
+	savedCredential.UpdatedAt = now()
+	require.NoError(t, storage.UpdateTOTPCredential(ctx, savedCredential))
+
+	updatedCredential, err := storage.TOTPCredential(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, updatedCredential.Confirmed)
+	assert.Equal(t, int64(42), updatedCredential.LastUsedStep)
+
+	// The code of the same secret can be used only once.
+	err = storage.UpdateTOTPCredential(ctx, savedCredential)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	savedCredential.LastUsedStep = 43
+	require.NoError(t, storage.UpdateTOTPCredential(ctx, savedCredential))
+
+	// The new secret starts the time steps over.
+	savedCredential.Secret = "new-secret"
+	savedCredential.Confirmed = false
+	savedCredential.LastUsedStep = 0
+	require.NoError(t, storage.UpdateTOTPCredential(ctx, savedCredential))
+
+	updatedCredential, err = storage.TOTPCredential(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-secret", updatedCredential.Secret)
+	assert.Zero(t, updatedCredential.LastUsedStep)
+
+	require.NoError(t, storage.RemoveTOTPCredential(ctx, credentialID))
+
+	_, err = storage.TOTPCredential(ctx, userID)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// Backup codes.
+	for _, hash := range []string{"code-hash-1", "code-hash-2"} {
+		_, err = storage.CreateBackupCode(ctx, models.BackupCode{
+			UserID:    userID,
+			CodeHash:  hash,
+			CreatedAt: now(),
+			UpdatedAt: now(),
+		})
+		require.NoError(t, err)
+	}
+
+	codes, err := storage.BackupCodes(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, codes, 2)
+	assert.Equal(t, "code-hash-1", codes[0].CodeHash)
+	assert.False(t, codes[0].Used)
+
+	codes[0].Used = true
+	codes[0].UpdatedAt = now()
+	require.NoError(t, storage.UpdateBackupCode(ctx, codes[0]))
+
+	// The backup code can be used only once.
+	err = storage.UpdateBackupCode(ctx, codes[0])
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	require.NoError(t, storage.RemoveBackupCode(ctx, codes[1].ID))
+
+	codes, err = storage.BackupCodes(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, codes, 1)
+	assert.True(t, codes[0].Used)
+
+	// MFA challenges.
+	challenge := models.MFAChallenge{
+		TokenHash:   "token-hash",
+		UserID:      userID,
+		ClientID:    client.ID,
+		UserAgent:   "agent",
+		Fingerprint: "fingerprint",
+		Issuer:      "issuer",
+		Nonce:       "nonce",
+		ExpiresAt:   now().Add(time.Minute),
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+
+	challengeID, err := storage.CreateMFAChallenge(ctx, challenge)
+	require.NoError(t, err)
+	assert.NotZero(t, challengeID)
+
+	_, err = storage.CreateMFAChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	savedChallenge, err := storage.MFAChallengeByHash(ctx, "token-hash")
+	require.NoError(t, err)
+	assert.Equal(t, challengeID, savedChallenge.ID)
+	assert.Equal(t, userID, savedChallenge.UserID)
+	assert.Equal(t, challenge.ClientID, savedChallenge.ClientID)
+	assert.Equal(t, "agent", savedChallenge.UserAgent)
+	assert.Equal(t, "fingerprint", savedChallenge.Fingerprint)
+	assert.Equal(t, "issuer", savedChallenge.Issuer)
+	assert.Equal(t, "nonce", savedChallenge.Nonce)
+	assertTimeEqual(t, challenge.ExpiresAt, savedChallenge.ExpiresAt)
+
+	// The attempts are counted by the storage.
+	savedChallenge.UpdatedAt = now()
+	for range 2 {
+		require.NoError(t, storage.UpdateMFAChallenge(ctx, savedChallenge, 3))
+	}
+
+	updatedChallenge, err := storage.MFAChallengeByHash(ctx, "token-hash")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updatedChallenge.Attempts)
+	assert.False(t, updatedChallenge.Used)
+
+	savedChallenge.Used = true
+	require.NoError(t, storage.UpdateMFAChallenge(ctx, savedChallenge, 3))
+
+	updatedChallenge, err = storage.MFAChallengeByHash(ctx, "token-hash")
+	require.NoError(t, err)
+	assert.Equal(t, 3, updatedChallenge.Attempts)
+	assert.True(t, updatedChallenge.Used)
+
+	// The challenge can be used only once.
+	err = storage.UpdateMFAChallenge(ctx, savedChallenge, 5)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// The challenge has no attempts left once they reach the maximum.
+	challenge.TokenHash = "exhausted-token-hash"
+	challenge.ID, err = storage.CreateMFAChallenge(ctx, challenge)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.UpdateMFAChallenge(ctx, challenge, 1))
+	err = storage.UpdateMFAChallenge(ctx, challenge, 1)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	_, err = storage.MFAChallengeByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testWebAuthn(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	seed(t, backend)
+
+	client, err := storage.ClientByCode(ctx, testClientCode)
+	require.NoError(t, err)
+
+	userID := createUser(t, storage, "test")
+
+	// WebAuthn credentials.
+	credential := models.WebAuthnCredential{
+		UserID:       userID,
+		CredentialID: "credential-id",
+		PublicKey:    []byte{0x01, 0x02, 0x03},
+		SignCount:    1,
+		Name:         "passkey",
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	}
+
+	credentialID, err := storage.CreateWebAuthnCredential(ctx, credential)
+	require.NoError(t, err)
+	assert.NotZero(t, credentialID)
+
+	// The credential ID is unique.
+	_, err = storage.CreateWebAuthnCredential(ctx, credential)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	credentials, err := storage.WebAuthnCredentials(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	assert.Equal(t, credentialID, credentials[0].ID)
+
+	savedCredential, err := storage.WebAuthnCredential(ctx, "credential-id")
+	require.NoError(t, err)
+	assert.Equal(t, credentialID, savedCredential.ID)
+	assert.Equal(t, userID, savedCredential.UserID)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, savedCredential.PublicKey)
+	assert.Equal(t, int64(1), savedCredential.SignCount)
+	assert.Equal(t, "passkey", savedCredential.Name)
+
+	savedCredential.SignCount = 5
+	savedCredential.UpdatedAt = now()
+	require.NoError(t, storage.UpdateWebAuthnCredential(ctx, savedCredential))
+
+	updatedCredential, err := storage.WebAuthnCredential(ctx, "credential-id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), updatedCredential.SignCount)
+
+	_, err = storage.WebAuthnCredential(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// WebAuthn challenges.
+	challenge := models.WebAuthnChallenge{
+		Challenge: "challenge",
+		Ceremony:  "login",
+		UserID:    null.IntFrom(userID),
+		ClientID:  null.IntFrom(client.ID),
+		Nonce:     "nonce",
+		ExpiresAt: now().Add(time.Minute),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+
+	challengeID, err := storage.CreateWebAuthnChallenge(ctx, challenge)
+	require.NoError(t, err)
+	assert.NotZero(t, challengeID)
+
+	_, err = storage.CreateWebAuthnChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityExists)
+
+	savedChallenge, err := storage.WebAuthnChallenge(ctx, "challenge")
+	require.NoError(t, err)
+	assert.Equal(t, challengeID, savedChallenge.ID)
+	assert.Equal(t, "login", savedChallenge.Ceremony)
+	assert.Equal(t, null.IntFrom(userID), savedChallenge.UserID)
+	assert.Equal(t, null.IntFrom(client.ID), savedChallenge.ClientID)
+	assert.Equal(t, "nonce", savedChallenge.Nonce)
+	assert.False(t, savedChallenge.Used)
+	assertTimeEqual(t, challenge.ExpiresAt, savedChallenge.ExpiresAt)
+
+	// The challenge without the user and the client.
+	_, err = storage.CreateWebAuthnChallenge(ctx, models.WebAuthnChallenge{
+		Challenge: "other-challenge",
+		Ceremony:  "login",
+		ExpiresAt: now().Add(time.Minute),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	})
+	require.NoError(t, err)
+
+	otherChallenge, err := storage.WebAuthnChallenge(ctx, "other-challenge")
+	require.NoError(t, err)
+	assert.False(t, otherChallenge.UserID.Valid)
+	assert.False(t, otherChallenge.ClientID.Valid)
+
+	savedChallenge.Used = true
+	savedChallenge.UpdatedAt = now()
+	require.NoError(t, storage.UpdateWebAuthnChallenge(ctx, savedChallenge))
+
+	updatedChallenge, err := storage.WebAuthnChallenge(ctx, "challenge")
+	require.NoError(t, err)
+	assert.True(t, updatedChallenge.Used)
+
+	// The challenge can be used only once.
+	err = storage.UpdateWebAuthnChallenge(ctx, savedChallenge)
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	_, err = storage.WebAuthnChallenge(ctx, "unknown")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+}
+
+func testLoginAttempts(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+
+	attempt := models.LoginAttempt{
+		Kind:          "username",
+		Key:           "test",
+		LastFailureAt: now(),
+		CreatedAt:     now(),
+		UpdatedAt:     now(),
+	}
+	windowStart := now().Add(-time.Hour)
+
+	savedAttempt, err := storage.FailLoginAttempt(ctx, attempt, windowStart)
+	require.NoError(t, err)
+	assert.NotZero(t, savedAttempt.ID)
+	assert.Equal(t, 1, savedAttempt.Failures)
+	assert.False(t, savedAttempt.LockedUntil.Valid)
+
+	id := savedAttempt.ID
+
+	// The failures of the same kind and key are counted in the same attempt.
+	savedAttempt, err = storage.FailLoginAttempt(ctx, attempt, windowStart)
+	require.NoError(t, err)
+	assert.Equal(t, id, savedAttempt.ID)
+	assert.Equal(t, 2, savedAttempt.Failures)
+
+	attempt.Kind = "ip"
+	otherAttempt, err := storage.FailLoginAttempt(ctx, attempt, windowStart)
+	require.NoError(t, err)
+	assert.NotEqual(t, id, otherAttempt.ID)
+	assert.Equal(t, 1, otherAttempt.Failures)
+
+	savedAttempt, err = storage.LoginAttempt(ctx, "username", "test")
+	require.NoError(t, err)
+	assert.Equal(t, id, savedAttempt.ID)
+	assert.Equal(t, 2, savedAttempt.Failures)
+	assert.False(t, savedAttempt.LockedUntil.Valid)
+
+	// The failures before the start of the window are forgotten.
+	attempt.Kind = "username"
+	attempt.LastFailureAt = now().Add(time.Minute)
+	savedAttempt, err = storage.FailLoginAttempt(ctx, attempt, now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, id, savedAttempt.ID)
+	assert.Equal(t, 1, savedAttempt.Failures)
+	assertTimeEqual(t, attempt.LastFailureAt, savedAttempt.LastFailureAt)
+
+	lockedUntil := now().Add(time.Hour)
+	savedAttempt.Failures = 5
+	savedAttempt.LockedUntil = null.TimeFrom(lockedUntil)
+	savedAttempt.UpdatedAt = now()
+	require.NoError(t, storage.UpdateLoginAttempt(ctx, savedAttempt))
+
+	updatedAttempt, err := storage.LoginAttempt(ctx, "username", "test")
+	require.NoError(t, err)
+	assert.Equal(t, 5, updatedAttempt.Failures)
+	require.True(t, updatedAttempt.LockedUntil.Valid)
+	assertTimeEqual(t, lockedUntil, updatedAttempt.LockedUntil.Time)
+
+	require.NoError(t, storage.RemoveLoginAttempt(ctx, id))
+
+	_, err = storage.LoginAttempt(ctx, "username", "test")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// The attempt of the other kind is kept.
+	_, err = storage.LoginAttempt(ctx, "ip", "test")
+	assert.NoError(t, err)
+
+	// The expired attempts are removed, the recent and the locked ones are kept.
+	for _, key := range []string{"expired", "locked", "recent"} {
+		attempt.Kind = "username"
+		attempt.Key = key
+		attempt.LastFailureAt = now().Add(-2 * time.Hour)
+		if key == "recent" {
+			attempt.LastFailureAt = now()
+		}
+
+		savedAttempt, err = storage.FailLoginAttempt(ctx, attempt, windowStart)
+		require.NoError(t, err)
+
+		if key == "locked" {
+			savedAttempt.LockedUntil = null.TimeFrom(now().Add(time.Hour))
+			require.NoError(t, storage.UpdateLoginAttempt(ctx, savedAttempt))
+		}
+	}
+
+	require.NoError(t, storage.RemoveExpiredLoginAttempts(ctx, "username", windowStart, now()))
+
+	_, err = storage.LoginAttempt(ctx, "username", "expired")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	for _, key := range []string{"locked", "recent"} {
+		_, err = storage.LoginAttempt(ctx, "username", key)
+		assert.NoError(t, err, key)
+	}
+
+	// The expired attempts of the other kind are kept.
+	require.NoError(t, storage.RemoveExpiredLoginAttempts(ctx, "username", now().Add(time.Hour), now()))
+
+	_, err = storage.LoginAttempt(ctx, "ip", "test")
+	assert.NoError(t, err)
+}
+
+func testTransactions(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	storage := backend.Storage
+	errInjected := errors.New("injected failure")
+
+	// Changes are committed.
+	err := storage.Transaction(ctx, func(ctx context.Context) error {
+		_, err := storage.CreateUser(ctx, newUser("committed"))
+
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = storage.UserByUsername(ctx, "committed")
+	assert.NoError(t, err)
+
+	// Changes are rolled back when the function fails.
+	err = storage.Transaction(ctx, func(ctx context.Context) error {
+		if _, err := storage.CreateUser(ctx, newUser("rolled back")); err != nil {
+			return err
+		}
+
+		return errInjected
+	})
+	assert.ErrorIs(t, err, errInjected)
+
+	_, err = storage.UserByUsername(ctx, "rolled back")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// Changes of the joined transaction are rolled back with the outer transaction.
+	err = storage.Transaction(ctx, func(ctx context.Context) error {
+		err := storage.Transaction(ctx, func(ctx context.Context) error {
+			_, err := storage.CreateUser(ctx, newUser("joined"))
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		return errInjected
+	})
+	assert.ErrorIs(t, err, errInjected)
+
+	_, err = storage.UserByUsername(ctx, "joined")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// Data changed in the transaction is visible in the transaction.
+	err = storage.Transaction(ctx, func(ctx context.Context) error {
+		id, err := storage.CreateUser(ctx, newUser("visible"))
+		if err != nil {
+			return err
+		}
+
+		_, err = storage.User(ctx, id)
+
+		return err
+	})
+	assert.NoError(t, err)
+}
+
+func seed(t *testing.T, backend Backend) {
+	t.Helper()
+
+	require.NoError(t, backend.Seed(context.Background()))
+}
+
+func createUser(t *testing.T, storage repository.Storage, username string) int64 {
+	t.Helper()
+
+	id, err := storage.CreateUser(context.Background(), newUser(username))
+	require.NoError(t, err)
+
+	return id
+}
+
+func newUser(username string) models.User {
+	return models.User{
+		Username:     username,
+		PasswordHash: "hash",
+		FullName:     username,
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	}
+}
+
+// now returns the current time with the precision all backends keep.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func assertTimeEqual(t *testing.T, expected, actual time.Time) {
+	t.Helper()
+
+	assert.True(t, expected.Equal(actual), "expected time %s, actual %s", expected, actual)
+}
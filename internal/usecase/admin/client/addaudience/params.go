@@ -0,0 +1,7 @@
+package addaudience
+
+// Params is a data for add client audience use-case.
+type Params struct {
+	ClientID int64
+	URL      string
+}
@@ -0,0 +1,90 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for create client use-case.
+type Repository interface {
+	Save(ctx context.Context, client *entity.Client) error
+}
+
+// UseCase is a use-case for creating a new client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new create client use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for creating a new client. The secret key of the client is generated.
+// If successful, the created client is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Client, error) {
+	const op = "usecase.admin.client.create"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("client code", data.Code),
+	)
+	log.Info("attempting to create client")
+
+	// Create client entity.
+	client, err := entity.NewClient(
+		data.Name,
+		data.Code,
+		entity.WithClientSessionLimit(data.MaxSessions, data.SessionEvictionPolicy),
+		entity.WithClientRequireVerifiedEmail(data.RequireVerifiedEmail),
+		entity.WithClientRequireMFA(data.RequireMFA),
+	)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidSessionLimit) {
+			log.Warn("invalid session limit", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidSessionLimit)
+		}
+
+		log.Error("error creating client", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, audience := range data.Audiences {
+		if err = client.AddAudience(audience); err != nil {
+			log.Warn("duplicate client audience", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientAudienceExists)
+		}
+	}
+
+	client.SetToCreate()
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &client); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			log.Warn("client already exists", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client created successfully", slog.Int64("client ID", client.ID))
+
+	return client, nil
+}
@@ -0,0 +1,87 @@
+package create
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	maxSessions := int32(2)
+	policy := enum.RejectNewSession
+	invalidPolicy := enum.SessionEvictionPolicyEnum("invalid")
+
+	testCases := []struct {
+		name          string
+		data          Params
+		expectedError error
+	}{
+		{
+			name: "creates client",
+			data: Params{
+				Name:                  "New client",
+				Code:                  "new-client",
+				MaxSessions:           &maxSessions,
+				SessionEvictionPolicy: &policy,
+				Audiences:             []string{"https://a.example.com", "https://b.example.com"},
+			},
+		},
+		{
+			name:          "client already exists",
+			data:          Params{Name: "Test client", Code: usecasetest.ClientCode},
+			expectedError: usecase.ErrClientExists,
+		},
+		{
+			name:          "invalid session limit",
+			data:          Params{Name: "New client", Code: "new-client", SessionEvictionPolicy: &invalidPolicy},
+			expectedError: usecase.ErrInvalidSessionLimit,
+		},
+		{
+			name: "duplicate audience",
+			data: Params{
+				Name:      "New client",
+				Code:      "new-client",
+				Audiences: []string{"https://a.example.com", "https://a.example.com"},
+			},
+			expectedError: usecase.ErrClientAudienceExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			log := usecasetest.Logger()
+
+			client, err := New(log, repository.NewClientRepository(log, fixture.Storage)).Execute(ctx, tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, client.SecretKey)
+
+			// The client and its audiences are saved.
+			storageClient, err := fixture.Storage.ClientByCode(ctx, tc.data.Code)
+			require.NoError(t, err)
+			assert.Equal(t, client.ID, storageClient.ID)
+			assert.Equal(t, client.SecretKey, storageClient.SecretKey)
+			assert.Equal(t, maxSessions, storageClient.MaxSessions.Int32)
+			assert.Equal(t, string(policy), storageClient.SessionEvictionPolicy.String)
+
+			audiences, err := fixture.Storage.ClientAudiences(ctx, client.ID)
+			require.NoError(t, err)
+			assert.Len(t, audiences, len(tc.data.Audiences))
+		})
+	}
+}
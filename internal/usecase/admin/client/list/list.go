@@ -0,0 +1,59 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for list clients use-case.
+type Repository interface {
+	Clients(ctx context.Context) ([]dto.ClientDetails, error)
+}
+
+// UseCase is a use-case for getting the list of clients.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new list clients use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting the list of clients. Deleted clients are not listed.
+func (uc *UseCase) Execute(ctx context.Context) ([]entity.Client, error) {
+	const op = "usecase.admin.client.list"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+
+	storageClientsData, err := uc.repo.Clients(ctx)
+	if err != nil {
+		log.Error("error getting clients from storage", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clients := make([]entity.Client, len(storageClientsData))
+	for i, storageClientData := range storageClientsData {
+		clients[i], err = entity.NewClient(
+			storageClientData.Name,
+			storageClientData.Code,
+			entity.WithClientDetails(storageClientData),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,7 @@
+package removeaudience
+
+// Params is a data for remove client audience use-case.
+type Params struct {
+	ClientID int64
+	URL      string
+}
@@ -0,0 +1,89 @@
+package removeaudience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for remove client audience use-case.
+type Repository interface {
+	ClientDetails(ctx context.Context, id int64) (dto.ClientDetails, error)
+
+	Save(ctx context.Context, client *entity.Client) error
+}
+
+// UseCase is a use-case for removing an audience from a client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new remove client audience use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for removing an audience from a client.
+// If successful, the updated client is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Client, error) {
+	const op = "usecase.admin.client.removeaudience"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", data.ClientID),
+		slog.String("audience", data.URL),
+	)
+	log.Info("attempting to remove client audience")
+
+	// Get client from storage.
+	storageClientData, err := uc.repo.ClientDetails(ctx, data.ClientID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientNotFound)
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create client entity.
+	client, err := entity.NewClient(
+		storageClientData.Name,
+		storageClientData.Code,
+		entity.WithClientDetails(storageClientData),
+	)
+	if err != nil {
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Remove client audience.
+	if err = client.RemoveAudience(data.URL); err != nil {
+		log.Warn("client audience not found", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientAudienceNotFound)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &client); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client audience removed successfully")
+
+	return client, nil
+}
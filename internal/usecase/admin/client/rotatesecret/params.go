@@ -0,0 +1,6 @@
+package rotatesecret
+
+// Params is a data for rotate client secret key use-case.
+type Params struct {
+	ID int64
+}
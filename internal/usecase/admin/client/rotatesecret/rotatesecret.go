@@ -0,0 +1,88 @@
+package rotatesecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for rotate client secret key use-case.
+type Repository interface {
+	ClientDetails(ctx context.Context, id int64) (dto.ClientDetails, error)
+
+	Save(ctx context.Context, client *entity.Client) error
+}
+
+// UseCase is a use-case for rotating the secret key of a client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new rotate client secret key use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for rotating the secret key of a client.
+// If successful, the client with the new secret key is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Client, error) {
+	const op = "usecase.admin.client.rotatesecret"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", data.ID),
+	)
+	log.Info("attempting to rotate client secret key")
+
+	// Get client from storage.
+	storageClientData, err := uc.repo.ClientDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientNotFound)
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create client entity.
+	client, err := entity.NewClient(
+		storageClientData.Name,
+		storageClientData.Code,
+		entity.WithClientDetails(storageClientData),
+	)
+	if err != nil {
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Rotate client secret key.
+	if err = client.RotateSecretKey(); err != nil {
+		log.Error("error rotating client secret key", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &client); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client secret key rotated successfully")
+
+	return client, nil
+}
@@ -0,0 +1,8 @@
+package setdefaultroles
+
+// Params is a data for set client default roles use-case.
+// If RoleCodes is empty, the users registered with the client get no roles.
+type Params struct {
+	ClientID  int64
+	RoleCodes []string
+}
@@ -0,0 +1,106 @@
+package setdefaultroles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+	"slices"
+)
+
+// Repository is a repository for set client default roles use-case.
+type Repository interface {
+	ClientDetails(ctx context.Context, id int64) (dto.ClientDetails, error)
+	RolesByCodes(ctx context.Context, codes []string) ([]dto.Role, error)
+
+	Save(ctx context.Context, client *entity.Client) error
+}
+
+// UseCase is a use-case for setting the roles granted to the users registered with a client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new set client default roles use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for setting the roles granted to the users registered with a client.
+// The previous default roles of the client are replaced. If successful, the updated client is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Client, error) {
+	const op = "usecase.admin.client.setdefaultroles"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("client ID", data.ClientID),
+		slog.Any("role codes", data.RoleCodes),
+	)
+	log.Info("attempting to set client default roles")
+
+	// Get client from storage.
+	storageClientData, err := uc.repo.ClientDetails(ctx, data.ClientID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrClientNotFound)
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create client entity.
+	client, err := entity.NewClient(
+		storageClientData.Name,
+		storageClientData.Code,
+		entity.WithClientDetails(storageClientData),
+	)
+	if err != nil {
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get default roles from storage.
+	roleCodes := slices.Compact(slices.Sorted(slices.Values(data.RoleCodes)))
+
+	roles := make([]dto.Role, 0)
+	if len(roleCodes) > 0 {
+		roles, err = uc.repo.RolesByCodes(ctx, roleCodes)
+		if err != nil {
+			log.Error("error getting roles from storage", sl.Err(err))
+
+			return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if len(roles) != len(roleCodes) {
+		log.Warn("role not found")
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, usecase.ErrRoleNotFound)
+	}
+
+	// Set client default roles.
+	client.SetDefaultRoles(roles)
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &client); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Client{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client default roles set successfully")
+
+	return client, nil
+}
@@ -0,0 +1,84 @@
+package setdefaultroles
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clientID      int64
+		roleCodes     []string
+		expectedRoles []string
+		expectedError error
+	}{
+		{
+			name:          "replaces default roles",
+			roleCodes:     []string{"editor", usecasetest.RoleCode, "editor"},
+			expectedRoles: []string{usecasetest.RoleCode, "editor"},
+		},
+		{
+			name:          "removes default roles",
+			roleCodes:     []string{},
+			expectedRoles: []string{},
+		},
+		{
+			name:          "role not found",
+			roleCodes:     []string{"unknown"},
+			expectedRoles: []string{usecasetest.RoleCode},
+			expectedError: usecase.ErrRoleNotFound,
+		},
+		{
+			name:          "client not found",
+			clientID:      100,
+			roleCodes:     []string{"editor"},
+			expectedRoles: []string{usecasetest.RoleCode},
+			expectedError: usecase.ErrClientNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			log := usecasetest.Logger()
+
+			_, err := fixture.Storage.SeedRole(ctx, models.Role{Code: "editor", Name: "Editor", Active: true})
+			require.NoError(t, err)
+
+			clientID := fixture.ClientID
+			if tc.clientID != 0 {
+				clientID = tc.clientID
+			}
+
+			_, err = New(log, repository.NewClientRepository(log, fixture.Storage)).Execute(ctx, Params{
+				ClientID:  clientID,
+				RoleCodes: tc.roleCodes,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			roles, err := fixture.Storage.RolesByClientID(ctx, fixture.ClientID)
+			require.NoError(t, err)
+
+			roleCodes := make([]string, len(roles))
+			for i, role := range roles {
+				roleCodes[i] = role.Code
+			}
+			assert.Equal(t, tc.expectedRoles, roleCodes)
+		})
+	}
+}
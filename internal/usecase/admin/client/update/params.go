@@ -0,0 +1,16 @@
+package update
+
+import "github.com/p1xray/pxr-sso/internal/enum"
+
+// Params is a data for update client use-case.
+// If MaxSessions and SessionEvictionPolicy are nil, the client uses the default session limit.
+// If RequireVerifiedEmail is set, only the users with the verified email can sign in to the client.
+// If RequireMFA is set, the users must pass the second authentication factor to sign in to the client.
+type Params struct {
+	ID                    int64
+	Name                  string
+	MaxSessions           *int32
+	SessionEvictionPolicy *enum.SessionEvictionPolicyEnum
+	RequireVerifiedEmail  bool
+	RequireMFA            bool
+}
@@ -0,0 +1,64 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for create permission use-case.
+type Repository interface {
+	Save(ctx context.Context, permission *entity.Permission) error
+}
+
+// UseCase is a use-case for creating a new permission.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new create permission use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for creating a new permission. The permission is active.
+// If successful, the created permission is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Permission, error) {
+	const op = "usecase.admin.permission.create"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("permission code", data.Code),
+	)
+	log.Info("attempting to create permission")
+
+	// Create permission entity.
+	permission := entity.NewPermission(data.Code, data.Description)
+	permission.SetToCreate()
+
+	// Save data to storage.
+	if err := uc.repo.Save(ctx, &permission); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			log.Warn("permission already exists", sl.Err(err))
+
+			return entity.Permission{}, fmt.Errorf("%s: %w", op, usecase.ErrPermissionExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("permission created successfully", slog.Int64("permission ID", permission.ID))
+
+	return permission, nil
+}
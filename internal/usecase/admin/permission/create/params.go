@@ -0,0 +1,7 @@
+package create
+
+// Params is a data for create permission use-case.
+type Params struct {
+	Code        string
+	Description string
+}
@@ -0,0 +1,57 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for list permissions use-case.
+type Repository interface {
+	Permissions(ctx context.Context, limit, offset int) ([]dto.PermissionDetails, int64, error)
+}
+
+// UseCase is a use-case for getting the list of permissions.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new list permissions use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting the page of the list of permissions. Deleted permissions are not listed.
+// If successful, the permissions of the page and the total number of permissions are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) ([]entity.Permission, int64, error) {
+	const op = "usecase.admin.permission.list"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+
+	storagePermissionsData, total, err := uc.repo.Permissions(ctx, data.PageSize, (data.Page-1)*data.PageSize)
+	if err != nil {
+		log.Error("error getting permissions from storage", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	permissions := make([]entity.Permission, len(storagePermissionsData))
+	for i, storagePermissionData := range storagePermissionsData {
+		permissions[i] = entity.NewPermission(
+			storagePermissionData.Code,
+			storagePermissionData.Description,
+			entity.WithPermissionDetails(storagePermissionData),
+		)
+	}
+
+	return permissions, total, nil
+}
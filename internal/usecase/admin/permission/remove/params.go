@@ -0,0 +1,6 @@
+package remove
+
+// Params is a data for remove permission use-case.
+type Params struct {
+	ID int64
+}
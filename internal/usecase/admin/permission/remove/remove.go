@@ -0,0 +1,81 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for remove permission use-case.
+type Repository interface {
+	PermissionDetails(ctx context.Context, id int64) (dto.PermissionDetails, error)
+
+	Save(ctx context.Context, permission *entity.Permission) error
+}
+
+// UseCase is a use-case for removing a permission.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new remove permission use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for removing a permission.
+// The permission is marked as deleted, it is no longer granted to the users of the roles it is attached to.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.admin.permission.remove"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("permission ID", data.ID),
+	)
+	log.Info("attempting to remove permission")
+
+	// Get permission from storage.
+	storagePermissionData, err := uc.repo.PermissionDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("permission not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrPermissionNotFound)
+		}
+
+		log.Error("error getting permission from storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create permission entity.
+	permission := entity.NewPermission(
+		storagePermissionData.Code,
+		storagePermissionData.Description,
+		entity.WithPermissionDetails(storagePermissionData),
+	)
+
+	// Remove permission.
+	permission.SetToRemove()
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &permission); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("permission removed successfully")
+
+	return nil
+}
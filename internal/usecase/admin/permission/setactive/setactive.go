@@ -0,0 +1,86 @@
+package setactive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for set permission active use-case.
+type Repository interface {
+	PermissionDetails(ctx context.Context, id int64) (dto.PermissionDetails, error)
+
+	Save(ctx context.Context, permission *entity.Permission) error
+}
+
+// UseCase is a use-case for activating or deactivating a permission.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new set permission active use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for activating or deactivating a permission.
+// The inactive permission is not granted to the users in the tokens issued afterwards.
+// If successful, the updated permission is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Permission, error) {
+	const op = "usecase.admin.permission.setactive"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("permission ID", data.ID),
+	)
+	log.Info("attempting to set permission active")
+
+	// Get permission from storage.
+	storagePermissionData, err := uc.repo.PermissionDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("permission not found", sl.Err(err))
+
+			return entity.Permission{}, fmt.Errorf("%s: %w", op, usecase.ErrPermissionNotFound)
+		}
+
+		log.Error("error getting permission from storage", sl.Err(err))
+
+		return entity.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create permission entity.
+	permission := entity.NewPermission(
+		storagePermissionData.Code,
+		storagePermissionData.Description,
+		entity.WithPermissionDetails(storagePermissionData),
+	)
+
+	// Activate or deactivate permission.
+	if data.Active {
+		permission.Activate()
+	} else {
+		permission.Deactivate()
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &permission); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Permission{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("permission active flag set successfully")
+
+	return permission, nil
+}
@@ -0,0 +1,78 @@
+package setactive
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		permissionID        int64
+		deactivated         bool
+		active              bool
+		expectedPermissions int
+		expectedError       error
+	}{
+		{
+			name:                "deactivates permission",
+			active:              false,
+			expectedPermissions: 0,
+		},
+		{
+			name:                "activates permission",
+			deactivated:         true,
+			active:              true,
+			expectedPermissions: 1,
+		},
+		{
+			name:                "permission not found",
+			permissionID:        100,
+			active:              false,
+			expectedPermissions: 1,
+			expectedError:       usecase.ErrPermissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			uc := New(log, repository.NewPermissionRepository(log, fixture.Storage))
+
+			if tc.deactivated {
+				_, err := uc.Execute(ctx, Params{ID: fixture.PermissionID, Active: false})
+				require.NoError(t, err)
+			}
+
+			permissionID := fixture.PermissionID
+			if tc.permissionID != 0 {
+				permissionID = tc.permissionID
+			}
+
+			permission, err := uc.Execute(ctx, Params{ID: permissionID, Active: tc.active})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.active, permission.Active)
+			}
+
+			// The inactive permission is not granted to the users of the roles it is attached to.
+			userPermissions, err := fixture.Storage.PermissionsByUserID(ctx, userID)
+			require.NoError(t, err)
+			assert.Len(t, userPermissions, tc.expectedPermissions)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package update
+
+// Params is a data for update permission use-case.
+type Params struct {
+	ID          int64
+	Description string
+}
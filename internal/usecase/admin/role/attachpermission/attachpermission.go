@@ -0,0 +1,103 @@
+package attachpermission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for attach permission to role use-case.
+type Repository interface {
+	RoleDetails(ctx context.Context, id int64) (dto.RoleDetails, error)
+	PermissionDetails(ctx context.Context, id int64) (dto.PermissionDetails, error)
+
+	Save(ctx context.Context, role *entity.Role) error
+}
+
+// UseCase is a use-case for attaching a permission to a role.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new attach permission to role use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for attaching a permission to a role.
+// The permission is granted to the users of the role in the tokens issued afterwards.
+// If successful, the updated role is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Role, error) {
+	const op = "usecase.admin.role.attachpermission"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("role ID", data.RoleID),
+		slog.Int64("permission ID", data.PermissionID),
+	)
+	log.Info("attempting to attach permission to role")
+
+	// Get role from storage.
+	storageRoleData, err := uc.repo.RoleDetails(ctx, data.RoleID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+
+			return entity.Role{}, fmt.Errorf("%s: %w", op, usecase.ErrRoleNotFound)
+		}
+
+		log.Error("error getting role from storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create role entity.
+	role := entity.NewRole(
+		storageRoleData.Code,
+		storageRoleData.Name,
+		storageRoleData.Description,
+		entity.WithRoleDetails(storageRoleData),
+	)
+
+	// Get permission from storage.
+	permission, err := uc.repo.PermissionDetails(ctx, data.PermissionID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("permission not found", sl.Err(err))
+
+			return entity.Role{}, fmt.Errorf("%s: %w", op, usecase.ErrPermissionNotFound)
+		}
+
+		log.Error("error getting permission from storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Attach permission.
+	if err = role.AttachPermission(permission); err != nil {
+		log.Warn("permission is already attached to role", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, usecase.ErrRolePermissionExists)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &role); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("permission attached to role successfully")
+
+	return role, nil
+}
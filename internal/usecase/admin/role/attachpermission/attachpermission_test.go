@@ -0,0 +1,91 @@
+package attachpermission
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/converter"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		roleID              int64
+		permissionCode      string
+		expectedPermissions []string
+		expectedError       error
+	}{
+		{
+			name:                "attaches permission",
+			permissionCode:      "profile:write",
+			expectedPermissions: []string{usecasetest.PermissionCode, "profile:write"},
+		},
+		{
+			name:                "permission is already attached",
+			permissionCode:      usecasetest.PermissionCode,
+			expectedPermissions: []string{usecasetest.PermissionCode},
+			expectedError:       usecase.ErrRolePermissionExists,
+		},
+		{
+			name:                "permission is deleted",
+			permissionCode:      "deleted",
+			expectedPermissions: []string{usecasetest.PermissionCode},
+			expectedError:       usecase.ErrPermissionNotFound,
+		},
+		{
+			name:                "role not found",
+			roleID:              100,
+			permissionCode:      "profile:write",
+			expectedPermissions: []string{usecasetest.PermissionCode},
+			expectedError:       usecase.ErrRoleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			permissionIDs := map[string]int64{usecasetest.PermissionCode: fixture.PermissionID}
+			for _, permission := range []models.Permission{
+				{Code: "profile:write", Active: true},
+				{Code: "deleted", Active: true, Deleted: true},
+			} {
+				id, err := fixture.Storage.SeedPermission(ctx, permission)
+				require.NoError(t, err)
+
+				permissionIDs[permission.Code] = id
+			}
+
+			roleID := fixture.RoleID
+			if tc.roleID != 0 {
+				roleID = tc.roleID
+			}
+
+			_, err := New(log, repository.NewRoleRepository(log, fixture.Storage)).Execute(ctx, Params{
+				RoleID:       roleID,
+				PermissionID: permissionIDs[tc.permissionCode],
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// The permissions of the role are granted to its users.
+			userPermissions, err := fixture.Storage.PermissionsByUserID(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPermissions, converter.ToPermissionCodes(userPermissions))
+		})
+	}
+}
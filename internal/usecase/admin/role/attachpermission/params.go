@@ -0,0 +1,7 @@
+package attachpermission
+
+// Params is a data for attach permission to role use-case.
+type Params struct {
+	RoleID       int64
+	PermissionID int64
+}
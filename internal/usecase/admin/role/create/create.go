@@ -0,0 +1,64 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for create role use-case.
+type Repository interface {
+	Save(ctx context.Context, role *entity.Role) error
+}
+
+// UseCase is a use-case for creating a new role.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new create role use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for creating a new role. The role is active and has no permissions.
+// If successful, the created role is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Role, error) {
+	const op = "usecase.admin.role.create"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("role code", data.Code),
+	)
+	log.Info("attempting to create role")
+
+	// Create role entity.
+	role := entity.NewRole(data.Code, data.Name, data.Description)
+	role.SetToCreate()
+
+	// Save data to storage.
+	if err := uc.repo.Save(ctx, &role); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			log.Warn("role already exists", sl.Err(err))
+
+			return entity.Role{}, fmt.Errorf("%s: %w", op, usecase.ErrRoleExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("role created successfully", slog.Int64("role ID", role.ID))
+
+	return role, nil
+}
@@ -0,0 +1,8 @@
+package create
+
+// Params is a data for create role use-case.
+type Params struct {
+	Code        string
+	Name        string
+	Description string
+}
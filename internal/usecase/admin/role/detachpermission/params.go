@@ -0,0 +1,7 @@
+package detachpermission
+
+// Params is a data for detach permission from role use-case.
+type Params struct {
+	RoleID       int64
+	PermissionID int64
+}
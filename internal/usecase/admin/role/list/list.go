@@ -0,0 +1,58 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for list roles use-case.
+type Repository interface {
+	Roles(ctx context.Context, limit, offset int) ([]dto.RoleDetails, int64, error)
+}
+
+// UseCase is a use-case for getting the list of roles.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new list roles use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting the page of the list of roles. Deleted roles are not listed.
+// If successful, the roles of the page and the total number of roles are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) ([]entity.Role, int64, error) {
+	const op = "usecase.admin.role.list"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+
+	storageRolesData, total, err := uc.repo.Roles(ctx, data.PageSize, (data.Page-1)*data.PageSize)
+	if err != nil {
+		log.Error("error getting roles from storage", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles := make([]entity.Role, len(storageRolesData))
+	for i, storageRoleData := range storageRolesData {
+		roles[i] = entity.NewRole(
+			storageRoleData.Code,
+			storageRoleData.Name,
+			storageRoleData.Description,
+			entity.WithRoleDetails(storageRoleData),
+		)
+	}
+
+	return roles, total, nil
+}
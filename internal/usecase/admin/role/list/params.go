@@ -0,0 +1,7 @@
+package list
+
+// Params is a data for list roles use-case. The pages are numbered from 1.
+type Params struct {
+	Page     int
+	PageSize int
+}
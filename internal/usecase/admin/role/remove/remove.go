@@ -0,0 +1,82 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for remove role use-case.
+type Repository interface {
+	RoleDetails(ctx context.Context, id int64) (dto.RoleDetails, error)
+
+	Save(ctx context.Context, role *entity.Role) error
+}
+
+// UseCase is a use-case for removing a role.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new remove role use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for removing a role.
+// The role is marked as deleted, it and its permissions are no longer granted to the users.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.admin.role.remove"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("role ID", data.ID),
+	)
+	log.Info("attempting to remove role")
+
+	// Get role from storage.
+	storageRoleData, err := uc.repo.RoleDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrRoleNotFound)
+		}
+
+		log.Error("error getting role from storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create role entity.
+	role := entity.NewRole(
+		storageRoleData.Code,
+		storageRoleData.Name,
+		storageRoleData.Description,
+		entity.WithRoleDetails(storageRoleData),
+	)
+
+	// Remove role.
+	role.SetToRemove()
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &role); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("role removed successfully")
+
+	return nil
+}
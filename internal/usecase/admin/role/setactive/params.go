@@ -0,0 +1,7 @@
+package setactive
+
+// Params is a data for set role active use-case.
+type Params struct {
+	ID     int64
+	Active bool
+}
@@ -0,0 +1,79 @@
+package setactive
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		roleID              int64
+		deactivated         bool
+		active              bool
+		expectedPermissions int
+		expectedError       error
+	}{
+		{
+			name:                "deactivates role",
+			active:              false,
+			expectedPermissions: 0,
+		},
+		{
+			name:                "activates role",
+			deactivated:         true,
+			active:              true,
+			expectedPermissions: 1,
+		},
+		{
+			name:                "role not found",
+			roleID:              100,
+			active:              false,
+			expectedPermissions: 1,
+			expectedError:       usecase.ErrRoleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			uc := New(log, repository.NewRoleRepository(log, fixture.Storage))
+
+			if tc.deactivated {
+				_, err := uc.Execute(ctx, Params{ID: fixture.RoleID, Active: false})
+				require.NoError(t, err)
+			}
+
+			roleID := fixture.RoleID
+			if tc.roleID != 0 {
+				roleID = tc.roleID
+			}
+
+			role, err := uc.Execute(ctx, Params{ID: roleID, Active: tc.active})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.active, role.Active)
+				assert.Equal(t, []string{usecasetest.PermissionCode}, role.PermissionCodes())
+			}
+
+			// The permissions of the inactive role are not granted to its users.
+			userPermissions, err := fixture.Storage.PermissionsByUserID(ctx, userID)
+			require.NoError(t, err)
+			assert.Len(t, userPermissions, tc.expectedPermissions)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package update
+
+// Params is a data for update role use-case.
+type Params struct {
+	ID          int64
+	Name        string
+	Description string
+}
@@ -0,0 +1,82 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for update role use-case.
+type Repository interface {
+	RoleDetails(ctx context.Context, id int64) (dto.RoleDetails, error)
+
+	Save(ctx context.Context, role *entity.Role) error
+}
+
+// UseCase is a use-case for updating a role.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new update role use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for updating the name and the description of a role.
+// If successful, the updated role is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Role, error) {
+	const op = "usecase.admin.role.update"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("role ID", data.ID),
+	)
+	log.Info("attempting to update role")
+
+	// Get role from storage.
+	storageRoleData, err := uc.repo.RoleDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+
+			return entity.Role{}, fmt.Errorf("%s: %w", op, usecase.ErrRoleNotFound)
+		}
+
+		log.Error("error getting role from storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create role entity.
+	role := entity.NewRole(
+		storageRoleData.Code,
+		storageRoleData.Name,
+		storageRoleData.Description,
+		entity.WithRoleDetails(storageRoleData),
+	)
+
+	// Update role.
+	role.Update(data.Name, data.Description)
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &role); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Role{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("role updated successfully")
+
+	return role, nil
+}
@@ -0,0 +1,108 @@
+package grantclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for grant client access to user use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	Client(ctx context.Context, id int64) (dto.Client, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for giving a user access to a client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new grant client access to user use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for giving a user access to a client.
+// The client is added to the clients the user has access to.
+// If successful, the updated user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.grantclient"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.Int64("client ID", data.ClientID),
+	)
+	log.Info("attempting to grant client access to user")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.UserID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+	)
+
+	// Get client from storage.
+	client, err := uc.repo.Client(ctx, data.ClientID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrClientNotFound)
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Grant client access.
+	if err = account.GrantClient(client); err != nil {
+		log.Warn("failed to grant client access to user", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrUserClientExists):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserClientExists)
+		}
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client access granted to user successfully")
+
+	return account, nil
+}
@@ -0,0 +1,7 @@
+package grantclient
+
+// Params is a data for grant client access to user use-case.
+type Params struct {
+	UserID   int64
+	ClientID int64
+}
@@ -0,0 +1,108 @@
+package grantrole
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for grant role to user use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	Role(ctx context.Context, id int64) (dto.Role, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for granting a role to a user.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new grant role to user use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for granting a role to a user.
+// The role and its permissions are granted to the user in the tokens issued afterwards.
+// If successful, the updated user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.grantrole"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.Int64("role ID", data.RoleID),
+	)
+	log.Info("attempting to grant role to user")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.UserID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+	)
+
+	// Get role from storage.
+	role, err := uc.repo.Role(ctx, data.RoleID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("role not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrRoleNotFound)
+		}
+
+		log.Error("error getting role from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Grant role.
+	if err = account.GrantRole(role); err != nil {
+		log.Warn("failed to grant role to user", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrUserRoleExists):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserRoleExists)
+		}
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("role granted to user successfully")
+
+	return account, nil
+}
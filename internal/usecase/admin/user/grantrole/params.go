@@ -0,0 +1,7 @@
+package grantrole
+
+// Params is a data for grant role to user use-case.
+type Params struct {
+	UserID int64
+	RoleID int64
+}
@@ -0,0 +1,64 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for list users use-case.
+type Repository interface {
+	Users(ctx context.Context, query string, deleted bool, limit, offset int) ([]dto.UserDetails, int64, error)
+}
+
+// UseCase is a use-case for getting the list of users.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new list users use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for searching the users by the username or the full name.
+// If successful, the users of the page and the total number of found users are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) ([]entity.UserAccount, int64, error) {
+	const op = "usecase.admin.user.list"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("query", data.Query),
+	)
+
+	storageUsersData, total, err := uc.repo.Users(
+		ctx,
+		data.Query,
+		data.Deleted,
+		data.PageSize,
+		(data.Page-1)*data.PageSize,
+	)
+	if err != nil {
+		log.Error("error getting users from storage", sl.Err(err))
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users := make([]entity.UserAccount, len(storageUsersData))
+	for i, storageUserData := range storageUsersData {
+		users[i] = entity.NewUserAccount(
+			storageUserData.Username,
+			storageUserData.FullName,
+			entity.WithUserAccountDetails(storageUserData),
+		)
+	}
+
+	return users, total, nil
+}
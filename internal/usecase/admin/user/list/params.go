@@ -0,0 +1,11 @@
+package list
+
+// Params is a data for list users use-case. The pages are numbered from 1.
+// Query is matched against the username and the full name of the users, the empty query matches all users.
+// If Deleted is set, the deleted users are listed instead of the users which are not deleted.
+type Params struct {
+	Query    string
+	Deleted  bool
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,62 @@
+package remove
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/admin/user/restore"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	log := usecasetest.Logger()
+
+	loginUseCase := login.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(0),
+		usecasetest.PasswordHasher(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(log, fixture.Storage),
+	)
+	loginParams := login.Params{
+		Username:   "user",
+		Password:   usecasetest.Password,
+		ClientCode: usecasetest.ClientCode,
+		Issuer:     usecasetest.Issuer,
+	}
+
+	_, err := loginUseCase.Execute(ctx, loginParams)
+	require.NoError(t, err)
+
+	userRepository := repository.NewUserRepository(log, fixture.Storage)
+	uc := New(log, userRepository)
+
+	// The removed user loses the sessions and can't sign in.
+	require.NoError(t, uc.Execute(ctx, Params{ID: userID}))
+	assert.Empty(t, fixture.Sessions(t, userID))
+
+	_, err = loginUseCase.Execute(ctx, loginParams)
+	assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+
+	assert.ErrorIs(t, uc.Execute(ctx, Params{ID: userID}), usecase.ErrUserNotFound)
+	assert.ErrorIs(t, uc.Execute(ctx, Params{ID: 100}), usecase.ErrUserNotFound)
+
+	// The restored user gets the roles back and can sign in again.
+	account, err := restore.New(log, userRepository).Execute(ctx, restore.Params{ID: userID})
+	require.NoError(t, err)
+	assert.False(t, account.Deleted)
+	assert.Equal(t, []string{usecasetest.RoleCode}, account.RoleCodes())
+
+	_, err = loginUseCase.Execute(ctx, loginParams)
+	assert.NoError(t, err)
+}
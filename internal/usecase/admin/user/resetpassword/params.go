@@ -0,0 +1,8 @@
+package resetpassword
+
+// Params is a data for reset user password use-case.
+// If TemporaryPassword is empty, a random temporary password is generated.
+type Params struct {
+	ID                int64
+	TemporaryPassword string
+}
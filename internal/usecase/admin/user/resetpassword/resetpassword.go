@@ -0,0 +1,117 @@
+package resetpassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for reset user password use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	UserSessions(ctx context.Context, userID int64) ([]dto.Session, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for resetting the user password by the administrator.
+type UseCase struct {
+	log            *slog.Logger
+	passwordPolicy entity.PasswordPolicy
+	passwordHasher entity.PasswordHasher
+	repo           Repository
+}
+
+// New returns new reset user password use-case.
+func New(
+	log *slog.Logger,
+	passwordPolicy entity.PasswordPolicy,
+	passwordHasher entity.PasswordHasher,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:            log,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		repo:           repo,
+	}
+}
+
+// Execute executes the use-case for resetting the user password by the administrator.
+// The user gets the temporary password and must change it on the next login, all the user sessions are revoked.
+// If successful, the updated user account and the temporary password are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, string, error) {
+	const op = "usecase.admin.user.resetpassword"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.ID),
+	)
+	log.Info("attempting to reset user password")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get user sessions from storage.
+	storageSessionsData, err := uc.repo.UserSessions(ctx, data.ID)
+	if err != nil {
+		log.Error("error getting user sessions from storage", sl.Err(err))
+
+		return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+		entity.WithUserAccountSessions(storageSessionsData),
+		entity.WithUserAccountPasswordHasher(uc.passwordHasher),
+	)
+
+	// Reset password.
+	temporaryPassword, err := account.ResetPassword(data.TemporaryPassword, uc.passwordPolicy)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			log.Warn("user is deleted", sl.Err(err))
+
+			return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrWeakPassword):
+			log.Warn("temporary password does not meet the password policy", sl.Err(err))
+
+			return entity.UserAccount{}, "", fmt.Errorf("%s: %w: %w", op, usecase.ErrWeakPassword, err)
+		}
+
+		log.Error("failed to reset password", sl.Err(err))
+
+		return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user password reset successfully")
+
+	return account, temporaryPassword, nil
+}
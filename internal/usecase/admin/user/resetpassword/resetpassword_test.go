@@ -0,0 +1,127 @@
+package resetpassword
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/changepassword"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name              string
+		userID            int64
+		temporaryPassword string
+		expectedError     error
+	}{
+		{
+			name:              "sets the temporary password",
+			temporaryPassword: "temporary-password",
+		},
+		{
+			name: "generates the temporary password",
+		},
+		{
+			name:              "temporary password is too short",
+			temporaryPassword: "short",
+			expectedError:     usecase.ErrWeakPassword,
+		},
+		{
+			name:          "user not found",
+			userID:        100,
+			expectedError: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+			passwordPolicy := usecasetest.PasswordPolicy(t)
+			authRepository := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				authRepository,
+			)
+			loginParams := login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			}
+
+			_, err := loginUseCase.Execute(ctx, loginParams)
+			require.NoError(t, err)
+
+			uc := New(log, passwordPolicy, usecasetest.PasswordHasher(), repository.NewUserRepository(log, fixture.Storage))
+
+			targetID := userID
+			if tc.userID != 0 {
+				targetID = tc.userID
+			}
+
+			account, temporaryPassword, err := uc.Execute(ctx, Params{
+				ID:                targetID,
+				TemporaryPassword: tc.temporaryPassword,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Len(t, fixture.Sessions(t, userID), 1)
+
+				_, err = loginUseCase.Execute(ctx, loginParams)
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, account.MustChangePassword)
+			assert.NotEmpty(t, temporaryPassword)
+			if tc.temporaryPassword != "" {
+				assert.Equal(t, tc.temporaryPassword, temporaryPassword)
+			}
+			assert.Empty(t, fixture.Sessions(t, userID))
+
+			// The user must change the temporary password before signing in.
+			loginParams.Password = temporaryPassword
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			assert.ErrorIs(t, err, usecase.ErrPasswordChangeRequired)
+
+			changePasswordUseCase := changepassword.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.LoginThrottleConfig(0),
+				passwordPolicy,
+				usecasetest.PasswordHasher(),
+				authRepository,
+			)
+			_, err = changePasswordUseCase.Execute(ctx, changepassword.Params{
+				Username:        "user",
+				CurrentPassword: temporaryPassword,
+				NewPassword:     "new-password",
+			})
+			require.NoError(t, err)
+
+			loginParams.Password = "new-password"
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package restore
+
+// Params is a data for restore user use-case.
+type Params struct {
+	ID int64
+}
@@ -0,0 +1,80 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for restore user use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for restoring a deleted user.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new restore user use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for restoring a deleted user. The restored user keeps the roles, the clients
+// and the blocked flag the user had when deleted. If successful, the restored user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.restore"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.ID),
+	)
+	log.Info("attempting to restore user")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+	)
+
+	// Restore user.
+	account.Restore()
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user restored successfully")
+
+	return account, nil
+}
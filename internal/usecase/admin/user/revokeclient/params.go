@@ -0,0 +1,7 @@
+package revokeclient
+
+// Params is a data for revoke client access from user use-case.
+type Params struct {
+	UserID   int64
+	ClientID int64
+}
@@ -0,0 +1,102 @@
+package revokeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for revoke client access from user use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	UserSessions(ctx context.Context, userID int64) ([]dto.Session, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for taking away a user access to a client.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new revoke client access from user use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for taking away a user access to a client.
+// The user sessions issued to the client are revoked. If successful, the updated user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.revokeclient"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.Int64("client ID", data.ClientID),
+	)
+	log.Info("attempting to revoke client access from user")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.UserID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get user sessions from storage.
+	storageSessionsData, err := uc.repo.UserSessions(ctx, data.UserID)
+	if err != nil {
+		log.Error("error getting user sessions from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+		entity.WithUserAccountSessions(storageSessionsData),
+	)
+
+	// Revoke client access.
+	if err = account.RevokeClient(data.ClientID); err != nil {
+		log.Warn("failed to revoke client access from user", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrUserClientNotFound):
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserClientNotFound)
+		}
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client access revoked from user successfully")
+
+	return account, nil
+}
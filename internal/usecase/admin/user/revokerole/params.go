@@ -0,0 +1,7 @@
+package revokerole
+
+// Params is a data for revoke role from user use-case.
+type Params struct {
+	UserID int64
+	RoleID int64
+}
@@ -0,0 +1,7 @@
+package setblocked
+
+// Params is a data for set user blocked use-case.
+type Params struct {
+	ID      int64
+	Blocked bool
+}
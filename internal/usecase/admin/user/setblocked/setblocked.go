@@ -0,0 +1,101 @@
+package setblocked
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for set user blocked use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	UserSessions(ctx context.Context, userID int64) ([]dto.Session, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for blocking or unblocking a user.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new set user blocked use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for blocking or unblocking a user.
+// The blocked user can't sign in, all the user sessions are revoked when the user is blocked.
+// If successful, the updated user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.setblocked"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.ID),
+		slog.Bool("blocked", data.Blocked),
+	)
+	log.Info("attempting to set user blocked")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get user sessions from storage.
+	storageSessionsData, err := uc.repo.UserSessions(ctx, data.ID)
+	if err != nil {
+		log.Error("error getting user sessions from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+		entity.WithUserAccountSessions(storageSessionsData),
+	)
+
+	// Block or unblock user.
+	if data.Blocked {
+		err = account.Block()
+	} else {
+		err = account.Unblock()
+	}
+	if err != nil {
+		log.Warn("user is deleted", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user blocked flag set successfully")
+
+	return account, nil
+}
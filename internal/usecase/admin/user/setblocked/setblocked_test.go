@@ -0,0 +1,108 @@
+package setblocked
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name               string
+		userID             int64
+		blockedBefore      bool
+		blocked            bool
+		expectedError      error
+		expectedSessions   int
+		expectedLoginError error
+	}{
+		{
+			name:               "blocks user and revokes sessions",
+			blocked:            true,
+			expectedSessions:   0,
+			expectedLoginError: usecase.ErrUserBlocked,
+		},
+		{
+			name:             "unblocks user",
+			blockedBefore:    true,
+			blocked:          false,
+			expectedSessions: 0,
+		},
+		{
+			name:             "user not found",
+			userID:           100,
+			blocked:          true,
+			expectedError:    usecase.ErrUserNotFound,
+			expectedSessions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			loginUseCase := login.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repository.NewAuthRepository(log, fixture.Storage),
+			)
+			loginParams := login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			}
+
+			uc := New(log, repository.NewUserRepository(log, fixture.Storage))
+
+			if tc.blockedBefore {
+				_, err := uc.Execute(ctx, Params{ID: userID, Blocked: true})
+				require.NoError(t, err)
+			}
+
+			_, err := loginUseCase.Execute(ctx, loginParams)
+			if !tc.blockedBefore {
+				require.NoError(t, err)
+			}
+
+			targetID := userID
+			if tc.userID != 0 {
+				targetID = tc.userID
+			}
+
+			account, err := uc.Execute(ctx, Params{ID: targetID, Blocked: tc.blocked})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.blocked, account.Blocked)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+
+			// The blocked user can't sign in.
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			if tc.expectedLoginError != nil {
+				assert.ErrorIs(t, err, tc.expectedLoginError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package unlock
+
+// Params is a data for unlock user use-case.
+type Params struct {
+	ID int64
+}
@@ -0,0 +1,95 @@
+package unlock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for unlock user use-case.
+type Repository interface {
+	UserDetails(ctx context.Context, id int64) (dto.UserDetails, error)
+	UserLoginAttempts(ctx context.Context, username string) ([]dto.LoginAttempt, error)
+	Save(ctx context.Context, account *entity.UserAccount) error
+}
+
+// UseCase is a use-case for unlocking a user locked after too many failed login attempts.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new unlock user use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for unlocking a user locked after too many failed login attempts.
+// The failed login attempts of the username are forgotten, so the user can sign in immediately.
+// If successful, the user account is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.UserAccount, error) {
+	const op = "usecase.admin.user.unlock"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.ID),
+	)
+	log.Info("attempting to unlock user")
+
+	// Get user from storage.
+	storageUserData, err := uc.repo.UserDetails(ctx, data.ID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get failed login attempts of the user from storage.
+	storageLoginAttemptsData, err := uc.repo.UserLoginAttempts(ctx, storageUserData.Username)
+	if err != nil {
+		log.Error("error getting user login attempts from storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user account entity.
+	account := entity.NewUserAccount(
+		storageUserData.Username,
+		storageUserData.FullName,
+		entity.WithUserAccountDetails(storageUserData),
+		entity.WithUserAccountLoginAttempts(storageLoginAttemptsData),
+	)
+
+	// Unlock user.
+	if err = account.Unlock(); err != nil {
+		log.Warn("user is deleted", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &account); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.UserAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user unlocked successfully")
+
+	return account, nil
+}
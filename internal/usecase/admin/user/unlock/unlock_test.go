@@ -0,0 +1,93 @@
+package unlock
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name               string
+		userID             int64
+		expectedError      error
+		expectedLoginError error
+	}{
+		{
+			name: "unlocks user",
+		},
+		{
+			name:               "user not found",
+			userID:             100,
+			expectedError:      usecase.ErrUserNotFound,
+			expectedLoginError: usecase.ErrAccountLocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			loginUseCase := login.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(2),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repository.NewAuthRepository(log, fixture.Storage),
+			)
+			loginParams := login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			}
+
+			// Lock the user by the failed logins.
+			failedLoginParams := loginParams
+			failedLoginParams.Password = "wrong-password"
+			for range 2 {
+				_, err := loginUseCase.Execute(ctx, failedLoginParams)
+				require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+			}
+
+			_, err := loginUseCase.Execute(ctx, loginParams)
+			require.ErrorIs(t, err, usecase.ErrAccountLocked)
+
+			uc := New(log, repository.NewUserRepository(log, fixture.Storage))
+
+			targetID := userID
+			if tc.userID != 0 {
+				targetID = tc.userID
+			}
+
+			account, err := uc.Execute(ctx, Params{ID: targetID})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, userID, account.ID)
+			}
+
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			if tc.expectedLoginError != nil {
+				assert.ErrorIs(t, err, tc.expectedLoginError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
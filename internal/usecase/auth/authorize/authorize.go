@@ -0,0 +1,169 @@
+package authorize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for authorize use-case.
+type Repository interface {
+	DataForAuthorize(ctx context.Context, username, clientCode, ipAddress string) (dto.DataForAuthorize, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for authorizing a client on behalf of a user by the authorization code flow.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	mfaCfg      config.MFAConfig
+	throttleCfg config.LoginThrottleConfig
+	repo        Repository
+}
+
+// New returns new authorize use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	mfaCfg config.MFAConfig,
+	throttleCfg config.LoginThrottleConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		mfaCfg:      mfaCfg,
+		throttleCfg: throttleCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for authorizing a client on behalf of a user.
+// If successful, a new single-use authorization code is returned.
+// The failed password checks are counted per username and per client IP address as the failed logins.
+// The failed MFA codes are counted per username, the codes are rejected for the MFA challenge TTL
+// once the MFA max attempts are reached.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (string, error) {
+	const op = "usecase.auth.authorize"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("client code", data.ClientCode),
+		slog.String("redirect uri", data.RedirectURI),
+		slog.String("IP address", data.IPAddress),
+	)
+	log.Info("attempting to authorize client")
+
+	// Get user and client data from storage.
+	storageAuthorizeData, err := uc.repo.DataForAuthorize(ctx, data.Username, data.ClientCode, data.IPAddress)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		}
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthNonce(data.Nonce),
+		entity.WithAuthUser(storageAuthorizeData.User),
+		entity.WithAuthClient(storageAuthorizeData.Client),
+		entity.WithAuthMFA(storageAuthorizeData.MFA),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageAuthorizeData.LoginAttempts...),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Authorize.
+	entityAuthorizeParams := entity.AuthorizeParams{
+		Username:            data.Username,
+		Password:            data.Password,
+		IPAddress:           data.IPAddress,
+		RedirectURI:         data.RedirectURI,
+		CodeChallenge:       data.CodeChallenge,
+		CodeChallengeMethod: data.CodeChallengeMethod,
+		CodeTTL:             uc.cfg.AuthorizationCodeTTL,
+		MFACode:             data.MFACode,
+		MFAMaxAttempts:      uc.mfaCfg.MaxAttempts,
+		MFALockoutDuration:  uc.mfaCfg.ChallengeTTL,
+	}
+	authorizationCode, err := auth.Authorize(entityAuthorizeParams)
+	if err != nil {
+		log.Warn("failed to authorize", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			// The failed password check is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return "", fmt.Errorf("%s: %w", op, err)
+			}
+
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrAccountLocked):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		case errors.Is(err, entity.ErrLoginThrottled):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		case errors.Is(err, entity.ErrUserBlocked):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrPasswordChangeRequired):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrPasswordChangeRequired)
+		case errors.Is(err, entity.ErrEmailNotVerified):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrEmailNotVerified)
+		case errors.Is(err, entity.ErrInvalidRedirectURI):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidRedirectURI)
+		case errors.Is(err, entity.ErrUnsupportedCodeChallengeMethod),
+			errors.Is(err, entity.ErrInvalidCodeChallenge):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidCodeChallenge)
+		case errors.Is(err, entity.ErrMFAEnrollmentRequired):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrMFAEnrollmentRequired)
+		case errors.Is(err, entity.ErrMFARequired):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrMFARequired)
+		case errors.Is(err, entity.ErrInvalidMFACode):
+			// The failed MFA code is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return "", fmt.Errorf("%s: %w", op, err)
+			}
+
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFACode)
+		case errors.Is(err, entity.ErrMFAAttemptsExceeded):
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrMFAAttemptsExceeded)
+		}
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data in storage.
+	err = uc.repo.Save(ctx, &auth)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("TOTP code or backup code is already used", sl.Err(err))
+
+			return "", fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFACode)
+		}
+
+		log.Error("error saving data to storage.", sl.Err(err))
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client authorized successfully")
+
+	return authorizationCode.Code, nil
+}
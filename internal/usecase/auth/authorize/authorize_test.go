@@ -0,0 +1,97 @@
+package authorize
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	redirectURI   = "https://client.example.com/callback"
+	codeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func Test_UseCase_Execute_MFAAttemptsExceeded(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		expectedError error
+	}{
+		{
+			name:          "rejects valid code once the attempts are exceeded",
+			failures:      usecasetest.MFAConfig().MaxAttempts,
+			expectedError: usecase.ErrMFAAttemptsExceeded,
+		},
+		{
+			name:     "authorizes by valid code before the attempts are exceeded",
+			failures: usecasetest.MFAConfig().MaxAttempts - 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret)
+
+			_, err := fixture.Storage.SeedClientRedirectURI(ctx, models.RedirectURI{
+				ClientID: fixture.ClientID,
+				URI:      redirectURI,
+			})
+			require.NoError(t, err)
+
+			log := usecasetest.Logger()
+			uc := New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				repository.NewAuthRepository(log, fixture.Storage),
+			)
+
+			params := Params{
+				Username:            "user",
+				Password:            usecasetest.Password,
+				IPAddress:           "192.0.2.1",
+				ClientCode:          usecasetest.ClientCode,
+				RedirectURI:         redirectURI,
+				CodeChallenge:       codeChallenge,
+				CodeChallengeMethod: entity.CodeChallengeMethodS256,
+			}
+
+			// Every login form is a new request, the failed codes are counted across them.
+			for range tc.failures {
+				params.MFACode = "000000"
+				_, err = uc.Execute(ctx, params)
+				require.ErrorIs(t, err, usecase.ErrInvalidMFACode)
+			}
+
+			params.MFACode = usecasetest.TOTPCode(t, usecasetest.TOTPSecret)
+			code, err := uc.Execute(ctx, params)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, code)
+
+			// The failed codes are forgotten once the valid code is sent.
+			_, err = fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindMFA), "user")
+			assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package authorize
+
+// Params is a data for authorize use-case.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+// MFACode is the TOTP code or the backup code of the user who must pass the second authentication factor.
+type Params struct {
+	Username            string
+	Password            string
+	IPAddress           string
+	ClientCode          string
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	MFACode             string
+}
@@ -0,0 +1,192 @@
+package changepassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtparser "github.com/p1xray/pxr-sso/pkg/jwt/parser"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for change password use-case.
+type Repository interface {
+	ClientByCode(ctx context.Context, code string) (dto.Client, error)
+	DataForChangePassword(ctx context.Context, username, ipAddress string) (dto.DataForChangePassword, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for changing the user password.
+type UseCase struct {
+	log            *slog.Logger
+	cfg            config.TokensConfig
+	throttleCfg    config.LoginThrottleConfig
+	passwordPolicy entity.PasswordPolicy
+	passwordHasher entity.PasswordHasher
+	repo           Repository
+}
+
+// New returns new change password use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	throttleCfg config.LoginThrottleConfig,
+	passwordPolicy entity.PasswordPolicy,
+	passwordHasher entity.PasswordHasher,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:            log,
+		cfg:            cfg,
+		throttleCfg:    throttleCfg,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		repo:           repo,
+	}
+}
+
+// Execute executes the use-case for changing the user password.
+// The current password is verified, the new password must meet the password policy.
+// The other user sessions are revoked, the number of revoked sessions is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (int, error) {
+	const op = "usecase.auth.changepassword"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("IP address", data.IPAddress),
+	)
+	log.Info("attempting to change user password")
+
+	// Get refresh token ID of the current session.
+	var currentRefreshTokenID string
+	if data.RefreshToken != "" {
+		refreshTokenID, err := uc.currentRefreshTokenID(ctx, log, data.RefreshToken, data.ClientCode)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		currentRefreshTokenID = refreshTokenID
+	}
+
+	// Get data for change password from storage.
+	storageChangePasswordData, err := uc.repo.DataForChangePassword(ctx, data.Username, data.IPAddress)
+	if err != nil {
+		log.Error("error getting user data from storage", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageChangePasswordData.User),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageChangePasswordData.LoginAttempts...),
+		entity.WithAuthSession(storageChangePasswordData.Sessions...),
+		entity.WithAuthPasswordHasher(uc.passwordHasher),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Change password.
+	changePasswordParams := entity.ChangePasswordParams{
+		Username:             data.Username,
+		CurrentPassword:      data.CurrentPassword,
+		IPAddress:            data.IPAddress,
+		NewPassword:          data.NewPassword,
+		PasswordPolicy:       uc.passwordPolicy,
+		ExceptRefreshTokenID: currentRefreshTokenID,
+	}
+	revokedCount, err := auth.ChangePassword(changePasswordParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("invalid credentials", sl.Err(err))
+
+			// The failed password check is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return 0, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrAccountLocked):
+			log.Warn("account is locked", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		case errors.Is(err, entity.ErrLoginThrottled):
+			log.Warn("password check is throttled", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrPasswordNotChanged):
+			log.Warn("new password matches the current password", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrPasswordNotChanged)
+		case errors.Is(err, entity.ErrWeakPassword):
+			log.Warn("new password does not meet the password policy", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w: %w", op, usecase.ErrWeakPassword, err)
+		case errors.Is(err, entity.ErrSessionNotFound):
+			log.Warn("current session not found", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrSessionNotFound)
+		}
+
+		log.Error("failed to change password", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user password changed successfully", slog.Int("revoked count", revokedCount))
+
+	return revokedCount, nil
+}
+
+func (uc *UseCase) currentRefreshTokenID(
+	ctx context.Context,
+	log *slog.Logger,
+	refreshToken, clientCode string,
+) (string, error) {
+	// Get client from storage.
+	client, err := uc.repo.ClientByCode(ctx, clientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return "", usecase.ErrClientNotFound
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+		return "", err
+	}
+
+	// Parse refresh token by client secret key.
+	refreshTokenClaims, err := jwtparser.ParseRefreshToken(refreshToken, []byte(client.SecretKey))
+	if err != nil {
+		log.Warn("error parsing refresh token", sl.Err(err))
+
+		return "", fmt.Errorf("%w: %w", usecase.ErrSessionNotFound, err)
+	}
+
+	return refreshTokenClaims.ID, nil
+}
@@ -0,0 +1,250 @@
+package changepassword
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const newPassword = "new-password"
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		username            string
+		currentPassword     string
+		newPassword         string
+		keepCurrentSession  bool
+		clientCode          string
+		expectedError       error
+		expectedRevoked     int
+		expectedSessions    int
+		expectedNewPassword bool
+	}{
+		{
+			name:                "changes the password and keeps the current session",
+			keepCurrentSession:  true,
+			expectedRevoked:     1,
+			expectedSessions:    1,
+			expectedNewPassword: true,
+		},
+		{
+			name:                "changes the password and revokes all the sessions",
+			expectedRevoked:     2,
+			expectedSessions:    0,
+			expectedNewPassword: true,
+		},
+		{
+			name:             "invalid current password",
+			currentPassword:  "wrong-password",
+			expectedError:    usecase.ErrInvalidCredentials,
+			expectedSessions: 2,
+		},
+		{
+			name:             "unknown user",
+			username:         "unknown",
+			expectedError:    usecase.ErrInvalidCredentials,
+			expectedSessions: 2,
+		},
+		{
+			name:             "new password is too short",
+			newPassword:      "short",
+			expectedError:    usecase.ErrWeakPassword,
+			expectedSessions: 2,
+		},
+		{
+			name:             "new password is breached",
+			newPassword:      usecasetest.BreachedPassword,
+			expectedError:    usecase.ErrWeakPassword,
+			expectedSessions: 2,
+		},
+		{
+			name:             "new password matches the current password",
+			newPassword:      usecasetest.Password,
+			expectedError:    usecase.ErrPasswordNotChanged,
+			expectedSessions: 2,
+		},
+		{
+			name:               "unknown client of the current session",
+			keepCurrentSession: true,
+			clientCode:         "unknown",
+			expectedError:      usecase.ErrClientNotFound,
+			expectedSessions:   2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+			authRepository := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				authRepository,
+			)
+			loginParams := login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			}
+
+			tokens, err := loginUseCase.Execute(ctx, loginParams)
+			require.NoError(t, err)
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			require.NoError(t, err)
+
+			uc := New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordPolicy(t),
+				usecasetest.PasswordHasher(),
+				authRepository,
+			)
+
+			params := Params{
+				Username:        "user",
+				CurrentPassword: usecasetest.Password,
+				NewPassword:     newPassword,
+			}
+			if tc.username != "" {
+				params.Username = tc.username
+			}
+			if tc.currentPassword != "" {
+				params.CurrentPassword = tc.currentPassword
+			}
+			if tc.newPassword != "" {
+				params.NewPassword = tc.newPassword
+			}
+			if tc.keepCurrentSession {
+				params.RefreshToken = tokens.RefreshToken
+				params.ClientCode = usecasetest.ClientCode
+			}
+			if tc.clientCode != "" {
+				params.ClientCode = tc.clientCode
+			}
+
+			revoked, err := uc.Execute(ctx, params)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRevoked, revoked)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+
+			// The user signs in with the new password only after it is changed.
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			if tc.expectedNewPassword {
+				assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+
+				loginParams.Password = newPassword
+				_, err = loginUseCase.Execute(ctx, loginParams)
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_UseCase_Execute_Throttle(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		expectedError error
+	}{
+		{
+			name:          "locks account after too many failed password checks",
+			failures:      3,
+			expectedError: usecase.ErrAccountLocked,
+		},
+		{
+			name:     "changes the password before lockout",
+			failures: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+			authRepository := repository.NewAuthRepository(log, fixture.Storage)
+
+			uc := New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.LoginThrottleConfig(3),
+				usecasetest.PasswordPolicy(t),
+				usecasetest.PasswordHasher(),
+				authRepository,
+			)
+
+			for range tc.failures {
+				_, err := uc.Execute(ctx, Params{
+					Username:        "user",
+					CurrentPassword: "wrong-password",
+					NewPassword:     newPassword,
+					IPAddress:       "192.0.2.1",
+				})
+				require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+			}
+
+			_, err := uc.Execute(ctx, Params{
+				Username:        "user",
+				CurrentPassword: usecasetest.Password,
+				NewPassword:     newPassword,
+				IPAddress:       "192.0.2.2",
+			})
+
+			// The failed password checks lock the login too.
+			loginUseCase := login.New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(3),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				authRepository,
+			)
+			_, loginErr := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				IPAddress:  "192.0.2.2",
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.ErrorIs(t, loginErr, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ErrorIs(t, loginErr, usecase.ErrInvalidCredentials)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package changepassword
+
+// Params is a data for change password use-case.
+// If RefreshToken is set, the session of the refresh token issued to the client is kept,
+// the other user sessions are revoked.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+type Params struct {
+	Username        string
+	CurrentPassword string
+	IPAddress       string
+	NewPassword     string
+	RefreshToken    string
+	ClientCode      string
+}
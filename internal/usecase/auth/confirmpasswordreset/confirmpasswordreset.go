@@ -0,0 +1,134 @@
+package confirmpasswordreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for confirm password reset use-case.
+type Repository interface {
+	DataForConfirmPasswordReset(ctx context.Context, tokenHash string) (dto.DataForConfirmPasswordReset, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for resetting the forgotten password by the password reset token.
+type UseCase struct {
+	log            *slog.Logger
+	cfg            config.TokensConfig
+	passwordPolicy entity.PasswordPolicy
+	passwordHasher entity.PasswordHasher
+	repo           Repository
+}
+
+// New returns new confirm password reset use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	passwordPolicy entity.PasswordPolicy,
+	passwordHasher entity.PasswordHasher,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:            log,
+		cfg:            cfg,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		repo:           repo,
+	}
+}
+
+// Execute executes the use-case for resetting the forgotten password by the password reset token.
+// The token is redeemed, the new password must meet the password policy. All the user sessions are revoked,
+// the number of revoked sessions is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (int, error) {
+	const op = "usecase.auth.confirmpasswordreset"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+	log.Info("attempting to confirm password reset")
+
+	// Get data for confirm password reset from storage.
+	tokenHash := entity.HashPasswordResetToken(data.Token)
+	storageConfirmPasswordResetData, err := uc.repo.DataForConfirmPasswordReset(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("password reset token not found", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrInvalidPasswordResetToken)
+		}
+
+		log.Error("error getting password reset data from storage", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageConfirmPasswordResetData.User),
+		entity.WithAuthSession(storageConfirmPasswordResetData.Sessions...),
+		entity.WithAuthPasswordResetToken(storageConfirmPasswordResetData.PasswordResetToken),
+		entity.WithAuthPasswordHasher(uc.passwordHasher),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Reset password.
+	confirmPasswordResetParams := entity.ConfirmPasswordResetParams{
+		NewPassword:    data.NewPassword,
+		PasswordPolicy: uc.passwordPolicy,
+	}
+	revokedCount, err := auth.ConfirmPasswordReset(confirmPasswordResetParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPasswordResetTokenNotFound),
+			errors.Is(err, entity.ErrPasswordResetTokenUsed),
+			errors.Is(err, entity.ErrPasswordResetTokenExpired),
+			errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("invalid password reset token", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrInvalidPasswordResetToken)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrWeakPassword):
+			log.Warn("new password does not meet the password policy", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w: %w", op, usecase.ErrWeakPassword, err)
+		}
+
+		log.Error("failed to reset password", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		// The token which has been redeemed concurrently is not found.
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("password reset token is already used", sl.Err(err))
+
+			return 0, fmt.Errorf("%s: %w", op, usecase.ErrInvalidPasswordResetToken)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("password reset successfully", slog.Int("revoked count", revokedCount))
+
+	return revokedCount, nil
+}
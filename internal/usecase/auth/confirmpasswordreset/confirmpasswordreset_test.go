@@ -0,0 +1,176 @@
+package confirmpasswordreset
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/notifier"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/requestpasswordreset"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const newPassword = "new-password"
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		token            string
+		newPassword      string
+		tokenTTL         time.Duration
+		blocked          bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "resets the password and revokes all the sessions",
+			expectedSessions: 0,
+		},
+		{
+			name:             "unknown token",
+			token:            "unknown",
+			expectedError:    usecase.ErrInvalidPasswordResetToken,
+			expectedSessions: 1,
+		},
+		{
+			name:             "expired token",
+			tokenTTL:         -time.Minute,
+			expectedError:    usecase.ErrInvalidPasswordResetToken,
+			expectedSessions: 1,
+		},
+		{
+			name:             "new password is too short",
+			newPassword:      "short",
+			expectedError:    usecase.ErrWeakPassword,
+			expectedSessions: 1,
+		},
+		{
+			name:             "user is blocked after the token is issued",
+			blocked:          true,
+			expectedError:    usecase.ErrUserBlocked,
+			expectedSessions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.SetUserEmail(t, userID, "user@example.com", true)
+			log := usecasetest.Logger()
+			tokensCfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			authRepository := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				tokensCfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				authRepository,
+			)
+			loginParams := login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			}
+
+			_, err := loginUseCase.Execute(ctx, loginParams)
+			require.NoError(t, err)
+
+			tokenTTL := time.Hour
+			if tc.tokenTTL != 0 {
+				tokenTTL = tc.tokenTTL
+			}
+			token := requestToken(t, ctx, fixture, tokenTTL)
+
+			if tc.blocked {
+				saved, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				saved.Blocked = true
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, saved))
+			}
+
+			uc := New(log, tokensCfg, usecasetest.PasswordPolicy(t), usecasetest.PasswordHasher(), authRepository)
+
+			params := Params{Token: token, NewPassword: newPassword}
+			if tc.token != "" {
+				params.Token = tc.token
+			}
+			if tc.newPassword != "" {
+				params.NewPassword = tc.newPassword
+			}
+
+			revoked, err := uc.Execute(ctx, params)
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, revoked)
+
+			// The token can be used only once.
+			_, err = uc.Execute(ctx, params)
+			assert.ErrorIs(t, err, usecase.ErrInvalidPasswordResetToken)
+
+			// The user signs in with the new password only.
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+
+			loginParams.Password = newPassword
+			_, err = loginUseCase.Execute(ctx, loginParams)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// requestToken requests the password reset of the test user and returns the token delivered to the outbox.
+func requestToken(t *testing.T, ctx context.Context, fixture usecasetest.Fixture, tokenTTL time.Duration) string {
+	t.Helper()
+
+	log := usecasetest.Logger()
+	outbox := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+
+	uc := requestpasswordreset.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		config.PasswordResetConfig{TokenTTL: tokenTTL, URL: "https://sso.example.com/reset-password"},
+		repository.NewAuthRepository(log, fixture.Storage),
+		outbox,
+	)
+	require.NoError(t, uc.Execute(ctx, requestpasswordreset.Params{Username: "user"}))
+
+	notifications, err := outbox.Notifications()
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+
+	for _, field := range strings.Fields(notifications[0].Body) {
+		if link, err := url.Parse(field); err == nil && link.Query().Has("token") {
+			return link.Query().Get("token")
+		}
+	}
+
+	require.Fail(t, "password reset link not found in the notification")
+
+	return ""
+}
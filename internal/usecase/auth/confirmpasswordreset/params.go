@@ -0,0 +1,7 @@
+package confirmpasswordreset
+
+// Params is a data for confirm password reset use-case.
+type Params struct {
+	Token       string
+	NewPassword string
+}
@@ -0,0 +1,108 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for client credentials use-case.
+type Repository interface {
+	DataForClientCredentials(ctx context.Context, clientCode string) (dto.DataForClientCredentials, error)
+}
+
+// UseCase is a use-case for issuing service-to-service tokens by the client credentials grant.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.TokensConfig
+	keyStore *jwtkeys.Store
+	repo     Repository
+}
+
+// New returns new client credentials use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		keyStore: keyStore,
+		repo:     repo,
+	}
+}
+
+// Execute executes the use-case for issuing client tokens by the client credentials grant.
+// If successful, a new access token of the client is returned. No session or refresh token is created.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
+	const op = "usecase.auth.credentials"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("client code", data.ClientCode),
+	)
+	log.Info("attempting to issue client tokens")
+
+	// Get client data from storage.
+	storageClientData, err := uc.repo.DataForClientCredentials(ctx, data.ClientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidClient)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create client credentials entity.
+	clientCredentials, err := entity.NewClientCredentials(
+		uc.cfg.AccessTokenTTL,
+		entity.WithClientCredentialsSigningKey(signingKey),
+		entity.WithClientCredentialsClient(storageClientData.Client),
+		entity.WithClientCredentialsPermissionCodes(storageClientData.PermissionCodes...),
+	)
+	if err != nil {
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Issue tokens.
+	entityIssueParams := entity.IssueClientTokensParams{
+		ClientSecret: data.ClientSecret,
+		Scopes:       data.Scopes,
+		Issuer:       data.Issuer,
+	}
+	tokens, err := clientCredentials.IssueTokens(entityIssueParams)
+	if err != nil {
+		log.Warn("failed to issue client tokens", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrInvalidClientCredentials):
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidClient)
+		case errors.Is(err, entity.ErrInvalidScope):
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidScope)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("client tokens issued successfully")
+
+	return tokens, nil
+}
@@ -0,0 +1,10 @@
+package credentials
+
+// Params is a data for client credentials use-case.
+// If Scopes is empty, all permissions of the client are requested.
+type Params struct {
+	ClientCode   string
+	ClientSecret string
+	Scopes       []string
+	Issuer       string
+}
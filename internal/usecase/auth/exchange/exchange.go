@@ -0,0 +1,153 @@
+package exchange
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for exchange authorization code use-case.
+type Repository interface {
+	DataForExchangeAuthorizationCode(
+		ctx context.Context,
+		codeHash, clientCode string,
+	) (dto.DataForExchangeAuthorizationCode, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for exchanging the authorization code for user tokens.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.TokensConfig
+	keyStore *jwtkeys.Store
+	repo     Repository
+}
+
+// New returns new exchange authorization code use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		keyStore: keyStore,
+		repo:     repo,
+	}
+}
+
+// Execute executes the use-case for exchanging the authorization code for user tokens.
+// If successful, new tokens are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
+	const op = "usecase.auth.exchange"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("client code", data.ClientCode),
+		slog.String("redirect uri", data.RedirectURI),
+	)
+	log.Info("attempting to exchange authorization code")
+
+	// Get authorization code data from storage.
+	codeHash := entity.HashAuthorizationCode(data.Code)
+	storageExchangeData, err := uc.repo.DataForExchangeAuthorizationCode(ctx, codeHash, data.ClientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidGrant)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check client secret if the client authenticates.
+	if data.ClientSecret != "" &&
+		subtle.ConstantTimeCompare([]byte(data.ClientSecret), []byte(storageExchangeData.Client.SecretKey)) != 1 {
+		log.Warn("invalid client secret")
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidClient)
+	}
+
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
+		entity.WithAuthSessionLimit(entity.SessionLimit{
+			MaxSessions:    uc.cfg.MaxSessionsPerClient,
+			EvictionPolicy: uc.cfg.SessionEvictionPolicy,
+		}),
+		entity.WithAuthUser(storageExchangeData.User),
+		entity.WithAuthClient(storageExchangeData.Client),
+		entity.WithAuthSession(storageExchangeData.Sessions...),
+		entity.WithAuthAuthorizationCode(storageExchangeData.AuthorizationCode),
+	)
+	if err != nil {
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Exchange authorization code.
+	entityExchangeParams := entity.ExchangeAuthorizationCodeParams{
+		RedirectURI:  data.RedirectURI,
+		CodeVerifier: data.CodeVerifier,
+		UserAgent:    data.UserAgent,
+		Fingerprint:  data.Fingerprint,
+		Issuer:       data.Issuer,
+	}
+	tokens, err := auth.ExchangeAuthorizationCode(entityExchangeParams)
+	if err != nil {
+		log.Warn("failed to exchange authorization code", sl.Err(err))
+
+		switch {
+		case errors.Is(err, entity.ErrAuthorizationCodeUsed),
+			errors.Is(err, entity.ErrAuthorizationCodeExpired),
+			errors.Is(err, entity.ErrAuthorizationCodeMismatch),
+			errors.Is(err, entity.ErrInvalidCodeVerifier),
+			errors.Is(err, entity.ErrInvalidCredentials),
+			errors.Is(err, entity.ErrUserBlocked),
+			errors.Is(err, entity.ErrPasswordChangeRequired),
+			errors.Is(err, entity.ErrEmailNotVerified):
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidGrant)
+		case errors.Is(err, entity.ErrSessionLimitExceeded):
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrSessionLimitExceeded)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data in storage.
+	err = uc.repo.Save(ctx, &auth)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("authorization code is already redeemed", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidGrant)
+		}
+
+		log.Error("error saving data to storage.", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("authorization code exchanged successfully")
+
+	return tokens, nil
+}
@@ -0,0 +1,14 @@
+package exchange
+
+// Params is a data for exchange authorization code use-case.
+// The client secret is optional for public clients, the authorization code is protected by PKCE.
+type Params struct {
+	Code         string
+	ClientCode   string
+	ClientSecret string
+	RedirectURI  string
+	CodeVerifier string
+	UserAgent    string
+	Fingerprint  string
+	Issuer       string
+}
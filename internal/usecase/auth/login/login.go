@@ -9,33 +9,54 @@ import (
 	"github.com/p1xray/pxr-sso/internal/entity"
 	"github.com/p1xray/pxr-sso/internal/infrastructure"
 	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	"github.com/p1xray/pxr-sso/pkg/logger/sl"
 	"log/slog"
 )
 
 // Repository is a repository for log in use-case.
 type Repository interface {
-	DataForLogin(ctx context.Context, username, clientCode string) (dto.DataForLogin, error)
+	DataForLogin(ctx context.Context, username, clientCode, ipAddress string) (dto.DataForLogin, error)
 	Save(ctx context.Context, auth *entity.Auth) error
 }
 
 // UseCase is a use-case for logging in a user.
 type UseCase struct {
-	log  *slog.Logger
-	cfg  config.TokensConfig
-	repo Repository
+	log            *slog.Logger
+	cfg            config.TokensConfig
+	mfaCfg         config.MFAConfig
+	throttleCfg    config.LoginThrottleConfig
+	passwordHasher entity.PasswordHasher
+	keyStore       *jwtkeys.Store
+	repo           Repository
 }
 
 // New returns new log in use-case.
-func New(log *slog.Logger, cfg config.TokensConfig, repo Repository) *UseCase {
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	mfaCfg config.MFAConfig,
+	throttleCfg config.LoginThrottleConfig,
+	passwordHasher entity.PasswordHasher,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
 	return &UseCase{
-		log:  log,
-		cfg:  cfg,
-		repo: repo,
+		log:            log,
+		cfg:            cfg,
+		mfaCfg:         mfaCfg,
+		throttleCfg:    throttleCfg,
+		passwordHasher: passwordHasher,
+		keyStore:       keyStore,
+		repo:           repo,
 	}
 }
 
 // Execute executes the use-case for logging in a user. If successful, new tokens are returned.
+// If the user must pass the second authentication factor, only the MFA token is returned,
+// the login is completed by the verify MFA use-case.
+// The failed logins are counted per username and per client IP address, the logins are delayed and locked
+// after too many failures. The outdated password hash is rehashed by the password hasher.
 func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
 	const op = "usecase.auth.login"
 
@@ -43,11 +64,12 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 		slog.String("op", op),
 		slog.String("username", data.Username),
 		slog.String("client code", data.ClientCode),
+		slog.String("IP address", data.IPAddress),
 	)
 	log.Info("attempting to login user")
 
 	// Get user data from storage.
-	storageLoginData, err := uc.repo.DataForLogin(ctx, data.Username, data.ClientCode)
+	storageLoginData, err := uc.repo.DataForLogin(ctx, data.Username, data.ClientCode, data.IPAddress)
 	if err != nil {
 		if errors.Is(err, infrastructure.ErrEntityNotFound) {
 			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
@@ -56,13 +78,31 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Create auth entity.
 	auth, err := entity.NewAuth(
 		uc.cfg.AccessTokenTTL,
 		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
+		entity.WithAuthSessionLimit(entity.SessionLimit{
+			MaxSessions:    uc.cfg.MaxSessionsPerClient,
+			EvictionPolicy: uc.cfg.SessionEvictionPolicy,
+		}),
+		entity.WithAuthNonce(data.Nonce),
 		entity.WithAuthUser(storageLoginData.User),
+		entity.WithAuthMFA(storageLoginData.MFA),
 		entity.WithAuthClient(storageLoginData.Client),
 		entity.WithAuthSession(storageLoginData.Sessions...),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageLoginData.LoginAttempts...),
+		entity.WithAuthPasswordHasher(uc.passwordHasher),
 	)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -70,13 +110,71 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 
 	// Log in.
 	entityLoginParams := entity.LoginParams{
-		Password:    data.Password,
-		UserAgent:   data.UserAgent,
-		Fingerprint: data.Fingerprint,
-		Issuer:      data.Issuer,
+		Username:        data.Username,
+		Password:        data.Password,
+		IPAddress:       data.IPAddress,
+		UserAgent:       data.UserAgent,
+		Fingerprint:     data.Fingerprint,
+		Issuer:          data.Issuer,
+		MFAChallengeTTL: uc.mfaCfg.ChallengeTTL,
 	}
 	tokens, err := auth.Login(entityLoginParams)
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCredentials) {
+			log.Warn("invalid credentials", sl.Err(err))
+
+			// The failed login is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		}
+
+		if errors.Is(err, entity.ErrAccountLocked) {
+			log.Warn("account is locked", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		}
+
+		if errors.Is(err, entity.ErrLoginThrottled) {
+			log.Warn("login is throttled", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		}
+
+		if errors.Is(err, entity.ErrSessionLimitExceeded) {
+			log.Warn("session limit exceeded", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrSessionLimitExceeded)
+		}
+
+		if errors.Is(err, entity.ErrUserBlocked) {
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		}
+
+		if errors.Is(err, entity.ErrPasswordChangeRequired) {
+			log.Warn("password change required", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrPasswordChangeRequired)
+		}
+
+		if errors.Is(err, entity.ErrEmailNotVerified) {
+			log.Warn("email is not verified", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrEmailNotVerified)
+		}
+
+		if errors.Is(err, entity.ErrMFAEnrollmentRequired) {
+			log.Warn("second authentication factor must be enrolled", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrMFAEnrollmentRequired)
+		}
+
 		log.Error("failed to login", sl.Err(err))
 
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -90,6 +188,12 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if tokens.MFAToken != "" {
+		log.Info("second authentication factor required to complete login")
+
+		return tokens, nil
+	}
+
 	log.Info("user logged in successfully")
 
 	return tokens, nil
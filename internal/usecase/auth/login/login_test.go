@@ -0,0 +1,495 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/p1xray/pxr-sso/pkg/passhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		maxSessions      int
+		evictionPolicy   enum.SessionEvictionPolicyEnum
+		previousLogins   int
+		username         string
+		password         string
+		requireEmail     bool
+		emailVerified    bool
+		requireMFA       bool
+		totpEnabled      bool
+		expectedMFA      bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "logs in user",
+			maxSessions:      5,
+			evictionPolicy:   enum.EvictOldest,
+			username:         "user",
+			password:         usecasetest.Password,
+			expectedSessions: 1,
+		},
+		{
+			name:             "evicts oldest session when limit is reached",
+			maxSessions:      2,
+			evictionPolicy:   enum.EvictOldest,
+			previousLogins:   2,
+			username:         "user",
+			password:         usecasetest.Password,
+			expectedSessions: 2,
+		},
+		{
+			name:             "rejects new session when limit is reached",
+			maxSessions:      1,
+			evictionPolicy:   enum.RejectNewSession,
+			previousLogins:   1,
+			username:         "user",
+			password:         usecasetest.Password,
+			expectedError:    usecase.ErrSessionLimitExceeded,
+			expectedSessions: 1,
+		},
+		{
+			name:           "unknown user",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "unknown",
+			password:       usecasetest.Password,
+			expectedError:  usecase.ErrInvalidCredentials,
+		},
+		{
+			name:           "wrong password",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "user",
+			password:       "wrong",
+			expectedError:  usecase.ErrInvalidCredentials,
+		},
+		{
+			name:             "logs in user with verified email to client which requires it",
+			maxSessions:      5,
+			evictionPolicy:   enum.EvictOldest,
+			username:         "user",
+			password:         usecasetest.Password,
+			requireEmail:     true,
+			emailVerified:    true,
+			expectedSessions: 1,
+		},
+		{
+			name:           "client requires verified email",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "user",
+			password:       usecasetest.Password,
+			requireEmail:   true,
+			expectedError:  usecase.ErrEmailNotVerified,
+		},
+		{
+			name:           "returns MFA token for user with enabled TOTP",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "user",
+			password:       usecasetest.Password,
+			totpEnabled:    true,
+			expectedMFA:    true,
+		},
+		{
+			name:           "returns MFA token for client which requires MFA",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "user",
+			password:       usecasetest.Password,
+			requireMFA:     true,
+			totpEnabled:    true,
+			expectedMFA:    true,
+		},
+		{
+			name:           "client requires MFA enrollment",
+			maxSessions:    5,
+			evictionPolicy: enum.EvictOldest,
+			username:       "user",
+			password:       usecasetest.Password,
+			requireMFA:     true,
+			expectedError:  usecase.ErrMFAEnrollmentRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.SetUserEmail(t, userID, "user@example.com", tc.emailVerified)
+
+			if tc.requireEmail {
+				client, err := fixture.Storage.Client(ctx, fixture.ClientID)
+				require.NoError(t, err)
+
+				client.RequireVerifiedEmail = true
+				require.NoError(t, fixture.Storage.UpdateClient(ctx, client))
+			}
+
+			if tc.requireMFA {
+				fixture.RequireMFA(t)
+			}
+
+			if tc.totpEnabled {
+				fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret)
+			}
+
+			uc := New(
+				usecasetest.Logger(),
+				usecasetest.TokensConfig(tc.maxSessions, tc.evictionPolicy),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+			)
+
+			for range tc.previousLogins {
+				_, err := uc.Execute(ctx, Params{
+					Username:   "user",
+					Password:   usecasetest.Password,
+					ClientCode: usecasetest.ClientCode,
+					Issuer:     usecasetest.Issuer,
+				})
+				require.NoError(t, err)
+			}
+
+			tokens, err := uc.Execute(ctx, Params{
+				Username:   tc.username,
+				Password:   tc.password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+
+			switch {
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+			case tc.expectedMFA:
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.MFAToken)
+				assert.Empty(t, tokens.AccessToken)
+				assert.Empty(t, tokens.RefreshToken)
+
+				// The MFA challenge is saved instead of the session.
+				challenge, err := fixture.Storage.MFAChallengeByHash(ctx, entity.HashMFAChallengeToken(tokens.MFAToken))
+				require.NoError(t, err)
+				assert.Equal(t, userID, challenge.UserID)
+				assert.Equal(t, fixture.ClientID, challenge.ClientID)
+			default:
+				require.NoError(t, err)
+				assert.Empty(t, tokens.MFAToken)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+
+				// The session of the issued refresh token is saved.
+				session, err := fixture.Storage.SessionByRefreshTokenID(ctx, tokens.RefreshTokenID)
+				require.NoError(t, err)
+				assert.Equal(t, userID, session.UserID)
+				assert.Equal(t, fixture.ClientID, session.ClientID.Int64)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
+
+func Test_UseCase_Execute_Throttle(t *testing.T) {
+	testCases := []struct {
+		name           string
+		failures       int
+		failedUsername string
+		username       string
+		ipAddress      string
+		expectedError  error
+	}{
+		{
+			name:           "locks account after too many failures",
+			failures:       3,
+			failedUsername: "user",
+			username:       "user",
+			ipAddress:      "192.0.2.2",
+			expectedError:  usecase.ErrAccountLocked,
+		},
+		{
+			name:           "locks unknown username after too many failures",
+			failures:       3,
+			failedUsername: "unknown",
+			username:       "unknown",
+			ipAddress:      "192.0.2.2",
+			expectedError:  usecase.ErrAccountLocked,
+		},
+		{
+			name:           "throttles address after too many failures",
+			failures:       3,
+			failedUsername: "unknown",
+			username:       "user",
+			ipAddress:      "192.0.2.1",
+			expectedError:  usecase.ErrLoginThrottled,
+		},
+		{
+			name:           "logs in user before lockout",
+			failures:       2,
+			failedUsername: "user",
+			username:       "user",
+			ipAddress:      "192.0.2.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			fixture.CreateUser(t, "user")
+
+			uc := New(
+				usecasetest.Logger(),
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(3),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+			)
+
+			for range tc.failures {
+				_, err := uc.Execute(ctx, Params{
+					Username:   tc.failedUsername,
+					Password:   "wrong",
+					IPAddress:  "192.0.2.1",
+					ClientCode: usecasetest.ClientCode,
+					Issuer:     usecasetest.Issuer,
+				})
+				require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+			}
+
+			_, err := uc.Execute(ctx, Params{
+				Username:   tc.username,
+				Password:   usecasetest.Password,
+				IPAddress:  tc.ipAddress,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_UseCase_Execute_ResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+
+	uc := New(
+		usecasetest.Logger(),
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(3),
+		usecasetest.PasswordHasher(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+	)
+
+	login := func(password, ipAddress string) error {
+		_, err := uc.Execute(ctx, Params{
+			Username:   "user",
+			Password:   password,
+			IPAddress:  ipAddress,
+			ClientCode: usecasetest.ClientCode,
+			Issuer:     usecasetest.Issuer,
+		})
+
+		return err
+	}
+
+	// The successful login forgets the failures of the username, so the failures do not add up to the lockout.
+	for _, ipAddress := range []string{"192.0.2.1", "192.0.2.2"} {
+		require.ErrorIs(t, login("wrong", ipAddress), usecase.ErrInvalidCredentials)
+		require.ErrorIs(t, login("wrong", ipAddress), usecase.ErrInvalidCredentials)
+		require.NoError(t, login(usecasetest.Password, ipAddress))
+	}
+
+	_, err := fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindUsername), "user")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	// The failures of the address are kept.
+	attempt, err := fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindIP), "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempt.Failures)
+}
+
+func Test_UseCase_Execute_CountsConcurrentFailures(t *testing.T) {
+	t.Parallel()
+
+	const failures = 20
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+
+	uc := New(
+		usecasetest.Logger(),
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(failures),
+		usecasetest.PasswordHasher(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+	)
+
+	// The failed logins read the same attempt concurrently, so none of the failures must be lost.
+	var wg sync.WaitGroup
+	errs := make(chan error, failures)
+	for i := range failures {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := uc.Execute(ctx, Params{
+				Username:   "user",
+				Password:   "wrong",
+				IPAddress:  fmt.Sprintf("192.0.2.%d", i+1),
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+	}
+
+	attempt, err := fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindUsername), "user")
+	require.NoError(t, err)
+	assert.Equal(t, failures, attempt.Failures)
+	assert.True(t, attempt.LockedUntil.Valid)
+
+	_, err = uc.Execute(ctx, Params{
+		Username:   "user",
+		Password:   usecasetest.Password,
+		IPAddress:  "192.0.2.100",
+		ClientCode: usecasetest.ClientCode,
+		Issuer:     usecasetest.Issuer,
+	})
+	assert.ErrorIs(t, err, usecase.ErrAccountLocked)
+}
+
+func Test_UseCase_Execute_RemovesExpiredFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+
+	uc := New(
+		usecasetest.Logger(),
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(3),
+		usecasetest.PasswordHasher(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+	)
+
+	// The attempt of the sprayed username failed before the failure window.
+	lastFailureAt := time.Now().Add(-2 * time.Hour)
+	_, err := fixture.Storage.FailLoginAttempt(ctx, models.LoginAttempt{
+		Kind:          string(enum.LoginAttemptKindUsername),
+		Key:           "sprayed",
+		LastFailureAt: lastFailureAt,
+		CreatedAt:     lastFailureAt,
+		UpdatedAt:     lastFailureAt,
+	}, time.Time{})
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{
+		Username:   "user",
+		Password:   "wrong",
+		IPAddress:  "192.0.2.1",
+		ClientCode: usecasetest.ClientCode,
+		Issuer:     usecasetest.Issuer,
+	})
+	require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+
+	_, err = fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindUsername), "sprayed")
+	assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+
+	attempt, err := fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindUsername), "user")
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+}
+
+func Test_UseCase_Execute_RehashesPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+
+	hasher := passhash.Argon2id{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+	uc := New(
+		usecasetest.Logger(),
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(3),
+		hasher,
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+	)
+
+	params := Params{
+		Username:   "user",
+		Password:   usecasetest.Password,
+		ClientCode: usecasetest.ClientCode,
+		Issuer:     usecasetest.Issuer,
+	}
+
+	// The bcrypt hash of the test user is rehashed with argon2id.
+	_, err := uc.Execute(ctx, params)
+	require.NoError(t, err)
+
+	user, err := fixture.Storage.UserByUsername(ctx, "user")
+	require.NoError(t, err)
+	assert.False(t, hasher.NeedsRehash(user.PasswordHash))
+	require.NoError(t, passhash.Verify(user.PasswordHash, usecasetest.Password))
+
+	// The rehashed password is kept.
+	_, err = uc.Execute(ctx, params)
+	require.NoError(t, err)
+
+	rehashedUser, err := fixture.Storage.UserByUsername(ctx, "user")
+	require.NoError(t, err)
+	assert.Equal(t, user.PasswordHash, rehashedUser.PasswordHash)
+}
@@ -1,11 +1,14 @@
 package login
 
 // Params is a data for log in use-case.
+// IPAddress is the address of the client the failed logins are counted by, it is optional.
 type Params struct {
 	Username    string
 	Password    string
+	IPAddress   string
 	ClientCode  string
 	UserAgent   string
 	Fingerprint string
 	Issuer      string
+	Nonce       string
 }
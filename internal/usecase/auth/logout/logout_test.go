@@ -0,0 +1,95 @@
+package logout
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		clientCode       string
+		loggedOut        bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "logs out user",
+			clientCode:       usecasetest.ClientCode,
+			expectedSessions: 0,
+		},
+		{
+			name:             "session is already logged out",
+			clientCode:       usecasetest.ClientCode,
+			loggedOut:        true,
+			expectedError:    usecase.ErrSessionNotFound,
+			expectedSessions: 0,
+		},
+		{
+			name:             "unknown client",
+			clientCode:       "unknown",
+			expectedError:    usecase.ErrClientNotFound,
+			expectedSessions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				cfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repo,
+			)
+			tokens, err := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			uc := New(log, cfg, repo)
+
+			if tc.loggedOut {
+				require.NoError(t, uc.Execute(ctx, Params{
+					RefreshToken: tokens.RefreshToken,
+					ClientCode:   usecasetest.ClientCode,
+				}))
+			}
+
+			err = uc.Execute(ctx, Params{
+				RefreshToken: tokens.RefreshToken,
+				ClientCode:   tc.clientCode,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/p1xray/pxr-sso/internal/entity"
 	"github.com/p1xray/pxr-sso/internal/infrastructure"
 	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	jwtparser "github.com/p1xray/pxr-sso/pkg/jwt/parser"
 	"github.com/p1xray/pxr-sso/pkg/logger/sl"
 	"log/slog"
+	"strconv"
 )
 
 // Repository is a repository for refresh user tokens use-case.
@@ -22,19 +24,34 @@ type Repository interface {
 	Save(ctx context.Context, auth *entity.Auth) error
 }
 
+// EventPublisher is a publisher of the security events for refresh user tokens use-case.
+type EventPublisher interface {
+	PublishSecurityEvent(ctx context.Context, event entity.SecurityEvent) error
+}
+
 // UseCase is a use-case for refreshing user tokens.
 type UseCase struct {
-	log  *slog.Logger
-	cfg  config.TokensConfig
-	repo Repository
+	log      *slog.Logger
+	cfg      config.TokensConfig
+	keyStore *jwtkeys.Store
+	repo     Repository
+	events   EventPublisher
 }
 
 // New returns new refresh user tokens use-case.
-func New(log *slog.Logger, cfg config.TokensConfig, repo Repository) *UseCase {
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+	events EventPublisher,
+) *UseCase {
 	return &UseCase{
-		log:  log,
-		cfg:  cfg,
-		repo: repo,
+		log:      log,
+		cfg:      cfg,
+		keyStore: keyStore,
+		repo:     repo,
+		events:   events,
 	}
 }
 
@@ -83,13 +100,24 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 		return entity.Tokens{}, err
 	}
 
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Create auth entity.
 	auth, err := entity.NewAuth(
 		uc.cfg.AccessTokenTTL,
 		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
 		entity.WithAuthUser(storageRefreshTokensData.User),
 		entity.WithAuthClient(client),
 		entity.WithAuthSession(storageRefreshTokensData.Session),
+		entity.WithAuthSession(storageRefreshTokensData.FamilySessions...),
+		entity.WithAuthConsumedRefreshToken(storageRefreshTokensData.ConsumedRefreshToken),
 	)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -103,6 +131,12 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 	}
 	tokens, err := auth.RefreshTokens(entityRefreshTokensParams)
 	if err != nil {
+		if errors.Is(err, entity.ErrRefreshTokenReused) {
+			log.Warn("refresh token is reused, revoking token family", sl.Err(err))
+
+			return entity.Tokens{}, uc.revokeTokenFamily(ctx, log, &auth, storageRefreshTokensData, data.ClientCode)
+		}
+
 		log.Error("failed to refresh tokens", sl.Err(err))
 
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -111,6 +145,13 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 	// Save data to storage.
 	err = uc.repo.Save(ctx, &auth)
 	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			// The refresh token has been rotated by the concurrent request.
+			log.Warn("refresh token is already consumed", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrSessionNotFound)
+		}
+
 		log.Error("error saving data to storage.", sl.Err(err))
 
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -120,3 +161,40 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 
 	return tokens, nil
 }
+
+// revokeTokenFamily removes the sessions of the token family from storage and publishes the security event.
+// ErrRefreshTokenReused is returned if the token family is revoked successfully.
+func (uc *UseCase) revokeTokenFamily(
+	ctx context.Context,
+	log *slog.Logger,
+	auth *entity.Auth,
+	storageRefreshTokensData dto.DataForRefreshTokens,
+	clientCode string,
+) error {
+	const op = "usecase.auth.refresh.revokeTokenFamily"
+
+	// Save data to storage.
+	if err := uc.repo.Save(ctx, auth); err != nil {
+		log.Error("error saving data to storage.", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Publish security event.
+	event := entity.NewSecurityEvent(
+		entity.SecurityEventRefreshTokenReused,
+		storageRefreshTokensData.ConsumedRefreshToken.UserID,
+		clientCode,
+		map[string]string{
+			"family id":              storageRefreshTokensData.ConsumedRefreshToken.FamilyID,
+			"revoked sessions count": strconv.Itoa(len(storageRefreshTokensData.FamilySessions)),
+		},
+	)
+	if err := uc.events.PublishSecurityEvent(ctx, event); err != nil {
+		log.Error("error publishing security event", sl.Err(err))
+	}
+
+	log.Info("token family revoked")
+
+	return fmt.Errorf("%s: %w", op, usecase.ErrRefreshTokenReused)
+}
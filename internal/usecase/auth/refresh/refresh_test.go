@@ -0,0 +1,130 @@
+package refresh
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/events"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		clientCode       string
+		reuse            bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "rotates refresh token",
+			clientCode:       usecasetest.ClientCode,
+			expectedSessions: 1,
+		},
+		{
+			name:             "revokes token family when refresh token is reused",
+			clientCode:       usecasetest.ClientCode,
+			reuse:            true,
+			expectedError:    usecase.ErrRefreshTokenReused,
+			expectedSessions: 0,
+		},
+		{
+			name:             "unknown client",
+			clientCode:       "unknown",
+			expectedError:    usecase.ErrClientNotFound,
+			expectedSessions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			keyStore := usecasetest.KeyStore(t)
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				cfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				keyStore,
+				repo,
+			)
+			loginTokens, err := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			uc := New(log, cfg, keyStore, repo, events.NewLogPublisher(log))
+
+			refreshToken := loginTokens.RefreshToken
+			if tc.reuse {
+				_, err = uc.Execute(ctx, Params{
+					RefreshToken: refreshToken,
+					ClientCode:   usecasetest.ClientCode,
+					Issuer:       usecasetest.Issuer,
+				})
+				require.NoError(t, err)
+			}
+
+			tokens, err := uc.Execute(ctx, Params{
+				RefreshToken: refreshToken,
+				ClientCode:   tc.clientCode,
+				Issuer:       usecasetest.Issuer,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, loginTokens.RefreshTokenID, tokens.RefreshTokenID)
+
+				// The rotated refresh token is consumed, the new session continues its family.
+				consumed, err := fixture.Storage.ConsumedRefreshTokenByID(ctx, loginTokens.RefreshTokenID)
+				require.NoError(t, err)
+
+				session, err := fixture.Storage.SessionByRefreshTokenID(ctx, tokens.RefreshTokenID)
+				require.NoError(t, err)
+				assert.Equal(t, consumed.FamilyID, session.FamilyID)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
+
+func Test_UseCase_Execute_InvalidRefreshToken(t *testing.T) {
+	fixture := usecasetest.NewFixture(t)
+	log := usecasetest.Logger()
+
+	uc := New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(log, fixture.Storage),
+		events.NewLogPublisher(log),
+	)
+
+	_, err := uc.Execute(context.Background(), Params{
+		RefreshToken: "invalid",
+		ClientCode:   usecasetest.ClientCode,
+		Issuer:       usecasetest.Issuer,
+	})
+	assert.Error(t, err)
+}
@@ -17,4 +17,5 @@ type Params struct {
 	UserAgent     string
 	Fingerprint   string
 	Issuer        string
+	Nonce         string
 }
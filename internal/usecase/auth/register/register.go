@@ -9,6 +9,7 @@ import (
 	"github.com/p1xray/pxr-sso/internal/entity"
 	"github.com/p1xray/pxr-sso/internal/infrastructure"
 	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	"github.com/p1xray/pxr-sso/pkg/logger/sl"
 	"log/slog"
 )
@@ -17,25 +18,40 @@ import (
 type Repository interface {
 	DataForRegister(ctx context.Context, username, clientCode string) (dto.DataForRegister, error)
 	Save(ctx context.Context, auth *entity.Auth) error
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // UseCase is a use-case for registering a new user.
 type UseCase struct {
-	log  *slog.Logger
-	cfg  config.TokensConfig
-	repo Repository
+	log            *slog.Logger
+	cfg            config.TokensConfig
+	passwordPolicy entity.PasswordPolicy
+	passwordHasher entity.PasswordHasher
+	keyStore       *jwtkeys.Store
+	repo           Repository
 }
 
 // New returns new register a new user use-case.
-func New(log *slog.Logger, cfg config.TokensConfig, repo Repository) *UseCase {
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	passwordPolicy entity.PasswordPolicy,
+	passwordHasher entity.PasswordHasher,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
 	return &UseCase{
-		log:  log,
-		cfg:  cfg,
-		repo: repo,
+		log:            log,
+		cfg:            cfg,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		keyStore:       keyStore,
+		repo:           repo,
 	}
 }
 
-// Execute executes the use-case for registering a new user. If successful, new tokens are returned.
+// Execute executes the use-case for registering a new user. The password must meet the password policy.
+// If successful, new tokens are returned.
 func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
 	const op = "usecase.auth.register"
 
@@ -54,14 +70,25 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Create auth entity.
 	auth, err := entity.NewAuth(
 		uc.cfg.AccessTokenTTL,
 		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
+		entity.WithAuthNonce(data.Nonce),
 		entity.WithAuthUser(storageData.User),
 		entity.WithAuthClient(storageData.Client),
 		entity.WithAuthDefaultRoles(storageData.ClientDefaultRoles...),
 		entity.WithAuthDefaultPermissionCodes(storageData.ClientDefaultPermissionCodes...),
+		entity.WithAuthPasswordHasher(uc.passwordHasher),
 	)
 	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
@@ -69,15 +96,16 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 
 	// Register.
 	entityRegisterParams := entity.RegisterParams{
-		Username:      data.Username,
-		Password:      data.Password,
-		FullName:      data.FIO,
-		DateOfBirth:   data.DateOfBirth,
-		Gender:        data.Gender,
-		AvatarFileKey: data.AvatarFileKey,
-		UserAgent:     data.UserAgent,
-		Fingerprint:   data.Fingerprint,
-		Issuer:        data.Issuer,
+		Username:       data.Username,
+		Password:       data.Password,
+		PasswordPolicy: uc.passwordPolicy,
+		FullName:       data.FIO,
+		DateOfBirth:    data.DateOfBirth,
+		Gender:         data.Gender,
+		AvatarFileKey:  data.AvatarFileKey,
+		UserAgent:      data.UserAgent,
+		Fingerprint:    data.Fingerprint,
+		Issuer:         data.Issuer,
 	}
 	err = auth.Register(entityRegisterParams)
 	if err != nil {
@@ -87,32 +115,47 @@ func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, err
 			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrUserExists)
 		}
 
+		if errors.Is(err, entity.ErrWeakPassword) {
+			log.Warn("password does not meet the password policy", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w: %w", op, usecase.ErrWeakPassword, err)
+		}
+
 		log.Error("failed to register", sl.Err(err))
 
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Save user data to storage.
-	err = uc.repo.Save(ctx, &auth)
-	if err != nil {
-		log.Error("error saving user data to storage.", sl.Err(err))
+	// Save user and session data to storage in one unit of work.
+	// The user is saved first, because the session tokens are issued for the saved user ID.
+	var tokens entity.Tokens
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		// Save user data to storage.
+		if err := uc.repo.Save(ctx, &auth); err != nil {
+			log.Error("error saving user data to storage.", sl.Err(err))
 
-		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
-	}
+			return err
+		}
 
-	// Create new session for saved user.
-	tokens, err := auth.CreateNewSession(data.Issuer, data.UserAgent, data.Fingerprint)
-	if err != nil {
-		log.Error("error creating new session for registered user.", sl.Err(err))
+		// Create new session for saved user.
+		var err error
+		tokens, err = auth.CreateNewSession(data.Issuer, data.UserAgent, data.Fingerprint)
+		if err != nil {
+			log.Error("error creating new session for registered user.", sl.Err(err))
 
-		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
-	}
+			return err
+		}
 
-	// Save session data to storage.
-	err = uc.repo.Save(ctx, &auth)
-	if err != nil {
-		log.Error("error saving session data to storage.", sl.Err(err))
+		// Save session data to storage.
+		if err = uc.repo.Save(ctx, &auth); err != nil {
+			log.Error("error saving session data to storage.", sl.Err(err))
 
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
 		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -0,0 +1,109 @@
+package register
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		password      string
+		expectedError error
+	}{
+		{
+			name:     "registers new user",
+			username: "new user",
+		},
+		{
+			name:          "user already exists",
+			username:      "existing user",
+			expectedError: usecase.ErrUserExists,
+		},
+		{
+			name:          "password is too short",
+			username:      "new user",
+			password:      "short",
+			expectedError: usecase.ErrWeakPassword,
+		},
+		{
+			name:          "password is the username",
+			username:      "new-user-name",
+			password:      "New-User-Name",
+			expectedError: usecase.ErrWeakPassword,
+		},
+		{
+			name:          "password is breached",
+			username:      "new user",
+			password:      usecasetest.BreachedPassword,
+			expectedError: usecase.ErrWeakPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			fixture.CreateUser(t, "existing user")
+
+			uc := New(
+				usecasetest.Logger(),
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.PasswordPolicy(t),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repository.NewAuthRepository(usecasetest.Logger(), fixture.Storage),
+			)
+
+			password := usecasetest.Password
+			if tc.password != "" {
+				password = tc.password
+			}
+
+			tokens, err := uc.Execute(ctx, Params{
+				Username:   tc.username,
+				Password:   password,
+				ClientCode: usecasetest.ClientCode,
+				FIO:        "Test User",
+				Issuer:     usecasetest.Issuer,
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEmpty(t, tokens.RefreshToken)
+
+			user, err := fixture.Storage.UserByUsername(ctx, tc.username)
+			require.NoError(t, err)
+			assert.Equal(t, "Test User", user.FullName)
+			assert.NotEqual(t, usecasetest.Password, user.PasswordHash)
+
+			// The user is linked to the client and gets the client default role.
+			_, err = fixture.Storage.ClientByCodeAndUserID(ctx, usecasetest.ClientCode, user.ID)
+			assert.NoError(t, err)
+
+			roles, err := fixture.Storage.RolesByUserID(ctx, user.ID)
+			require.NoError(t, err)
+			require.Len(t, roles, 1)
+			assert.Equal(t, usecasetest.RoleCode, roles[0].Code)
+
+			sessions := fixture.Sessions(t, user.ID)
+			require.Len(t, sessions, 1)
+			assert.Equal(t, tokens.RefreshTokenID, sessions[0].RefreshToken)
+		})
+	}
+}
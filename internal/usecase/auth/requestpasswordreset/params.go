@@ -0,0 +1,6 @@
+package requestpasswordreset
+
+// Params is a data for request password reset use-case.
+type Params struct {
+	Username string
+}
@@ -0,0 +1,134 @@
+package requestpasswordreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for request password reset use-case.
+type Repository interface {
+	DataForRequestPasswordReset(ctx context.Context, username string) (dto.DataForRequestPasswordReset, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// Notifier is a notifier which delivers the password reset token to the user.
+type Notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+// UseCase is a use-case for requesting the password reset.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.TokensConfig
+	resetCfg config.PasswordResetConfig
+	repo     Repository
+	notifier Notifier
+}
+
+// New returns new request password reset use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	resetCfg config.PasswordResetConfig,
+	repo Repository,
+	notifier Notifier,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		resetCfg: resetCfg,
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+// Execute executes the use-case for requesting the password reset. The single-use password reset token
+// is created and delivered by the notifier to the verified email of the user, or to the verified phone
+// if the email is not verified. The result does not reveal whether the user exists: the request for the unknown,
+// deleted or blocked user, or for the user without verified contacts, succeeds without creating a token,
+// the failure to deliver the token is only logged.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.auth.requestpasswordreset"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+	)
+	log.Info("attempting to request password reset")
+
+	// Get data for request password reset from storage.
+	storageRequestPasswordResetData, err := uc.repo.DataForRequestPasswordReset(ctx, data.Username)
+	if err != nil {
+		log.Error("error getting user data from storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageRequestPasswordResetData.User),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create password reset token.
+	token, err := auth.RequestPasswordReset(uc.resetCfg.TokenTTL)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCredentials) || errors.Is(err, entity.ErrUserBlocked) {
+			log.Warn("password reset is not allowed for the user", sl.Err(err))
+
+			return nil
+		}
+
+		if errors.Is(err, entity.ErrNoVerifiedContact) {
+			log.Warn("user has no verified contact to deliver password reset token to", sl.Err(err))
+
+			return nil
+		}
+
+		log.Error("failed to create password reset token", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Deliver password reset token.
+	channel, recipient, err := auth.User.VerifiedContact()
+	if err != nil {
+		log.Error("failed to get verified contact of the user", sl.Err(err))
+
+		return nil
+	}
+
+	notification, err := entity.NewPasswordResetNotification(channel, recipient, token, uc.resetCfg.URL)
+	if err != nil {
+		log.Error("failed to create password reset notification", sl.Err(err))
+
+		return nil
+	}
+
+	if err = uc.notifier.Notify(ctx, notification); err != nil {
+		log.Error("failed to deliver password reset token", sl.Err(err))
+
+		return nil
+	}
+
+	log.Info("password reset requested successfully")
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package requestpasswordreset
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/notifier"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		username              string
+		email                 string
+		emailVerified         bool
+		phone                 string
+		phoneVerified         bool
+		blocked               bool
+		deleted               bool
+		expectedNotifications int
+		expectedChannel       enum.ContactChannelEnum
+		expectedRecipient     string
+	}{
+		{
+			name:                  "delivers the password reset token to the verified email",
+			username:              "user",
+			email:                 "user@example.com",
+			emailVerified:         true,
+			phone:                 "+15550000000",
+			phoneVerified:         true,
+			expectedNotifications: 1,
+			expectedChannel:       enum.ContactChannelEmail,
+			expectedRecipient:     "user@example.com",
+		},
+		{
+			name:                  "delivers the password reset token to the verified phone",
+			username:              "user",
+			email:                 "user@example.com",
+			phone:                 "+15550000000",
+			phoneVerified:         true,
+			expectedNotifications: 1,
+			expectedChannel:       enum.ContactChannelPhone,
+			expectedRecipient:     "+15550000000",
+		},
+		{
+			name:     "user without verified contacts",
+			username: "user",
+			email:    "user@example.com",
+		},
+		{
+			name:     "unknown user",
+			username: "unknown",
+		},
+		{
+			name:          "blocked user",
+			username:      "user",
+			email:         "user@example.com",
+			emailVerified: true,
+			blocked:       true,
+		},
+		{
+			name:          "deleted user",
+			username:      "user",
+			email:         "user@example.com",
+			emailVerified: true,
+			deleted:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			if tc.email != "" {
+				fixture.SetUserEmail(t, userID, tc.email, tc.emailVerified)
+			}
+
+			if tc.phone != "" {
+				fixture.SetUserPhone(t, userID, tc.phone, tc.phoneVerified)
+			}
+
+			if tc.blocked || tc.deleted {
+				saved, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				saved.Blocked = tc.blocked
+				saved.Deleted = tc.deleted
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, saved))
+			}
+
+			outbox := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+			uc := New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				config.PasswordResetConfig{TokenTTL: time.Hour, URL: "https://sso.example.com/reset-password"},
+				repository.NewAuthRepository(log, fixture.Storage),
+				outbox,
+			)
+
+			// The result is the same whether the password reset is allowed or not.
+			require.NoError(t, uc.Execute(ctx, Params{Username: tc.username}))
+
+			notifications, err := outbox.Notifications()
+			require.NoError(t, err)
+			require.Len(t, notifications, tc.expectedNotifications)
+
+			if tc.expectedNotifications > 0 {
+				assert.Equal(t, tc.expectedChannel, notifications[0].Channel)
+				assert.Equal(t, tc.expectedRecipient, notifications[0].Recipient)
+				assert.Contains(t, notifications[0].Body, "https://sso.example.com/reset-password?token=")
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package verifymfa
+
+// Params is a data for verify MFA use-case.
+// MFAToken is the token of the MFA challenge returned by the log in use-case,
+// Code is the TOTP code or the backup code of the user.
+type Params struct {
+	MFAToken string
+	Code     string
+}
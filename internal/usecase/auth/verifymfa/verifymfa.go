@@ -0,0 +1,182 @@
+package verifymfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for verify MFA use-case.
+type Repository interface {
+	DataForVerifyMFA(ctx context.Context, tokenHash string) (dto.DataForVerifyMFA, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for completing the login by the second authentication factor.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.TokensConfig
+	mfaCfg   config.MFAConfig
+	keyStore *jwtkeys.Store
+	repo     Repository
+}
+
+// New returns new verify MFA use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	mfaCfg config.MFAConfig,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		mfaCfg:   mfaCfg,
+		keyStore: keyStore,
+		repo:     repo,
+	}
+}
+
+// Execute executes the use-case for completing the login by the second authentication factor.
+// If successful, new tokens are returned. The mismatched code counts as an attempt of the MFA challenge,
+// the user must log in again once the attempts are exceeded. The mismatched codes are counted per username
+// as well, the MFA codes of the username are locked for the challenge TTL once the attempts are exceeded.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
+	const op = "usecase.auth.verifymfa"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+	log.Info("attempting to verify second authentication factor")
+
+	// Get MFA challenge data from storage.
+	tokenHash := entity.HashMFAChallengeToken(data.MFAToken)
+	storageVerifyMFAData, err := uc.repo.DataForVerifyMFA(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFAToken)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
+		entity.WithAuthSessionLimit(entity.SessionLimit{
+			MaxSessions:    uc.cfg.MaxSessionsPerClient,
+			EvictionPolicy: uc.cfg.SessionEvictionPolicy,
+		}),
+		entity.WithAuthUser(storageVerifyMFAData.User),
+		entity.WithAuthMFA(storageVerifyMFAData.MFA),
+		entity.WithAuthClient(storageVerifyMFAData.Client),
+		entity.WithAuthSession(storageVerifyMFAData.Sessions...),
+		entity.WithAuthMFAChallenge(storageVerifyMFAData.MFAChallenge),
+		entity.WithAuthLoginAttempts(storageVerifyMFAData.LoginAttempts...),
+	)
+	if err != nil {
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Verify second authentication factor.
+	entityVerifyMFAParams := entity.VerifyMFAParams{
+		Code:            data.Code,
+		MaxAttempts:     uc.mfaCfg.MaxAttempts,
+		LockoutDuration: uc.mfaCfg.ChallengeTTL,
+	}
+	tokens, err := auth.VerifyMFA(entityVerifyMFAParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidMFACode):
+			log.Warn("invalid second authentication factor code", sl.Err(err))
+
+			// The mismatched code counts as an attempt. The challenge used or exhausted concurrently is reported
+			// like on the valid code, so the error does not tell the valid code from the mismatched one.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				if errors.Is(err, infrastructure.ErrEntityNotFound) {
+					log.Warn("MFA challenge is already used or has no attempts left", sl.Err(err))
+
+					return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFAToken)
+				}
+
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFACode)
+		case errors.Is(err, entity.ErrMFAChallengeNotFound), errors.Is(err, entity.ErrMFAChallengeExpired):
+			log.Warn("invalid MFA challenge", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFAToken)
+		case errors.Is(err, entity.ErrMFAAttemptsExceeded):
+			log.Warn("MFA attempts exceeded", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrMFAAttemptsExceeded)
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("user is deleted", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFAToken)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrPasswordChangeRequired):
+			log.Warn("password change required", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrPasswordChangeRequired)
+		case errors.Is(err, entity.ErrEmailNotVerified):
+			log.Warn("email is not verified", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrEmailNotVerified)
+		case errors.Is(err, entity.ErrMFAEnrollmentRequired):
+			log.Warn("second authentication factor is disabled", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrMFAEnrollmentRequired)
+		case errors.Is(err, entity.ErrSessionLimitExceeded):
+			log.Warn("session limit exceeded", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrSessionLimitExceeded)
+		}
+
+		log.Error("failed to verify second authentication factor", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("MFA challenge or backup code is already used or the challenge has no attempts left", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFAToken)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged in successfully")
+
+	return tokens, nil
+}
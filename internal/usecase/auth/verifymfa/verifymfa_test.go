@@ -0,0 +1,302 @@
+package verifymfa
+
+import (
+	"context"
+	"errors"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+const backupCode = "abcde-fghjk"
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mfaToken         string
+		code             func(t *testing.T) string
+		blocked          bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "completes login by TOTP code",
+			code:             func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			expectedSessions: 1,
+		},
+		{
+			name:             "completes login by backup code",
+			code:             func(*testing.T) string { return backupCode },
+			expectedSessions: 1,
+		},
+		{
+			name:          "unknown MFA token",
+			mfaToken:      "unknown",
+			code:          func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			expectedError: usecase.ErrInvalidMFAToken,
+		},
+		{
+			name:          "invalid code",
+			code:          func(*testing.T) string { return "000000" },
+			expectedError: usecase.ErrInvalidMFACode,
+		},
+		{
+			name:          "user is blocked after the challenge is issued",
+			code:          func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			blocked:       true,
+			expectedError: usecase.ErrUserBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret, backupCode)
+
+			mfaToken := login2FA(t, fixture)
+			if tc.mfaToken != "" {
+				mfaToken = tc.mfaToken
+			}
+
+			if tc.blocked {
+				user, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				user.Blocked = true
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, user))
+			}
+
+			tokens, err := newUseCase(t, fixture).Execute(ctx, Params{MFAToken: mfaToken, Code: tc.code(t)})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.NotEmpty(t, tokens.IDToken)
+
+				session, err := fixture.Storage.SessionByRefreshTokenID(ctx, tokens.RefreshTokenID)
+				require.NoError(t, err)
+				assert.Equal(t, userID, session.UserID)
+				assert.Equal(t, fixture.ClientID, session.ClientID.Int64)
+				assert.Equal(t, "user agent", session.UserAgent)
+				assert.Equal(t, "fingerprint", session.Fingerprint)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
+
+func Test_UseCase_Execute_SingleUse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret, backupCode)
+	uc := newUseCase(t, fixture)
+
+	// The challenge is completed only once.
+	mfaToken := login2FA(t, fixture)
+	_, err := uc.Execute(ctx, Params{MFAToken: mfaToken, Code: backupCode})
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{MFAToken: mfaToken, Code: usecasetest.TOTPCode(t, usecasetest.TOTPSecret)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidMFAToken)
+
+	// The backup code is used only once.
+	_, err = uc.Execute(ctx, Params{MFAToken: login2FA(t, fixture), Code: backupCode})
+	assert.ErrorIs(t, err, usecase.ErrInvalidMFACode)
+
+	// The TOTP code is used only once.
+	code := usecasetest.TOTPCode(t, usecasetest.TOTPSecret)
+	_, err = uc.Execute(ctx, Params{MFAToken: login2FA(t, fixture), Code: code})
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{MFAToken: login2FA(t, fixture), Code: code})
+	assert.ErrorIs(t, err, usecase.ErrInvalidMFACode)
+
+	assert.Len(t, fixture.Sessions(t, userID), 2)
+}
+
+func Test_UseCase_Execute_AttemptsExceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret)
+	uc := newUseCase(t, fixture)
+
+	mfaToken := login2FA(t, fixture)
+	for range usecasetest.MFAConfig().MaxAttempts {
+		_, err := uc.Execute(ctx, Params{MFAToken: mfaToken, Code: "000000"})
+		assert.ErrorIs(t, err, usecase.ErrInvalidMFACode)
+	}
+
+	// The valid code is rejected once the attempts are exceeded.
+	_, err := uc.Execute(ctx, Params{MFAToken: mfaToken, Code: usecasetest.TOTPCode(t, usecasetest.TOTPSecret)})
+	assert.ErrorIs(t, err, usecase.ErrMFAAttemptsExceeded)
+
+	challenge, err := fixture.Storage.MFAChallengeByHash(ctx, entity.HashMFAChallengeToken(mfaToken))
+	require.NoError(t, err)
+	assert.Equal(t, usecasetest.MFAConfig().MaxAttempts, challenge.Attempts)
+	assert.Empty(t, fixture.Sessions(t, userID))
+}
+
+func Test_UseCase_Execute_UsernameAttempts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		expectedError error
+	}{
+		{
+			name:     "logs in when the failed codes are below the limit",
+			failures: usecasetest.MFAConfig().MaxAttempts - 1,
+		},
+		{
+			name:          "throws an error when the failed codes of the username reach the limit",
+			failures:      usecasetest.MFAConfig().MaxAttempts,
+			expectedError: usecase.ErrMFAAttemptsExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret)
+			uc := newUseCase(t, fixture)
+
+			// Every login issues a new challenge, the failed codes are counted across them.
+			for range tc.failures {
+				_, err := uc.Execute(ctx, Params{MFAToken: login2FA(t, fixture), Code: "000000"})
+				require.ErrorIs(t, err, usecase.ErrInvalidMFACode)
+			}
+
+			mfaToken := login2FA(t, fixture)
+			_, err := uc.Execute(ctx, Params{MFAToken: mfaToken, Code: usecasetest.TOTPCode(t, usecasetest.TOTPSecret)})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, fixture.Sessions(t, userID))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, fixture.Sessions(t, userID), 1)
+
+			// The failed codes are forgotten once the valid code is sent.
+			_, err = fixture.Storage.LoginAttempt(ctx, string(enum.LoginAttemptKindMFA), "user")
+			assert.ErrorIs(t, err, infrastructure.ErrEntityNotFound)
+		})
+	}
+}
+
+func Test_UseCase_Execute_ConcurrentAttempts(t *testing.T) {
+	t.Parallel()
+
+	const verifications = 20
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret)
+	uc := newUseCase(t, fixture)
+
+	// The concurrent verifications read the same attempts, so the attempts are counted by the storage.
+	mfaToken := login2FA(t, fixture)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, verifications)
+	for range verifications {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := uc.Execute(ctx, Params{MFAToken: mfaToken, Code: "000000"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	invalidCodes := 0
+	for err := range errs {
+		if errors.Is(err, usecase.ErrInvalidMFACode) {
+			invalidCodes++
+
+			continue
+		}
+
+		assert.True(t, errors.Is(err, usecase.ErrInvalidMFAToken) || errors.Is(err, usecase.ErrMFAAttemptsExceeded), err)
+	}
+	assert.Equal(t, usecasetest.MFAConfig().MaxAttempts, invalidCodes)
+
+	challenge, err := fixture.Storage.MFAChallengeByHash(ctx, entity.HashMFAChallengeToken(mfaToken))
+	require.NoError(t, err)
+	assert.Equal(t, usecasetest.MFAConfig().MaxAttempts, challenge.Attempts)
+	assert.Empty(t, fixture.Sessions(t, userID))
+}
+
+func newUseCase(t *testing.T, fixture usecasetest.Fixture) *UseCase {
+	t.Helper()
+
+	log := usecasetest.Logger()
+
+	return New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(log, fixture.Storage),
+	)
+}
+
+// login2FA logs in the user with the enabled second authentication factor and returns the MFA token.
+func login2FA(t *testing.T, fixture usecasetest.Fixture) string {
+	t.Helper()
+
+	log := usecasetest.Logger()
+	loginUseCase := login.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.MFAConfig(),
+		usecasetest.LoginThrottleConfig(0),
+		usecasetest.PasswordHasher(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(log, fixture.Storage),
+	)
+
+	tokens, err := loginUseCase.Execute(context.Background(), login.Params{
+		Username:    "user",
+		Password:    usecasetest.Password,
+		ClientCode:  usecasetest.ClientCode,
+		UserAgent:   "user agent",
+		Fingerprint: "fingerprint",
+		Issuer:      usecasetest.Issuer,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.MFAToken)
+
+	return tokens.MFAToken
+}
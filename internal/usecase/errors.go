@@ -3,9 +3,64 @@ package usecase
 import "errors"
 
 var (
-	ErrClientNotFound     = errors.New("client not found")
-	ErrSessionNotFound    = errors.New("session not found")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserExists         = errors.New("user already exists")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrClientNotFound       = errors.New("client not found")
+	ErrSessionNotFound      = errors.New("session not found")
+	ErrRefreshTokenReused   = errors.New("refresh token is reused")
+	ErrSessionLimitExceeded = errors.New("session limit exceeded")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrUserExists           = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+
+	ErrInvalidClient        = errors.New("invalid client")
+	ErrInvalidRedirectURI   = errors.New("invalid redirect URI")
+	ErrInvalidCodeChallenge = errors.New("invalid code challenge")
+	ErrInvalidGrant         = errors.New("invalid grant")
+	ErrInvalidScope         = errors.New("invalid scope")
+
+	ErrInvalidToken           = errors.New("invalid token")
+	ErrPermissionDenied       = errors.New("permission denied")
+	ErrClientExists           = errors.New("client already exists")
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrInvalidSessionLimit    = errors.New("invalid session limit")
+	ErrClientAudienceExists   = errors.New("client audience already exists")
+	ErrClientAudienceNotFound = errors.New("client audience not found")
+	ErrRoleExists             = errors.New("role already exists")
+	ErrPermissionExists       = errors.New("permission already exists")
+	ErrPermissionNotFound     = errors.New("permission not found")
+	ErrRolePermissionExists   = errors.New("permission is already attached to the role")
+	ErrRolePermissionNotFound = errors.New("permission is not attached to the role")
+	ErrUserBlocked            = errors.New("user is blocked")
+	ErrUserRoleExists         = errors.New("role is already granted to the user")
+	ErrUserRoleNotFound       = errors.New("role is not granted to the user")
+	ErrUserClientExists       = errors.New("user already has access to the client")
+	ErrUserClientNotFound     = errors.New("user has no access to the client")
+	ErrInvalidProfile         = errors.New("invalid profile")
+	ErrWeakPassword           = errors.New("password does not meet the password policy")
+	ErrPasswordNotChanged     = errors.New("new password matches the current password")
+	ErrPasswordChangeRequired = errors.New("password must be changed")
+
+	ErrInvalidPasswordResetToken = errors.New("invalid password reset token")
+
+	ErrEmailNotVerified             = errors.New("email is not verified")
+	ErrContactExists                = errors.New("contact is already used by another user")
+	ErrContactNotSet                = errors.New("contact is not set")
+	ErrContactAlreadyVerified       = errors.New("contact is already verified")
+	ErrInvalidVerificationCode      = errors.New("invalid verification code")
+	ErrVerificationAttemptsExceeded = errors.New("verification attempts exceeded")
+
+	ErrMFARequired           = errors.New("second authentication factor is required")
+	ErrMFAEnrollmentRequired = errors.New("second authentication factor must be enrolled")
+	ErrInvalidMFACode        = errors.New("invalid second authentication factor code")
+	ErrInvalidMFAToken       = errors.New("invalid MFA token")
+	ErrMFAAttemptsExceeded   = errors.New("MFA attempts exceeded")
+	ErrTOTPAlreadyEnabled    = errors.New("TOTP is already enabled")
+	ErrTOTPNotEnrolled       = errors.New("TOTP is not enrolled")
+	ErrTOTPNotEnabled        = errors.New("TOTP is not enabled")
+
+	ErrInvalidWebAuthnChallenge = errors.New("invalid WebAuthn challenge")
+	ErrInvalidWebAuthnResponse  = errors.New("invalid WebAuthn response")
+	ErrWebAuthnCredentialExists = errors.New("WebAuthn credential is already registered")
+
+	ErrAccountLocked  = errors.New("account is temporarily locked")
+	ErrLoginThrottled = errors.New("too many failed login attempts")
 )
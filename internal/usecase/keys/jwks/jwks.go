@@ -0,0 +1,38 @@
+package jwks
+
+import (
+	"context"
+	"github.com/go-jose/go-jose/v4"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"log/slog"
+)
+
+// UseCase is a use-case for getting the public keys for verifying access tokens.
+type UseCase struct {
+	log      *slog.Logger
+	keyStore *jwtkeys.Store
+}
+
+// New returns new public keys use-case.
+func New(log *slog.Logger, keyStore *jwtkeys.Store) *UseCase {
+	return &UseCase{
+		log:      log,
+		keyStore: keyStore,
+	}
+}
+
+// Execute executes the use-case for getting the public keys for verifying access tokens.
+// The key set contains the active key, the keys to be activated and the recently retired keys.
+// If access tokens are signed by the client secret keys, an empty key set is returned.
+func (uc *UseCase) Execute(_ context.Context) (jose.JSONWebKeySet, error) {
+	const op = "usecase.keys.jwks"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+
+	keySet := uc.keyStore.PublicKeySet()
+	log.Debug("public keys are received", slog.Int("count", len(keySet.Keys)))
+
+	return keySet, nil
+}
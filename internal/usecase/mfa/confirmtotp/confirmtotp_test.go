@@ -0,0 +1,112 @@
+package confirmtotp
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/mfa/enrolltotp"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		password      string
+		code          string
+		notEnrolled   bool
+		enabled       bool
+		expectedError error
+	}{
+		{
+			name: "confirms the enrolled credential",
+		},
+		{
+			name:          "invalid password",
+			password:      "wrong-password",
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:          "invalid code",
+			code:          "000000",
+			expectedError: usecase.ErrInvalidMFACode,
+		},
+		{
+			name:          "credential is not enrolled",
+			notEnrolled:   true,
+			expectedError: usecase.ErrTOTPNotEnrolled,
+		},
+		{
+			name:          "credential is already enabled",
+			notEnrolled:   true,
+			enabled:       true,
+			expectedError: usecase.ErrTOTPAlreadyEnabled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			secret := usecasetest.TOTPSecret
+			if tc.enabled {
+				fixture.EnableTOTP(t, userID, secret)
+			}
+
+			if !tc.notEnrolled {
+				enrollment, err := enrolltotp.New(log, cfg, usecasetest.MFAConfig(), usecasetest.LoginThrottleConfig(0), repo).
+					Execute(ctx, enrolltotp.Params{Username: "user", Password: usecasetest.Password})
+				require.NoError(t, err)
+				assert.Contains(t, enrollment.URI, "otpauth://totp/")
+
+				secret = enrollment.Secret
+			}
+
+			password := usecasetest.Password
+			if tc.password != "" {
+				password = tc.password
+			}
+
+			code := usecasetest.TOTPCode(t, secret)
+			if tc.code != "" {
+				code = tc.code
+			}
+
+			backupCodes, err := New(log, cfg, usecasetest.MFAConfig(), usecasetest.LoginThrottleConfig(0), repo).
+				Execute(ctx, Params{Username: "user", Password: password, Code: code})
+
+			credential, credentialErr := fixture.Storage.TOTPCredential(ctx, userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				if !tc.enabled && !tc.notEnrolled {
+					require.NoError(t, credentialErr)
+					assert.False(t, credential.Confirmed)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, backupCodes, usecasetest.MFAConfig().BackupCodesCount)
+
+			require.NoError(t, credentialErr)
+			assert.True(t, credential.Confirmed)
+
+			storedCodes, err := fixture.Storage.BackupCodes(ctx, userID)
+			require.NoError(t, err)
+			assert.Len(t, storedCodes, usecasetest.MFAConfig().BackupCodesCount)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package confirmtotp
+
+// Params is a data for confirm TOTP use-case.
+// Code is the first code generated by the authenticator application from the enrolled secret.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+type Params struct {
+	Username  string
+	Password  string
+	IPAddress string
+	Code      string
+}
@@ -0,0 +1,110 @@
+package disabletotp
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const backupCode = "abcde-fghjk"
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		password      string
+		code          func(t *testing.T) string
+		notEnabled    bool
+		expectedError error
+	}{
+		{
+			name: "disables by TOTP code",
+			code: func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+		},
+		{
+			name: "disables by backup code",
+			code: func(*testing.T) string { return backupCode },
+		},
+		{
+			name:          "unknown user",
+			username:      "unknown",
+			code:          func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:          "invalid password",
+			password:      "wrong-password",
+			code:          func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:          "invalid code",
+			code:          func(*testing.T) string { return "000000" },
+			expectedError: usecase.ErrInvalidMFACode,
+		},
+		{
+			name:          "credential is not enabled",
+			code:          func(t *testing.T) string { return usecasetest.TOTPCode(t, usecasetest.TOTPSecret) },
+			notEnabled:    true,
+			expectedError: usecase.ErrTOTPNotEnabled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			if !tc.notEnabled {
+				fixture.EnableTOTP(t, userID, usecasetest.TOTPSecret, backupCode)
+			}
+
+			username := "user"
+			if tc.username != "" {
+				username = tc.username
+			}
+
+			password := usecasetest.Password
+			if tc.password != "" {
+				password = tc.password
+			}
+
+			log := usecasetest.Logger()
+			uc := New(
+				log,
+				usecasetest.TokensConfig(5, enum.EvictOldest),
+				usecasetest.LoginThrottleConfig(0),
+				repository.NewAuthRepository(log, fixture.Storage),
+			)
+
+			err := uc.Execute(ctx, Params{Username: username, Password: password, Code: tc.code(t)})
+
+			_, credentialErr := fixture.Storage.TOTPCredential(ctx, userID)
+			backupCodes, backupCodesErr := fixture.Storage.BackupCodes(ctx, userID)
+			require.NoError(t, backupCodesErr)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+
+				if !tc.notEnabled {
+					assert.NoError(t, credentialErr)
+					assert.Len(t, backupCodes, 1)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ErrorIs(t, credentialErr, infrastructure.ErrEntityNotFound)
+			assert.Empty(t, backupCodes)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package disabletotp
+
+// Params is a data for disable TOTP use-case.
+// Code is the TOTP code or the unused backup code of the user.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+type Params struct {
+	Username  string
+	Password  string
+	IPAddress string
+	Code      string
+}
@@ -0,0 +1,135 @@
+package enrolltotp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for enroll TOTP use-case.
+type Repository interface {
+	DataForMFA(ctx context.Context, username, ipAddress string) (dto.DataForMFA, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for enrolling the TOTP credential of the user.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	mfaCfg      config.MFAConfig
+	throttleCfg config.LoginThrottleConfig
+	repo        Repository
+}
+
+// New returns new enroll TOTP use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	mfaCfg config.MFAConfig,
+	throttleCfg config.LoginThrottleConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		mfaCfg:      mfaCfg,
+		throttleCfg: throttleCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for enrolling the TOTP credential of the user.
+// The password of the user is verified, the new secret and its otpauth:// URI are returned.
+// The credential is not used until the user confirms it by the first code.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.TOTPEnrollment, error) {
+	const op = "usecase.mfa.enrolltotp"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("IP address", data.IPAddress),
+	)
+	log.Info("attempting to enroll TOTP credential")
+
+	// Get MFA data from storage.
+	storageMFAData, err := uc.repo.DataForMFA(ctx, data.Username, data.IPAddress)
+	if err != nil {
+		log.Error("error getting user MFA data from storage", sl.Err(err))
+
+		return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageMFAData.User),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageMFAData.LoginAttempts...),
+		entity.WithAuthMFA(storageMFAData.MFA),
+	)
+	if err != nil {
+		return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Enroll TOTP credential.
+	enrollTOTPParams := entity.EnrollTOTPParams{
+		Username:  data.Username,
+		Password:  data.Password,
+		IPAddress: data.IPAddress,
+		Issuer:    uc.mfaCfg.Issuer,
+	}
+	enrollment, err := auth.EnrollTOTP(enrollTOTPParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("invalid credentials", sl.Err(err))
+
+			// The failed password check is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrAccountLocked):
+			log.Warn("account is locked", sl.Err(err))
+
+			return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		case errors.Is(err, entity.ErrLoginThrottled):
+			log.Warn("password check is throttled", sl.Err(err))
+
+			return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrTOTPAlreadyEnabled):
+			log.Warn("TOTP is already enabled", sl.Err(err))
+
+			return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, usecase.ErrTOTPAlreadyEnabled)
+		}
+
+		log.Error("failed to enroll TOTP credential", sl.Err(err))
+
+		return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("TOTP credential enrolled successfully")
+
+	return enrollment, nil
+}
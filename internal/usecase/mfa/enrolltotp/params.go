@@ -0,0 +1,9 @@
+package enrolltotp
+
+// Params is a data for enroll TOTP use-case.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+type Params struct {
+	Username  string
+	Password  string
+	IPAddress string
+}
@@ -0,0 +1,152 @@
+package regeneratebackupcodes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for regenerate backup codes use-case.
+type Repository interface {
+	DataForMFA(ctx context.Context, username, ipAddress string) (dto.DataForMFA, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for regenerating the backup codes of the user.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	mfaCfg      config.MFAConfig
+	throttleCfg config.LoginThrottleConfig
+	repo        Repository
+}
+
+// New returns new regenerate backup codes use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	mfaCfg config.MFAConfig,
+	throttleCfg config.LoginThrottleConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		mfaCfg:      mfaCfg,
+		throttleCfg: throttleCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for regenerating the backup codes of the user.
+// The password and the second authentication factor are verified, and if successful,
+// the previous backup codes are replaced with the new generated ones which are returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) ([]string, error) {
+	const op = "usecase.mfa.regeneratebackupcodes"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("IP address", data.IPAddress),
+	)
+	log.Info("attempting to regenerate backup codes")
+
+	// Get MFA data from storage.
+	storageMFAData, err := uc.repo.DataForMFA(ctx, data.Username, data.IPAddress)
+	if err != nil {
+		log.Error("error getting user MFA data from storage", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageMFAData.User),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageMFAData.LoginAttempts...),
+		entity.WithAuthMFA(storageMFAData.MFA),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Regenerate backup codes.
+	regenerateBackupCodesParams := entity.RegenerateBackupCodesParams{
+		Username:  data.Username,
+		Password:  data.Password,
+		IPAddress: data.IPAddress,
+		Code:      data.Code,
+		Count:     uc.mfaCfg.BackupCodesCount,
+	}
+	backupCodes, err := auth.RegenerateBackupCodes(regenerateBackupCodesParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("invalid credentials", sl.Err(err))
+
+			// The failed password check is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrAccountLocked):
+			log.Warn("account is locked", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		case errors.Is(err, entity.ErrLoginThrottled):
+			log.Warn("password check is throttled", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrTOTPNotEnabled):
+			log.Warn("TOTP is not enabled", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrTOTPNotEnabled)
+		case errors.Is(err, entity.ErrInvalidMFACode):
+			log.Warn("invalid second authentication factor code", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFACode)
+		}
+
+		log.Error("failed to regenerate backup codes", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("TOTP code or backup code is already used", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrInvalidMFACode)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	codes := make([]string, len(backupCodes))
+	for i, backupCode := range backupCodes {
+		codes[i] = backupCode.Code
+	}
+
+	log.Info("backup codes regenerated successfully")
+
+	return codes, nil
+}
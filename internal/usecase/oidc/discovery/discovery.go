@@ -0,0 +1,89 @@
+package discovery
+
+import (
+	"context"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"log/slog"
+	"strings"
+)
+
+// Paths of the endpoints relative to the issuer.
+const (
+	JWKSPath          = "/.well-known/jwks.json"
+	AuthorizationPath = "/authorize"
+	TokenPath         = "/token"
+	IntrospectionPath = "/introspect"
+)
+
+var (
+	responseTypesSupported            = []string{"code"}
+	grantTypesSupported               = []string{"authorization_code", "client_credentials"}
+	codeChallengeMethodsSupported     = []string{entity.CodeChallengeMethodS256}
+	tokenEndpointAuthMethodsSupported = []string{"client_secret_basic", "client_secret_post", "none"}
+	subjectTypesSupported             = []string{"public"}
+	scopesSupported                   = []string{"openid", "profile"}
+	claimsSupported                   = []string{
+		"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+		"name", "preferred_username", "birthdate", "gender", "email", "email_verified",
+	}
+)
+
+// UseCase is a use-case for getting the OpenID Connect discovery document.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.OIDCConfig
+	keyStore *jwtkeys.Store
+}
+
+// New returns new OpenID Connect discovery use-case.
+func New(log *slog.Logger, cfg config.OIDCConfig, keyStore *jwtkeys.Store) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		keyStore: keyStore,
+	}
+}
+
+// Execute executes the use-case for getting the OpenID Connect discovery document.
+// If tokens are signed by the client secret keys, HS256 is the only supported ID token signing algorithm.
+func (uc *UseCase) Execute(_ context.Context) (dto.OpenIDConfiguration, error) {
+	const op = "usecase.oidc.discovery"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+
+	algorithms := uc.keyStore.Algorithms()
+	if len(algorithms) == 0 {
+		algorithms = []jose.SignatureAlgorithm{jose.HS256}
+	}
+
+	signingAlgorithms := make([]string, len(algorithms))
+	for i, algorithm := range algorithms {
+		signingAlgorithms[i] = string(algorithm)
+	}
+
+	issuer := strings.TrimSuffix(uc.cfg.Issuer, "/")
+
+	log.Debug("discovery document is built", slog.String("issuer", issuer))
+
+	return dto.OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + AuthorizationPath,
+		TokenEndpoint:                     issuer + TokenPath,
+		IntrospectionEndpoint:             issuer + IntrospectionPath,
+		JWKSURI:                           issuer + JWKSPath,
+		ResponseTypesSupported:            responseTypesSupported,
+		GrantTypesSupported:               grantTypesSupported,
+		CodeChallengeMethodsSupported:     codeChallengeMethodsSupported,
+		TokenEndpointAuthMethodsSupported: tokenEndpointAuthMethodsSupported,
+		SubjectTypesSupported:             subjectTypesSupported,
+		IDTokenSigningAlgValuesSupported:  signingAlgorithms,
+		ScopesSupported:                   scopesSupported,
+		ClaimsSupported:                   claimsSupported,
+	}, nil
+}
@@ -59,7 +59,9 @@ func (uc *UseCase) Execute(ctx context.Context, id int64) (entity.User, error) {
 		storageUserData.DateOfBirth,
 		storageUserData.Gender,
 		storageUserData.AvatarFileKey,
-		entity.WithUserID(storageUserData.ID))
+		entity.WithUserID(storageUserData.ID),
+		entity.WithUserEmail(storageUserData.Email, storageUserData.EmailVerified),
+		entity.WithUserPhone(storageUserData.Phone, storageUserData.PhoneVerified))
 
 	return user, nil
 }
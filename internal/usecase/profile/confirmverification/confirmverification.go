@@ -0,0 +1,153 @@
+package confirmverification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for confirm contact verification use-case.
+type Repository interface {
+	DataForContactVerification(
+		ctx context.Context,
+		userID int64,
+		channel enum.ContactChannelEnum,
+	) (dto.DataForContactVerification, error)
+	SaveContactVerification(ctx context.Context, verification *entity.ContactVerification) error
+}
+
+// UseCase is a use-case for confirming the verification of the user contact.
+type UseCase struct {
+	log  *slog.Logger
+	cfg  config.VerificationConfig
+	repo Repository
+}
+
+// New returns new confirm contact verification use-case.
+func New(log *slog.Logger, cfg config.VerificationConfig, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		cfg:  cfg,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for confirming the verification of the user contact.
+// If the code matches the code sent to the contact, the contact is marked as verified.
+// Every mismatched code counts as an attempt, once the attempts are exceeded the new code must be requested.
+// The contact can't be verified if it is already verified by another user.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.profile.confirmverification"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.String("channel", string(data.Channel)),
+	)
+	log.Info("attempting to confirm contact verification")
+
+	// Get data for contact verification from storage.
+	storageVerificationData, err := uc.repo.DataForContactVerification(ctx, data.UserID, data.Channel)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting contact verification data from storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create contact verification entity.
+	storageUserData := storageVerificationData.User
+	user := entity.NewUser(
+		storageUserData.Username,
+		storageUserData.FullName,
+		storageUserData.DateOfBirth,
+		storageUserData.Gender,
+		storageUserData.AvatarFileKey,
+		entity.WithUserID(storageUserData.ID),
+		entity.WithUserStatus(storageUserData.Blocked, storageUserData.Deleted),
+		entity.WithUserEmail(storageUserData.Email, storageUserData.EmailVerified),
+		entity.WithUserPhone(storageUserData.Phone, storageUserData.PhoneVerified),
+	)
+
+	verification, err := entity.NewContactVerification(
+		user,
+		data.Channel,
+		entity.WithContactVerificationCode(storageVerificationData.VerificationCode),
+	)
+	if err != nil {
+		log.Error("failed to create contact verification entity", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Verify code.
+	confirmParams := entity.ConfirmContactVerificationParams{
+		Code:        data.Code,
+		MaxAttempts: uc.cfg.MaxAttempts,
+	}
+	if err = verification.Confirm(confirmParams); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			log.Warn("user is deleted", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrVerificationCodeMismatch):
+			log.Warn("verification code mismatch", sl.Err(err))
+
+			// The mismatched code counts as an attempt.
+			if err = uc.repo.SaveContactVerification(ctx, &verification); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrInvalidVerificationCode)
+		case errors.Is(err, entity.ErrVerificationCodeNotFound), errors.Is(err, entity.ErrVerificationCodeExpired):
+			log.Warn("invalid verification code", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrInvalidVerificationCode)
+		case errors.Is(err, entity.ErrVerificationAttemptsExceeded):
+			log.Warn("verification attempts exceeded", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrVerificationAttemptsExceeded)
+		}
+
+		log.Error("failed to verify code", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.SaveContactVerification(ctx, &verification); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			log.Warn("contact is already verified by another user", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrContactExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("contact verified successfully")
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package confirmverification
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/notifier"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/profile/requestverification"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+const maxAttempts = 3
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name          string
+		codeTTL       time.Duration
+		wrongCodes    int
+		changeEmail   bool
+		verifiedTaken bool
+		expectedError error
+	}{
+		{
+			name: "verifies the email",
+		},
+		{
+			name:       "verifies the email after the mismatched code",
+			wrongCodes: maxAttempts - 1,
+		},
+		{
+			name:          "attempts are exceeded",
+			wrongCodes:    maxAttempts,
+			expectedError: usecase.ErrVerificationAttemptsExceeded,
+		},
+		{
+			name:          "expired code",
+			codeTTL:       -time.Minute,
+			expectedError: usecase.ErrInvalidVerificationCode,
+		},
+		{
+			name:          "email is changed after the code is sent",
+			changeEmail:   true,
+			expectedError: usecase.ErrInvalidVerificationCode,
+		},
+		{
+			name:          "email is verified by another user",
+			verifiedTaken: true,
+			expectedError: usecase.ErrContactExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.SetUserEmail(t, userID, "user@example.com", false)
+			log := usecasetest.Logger()
+			profileRepository := repository.NewProfileRepository(log, fixture.Storage)
+
+			codeTTL := time.Minute
+			if tc.codeTTL != 0 {
+				codeTTL = tc.codeTTL
+			}
+			code := requestCode(t, ctx, profileRepository, userID, codeTTL)
+
+			if tc.changeEmail {
+				fixture.SetUserEmail(t, userID, "new@example.com", false)
+			}
+
+			if tc.verifiedTaken {
+				otherUserID := fixture.CreateUser(t, "other user")
+				fixture.SetUserEmail(t, otherUserID, "user@example.com", true)
+			}
+
+			uc := New(log, config.VerificationConfig{CodeTTL: time.Minute, MaxAttempts: maxAttempts}, profileRepository)
+			params := Params{UserID: userID, Channel: enum.ContactChannelEmail, Code: code}
+
+			for range tc.wrongCodes {
+				err := uc.Execute(ctx, Params{UserID: userID, Channel: enum.ContactChannelEmail, Code: "wrong"})
+				require.ErrorIs(t, err, usecase.ErrInvalidVerificationCode)
+			}
+
+			err := uc.Execute(ctx, params)
+
+			saved, savedErr := fixture.Storage.User(ctx, userID)
+			require.NoError(t, savedErr)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.False(t, saved.EmailVerified)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, saved.EmailVerified)
+
+			// The code can be used only once.
+			err = uc.Execute(ctx, params)
+			assert.ErrorIs(t, err, usecase.ErrInvalidVerificationCode)
+		})
+	}
+}
+
+// requestCode requests the verification of the email of the user and returns the code delivered to the outbox.
+func requestCode(
+	t *testing.T,
+	ctx context.Context,
+	repo *repository.Profile,
+	userID int64,
+	codeTTL time.Duration,
+) string {
+	t.Helper()
+
+	outbox := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	uc := requestverification.New(
+		usecasetest.Logger(),
+		config.VerificationConfig{CodeTTL: codeTTL, MaxAttempts: maxAttempts},
+		repo,
+		outbox,
+	)
+	require.NoError(t, uc.Execute(ctx, requestverification.Params{UserID: userID, Channel: enum.ContactChannelEmail}))
+
+	notifications, err := outbox.Notifications()
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+
+	code := regexp.MustCompile(`\b\d{6}\b`).FindString(notifications[0].Body)
+	require.NotEmpty(t, code)
+
+	return code
+}
@@ -0,0 +1,10 @@
+package confirmverification
+
+import "github.com/p1xray/pxr-sso/internal/enum"
+
+// Params is a data for confirm contact verification use-case.
+type Params struct {
+	UserID  int64
+	Channel enum.ContactChannelEnum
+	Code    string
+}
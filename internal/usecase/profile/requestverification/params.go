@@ -0,0 +1,9 @@
+package requestverification
+
+import "github.com/p1xray/pxr-sso/internal/enum"
+
+// Params is a data for request contact verification use-case.
+type Params struct {
+	UserID  int64
+	Channel enum.ContactChannelEnum
+}
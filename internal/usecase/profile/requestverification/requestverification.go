@@ -0,0 +1,146 @@
+package requestverification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for request contact verification use-case.
+type Repository interface {
+	DataForContactVerification(
+		ctx context.Context,
+		userID int64,
+		channel enum.ContactChannelEnum,
+	) (dto.DataForContactVerification, error)
+	SaveContactVerification(ctx context.Context, verification *entity.ContactVerification) error
+}
+
+// Notifier is a notifier which delivers the verification code to the contact of the user.
+type Notifier interface {
+	Notify(ctx context.Context, notification entity.Notification) error
+}
+
+// UseCase is a use-case for requesting the verification of the user contact.
+type UseCase struct {
+	log      *slog.Logger
+	cfg      config.VerificationConfig
+	repo     Repository
+	notifier Notifier
+}
+
+// New returns new request contact verification use-case.
+func New(log *slog.Logger, cfg config.VerificationConfig, repo Repository, notifier Notifier) *UseCase {
+	return &UseCase{
+		log:      log,
+		cfg:      cfg,
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+// Execute executes the use-case for requesting the verification of the user contact.
+// The one-time verification code is created and delivered by the notifier to the contact of the channel,
+// the previously requested code of the contact is replaced.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.profile.requestverification"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.String("channel", string(data.Channel)),
+	)
+	log.Info("attempting to request contact verification")
+
+	// Get data for contact verification from storage.
+	storageVerificationData, err := uc.repo.DataForContactVerification(ctx, data.UserID, data.Channel)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting contact verification data from storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create contact verification entity.
+	storageUserData := storageVerificationData.User
+	user := entity.NewUser(
+		storageUserData.Username,
+		storageUserData.FullName,
+		storageUserData.DateOfBirth,
+		storageUserData.Gender,
+		storageUserData.AvatarFileKey,
+		entity.WithUserID(storageUserData.ID),
+		entity.WithUserStatus(storageUserData.Blocked, storageUserData.Deleted),
+		entity.WithUserEmail(storageUserData.Email, storageUserData.EmailVerified),
+		entity.WithUserPhone(storageUserData.Phone, storageUserData.PhoneVerified),
+	)
+
+	verification, err := entity.NewContactVerification(
+		user,
+		data.Channel,
+		entity.WithContactVerificationCode(storageVerificationData.VerificationCode),
+	)
+	if err != nil {
+		log.Error("failed to create contact verification entity", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create verification code.
+	code, err := verification.Request(uc.cfg.CodeTTL)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUserDeleted):
+			log.Warn("user is deleted", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrContactNotSet):
+			log.Warn("contact is not set", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrContactNotSet)
+		case errors.Is(err, entity.ErrContactAlreadyVerified):
+			log.Warn("contact is already verified", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrContactAlreadyVerified)
+		}
+
+		log.Error("failed to create verification code", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.SaveContactVerification(ctx, &verification); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Deliver verification code.
+	if err = uc.notifier.Notify(ctx, entity.NewVerificationCodeNotification(code)); err != nil {
+		log.Error("failed to deliver verification code", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("contact verification requested successfully")
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package requestverification
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/notifier"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name              string
+		userID            int64
+		channel           enum.ContactChannelEnum
+		emailVerified     bool
+		blocked           bool
+		expectedError     error
+		expectedRecipient string
+	}{
+		{
+			name:              "delivers the code to the email",
+			channel:           enum.ContactChannelEmail,
+			expectedRecipient: "user@example.com",
+		},
+		{
+			name:              "delivers the code to the phone",
+			channel:           enum.ContactChannelPhone,
+			expectedRecipient: "+15550000000",
+		},
+		{
+			name:          "email is already verified",
+			channel:       enum.ContactChannelEmail,
+			emailVerified: true,
+			expectedError: usecase.ErrContactAlreadyVerified,
+		},
+		{
+			name:          "blocked user",
+			channel:       enum.ContactChannelEmail,
+			blocked:       true,
+			expectedError: usecase.ErrUserBlocked,
+		},
+		{
+			name:          "user not found",
+			userID:        100,
+			channel:       enum.ContactChannelEmail,
+			expectedError: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			fixture.SetUserEmail(t, userID, "user@example.com", tc.emailVerified)
+			fixture.SetUserPhone(t, userID, "+15550000000", false)
+			log := usecasetest.Logger()
+
+			if tc.blocked {
+				saved, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				saved.Blocked = true
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, saved))
+			}
+
+			outbox := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+			uc := New(
+				log,
+				config.VerificationConfig{CodeTTL: time.Minute, MaxAttempts: 5},
+				repository.NewProfileRepository(log, fixture.Storage),
+				outbox,
+			)
+
+			params := Params{UserID: userID, Channel: tc.channel}
+			if tc.userID != 0 {
+				params.UserID = tc.userID
+			}
+
+			err := uc.Execute(ctx, params)
+
+			notifications, notificationsErr := outbox.Notifications()
+			require.NoError(t, notificationsErr)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, notifications)
+
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, notifications, 1)
+			assert.Equal(t, tc.channel, notifications[0].Channel)
+			assert.Equal(t, tc.expectedRecipient, notifications[0].Recipient)
+
+			code, err := fixture.Storage.VerificationCode(ctx, userID, string(tc.channel))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRecipient, code.Target)
+
+			// The code is sent in the notification, the storage keeps only its hash.
+			sentCode := regexp.MustCompile(`\b\d{6}\b`).FindString(notifications[0].Body)
+			require.NotEmpty(t, sentCode)
+			assert.Equal(t, entity.HashVerificationCode(sentCode), code.CodeHash)
+		})
+	}
+}
+
+func Test_UseCase_Execute_ContactNotSet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	log := usecasetest.Logger()
+
+	outbox := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	uc := New(
+		log,
+		config.VerificationConfig{CodeTTL: time.Minute, MaxAttempts: 5},
+		repository.NewProfileRepository(log, fixture.Storage),
+		outbox,
+	)
+
+	err := uc.Execute(ctx, Params{UserID: userID, Channel: enum.ContactChannelPhone})
+	assert.ErrorIs(t, err, usecase.ErrContactNotSet)
+}
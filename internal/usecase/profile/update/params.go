@@ -0,0 +1,19 @@
+package update
+
+import (
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"time"
+)
+
+// Params is a data for update user profile use-case.
+// Only the fields listed in Fields are updated, the nil value of the listed field clears it.
+type Params struct {
+	UserID        int64
+	Fields        []enum.ProfileFieldEnum
+	FullName      string
+	DateOfBirth   *time.Time
+	Gender        *enum.GenderEnum
+	AvatarFileKey *string
+	Email         *string
+	Phone         *string
+}
@@ -0,0 +1,113 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for update user profile use-case.
+type Repository interface {
+	UserProfile(ctx context.Context, id int64) (dto.UserProfile, error)
+	Save(ctx context.Context, user *entity.User) error
+}
+
+// UseCase is a use-case for updating user profile data.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new update user profile use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for updating user profile data.
+// The fields of the profile which are not listed in the params are left alone.
+// The changed email or phone must be verified again.
+// If successful, the updated user profile is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.User, error) {
+	const op = "usecase.profile.update"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+	)
+	log.Info("attempting to update user profile")
+
+	// Get user profile from storage.
+	storageUserData, err := uc.repo.UserProfile(ctx, data.UserID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user profile data", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create user entity.
+	user := entity.NewUser(
+		storageUserData.Username,
+		storageUserData.FullName,
+		storageUserData.DateOfBirth,
+		storageUserData.Gender,
+		storageUserData.AvatarFileKey,
+		entity.WithUserID(storageUserData.ID),
+		entity.WithUserStatus(storageUserData.Blocked, storageUserData.Deleted),
+		entity.WithUserEmail(storageUserData.Email, storageUserData.EmailVerified),
+		entity.WithUserPhone(storageUserData.Phone, storageUserData.PhoneVerified),
+	)
+
+	// Update user profile.
+	updateProfileParams := entity.UpdateProfileParams{
+		Fields:        data.Fields,
+		FullName:      data.FullName,
+		DateOfBirth:   data.DateOfBirth,
+		Gender:        data.Gender,
+		AvatarFileKey: data.AvatarFileKey,
+		Email:         data.Email,
+		Phone:         data.Phone,
+	}
+	if err = user.UpdateProfile(updateProfileParams); err != nil {
+		if errors.Is(err, entity.ErrUserDeleted) {
+			log.Warn("user is deleted", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Warn("invalid profile data", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidProfile)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &user); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityExists) {
+			log.Warn("contact is already used by another user", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrContactExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user profile updated successfully")
+
+	return user, nil
+}
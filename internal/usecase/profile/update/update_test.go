@@ -0,0 +1,201 @@
+package update
+
+import (
+	"context"
+	"github.com/guregu/null/v6"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	dateOfBirth := time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)
+	futureDate := time.Now().AddDate(1, 0, 0)
+	female := enum.FEMALE
+	unknownGender := enum.GenderEnum(5)
+	avatarFileKey := "avatar"
+
+	testCases := []struct {
+		name                  string
+		userID                int64
+		deleted               bool
+		params                Params
+		expectedError         error
+		expectedFullName      string
+		expectedDateOfBirth   null.Time
+		expectedGender        null.Int16
+		expectedAvatarFileKey null.String
+	}{
+		{
+			name: "updates only the fields of the mask",
+			params: Params{
+				Fields:        []enum.ProfileFieldEnum{enum.ProfileFieldDateOfBirth, enum.ProfileFieldGender},
+				FullName:      "Ignored",
+				DateOfBirth:   &dateOfBirth,
+				Gender:        &female,
+				AvatarFileKey: &avatarFileKey,
+			},
+			expectedFullName:    "user",
+			expectedDateOfBirth: null.TimeFrom(dateOfBirth),
+			expectedGender:      null.Int16From(int16(enum.FEMALE)),
+		},
+		{
+			name: "updates the full name and the avatar",
+			params: Params{
+				Fields:        []enum.ProfileFieldEnum{enum.ProfileFieldFullName, enum.ProfileFieldAvatarFileKey},
+				FullName:      " New Name ",
+				AvatarFileKey: &avatarFileKey,
+			},
+			expectedFullName:      "New Name",
+			expectedAvatarFileKey: null.StringFrom(avatarFileKey),
+		},
+		{
+			name: "full name can't be cleared",
+			params: Params{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldFullName, enum.ProfileFieldGender},
+				Gender: &female,
+			},
+			expectedError:    usecase.ErrInvalidProfile,
+			expectedFullName: "user",
+		},
+		{
+			name: "date of birth in the future",
+			params: Params{
+				Fields:      []enum.ProfileFieldEnum{enum.ProfileFieldDateOfBirth},
+				DateOfBirth: &futureDate,
+			},
+			expectedError:    usecase.ErrInvalidProfile,
+			expectedFullName: "user",
+		},
+		{
+			name: "unknown gender",
+			params: Params{
+				Fields: []enum.ProfileFieldEnum{enum.ProfileFieldGender},
+				Gender: &unknownGender,
+			},
+			expectedError:    usecase.ErrInvalidProfile,
+			expectedFullName: "user",
+		},
+		{
+			name:    "deleted user",
+			deleted: true,
+			params: Params{
+				Fields:   []enum.ProfileFieldEnum{enum.ProfileFieldFullName},
+				FullName: "New Name",
+			},
+			expectedError:    usecase.ErrUserNotFound,
+			expectedFullName: "user",
+		},
+		{
+			name:   "user not found",
+			userID: 100,
+			params: Params{
+				Fields:   []enum.ProfileFieldEnum{enum.ProfileFieldFullName},
+				FullName: "New Name",
+			},
+			expectedError:    usecase.ErrUserNotFound,
+			expectedFullName: "user",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			log := usecasetest.Logger()
+
+			if tc.deleted {
+				saved, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				saved.Deleted = true
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, saved))
+			}
+
+			uc := New(log, repository.NewProfileRepository(log, fixture.Storage))
+
+			params := tc.params
+			params.UserID = userID
+			if tc.userID != 0 {
+				params.UserID = tc.userID
+			}
+
+			user, err := uc.Execute(ctx, params)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedFullName, user.FullName)
+			}
+
+			saved, err := fixture.Storage.User(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFullName, saved.FullName)
+			assert.Equal(t, tc.expectedDateOfBirth.Valid, saved.DateOfBirth.Valid)
+			if tc.expectedDateOfBirth.Valid {
+				assert.True(t, tc.expectedDateOfBirth.Time.Equal(saved.DateOfBirth.Time))
+			}
+			assert.Equal(t, tc.expectedGender, saved.Gender)
+			assert.Equal(t, tc.expectedAvatarFileKey, saved.AvatarFileKey)
+		})
+	}
+}
+
+func Test_UseCase_Execute_Contacts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	otherUserID := fixture.CreateUser(t, "other")
+	fixture.SetUserEmail(t, otherUserID, "other@example.com", true)
+	log := usecasetest.Logger()
+
+	uc := New(log, repository.NewProfileRepository(log, fixture.Storage))
+
+	email := "User@Example.com"
+	phone := "+15550000000"
+	user, err := uc.Execute(ctx, Params{
+		UserID: userID,
+		Fields: []enum.ProfileFieldEnum{enum.ProfileFieldEmail, enum.ProfileFieldPhone},
+		Email:  &email,
+		Phone:  &phone,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", *user.Email)
+	assert.Equal(t, phone, *user.Phone)
+
+	saved, err := fixture.Storage.User(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("user@example.com"), saved.Email)
+	assert.False(t, saved.EmailVerified)
+	assert.Equal(t, null.StringFrom(phone), saved.Phone)
+
+	// The verified email of another user is set unverified, it stays verified by its owner.
+	takenEmail := "Other@example.com"
+	_, err = uc.Execute(ctx, Params{
+		UserID: userID,
+		Fields: []enum.ProfileFieldEnum{enum.ProfileFieldEmail},
+		Email:  &takenEmail,
+	})
+	require.NoError(t, err)
+
+	saved, err = fixture.Storage.User(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("other@example.com"), saved.Email)
+	assert.False(t, saved.EmailVerified)
+
+	otherUser, err := fixture.Storage.User(ctx, otherUserID)
+	require.NoError(t, err)
+	assert.Equal(t, null.StringFrom("other@example.com"), otherUser.Email)
+	assert.True(t, otherUser.EmailVerified)
+}
@@ -0,0 +1,69 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for list user sessions use-case.
+type Repository interface {
+	UserSessions(ctx context.Context, userID int64) ([]dto.Session, error)
+}
+
+// UseCase is a use-case for getting the list of user sessions.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new list user sessions use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting the list of user sessions.
+func (uc *UseCase) Execute(ctx context.Context, userID int64) ([]entity.Session, error) {
+	const op = "usecase.session.list"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	// Get user sessions from storage.
+	storageSessions, err := uc.repo.UserSessions(ctx, userID)
+	if err != nil {
+		log.Error("error getting user sessions from storage", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessions := make([]entity.Session, 0, len(storageSessions))
+	for _, storageSession := range storageSessions {
+		session, err := entity.NewSession(
+			storageSession.UserID,
+			storageSession.UserAgent,
+			storageSession.Fingerprint,
+			entity.WithSessionID(storageSession.ID),
+			entity.WithSessionRefreshTokenID(storageSession.RefreshTokenID),
+			entity.WithSessionFamilyID(storageSession.FamilyID),
+			entity.WithSessionExpiresAt(storageSession.ExpiresAt),
+			entity.WithSessionCreatedAt(storageSession.CreatedAt),
+			entity.WithSessionLastUsedAt(storageSession.LastUsedAt),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
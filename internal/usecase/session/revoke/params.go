@@ -0,0 +1,8 @@
+package revoke
+
+// Params is a data for revoke user session use-case.
+// If UserID is set, the session must belong to the user.
+type Params struct {
+	SessionID int64
+	UserID    int64
+}
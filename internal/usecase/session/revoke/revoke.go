@@ -0,0 +1,94 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for revoke user session use-case.
+type Repository interface {
+	DataForRevokeSession(ctx context.Context, sessionID int64) (dto.DataForRevokeSession, error)
+
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for revoking a user session.
+type UseCase struct {
+	log  *slog.Logger
+	cfg  config.TokensConfig
+	repo Repository
+}
+
+// New returns new revoke user session use-case.
+func New(log *slog.Logger, cfg config.TokensConfig, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		cfg:  cfg,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for revoking a user session.
+// The session of another user is not found if the user is set.
+func (uc *UseCase) Execute(ctx context.Context, data Params) error {
+	const op = "usecase.session.revoke"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("session ID", data.SessionID),
+		slog.Int64("user ID", data.UserID),
+	)
+	log.Info("attempting to revoke session")
+
+	// Get data for revoke session from storage.
+	storageRevokeSessionData, err := uc.repo.DataForRevokeSession(ctx, data.SessionID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("session not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrSessionNotFound)
+		}
+
+		log.Error("error getting session from storage", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if data.UserID != 0 && storageRevokeSessionData.Session.UserID != data.UserID {
+		log.Warn("session belongs to another user")
+
+		return fmt.Errorf("%s: %w", op, usecase.ErrSessionNotFound)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSession(storageRevokeSessionData.Session),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Revoke session.
+	auth.RevokeSessions("")
+
+	// Save data to storage.
+	err = uc.repo.Save(ctx, &auth)
+	if err != nil {
+		log.Error("error saving data to storage.", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("session revoked successfully")
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package revoke
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name             string
+		otherUser        bool
+		anyUser          bool
+		expectedError    error
+		expectedSessions int
+	}{
+		{
+			name:             "revokes session of user",
+			expectedSessions: 0,
+		},
+		{
+			name:             "revokes session of any user",
+			anyUser:          true,
+			expectedSessions: 0,
+		},
+		{
+			name:             "session of another user is not found",
+			otherUser:        true,
+			expectedError:    usecase.ErrSessionNotFound,
+			expectedSessions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			otherUserID := fixture.CreateUser(t, "other user")
+
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				cfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				usecasetest.KeyStore(t),
+				repo,
+			)
+			_, err := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			sessions := fixture.Sessions(t, userID)
+			require.Len(t, sessions, 1)
+
+			params := Params{
+				SessionID: sessions[0].ID,
+				UserID:    userID,
+			}
+			if tc.otherUser {
+				params.UserID = otherUserID
+			}
+			if tc.anyUser {
+				params.UserID = 0
+			}
+
+			err = New(log, cfg, repo).Execute(ctx, params)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
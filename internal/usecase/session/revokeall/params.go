@@ -0,0 +1,10 @@
+package revokeall
+
+// Params is a data for revoke all user sessions use-case.
+// If ExceptCurrent is set, the session of the refresh token issued to the client is kept.
+type Params struct {
+	UserID        int64
+	ExceptCurrent bool
+	RefreshToken  string
+	ClientCode    string
+}
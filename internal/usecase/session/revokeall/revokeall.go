@@ -0,0 +1,132 @@
+package revokeall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtparser "github.com/p1xray/pxr-sso/pkg/jwt/parser"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for revoke all user sessions use-case.
+type Repository interface {
+	ClientByCode(ctx context.Context, code string) (dto.Client, error)
+	DataForRevokeAllSessions(ctx context.Context, userID int64) (dto.DataForRevokeAllSessions, error)
+
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for revoking all user sessions.
+type UseCase struct {
+	log  *slog.Logger
+	cfg  config.TokensConfig
+	repo Repository
+}
+
+// New returns new revoke all user sessions use-case.
+func New(log *slog.Logger, cfg config.TokensConfig, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		cfg:  cfg,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for revoking all user sessions. If successful, the number of revoked sessions
+// is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (int, error) {
+	const op = "usecase.session.revokeall"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.Int64("user ID", data.UserID),
+		slog.Bool("except current", data.ExceptCurrent),
+	)
+	log.Info("attempting to revoke all sessions")
+
+	// Get refresh token ID of the current session.
+	var currentRefreshTokenID string
+	if data.ExceptCurrent {
+		refreshTokenID, err := uc.currentRefreshTokenID(ctx, log, data.RefreshToken, data.ClientCode)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		currentRefreshTokenID = refreshTokenID
+	}
+
+	// Get data for revoke all sessions from storage.
+	storageRevokeAllSessionsData, err := uc.repo.DataForRevokeAllSessions(ctx, data.UserID)
+	if err != nil {
+		log.Error("error getting user sessions from storage", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSession(storageRevokeAllSessionsData.Sessions...),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check the current session belongs to the user.
+	if data.ExceptCurrent && !auth.HasSession(currentRefreshTokenID) {
+		log.Warn("current session not found")
+
+		return 0, fmt.Errorf("%s: %w", op, usecase.ErrSessionNotFound)
+	}
+
+	// Revoke sessions.
+	revokedCount := auth.RevokeSessions(currentRefreshTokenID)
+
+	// Save data to storage.
+	err = uc.repo.Save(ctx, &auth)
+	if err != nil {
+		log.Error("error saving data to storage.", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("sessions revoked successfully", slog.Int("revoked count", revokedCount))
+
+	return revokedCount, nil
+}
+
+func (uc *UseCase) currentRefreshTokenID(
+	ctx context.Context,
+	log *slog.Logger,
+	refreshToken, clientCode string,
+) (string, error) {
+	// Get client from storage.
+	client, err := uc.repo.ClientByCode(ctx, clientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client not found", sl.Err(err))
+
+			return "", usecase.ErrClientNotFound
+		}
+
+		log.Error("error getting client from storage", sl.Err(err))
+		return "", err
+	}
+
+	// Parse refresh token by client secret key.
+	refreshTokenClaims, err := jwtparser.ParseRefreshToken(refreshToken, []byte(client.SecretKey))
+	if err != nil {
+		log.Warn("error parsing refresh token", sl.Err(err))
+
+		return "", fmt.Errorf("%w: %w", usecase.ErrSessionNotFound, err)
+	}
+
+	return refreshTokenClaims.ID, nil
+}
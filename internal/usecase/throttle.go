@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/entity"
+)
+
+// LoginThrottle returns the brute-force protection of the password checks by its configuration.
+// The protection is shared by all use-cases which check the password of the user.
+func LoginThrottle(cfg config.LoginThrottleConfig) entity.LoginThrottle {
+	return entity.LoginThrottle{
+		MaxFailures:     cfg.MaxFailures,
+		IPMaxFailures:   cfg.IPMaxFailures,
+		BaseDelay:       cfg.BaseDelay,
+		MaxDelay:        cfg.MaxDelay,
+		LockoutDuration: cfg.LockoutDuration,
+		FailureWindow:   cfg.FailureWindow,
+	}
+}
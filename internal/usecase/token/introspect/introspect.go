@@ -0,0 +1,139 @@
+package introspect
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for token introspection use-case.
+type Repository interface {
+	ClientByCode(ctx context.Context, code string) (dto.Client, error)
+	SessionByRefreshTokenID(ctx context.Context, refreshTokenID string) (dto.Session, error)
+	SessionByFamilyID(ctx context.Context, familyID string) (dto.Session, error)
+}
+
+// UseCase is a use-case for introspecting access and refresh tokens (RFC 7662).
+type UseCase struct {
+	log      *slog.Logger
+	keyStore *jwtkeys.Store
+	repo     Repository
+}
+
+// New returns new token introspection use-case.
+func New(
+	log *slog.Logger,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		keyStore: keyStore,
+		repo:     repo,
+	}
+}
+
+// Execute executes the use-case for introspecting a token.
+// The token is active if its signature is valid, it is not expired and its session is not revoked.
+// If the token is not active, the introspection with only the active flag set to false is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Introspection, error) {
+	const op = "usecase.token.introspect"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("client code", data.ClientCode),
+		slog.String("token type hint", data.TokenTypeHint),
+	)
+	log.Info("attempting to introspect token")
+
+	// Authenticate the client requesting the introspection.
+	client, err := uc.repo.ClientByCode(ctx, data.ClientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidClient)
+		}
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.SecretKey), []byte(data.ClientSecret)) != 1 {
+		log.Warn("invalid client secret")
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidClient)
+	}
+
+	// Parse token.
+	token, err := entity.ParseIntrospectedToken(data.Token)
+	if err != nil {
+		log.Info("token is not active", sl.Err(err))
+
+		return entity.Introspection{}, nil
+	}
+
+	// Get the key for verifying the token signature.
+	introspectParams := entity.IntrospectTokenParams{
+		KeySet:     uc.keyStore.PublicKeySet(),
+		SecretKey:  client.SecretKey,
+		ClientCode: client.Code,
+	}
+	if token.Type() == entity.TokenTypeAccessToken && token.IsSignedBySecretKey() && token.ClientID() != client.Code {
+		tokenClient, err := uc.repo.ClientByCode(ctx, token.ClientID())
+		if err != nil {
+			if errors.Is(err, infrastructure.ErrEntityNotFound) {
+				log.Info("token is not active", sl.Err(err))
+
+				return entity.Introspection{}, nil
+			}
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		introspectParams.SecretKey = tokenClient.SecretKey
+	}
+
+	// Verify token.
+	introspection, err := token.Introspect(introspectParams)
+	if err != nil {
+		log.Info("token is not active", sl.Err(err))
+
+		return entity.Introspection{}, nil
+	}
+
+	// Check the session the token belongs to. The refresh token is active only until it is rotated,
+	// the access token is active while the token family of its session is not revoked.
+	if introspection.SessionID != "" {
+		var session dto.Session
+		if introspection.TokenType == entity.TokenTypeRefreshToken {
+			session, err = uc.repo.SessionByRefreshTokenID(ctx, introspection.SessionID)
+		} else {
+			session, err = uc.repo.SessionByFamilyID(ctx, introspection.SessionID)
+		}
+		if err != nil {
+			if errors.Is(err, infrastructure.ErrEntityNotFound) {
+				log.Info("session of the token is revoked")
+
+				return entity.Introspection{}, nil
+			}
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = introspection.ValidateSession(session); err != nil {
+			log.Info("token is not active", sl.Err(err))
+
+			return entity.Introspection{}, nil
+		}
+	}
+
+	log.Info("token is active")
+
+	return introspection, nil
+}
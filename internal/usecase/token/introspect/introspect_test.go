@@ -0,0 +1,106 @@
+package introspect
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/events"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/logout"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/refresh"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		loggedOut             bool
+		expectedAccessActive  bool
+		expectedRefreshActive bool
+	}{
+		{
+			name:                  "access tokens are active after the refresh token rotation",
+			expectedAccessActive:  true,
+			expectedRefreshActive: true,
+		},
+		{
+			name:      "tokens are not active after the session is revoked",
+			loggedOut: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			fixture.CreateUser(t, "user")
+
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			keyStore := usecasetest.KeyStore(t)
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				cfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				keyStore,
+				repo,
+			)
+			loginTokens, err := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			refreshedTokens, err := refresh.New(log, cfg, keyStore, repo, events.NewLogPublisher(log)).Execute(ctx, refresh.Params{
+				RefreshToken: loginTokens.RefreshToken,
+				ClientCode:   usecasetest.ClientCode,
+				Issuer:       usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			if tc.loggedOut {
+				require.NoError(t, logout.New(log, cfg, repo).Execute(ctx, logout.Params{
+					RefreshToken: refreshedTokens.RefreshToken,
+					ClientCode:   usecasetest.ClientCode,
+				}))
+			}
+
+			introspect := func(token string) entity.Introspection {
+				introspection, err := New(log, keyStore, repo).Execute(ctx, Params{
+					Token:        token,
+					ClientCode:   usecasetest.ClientCode,
+					ClientSecret: usecasetest.ClientSecret,
+				})
+				require.NoError(t, err)
+
+				return introspection
+			}
+
+			// The access tokens of the session refer to its token family, so both stay active.
+			for _, accessToken := range []string{loginTokens.AccessToken, refreshedTokens.AccessToken} {
+				introspection := introspect(accessToken)
+				assert.Equal(t, tc.expectedAccessActive, introspection.Active)
+			}
+
+			// The ID token is not an access token, so it is never active.
+			require.NotEmpty(t, loginTokens.IDToken)
+			assert.False(t, introspect(loginTokens.IDToken).Active)
+
+			// The rotated refresh token is not active anymore.
+			assert.False(t, introspect(loginTokens.RefreshToken).Active)
+			assert.Equal(t, tc.expectedRefreshActive, introspect(refreshedTokens.RefreshToken).Active)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package introspect
+
+// Params is a data for token introspection use-case.
+// The client requesting the introspection authenticates by its code and secret.
+type Params struct {
+	Token         string
+	TokenTypeHint string
+	ClientCode    string
+	ClientSecret  string
+}
@@ -0,0 +1,9 @@
+package verify
+
+// Params is a data for access token verification use-case.
+// If RequiredPermission is set, the permission must be granted both to the access token
+// and to the owner of the access token.
+type Params struct {
+	AccessToken        string
+	RequiredPermission string
+}
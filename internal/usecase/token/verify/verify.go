@@ -0,0 +1,168 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"log/slog"
+)
+
+// Repository is a repository for access token verification use-case.
+type Repository interface {
+	ClientByCode(ctx context.Context, code string) (dto.Client, error)
+	SessionByFamilyID(ctx context.Context, familyID string) (dto.Session, error)
+	UserPermissionCodes(ctx context.Context, userID int64) ([]string, error)
+	ClientPermissionCodes(ctx context.Context, clientID int64) ([]string, error)
+}
+
+// UseCase is a use-case for verifying the access token of the caller of the protected API.
+type UseCase struct {
+	log      *slog.Logger
+	keyStore *jwtkeys.Store
+	repo     Repository
+}
+
+// New returns new access token verification use-case.
+func New(
+	log *slog.Logger,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:      log,
+		keyStore: keyStore,
+		repo:     repo,
+	}
+}
+
+// Execute executes the use-case for verifying an access token.
+// The access token is valid if it is marked as an access token, its signature is valid, it is not expired,
+// it is issued for the audiences of its client and its session is not revoked. The access tokens of the users
+// must belong to a session of the user and the client, only the access tokens of the clients have no session.
+// The access tokens signed by the client secret keys are rejected if the signing keys are configured.
+// If successful, the state of the access token with the permissions of its owner is returned.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Introspection, error) {
+	const op = "usecase.token.verify"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("required permission", data.RequiredPermission),
+	)
+
+	// Parse access token.
+	token, err := entity.ParseIntrospectedToken(data.AccessToken)
+	if err != nil {
+		log.Warn("invalid access token", sl.Err(err))
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	// The ID tokens and the refresh tokens have no access token use.
+	if token.Type() != entity.TokenTypeAccessToken {
+		log.Warn("token is not an access token")
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	// The SSO signs the access tokens by the signing keys if they are configured, so the access token
+	// signed by the client secret key is forged by the client.
+	if token.IsSignedBySecretKey() && uc.keyStore.HasKeys() {
+		log.Warn("access token is signed by the client secret key")
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	// Get the client of the access token.
+	client, err := uc.repo.ClientByCode(ctx, token.ClientID())
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("client of the access token not found", sl.Err(err))
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+		}
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get the key for verifying the access token signature.
+	introspectParams := entity.IntrospectTokenParams{
+		KeySet: uc.keyStore.PublicKeySet(),
+	}
+	if token.IsSignedBySecretKey() {
+		introspectParams.SecretKey = client.SecretKey
+		introspectParams.ClientCode = client.Code
+	}
+
+	// Verify access token.
+	introspection, err := token.Introspect(introspectParams)
+	if err != nil {
+		log.Warn("invalid access token", sl.Err(err))
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	// Check the client and the audience of the access token.
+	if err = introspection.ValidateClient(client); err != nil {
+		log.Warn("invalid access token", sl.Err(err))
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	// Check the session the access token belongs to.
+	if introspection.IsUserToken() && introspection.SessionID == "" {
+		log.Warn("access token of the user has no session")
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+	}
+
+	var session dto.Session
+	if introspection.SessionID != "" {
+		session, err = uc.repo.SessionByFamilyID(ctx, introspection.SessionID)
+		if err != nil {
+			if errors.Is(err, infrastructure.ErrEntityNotFound) {
+				log.Warn("session of the access token is revoked")
+
+				return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+			}
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = introspection.ValidateSession(session); err != nil {
+			log.Warn("invalid access token", sl.Err(err))
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+		}
+
+		if err = introspection.ValidateSessionOwner(session, client); err != nil {
+			log.Warn("invalid access token", sl.Err(err))
+
+			return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidToken)
+		}
+	}
+
+	// Get the permissions of the owner of the access token, the scope of the token may outlive them.
+	if introspection.IsUserToken() {
+		introspection.Permissions, err = uc.repo.UserPermissionCodes(ctx, session.UserID)
+	} else {
+		introspection.Permissions, err = uc.repo.ClientPermissionCodes(ctx, client.ID)
+	}
+	if err != nil {
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Check the permission of the access token.
+	if data.RequiredPermission != "" && !introspection.HasPermission(data.RequiredPermission) {
+		log.Warn("required permission is not granted to the access token", slog.String("subject", introspection.Subject))
+
+		return entity.Introspection{}, fmt.Errorf("%s: %w", op, usecase.ErrPermissionDenied)
+	}
+
+	return introspection, nil
+}
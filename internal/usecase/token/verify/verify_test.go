@@ -0,0 +1,266 @@
+package verify
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/events"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/login"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/logout"
+	"github.com/p1xray/pxr-sso/internal/usecase/auth/refresh"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	jwtcreator "github.com/p1xray/pxr-sso/pkg/jwt/creator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name               string
+		requiredPermission string
+		signingKeys        bool
+		grantAdmin         bool
+		refreshed          bool
+		loggedOut          bool
+		invalidToken       bool
+		idToken            bool
+		forge              func(userID int64, tokens entity.Tokens) jwtcreator.AccessTokenCreateData
+		expectedError      error
+	}{
+		{
+			name: "verifies access token",
+		},
+		{
+			name:        "verifies access token signed by the signing key",
+			signingKeys: true,
+		},
+		{
+			name:               "required permission is granted",
+			requiredPermission: usecasetest.PermissionCode,
+		},
+		{
+			name:               "admin permission is granted",
+			requiredPermission: entity.PermissionAdmin,
+			grantAdmin:         true,
+		},
+		{
+			name:               "required permission is not granted",
+			requiredPermission: entity.PermissionAdmin,
+			expectedError:      usecase.ErrPermissionDenied,
+		},
+		{
+			name:          "session is revoked",
+			loggedOut:     true,
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name:      "verifies access token after the refresh token rotation",
+			refreshed: true,
+		},
+		{
+			name:          "session is revoked after the refresh token rotation",
+			refreshed:     true,
+			loggedOut:     true,
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name:          "invalid access token",
+			invalidToken:  true,
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name:          "ID token is not an access token",
+			idToken:       true,
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name:        "access token signed by the client secret key while the signing keys are configured",
+			signingKeys: true,
+			forge: func(userID int64, tokens entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   strconv.FormatInt(userID, 10),
+					ClientID:  usecasetest.ClientCode,
+					SessionID: tokens.RefreshTokenID,
+					TokenUse:  jwtclaims.TokenUseAccess,
+					Audiences: []string{usecasetest.Audience},
+				}
+			},
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name: "access token of the user without session",
+			forge: func(userID int64, _ entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   strconv.FormatInt(userID, 10),
+					ClientID:  usecasetest.ClientCode,
+					TokenUse:  jwtclaims.TokenUseAccess,
+					Audiences: []string{usecasetest.Audience},
+				}
+			},
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name: "access token of another user of the session",
+			forge: func(userID int64, tokens entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   strconv.FormatInt(userID+1, 10),
+					ClientID:  usecasetest.ClientCode,
+					SessionID: tokens.RefreshTokenID,
+					TokenUse:  jwtclaims.TokenUseAccess,
+					Audiences: []string{usecasetest.Audience},
+				}
+			},
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name: "access token for another audience",
+			forge: func(userID int64, tokens entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   strconv.FormatInt(userID, 10),
+					ClientID:  usecasetest.ClientCode,
+					SessionID: tokens.RefreshTokenID,
+					TokenUse:  jwtclaims.TokenUseAccess,
+					Audiences: []string{"https://another.example.com"},
+				}
+			},
+			expectedError: usecase.ErrInvalidToken,
+		},
+		{
+			name:               "admin scope of the user without the admin permission",
+			requiredPermission: entity.PermissionAdmin,
+			forge: func(userID int64, tokens entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   strconv.FormatInt(userID, 10),
+					ClientID:  usecasetest.ClientCode,
+					SessionID: tokens.RefreshTokenID,
+					TokenUse:  jwtclaims.TokenUseAccess,
+					Audiences: []string{usecasetest.Audience},
+					Scopes:    []string{entity.PermissionAdmin},
+				}
+			},
+			expectedError: usecase.ErrPermissionDenied,
+		},
+		{
+			name:               "admin scope of the client without the admin permission",
+			requiredPermission: entity.PermissionAdmin,
+			forge: func(int64, entity.Tokens) jwtcreator.AccessTokenCreateData {
+				return jwtcreator.AccessTokenCreateData{
+					Subject:   usecasetest.ClientCode,
+					ClientID:  usecasetest.ClientCode,
+					TokenUse:  jwtclaims.TokenUseClient,
+					Audiences: []string{usecasetest.Audience},
+					Scopes:    []string{entity.PermissionAdmin},
+				}
+			},
+			expectedError: usecase.ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+
+			if tc.grantAdmin {
+				permissionID, err := fixture.Storage.SeedPermission(ctx, models.Permission{
+					Code:   entity.PermissionAdmin,
+					Active: true,
+				})
+				require.NoError(t, err)
+				require.NoError(t, fixture.Storage.SeedRolePermission(ctx, fixture.RoleID, permissionID))
+			}
+
+			log := usecasetest.Logger()
+			cfg := usecasetest.TokensConfig(5, enum.EvictOldest)
+			keyStore := usecasetest.KeyStore(t)
+			if tc.signingKeys {
+				keyStore = usecasetest.SigningKeyStore(t)
+			}
+			repo := repository.NewAuthRepository(log, fixture.Storage)
+
+			loginUseCase := login.New(
+				log,
+				cfg,
+				usecasetest.MFAConfig(),
+				usecasetest.LoginThrottleConfig(0),
+				usecasetest.PasswordHasher(),
+				keyStore,
+				repo,
+			)
+			tokens, err := loginUseCase.Execute(ctx, login.Params{
+				Username:   "user",
+				Password:   usecasetest.Password,
+				ClientCode: usecasetest.ClientCode,
+				Issuer:     usecasetest.Issuer,
+			})
+			require.NoError(t, err)
+
+			// The access tokens issued both before and after the rotation are verified.
+			accessTokens := []string{tokens.AccessToken}
+
+			if tc.refreshed {
+				tokens, err = refresh.New(log, cfg, keyStore, repo, events.NewLogPublisher(log)).Execute(ctx, refresh.Params{
+					RefreshToken: tokens.RefreshToken,
+					ClientCode:   usecasetest.ClientCode,
+					Issuer:       usecasetest.Issuer,
+				})
+				require.NoError(t, err)
+
+				accessTokens = append(accessTokens, tokens.AccessToken)
+			}
+
+			if tc.idToken {
+				require.NotEmpty(t, tokens.IDToken)
+
+				accessTokens = []string{tokens.IDToken}
+			}
+
+			// The forged access tokens are signed by the client secret key like the tokens issued without signing keys.
+			if tc.forge != nil {
+				forgeData := tc.forge(userID, tokens)
+				forgeData.TTL = time.Minute
+				forgeData.Key = []byte(usecasetest.ClientSecret)
+
+				accessToken, err := jwtcreator.NewAccessToken(forgeData)
+				require.NoError(t, err)
+
+				accessTokens = []string{accessToken}
+			}
+
+			if tc.loggedOut {
+				require.NoError(t, logout.New(log, cfg, repo).Execute(ctx, logout.Params{
+					RefreshToken: tokens.RefreshToken,
+					ClientCode:   usecasetest.ClientCode,
+				}))
+			}
+
+			for _, accessToken := range accessTokens {
+				if tc.invalidToken {
+					accessToken += "invalid"
+				}
+
+				introspection, err := New(log, keyStore, repo).Execute(ctx, Params{
+					AccessToken:        accessToken,
+					RequiredPermission: tc.requiredPermission,
+				})
+
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				} else {
+					require.NoError(t, err)
+					assert.Equal(t, strconv.FormatInt(userID, 10), introspection.Subject)
+				}
+			}
+		})
+	}
+}
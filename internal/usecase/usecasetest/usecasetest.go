@@ -0,0 +1,333 @@
+// Package usecasetest provides the fixture for testing the use-cases on the in-memory storage.
+package usecasetest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/guregu/null/v6"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/memory"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/storage/models"
+	"github.com/p1xray/pxr-sso/pkg/breached"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/passhash"
+	"github.com/p1xray/pxr-sso/pkg/totp"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"github.com/p1xray/pxr-sso/pkg/webauthn/webauthntest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+const (
+	ClientCode       = "test-client"
+	ClientSecret     = "98649a5c-2137-4a78-a63f-fbab416a7f9e"
+	RoleCode         = "user"
+	PermissionCode   = "profile:read"
+	Audience         = "https://api.example.com"
+	Issuer           = "https://sso.example.com"
+	Password         = "password"
+	BreachedPassword = "qwerty123"
+	TOTPSecret       = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	RPID             = "example.com"
+	RPOrigin         = "https://login.example.com"
+)
+
+// Fixture is the in-memory storage seeded with the test client, its default role and permission.
+type Fixture struct {
+	Storage      *memory.Storage
+	ClientID     int64
+	RoleID       int64
+	PermissionID int64
+}
+
+// Logger returns the logger which discards the records.
+func Logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// TokensConfig returns the tokens configuration with the session limit of the client.
+func TokensConfig(maxSessions int, evictionPolicy enum.SessionEvictionPolicyEnum) config.TokensConfig {
+	return config.TokensConfig{
+		AccessTokenTTL:        time.Hour,
+		RefreshTokenTTL:       24 * time.Hour,
+		AuthorizationCodeTTL:  time.Minute,
+		MaxSessionsPerClient:  maxSessions,
+		SessionEvictionPolicy: evictionPolicy,
+	}
+}
+
+// MFAConfig returns the configuration of the second authentication factor for tests.
+func MFAConfig() config.MFAConfig {
+	return config.MFAConfig{
+		Issuer:           "pxr-sso",
+		ChallengeTTL:     time.Minute,
+		MaxAttempts:      3,
+		BackupCodesCount: 2,
+	}
+}
+
+// WebAuthnConfig returns the configuration of the WebAuthn relying party for tests.
+func WebAuthnConfig() config.WebAuthnConfig {
+	return config.WebAuthnConfig{
+		RPID:         RPID,
+		RPName:       "pxr-sso",
+		Origins:      []string{RPOrigin},
+		ChallengeTTL: time.Minute,
+	}
+}
+
+// LoginThrottleConfig returns the configuration of the brute-force protection for tests.
+// The delay after the failed login is disabled, so the tests may retry the login at once.
+func LoginThrottleConfig(maxFailures int) config.LoginThrottleConfig {
+	return config.LoginThrottleConfig{
+		MaxFailures:     maxFailures,
+		IPMaxFailures:   maxFailures,
+		LockoutDuration: time.Hour,
+		FailureWindow:   time.Hour,
+	}
+}
+
+// PasswordPolicy returns the password policy for tests. The passwords must be at least 8 characters long,
+// can't be the username or the full name of the user and can't be BreachedPassword.
+func PasswordPolicy(t *testing.T) entity.PasswordPolicy {
+	t.Helper()
+
+	hash := sha1.Sum([]byte(BreachedPassword))
+	breachedPasswords, err := breached.New(hex.EncodeToString(hash[:]))
+	require.NoError(t, err)
+
+	return entity.PasswordPolicy{
+		MinLength:      8,
+		ForbidUserInfo: true,
+		Breached:       breachedPasswords,
+	}
+}
+
+// PasswordHasher returns the password hasher for tests. The passwords of the test users are hashed
+// with the same parameters, so they are not rehashed on login.
+func PasswordHasher() entity.PasswordHasher {
+	return passhash.Bcrypt{Cost: bcrypt.MinCost}
+}
+
+// KeyStore returns the key store without signing keys, the access tokens are signed by the client secret key.
+func KeyStore(t *testing.T) *jwtkeys.Store {
+	t.Helper()
+
+	keyStore, err := jwtkeys.NewStore(time.Hour)
+	require.NoError(t, err)
+
+	return keyStore
+}
+
+// SigningKeyStore returns the key store with the active ECDSA signing key.
+func SigningKeyStore(t *testing.T) *jwtkeys.Store {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signingKey, err := jwtkeys.NewSigningKey(key, "")
+	require.NoError(t, err)
+
+	keyStore, err := jwtkeys.NewStore(time.Hour, jwtkeys.StoredKey{SigningKey: signingKey})
+	require.NoError(t, err)
+
+	return keyStore
+}
+
+// NewFixture creates the in-memory storage and seeds it with the test client, its default role and permission.
+func NewFixture(t *testing.T) Fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	storage := memory.New()
+
+	clientID, err := storage.SeedClient(ctx, models.Client{Name: "Test client", Code: ClientCode, SecretKey: ClientSecret})
+	require.NoError(t, err)
+
+	_, err = storage.SeedClientAudience(ctx, models.Audience{ClientID: clientID, URL: Audience})
+	require.NoError(t, err)
+
+	roleID, err := storage.SeedRole(ctx, models.Role{Code: RoleCode, Name: "User", Active: true})
+	require.NoError(t, err)
+
+	permissionID, err := storage.SeedPermission(ctx, models.Permission{Code: PermissionCode, Active: true})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SeedRolePermission(ctx, roleID, permissionID))
+	require.NoError(t, storage.SeedClientDefaultRole(ctx, clientID, roleID))
+
+	return Fixture{
+		Storage:      storage,
+		ClientID:     clientID,
+		RoleID:       roleID,
+		PermissionID: permissionID,
+	}
+}
+
+// CreateUser creates the user of the test client with the test password and the default role.
+func (f Fixture) CreateUser(t *testing.T, username string) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	userID, err := f.Storage.CreateUser(ctx, models.User{
+		Username:     username,
+		PasswordHash: string(passwordHash),
+		FullName:     username,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	require.NoError(t, err)
+
+	_, err = f.Storage.CreateUserClientLink(ctx, models.UserClientLink{
+		UserID:    userID,
+		ClientID:  f.ClientID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = f.Storage.CreateUserRoleLink(ctx, models.UserRoleLink{
+		UserID:    userID,
+		RoleID:    f.RoleID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	return userID
+}
+
+// Sessions returns the sessions of the user.
+func (f Fixture) Sessions(t *testing.T, userID int64) []models.Session {
+	t.Helper()
+
+	sessions, err := f.Storage.SessionsByUserID(context.Background(), userID)
+	require.NoError(t, err)
+
+	return sessions
+}
+
+// SetUserEmail sets the email of the user.
+func (f Fixture) SetUserEmail(t *testing.T, userID int64, email string, verified bool) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	user, err := f.Storage.User(ctx, userID)
+	require.NoError(t, err)
+
+	user.Email = null.StringFrom(email)
+	user.EmailVerified = verified
+	require.NoError(t, f.Storage.UpdateUserProfile(ctx, user))
+}
+
+// SetUserPhone sets the phone of the user.
+func (f Fixture) SetUserPhone(t *testing.T, userID int64, phone string, verified bool) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	user, err := f.Storage.User(ctx, userID)
+	require.NoError(t, err)
+
+	user.Phone = null.StringFrom(phone)
+	user.PhoneVerified = verified
+	require.NoError(t, f.Storage.UpdateUserProfile(ctx, user))
+}
+
+// EnableTOTP enables the confirmed TOTP credential with the secret and the backup codes for the user.
+func (f Fixture) EnableTOTP(t *testing.T, userID int64, secret string, backupCodes ...string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	_, err := f.Storage.CreateTOTPCredential(ctx, models.TOTPCredential{
+		UserID:    userID,
+		Secret:    secret,
+		Confirmed: true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	for _, code := range backupCodes {
+		_, err = f.Storage.CreateBackupCode(ctx, models.BackupCode{
+			UserID:    userID,
+			CodeHash:  entity.HashBackupCode(code),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+		require.NoError(t, err)
+	}
+}
+
+// RequireMFA sets the flag the users must pass the second authentication factor to sign in to the client.
+func (f Fixture) RequireMFA(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	client, err := f.Storage.Client(ctx, f.ClientID)
+	require.NoError(t, err)
+
+	client.RequireMFA = true
+	require.NoError(t, f.Storage.UpdateClient(ctx, client))
+}
+
+// RegisterPasskey registers the WebAuthn credential of the new software authenticator for the user.
+// The authenticator is returned to perform the login ceremonies.
+func (f Fixture) RegisterPasskey(t *testing.T, userID int64) *webauthntest.Authenticator {
+	t.Helper()
+
+	authenticator, err := webauthntest.New(RPID, RPOrigin)
+	require.NoError(t, err)
+
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+
+	clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+	require.NoError(t, err)
+
+	relyingParty := webauthn.RelyingParty{ID: RPID, Origins: []string{RPOrigin}, UserVerification: true}
+	credential, err := relyingParty.VerifyRegistration(clientDataJSON, attestationObject, challenge)
+	require.NoError(t, err)
+
+	_, err = f.Storage.CreateWebAuthnCredential(context.Background(), models.WebAuthnCredential{
+		UserID:       userID,
+		CredentialID: webauthn.EncodeID(credential.ID),
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Name:         "passkey",
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	return authenticator
+}
+
+// TOTPCode returns the current TOTP code of the secret.
+func TOTPCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	return code
+}
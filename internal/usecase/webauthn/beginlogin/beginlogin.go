@@ -0,0 +1,108 @@
+package beginlogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"log/slog"
+)
+
+// Repository is a repository for begin WebAuthn login use-case.
+type Repository interface {
+	DataForBeginWebAuthnLogin(ctx context.Context, username, clientCode string) (dto.DataForBeginWebAuthnLogin, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for starting the login by the WebAuthn credential.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	webAuthnCfg config.WebAuthnConfig
+	repo        Repository
+}
+
+// New returns new begin WebAuthn login use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	webAuthnCfg config.WebAuthnConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		webAuthnCfg: webAuthnCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for starting the login by the WebAuthn credential.
+// The options of navigator.credentials.get() with the new challenge are returned,
+// the login is completed by the finish WebAuthn login use-case.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.WebAuthnRequestOptions, error) {
+	const op = "usecase.webauthn.beginlogin"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("client code", data.ClientCode),
+	)
+	log.Info("attempting to begin WebAuthn login")
+
+	// Get client and user data from storage.
+	storageLoginData, err := uc.repo.DataForBeginWebAuthnLogin(ctx, data.Username, data.ClientCode)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.WebAuthnRequestOptions{}, fmt.Errorf("%s: %w", op, usecase.ErrClientNotFound)
+		}
+
+		return entity.WebAuthnRequestOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthNonce(data.Nonce),
+		entity.WithAuthUser(storageLoginData.User),
+		entity.WithAuthWebAuthnCredentials(storageLoginData.WebAuthnCredentials...),
+		entity.WithAuthClient(storageLoginData.Client),
+	)
+	if err != nil {
+		return entity.WebAuthnRequestOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Begin WebAuthn login.
+	beginWebAuthnLoginParams := entity.BeginWebAuthnLoginParams{
+		RelyingParty: webauthn.RelyingParty{
+			ID:               uc.webAuthnCfg.RPID,
+			Origins:          uc.webAuthnCfg.Origins,
+			UserVerification: true,
+		},
+		ChallengeTTL: uc.webAuthnCfg.ChallengeTTL,
+	}
+	options, err := auth.BeginWebAuthnLogin(beginWebAuthnLoginParams)
+	if err != nil {
+		log.Error("failed to begin WebAuthn login", sl.Err(err))
+
+		return entity.WebAuthnRequestOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.WebAuthnRequestOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("WebAuthn login begun successfully")
+
+	return options, nil
+}
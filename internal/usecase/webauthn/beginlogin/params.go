@@ -0,0 +1,10 @@
+package beginlogin
+
+// Params is a data for begin WebAuthn login use-case.
+// Username is optional, without it any discoverable credential of the user is accepted.
+// Nonce is the nonce of the client which is put into the ID token of the session.
+type Params struct {
+	Username   string
+	ClientCode string
+	Nonce      string
+}
@@ -0,0 +1,138 @@
+package beginregistration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"log/slog"
+)
+
+// Repository is a repository for begin WebAuthn registration use-case.
+type Repository interface {
+	DataForBeginWebAuthnRegistration(ctx context.Context, username, ipAddress string) (dto.DataForWebAuthnRegistration, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for starting the registration of the WebAuthn credential of the user.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	webAuthnCfg config.WebAuthnConfig
+	throttleCfg config.LoginThrottleConfig
+	repo        Repository
+}
+
+// New returns new begin WebAuthn registration use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	webAuthnCfg config.WebAuthnConfig,
+	throttleCfg config.LoginThrottleConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		webAuthnCfg: webAuthnCfg,
+		throttleCfg: throttleCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for starting the registration of the WebAuthn credential of the user.
+// The password of the user is verified, the options of navigator.credentials.create() with the new challenge
+// are returned. The registration is completed by the finish WebAuthn registration use-case.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.WebAuthnCreationOptions, error) {
+	const op = "usecase.webauthn.beginregistration"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("username", data.Username),
+		slog.String("IP address", data.IPAddress),
+	)
+	log.Info("attempting to begin WebAuthn registration")
+
+	// Get user data from storage.
+	storageRegistrationData, err := uc.repo.DataForBeginWebAuthnRegistration(ctx, data.Username, data.IPAddress)
+	if err != nil {
+		log.Error("error getting user WebAuthn data from storage", sl.Err(err))
+
+		return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageRegistrationData.User),
+		entity.WithAuthLoginThrottle(usecase.LoginThrottle(uc.throttleCfg)),
+		entity.WithAuthLoginAttempts(storageRegistrationData.LoginAttempts...),
+		entity.WithAuthWebAuthnCredentials(storageRegistrationData.WebAuthnCredentials...),
+	)
+	if err != nil {
+		return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Begin WebAuthn registration.
+	beginWebAuthnRegistrationParams := entity.BeginWebAuthnRegistrationParams{
+		Username:  data.Username,
+		Password:  data.Password,
+		IPAddress: data.IPAddress,
+		RelyingParty: webauthn.RelyingParty{
+			ID:               uc.webAuthnCfg.RPID,
+			Origins:          uc.webAuthnCfg.Origins,
+			UserVerification: true,
+		},
+		RelyingPartyName: uc.webAuthnCfg.RPName,
+		ChallengeTTL:     uc.webAuthnCfg.ChallengeTTL,
+	}
+	options, err := auth.BeginWebAuthnRegistration(beginWebAuthnRegistrationParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("invalid credentials", sl.Err(err))
+
+			// The failed password check is counted.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrAccountLocked):
+			log.Warn("account is locked", sl.Err(err))
+
+			return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, usecase.ErrAccountLocked)
+		case errors.Is(err, entity.ErrLoginThrottled):
+			log.Warn("password check is throttled", sl.Err(err))
+
+			return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, usecase.ErrLoginThrottled)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		}
+
+		log.Error("failed to begin WebAuthn registration", sl.Err(err))
+
+		return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.WebAuthnCreationOptions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("WebAuthn registration begun successfully")
+
+	return options, nil
+}
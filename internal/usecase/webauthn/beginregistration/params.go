@@ -0,0 +1,9 @@
+package beginregistration
+
+// Params is a data for begin WebAuthn registration use-case.
+// IPAddress is the client IP address the failed password checks are counted by, it is optional.
+type Params struct {
+	Username  string
+	Password  string
+	IPAddress string
+}
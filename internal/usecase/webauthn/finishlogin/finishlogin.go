@@ -0,0 +1,178 @@
+package finishlogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"log/slog"
+)
+
+// Repository is a repository for finish WebAuthn login use-case.
+type Repository interface {
+	DataForFinishWebAuthnLogin(ctx context.Context, challenge, credentialID string) (dto.DataForFinishWebAuthnLogin, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for completing the login by the WebAuthn credential.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	webAuthnCfg config.WebAuthnConfig
+	keyStore    *jwtkeys.Store
+	repo        Repository
+}
+
+// New returns new finish WebAuthn login use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	webAuthnCfg config.WebAuthnConfig,
+	keyStore *jwtkeys.Store,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		webAuthnCfg: webAuthnCfg,
+		keyStore:    keyStore,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for completing the login by the WebAuthn credential.
+// The assertion of the credential is verified against the login challenge it is signed over,
+// and if successful, new tokens are returned. The challenge can't be used again.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.Tokens, error) {
+	const op = "usecase.webauthn.finishlogin"
+
+	log := uc.log.With(
+		slog.String("op", op),
+		slog.String("credential ID", data.CredentialID),
+	)
+	log.Info("attempting to finish WebAuthn login")
+
+	// Get the challenge the assertion is signed over.
+	clientData, err := webauthn.ParseClientData(data.ClientDataJSON)
+	if err != nil {
+		log.Warn("invalid client data", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+	}
+
+	// Get WebAuthn credential data from storage.
+	storageLoginData, err := uc.repo.DataForFinishWebAuthnLogin(ctx, clientData.Challenge, data.CredentialID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		}
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Get the active key for signing access tokens.
+	signingKey, err := uc.keyStore.ActiveKey()
+	if err != nil {
+		log.Error("error getting signing key", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthSigningKey(signingKey),
+		entity.WithAuthSessionLimit(entity.SessionLimit{
+			MaxSessions:    uc.cfg.MaxSessionsPerClient,
+			EvictionPolicy: uc.cfg.SessionEvictionPolicy,
+		}),
+		entity.WithAuthUser(storageLoginData.User),
+		entity.WithAuthClient(storageLoginData.Client),
+		entity.WithAuthSession(storageLoginData.Sessions...),
+		entity.WithAuthWebAuthnCredentials(storageLoginData.WebAuthnCredential),
+		entity.WithAuthWebAuthnChallenge(storageLoginData.WebAuthnChallenge),
+	)
+	if err != nil {
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Finish WebAuthn login.
+	finishWebAuthnLoginParams := entity.FinishWebAuthnLoginParams{
+		RelyingParty: webauthn.RelyingParty{
+			ID:               uc.webAuthnCfg.RPID,
+			Origins:          uc.webAuthnCfg.Origins,
+			UserVerification: true,
+		},
+		ClientDataJSON:    data.ClientDataJSON,
+		AuthenticatorData: data.AuthenticatorData,
+		Signature:         data.Signature,
+		UserAgent:         data.UserAgent,
+		Fingerprint:       data.Fingerprint,
+		Issuer:            data.Issuer,
+	}
+	tokens, err := auth.FinishWebAuthnLogin(finishWebAuthnLoginParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCredentials), errors.Is(err, entity.ErrWebAuthnSignCountMismatch):
+			log.Warn("invalid WebAuthn assertion", sl.Err(err))
+
+			// The challenge is used whether the assertion is valid or not.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidCredentials)
+		case errors.Is(err, entity.ErrWebAuthnChallengeNotFound), errors.Is(err, entity.ErrWebAuthnChallengeExpired):
+			log.Warn("invalid WebAuthn challenge", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		case errors.Is(err, entity.ErrPasswordChangeRequired):
+			log.Warn("password change required", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrPasswordChangeRequired)
+		case errors.Is(err, entity.ErrEmailNotVerified):
+			log.Warn("email is not verified", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrEmailNotVerified)
+		case errors.Is(err, entity.ErrSessionLimitExceeded):
+			log.Warn("session limit exceeded", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrSessionLimitExceeded)
+		}
+
+		log.Error("failed to finish WebAuthn login", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("WebAuthn challenge is already used", sl.Err(err))
+
+			return entity.Tokens{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.Tokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user logged in successfully")
+
+	return tokens, nil
+}
@@ -0,0 +1,247 @@
+package finishlogin
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginlogin"
+	"github.com/p1xray/pxr-sso/pkg/webauthn/webauthntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name               string
+		username           string
+		credentialID       string
+		challenge          string
+		otherAuthenticator bool
+		cloned             bool
+		blocked            bool
+		expectedError      error
+		expectedSessions   int
+	}{
+		{
+			name:             "completes login by the discoverable credential",
+			expectedSessions: 1,
+		},
+		{
+			name:             "completes login by the credential of the user",
+			username:         "user",
+			expectedSessions: 1,
+		},
+		{
+			name:          "challenge is issued for another user",
+			username:      "other",
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:          "unknown credential",
+			credentialID:  "unknown",
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:          "unknown challenge",
+			challenge:     "unknown",
+			expectedError: usecase.ErrInvalidCredentials,
+		},
+		{
+			name:               "assertion is signed by another authenticator",
+			otherAuthenticator: true,
+			expectedError:      usecase.ErrInvalidCredentials,
+		},
+		{
+			name:             "signature counter of the cloned authenticator",
+			cloned:           true,
+			expectedError:    usecase.ErrInvalidCredentials,
+			expectedSessions: 1,
+		},
+		{
+			name:          "user is blocked",
+			blocked:       true,
+			expectedError: usecase.ErrUserBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+			otherUserID := fixture.CreateUser(t, "other")
+			fixture.RegisterPasskey(t, otherUserID)
+			authenticator := fixture.RegisterPasskey(t, userID)
+
+			if tc.blocked {
+				user, err := fixture.Storage.User(ctx, userID)
+				require.NoError(t, err)
+
+				user.Blocked = true
+				require.NoError(t, fixture.Storage.UpdateUserStatus(ctx, user))
+			}
+
+			if tc.cloned {
+				// The original authenticator has been used, the counter of the clone is behind.
+				_, err := newUseCase(t, fixture).Execute(ctx, assertion(t, authenticator, beginLogin(t, fixture, "")))
+				require.NoError(t, err)
+
+				authenticator.SignCount = 0
+			}
+
+			challenge := beginLogin(t, fixture, tc.username)
+			if tc.challenge != "" {
+				challenge = tc.challenge
+			}
+
+			signer := authenticator
+			if tc.otherAuthenticator {
+				other, err := webauthntest.New(usecasetest.RPID, usecasetest.RPOrigin)
+				require.NoError(t, err)
+
+				other.CredentialID = authenticator.CredentialID
+				signer = other
+			}
+
+			params := assertion(t, signer, challenge)
+			if tc.credentialID != "" {
+				params.CredentialID = tc.credentialID
+			}
+
+			tokens, err := newUseCase(t, fixture).Execute(ctx, params)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.NotEmpty(t, tokens.IDToken)
+				assert.Empty(t, tokens.MFAToken)
+
+				session, err := fixture.Storage.SessionByRefreshTokenID(ctx, tokens.RefreshTokenID)
+				require.NoError(t, err)
+				assert.Equal(t, userID, session.UserID)
+				assert.Equal(t, fixture.ClientID, session.ClientID.Int64)
+				assert.Equal(t, "user agent", session.UserAgent)
+				assert.Equal(t, "fingerprint", session.Fingerprint)
+
+				credential, err := fixture.Storage.WebAuthnCredential(ctx, authenticator.CredentialIDString())
+				require.NoError(t, err)
+				assert.Equal(t, int64(authenticator.SignCount), credential.SignCount)
+			}
+
+			assert.Len(t, fixture.Sessions(t, userID), tc.expectedSessions)
+		})
+	}
+}
+
+func Test_UseCase_Execute_SingleUse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	userID := fixture.CreateUser(t, "user")
+	authenticator := fixture.RegisterPasskey(t, userID)
+	uc := newUseCase(t, fixture)
+
+	// The challenge is completed only once.
+	challenge := beginLogin(t, fixture, "")
+	_, err := uc.Execute(ctx, assertion(t, authenticator, challenge))
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, assertion(t, authenticator, challenge))
+	assert.ErrorIs(t, err, usecase.ErrInvalidWebAuthnChallenge)
+
+	// The invalid assertion uses the challenge.
+	challenge = beginLogin(t, fixture, "")
+	params := assertion(t, authenticator, challenge)
+	params.Signature[len(params.Signature)-1] ^= 0xff
+	_, err = uc.Execute(ctx, params)
+	assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+
+	_, err = uc.Execute(ctx, assertion(t, authenticator, challenge))
+	assert.ErrorIs(t, err, usecase.ErrInvalidWebAuthnChallenge)
+
+	assert.Len(t, fixture.Sessions(t, userID), 1)
+}
+
+func Test_BeginLogin_UnknownClient(t *testing.T) {
+	t.Parallel()
+
+	fixture := usecasetest.NewFixture(t)
+	log := usecasetest.Logger()
+
+	_, err := beginlogin.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		repository.NewAuthRepository(log, fixture.Storage),
+	).Execute(context.Background(), beginlogin.Params{ClientCode: "unknown"})
+	assert.ErrorIs(t, err, usecase.ErrClientNotFound)
+}
+
+func newUseCase(t *testing.T, fixture usecasetest.Fixture) *UseCase {
+	t.Helper()
+
+	log := usecasetest.Logger()
+
+	return New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		usecasetest.KeyStore(t),
+		repository.NewAuthRepository(log, fixture.Storage),
+	)
+}
+
+// beginLogin begins the login by the WebAuthn credential and returns the challenge.
+// If the username is given, only the credentials of the user are allowed.
+func beginLogin(t *testing.T, fixture usecasetest.Fixture, username string) string {
+	t.Helper()
+
+	log := usecasetest.Logger()
+	options, err := beginlogin.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		repository.NewAuthRepository(log, fixture.Storage),
+	).Execute(context.Background(), beginlogin.Params{
+		Username:   username,
+		ClientCode: usecasetest.ClientCode,
+		Nonce:      "nonce",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, options.Challenge)
+
+	if username == "" {
+		assert.Empty(t, options.AllowCredentialIDs)
+	} else {
+		assert.Len(t, options.AllowCredentialIDs, 1)
+	}
+
+	return options.Challenge
+}
+
+// assertion performs the authentication ceremony of the challenge by the authenticator.
+func assertion(t *testing.T, authenticator *webauthntest.Authenticator, challenge string) Params {
+	t.Helper()
+
+	clientDataJSON, authenticatorData, signature, err := authenticator.Get(challenge)
+	require.NoError(t, err)
+
+	return Params{
+		CredentialID:      authenticator.CredentialIDString(),
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authenticatorData,
+		Signature:         signature,
+		UserAgent:         "user agent",
+		Fingerprint:       "fingerprint",
+		Issuer:            usecasetest.Issuer,
+	}
+}
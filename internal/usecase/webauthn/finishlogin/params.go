@@ -0,0 +1,14 @@
+package finishlogin
+
+// Params is a data for finish WebAuthn login use-case.
+// CredentialID is the base64url encoding of the credential ID, ClientDataJSON, AuthenticatorData and Signature
+// are the response of navigator.credentials.get().
+type Params struct {
+	CredentialID      string
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserAgent         string
+	Fingerprint       string
+	Issuer            string
+}
@@ -0,0 +1,156 @@
+package finishregistration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/p1xray/pxr-sso/internal/config"
+	"github.com/p1xray/pxr-sso/internal/dto"
+	"github.com/p1xray/pxr-sso/internal/entity"
+	"github.com/p1xray/pxr-sso/internal/infrastructure"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/pkg/logger/sl"
+	"github.com/p1xray/pxr-sso/pkg/webauthn"
+	"log/slog"
+)
+
+// Repository is a repository for finish WebAuthn registration use-case.
+type Repository interface {
+	DataForFinishWebAuthnRegistration(ctx context.Context, challenge string) (dto.DataForWebAuthnRegistration, error)
+	Save(ctx context.Context, auth *entity.Auth) error
+}
+
+// UseCase is a use-case for completing the registration of the WebAuthn credential of the user.
+type UseCase struct {
+	log         *slog.Logger
+	cfg         config.TokensConfig
+	webAuthnCfg config.WebAuthnConfig
+	repo        Repository
+}
+
+// New returns new finish WebAuthn registration use-case.
+func New(
+	log *slog.Logger,
+	cfg config.TokensConfig,
+	webAuthnCfg config.WebAuthnConfig,
+	repo Repository,
+) *UseCase {
+	return &UseCase{
+		log:         log,
+		cfg:         cfg,
+		webAuthnCfg: webAuthnCfg,
+		repo:        repo,
+	}
+}
+
+// Execute executes the use-case for completing the registration of the WebAuthn credential of the user.
+// The response of the authenticator is verified against the registration challenge it is signed over,
+// and if successful, the created credential is returned. The challenge can't be used again.
+func (uc *UseCase) Execute(ctx context.Context, data Params) (entity.WebAuthnCredential, error) {
+	const op = "usecase.webauthn.finishregistration"
+
+	log := uc.log.With(
+		slog.String("op", op),
+	)
+	log.Info("attempting to finish WebAuthn registration")
+
+	// Get the challenge the response is signed over.
+	clientData, err := webauthn.ParseClientData(data.ClientDataJSON)
+	if err != nil {
+		log.Warn("invalid client data", sl.Err(err))
+
+		return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnResponse)
+	}
+
+	// Get WebAuthn challenge data from storage.
+	storageRegistrationData, err := uc.repo.DataForFinishWebAuthnRegistration(ctx, clientData.Challenge)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		}
+
+		return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Create auth entity.
+	auth, err := entity.NewAuth(
+		uc.cfg.AccessTokenTTL,
+		uc.cfg.RefreshTokenTTL,
+		entity.WithAuthUser(storageRegistrationData.User),
+		entity.WithAuthWebAuthnCredentials(storageRegistrationData.WebAuthnCredentials...),
+		entity.WithAuthWebAuthnChallenge(storageRegistrationData.WebAuthnChallenge),
+	)
+	if err != nil {
+		return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Finish WebAuthn registration.
+	finishWebAuthnRegistrationParams := entity.FinishWebAuthnRegistrationParams{
+		RelyingParty: webauthn.RelyingParty{
+			ID:               uc.webAuthnCfg.RPID,
+			Origins:          uc.webAuthnCfg.Origins,
+			UserVerification: true,
+		},
+		ClientDataJSON:    data.ClientDataJSON,
+		AttestationObject: data.AttestationObject,
+		Name:              data.Name,
+	}
+	credential, err := auth.FinishWebAuthnRegistration(finishWebAuthnRegistrationParams)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidWebAuthnResponse):
+			log.Warn("invalid WebAuthn response", sl.Err(err))
+
+			// The challenge is used whether the response is valid or not.
+			if err = uc.repo.Save(ctx, &auth); err != nil {
+				log.Error("error saving data to storage", sl.Err(err))
+
+				return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnResponse)
+		case errors.Is(err, entity.ErrWebAuthnChallengeNotFound), errors.Is(err, entity.ErrWebAuthnChallengeExpired):
+			log.Warn("invalid WebAuthn challenge", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		case errors.Is(err, entity.ErrWebAuthnCredentialExists):
+			log.Warn("WebAuthn credential is already registered", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrWebAuthnCredentialExists)
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			log.Warn("user is deleted", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		case errors.Is(err, entity.ErrUserBlocked):
+			log.Warn("user is blocked", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrUserBlocked)
+		}
+
+		log.Error("failed to finish WebAuthn registration", sl.Err(err))
+
+		return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Save data to storage.
+	if err = uc.repo.Save(ctx, &auth); err != nil {
+		switch {
+		case errors.Is(err, infrastructure.ErrEntityNotFound):
+			log.Warn("WebAuthn challenge is already used", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrInvalidWebAuthnChallenge)
+		case errors.Is(err, infrastructure.ErrEntityExists):
+			log.Warn("WebAuthn credential is already registered", sl.Err(err))
+
+			return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, usecase.ErrWebAuthnCredentialExists)
+		}
+
+		log.Error("error saving data to storage", sl.Err(err))
+
+		return entity.WebAuthnCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("WebAuthn credential registered successfully")
+
+	return credential, nil
+}
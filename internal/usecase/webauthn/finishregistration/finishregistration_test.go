@@ -0,0 +1,201 @@
+package finishregistration
+
+import (
+	"context"
+	"github.com/p1xray/pxr-sso/internal/enum"
+	"github.com/p1xray/pxr-sso/internal/infrastructure/repository"
+	"github.com/p1xray/pxr-sso/internal/usecase"
+	"github.com/p1xray/pxr-sso/internal/usecase/usecasetest"
+	"github.com/p1xray/pxr-sso/internal/usecase/webauthn/beginregistration"
+	"github.com/p1xray/pxr-sso/pkg/webauthn/webauthntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_UseCase_Execute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		challenge           string
+		registered          bool
+		configure           func(a *webauthntest.Authenticator)
+		expectedError       error
+		expectedCredentials int
+	}{
+		{
+			name:                "registers the credential",
+			expectedCredentials: 1,
+		},
+		{
+			name:          "unknown challenge",
+			challenge:     "unknown",
+			expectedError: usecase.ErrInvalidWebAuthnChallenge,
+		},
+		{
+			name:                "credential is already registered",
+			registered:          true,
+			expectedError:       usecase.ErrWebAuthnCredentialExists,
+			expectedCredentials: 1,
+		},
+		{
+			name: "user is not verified by the authenticator",
+			configure: func(a *webauthntest.Authenticator) {
+				a.UserVerified = false
+			},
+			expectedError: usecase.ErrInvalidWebAuthnResponse,
+		},
+		{
+			name: "relying party ID mismatch",
+			configure: func(a *webauthntest.Authenticator) {
+				a.RPID = "evil.example.com"
+			},
+			expectedError: usecase.ErrInvalidWebAuthnResponse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fixture := usecasetest.NewFixture(t)
+			userID := fixture.CreateUser(t, "user")
+
+			authenticator, err := webauthntest.New(usecasetest.RPID, usecasetest.RPOrigin)
+			require.NoError(t, err)
+
+			if tc.registered {
+				registered := fixture.RegisterPasskey(t, userID)
+				authenticator.CredentialID = registered.CredentialID
+			}
+
+			if tc.configure != nil {
+				tc.configure(authenticator)
+			}
+
+			challenge := beginRegistration(t, fixture)
+			if tc.challenge != "" {
+				challenge = tc.challenge
+			}
+
+			clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+			require.NoError(t, err)
+
+			credential, err := newUseCase(fixture).Execute(ctx, Params{
+				ClientDataJSON:    clientDataJSON,
+				AttestationObject: attestationObject,
+				Name:              "laptop",
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, authenticator.CredentialIDString(), credential.CredentialID)
+				assert.Equal(t, "laptop", credential.Name)
+
+				saved, err := fixture.Storage.WebAuthnCredential(ctx, credential.CredentialID)
+				require.NoError(t, err)
+				assert.Equal(t, userID, saved.UserID)
+				assert.Equal(t, credential.PublicKey, saved.PublicKey)
+			}
+
+			credentials, err := fixture.Storage.WebAuthnCredentials(ctx, userID)
+			require.NoError(t, err)
+			assert.Len(t, credentials, tc.expectedCredentials)
+		})
+	}
+}
+
+func Test_UseCase_Execute_SingleUse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+	uc := newUseCase(fixture)
+
+	authenticator, err := webauthntest.New(usecasetest.RPID, usecasetest.RPOrigin)
+	require.NoError(t, err)
+
+	// The invalid response uses the challenge.
+	challenge := beginRegistration(t, fixture)
+
+	authenticator.UserVerified = false
+	clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{ClientDataJSON: clientDataJSON, AttestationObject: attestationObject})
+	assert.ErrorIs(t, err, usecase.ErrInvalidWebAuthnResponse)
+
+	authenticator.UserVerified = true
+	clientDataJSON, attestationObject, err = authenticator.Create(challenge)
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{ClientDataJSON: clientDataJSON, AttestationObject: attestationObject})
+	assert.ErrorIs(t, err, usecase.ErrInvalidWebAuthnChallenge)
+
+	// The challenge is completed only once.
+	challenge = beginRegistration(t, fixture)
+	clientDataJSON, attestationObject, err = authenticator.Create(challenge)
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{ClientDataJSON: clientDataJSON, AttestationObject: attestationObject})
+	require.NoError(t, err)
+
+	other, err := webauthntest.New(usecasetest.RPID, usecasetest.RPOrigin)
+	require.NoError(t, err)
+
+	clientDataJSON, attestationObject, err = other.Create(challenge)
+	require.NoError(t, err)
+
+	_, err = uc.Execute(ctx, Params{ClientDataJSON: clientDataJSON, AttestationObject: attestationObject})
+	assert.ErrorIs(t, err, usecase.ErrInvalidWebAuthnChallenge)
+}
+
+func Test_BeginRegistration_InvalidPassword(t *testing.T) {
+	t.Parallel()
+
+	fixture := usecasetest.NewFixture(t)
+	fixture.CreateUser(t, "user")
+	log := usecasetest.Logger()
+
+	_, err := beginregistration.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		usecasetest.LoginThrottleConfig(0),
+		repository.NewAuthRepository(log, fixture.Storage),
+	).Execute(context.Background(), beginregistration.Params{Username: "user", Password: "wrong-password"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+}
+
+func newUseCase(fixture usecasetest.Fixture) *UseCase {
+	log := usecasetest.Logger()
+
+	return New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		repository.NewAuthRepository(log, fixture.Storage),
+	)
+}
+
+// beginRegistration begins the registration of the WebAuthn credential of the user and returns the challenge.
+func beginRegistration(t *testing.T, fixture usecasetest.Fixture) string {
+	t.Helper()
+
+	log := usecasetest.Logger()
+	options, err := beginregistration.New(
+		log,
+		usecasetest.TokensConfig(5, enum.EvictOldest),
+		usecasetest.WebAuthnConfig(),
+		usecasetest.LoginThrottleConfig(0),
+		repository.NewAuthRepository(log, fixture.Storage),
+	).Execute(context.Background(), beginregistration.Params{Username: "user", Password: usecasetest.Password})
+	require.NoError(t, err)
+	require.NotEmpty(t, options.Challenge)
+	assert.Equal(t, usecasetest.RPID, options.RPID)
+
+	return options.Challenge
+}
@@ -0,0 +1,10 @@
+package finishregistration
+
+// Params is a data for finish WebAuthn registration use-case.
+// ClientDataJSON and AttestationObject are the response of navigator.credentials.create(),
+// Name is the name of the credential shown to the user.
+type Params struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Name              string
+}
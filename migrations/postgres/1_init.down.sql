@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS authorization_codes;
+DROP TABLE IF EXISTS client_redirect_uris;
+DROP TABLE IF EXISTS client_audiences;
+DROP TABLE IF EXISTS client_permissions;
+DROP TABLE IF EXISTS client_default_roles;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS consumed_refresh_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS user_clients;
+DROP TABLE IF EXISTS clients;
+DROP TABLE IF EXISTS users;
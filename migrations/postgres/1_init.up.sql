@@ -0,0 +1,173 @@
+CREATE TABLE IF NOT EXISTS users
+(
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    fio VARCHAR(255) NOT NULL,
+    date_of_birth TIMESTAMPTZ,
+    gender SMALLINT,
+    avatar_file_key VARCHAR(255),
+    deleted BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_users_username_password_hash ON users (username, password_hash);
+
+CREATE TABLE IF NOT EXISTS clients
+(
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(255) NOT NULL UNIQUE,
+    secret_key VARCHAR(255) NOT NULL,
+    max_sessions INTEGER,
+    session_eviction_policy VARCHAR(20),
+    deleted BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_clients
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_clients_client_id ON user_clients (client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_clients_user_id_client_id ON user_clients (user_id, client_id);
+
+CREATE TABLE IF NOT EXISTS sessions
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    client_id BIGINT REFERENCES clients (id),
+    refresh_token VARCHAR(255) NOT NULL UNIQUE,
+    family_id VARCHAR(255) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id_client_id ON sessions (user_id, client_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_family_id ON sessions (family_id);
+
+CREATE TABLE IF NOT EXISTS consumed_refresh_tokens
+(
+    id BIGSERIAL PRIMARY KEY,
+    refresh_token VARCHAR(255) NOT NULL UNIQUE,
+    family_id VARCHAR(255) NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_consumed_refresh_tokens_family_id ON consumed_refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS roles
+(
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000),
+    active BOOLEAN NOT NULL,
+    deleted BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_roles_active ON roles (active);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(255) NOT NULL UNIQUE,
+    description VARCHAR(1000),
+    active BOOLEAN NOT NULL,
+    deleted BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_permissions_active ON permissions (active);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+    id BIGSERIAL PRIMARY KEY,
+    role_id BIGINT NOT NULL REFERENCES roles (id),
+    permission_id BIGINT NOT NULL REFERENCES permissions (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_role_permissions_role_id ON role_permissions (role_id);
+CREATE INDEX IF NOT EXISTS idx_role_permissions_permission_id ON role_permissions (permission_id);
+
+CREATE TABLE IF NOT EXISTS user_roles
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    role_id BIGINT NOT NULL REFERENCES roles (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_roles_user_id ON user_roles (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles (role_id);
+
+CREATE TABLE IF NOT EXISTS client_default_roles
+(
+    id BIGSERIAL PRIMARY KEY,
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    role_id BIGINT NOT NULL REFERENCES roles (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_client_default_roles_client_id ON client_default_roles (client_id);
+CREATE INDEX IF NOT EXISTS idx_client_default_roles_role_id ON client_default_roles (role_id);
+
+CREATE TABLE IF NOT EXISTS client_permissions
+(
+    id BIGSERIAL PRIMARY KEY,
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    permission_id BIGINT NOT NULL REFERENCES permissions (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_client_permissions_permission_id ON client_permissions (permission_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_permissions_client_id_permission_id ON client_permissions (client_id, permission_id);
+
+CREATE TABLE IF NOT EXISTS client_audiences
+(
+    id BIGSERIAL PRIMARY KEY,
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    url VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_client_audiences_client_id ON client_audiences (client_id);
+
+CREATE TABLE IF NOT EXISTS client_redirect_uris
+(
+    id BIGSERIAL PRIMARY KEY,
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    uri VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_redirect_uris_client_id_uri ON client_redirect_uris (client_id, uri);
+
+CREATE TABLE IF NOT EXISTS authorization_codes
+(
+    id BIGSERIAL PRIMARY KEY,
+    code_hash VARCHAR(255) NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    redirect_uri VARCHAR(1000) NOT NULL,
+    code_challenge VARCHAR(255) NOT NULL,
+    code_challenge_method VARCHAR(10) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    auth_time TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
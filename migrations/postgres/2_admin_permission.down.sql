@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE code = 'sso:admin');
+DELETE FROM client_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE code = 'sso:admin');
+DELETE FROM permissions WHERE code = 'sso:admin';
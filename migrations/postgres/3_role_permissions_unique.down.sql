@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_role_permissions_role_id_permission_id;
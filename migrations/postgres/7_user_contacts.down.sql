@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS verification_codes;
+
+ALTER TABLE clients DROP COLUMN require_verified_email;
+
+ALTER TABLE users DROP COLUMN phone_verified;
+ALTER TABLE users DROP COLUMN phone;
+ALTER TABLE users DROP COLUMN email_verified;
+ALTER TABLE users DROP COLUMN email;
@@ -0,0 +1,40 @@
+ALTER TABLE clients ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS totp_credentials
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL UNIQUE REFERENCES users (id),
+    secret VARCHAR(255) NOT NULL,
+    confirmed BOOLEAN NOT NULL,
+    last_used_step BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS backup_codes
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    code_hash VARCHAR(255) NOT NULL,
+    used BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_backup_codes_user_id ON backup_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS mfa_challenges
+(
+    id BIGSERIAL PRIMARY KEY,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    client_id BIGINT NOT NULL REFERENCES clients (id),
+    user_agent VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(255) NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts INTEGER NOT NULL,
+    used BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
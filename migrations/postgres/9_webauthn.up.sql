@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS webauthn_credentials
+(
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    credential_id VARCHAR(1024) NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials (user_id);
+
+CREATE TABLE IF NOT EXISTS webauthn_challenges
+(
+    id BIGSERIAL PRIMARY KEY,
+    challenge VARCHAR(255) NOT NULL UNIQUE,
+    ceremony VARCHAR(50) NOT NULL,
+    user_id BIGINT NULL REFERENCES users (id),
+    client_id BIGINT NULL REFERENCES clients (id),
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_permissions_role_id_permission_id ON role_permissions (role_id, permission_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_roles_user_id_role_id;
+ALTER TABLE users DROP COLUMN blocked;
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN blocked BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_user_id_role_id ON user_roles (user_id, role_id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS verification_codes;
+
+ALTER TABLE clients DROP COLUMN require_verified_email;
+
+DROP INDEX IF EXISTS idx_users_phone;
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users DROP COLUMN phone_verified;
+ALTER TABLE users DROP COLUMN phone;
+ALTER TABLE users DROP COLUMN email_verified;
+ALTER TABLE users DROP COLUMN email;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS backup_codes;
+DROP TABLE IF EXISTS totp_credentials;
+
+ALTER TABLE clients DROP COLUMN require_mfa;
@@ -0,0 +1,45 @@
+ALTER TABLE clients ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS totp_credentials
+(
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    secret VARCHAR(255) NOT NULL,
+    confirmed BOOL NOT NULL,
+    last_used_step INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS backup_codes
+(
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    used BOOL NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_backup_codes_user_id ON backup_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS mfa_challenges
+(
+    id INTEGER PRIMARY KEY,
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    client_id INTEGER NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(255) NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL,
+    used BOOL NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id),
+    FOREIGN KEY (client_id)  REFERENCES clients (id)
+);
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_token_hash ON mfa_challenges (token_hash);
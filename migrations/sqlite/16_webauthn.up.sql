@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS webauthn_credentials
+(
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    credential_id VARCHAR(1024) NOT NULL UNIQUE,
+    public_key BLOB NOT NULL,
+    sign_count INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials (user_id);
+
+CREATE TABLE IF NOT EXISTS webauthn_challenges
+(
+    id INTEGER PRIMARY KEY,
+    challenge VARCHAR(255) NOT NULL UNIQUE,
+    ceremony VARCHAR(50) NOT NULL,
+    user_id INTEGER NULL,
+    client_id INTEGER NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used BOOL NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id),
+    FOREIGN KEY (client_id)  REFERENCES clients (id)
+);
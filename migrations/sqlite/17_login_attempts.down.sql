@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_login_attempts_kind_last_failure_at;
+DROP TABLE IF EXISTS login_attempts;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS login_attempts
+(
+    id INTEGER PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (kind, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_kind_last_failure_at ON login_attempts (kind, last_failure_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_email;
+DROP INDEX IF EXISTS idx_users_phone;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email) WHERE email_verified;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone ON users (phone) WHERE phone_verified;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_authorization_codes_code_hash;
+DROP TABLE IF EXISTS authorization_codes;
+DROP INDEX IF EXISTS idx_client_redirect_uris_client_id_uri;
+DROP INDEX IF EXISTS idx_client_redirect_uris_client_id;
+DROP TABLE IF EXISTS client_redirect_uris;
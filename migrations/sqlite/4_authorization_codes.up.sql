@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS client_redirect_uris
+(
+    id INTEGER PRIMARY KEY,
+    client_id INTEGER NOT NULL,
+    uri VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (client_id)  REFERENCES clients (id)
+);
+CREATE INDEX IF NOT EXISTS idx_client_redirect_uris_client_id ON client_redirect_uris (client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_redirect_uris_client_id_uri ON client_redirect_uris (client_id, uri);
+
+CREATE TABLE IF NOT EXISTS authorization_codes
+(
+    id INTEGER PRIMARY KEY,
+    code_hash VARCHAR(255) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    client_id INTEGER NOT NULL,
+    redirect_uri VARCHAR(1000) NOT NULL,
+    code_challenge VARCHAR(255) NOT NULL,
+    code_challenge_method VARCHAR(10) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    auth_time TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used BOOL NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id),
+    FOREIGN KEY (client_id)  REFERENCES clients (id)
+);
+CREATE INDEX IF NOT EXISTS idx_authorization_codes_code_hash ON authorization_codes (code_hash);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_client_permissions_client_id_permission_id;
+DROP INDEX IF EXISTS idx_client_permissions_permission_id;
+DROP INDEX IF EXISTS idx_client_permissions_client_id;
+DROP TABLE IF EXISTS client_permissions;
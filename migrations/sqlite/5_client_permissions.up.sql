@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS client_permissions
+(
+    id INTEGER PRIMARY KEY,
+    client_id INTEGER NOT NULL,
+    permission_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (client_id)  REFERENCES clients (id),
+    FOREIGN KEY (permission_id)  REFERENCES permissions (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_permissions_client_id ON client_permissions (client_id);
+CREATE INDEX IF NOT EXISTS idx_client_permissions_permission_id ON client_permissions (permission_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_client_permissions_client_id_permission_id ON client_permissions (client_id, permission_id);
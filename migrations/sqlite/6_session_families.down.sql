@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_consumed_refresh_tokens_family_id;
+DROP INDEX IF EXISTS idx_consumed_refresh_tokens_refresh_token;
+DROP TABLE IF EXISTS consumed_refresh_tokens;
+DROP INDEX IF EXISTS idx_sessions_family_id;
+ALTER TABLE sessions DROP COLUMN family_id;
@@ -0,0 +1,17 @@
+ALTER TABLE sessions ADD COLUMN family_id VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE sessions SET family_id = refresh_token WHERE family_id = '';
+CREATE INDEX IF NOT EXISTS idx_sessions_family_id ON sessions (family_id);
+
+CREATE TABLE IF NOT EXISTS consumed_refresh_tokens
+(
+    id INTEGER PRIMARY KEY,
+    refresh_token VARCHAR(255) NOT NULL UNIQUE,
+    family_id VARCHAR(255) NOT NULL,
+    user_id INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id)  REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_consumed_refresh_tokens_refresh_token ON consumed_refresh_tokens (refresh_token);
+CREATE INDEX IF NOT EXISTS idx_consumed_refresh_tokens_family_id ON consumed_refresh_tokens (family_id);
@@ -0,0 +1,2 @@
+ALTER TABLE sessions ADD COLUMN last_used_at TIMESTAMP;
+UPDATE sessions SET last_used_at = updated_at WHERE last_used_at IS NULL;
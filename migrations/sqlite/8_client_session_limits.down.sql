@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sessions_user_id_client_id;
+ALTER TABLE sessions DROP COLUMN client_id;
+
+ALTER TABLE clients DROP COLUMN session_eviction_policy;
+ALTER TABLE clients DROP COLUMN max_sessions;
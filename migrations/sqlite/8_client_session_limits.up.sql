@@ -0,0 +1,5 @@
+ALTER TABLE clients ADD COLUMN max_sessions INTEGER;
+ALTER TABLE clients ADD COLUMN session_eviction_policy VARCHAR(20);
+
+ALTER TABLE sessions ADD COLUMN client_id INTEGER REFERENCES clients (id);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id_client_id ON sessions (user_id, client_id);
@@ -0,0 +1,2 @@
+INSERT INTO permissions (code, description, active, deleted, created_at, updated_at)
+VALUES ('sso:admin', 'Administration of the SSO clients, roles and users', TRUE, FALSE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
@@ -0,0 +1,178 @@
+// Package breached implements the offline list of the passwords known from the data breaches.
+// The list keeps the SHA-1 hashes of the passwords grouped by the first 5 hex characters, like the k-anonymity
+// range API of Have I Been Pwned (https://haveibeenpwned.com/API/v3#PwnedPasswords), so the password is looked up
+// by the range of its hash prefix and never leaves the process.
+package breached
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// PrefixLength is the number of hex characters of the hash prefix the hashes are grouped by.
+	PrefixLength = 5
+
+	hashLength = sha1.Size * 2
+)
+
+// ErrInvalidHash is returned when the line of the list is not a SHA-1 hash.
+var ErrInvalidHash = errors.New("invalid SHA-1 hash")
+
+// List is the list of the SHA-1 hashes of the breached passwords grouped by the hash prefix.
+// The zero value is not usable, the list is created by New or Load.
+type List struct {
+	ranges map[string]map[string]struct{}
+}
+
+// New returns a new list with the SHA-1 hashes, the hashes are hex encoded in any case.
+func New(hashes ...string) (*List, error) {
+	list := &List{ranges: make(map[string]map[string]struct{})}
+	for _, hash := range hashes {
+		if err := list.Add(hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// Load returns the list loaded from the path. If the path is a file, its lines are the hex encoded SHA-1 hashes.
+// If the path is a directory, its files are the ranges named by the hash prefix, like "21BD1.txt", and their lines
+// are the hash suffixes. The lines may end with the ":count" of the breaches, like the lines of the Have I Been Pwned
+// downloads and range responses. The empty lines and the lines starting with "#" are skipped.
+func Load(path string) (*List, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	list, _ := New()
+
+	if !info.IsDir() {
+		if err = list.loadFile(path, ""); err != nil {
+			return nil, err
+		}
+
+		return list, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if len(prefix) != PrefixLength {
+			continue
+		}
+
+		if err = list.loadFile(filepath.Join(path, entry.Name()), prefix); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// Add adds the hex encoded SHA-1 hash to the list.
+func (l *List) Add(hash string) error {
+	hash = strings.ToUpper(strings.TrimSpace(hash))
+	if len(hash) != hashLength {
+		return fmt.Errorf("%w: %q", ErrInvalidHash, hash)
+	}
+
+	if _, err := hex.DecodeString(hash); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidHash, hash)
+	}
+
+	prefix, suffix := hash[:PrefixLength], hash[PrefixLength:]
+
+	suffixes, ok := l.ranges[prefix]
+	if !ok {
+		suffixes = make(map[string]struct{})
+		l.ranges[prefix] = suffixes
+	}
+	suffixes[suffix] = struct{}{}
+
+	return nil
+}
+
+// Range returns the sorted suffixes of the hashes which start with the prefix.
+func (l *List) Range(prefix string) []string {
+	suffixes := l.ranges[strings.ToUpper(prefix)]
+
+	result := make([]string, 0, len(suffixes))
+	for suffix := range suffixes {
+		result = append(result, suffix)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// Contains reports whether the password is in the list. Only the range of the hash prefix is looked up.
+func (l *List) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, ok := l.ranges[hash[:PrefixLength]][hash[PrefixLength:]]
+
+	return ok
+}
+
+// Len returns the number of the hashes in the list.
+func (l *List) Len() int {
+	var n int
+	for _, suffixes := range l.ranges {
+		n += len(suffixes)
+	}
+
+	return n
+}
+
+// loadFile adds the hashes of the file to the list. If the prefix is set, the lines of the file are the suffixes
+// of the hashes with the prefix.
+func (l *List) loadFile(path, prefix string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = l.read(f, prefix); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (l *List) read(r io.Reader, prefix string) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		if err := l.Add(prefix + hash); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,72 @@
+package breached
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// passwordHash is the SHA-1 hash of "password".
+	passwordHash = "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"
+	// qwertyHash is the SHA-1 hash of "qwerty".
+	qwertyHash = "B1B3773A05C0ED0176787A4F1574FF0075F7521E"
+)
+
+func TestList_Contains(t *testing.T) {
+	t.Parallel()
+
+	list, err := New(passwordHash, "b1b3773a05c0ed0176787a4f1574ff0075f7521e")
+	require.NoError(t, err)
+
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("qwerty"))
+	assert.False(t, list.Contains("Password"))
+	assert.Equal(t, 2, list.Len())
+
+	assert.Equal(t, []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, list.Range("5baa6"))
+	assert.Empty(t, list.Range("00000"))
+
+	_, err = New("not a hash")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// The file of the full hashes.
+	file := filepath.Join(dir, "hashes.txt")
+	content := "# breached passwords\n" + passwordHash + ":9545824\n\n" + qwertyHash + "\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	list, err := Load(file)
+	require.NoError(t, err)
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("qwerty"))
+
+	// The directory of the ranges.
+	rangesDir := filepath.Join(dir, "ranges")
+	require.NoError(t, os.Mkdir(rangesDir, 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(rangesDir, "5BAA6.txt"),
+		[]byte("003D68EB55068C33ACE09247EE4C639306B:3\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\n"),
+		0o600,
+	))
+
+	list, err = Load(rangesDir)
+	require.NoError(t, err)
+	assert.True(t, list.Contains("password"))
+	assert.False(t, list.Contains("qwerty"))
+	assert.Equal(t, 2, list.Len())
+
+	// The invalid hash.
+	invalid := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalid, []byte("5BAA6\n"), 0o600))
+
+	_, err = Load(invalid)
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
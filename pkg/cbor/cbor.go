@@ -0,0 +1,381 @@
+// Package cbor implements the subset of CBOR (RFC 8949) used by WebAuthn attestation objects and COSE keys.
+// Only the definite-length items are supported. The integers are decoded as int64, the byte strings as []byte,
+// the text strings as string, the arrays as []any and the maps as map[any]any.
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+
+	simpleFalse   = 20
+	simpleTrue    = 21
+	simpleNull    = 22
+	simpleFloat32 = 26
+	simpleFloat64 = 27
+
+	additionalUint8  = 24
+	additionalUint16 = 25
+	additionalUint32 = 26
+	additionalUint64 = 27
+
+	// maxDepth is the maximum nesting of arrays and maps.
+	maxDepth = 16
+)
+
+var (
+	// ErrUnexpectedEnd is returned when the data ends in the middle of the item.
+	ErrUnexpectedEnd = errors.New("cbor: unexpected end of data")
+
+	// ErrUnsupported is returned for the items out of the supported subset.
+	ErrUnsupported = errors.New("cbor: unsupported item")
+
+	// ErrTrailingData is returned by Unmarshal when the data has bytes after the item.
+	ErrTrailingData = errors.New("cbor: trailing data")
+)
+
+// Unmarshal decodes the single item of the data.
+func Unmarshal(data []byte) (any, error) {
+	value, n, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != len(data) {
+		return nil, ErrTrailingData
+	}
+
+	return value, nil
+}
+
+// Decode decodes the first item of the data and returns the number of bytes read.
+// It is used when the item is followed by other data, like the credential public key
+// in the authenticator data.
+func Decode(data []byte) (any, int, error) {
+	d := decoder{data: data}
+
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, d.offset, nil
+}
+
+type decoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *decoder) decode(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nesting is too deep", ErrUnsupported)
+	}
+
+	major, additional, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	if major == majorSimple {
+		return d.simple(additional)
+	}
+
+	argument, err := d.argument(additional)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if argument > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: integer overflows int64", ErrUnsupported)
+		}
+
+		return int64(argument), nil
+	case majorNegative:
+		if argument > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: integer overflows int64", ErrUnsupported)
+		}
+
+		return -1 - int64(argument), nil
+	case majorBytes:
+		return d.bytes(argument)
+	case majorText:
+		text, err := d.bytes(argument)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(text), nil
+	case majorArray:
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, ErrUnexpectedEnd
+		}
+
+		array := make([]any, argument)
+		for i := range array {
+			if array[i], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+
+		return array, nil
+	case majorMap:
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, ErrUnexpectedEnd
+		}
+
+		m := make(map[any]any, argument)
+		for range argument {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("%w: map key must be an integer or a text string", ErrUnsupported)
+			}
+
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			m[key] = value
+		}
+
+		return m, nil
+	case majorTag:
+		// The tags are not used by WebAuthn, the tagged item is returned as is.
+		return d.decode(depth + 1)
+	}
+
+	return nil, ErrUnsupported
+}
+
+func (d *decoder) head() (byte, byte, error) {
+	if d.offset >= len(d.data) {
+		return 0, 0, ErrUnexpectedEnd
+	}
+
+	b := d.data[d.offset]
+	d.offset++
+
+	return b >> 5, b & 0x1f, nil
+}
+
+func (d *decoder) argument(additional byte) (uint64, error) {
+	switch {
+	case additional < additionalUint8:
+		return uint64(additional), nil
+	case additional == additionalUint8:
+		b, err := d.bytes(1)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(b[0]), nil
+	case additional == additionalUint16:
+		b, err := d.bytes(2)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case additional == additionalUint32:
+		b, err := d.bytes(4)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	case additional == additionalUint64:
+		b, err := d.bytes(8)
+		if err != nil {
+			return 0, err
+		}
+
+		return binary.BigEndian.Uint64(b), nil
+	}
+
+	return 0, fmt.Errorf("%w: indefinite length", ErrUnsupported)
+}
+
+func (d *decoder) simple(additional byte) (any, error) {
+	switch additional {
+	case simpleFalse:
+		return false, nil
+	case simpleTrue:
+		return true, nil
+	case simpleNull:
+		return nil, nil
+	case simpleFloat32:
+		b, err := d.bytes(4)
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case simpleFloat64:
+		b, err := d.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+
+	return nil, fmt.Errorf("%w: simple value %d", ErrUnsupported, additional)
+}
+
+func (d *decoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.offset) {
+		return nil, ErrUnexpectedEnd
+	}
+
+	b := d.data[d.offset : d.offset+int(n)]
+	d.offset += int(n)
+
+	return b, nil
+}
+
+// Marshal encodes the value. The supported values are the integers, []byte, string, bool, nil,
+// []any, map[any]any, map[string]any and map[int]any. The map keys are sorted in the canonical order
+// (RFC 8949, section 4.2.1), so the encoding is deterministic.
+func Marshal(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(majorSimple<<5 | simpleNull)
+	case bool:
+		if v {
+			buf.WriteByte(majorSimple<<5 | simpleTrue)
+		} else {
+			buf.WriteByte(majorSimple<<5 | simpleFalse)
+		}
+	case int:
+		encodeInt(buf, int64(v))
+	case int64:
+		encodeInt(buf, v)
+	case uint64:
+		encodeHead(buf, majorUnsigned, v)
+	case []byte:
+		encodeHead(buf, majorBytes, uint64(len(v)))
+		buf.Write(v)
+	case string:
+		encodeHead(buf, majorText, uint64(len(v)))
+		buf.WriteString(v)
+	case []any:
+		encodeHead(buf, majorArray, uint64(len(v)))
+		for _, item := range v {
+			if err := encode(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[any]any:
+		return encodeMap(buf, v)
+	case map[string]any:
+		m := make(map[any]any, len(v))
+		for key, item := range v {
+			m[key] = item
+		}
+
+		return encodeMap(buf, m)
+	case map[int]any:
+		m := make(map[any]any, len(v))
+		for key, item := range v {
+			m[key] = item
+		}
+
+		return encodeMap(buf, m)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupported, value)
+	}
+
+	return nil
+}
+
+func encodeMap(buf *bytes.Buffer, m map[any]any) error {
+	type entry struct {
+		key   []byte
+		value any
+	}
+
+	entries := make([]entry, 0, len(m))
+	for key, value := range m {
+		encodedKey, err := Marshal(key)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{key: encodedKey, value: value})
+	}
+
+	// The canonical order is the bytewise lexicographic order of the encoded keys.
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	encodeHead(buf, majorMap, uint64(len(entries)))
+	for _, e := range entries {
+		buf.Write(e.key)
+		if err := encode(buf, e.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeInt(buf *bytes.Buffer, v int64) {
+	if v >= 0 {
+		encodeHead(buf, majorUnsigned, uint64(v))
+		return
+	}
+
+	encodeHead(buf, majorNegative, uint64(-1-v))
+}
+
+func encodeHead(buf *bytes.Buffer, major byte, argument uint64) {
+	switch {
+	case argument < additionalUint8:
+		buf.WriteByte(major<<5 | byte(argument))
+	case argument <= math.MaxUint8:
+		buf.WriteByte(major<<5 | additionalUint8)
+		buf.WriteByte(byte(argument))
+	case argument <= math.MaxUint16:
+		buf.WriteByte(major<<5 | additionalUint16)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(argument)))
+	case argument <= math.MaxUint32:
+		buf.WriteByte(major<<5 | additionalUint32)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(argument)))
+	default:
+		buf.WriteByte(major<<5 | additionalUint64)
+		buf.Write(binary.BigEndian.AppendUint64(nil, argument))
+	}
+}
@@ -0,0 +1,117 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	// The examples of RFC 8949, appendix A.
+	tests := []struct {
+		hex  string
+		want any
+	}{
+		{hex: "00", want: int64(0)},
+		{hex: "17", want: int64(23)},
+		{hex: "1818", want: int64(24)},
+		{hex: "1903e8", want: int64(1000)},
+		{hex: "1a000f4240", want: int64(1000000)},
+		{hex: "1b000000e8d4a51000", want: int64(1000000000000)},
+		{hex: "20", want: int64(-1)},
+		{hex: "3903e7", want: int64(-1000)},
+		{hex: "f4", want: false},
+		{hex: "f5", want: true},
+		{hex: "f6", want: nil},
+		{hex: "fb3ff199999999999a", want: 1.1},
+		{hex: "4401020304", want: []byte{1, 2, 3, 4}},
+		{hex: "6449455446", want: "IETF"},
+		{hex: "83010203", want: []any{int64(1), int64(2), int64(3)}},
+		{hex: "a201020304", want: map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{hex: "a26161016162820203", want: map[any]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{hex: "c074323031332d30332d32315432303a30343a30305a", want: "2013-03-21T20:04:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := hex.DecodeString(tt.hex)
+			require.NoError(t, err)
+
+			value, err := Unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hex     string
+		wantErr error
+	}{
+		{name: "empty data", hex: "", wantErr: ErrUnexpectedEnd},
+		{name: "truncated byte string", hex: "4401", wantErr: ErrUnexpectedEnd},
+		{name: "truncated map", hex: "a201", wantErr: ErrUnexpectedEnd},
+		{name: "indefinite length", hex: "5f42010243030405ff", wantErr: ErrUnsupported},
+		{name: "integer overflow", hex: "1bffffffffffffffff", wantErr: ErrUnsupported},
+		{name: "byte string map key", hex: "a14101f6", wantErr: ErrUnsupported},
+		{name: "trailing data", hex: "0000", wantErr: ErrTrailingData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := hex.DecodeString(tt.hex)
+			require.NoError(t, err)
+
+			_, err = Unmarshal(data)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	value, n, err := Decode([]byte{0x18, 0x64, 0xff, 0xff})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), value)
+	assert.Equal(t, 2, n)
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	value := map[int]any{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: []byte{0xaa},
+		-3: "text",
+	}
+
+	data, err := Marshal(value)
+	require.NoError(t, err)
+
+	// The keys are sorted in the canonical order: 1, 3, -1, -2, -3.
+	assert.Equal(t, "a50102032620012141aa226474657874", hex.EncodeToString(data))
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{
+		int64(1):  int64(2),
+		int64(3):  int64(-7),
+		int64(-1): int64(1),
+		int64(-2): []byte{0xaa},
+		int64(-3): "text",
+	}, decoded)
+}
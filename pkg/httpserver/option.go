@@ -0,0 +1,31 @@
+package httpserver
+
+import (
+	"net"
+	"time"
+)
+
+// Option is how options for the Server are set up.
+type Option func(*Server)
+
+// WithPort sets up a port for HTTP server.
+func WithPort(port string) Option {
+	return func(s *Server) {
+		s.App.Addr = net.JoinHostPort("", port)
+	}
+}
+
+// WithTimeout sets up read and write timeouts for HTTP server.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.App.ReadTimeout = timeout
+		s.App.WriteTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout sets up a timeout for graceful shutdown of HTTP server.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
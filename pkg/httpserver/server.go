@@ -0,0 +1,68 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultPort            = "80"
+	defaultTimeout         = 30 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+)
+
+// Server provides access to the HTTP server.
+type Server struct {
+	App             *http.Server
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+// New returns new HTTP server instance.
+func New(handler http.Handler, opts ...Option) *Server {
+	s := &Server{
+		App: &http.Server{
+			Addr:         net.JoinHostPort("", defaultPort),
+			Handler:      handler,
+			ReadTimeout:  defaultTimeout,
+			WriteTimeout: defaultTimeout,
+		},
+		notify:          make(chan error, 1),
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start - starts the HTTP server.
+func (s *Server) Start() {
+	go func() {
+		defer close(s.notify)
+
+		if err := s.App.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.notify <- fmt.Errorf("failed to serve: %w", err)
+		}
+	}()
+}
+
+// Notify - notifies about HTTP server errors.
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Stop - gracefully stops the HTTP server.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	return s.App.Shutdown(ctx)
+}
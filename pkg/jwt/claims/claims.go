@@ -5,6 +5,13 @@ import (
 	"github.com/go-jose/go-jose/v4/jwt"
 )
 
+// Token uses of the access tokens. The access tokens of the users and the access tokens issued to the clients
+// by the client credentials grant share the subject claim, so the token use tells them apart.
+const (
+	TokenUseAccess = "access"
+	TokenUseClient = "client"
+)
+
 // ValidatedClaims is the struct that will be inserted into the context for the user.
 type ValidatedClaims struct {
 	RegisteredClaims AccessTokenClaims
@@ -13,7 +20,10 @@ type ValidatedClaims struct {
 
 // RegisteredCustomClaims are custom claims of the current SSO project.
 type RegisteredCustomClaims struct {
-	Scope string `json:"scope,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	TokenUse  string `json:"token_use,omitempty"`
 }
 
 // AccessTokenClaims are all access token claims of the current SSO project.
@@ -28,6 +38,19 @@ type RefreshTokenClaims struct {
 	Expiry *jwt.NumericDate `json:"exp,omitempty"`
 }
 
+// IDTokenClaims are OpenID Connect ID token claims of the current SSO project.
+type IDTokenClaims struct {
+	jwt.Claims
+	AuthTime          *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce             string           `json:"nonce,omitempty"`
+	Name              string           `json:"name,omitempty"`
+	PreferredUsername string           `json:"preferred_username,omitempty"`
+	Birthdate         string           `json:"birthdate,omitempty"`
+	Gender            string           `json:"gender,omitempty"`
+	Email             string           `json:"email,omitempty"`
+	EmailVerified     bool             `json:"email_verified,omitempty"`
+}
+
 // CustomClaims defines any custom data / claims wanted.
 type CustomClaims interface {
 	Validate(ctx context.Context) error
@@ -7,6 +7,7 @@ import (
 	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/google/uuid"
 	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	"strings"
 	"time"
 )
@@ -17,14 +18,21 @@ var (
 )
 
 // AccessTokenCreateData is data to create new access token.
+// ClientID is the client the token is issued to, SessionID is the user session the token is issued for.
+// TokenUse tells the access tokens of the users from the access tokens of the clients.
+// If SigningKey is set, the token is signed with it, otherwise the token is signed by HS256 with Key.
 type AccessTokenCreateData struct {
 	Subject      string
+	ClientID     string
+	SessionID    string
+	TokenUse     string
 	Audiences    []string
 	Scopes       []string
 	Issuer       string
 	CustomClaims map[string]interface{}
 	TTL          time.Duration
 	Key          []byte
+	SigningKey   *jwtkeys.SigningKey
 }
 
 // NewAccessToken returns new JWT with claims.
@@ -41,11 +49,73 @@ func NewAccessToken(data AccessTokenCreateData) (string, error) {
 			NotBefore: jwt.NewNumericDate(now),
 		},
 		RegisteredCustomClaims: jwtclaims.RegisteredCustomClaims{
-			Scope: strings.Join(data.Scopes, " "),
+			Scope:     strings.Join(data.Scopes, " "),
+			ClientID:  data.ClientID,
+			SessionID: data.SessionID,
+			TokenUse:  data.TokenUse,
 		},
 	}
 
-	tokenStr, err := createSignedTokenWithClaims(data.Key, registeredClaims, data.CustomClaims)
+	tokenStr, err := createSignedTokenWithClaims(signingKey(data.Key, data.SigningKey), registeredClaims, data.CustomClaims)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenStr, nil
+}
+
+// IDTokenCreateData is data to create new OpenID Connect ID token.
+// The audience of the ID token is the client the user is authenticated for.
+// If SigningKey is set, the token is signed with it, otherwise the token is signed by HS256 with Key.
+// The email claims are left out if Email is empty.
+type IDTokenCreateData struct {
+	Subject           string
+	Audience          string
+	Issuer            string
+	Nonce             string
+	Name              string
+	PreferredUsername string
+	Birthdate         *time.Time
+	Gender            string
+	Email             string
+	EmailVerified     bool
+	AuthTime          time.Time
+	TTL               time.Duration
+	Key               []byte
+	SigningKey        *jwtkeys.SigningKey
+}
+
+// NewIDToken returns new OpenID Connect ID token.
+func NewIDToken(data IDTokenCreateData) (string, error) {
+	now := time.Now()
+	claims := jwtclaims.IDTokenClaims{
+		Claims: jwt.Claims{
+			Subject:  data.Subject,
+			Issuer:   data.Issuer,
+			Audience: jwt.Audience{data.Audience},
+			Expiry:   jwt.NewNumericDate(now.Add(data.TTL)),
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+		Nonce:             data.Nonce,
+		Name:              data.Name,
+		PreferredUsername: data.PreferredUsername,
+		Gender:            data.Gender,
+		Email:             data.Email,
+	}
+
+	if data.Email != "" {
+		claims.EmailVerified = data.EmailVerified
+	}
+
+	if !data.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(data.AuthTime)
+	}
+
+	if data.Birthdate != nil {
+		claims.Birthdate = data.Birthdate.Format(time.DateOnly)
+	}
+
+	tokenStr, err := createSignedTokenWithClaims(signingKey(data.Key, data.SigningKey), claims, nil)
 	if err != nil {
 		return "", err
 	}
@@ -55,25 +125,51 @@ func NewAccessToken(data AccessTokenCreateData) (string, error) {
 
 // NewRefreshToken returns new refresh token.
 func NewRefreshToken(key []byte, ttl time.Duration) (refreshToken string, refreshTokenID string, err error) {
-	id := uuid.New().String()
+	id := NewRefreshTokenID()
+
+	tokenStr, err := NewRefreshTokenWithID(key, id, ttl)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tokenStr, id, nil
+}
+
+// NewRefreshTokenID returns new identifier for the refresh token.
+// The identifier is generated before the token when other tokens must refer to it.
+func NewRefreshTokenID() string {
+	return uuid.New().String()
+}
+
+// NewRefreshTokenWithID returns new refresh token with the identifier.
+func NewRefreshTokenWithID(key []byte, id string, ttl time.Duration) (string, error) {
 	now := time.Now()
 	claims := jwtclaims.RefreshTokenClaims{
 		ID:     id,
 		Expiry: jwt.NewNumericDate(now.Add(ttl)),
 	}
 
-	tokenStr, err := createSignedTokenWithClaims(key, claims, nil)
-	if err != nil {
-		return "", "", err
+	return createSignedTokenWithClaims(hmacSigningKey(key), claims, nil)
+}
+
+func signingKey(key []byte, asymmetricKey *jwtkeys.SigningKey) jose.SigningKey {
+	if asymmetricKey != nil {
+		return asymmetricKey.JOSE()
 	}
 
-	return tokenStr, id, nil
+	return hmacSigningKey(key)
+}
+
+func hmacSigningKey(key []byte) jose.SigningKey {
+	return jose.SigningKey{Algorithm: jose.HS256, Key: key}
 }
 
-func createSignedTokenWithClaims(key []byte, registeredClaims interface{}, customClaims interface{}) (string, error) {
-	sig, err := jose.NewSigner(
-		jose.SigningKey{Algorithm: jose.HS256, Key: key},
-		(&jose.SignerOptions{}).WithType("JWT"))
+func createSignedTokenWithClaims(
+	key jose.SigningKey,
+	registeredClaims interface{},
+	customClaims interface{},
+) (string, error) {
+	sig, err := jose.NewSigner(key, (&jose.SignerOptions{}).WithType("JWT"))
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", ErrCreateSigner, err)
 	}
@@ -1,9 +1,16 @@
 package jwtcreator
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/google/uuid"
+	jwtkeys "github.com/p1xray/pxr-sso/pkg/jwt/keys"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"strings"
@@ -33,6 +40,8 @@ func Test_NewAccessToken(t *testing.T) {
 			name: "successfully creates a new token with data",
 			data: AccessTokenCreateData{
 				Subject:   "1",
+				ClientID:  "testClient",
+				SessionID: "testSession",
 				Audiences: []string{"testAudience"},
 				Issuer:    "testIssuer",
 				Scopes:    []string{"test.read", "test.write"},
@@ -88,6 +97,72 @@ func Test_NewAccessToken(t *testing.T) {
 	}
 }
 
+func Test_NewAccessToken_WithSigningKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		key               crypto.Signer
+		expectedAlgorithm jose.SignatureAlgorithm
+	}{
+		{
+			name:              "successfully creates a new token signed by RSA key",
+			key:               rsaKey,
+			expectedAlgorithm: jose.RS256,
+		},
+		{
+			name:              "successfully creates a new token signed by ECDSA key",
+			key:               ecdsaKey,
+			expectedAlgorithm: jose.ES256,
+		},
+		{
+			name:              "successfully creates a new token signed by Ed25519 key",
+			key:               ed25519Key,
+			expectedAlgorithm: jose.EdDSA,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signingKey, err := jwtkeys.NewSigningKey(tc.key, "")
+			require.NoError(t, err)
+
+			data := AccessTokenCreateData{
+				Subject:    "1",
+				Audiences:  []string{"testAudience"},
+				Issuer:     "testIssuer",
+				Scopes:     []string{"test.read", "test.write"},
+				TTL:        time.Duration(30) * time.Minute,
+				Key:        []byte(validKey),
+				SigningKey: &signingKey,
+			}
+
+			tokenStr, err := NewAccessToken(data)
+			require.NoError(t, err)
+
+			token, err := jwt.ParseSigned(tokenStr, []jose.SignatureAlgorithm{tc.expectedAlgorithm})
+			require.NoError(t, err)
+			require.Len(t, token.Headers, 1)
+			assert.Equal(t, signingKey.ID, token.Headers[0].KeyID)
+
+			claims := make(map[string]interface{})
+			err = token.Claims(tc.key.Public(), &claims)
+			require.NoError(t, err)
+
+			checkAccessTokenClaims(t, claims, data)
+		})
+	}
+}
+
 func Test_NewRefreshToken(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -132,6 +207,103 @@ func Test_NewRefreshToken(t *testing.T) {
 	}
 }
 
+func Test_NewIDToken(t *testing.T) {
+	birthdate := time.Date(1990, time.March, 8, 0, 0, 0, 0, time.UTC)
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		data          IDTokenCreateData
+		expectedError error
+	}{
+		{
+			name: "successfully creates a new token",
+			data: IDTokenCreateData{
+				Subject:  "1",
+				Audience: "testClient",
+				Issuer:   "testIssuer",
+				TTL:      time.Duration(30) * time.Minute,
+				Key:      []byte(validKey),
+			},
+		},
+		{
+			name: "successfully creates a new token with profile claims",
+			data: IDTokenCreateData{
+				Subject:           "1",
+				Audience:          "testClient",
+				Issuer:            "testIssuer",
+				Nonce:             "testNonce",
+				Name:              "Test User",
+				PreferredUsername: "test",
+				Birthdate:         &birthdate,
+				Gender:            "female",
+				Email:             "test@example.com",
+				EmailVerified:     true,
+				AuthTime:          authTime,
+				TTL:               time.Duration(30) * time.Minute,
+				Key:               []byte(validKey),
+			},
+		},
+		{
+			name: "throws an error when creating a token signed by invalid key",
+			data: IDTokenCreateData{
+				Key: []byte(invalidKey),
+			},
+			expectedError: ErrTokenSerialize,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenStr, err := NewIDToken(tc.data)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				token, err := jwt.ParseSigned(tokenStr, []jose.SignatureAlgorithm{jose.HS256})
+				require.NoError(t, err)
+
+				claims := make(map[string]interface{})
+				err = token.Claims(tc.data.Key, &claims)
+				require.NoError(t, err)
+
+				checkSubClaim(t, claims, tc.data.Subject)
+				checkIssClaim(t, claims, tc.data.Issuer)
+				checkAudClaim(t, claims, []string{tc.data.Audience})
+				checkExpClaim(t, claims)
+				checkIatClaim(t, claims)
+				checkOptionalStringClaim(t, claims, "nonce", tc.data.Nonce)
+				checkOptionalStringClaim(t, claims, "name", tc.data.Name)
+				checkOptionalStringClaim(t, claims, "preferred_username", tc.data.PreferredUsername)
+				checkOptionalStringClaim(t, claims, "gender", tc.data.Gender)
+				checkOptionalStringClaim(t, claims, "email", tc.data.Email)
+
+				if tc.data.EmailVerified {
+					assert.Equal(t, true, claims["email_verified"])
+				} else {
+					assert.NotContains(t, claims, "email_verified")
+				}
+
+				if tc.data.Birthdate != nil {
+					checkOptionalStringClaim(t, claims, "birthdate", "1990-03-08")
+				} else {
+					assert.NotContains(t, claims, "birthdate")
+				}
+
+				if !tc.data.AuthTime.IsZero() {
+					assert.Equal(t, float64(tc.data.AuthTime.Unix()), claims["auth_time"])
+				} else {
+					assert.NotContains(t, claims, "auth_time")
+				}
+			}
+		})
+	}
+}
+
 func checkAccessTokenClaims(t *testing.T, claims map[string]interface{}, expectedData AccessTokenCreateData) {
 	checkJtiClaim(t, claims)
 	checkSubClaim(t, claims, expectedData.Subject)
@@ -141,6 +313,8 @@ func checkAccessTokenClaims(t *testing.T, claims map[string]interface{}, expecte
 	checkNbfClaim(t, claims)
 	checkIatClaim(t, claims)
 	checkScopeClaim(t, claims, expectedData.Scopes)
+	checkOptionalStringClaim(t, claims, "client_id", expectedData.ClientID)
+	checkOptionalStringClaim(t, claims, "sid", expectedData.SessionID)
 	checkCustomClaims(t, claims, expectedData.CustomClaims)
 }
 
@@ -206,7 +380,7 @@ func checkAudClaim(t *testing.T, claims map[string]interface{}, expectedAudience
 	default:
 		t.Error("invalid type of aud claim")
 	}
-	
+
 	assert.Equal(t, expectedAudiences, audiences)
 }
 
@@ -270,6 +444,15 @@ func checkCustomClaims(t *testing.T, claims map[string]interface{}, expectedCust
 	}
 }
 
+func checkOptionalStringClaim(t *testing.T, claims map[string]interface{}, name, expectedValue string) {
+	if expectedValue == "" {
+		assert.NotContains(t, claims, name)
+		return
+	}
+
+	assert.Equal(t, expectedValue, claims[name])
+}
+
 func parseJtiClaim(t *testing.T, claims map[string]interface{}) string {
 	jti, ok := claims["jti"]
 	require.True(t, ok)
@@ -1,7 +1,9 @@
 package jwtmiddleware
 
 import (
+	"context"
 	"errors"
+	"google.golang.org/grpc/metadata"
 	"net/http"
 	"strings"
 )
@@ -9,6 +11,9 @@ import (
 const (
 	authorizationHeader = "Authorization"
 	bearerPrefix        = "Bearer"
+
+	// authorizationMetadataKey is the gRPC metadata key of the token. The metadata keys are lowercase.
+	authorizationMetadataKey = "authorization"
 )
 
 var (
@@ -33,3 +38,24 @@ func AuthHeaderTokenExtractor(r *http.Request) (string, error) {
 
 	return authHeaderParts[1], nil
 }
+
+// MetadataTokenExtractor extracts the token from the "authorization" metadata of the incoming gRPC request.
+// The empty token is returned if there is no metadata.
+func MetadataTokenExtractor(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", nil
+	}
+
+	authParts := strings.Fields(values[0])
+	if len(authParts) != 2 || !strings.EqualFold(authParts[0], bearerPrefix) {
+		return "", ErrInvalidHeaderFormat
+	}
+
+	return authParts[1], nil
+}
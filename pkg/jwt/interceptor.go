@@ -0,0 +1,144 @@
+package jwtmiddleware
+
+import (
+	"context"
+	"errors"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+)
+
+// ErrInsufficientScope is returned when the JWT does not have the scopes required by the method.
+var ErrInsufficientScope = errors.New("jwt has insufficient scope")
+
+// Interceptor is the gRPC server interceptor which validates the access token of the requests.
+// The token is sent in the "authorization" metadata in the "Bearer {token}" format, the validated claims
+// are put in the context of the handler.
+type Interceptor struct {
+	validateToken  ValidateToken
+	skipMethods    map[string]struct{}
+	requiredScopes map[string][]string
+}
+
+// InterceptorOption is the option of the Interceptor.
+type InterceptorOption func(*Interceptor)
+
+// WithSkipMethods sets the methods which are called without the access token, like "/sso.Auth/Login".
+// The methods are the full gRPC method names.
+func WithSkipMethods(methods ...string) InterceptorOption {
+	return func(i *Interceptor) {
+		for _, method := range methods {
+			i.skipMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithRequiredScopes sets the scopes the access token must have to call the method.
+// The method is the full gRPC method name.
+func WithRequiredScopes(method string, scopes ...string) InterceptorOption {
+	return func(i *Interceptor) {
+		i.requiredScopes[method] = append(i.requiredScopes[method], scopes...)
+	}
+}
+
+// NewInterceptor returns new gRPC server interceptor which validates the access tokens by the validateToken,
+// like validator.Validator.ValidateToken.
+func NewInterceptor(validateToken ValidateToken, opts ...InterceptorOption) *Interceptor {
+	i := &Interceptor{
+		validateToken:  validateToken,
+		skipMethods:    make(map[string]struct{}),
+		requiredScopes: make(map[string][]string),
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// Unary returns the unary server interceptor.
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// ClaimsFromContext returns the validated claims of the access token put in the context by the middleware
+// or the interceptor.
+func ClaimsFromContext(ctx context.Context) (jwtclaims.ValidatedClaims, bool) {
+	claims, ok := ctx.Value(ContextKey{}).(jwtclaims.ValidatedClaims)
+
+	return claims, ok
+}
+
+// authenticate validates the access token of the method call, and if successful, returns the context
+// with the validated claims. The errors are the gRPC status errors.
+func (i *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if _, ok := i.skipMethods[method]; ok {
+		return ctx, nil
+	}
+
+	token, err := MetadataTokenExtractor(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "error extracting token: %s", err)
+	}
+
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, ErrJWTMissing.Error())
+	}
+
+	validatedClaims, err := i.validateToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, ErrJWTInvalid.Error())
+	}
+
+	if !hasScopes(validatedClaims.RegisteredClaims.Scope, i.requiredScopes[method]) {
+		return nil, status.Error(codes.PermissionDenied, ErrInsufficientScope.Error())
+	}
+
+	return context.WithValue(ctx, ContextKey{}, validatedClaims), nil
+}
+
+// hasScopes reports whether the space-delimited scope contains all the required scopes.
+func hasScopes(scope string, required []string) bool {
+	granted := strings.Fields(scope)
+	for _, requiredScope := range required {
+		if !slices.Contains(granted, requiredScope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// serverStream is the server stream with the context of the validated claims.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
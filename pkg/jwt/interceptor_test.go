@@ -0,0 +1,194 @@
+package jwtmiddleware
+
+import (
+	"context"
+	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
+	jwtcreator "github.com/p1xray/pxr-sso/pkg/jwt/creator"
+	"github.com/p1xray/pxr-sso/pkg/jwt/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+const (
+	testMethod        = "/test.Service/Method"
+	testSkippedMethod = "/test.Service/Public"
+	testScopedMethod  = "/test.Service/Admin"
+)
+
+func Test_Interceptor_Unary(t *testing.T) {
+	const (
+		key      = "98649a5c-2137-4a78-a63f-fbab416a7f9e"
+		issuer   = "http://localhost:6004"
+		audience = "test"
+	)
+
+	keyFunc := func(context.Context) ([]byte, error) {
+		return []byte(key), nil
+	}
+
+	jwtValidator, err := validator.New(keyFunc, issuer, []string{audience})
+	require.NoError(t, err)
+
+	newToken := func(t *testing.T, key string, scopes ...string) string {
+		t.Helper()
+
+		token, err := jwtcreator.NewAccessToken(jwtcreator.AccessTokenCreateData{
+			Subject:   "1",
+			Audiences: []string{audience},
+			Issuer:    issuer,
+			Scopes:    scopes,
+			TTL:       time.Hour,
+			Key:       []byte(key),
+		})
+		require.NoError(t, err)
+
+		return token
+	}
+
+	interceptor := NewInterceptor(
+		jwtValidator.ValidateToken,
+		WithSkipMethods(testSkippedMethod),
+		WithRequiredScopes(testScopedMethod, "admin.read", "admin.write"),
+	)
+
+	testCases := []struct {
+		name          string
+		method        string
+		authorization string
+		expectedCode  codes.Code
+		expectClaims  bool
+	}{
+		{
+			name:          "successfully validates a token",
+			method:        testMethod,
+			authorization: "Bearer " + newToken(t, key),
+			expectedCode:  codes.OK,
+			expectClaims:  true,
+		},
+		{
+			name:          "successfully validates a token with required scopes",
+			method:        testScopedMethod,
+			authorization: "Bearer " + newToken(t, key, "profile.read", "admin.read", "admin.write"),
+			expectedCode:  codes.OK,
+			expectClaims:  true,
+		},
+		{
+			name:         "skips the method without a token",
+			method:       testSkippedMethod,
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "fails without a token",
+			method:       testMethod,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:          "fails with an invalid authorization format",
+			method:        testMethod,
+			authorization: newToken(t, key),
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "fails with an invalid token",
+			method:        testMethod,
+			authorization: "Bearer " + newToken(t, "05c5328f-17cb-4b42-a085-4089c03b86f8"),
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "fails with an insufficient scope",
+			method:        testScopedMethod,
+			authorization: "Bearer " + newToken(t, key, "admin.read"),
+			expectedCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.authorization))
+			}
+
+			var (
+				claims    jwtclaims.ValidatedClaims
+				hasClaims bool
+			)
+			handler := func(ctx context.Context, _ any) (any, error) {
+				claims, hasClaims = ClaimsFromContext(ctx)
+
+				return "response", nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			response, err := interceptor.Unary()(ctx, "request", info, handler)
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if tc.expectedCode != codes.OK {
+				assert.Nil(t, response)
+
+				return
+			}
+
+			assert.Equal(t, "response", response)
+			assert.Equal(t, tc.expectClaims, hasClaims)
+			if tc.expectClaims {
+				assert.Equal(t, "1", claims.RegisteredClaims.Subject)
+			}
+		})
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func Test_Interceptor_Stream(t *testing.T) {
+	t.Parallel()
+
+	validateToken := func(_ context.Context, token string) (jwtclaims.ValidatedClaims, error) {
+		if token != "valid" {
+			return jwtclaims.ValidatedClaims{}, ErrJWTInvalid
+		}
+
+		return jwtclaims.ValidatedClaims{
+			RegisteredClaims: jwtclaims.AccessTokenClaims{
+				RegisteredCustomClaims: jwtclaims.RegisteredCustomClaims{Scope: "stream.read"},
+			},
+		}, nil
+	}
+
+	interceptor := NewInterceptor(validateToken, WithRequiredScopes(testScopedMethod, "stream.read"))
+	stream := interceptor.Stream()
+
+	newStream := func(authorization string) grpc.ServerStream {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+
+		return &testServerStream{ctx: ctx}
+	}
+
+	var claims jwtclaims.ValidatedClaims
+	handler := func(_ any, ss grpc.ServerStream) error {
+		claims, _ = ClaimsFromContext(ss.Context())
+
+		return nil
+	}
+
+	err := stream(nil, newStream("Bearer valid"), &grpc.StreamServerInfo{FullMethod: testScopedMethod}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "stream.read", claims.RegisteredClaims.Scope)
+
+	err = stream(nil, newStream("Bearer invalid"), &grpc.StreamServerInfo{FullMethod: testScopedMethod}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
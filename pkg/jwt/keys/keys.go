@@ -0,0 +1,202 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"os"
+)
+
+const signatureUse = "sig"
+
+var (
+	ErrReadPrivateKey           = errors.New("error reading private key")
+	ErrDecodePEM                = errors.New("error decoding PEM block")
+	ErrParsePrivateKey          = errors.New("error parsing private key")
+	ErrUnsupportedKeyType       = errors.New("unsupported private key type")
+	ErrUnsupportedAlgorithm     = errors.New("unsupported signature algorithm")
+	ErrAlgorithmKeyTypeMismatch = errors.New("signature algorithm does not match private key type")
+	ErrComputeKeyID             = errors.New("error computing key ID")
+)
+
+// SigningKey is a private key with the algorithm which is used to sign tokens.
+// ID is put into the "kid" header of the signed tokens.
+type SigningKey struct {
+	ID        string
+	Algorithm jose.SignatureAlgorithm
+	Key       crypto.Signer
+}
+
+// NewSigningKey returns new signing key. If the algorithm is empty, it is chosen by the type of the key.
+// The key ID is the RFC 7638 thumbprint of the public key.
+func NewSigningKey(key crypto.Signer, algorithm jose.SignatureAlgorithm) (SigningKey, error) {
+	if algorithm == "" {
+		defaultAlgorithm, err := DefaultAlgorithm(key)
+		if err != nil {
+			return SigningKey{}, err
+		}
+
+		algorithm = defaultAlgorithm
+	}
+
+	if err := validateAlgorithm(key, algorithm); err != nil {
+		return SigningKey{}, err
+	}
+
+	id, err := Thumbprint(key)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{
+		ID:        id,
+		Algorithm: algorithm,
+		Key:       key,
+	}, nil
+}
+
+// LoadSigningKey loads a PEM encoded private key from the file and returns new signing key.
+func LoadSigningKey(path string, algorithm jose.SignatureAlgorithm) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("%w: %w", ErrReadPrivateKey, err)
+	}
+
+	key, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return NewSigningKey(key, algorithm)
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded RSA, ECDSA or Ed25519 private key.
+// PKCS #8, PKCS #1 and SEC 1 encodings are supported.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrDecodePEM
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKeyType
+		}
+
+		return signer, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParsePrivateKey, err)
+	}
+
+	return key, nil
+}
+
+// DefaultAlgorithm returns the signature algorithm used by default for the type of the key.
+func DefaultAlgorithm(key crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+	case ed25519.PrivateKey:
+		return jose.EdDSA, nil
+	}
+
+	return "", ErrUnsupportedKeyType
+}
+
+// Thumbprint returns the base64url encoded RFC 7638 SHA-256 thumbprint of the public part of the key.
+func Thumbprint(key crypto.Signer) (string, error) {
+	jwk := jose.JSONWebKey{Key: key.Public()}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrComputeKeyID, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// JOSE returns the signing key in the form used by the JOSE signer.
+// If the key has an ID, the signer puts it into the "kid" header.
+func (k SigningKey) JOSE() jose.SigningKey {
+	if k.ID == "" {
+		return jose.SigningKey{Algorithm: k.Algorithm, Key: k.Key}
+	}
+
+	return jose.SigningKey{
+		Algorithm: k.Algorithm,
+		Key: jose.JSONWebKey{
+			Key:       k.Key,
+			KeyID:     k.ID,
+			Algorithm: string(k.Algorithm),
+		},
+	}
+}
+
+// PublicJWK returns the public part of the signing key as a JSON Web Key.
+func (k SigningKey) PublicJWK() jose.JSONWebKey {
+	return jose.JSONWebKey{
+		Key:       k.Key.Public(),
+		KeyID:     k.ID,
+		Algorithm: string(k.Algorithm),
+		Use:       signatureUse,
+	}
+}
+
+// PublicKeySet returns a JSON Web Key Set with the public parts of the signing keys.
+func PublicKeySet(keys ...SigningKey) jose.JSONWebKeySet {
+	keySet := jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, len(keys))}
+	for i, key := range keys {
+		keySet.Keys[i] = key.PublicJWK()
+	}
+
+	return keySet
+}
+
+func validateAlgorithm(key crypto.Signer, algorithm jose.SignatureAlgorithm) error {
+	var valid bool
+	switch algorithm {
+	case jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512:
+		_, valid = key.(*rsa.PrivateKey)
+	case jose.ES256, jose.ES384, jose.ES512:
+		var defaultAlgorithm jose.SignatureAlgorithm
+		if _, ok := key.(*ecdsa.PrivateKey); ok {
+			defaultAlgorithm, _ = DefaultAlgorithm(key)
+		}
+		valid = defaultAlgorithm == algorithm
+	case jose.EdDSA:
+		_, valid = key.(ed25519.PrivateKey)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: %s", ErrAlgorithmKeyTypeMismatch, algorithm)
+	}
+
+	return nil
+}
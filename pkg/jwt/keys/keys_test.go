@@ -0,0 +1,186 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_NewSigningKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		key               crypto.Signer
+		algorithm         jose.SignatureAlgorithm
+		expectedAlgorithm jose.SignatureAlgorithm
+		expectedError     error
+	}{
+		{
+			name:              "successfully creates RSA key with default algorithm",
+			key:               rsaKey,
+			expectedAlgorithm: jose.RS256,
+		},
+		{
+			name:              "successfully creates RSA key with PS256 algorithm",
+			key:               rsaKey,
+			algorithm:         jose.PS256,
+			expectedAlgorithm: jose.PS256,
+		},
+		{
+			name:              "successfully creates ECDSA key with default algorithm",
+			key:               ecdsaKey,
+			expectedAlgorithm: jose.ES256,
+		},
+		{
+			name:              "successfully creates Ed25519 key with default algorithm",
+			key:               ed25519Key,
+			expectedAlgorithm: jose.EdDSA,
+		},
+		{
+			name:          "throws an error when algorithm does not match RSA key",
+			key:           rsaKey,
+			algorithm:     jose.ES256,
+			expectedError: ErrAlgorithmKeyTypeMismatch,
+		},
+		{
+			name:          "throws an error when curve does not match ECDSA algorithm",
+			key:           ecdsaKey,
+			algorithm:     jose.ES384,
+			expectedError: ErrAlgorithmKeyTypeMismatch,
+		},
+		{
+			name:          "throws an error when algorithm is symmetric",
+			key:           rsaKey,
+			algorithm:     jose.HS256,
+			expectedError: ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signingKey, err := NewSigningKey(tc.key, tc.algorithm)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedAlgorithm, signingKey.Algorithm)
+				assert.NotEmpty(t, signingKey.ID)
+
+				jwk := signingKey.PublicJWK()
+				assert.True(t, jwk.IsPublic())
+				assert.True(t, jwk.Valid())
+				assert.Equal(t, string(tc.expectedAlgorithm), jwk.Algorithm)
+				assert.Equal(t, "sig", jwk.Use)
+				assert.Equal(t, signingKey.ID, jwk.KeyID)
+			}
+		})
+	}
+}
+
+func Test_LoadSigningKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8RSA, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+
+	sec1ECDSA, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+
+	pkcs8Ed25519, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		pem               []byte
+		expectedAlgorithm jose.SignatureAlgorithm
+		expectedError     error
+	}{
+		{
+			name:              "successfully loads PKCS #8 RSA key",
+			pem:               pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8RSA}),
+			expectedAlgorithm: jose.RS256,
+		},
+		{
+			name: "successfully loads PKCS #1 RSA key",
+			pem: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+			}),
+			expectedAlgorithm: jose.RS256,
+		},
+		{
+			name:              "successfully loads SEC 1 ECDSA key",
+			pem:               pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1ECDSA}),
+			expectedAlgorithm: jose.ES256,
+		},
+		{
+			name:              "successfully loads PKCS #8 Ed25519 key",
+			pem:               pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Ed25519}),
+			expectedAlgorithm: jose.EdDSA,
+		},
+		{
+			name:          "throws an error when file is not PEM encoded",
+			pem:           []byte("invalid key"),
+			expectedError: ErrDecodePEM,
+		},
+		{
+			name:          "throws an error when PEM block is not a private key",
+			pem:           pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("invalid key")}),
+			expectedError: ErrParsePrivateKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "key.pem")
+			require.NoError(t, os.WriteFile(path, tc.pem, 0o600))
+
+			signingKey, err := LoadSigningKey(path, "")
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedAlgorithm, signingKey.Algorithm)
+			}
+		})
+	}
+
+	t.Run("throws an error when file does not exist", func(t *testing.T) {
+		_, err := LoadSigningKey(filepath.Join(t.TempDir(), "missing.pem"), "")
+		assert.ErrorIs(t, err, ErrReadPrivateKey)
+	})
+}
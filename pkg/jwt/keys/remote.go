@@ -0,0 +1,88 @@
+package jwtkeys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultRemoteKeySetTTL = 10 * time.Minute
+
+var (
+	ErrFetchKeySet = errors.New("error fetching key set")
+)
+
+// RemoteKeySet is a JSON Web Key Set fetched from the JWKS endpoint of the SSO.
+// Fetched keys are cached for the configured time.
+type RemoteKeySet struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+
+	mu        sync.Mutex
+	keySet    jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// NewRemoteKeySet returns new remote key set for the JWKS endpoint URL.
+// If ttl is zero, the keys are cached for 10 minutes.
+func NewRemoteKeySet(url string, ttl time.Duration) *RemoteKeySet {
+	if ttl == 0 {
+		ttl = defaultRemoteKeySetTTL
+	}
+
+	return &RemoteKeySet{
+		url:    url,
+		ttl:    ttl,
+		client: http.DefaultClient,
+	}
+}
+
+// KeySet returns the cached key set, fetching it from the JWKS endpoint if the cache is expired.
+func (r *RemoteKeySet) KeySet(ctx context.Context) (jose.JSONWebKeySet, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.fetchedAt.IsZero() && time.Since(r.fetchedAt) < r.ttl {
+		return r.keySet, nil
+	}
+
+	keySet, err := r.fetch(ctx)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("%w: %w", ErrFetchKeySet, err)
+	}
+
+	r.keySet = keySet
+	r.fetchedAt = time.Now()
+
+	return keySet, nil
+}
+
+func (r *RemoteKeySet) fetch(ctx context.Context) (jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return jose.JSONWebKeySet{}, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err = json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+
+	return keySet, nil
+}
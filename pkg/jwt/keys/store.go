@@ -0,0 +1,168 @@
+package jwtkeys
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrEmptyKeyID       = errors.New("key ID is required")
+	ErrDuplicateKeyID   = errors.New("key with the same ID already exists")
+	ErrInvalidKeyPeriod = errors.New("key retirement time must be after its activation time")
+	ErrNoActiveKey      = errors.New("no active signing key")
+)
+
+// StoredKey is a signing key held by the key store with its validity period.
+type StoredKey struct {
+	SigningKey
+
+	// ActivatesAt is the time from which new tokens are signed with the key.
+	ActivatesAt time.Time
+	// RetiresAt is the time from which new tokens are no longer signed with the key.
+	// Zero time means the key is never retired.
+	RetiresAt time.Time
+}
+
+// Store is a thread-safe store of signing keys which supports key rotation.
+//
+// New tokens are signed with the active key: the key with the latest activation time among the keys
+// whose validity period contains the current time. The public part of a key is published from the moment
+// the key is added to the store, so verifiers learn about the key before it is activated,
+// and until the verification period passes after its retirement, so tokens signed before the retirement
+// remain verifiable.
+type Store struct {
+	mu                 sync.RWMutex
+	keys               []StoredKey
+	verificationPeriod time.Duration
+	now                func() time.Time
+}
+
+// NewStore returns new key store with the keys.
+// The verification period is usually the lifetime of the tokens signed with the keys.
+func NewStore(verificationPeriod time.Duration, keys ...StoredKey) (*Store, error) {
+	store := &Store{
+		keys:               make([]StoredKey, 0, len(keys)),
+		verificationPeriod: verificationPeriod,
+		now:                time.Now,
+	}
+
+	for _, key := range keys {
+		if err := store.Add(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// Add adds the key to the store.
+func (s *Store) Add(key StoredKey) error {
+	if key.ID == "" {
+		return ErrEmptyKeyID
+	}
+
+	if !key.RetiresAt.IsZero() && !key.RetiresAt.After(key.ActivatesAt) {
+		return fmt.Errorf("%w: %s", ErrInvalidKeyPeriod, key.ID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, storedKey := range s.keys {
+		if storedKey.ID == key.ID {
+			return fmt.Errorf("%w: %s", ErrDuplicateKeyID, key.ID)
+		}
+	}
+
+	s.keys = append(s.keys, key)
+	sort.SliceStable(s.keys, func(i, j int) bool {
+		return s.keys[i].ActivatesAt.After(s.keys[j].ActivatesAt)
+	})
+
+	return nil
+}
+
+// ActiveKey returns the key for signing new tokens.
+// If the store is nil or has no keys, nil is returned and tokens are signed with the client secret keys.
+func (s *Store) ActiveKey() (*SigningKey, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.keys) == 0 {
+		return nil, nil
+	}
+
+	now := s.now()
+	for _, key := range s.keys {
+		if key.isActive(now) {
+			signingKey := key.SigningKey
+			return &signingKey, nil
+		}
+	}
+
+	return nil, ErrNoActiveKey
+}
+
+// HasKeys reports whether the store has keys. If it has, tokens are never signed with the client secret keys.
+func (s *Store) HasKeys() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.keys) > 0
+}
+
+// PublicKeySet returns a JSON Web Key Set with the public parts of the keys which can be used for verifying tokens.
+func (s *Store) PublicKeySet() jose.JSONWebKeySet {
+	keySet := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	if s == nil {
+		return keySet
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	for _, key := range s.keys {
+		if key.isPublished(now, s.verificationPeriod) {
+			keySet.Keys = append(keySet.Keys, key.PublicJWK())
+		}
+	}
+
+	return keySet
+}
+
+// Algorithms returns the signature algorithms of the keys which can be used for verifying tokens.
+func (s *Store) Algorithms() []jose.SignatureAlgorithm {
+	keySet := s.PublicKeySet()
+
+	algorithms := make([]jose.SignatureAlgorithm, 0, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		algorithm := jose.SignatureAlgorithm(key.Algorithm)
+		if !slices.Contains(algorithms, algorithm) {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	return algorithms
+}
+
+func (k StoredKey) isActive(now time.Time) bool {
+	return !now.Before(k.ActivatesAt) && (k.RetiresAt.IsZero() || now.Before(k.RetiresAt))
+}
+
+func (k StoredKey) isPublished(now time.Time, verificationPeriod time.Duration) bool {
+	return k.RetiresAt.IsZero() || now.Before(k.RetiresAt.Add(verificationPeriod))
+}
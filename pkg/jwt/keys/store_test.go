@@ -0,0 +1,179 @@
+package jwtkeys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_NewStore(t *testing.T) {
+	key := newTestSigningKey(t)
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		keys          []StoredKey
+		expectedError error
+	}{
+		{
+			name: "successfully creates a store",
+			keys: []StoredKey{
+				{SigningKey: key, ActivatesAt: now, RetiresAt: now.Add(time.Hour)},
+			},
+		},
+		{
+			name: "successfully creates an empty store",
+		},
+		{
+			name: "throws an error when the key ID is empty",
+			keys: []StoredKey{
+				{SigningKey: SigningKey{Algorithm: key.Algorithm, Key: key.Key}, ActivatesAt: now},
+			},
+			expectedError: ErrEmptyKeyID,
+		},
+		{
+			name: "throws an error when the key IDs are duplicated",
+			keys: []StoredKey{
+				{SigningKey: key, ActivatesAt: now},
+				{SigningKey: key, ActivatesAt: now.Add(time.Hour)},
+			},
+			expectedError: ErrDuplicateKeyID,
+		},
+		{
+			name: "throws an error when the key is retired before activation",
+			keys: []StoredKey{
+				{SigningKey: key, ActivatesAt: now, RetiresAt: now.Add(-time.Hour)},
+			},
+			expectedError: ErrInvalidKeyPeriod,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewStore(time.Hour, tc.keys...)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Store_Rotation(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	oldKey := newTestSigningKey(t)
+	newKey := newTestSigningKey(t)
+	nextKey := newTestSigningKey(t)
+
+	store, err := NewStore(
+		time.Hour,
+		StoredKey{SigningKey: oldKey, ActivatesAt: start, RetiresAt: start.Add(24 * time.Hour)},
+		StoredKey{SigningKey: newKey, ActivatesAt: start.Add(24 * time.Hour)},
+		StoredKey{SigningKey: nextKey, ActivatesAt: start.Add(48 * time.Hour)},
+	)
+	require.NoError(t, err)
+	assert.True(t, store.HasKeys())
+
+	testCases := []struct {
+		name              string
+		now               time.Time
+		expectedActiveKey string
+		expectedKeyIDs    []string
+		expectedError     error
+	}{
+		{
+			name:           "throws an error when no key is active yet",
+			now:            start.Add(-time.Minute),
+			expectedKeyIDs: []string{oldKey.ID, newKey.ID, nextKey.ID},
+			expectedError:  ErrNoActiveKey,
+		},
+		{
+			name:              "signs with the first key and publishes the upcoming keys",
+			now:               start.Add(time.Hour),
+			expectedActiveKey: oldKey.ID,
+			expectedKeyIDs:    []string{oldKey.ID, newKey.ID, nextKey.ID},
+		},
+		{
+			name:              "signs with the new key and publishes the retired key during the verification period",
+			now:               start.Add(24*time.Hour + 30*time.Minute),
+			expectedActiveKey: newKey.ID,
+			expectedKeyIDs:    []string{oldKey.ID, newKey.ID, nextKey.ID},
+		},
+		{
+			name:              "stops publishing the retired key after the verification period",
+			now:               start.Add(26 * time.Hour),
+			expectedActiveKey: newKey.ID,
+			expectedKeyIDs:    []string{newKey.ID, nextKey.ID},
+		},
+		{
+			name:              "signs with the latest activated key",
+			now:               start.Add(48 * time.Hour),
+			expectedActiveKey: nextKey.ID,
+			expectedKeyIDs:    []string{newKey.ID, nextKey.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store.mu.Lock()
+			store.now = func() time.Time { return tc.now }
+			store.mu.Unlock()
+
+			activeKey, err := store.ActiveKey()
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, activeKey)
+				assert.Equal(t, tc.expectedActiveKey, activeKey.ID)
+			}
+
+			keySet := store.PublicKeySet()
+			keyIDs := make([]string, 0, len(keySet.Keys))
+			for _, key := range keySet.Keys {
+				assert.True(t, key.IsPublic())
+				keyIDs = append(keyIDs, key.KeyID)
+			}
+			assert.ElementsMatch(t, tc.expectedKeyIDs, keyIDs)
+			assert.Equal(t, []jose.SignatureAlgorithm{jose.ES256}, store.Algorithms())
+		})
+	}
+}
+
+func Test_Store_Empty(t *testing.T) {
+	var nilStore *Store
+
+	emptyStore, err := NewStore(time.Hour)
+	require.NoError(t, err)
+
+	for _, store := range []*Store{nilStore, emptyStore} {
+		activeKey, err := store.ActiveKey()
+		require.NoError(t, err)
+		assert.Nil(t, activeKey)
+		assert.False(t, store.HasKeys())
+
+		assert.Empty(t, store.PublicKeySet().Keys)
+		assert.Empty(t, store.Algorithms())
+	}
+}
+
+func newTestSigningKey(t *testing.T) SigningKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signingKey, err := NewSigningKey(key, "")
+	require.NoError(t, err)
+
+	return signingKey
+}
@@ -12,25 +12,27 @@ var (
 	ErrParseToken             = errors.New("error parsing token")
 	ErrParseTokenClaims       = errors.New("error getting token claims")
 	ErrParseTokenCustomClaims = errors.New("error getting token custom claims")
+	ErrKeyNotFound            = errors.New("no key in the key set matches the token")
 )
 
-// ParseAccessToken parses access token using a secret key into a set of claims.
+// ParseAccessToken parses access token using a key into a set of claims.
+// The key is a secret key for HMAC signed tokens or a public key for asymmetrically signed tokens.
 func ParseAccessToken(
 	token *jwt.JSONWebToken,
-	secretKey []byte,
+	key interface{},
 	customClaimsFunc func() jwtclaims.CustomClaims,
 ) (jwtclaims.AccessTokenClaims, jwtclaims.CustomClaims, error) {
 	defaultClaims := jwt.Claims{}
 	registeredCustomClaims := jwtclaims.RegisteredCustomClaims{}
 	var customClaims jwtclaims.CustomClaims
 
-	if err := token.Claims(secretKey, &defaultClaims, &registeredCustomClaims); err != nil {
+	if err := token.Claims(key, &defaultClaims, &registeredCustomClaims); err != nil {
 		return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrParseTokenClaims, err)
 	}
 
 	if customClaimsExist(customClaimsFunc) {
 		customClaims = customClaimsFunc()
-		if err := token.Claims(secretKey, &customClaims); err != nil {
+		if err := token.Claims(key, &customClaims); err != nil {
 			return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrParseTokenCustomClaims, err)
 		}
 	}
@@ -43,6 +45,35 @@ func ParseAccessToken(
 	return registeredClaims, customClaims, nil
 }
 
+// ParseAccessTokenWithKeySet parses access token using the public keys of the key set into a set of claims.
+// Every key of the key set suitable for the token signature algorithm and key ID is tried.
+func ParseAccessTokenWithKeySet(
+	token *jwt.JSONWebToken,
+	keySet jose.JSONWebKeySet,
+	customClaimsFunc func() jwtclaims.CustomClaims,
+) (jwtclaims.AccessTokenClaims, jwtclaims.CustomClaims, error) {
+	signatureAlgorithm, err := ParseSignatureAlgorithm(token)
+	if err != nil {
+		return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrParseToken, err)
+	}
+
+	candidates := VerificationKeys(keySet, signatureAlgorithm, ParseKeyID(token))
+	if len(candidates) == 0 {
+		return jwtclaims.AccessTokenClaims{}, nil, ErrKeyNotFound
+	}
+
+	for _, candidate := range candidates {
+		registeredClaims, customClaims, parseErr := ParseAccessToken(token, candidate.Key, customClaimsFunc)
+		if parseErr == nil {
+			return registeredClaims, customClaims, nil
+		}
+
+		err = parseErr
+	}
+
+	return jwtclaims.AccessTokenClaims{}, nil, err
+}
+
 // ParseRefreshToken parses refresh token as a string using a secret key into a set of claims.
 func ParseRefreshToken(tokenStr string, secretKey []byte) (jwtclaims.RefreshTokenClaims, error) {
 	token, err := jwt.ParseSigned(tokenStr, []jose.SignatureAlgorithm{jose.HS256})
@@ -72,6 +103,42 @@ func ParseSignatureAlgorithm(token *jwt.JSONWebToken) (jose.SignatureAlgorithm,
 	return jose.SignatureAlgorithm(signatureAlgorithm), nil
 }
 
+// ParseKeyID parses key ID from token header. If the token has no key ID, an empty string is returned.
+func ParseKeyID(token *jwt.JSONWebToken) string {
+	if len(token.Headers) == 0 {
+		return ""
+	}
+
+	return token.Headers[0].KeyID
+}
+
+// VerificationKeys returns the public keys of the key set suitable for the token signature algorithm.
+// If the token has a key ID, only the keys with the same ID are returned.
+func VerificationKeys(
+	keySet jose.JSONWebKeySet,
+	signatureAlgorithm jose.SignatureAlgorithm,
+	keyID string,
+) []jose.JSONWebKey {
+	keys := make([]jose.JSONWebKey, 0, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if keyID != "" && key.KeyID != keyID {
+			continue
+		}
+
+		if key.Algorithm != "" && key.Algorithm != string(signatureAlgorithm) {
+			continue
+		}
+
+		if !key.IsPublic() {
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
 func customClaimsExist(customClaimsFunc func() jwtclaims.CustomClaims) bool {
 	return customClaimsFunc != nil && customClaimsFunc() != nil
 }
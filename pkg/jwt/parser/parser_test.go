@@ -2,6 +2,9 @@ package jwtparser
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/google/go-cmp/cmp"
@@ -202,3 +205,91 @@ func Test_ParseRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseAccessTokenWithKeySet(t *testing.T) {
+	now := time.Now()
+	tokenClaims := jwtclaims.AccessTokenClaims{
+		Claims: jwt.Claims{
+			ID:        "5f7a093e-9301-4fb5-9eeb-c7b529f16ce8",
+			Subject:   "1",
+			Issuer:    "testIssuer",
+			Audience:  []string{"testAudience"},
+			Expiry:    jwt.NewNumericDate(now.Add(time.Hour)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	signingKey := newTestECDSAKey(t)
+	otherKey := newTestECDSAKey(t)
+
+	publicKey := func(key *ecdsa.PrivateKey, keyID string) jose.JSONWebKey {
+		return jose.JSONWebKey{Key: key.Public(), KeyID: keyID, Algorithm: string(jose.ES256), Use: "sig"}
+	}
+
+	testCases := []struct {
+		name          string
+		keySet        jose.JSONWebKeySet
+		expectedError error
+	}{
+		{
+			name:   "successfully parse a token",
+			keySet: jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey(signingKey, "key1")}},
+		},
+		{
+			name: "successfully parse a token when the key set has several keys",
+			keySet: jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				publicKey(otherKey, "key2"),
+				publicKey(signingKey, "key1"),
+			}},
+		},
+		{
+			name:          "throws an error if no key matches the key ID",
+			keySet:        jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey(signingKey, "key2")}},
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "throws an error if the key does not verify the signature",
+			keySet:        jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey(otherKey, "key1")}},
+			expectedError: ErrParseTokenClaims,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sig, err := jose.NewSigner(
+				jose.SigningKey{Algorithm: jose.ES256, Key: jose.JSONWebKey{Key: signingKey, KeyID: "key1"}},
+				(&jose.SignerOptions{}).WithType("JWT"))
+			require.NoError(t, err)
+
+			tokenStr, err := jwt.Signed(sig).Claims(tokenClaims).Serialize()
+			require.NoError(t, err)
+
+			token, err := jwt.ParseSigned(tokenStr, []jose.SignatureAlgorithm{jose.ES256})
+			require.NoError(t, err)
+
+			registeredClaims, _, err := ParseAccessTokenWithKeySet(token, tc.keySet, nil)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+
+				if !cmp.Equal(tokenClaims, registeredClaims) {
+					t.Fatal(cmp.Diff(tokenClaims, registeredClaims))
+				}
+			}
+		})
+	}
+}
+
+func newTestECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
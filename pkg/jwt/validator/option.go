@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"github.com/go-jose/go-jose/v4"
 	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
 	"time"
 )
@@ -25,3 +26,12 @@ func WithAllowedClockSkew(d time.Duration) Option {
 		v.allowedClockSkew = d
 	}
 }
+
+// WithSignatureAlgorithms is an option which sets up the signature algorithms accepted by the validator.
+// If this option is not used the validator created by New accepts only HS256
+// and the validator created by NewWithKeySet accepts RS256, ES256 and EdDSA.
+func WithSignatureAlgorithms(algorithms ...jose.SignatureAlgorithm) Option {
+	return func(v *Validator) {
+		v.signatureAlgorithms = algorithms
+	}
+}
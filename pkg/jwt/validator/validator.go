@@ -14,6 +14,7 @@ import (
 
 var (
 	ErrEmptyKeyFunc              = errors.New("keyFunc is required")
+	ErrEmptySignatureAlgorithms  = errors.New("at least one signature algorithm is required")
 	ErrEmptyIssuer               = errors.New("issuer is required")
 	ErrEmptyAudience             = errors.New("audience is required")
 	ErrParsingToken              = errors.New("error parsing token")
@@ -23,18 +24,22 @@ var (
 	ErrValidatingClaims          = errors.New("error validating token claims")
 	ErrValidatingCustomClaims    = errors.New("error validating  token custom claims")
 	ErrGettingKey                = errors.New("error getting key")
+	ErrKeyNotFound               = errors.New("no key in the key set matches the token")
 )
 
+// defaultKeySetAlgorithms are the signature algorithms accepted by default by the validator with a key set.
+var defaultKeySetAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.ES256, jose.EdDSA}
+
 // Validator is used to validate JWT.
 type Validator struct {
-	keyFunc            func(context.Context) ([]byte, error)
-	signatureAlgorithm jose.SignatureAlgorithm
-	expectedClaims     jwt.Expected
-	customClaims       func() jwtclaims.CustomClaims
-	allowedClockSkew   time.Duration
+	keyFunc             func(context.Context) (interface{}, error)
+	signatureAlgorithms []jose.SignatureAlgorithm
+	expectedClaims      jwt.Expected
+	customClaims        func() jwtclaims.CustomClaims
+	allowedClockSkew    time.Duration
 }
 
-// New returns new JWT validator instance.
+// New returns new JWT validator instance for tokens signed by HS256 with a secret key.
 func New(
 	keyFunc func(context.Context) ([]byte, error),
 	issuer string,
@@ -45,6 +50,40 @@ func New(
 		return nil, ErrEmptyKeyFunc
 	}
 
+	secretKeyFunc := func(ctx context.Context) (interface{}, error) {
+		return keyFunc(ctx)
+	}
+
+	return newValidator(secretKeyFunc, []jose.SignatureAlgorithm{jose.HS256}, issuer, audience, options...)
+}
+
+// NewWithKeySet returns new JWT validator instance for tokens signed asymmetrically (RS256, ES256, EdDSA).
+// The token is verified by the public keys of the key set, e.g. fetched from the JWKS endpoint of the SSO.
+// Accepted signature algorithms can be changed by the WithSignatureAlgorithms option.
+func NewWithKeySet(
+	keySetFunc func(context.Context) (jose.JSONWebKeySet, error),
+	issuer string,
+	audience []string,
+	options ...Option,
+) (*Validator, error) {
+	if keySetFunc == nil {
+		return nil, ErrEmptyKeyFunc
+	}
+
+	keyFunc := func(ctx context.Context) (interface{}, error) {
+		return keySetFunc(ctx)
+	}
+
+	return newValidator(keyFunc, defaultKeySetAlgorithms, issuer, audience, options...)
+}
+
+func newValidator(
+	keyFunc func(context.Context) (interface{}, error),
+	signatureAlgorithms []jose.SignatureAlgorithm,
+	issuer string,
+	audience []string,
+	options ...Option,
+) (*Validator, error) {
 	if issuer == "" {
 		return nil, ErrEmptyIssuer
 	}
@@ -54,8 +93,8 @@ func New(
 	}
 
 	validator := &Validator{
-		keyFunc:            keyFunc,
-		signatureAlgorithm: jose.HS256,
+		keyFunc:             keyFunc,
+		signatureAlgorithms: signatureAlgorithms,
 		expectedClaims: jwt.Expected{
 			Issuer:      issuer,
 			AnyAudience: audience,
@@ -66,12 +105,16 @@ func New(
 		opt(validator)
 	}
 
+	if len(validator.signatureAlgorithms) == 0 {
+		return nil, ErrEmptySignatureAlgorithms
+	}
+
 	return validator, nil
 }
 
 // ValidateToken validates the passed token and returns the validated claims from the token.
 func (v *Validator) ValidateToken(ctx context.Context, tokenString string) (jwtclaims.ValidatedClaims, error) {
-	token, err := jwt.ParseSigned(tokenString, []jose.SignatureAlgorithm{v.signatureAlgorithm})
+	token, err := jwt.ParseSigned(tokenString, v.signatureAlgorithms)
 	if err != nil {
 		return jwtclaims.ValidatedClaims{}, fmt.Errorf("%w: %w", ErrParsingToken, err)
 	}
@@ -81,7 +124,7 @@ func (v *Validator) ValidateToken(ctx context.Context, tokenString string) (jwtc
 		return jwtclaims.ValidatedClaims{}, fmt.Errorf("%w: %w", ErrParsingSignatureAlgorithm, err)
 	}
 
-	if err = validateSigningMethod(v.signatureAlgorithm, signatureAlgorithm); err != nil {
+	if err = validateSigningMethod(v.signatureAlgorithms, signatureAlgorithm); err != nil {
 		return jwtclaims.ValidatedClaims{}, fmt.Errorf("%w: %w", ErrInvalidSigningMethod, err)
 	}
 
@@ -117,19 +160,36 @@ func (v *Validator) parseClaims(
 		return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrGettingKey, err)
 	}
 
-	registeredClaims, customClaims, err := jwtparser.ParseAccessToken(token, key, v.customClaims)
+	keySet, ok := key.(jose.JSONWebKeySet)
+	if !ok {
+		registeredClaims, customClaims, err := jwtparser.ParseAccessToken(token, key, v.customClaims)
+		if err != nil {
+			return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrParsingToken, err)
+		}
+
+		return registeredClaims, customClaims, nil
+	}
+
+	registeredClaims, customClaims, err := jwtparser.ParseAccessTokenWithKeySet(token, keySet, v.customClaims)
 	if err != nil {
+		if errors.Is(err, jwtparser.ErrKeyNotFound) {
+			return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrKeyNotFound, err)
+		}
+
 		return jwtclaims.AccessTokenClaims{}, nil, fmt.Errorf("%w: %w", ErrParsingToken, err)
 	}
 
 	return registeredClaims, customClaims, nil
 }
 
-func validateSigningMethod(validAlgorithmName, tokenAlgorithmName jose.SignatureAlgorithm) error {
-	if validAlgorithmName != tokenAlgorithmName {
-		return fmt.Errorf("expected %q signing algorithm but token specified %q", validAlgorithmName, tokenAlgorithmName)
+func validateSigningMethod(validAlgorithms []jose.SignatureAlgorithm, tokenAlgorithmName jose.SignatureAlgorithm) error {
+	for _, validAlgorithm := range validAlgorithms {
+		if validAlgorithm == tokenAlgorithmName {
+			return nil
+		}
 	}
-	return nil
+
+	return fmt.Errorf("expected one of %q signing algorithms but token specified %q", validAlgorithms, tokenAlgorithmName)
 }
 
 func validateClaimsWithLeeway(claims jwt.Claims, expected jwt.Expected, leeway time.Duration) error {
@@ -2,7 +2,13 @@ package validator
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/go-jose/go-jose/v4/jwt"
 	jwtclaims "github.com/p1xray/pxr-sso/pkg/jwt/claims"
 	"github.com/stretchr/testify/assert"
@@ -48,6 +54,123 @@ func Test_New(t *testing.T) {
 		_, err := New(validKeyFunc, issuer, []string{})
 		assert.EqualError(t, err, ErrEmptyAudience.Error())
 	})
+
+	t.Run("throws an error when the signature algorithms are empty", func(t *testing.T) {
+		_, err := New(validKeyFunc, issuer, []string{audience}, WithSignatureAlgorithms())
+		assert.EqualError(t, err, ErrEmptySignatureAlgorithms.Error())
+	})
+}
+
+func Test_NewWithKeySet(t *testing.T) {
+	t.Run("throws an error when the keySetFunc is nil", func(t *testing.T) {
+		_, err := NewWithKeySet(nil, issuer, []string{audience})
+		assert.EqualError(t, err, ErrEmptyKeyFunc.Error())
+	})
+
+	t.Run("throws an error when the issuer is empty", func(t *testing.T) {
+		_, err := NewWithKeySet(emptyKeySetFunc, "", []string{audience})
+		assert.EqualError(t, err, ErrEmptyIssuer.Error())
+	})
+
+	t.Run("throws an error when the audience is empty", func(t *testing.T) {
+		_, err := NewWithKeySet(emptyKeySetFunc, issuer, []string{})
+		assert.EqualError(t, err, ErrEmptyAudience.Error())
+	})
+}
+
+func Test_ValidateToken_WithKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	anotherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keySet := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: rsaKey.Public(), Algorithm: string(jose.RS256), Use: "sig"},
+			{Key: ecdsaKey.Public(), Algorithm: string(jose.ES256), Use: "sig"},
+			{Key: ed25519PublicKey, Algorithm: string(jose.EdDSA), Use: "sig"},
+		},
+	}
+	keySetFunc := func(context.Context) (jose.JSONWebKeySet, error) {
+		return keySet, nil
+	}
+
+	expectedClaims := jwt.Claims{
+		Subject:  "1",
+		Issuer:   issuer,
+		Audience: []string{audience},
+	}
+
+	testCases := []struct {
+		name          string
+		signingKey    jose.SigningKey
+		keySetFunc    func(context.Context) (jose.JSONWebKeySet, error)
+		expectedError error
+	}{
+		{
+			name:       "successfully validates a token signed by RSA key",
+			signingKey: jose.SigningKey{Algorithm: jose.RS256, Key: rsaKey},
+			keySetFunc: keySetFunc,
+		},
+		{
+			name:       "successfully validates a token signed by ECDSA key",
+			signingKey: jose.SigningKey{Algorithm: jose.ES256, Key: ecdsaKey},
+			keySetFunc: keySetFunc,
+		},
+		{
+			name:       "successfully validates a token signed by Ed25519 key",
+			signingKey: jose.SigningKey{Algorithm: jose.EdDSA, Key: ed25519Key},
+			keySetFunc: keySetFunc,
+		},
+		{
+			name:          "throws an error when the token is signed by a key not from the key set",
+			signingKey:    jose.SigningKey{Algorithm: jose.RS256, Key: anotherRSAKey},
+			keySetFunc:    keySetFunc,
+			expectedError: ErrParsingToken,
+		},
+		{
+			name:          "throws an error when the token is signed by HMAC",
+			signingKey:    jose.SigningKey{Algorithm: jose.HS256, Key: []byte("05c5328f-17cb-4b42-a085-4089c03b86f8")},
+			keySetFunc:    keySetFunc,
+			expectedError: ErrParsingToken,
+		},
+		{
+			name:          "throws an error when the key set is empty",
+			signingKey:    jose.SigningKey{Algorithm: jose.RS256, Key: rsaKey},
+			keySetFunc:    emptyKeySetFunc,
+			expectedError: ErrKeyNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sig, err := jose.NewSigner(tc.signingKey, (&jose.SignerOptions{}).WithType("JWT"))
+			require.NoError(t, err)
+
+			token, err := jwt.Signed(sig).Claims(expectedClaims).Serialize()
+			require.NoError(t, err)
+
+			validator, err := NewWithKeySet(tc.keySetFunc, issuer, []string{audience})
+			require.NoError(t, err)
+
+			tokenClaims, err := validator.ValidateToken(context.Background(), token)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, expectedClaims, tokenClaims.RegisteredClaims.Claims)
+			}
+		})
+	}
 }
 
 func Test_ValidateToken(t *testing.T) {
@@ -295,6 +418,90 @@ func invalidKeyFunc(context.Context) ([]byte, error) {
 	return []byte("fae35d9e-3696-499d-ae4a-9786b4273e68"), nil
 }
 
+func Test_ValidateToken_WithKeyID(t *testing.T) {
+	firstKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	secondKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keySetFunc := func(context.Context) (jose.JSONWebKeySet, error) {
+		return jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{
+				{Key: firstKey.Public(), KeyID: "first", Algorithm: string(jose.ES256), Use: "sig"},
+				{Key: secondKey.Public(), KeyID: "second", Algorithm: string(jose.ES256), Use: "sig"},
+			},
+		}, nil
+	}
+
+	expectedClaims := jwt.Claims{
+		Subject:  "1",
+		Issuer:   issuer,
+		Audience: []string{audience},
+	}
+
+	testCases := []struct {
+		name          string
+		key           *ecdsa.PrivateKey
+		keyID         string
+		expectedError error
+	}{
+		{
+			name:  "successfully validates a token signed by the first key",
+			key:   firstKey,
+			keyID: "first",
+		},
+		{
+			name:  "successfully validates a token signed by the second key",
+			key:   secondKey,
+			keyID: "second",
+		},
+		{
+			name:          "throws an error when the key ID is unknown",
+			key:           firstKey,
+			keyID:         "unknown",
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "throws an error when the token is signed by another key than the key ID points to",
+			key:           firstKey,
+			keyID:         "second",
+			expectedError: ErrParsingToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			signingKey := jose.SigningKey{
+				Algorithm: jose.ES256,
+				Key:       jose.JSONWebKey{Key: tc.key, KeyID: tc.keyID},
+			}
+			sig, err := jose.NewSigner(signingKey, (&jose.SignerOptions{}).WithType("JWT"))
+			require.NoError(t, err)
+
+			token, err := jwt.Signed(sig).Claims(expectedClaims).Serialize()
+			require.NoError(t, err)
+
+			validator, err := NewWithKeySet(keySetFunc, issuer, []string{audience})
+			require.NoError(t, err)
+
+			tokenClaims, err := validator.ValidateToken(context.Background(), token)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, expectedClaims, tokenClaims.RegisteredClaims.Claims)
+			}
+		})
+	}
+}
+
 func keyFuncReturnsNil(context.Context) ([]byte, error) {
 	return nil, ErrGettingKey
 }
+
+func emptyKeySetFunc(context.Context) (jose.JSONWebKeySet, error) {
+	return jose.JSONWebKeySet{}, nil
+}
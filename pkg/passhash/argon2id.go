@@ -0,0 +1,142 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// argon2idPrefix is the prefix of the argon2id hashes in the PHC string format.
+const argon2idPrefix = "$argon2id$"
+
+// encoding is the encoding of the salt and the key of the argon2id hashes.
+var encoding = base64.RawStdEncoding
+
+// DefaultArgon2id is the argon2id hasher with the second recommended parameters of RFC 9106.
+var DefaultArgon2id = Argon2id{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2id is the hasher of the argon2id algorithm. Memory is in KiB. The hashes are encoded in the PHC string format,
+// like "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>".
+type Argon2id struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// argon2idHash is the decoded argon2id hash.
+type argon2idHash struct {
+	params Argon2id
+	salt   []byte
+	key    []byte
+}
+
+// Validate checks the parameters of the hasher. The memory must be at least 8 KiB per lane,
+// the salt must be at least 8 bytes long and the key at least 4 bytes long (RFC 9106, section 3.1).
+func (h Argon2id) Validate() error {
+	if h.Parallelism == 0 || h.Iterations == 0 || h.Memory < 8*uint32(h.Parallelism) ||
+		h.SaltLength < 8 || h.KeyLength < 4 {
+		return fmt.Errorf("%w: argon2id m=%d,t=%d,p=%d", ErrInvalidParams, h.Memory, h.Iterations, h.Parallelism)
+	}
+
+	return nil
+}
+
+// Hash returns the encoded argon2id hash of the password with the random salt.
+func (h Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.Memory,
+		h.Iterations,
+		h.Parallelism,
+		encoding.EncodeToString(salt),
+		encoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks the password matches the encoded hash of any supported algorithm.
+func (h Argon2id) Verify(encodedHash, password string) error {
+	return Verify(encodedHash, password)
+}
+
+// NeedsRehash reports whether the encoded hash is not the argon2id hash with the parameters of the hasher.
+func (h Argon2id) NeedsRehash(encodedHash string) bool {
+	hash, err := decodeArgon2id(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return hash.params != h
+}
+
+func verifyArgon2id(encodedHash, password string) error {
+	hash, err := decodeArgon2id(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	p := hash.params
+	key := argon2.IDKey([]byte(password), hash.salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(key, hash.key) != 1 {
+		return ErrMismatchedPassword
+	}
+
+	return nil
+}
+
+func decodeArgon2id(encodedHash string) (argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=4", salt, key.
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2idHash{}, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2idHash{}, ErrInvalidHash
+	}
+
+	var params Argon2id
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return argon2idHash{}, ErrInvalidHash
+	}
+
+	salt, err := encoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idHash{}, ErrInvalidHash
+	}
+
+	key, err := encoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2idHash{}, ErrInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return argon2idHash{
+		params: params,
+		salt:   salt,
+		key:    key,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package passhash
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// bcryptPrefixes are the prefixes of the bcrypt hashes in the modular crypt format.
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// Bcrypt is the hasher of the bcrypt algorithm. The cost is stored in the hash, like "$2a$10$<salt and key>".
+// Only the first 72 bytes of the password are hashed by bcrypt, so the longer passwords are rejected.
+type Bcrypt struct {
+	Cost int
+}
+
+// Validate checks the cost of the hasher is in the range of bcrypt.
+// Unlike bcrypt, the cost out of the range is not replaced by the default one, because the hashes
+// would never match the hasher and would be rehashed on every login.
+func (h Bcrypt) Validate() error {
+	if h.Cost < bcrypt.MinCost || h.Cost > bcrypt.MaxCost {
+		return fmt.Errorf("%w: bcrypt cost %d", ErrInvalidParams, h.Cost)
+	}
+
+	return nil
+}
+
+// Hash returns the encoded bcrypt hash of the password.
+func (h Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// Verify checks the password matches the encoded hash of any supported algorithm.
+func (h Bcrypt) Verify(encodedHash, password string) error {
+	return Verify(encodedHash, password)
+}
+
+// NeedsRehash reports whether the encoded hash is not the bcrypt hash with the cost of the hasher.
+func (h Bcrypt) NeedsRehash(encodedHash string) bool {
+	if !isBcrypt(encodedHash) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.Cost
+}
+
+func verifyBcrypt(encodedHash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return ErrMismatchedPassword
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidHash, err)
+}
+
+func isBcrypt(encodedHash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
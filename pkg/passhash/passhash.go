@@ -0,0 +1,40 @@
+// Package passhash implements the password hashing with the argon2id (RFC 9106) and bcrypt algorithms.
+// The hashes are encoded together with the algorithm and its parameters, so the password is verified against
+// the hash of any supported algorithm, and the hashes produced with other parameters are detected to be rehashed.
+package passhash
+
+import (
+	"errors"
+	"strings"
+)
+
+// The errors of the password hashing.
+var (
+	ErrMismatchedPassword = errors.New("passhash: password does not match the hash")
+	ErrUnknownAlgorithm   = errors.New("passhash: unknown hash algorithm")
+	ErrInvalidHash        = errors.New("passhash: invalid hash")
+	ErrInvalidParams      = errors.New("passhash: invalid hasher parameters")
+)
+
+// Hasher hashes the passwords with the algorithm and the parameters of the hasher.
+type Hasher interface {
+	// Hash returns the encoded hash of the password.
+	Hash(password string) (string, error)
+	// Verify checks the password matches the encoded hash of any supported algorithm.
+	Verify(encodedHash, password string) error
+	// NeedsRehash reports whether the encoded hash is produced by another algorithm or with other parameters.
+	NeedsRehash(encodedHash string) bool
+}
+
+// Verify checks the password matches the encoded hash of any supported algorithm.
+// ErrMismatchedPassword is returned if the password does not match.
+func Verify(encodedHash, password string) error {
+	switch {
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		return verifyArgon2id(encodedHash, password)
+	case isBcrypt(encodedHash):
+		return verifyBcrypt(encodedHash, password)
+	}
+
+	return ErrUnknownAlgorithm
+}
@@ -0,0 +1,105 @@
+package passhash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+)
+
+// testArgon2id is the argon2id hasher with the cheap parameters for tests.
+var testArgon2id = Argon2id{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHasher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		prefix string
+	}{
+		{
+			name:   "argon2id",
+			hasher: testArgon2id,
+			prefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		{
+			name:   "bcrypt",
+			hasher: Bcrypt{Cost: bcrypt.MinCost},
+			prefix: "$2a$04$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hash, err := tt.hasher.Hash("password")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash, tt.prefix))
+			assert.False(t, tt.hasher.NeedsRehash(hash))
+
+			assert.NoError(t, tt.hasher.Verify(hash, "password"))
+			assert.ErrorIs(t, tt.hasher.Verify(hash, "Password"), ErrMismatchedPassword)
+
+			// The salt is random.
+			otherHash, err := tt.hasher.Hash("password")
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, otherHash)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	argon2idHash, err := testArgon2id.Hash("password")
+	require.NoError(t, err)
+
+	bcryptHash, err := Bcrypt{Cost: bcrypt.MinCost}.Hash("password")
+	require.NoError(t, err)
+
+	// The other algorithm.
+	assert.True(t, testArgon2id.NeedsRehash(bcryptHash))
+	assert.True(t, Bcrypt{Cost: bcrypt.MinCost}.NeedsRehash(argon2idHash))
+
+	// The other parameters.
+	stronger := testArgon2id
+	stronger.Iterations = 2
+	assert.True(t, stronger.NeedsRehash(argon2idHash))
+	assert.True(t, Bcrypt{Cost: bcrypt.MinCost + 1}.NeedsRehash(bcryptHash))
+
+	// The hash of the other algorithm is verified.
+	assert.NoError(t, stronger.Verify(argon2idHash, "password"))
+	assert.NoError(t, stronger.Verify(bcryptHash, "password"))
+}
+
+func TestVerify_InvalidHash(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, Verify("", "password"), ErrUnknownAlgorithm)
+	assert.ErrorIs(t, Verify("plain-text", "password"), ErrUnknownAlgorithm)
+	assert.ErrorIs(t, Verify("$argon2id$v=19$m=1024,t=1,p=1$salt", "password"), ErrInvalidHash)
+	assert.ErrorIs(t, Verify("$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5", "password"), ErrInvalidHash)
+	assert.ErrorIs(t, Verify("$2a$10$invalid", "password"), ErrInvalidHash)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultArgon2id.Validate())
+	assert.NoError(t, testArgon2id.Validate())
+	assert.ErrorIs(t, Argon2id{Memory: 64, Iterations: 1, SaltLength: 16, KeyLength: 32}.Validate(), ErrInvalidParams)
+	assert.ErrorIs(t, Argon2id{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 4, KeyLength: 32}.Validate(), ErrInvalidParams)
+
+	assert.NoError(t, Bcrypt{Cost: bcrypt.DefaultCost}.Validate())
+	assert.ErrorIs(t, Bcrypt{Cost: bcrypt.MinCost - 1}.Validate(), ErrInvalidParams)
+	assert.ErrorIs(t, Bcrypt{Cost: bcrypt.MaxCost + 1}.Validate(), ErrInvalidParams)
+}
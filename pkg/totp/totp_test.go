@@ -0,0 +1,119 @@
+package totp
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 secret of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	t.Parallel()
+
+	// The RFC 6238 test vectors truncated to 6 digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := GenerateCode(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestGenerateCode_InvalidSecret(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateCode("not base32!", 1)
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1234567890, 0)
+	step := Step(now)
+
+	previousCode, err := GenerateCode(rfcSecret, step-1)
+	require.NoError(t, err)
+
+	staleCode, err := GenerateCode(rfcSecret, step-2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		code      string
+		wantStep  int64
+		wantValid bool
+	}{
+		{name: "current code", code: "005924", wantStep: step, wantValid: true},
+		{name: "code within skew", code: previousCode, wantStep: step - 1, wantValid: true},
+		{name: "code outside skew", code: staleCode},
+		{name: "wrong code", code: "000000"},
+		{name: "short code", code: "5924"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matchedStep, valid, err := Validate(rfcSecret, tt.code, now, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValid, valid)
+			assert.Equal(t, tt.wantStep, matchedStep)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	code, err := GenerateCode(secret, Step(time.Now()))
+	require.NoError(t, err)
+
+	_, valid, err := Validate(secret, code, time.Now(), 1)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	uri := URI("SECRET", "pxr-sso", "john doe")
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/pxr-sso:john doe", parsed.Path)
+	assert.Equal(t, "SECRET", parsed.Query().Get("secret"))
+	assert.Equal(t, "pxr-sso", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
@@ -0,0 +1,141 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"github.com/p1xray/pxr-sso/pkg/cbor"
+	"slices"
+)
+
+// The attestation statement formats.
+const (
+	FormatNone   = "none"
+	FormatPacked = "packed"
+)
+
+// aaguidExtensionOID is the OID of the attestation certificate extension with the AAGUID of the authenticator.
+var aaguidExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+// attestationObject is the attestation object of the registration ceremony.
+type attestationObject struct {
+	format   string
+	authData []byte
+	attStmt  map[any]any
+}
+
+func parseAttestationObject(data []byte) (attestationObject, error) {
+	value, err := cbor.Unmarshal(data)
+	if err != nil {
+		return attestationObject{}, ErrInvalidAttestation
+	}
+
+	m, ok := value.(map[any]any)
+	if !ok {
+		return attestationObject{}, ErrInvalidAttestation
+	}
+
+	format, _ := m["fmt"].(string)
+	authData, _ := m["authData"].([]byte)
+	attStmt, ok := m["attStmt"].(map[any]any)
+	if format == "" || len(authData) == 0 || !ok {
+		return attestationObject{}, ErrInvalidAttestation
+	}
+
+	return attestationObject{
+		format:   format,
+		authData: authData,
+		attStmt:  attStmt,
+	}, nil
+}
+
+// verify verifies the attestation statement of the credential. The trust of the attestation certificate
+// is not checked, the statement proves only the authenticator holds the private key of the credential.
+func (a attestationObject) verify(authData AuthenticatorData, credentialKey publicKey, clientDataHash []byte) error {
+	switch a.format {
+	case FormatNone:
+		if len(a.attStmt) != 0 {
+			return ErrInvalidAttestation
+		}
+
+		return nil
+	case FormatPacked:
+		return a.verifyPacked(authData, credentialKey, clientDataHash)
+	}
+
+	return ErrUnsupportedAttestation
+}
+
+// verifyPacked verifies the packed attestation statement (https://www.w3.org/TR/webauthn-2/#sctn-packed-attestation).
+// The statement is signed by the attestation certificate or by the credential itself (self attestation).
+func (a attestationObject) verifyPacked(authData AuthenticatorData, credentialKey publicKey, clientDataHash []byte) error {
+	alg, ok := a.attStmt["alg"].(int64)
+	if !ok {
+		return ErrInvalidAttestation
+	}
+
+	sig, ok := a.attStmt["sig"].([]byte)
+	if !ok {
+		return ErrInvalidAttestation
+	}
+
+	signedData := slices.Concat(a.authData, clientDataHash)
+
+	x5c, ok := a.attStmt["x5c"].([]any)
+	if !ok {
+		// Self attestation.
+		if alg != credentialKey.alg {
+			return ErrInvalidAttestation
+		}
+
+		return credentialKey.verify(signedData, sig)
+	}
+
+	if len(x5c) == 0 {
+		return ErrInvalidAttestation
+	}
+
+	certDER, ok := x5c[0].([]byte)
+	if !ok {
+		return ErrInvalidAttestationCert
+	}
+
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return ErrInvalidAttestationCert
+	}
+
+	if err = verifyAttestationCert(cert, authData.AAGUID); err != nil {
+		return err
+	}
+
+	return verifySignature(alg, cert.PublicKey, signedData, sig)
+}
+
+// verifyAttestationCert checks the requirements of the packed attestation certificate.
+func verifyAttestationCert(cert *x509.Certificate, aaguid []byte) error {
+	if cert.Version != 3 || (cert.BasicConstraintsValid && cert.IsCA) {
+		return ErrInvalidAttestationCert
+	}
+
+	for _, extension := range cert.Extensions {
+		if !extension.Id.Equal(aaguidExtensionOID) {
+			continue
+		}
+
+		if extension.Critical {
+			return ErrInvalidAttestationCert
+		}
+
+		var certAAGUID []byte
+		if _, err := asn1.Unmarshal(extension.Value, &certAAGUID); err != nil {
+			return ErrInvalidAttestationCert
+		}
+
+		if !bytes.Equal(certAAGUID, aaguid) {
+			return ErrInvalidAttestationCert
+		}
+	}
+
+	return nil
+}
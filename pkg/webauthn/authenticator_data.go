@@ -0,0 +1,100 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"github.com/p1xray/pxr-sso/pkg/cbor"
+)
+
+// The flags of the authenticator data.
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+const (
+	rpIDHashLength      = 32
+	aaguidLength        = 16
+	authDataFixedLength = rpIDHashLength + 1 + 4
+)
+
+// AuthenticatorData is the data the authenticator signs in both ceremonies.
+// The attested credential data is set only by the registration ceremony.
+type AuthenticatorData struct {
+	RPIDHash            []byte
+	Flags               byte
+	SignCount           uint32
+	AAGUID              []byte
+	CredentialID        []byte
+	CredentialPublicKey []byte
+}
+
+// ParseAuthenticatorData returns the authenticator data of its binary representation.
+func ParseAuthenticatorData(data []byte) (AuthenticatorData, error) {
+	if len(data) < authDataFixedLength {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	authData := AuthenticatorData{
+		RPIDHash:  data[:rpIDHashLength],
+		Flags:     data[rpIDHashLength],
+		SignCount: binary.BigEndian.Uint32(data[rpIDHashLength+1 : authDataFixedLength]),
+	}
+	rest := data[authDataFixedLength:]
+
+	if authData.HasAttestedCredentialData() {
+		if len(rest) < aaguidLength+2 {
+			return AuthenticatorData{}, ErrInvalidAuthenticatorData
+		}
+
+		authData.AAGUID = rest[:aaguidLength]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[aaguidLength : aaguidLength+2]))
+		rest = rest[aaguidLength+2:]
+
+		if credentialIDLength == 0 || len(rest) < credentialIDLength {
+			return AuthenticatorData{}, ErrInvalidAuthenticatorData
+		}
+
+		authData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		_, n, err := cbor.Decode(rest)
+		if err != nil {
+			return AuthenticatorData{}, ErrInvalidAuthenticatorData
+		}
+
+		authData.CredentialPublicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if authData.Flags&FlagExtensionData != 0 {
+		_, n, err := cbor.Decode(rest)
+		if err != nil {
+			return AuthenticatorData{}, ErrInvalidAuthenticatorData
+		}
+
+		rest = rest[n:]
+	}
+
+	if len(rest) != 0 {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	return authData, nil
+}
+
+// UserPresent reports whether the user has interacted with the authenticator.
+func (d AuthenticatorData) UserPresent() bool {
+	return d.Flags&FlagUserPresent != 0
+}
+
+// UserVerified reports whether the authenticator has verified the user by the PIN or the biometrics.
+func (d AuthenticatorData) UserVerified() bool {
+	return d.Flags&FlagUserVerified != 0
+}
+
+// HasAttestedCredentialData reports whether the data includes the created credential.
+func (d AuthenticatorData) HasAttestedCredentialData() bool {
+	return d.Flags&FlagAttestedCredentialData != 0
+}
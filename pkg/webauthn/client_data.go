@@ -0,0 +1,33 @@
+package webauthn
+
+import (
+	"encoding/json"
+)
+
+const (
+	ceremonyCreate = "webauthn.create"
+	ceremonyGet    = "webauthn.get"
+)
+
+// ClientData is the client data collected by the browser for the ceremony.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// ParseClientData returns the client data of the JSON serialization passed by the browser.
+// The relying party uses the challenge of the client data to find the ceremony the response belongs to.
+func ParseClientData(clientDataJSON []byte) (ClientData, error) {
+	var clientData ClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return ClientData{}, ErrInvalidClientData
+	}
+
+	if clientData.Type == "" || clientData.Challenge == "" || clientData.Origin == "" {
+		return ClientData{}, ErrInvalidClientData
+	}
+
+	return clientData, nil
+}
@@ -0,0 +1,154 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"github.com/p1xray/pxr-sso/pkg/cbor"
+	"math/big"
+)
+
+// The COSE algorithms of the credential public keys (https://www.iana.org/assignments/cose/cose.xhtml).
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// The COSE key parameters.
+const (
+	coseKeyType   int64 = 1
+	coseAlgorithm int64 = 3
+	coseCurve     int64 = -1
+	coseX         int64 = -2
+	coseY         int64 = -3
+	coseN         int64 = -1
+	coseE         int64 = -2
+
+	coseKeyTypeOKP int64 = 1
+	coseKeyTypeEC2 int64 = 2
+	coseKeyTypeRSA int64 = 3
+
+	coseCurveP256    int64 = 1
+	coseCurveEd25519 int64 = 6
+)
+
+// publicKey is the credential public key of the COSE algorithm.
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parsePublicKey returns the public key of the COSE key.
+func parsePublicKey(coseKey []byte) (publicKey, error) {
+	value, err := cbor.Unmarshal(coseKey)
+	if err != nil {
+		return publicKey{}, ErrInvalidPublicKey
+	}
+
+	m, ok := value.(map[any]any)
+	if !ok {
+		return publicKey{}, ErrInvalidPublicKey
+	}
+
+	keyType, _ := m[coseKeyType].(int64)
+	alg, _ := m[coseAlgorithm].(int64)
+
+	switch {
+	case alg == AlgES256 && keyType == coseKeyTypeEC2:
+		curve, _ := m[coseCurve].(int64)
+		x, _ := m[coseX].([]byte)
+		y, _ := m[coseY].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return publicKey{}, ErrInvalidPublicKey
+		}
+
+		// The point must be on the curve.
+		if _, err = ecdh.P256().NewPublicKey(append(append([]byte{0x04}, x...), y...)); err != nil {
+			return publicKey{}, ErrInvalidPublicKey
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		return publicKey{alg: alg, key: key}, nil
+	case alg == AlgEdDSA && keyType == coseKeyTypeOKP:
+		curve, _ := m[coseCurve].(int64)
+		x, _ := m[coseX].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, ErrInvalidPublicKey
+		}
+
+		return publicKey{alg: alg, key: ed25519.PublicKey(x)}, nil
+	case alg == AlgRS256 && keyType == coseKeyTypeRSA:
+		n, _ := m[coseN].([]byte)
+		e, _ := m[coseE].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return publicKey{}, ErrInvalidPublicKey
+		}
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+
+		return publicKey{alg: alg, key: key}, nil
+	}
+
+	return publicKey{}, ErrUnsupportedAlgorithm
+}
+
+// verify verifies the signature of the message.
+func (k publicKey) verify(message, signature []byte) error {
+	return verifySignature(k.alg, k.key, message, signature)
+}
+
+// verifySignature verifies the signature of the message by the public key of the COSE algorithm.
+func verifySignature(alg int64, key crypto.PublicKey, message, signature []byte) error {
+	switch alg {
+	case AlgES256:
+		ecdsaKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(ecdsaKey, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	case AlgEdDSA:
+		ed25519Key, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+
+		if !ed25519.Verify(ed25519Key, message, signature) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	case AlgRS256:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+
+		digest := sha256.Sum256(message)
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	}
+
+	return ErrUnsupportedAlgorithm
+}
@@ -0,0 +1,198 @@
+// Package webauthn implements the relying party side of the WebAuthn registration and authentication
+// ceremonies (https://www.w3.org/TR/webauthn-2/). The attestation statements of the "none" and "packed" formats
+// are verified, the credential public keys are COSE keys of the ES256, EdDSA and RS256 algorithms.
+package webauthn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"slices"
+)
+
+const (
+	// challengeLength is the number of random bytes of the challenge.
+	challengeLength = 32
+)
+
+// The errors of the ceremonies verification.
+var (
+	ErrInvalidClientData        = errors.New("webauthn: invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("webauthn: invalid authenticator data")
+	ErrInvalidAttestation       = errors.New("webauthn: invalid attestation")
+	ErrUnsupportedAttestation   = errors.New("webauthn: unsupported attestation format")
+	ErrInvalidPublicKey         = errors.New("webauthn: invalid credential public key")
+	ErrUnsupportedAlgorithm     = errors.New("webauthn: unsupported algorithm")
+	ErrInvalidSignature         = errors.New("webauthn: invalid signature")
+	ErrCeremonyMismatch         = errors.New("webauthn: ceremony type mismatch")
+	ErrChallengeMismatch        = errors.New("webauthn: challenge mismatch")
+	ErrOriginMismatch           = errors.New("webauthn: origin mismatch")
+	ErrRelyingPartyMismatch     = errors.New("webauthn: relying party ID mismatch")
+	ErrUserNotPresent           = errors.New("webauthn: user is not present")
+	ErrUserNotVerified          = errors.New("webauthn: user is not verified")
+	ErrMissingCredentialData    = errors.New("webauthn: attested credential data is missing")
+	ErrUnexpectedCredentialData = errors.New("webauthn: unexpected attested credential data")
+	ErrInvalidAttestationCert   = errors.New("webauthn: invalid attestation certificate")
+)
+
+// encoding is the base64url encoding without padding used by WebAuthn for the binary values in JSON.
+var encoding = base64.RawURLEncoding
+
+// RelyingParty is the relying party which verifies the ceremonies.
+// The origins are the web origins the ceremonies may be performed on, like "https://login.example.com".
+// If UserVerification is set, the authenticator must verify the user by the PIN or the biometrics,
+// which is required for the passwordless login.
+type RelyingParty struct {
+	ID               string
+	Origins          []string
+	UserVerification bool
+}
+
+// Credential is the public key credential created by the registration ceremony.
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	SignCount uint32
+	AAGUID    []byte
+	Format    string
+}
+
+// NewChallenge returns a new random challenge encoded with base64url.
+func NewChallenge() (string, error) {
+	challenge := make([]byte, challengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(challenge), nil
+}
+
+// EncodeID returns the base64url encoding of the binary value, like the credential ID or the user handle.
+func EncodeID(id []byte) string {
+	return encoding.EncodeToString(id)
+}
+
+// DecodeID returns the binary value of the base64url encoding.
+func DecodeID(id string) ([]byte, error) {
+	return encoding.DecodeString(id)
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create() to the challenge,
+// and if successful, returns the created credential.
+func (rp RelyingParty) VerifyRegistration(clientDataJSON, attestationObject []byte, challenge string) (Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, ceremonyCreate, challenge); err != nil {
+		return Credential{}, err
+	}
+
+	attestation, err := parseAttestationObject(attestationObject)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	authData, err := ParseAuthenticatorData(attestation.authData)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	if err = rp.verifyAuthenticatorData(authData); err != nil {
+		return Credential{}, err
+	}
+
+	if !authData.HasAttestedCredentialData() {
+		return Credential{}, ErrMissingCredentialData
+	}
+
+	publicKey, err := parsePublicKey(authData.CredentialPublicKey)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if err = attestation.verify(authData, publicKey, clientDataHash[:]); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:        authData.CredentialID,
+		PublicKey: authData.CredentialPublicKey,
+		SignCount: authData.SignCount,
+		AAGUID:    authData.AAGUID,
+		Format:    attestation.format,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get() to the challenge signed by the credential
+// with the COSE public key, and if successful, returns the signature counter of the authenticator.
+func (rp RelyingParty) VerifyAssertion(
+	credentialPublicKey, clientDataJSON, authenticatorData, signature []byte,
+	challenge string,
+) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, ceremonyGet, challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := ParseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = rp.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	if authData.HasAttestedCredentialData() {
+		return 0, ErrUnexpectedCredentialData
+	}
+
+	publicKey, err := parsePublicKey(credentialPublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if err = publicKey.verify(slices.Concat(authenticatorData, clientDataHash[:]), signature); err != nil {
+		return 0, err
+	}
+
+	return authData.SignCount, nil
+}
+
+func (rp RelyingParty) verifyClientData(clientDataJSON []byte, ceremony, challenge string) error {
+	clientData, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+
+	if clientData.Type != ceremony {
+		return ErrCeremonyMismatch
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return ErrChallengeMismatch
+	}
+
+	if !slices.Contains(rp.Origins, clientData.Origin) {
+		return ErrOriginMismatch
+	}
+
+	return nil
+}
+
+func (rp RelyingParty) verifyAuthenticatorData(authData AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData.RPIDHash, rpIDHash[:]) != 1 {
+		return ErrRelyingPartyMismatch
+	}
+
+	if !authData.UserPresent() {
+		return ErrUserNotPresent
+	}
+
+	if rp.UserVerification && !authData.UserVerified() {
+		return ErrUserNotVerified
+	}
+
+	return nil
+}
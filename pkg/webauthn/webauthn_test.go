@@ -0,0 +1,171 @@
+package webauthn
+
+import (
+	"github.com/p1xray/pxr-sso/pkg/webauthn/webauthntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://login.example.com"
+)
+
+var testRelyingParty = RelyingParty{
+	ID:               testRPID,
+	Origins:          []string{testOrigin},
+	UserVerification: true,
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configure  func(t *testing.T, a *webauthntest.Authenticator)
+		challenge  string
+		wantFormat string
+		wantErr    error
+	}{
+		{
+			name:       "none attestation",
+			wantFormat: FormatNone,
+		},
+		{
+			name: "packed self attestation",
+			configure: func(_ *testing.T, a *webauthntest.Authenticator) {
+				a.WithSelfAttestation()
+			},
+			wantFormat: FormatPacked,
+		},
+		{
+			name: "packed attestation with certificate",
+			configure: func(t *testing.T, a *webauthntest.Authenticator) {
+				_, err := a.WithAttestationCertificate()
+				require.NoError(t, err)
+			},
+			wantFormat: FormatPacked,
+		},
+		{
+			name:      "challenge mismatch",
+			challenge: "other-challenge",
+			wantErr:   ErrChallengeMismatch,
+		},
+		{
+			name: "origin mismatch",
+			configure: func(_ *testing.T, a *webauthntest.Authenticator) {
+				a.Origin = "https://evil.example.com"
+			},
+			wantErr: ErrOriginMismatch,
+		},
+		{
+			name: "relying party ID mismatch",
+			configure: func(_ *testing.T, a *webauthntest.Authenticator) {
+				a.RPID = "evil.example.com"
+			},
+			wantErr: ErrRelyingPartyMismatch,
+		},
+		{
+			name: "user is not verified",
+			configure: func(_ *testing.T, a *webauthntest.Authenticator) {
+				a.UserVerified = false
+			},
+			wantErr: ErrUserNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authenticator, err := webauthntest.New(testRPID, testOrigin)
+			require.NoError(t, err)
+			if tt.configure != nil {
+				tt.configure(t, authenticator)
+			}
+
+			challenge, err := NewChallenge()
+			require.NoError(t, err)
+
+			clientDataJSON, attestationObject, err := authenticator.Create(challenge)
+			require.NoError(t, err)
+
+			if tt.challenge != "" {
+				challenge = tt.challenge
+			}
+
+			credential, err := testRelyingParty.VerifyRegistration(clientDataJSON, attestationObject, challenge)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, authenticator.CredentialID, credential.ID)
+			assert.Equal(t, authenticator.AAGUID, credential.AAGUID)
+			assert.Equal(t, tt.wantFormat, credential.Format)
+			assert.NotEmpty(t, credential.PublicKey)
+		})
+	}
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := webauthntest.New(testRPID, testOrigin)
+	require.NoError(t, err)
+
+	registrationChallenge, err := NewChallenge()
+	require.NoError(t, err)
+
+	clientDataJSON, attestationObject, err := authenticator.Create(registrationChallenge)
+	require.NoError(t, err)
+
+	credential, err := testRelyingParty.VerifyRegistration(clientDataJSON, attestationObject, registrationChallenge)
+	require.NoError(t, err)
+
+	challenge, err := NewChallenge()
+	require.NoError(t, err)
+
+	// Valid assertion.
+	clientDataJSON, authenticatorData, signature, err := authenticator.Get(challenge)
+	require.NoError(t, err)
+
+	signCount, err := testRelyingParty.VerifyAssertion(credential.PublicKey, clientDataJSON, authenticatorData, signature, challenge)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), signCount)
+
+	// Registration response is not an assertion.
+	_, err = testRelyingParty.VerifyAssertion(credential.PublicKey, clientDataJSON, authenticatorData, signature, registrationChallenge)
+	assert.ErrorIs(t, err, ErrChallengeMismatch)
+
+	// Tampered signature.
+	tampered := append([]byte{}, signature...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = testRelyingParty.VerifyAssertion(credential.PublicKey, clientDataJSON, authenticatorData, tampered, challenge)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// Signature of the other credential.
+	other, err := webauthntest.New(testRPID, testOrigin)
+	require.NoError(t, err)
+
+	clientDataJSON, authenticatorData, signature, err = other.Get(challenge)
+	require.NoError(t, err)
+
+	_, err = testRelyingParty.VerifyAssertion(credential.PublicKey, clientDataJSON, authenticatorData, signature, challenge)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseAuthenticatorData_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseAuthenticatorData(make([]byte, 10))
+	assert.ErrorIs(t, err, ErrInvalidAuthenticatorData)
+
+	// The attested credential data flag is set, but the data is missing.
+	data := make([]byte, authDataFixedLength)
+	data[rpIDHashLength] = FlagUserPresent | FlagAttestedCredentialData
+	_, err = ParseAuthenticatorData(data)
+	assert.ErrorIs(t, err, ErrInvalidAuthenticatorData)
+}
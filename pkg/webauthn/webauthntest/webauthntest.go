@@ -0,0 +1,240 @@
+// Package webauthntest provides the software authenticator which performs the WebAuthn ceremonies in-process.
+// It is used by the tests of the relying party.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/p1xray/pxr-sso/pkg/cbor"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	credentialIDLength = 16
+
+	flagUserPresent            byte = 0x01
+	flagUserVerified           byte = 0x04
+	flagAttestedCredentialData byte = 0x40
+
+	algES256 = -7
+)
+
+// aaguidExtensionOID is the OID of the attestation certificate extension with the AAGUID of the authenticator.
+var aaguidExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+// Authenticator is the software authenticator with the single ES256 credential.
+// The attestation statement format is "none" unless the attestation is set by WithSelfAttestation
+// or WithAttestationCertificate.
+type Authenticator struct {
+	RPID         string
+	Origin       string
+	CredentialID []byte
+	AAGUID       []byte
+	SignCount    uint32
+	UserVerified bool
+
+	format          string
+	key             *ecdsa.PrivateKey
+	attestationKey  *ecdsa.PrivateKey
+	attestationCert []byte
+}
+
+// New returns a new authenticator with the generated credential for the relying party.
+// The ceremonies are performed on the origin with the user verification.
+func New(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID := make([]byte, credentialIDLength)
+	if _, err = rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+
+	aaguid := make([]byte, 16)
+	if _, err = rand.Read(aaguid); err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		CredentialID: credentialID,
+		AAGUID:       aaguid,
+		UserVerified: true,
+		format:       "none",
+		key:          key,
+	}, nil
+}
+
+// WithSelfAttestation makes the authenticator sign the packed attestation statement by the credential itself.
+func (a *Authenticator) WithSelfAttestation() *Authenticator {
+	a.format = "packed"
+	a.attestationKey = nil
+	a.attestationCert = nil
+
+	return a
+}
+
+// WithAttestationCertificate makes the authenticator sign the packed attestation statement by the generated
+// attestation key, the self-signed attestation certificate is sent in the statement.
+func (a *Authenticator) WithAttestationCertificate() (*Authenticator, error) {
+	attestationKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	aaguidValue, err := asn1.Marshal(a.AAGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			Country:            []string{"US"},
+			Organization:       []string{"Software Authenticator"},
+			OrganizationalUnit: []string{"Authenticator Attestation"},
+			CommonName:         "webauthntest",
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  false,
+		ExtraExtensions: []pkix.Extension{
+			{Id: aaguidExtensionOID, Value: aaguidValue},
+		},
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &attestationKey.PublicKey, attestationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	a.format = "packed"
+	a.attestationKey = attestationKey
+	a.attestationCert = cert
+
+	return a, nil
+}
+
+// Create performs the registration ceremony of the challenge, the response of navigator.credentials.create()
+// is returned.
+func (a *Authenticator) Create(challenge string) (clientDataJSON, attestationObject []byte, err error) {
+	clientDataJSON, err = a.clientData("webauthn.create", challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1:  2,
+		3:  algES256,
+		-1: 1,
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credentialIDLength := binary.BigEndian.AppendUint16(nil, uint16(len(a.CredentialID)))
+	authData := slices.Concat(
+		a.authData(flagAttestedCredentialData),
+		a.AAGUID,
+		credentialIDLength,
+		a.CredentialID,
+		publicKey,
+	)
+
+	attStmt := map[string]any{}
+	if a.format == "packed" {
+		clientDataHash := sha256.Sum256(clientDataJSON)
+		signingKey := a.key
+		if a.attestationKey != nil {
+			signingKey = a.attestationKey
+		}
+
+		sig, err := sign(signingKey, slices.Concat(authData, clientDataHash[:]))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		attStmt["alg"] = algES256
+		attStmt["sig"] = sig
+		if a.attestationCert != nil {
+			attStmt["x5c"] = []any{a.attestationCert}
+		}
+	}
+
+	attestationObject, err = cbor.Marshal(map[string]any{
+		"fmt":      a.format,
+		"authData": authData,
+		"attStmt":  attStmt,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientDataJSON, attestationObject, nil
+}
+
+// Get performs the authentication ceremony of the challenge, the response of navigator.credentials.get()
+// is returned. The signature counter is incremented.
+func (a *Authenticator) Get(challenge string) (clientDataJSON, authenticatorData, signature []byte, err error) {
+	clientDataJSON, err = a.clientData("webauthn.get", challenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	a.SignCount++
+	authenticatorData = a.authData(0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signature, err = sign(a.key, slices.Concat(authenticatorData, clientDataHash[:]))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return clientDataJSON, authenticatorData, signature, nil
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    a.Origin,
+	})
+}
+
+func (a *Authenticator) authData(flags byte) []byte {
+	flags |= flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+
+	return slices.Concat(rpIDHash[:], []byte{flags}, binary.BigEndian.AppendUint32(nil, a.SignCount))
+}
+
+// CredentialIDString returns the base64url encoding of the credential ID.
+func (a *Authenticator) CredentialIDString() string {
+	return base64.RawURLEncoding.EncodeToString(a.CredentialID)
+}
+
+func sign(key *ecdsa.PrivateKey, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+
+	return ecdsa.SignASN1(rand.Reader, key, digest[:])
+}